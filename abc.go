@@ -0,0 +1,143 @@
+package gago
+
+import (
+	"errors"
+	"sort"
+)
+
+const trialsKey = "trials"
+
+// ModABC implements the artificial bee colony algorithm, where each individual
+// is a food source exploited by a bee. A bee looks for a better source in the
+// neighbourhood of it's own: a random gene is moved toward or away from the
+// same gene of another random source, by a uniform factor in [-1, 1] for a
+// float64 gene, whereas any other gene is copied from the other source. The new
+// source is evaluated and replaces the old one if it's better, otherwise the
+// number of unsuccessful trials of the old source is increased. At each
+// generation every source is first exploited by an employed bee, then
+// NbrOnlookers onlooker bees, as many as there are individuals if NbrOnlookers
+// is 0, exploit sources chosen with a probability proportional to their rank
+// in the population. Finally scout bees abandon the sources that have been
+// exploited unsuccessfully more than Limit times, the number of individuals
+// times the number of genes if Limit is 0, and replace them with random
+// sources produced by Initializer.
+//
+// The number of trials of a source is stored in the individual's metadata and
+// can be retrieved with TrialsOf.
+type ModABC struct {
+	Limit        int
+	NbrOnlookers int
+	Initializer  Initializer
+}
+
+// TrialsOf returns the number of times the food source of an individual was
+// unsuccessfully exploited by ModABC.
+func TrialsOf(indi Individual) int {
+	var trials, _ = indi.Meta(trialsKey).(int)
+	return trials
+}
+
+// Exploit the i-th source of a population.
+func (mod ModABC) exploit(pop *Population, i int) {
+	var n = len(pop.Individuals)
+	if n < 2 {
+		return
+	}
+	var (
+		source  = pop.Individuals[i]
+		k       = (i + 1 + pop.rng.Intn(n-1)) % n
+		partner = pop.Individuals[k].Genome
+		j       = pop.rng.Intn(len(source.Genome))
+	)
+	if j >= len(partner) {
+		return
+	}
+	var candidate = source.clone(pop.rng)
+	candidate.Evaluated = false
+	if x, ok := source.Genome[j].(float64); ok {
+		if y, ok := partner[j].(float64); ok {
+			candidate.Genome[j] = x + (2*pop.rng.Float64()-1)*(x-y)
+		}
+	} else {
+		candidate.Genome[j] = partner[j]
+	}
+	candidate.Evaluate(pop.ff)
+	if less(pop.cmp, candidate, source) {
+		candidate.SetMeta(trialsKey, 0)
+		pop.Individuals[i] = candidate
+		return
+	}
+	pop.Individuals[i].SetMeta(trialsKey, TrialsOf(source)+1)
+}
+
+// Apply the artificial bee colony algorithm to a population.
+func (mod ModABC) Apply(pop *Population) {
+	var (
+		n           = len(pop.Individuals)
+		nbGenes     = len(pop.Individuals[0].Genome)
+		limit       = mod.Limit
+		nbOnlookers = mod.NbrOnlookers
+	)
+	if limit == 0 {
+		limit = n * nbGenes
+	}
+	if nbOnlookers == 0 {
+		nbOnlookers = n
+	}
+	// Employed bees
+	for i := range pop.Individuals {
+		mod.exploit(pop, i)
+	}
+	// Onlooker bees, the best source has weight n and the worst one weight 1
+	var (
+		order   = make([]int, n)
+		indis   = make(Individuals, n)
+		weights = make([]float64, n)
+	)
+	for i := range order {
+		order[i] = i
+	}
+	copy(indis, pop.Individuals)
+	sort.Stable(byComparator{indis, order, pop.cmp})
+	for r, i := range order {
+		weights[i] = float64(n - r)
+	}
+	var total = float64(n*(n+1)) / 2
+	for b := 0; b < nbOnlookers; b++ {
+		var (
+			x = pop.rng.Float64() * total
+			i = 0
+		)
+		for ; i < n-1 && x >= weights[i]; i++ {
+			x -= weights[i]
+		}
+		mod.exploit(pop, i)
+	}
+	// Scout bees
+	for i, indi := range pop.Individuals {
+		if TrialsOf(indi) <= limit {
+			continue
+		}
+		var source = makeIndividual(nbGenes, pop.rng)
+		mod.Initializer.Apply(&source, pop.rng)
+		source.Evaluate(pop.ff)
+		pop.Individuals[i] = source
+	}
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModABC) Validate() error {
+	// Check the abandonment limit
+	if mod.Limit < 0 {
+		return errors.New("'Limit' should be higher or equal to 0")
+	}
+	// Check the number of onlookers
+	if mod.NbrOnlookers < 0 {
+		return errors.New("'NbrOnlookers' should be higher or equal to 0")
+	}
+	// Check the initializer presence
+	if mod.Initializer == nil {
+		return errors.New("'Initializer' cannot be nil")
+	}
+	return nil
+}
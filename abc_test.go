@@ -0,0 +1,67 @@
+package gago
+
+import (
+	"testing"
+)
+
+func TestModABC(t *testing.T) {
+	var (
+		pop = makePopulation(10, 3, ff, initializer)
+		mod = ModABC{Limit: 1000, Initializer: initializer}
+	)
+	pop.Individuals.Evaluate(pop.ff)
+	pop.Individuals.Sort()
+	var before = pop.Individuals[0].Fitness
+	for i := 0; i < 5; i++ {
+		mod.Apply(&pop)
+	}
+	if len(pop.Individuals) != 10 {
+		t.Error("The size of the population was modified")
+	}
+	pop.Individuals.Sort()
+	if pop.Individuals[0].Fitness > before {
+		t.Error("The best source was lost")
+	}
+	var trials int
+	for _, indi := range pop.Individuals {
+		trials += TrialsOf(indi)
+	}
+	if trials == 0 {
+		t.Error("No trial was counted")
+	}
+}
+
+func TestModABCScouts(t *testing.T) {
+	var (
+		pop = makePopulation(4, 2, ff, initializer)
+		mod = ModABC{Limit: 1, Initializer: initializer}
+	)
+	pop.Individuals.Evaluate(pop.ff)
+	for i := range pop.Individuals {
+		pop.Individuals[i].SetMeta(trialsKey, 5)
+	}
+	mod.Apply(&pop)
+	for _, indi := range pop.Individuals {
+		if TrialsOf(indi) > 1 {
+			t.Error("An exhausted source wasn't abandoned")
+		}
+		if !indi.Evaluated {
+			t.Error("A new source wasn't evaluated")
+		}
+	}
+}
+
+func TestModABCValidate(t *testing.T) {
+	var mod = ModABC{Initializer: initializer}
+	if mod.Validate() != nil {
+		t.Error("Should not have returned an error")
+	}
+	mod.Limit = -1
+	if mod.Validate() == nil {
+		t.Error("Should have returned an error")
+	}
+	mod = ModABC{}
+	if mod.Validate() == nil {
+		t.Error("Should have returned an error")
+	}
+}
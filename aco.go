@@ -0,0 +1,254 @@
+package gago
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// A colony holds the pheromone trails of a population for ModACO. The genes
+// are numbered by their position in the genome of the first individual the
+// colony saw, the trail between two genes is the desirability of placing the
+// second gene right after the first one.
+type colony struct {
+	genes      []interface{}
+	index      map[interface{}]int
+	pheromones [][]float64
+	heuristics [][]float64 // Heuristic desirability of each transition raised to the power Beta
+}
+
+// Create the colony of a set of genes, every trail starts at 1.
+func newColony(genes Genome, heuristic func(a, b interface{}) float64, beta float64) *colony {
+	var c = &colony{
+		genes:      append([]interface{}{}, genes...),
+		index:      make(map[interface{}]int, len(genes)),
+		pheromones: make([][]float64, len(genes)),
+		heuristics: make([][]float64, len(genes)),
+	}
+	for i, gene := range genes {
+		c.index[gene] = i
+		c.pheromones[i] = make([]float64, len(genes))
+		c.heuristics[i] = make([]float64, len(genes))
+		for j := range genes {
+			c.pheromones[i][j] = 1
+			c.heuristics[i][j] = 1
+			if heuristic != nil && i != j {
+				c.heuristics[i][j] = math.Pow(heuristic(genes[i], genes[j]), beta)
+			}
+		}
+	}
+	return c
+}
+
+// Evaporate the trails and let the best ants deposit pheromone on the
+// transitions of their genomes, the r-th best ant deposits 1/(r+1). If cycle is
+// true the transition from the last gene to the first one is included, if
+// symmetric is true the reverse transitions get the same deposit.
+func (c *colony) update(best Individuals, rho, minPheromone float64, cycle, symmetric bool) {
+	for i := range c.pheromones {
+		for j := range c.pheromones[i] {
+			c.pheromones[i][j] *= 1 - rho
+		}
+	}
+	var deposit = func(a, b interface{}, amount float64) {
+		var i, okA = c.index[a]
+		var j, okB = c.index[b]
+		if !okA || !okB {
+			return
+		}
+		c.pheromones[i][j] += amount
+		if symmetric {
+			c.pheromones[j][i] += amount
+		}
+	}
+	for r, ant := range best {
+		var amount = 1 / float64(r+1)
+		for k := 1; k < len(ant.Genome); k++ {
+			deposit(ant.Genome[k-1], ant.Genome[k], amount)
+		}
+		if cycle && len(ant.Genome) > 1 {
+			deposit(ant.Genome[len(ant.Genome)-1], ant.Genome[0], amount)
+		}
+	}
+	for i := range c.pheromones {
+		for j := range c.pheromones[i] {
+			c.pheromones[i][j] = math.Max(c.pheromones[i][j], minPheromone)
+		}
+	}
+}
+
+// Build a genome by starting from a random gene and choosing each next gene
+// among the remaining ones with a probability proportional to
+// pheromone^alpha * heuristic^beta.
+func (c *colony) construct(alpha float64, rng *rand.Rand) Genome {
+	var (
+		n       = len(c.genes)
+		genome  = make(Genome, n)
+		visited = make([]bool, n)
+		weights = make([]float64, n)
+		current = rng.Intn(n)
+	)
+	genome[0] = c.genes[current]
+	visited[current] = true
+	for k := 1; k < n; k++ {
+		var total float64
+		for j := range weights {
+			weights[j] = 0
+			if !visited[j] {
+				weights[j] = math.Pow(c.pheromones[current][j], alpha) * c.heuristics[current][j]
+				total += weights[j]
+			}
+		}
+		var next = -1
+		if total > 0 && !math.IsInf(total, 1) {
+			var r = rng.Float64() * total
+			for j, w := range weights {
+				if w > 0 {
+					next = j
+					if r < w {
+						break
+					}
+					r -= w
+				}
+			}
+		}
+		// Fall back to a uniform choice if the weights are degenerate
+		if next < 0 {
+			var remaining []int
+			for j := range visited {
+				if !visited[j] {
+					remaining = append(remaining, j)
+				}
+			}
+			next = remaining[rng.Intn(len(remaining))]
+		}
+		genome[k] = c.genes[next]
+		visited[next] = true
+		current = next
+	}
+	return genome
+}
+
+// ModACO implements ant colony optimization for permutation genomes, such as
+// the ones of InitPermutationI or InitUniqueS, which gives a baseline to
+// compare the GA against on ordering problems. Each population is a colony of
+// ants that lay pheromone on the transitions between consecutive genes. At
+// each generation the trails evaporate at rate Rho, 0.1 if Rho is 0, and the
+// NbrBest best individuals, the best one if NbrBest is 0, deposit pheromone on
+// the transitions of their genomes, the r-th best one depositing 1/r. The
+// trails are kept above MinPheromone so that every transition stays possible.
+// The individuals are then replaced by new ants, except for the Elitism best
+// ones. An ant starts from a random gene and picks each next gene among the
+// remaining ones with a probability proportional to pheromone^Alpha *
+// heuristic^Beta, Alpha being 1 if it's 0 and Beta 2 if it's 0. Heuristic
+// returns the desirability of placing gene b right after gene a, for example
+// the inverse of the distance between two cities, the transitions are equally
+// desirable if Heuristic is nil.
+//
+// If Cycle is true the genome is a cycle, as in the travelling salesman
+// problem, hence the transition from the last gene to the first one gets
+// pheromone too. If Symmetric is true a transition and it's reverse share
+// their pheromone. The ants can be improved by a local search, such as
+// LocalTwoOpt, by wrapping ModACO in a ModMemetic.
+//
+// The genes should be comparable, they are identified by their value. ModACO
+// keeps the pheromone trails of each population, which are reset when the
+// population is created again, for example when the GA is initialized, hence
+// it has to be used through a pointer.
+type ModACO struct {
+	Alpha        float64
+	Beta         float64
+	Rho          float64
+	NbrBest      int
+	Elitism      int
+	MinPheromone float64
+	Heuristic    func(a, b interface{}) float64
+	Cycle        bool
+	Symmetric    bool
+	mu           sync.Mutex
+	colonies     map[*rand.Rand]*colony // Colony of each population, identified by it's generator
+}
+
+// Apply ACO to a population.
+func (mod *ModACO) Apply(pop *Population) {
+	var alpha, beta, rho = mod.Alpha, mod.Beta, mod.Rho
+	if alpha == 0 {
+		alpha = 1
+	}
+	if beta == 0 {
+		beta = 2
+	}
+	if rho == 0 {
+		rho = 0.1
+	}
+	var sorted = sortedIndividuals(pop)
+	mod.mu.Lock()
+	if mod.colonies == nil {
+		mod.colonies = make(map[*rand.Rand]*colony)
+	}
+	var c, ok = mod.colonies[pop.rng]
+	if !ok || len(c.genes) != len(sorted[0].Genome) {
+		c = newColony(sorted[0].Genome, mod.Heuristic, beta)
+		mod.colonies[pop.rng] = c
+	}
+	mod.mu.Unlock()
+	// The colony only belongs to the population, it can be updated without
+	// holding the lock
+	c.update(sorted[:min(max(mod.NbrBest, 1), len(sorted))], rho, mod.MinPheromone, mod.Cycle, mod.Symmetric)
+	var (
+		indis   = make(Individuals, len(sorted))
+		elitism = min(mod.Elitism, len(sorted))
+	)
+	copy(indis, sorted[:elitism])
+	for i := elitism; i < len(indis); i++ {
+		indis[i] = makeIndividual(0, pop.rng)
+		indis[i].Genome = c.construct(alpha, pop.rng)
+	}
+	pop.Individuals = indis
+}
+
+// Pheromones returns a copy of the pheromone trails of a population along with
+// the genes they refer to, the trail from genes[i] to genes[j] being
+// pheromones[i][j]. Nil is returned if the population doesn't have a colony.
+func (mod *ModACO) Pheromones(pop Population) ([]interface{}, [][]float64) {
+	mod.mu.Lock()
+	defer mod.mu.Unlock()
+	var c, ok = mod.colonies[pop.rng]
+	if !ok {
+		return nil, nil
+	}
+	var pheromones = make([][]float64, len(c.pheromones))
+	for i, row := range c.pheromones {
+		pheromones[i] = append([]float64{}, row...)
+	}
+	return append([]interface{}{}, c.genes...), pheromones
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod *ModACO) Validate() error {
+	// Check the exponents
+	if mod.Alpha < 0 {
+		return errors.New("'Alpha' should be higher or equal to 0")
+	}
+	if mod.Beta < 0 {
+		return errors.New("'Beta' should be higher or equal to 0")
+	}
+	// Check the evaporation rate
+	if mod.Rho < 0 || mod.Rho > 1 {
+		return errors.New("'Rho' should belong to the [0, 1] interval")
+	}
+	// Check the number of depositing ants
+	if mod.NbrBest < 0 {
+		return errors.New("'NbrBest' should be higher or equal to 0")
+	}
+	// Check the number of elites
+	if mod.Elitism < 0 {
+		return errors.New("'Elitism' should be higher or equal to 0")
+	}
+	// Check the minimum pheromone
+	if mod.MinPheromone < 0 {
+		return errors.New("'MinPheromone' should be higher or equal to 0")
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestColonyConstruct(t *testing.T) {
+	var (
+		c   = newColony(Genome{0, 1, 2, 3, 4, 5}, nil, 2)
+		rng = rand.New(rand.NewSource(42))
+	)
+	for i := 0; i < 20; i++ {
+		var (
+			genome = c.construct(1, rng)
+			seen   = make(map[interface{}]bool)
+		)
+		for _, gene := range genome {
+			seen[gene] = true
+		}
+		if len(genome) != 6 || len(seen) != 6 {
+			t.Errorf("%v is not a permutation", genome)
+		}
+	}
+	// A transition without pheromone is never chosen when there are others
+	for i := range c.pheromones {
+		for j := range c.pheromones[i] {
+			c.pheromones[i][j] = 0
+		}
+		c.pheromones[i][(i+1)%6] = 1
+	}
+	var genome = c.construct(1, rng)
+	for k := 1; k < len(genome); k++ {
+		if genome[k] != (genome[k-1].(int)+1)%6 {
+			t.Errorf("The ant didn't follow the trail, got %v", genome)
+			break
+		}
+	}
+}
+
+func TestColonyUpdate(t *testing.T) {
+	var c = newColony(Genome{"a", "b", "c"}, nil, 2)
+	c.update(Individuals{
+		{Genome: Genome{"a", "b", "c"}},
+		{Genome: Genome{"c", "b", "a"}},
+	}, 0.5, 0.2, true, false)
+	var expected = [][]float64{
+		{0.5, 1.5, 0.5 + 0.5},
+		{0.5 + 0.5, 0.5, 1.5},
+		{1.5, 0.5 + 0.5, 0.5},
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if math.Abs(c.pheromones[i][j]-expected[i][j]) > 1e-10 {
+				t.Errorf("Expected %v, got %v", expected, c.pheromones)
+				return
+			}
+		}
+	}
+	// Evaporation stops at the minimum
+	for i := 0; i < 20; i++ {
+		c.update(nil, 0.5, 0.2, true, false)
+	}
+	if c.pheromones[0][1] != 0.2 {
+		t.Errorf("Expected the pheromone to be 0.2, got %f", c.pheromones[0][1])
+	}
+	// Symmetric deposits
+	c = newColony(Genome{"a", "b", "c"}, nil, 2)
+	c.update(Individuals{{Genome: Genome{"a", "b", "c"}}}, 0, 0, false, true)
+	if c.pheromones[0][1] != 2 || c.pheromones[1][0] != 2 || c.pheromones[2][0] != 1 {
+		t.Errorf("Unexpected pheromones %v", c.pheromones)
+	}
+}
+
+func TestModACO(t *testing.T) {
+	var (
+		pop = makePopulation(10, 6, tourLength, InitPermutationI{})
+		mod = &ModACO{Elitism: 2}
+	)
+	pop.Individuals.Evaluate(pop.ff)
+	pop.Individuals.SortWith(nil)
+	var best = append(Individuals{}, pop.Individuals[:2]...)
+	if genes, _ := mod.Pheromones(pop); genes != nil {
+		t.Error("The population shouldn't have a colony yet")
+	}
+	mod.Apply(&pop)
+	if len(pop.Individuals) != 10 {
+		t.Error("The size of the population was modified")
+	}
+	if pop.Individuals[0].Name != best[0].Name || pop.Individuals[1].Name != best[1].Name {
+		t.Error("The elites weren't kept")
+	}
+	for _, indi := range pop.Individuals[2:] {
+		if indi.Evaluated {
+			t.Error("An ant is marked as evaluated")
+		}
+	}
+	if genes, pheromones := mod.Pheromones(pop); len(genes) != 6 || len(pheromones) != 6 {
+		t.Error("The population should have a colony")
+	}
+}
+
+func TestModACOSolvesTour(t *testing.T) {
+	var (
+		n   = 10
+		pos = func(gene interface{}) (float64, float64) {
+			var a = 2 * math.Pi * float64(gene.(int)) / float64(n)
+			return math.Cos(a), math.Sin(a)
+		}
+		ga = GA{
+			Ff:             tourLength,
+			Initializer:    InitPermutationI{},
+			NbrGenes:       n,
+			NbrPopulations: 1,
+			NbrIndividuals: 20,
+			Model: &ModACO{
+				NbrBest: 2,
+				Elitism: 1,
+				Heuristic: func(a, b interface{}) float64 {
+					var xa, ya = pos(a)
+					var xb, yb = pos(b)
+					return 1 / math.Hypot(xa-xb, ya-yb)
+				},
+				Cycle:     true,
+				Symmetric: true,
+			},
+		}
+	)
+	ga.Initialize()
+	for i := 0; i < 30; i++ {
+		ga.Enhance()
+	}
+	var optimal = 2 * float64(n) * math.Sin(math.Pi/float64(n))
+	if ga.Best().Fitness > optimal+1e-6 {
+		t.Errorf("Expected the optimal tour of length %f, got %f", optimal, ga.Best().Fitness)
+	}
+}
+
+func TestModACOValidate(t *testing.T) {
+	var mod = &ModACO{}
+	if mod.Validate() != nil {
+		t.Error("Should not have returned an error")
+	}
+	mod.Rho = 1.5
+	if mod.Validate() == nil {
+		t.Error("Should have returned an error")
+	}
+	mod = &ModACO{Beta: -1}
+	if mod.Validate() == nil {
+		t.Error("Should have returned an error")
+	}
+	mod = &ModACO{Elitism: -1}
+	if mod.Validate() == nil {
+		t.Error("Should have returned an error")
+	}
+}
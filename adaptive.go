@@ -0,0 +1,272 @@
+package gago
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// operatorCredit keeps track of the quality of a set of operators and chooses
+// which one to use through probability matching. The quality of an operator is
+// an exponential moving average of the rewards it obtained, the probability of
+// choosing an operator is proportional to it's quality while never going under
+// a minimum probability so that every operator keeps a chance of being used.
+// The credit is shared between populations, hence it is protected by a mutex.
+type operatorCredit struct {
+	mu        sync.Mutex
+	qualities []float64
+	uses      []int
+}
+
+// Make sure the credit is initialized for n operators. The operators all start
+// with the same quality.
+func (credit *operatorCredit) init(n int) {
+	if len(credit.qualities) != n {
+		credit.qualities = make([]float64, n)
+		credit.uses = make([]int, n)
+		for i := range credit.qualities {
+			credit.qualities[i] = 1
+		}
+	}
+}
+
+// Compute the probability of choosing each operator, the lock has to be held.
+func (credit *operatorCredit) probabilities(pMin float64) []float64 {
+	var (
+		n     = len(credit.qualities)
+		probs = make([]float64, n)
+		total float64
+	)
+	for _, q := range credit.qualities {
+		total += q
+	}
+	for i, q := range credit.qualities {
+		if total == 0 {
+			probs[i] = 1 / float64(n)
+		} else {
+			probs[i] = pMin + (1-float64(n)*pMin)*q/total
+		}
+	}
+	return probs
+}
+
+// Choose the index of an operator out of n operators.
+func (credit *operatorCredit) pick(n int, pMin float64, rng *rand.Rand) int {
+	credit.mu.Lock()
+	defer credit.mu.Unlock()
+	credit.init(n)
+	var (
+		probs = credit.probabilities(pMin)
+		r     = rng.Float64()
+	)
+	for i, p := range probs {
+		if r < p {
+			credit.uses[i]++
+			return i
+		}
+		r -= p
+	}
+	credit.uses[n-1]++
+	return n - 1
+}
+
+// Update the quality of the i-th operator with a new reward.
+func (credit *operatorCredit) reward(i int, reward, alpha float64) {
+	credit.mu.Lock()
+	defer credit.mu.Unlock()
+	credit.qualities[i] += alpha * (reward - credit.qualities[i])
+}
+
+// Compute the improvement brought by a set of children over a set of parents.
+// Only strict improvements are rewarded.
+func improvement(parents, children Individuals) float64 {
+	var (
+		bestParent = math.Inf(1)
+		bestChild  = math.Inf(1)
+	)
+	for _, parent := range parents {
+		bestParent = math.Min(bestParent, parent.Fitness)
+	}
+	for _, child := range children {
+		bestChild = math.Min(bestChild, child.Fitness)
+	}
+	return math.Max(0, bestParent-bestChild)
+}
+
+// CrossAdaptive holds a set of crossover operators and learns which ones to
+// use through adaptive operator selection. Each time the crossover is applied
+// one of the operators is chosen through probability matching, the offsprings
+// are evaluated and the operator is rewarded with the improvement of the best
+// offspring over the best parent. When CrossAdaptive is part of the model of a
+// GA the offsprings are evaluated with the fitness function of the population,
+// hence the evaluations count towards the GA's evaluations, else they are
+// evaluated with Ff. If PerEvaluation is true the reward is
+// divided by the number of fitness evaluations the application required, which
+// favors the operators that make the best use of an evaluation budget. Because
+// the rewards are tracked across generations, CrossAdaptive has to be used
+// through a pointer.
+type CrossAdaptive struct {
+	Operators     []Crossover
+	Ff            FitnessFunction // Fitness function used to reward the operators outside of a GA
+	PMin          float64         // Minimum probability of choosing each operator
+	Alpha         float64         // Adaptation rate of the qualities in (0, 1]
+	PerEvaluation bool            // Divide the rewards by the number of evaluations
+	credit        operatorCredit
+}
+
+// Apply adaptive crossover.
+func (cross *CrossAdaptive) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	return cross.apply(p1, p2, cross.Ff, rng)
+}
+
+// Apply adaptive crossover and evaluate the individuals with ff.
+func (cross *CrossAdaptive) apply(p1 Individual, p2 Individual, ff FitnessFunction, rng *rand.Rand) (Individual, Individual) {
+	var (
+		i      = cross.credit.pick(len(cross.Operators), cross.PMin, rng)
+		o1, o2 = cross.Operators[i].Apply(p1, p2, rng)
+	)
+	// The parents are copies, evaluating them doesn't alter the population
+	var (
+		indis       = Individuals{p1, p2, o1, o2}
+		evaluations = indis.unevaluated()
+	)
+	indis.Evaluate(ff)
+	var reward = improvement(indis[:2], indis[2:])
+	if cross.PerEvaluation {
+		reward /= math.Max(1, float64(evaluations))
+	}
+	cross.credit.reward(i, reward, cross.Alpha)
+	return indis[2], indis[3]
+}
+
+// Validate the parameters of the crossover.
+func (cross *CrossAdaptive) Validate() error {
+	return validateAdaptive(len(cross.Operators), cross.PMin, cross.Alpha)
+}
+
+// Probabilities returns the current probability of choosing each operator.
+func (cross *CrossAdaptive) Probabilities() []float64 {
+	cross.credit.mu.Lock()
+	defer cross.credit.mu.Unlock()
+	cross.credit.init(len(cross.Operators))
+	return cross.credit.probabilities(cross.PMin)
+}
+
+// MutAdaptive holds a set of mutators and learns which ones to use through
+// adaptive operator selection, in the same fashion as CrossAdaptive. The
+// individual is evaluated before and after the mutation in order to reward the
+// chosen mutator. MutAdaptive has to be used through a pointer.
+type MutAdaptive struct {
+	Operators     []Mutator
+	Ff            FitnessFunction // Fitness function used to reward the operators outside of a GA
+	PMin          float64         // Minimum probability of choosing each operator
+	Alpha         float64         // Adaptation rate of the qualities in (0, 1]
+	PerEvaluation bool            // Divide the rewards by the number of evaluations
+	credit        operatorCredit
+}
+
+// Apply adaptive mutation.
+func (mut *MutAdaptive) Apply(indi *Individual, rng *rand.Rand) {
+	mut.apply(indi, mut.Ff, rng)
+}
+
+// Apply adaptive mutation and evaluate the individual with ff.
+func (mut *MutAdaptive) apply(indi *Individual, ff FitnessFunction, rng *rand.Rand) {
+	var (
+		i           = mut.credit.pick(len(mut.Operators), mut.PMin, rng)
+		evaluations = 1
+	)
+	if !indi.Evaluated {
+		evaluations++
+	}
+	indi.Evaluate(ff)
+	var before = *indi
+	mut.Operators[i].Apply(indi, rng)
+	indi.Evaluated = false
+	indi.Evaluate(ff)
+	var reward = improvement(Individuals{before}, Individuals{*indi})
+	if mut.PerEvaluation {
+		reward /= float64(evaluations)
+	}
+	mut.credit.reward(i, reward, mut.Alpha)
+}
+
+// Validate the parameters of the mutator.
+func (mut *MutAdaptive) Validate() error {
+	return validateAdaptive(len(mut.Operators), mut.PMin, mut.Alpha)
+}
+
+// Probabilities returns the current probability of choosing each operator.
+func (mut *MutAdaptive) Probabilities() []float64 {
+	mut.credit.mu.Lock()
+	defer mut.credit.mu.Unlock()
+	mut.credit.init(len(mut.Operators))
+	return mut.credit.probabilities(mut.PMin)
+}
+
+// Check the parameters of an adaptive operator holding n operators.
+func validateAdaptive(n int, pMin, alpha float64) error {
+	// Check the operators presence
+	if n == 0 {
+		return errors.New("'Operators' should contain at least one operator")
+	}
+	// Check the minimum probability, the minimum probabilities of the
+	// operators can't sum up to more than 1
+	if pMin < 0 || pMin*float64(n) > 1 {
+		return errors.New("'PMin' should be comprised between 0 and 1/len(Operators)")
+	}
+	// Check the adaptation rate
+	if alpha <= 0 || alpha > 1 {
+		return errors.New("'Alpha' should be comprised between 0 excluded and 1")
+	}
+	return nil
+}
+
+// An adaptive crossover bound to the fitness function of a population.
+type boundCrossAdaptive struct {
+	*CrossAdaptive
+	ff FitnessFunction
+}
+
+func (cross boundCrossAdaptive) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	return cross.apply(p1, p2, cross.ff, rng)
+}
+
+func (cross boundCrossAdaptive) unwrap() interface{} {
+	return cross.CrossAdaptive
+}
+
+// An adaptive mutator bound to the fitness function of a population.
+type boundMutAdaptive struct {
+	*MutAdaptive
+	ff FitnessFunction
+}
+
+func (mut boundMutAdaptive) Apply(indi *Individual, rng *rand.Rand) {
+	mut.apply(indi, mut.ff, rng)
+}
+
+func (mut boundMutAdaptive) unwrap() interface{} {
+	return mut.MutAdaptive
+}
+
+// Return a copy of a model whose adaptive operators evaluate the individuals
+// with ff, which is the counted fitness function of a population, so that the
+// evaluations they require are part of the GA's evaluations.
+func bindModel(model Model, ff FitnessFunction) Model {
+	return wrapModel(model, wrappers{
+		crossover: func(cross Crossover) Crossover {
+			if adaptive, ok := cross.(*CrossAdaptive); ok {
+				return boundCrossAdaptive{adaptive, ff}
+			}
+			return cross
+		},
+		mutator: func(mut Mutator) Mutator {
+			if adaptive, ok := mut.(*MutAdaptive); ok {
+				return boundMutAdaptive{adaptive, ff}
+			}
+			return mut
+		},
+	})
+}
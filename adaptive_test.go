@@ -0,0 +1,157 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// crossConstant is a crossover that fills the offsprings with a constant.
+type crossConstant struct {
+	value float64
+}
+
+func (cross crossConstant) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var (
+		o1 = makeIndividual(len(p1.Genome), rng)
+		o2 = makeIndividual(len(p2.Genome), rng)
+	)
+	for i := range o1.Genome {
+		o1.Genome[i] = cross.value
+		o2.Genome[i] = cross.value
+	}
+	return o1, o2
+}
+
+func TestCrossAdaptive(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = makeIndividuals(2, 3, rng)
+		init  = InitUniformF{-5.0, 5.0}
+		ff    = Float64Function{func(X []float64) float64 {
+			var sum float64
+			for _, x := range X {
+				sum += math.Abs(x)
+			}
+			return sum
+		}}
+		// The first operator always improves, the second one never does
+		cross = &CrossAdaptive{
+			Operators: []Crossover{crossConstant{0}, crossConstant{100}},
+			Ff:        ff,
+			PMin:      0.1,
+			Alpha:     0.3,
+		}
+	)
+	for i := range indis {
+		init.Apply(&indis[i], rng)
+	}
+	for i := 0; i < 50; i++ {
+		var o1, o2 = cross.Apply(indis[0], indis[1], rng)
+		// Check the offsprings have been evaluated
+		if !o1.Evaluated || !o2.Evaluated {
+			t.Error("CrossAdaptive didn't evaluate the offsprings")
+		}
+	}
+	var probs = cross.Probabilities()
+	// Check the probabilities sum up to 1 and respect the minimum probability
+	if math.Abs(probs[0]+probs[1]-1) > 1e-10 || probs[1] < cross.PMin {
+		t.Error("CrossAdaptive probabilities are incoherent")
+	}
+	// Check the improving operator is preferred
+	if probs[0] <= probs[1] {
+		t.Error("CrossAdaptive didn't learn to prefer the improving operator")
+	}
+}
+
+func TestMutAdaptive(t *testing.T) {
+	var (
+		src  = rand.NewSource(time.Now().UnixNano())
+		rng  = rand.New(src)
+		indi = makeIndividual(3, rng)
+		init = InitUniformF{-5.0, 5.0}
+		mut  = &MutAdaptive{
+			Operators: []Mutator{MutNormalF{1, 1}, MutPermute{2}},
+			Ff:        ff,
+			PMin:      0.1,
+			Alpha:     0.3,
+		}
+	)
+	init.Apply(&indi, rng)
+	for i := 0; i < 10; i++ {
+		indi.Mutate(mut, rng)
+		// Check the individual was evaluated after being mutated
+		if !indi.Evaluated || indi.Fitness != ff.apply(indi.Genome) {
+			t.Error("MutAdaptive didn't evaluate the mutated individual")
+		}
+	}
+}
+
+func TestAdaptiveCountedEvaluations(t *testing.T) {
+	var (
+		calls int64
+		ff    = Float64Function{func(X []float64) float64 {
+			atomic.AddInt64(&calls, 1)
+			return math.Abs(X[0]) + math.Abs(X[1])
+		}}
+		// The fitness function of the operators isn't used within a GA
+		unused = Float64Function{func(X []float64) float64 {
+			t.Error("The adaptive operators should evaluate with the GA's fitness function")
+			return 0
+		}}
+		ga = GA{
+			NbrPopulations: 2,
+			NbrIndividuals: 10,
+			NbrGenes:       2,
+			Ff:             ff,
+			Initializer:    InitUniformF{-5.0, 5.0},
+			Model: ModGenerational{
+				Selector:  SelTournament{NbParticipants: 2},
+				Crossover: &CrossAdaptive{Operators: []Crossover{CrossUniformF{}, crossConstant{0}}, Ff: unused, PMin: 0.1, Alpha: 0.3},
+				Mutator:   &MutAdaptive{Operators: []Mutator{MutNormalF{1, 1}}, Ff: unused, PMin: 0.1, Alpha: 0.3},
+				MutRate:   0.5,
+			},
+		}
+	)
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	if ga.Evaluations != int(atomic.LoadInt64(&calls)) {
+		t.Errorf("Expected %d evaluations, got %d", calls, ga.Evaluations)
+	}
+}
+
+func TestAdaptiveValidate(t *testing.T) {
+	var operators = []interface{ Validate() error }{
+		&CrossAdaptive{Operators: []Crossover{CrossUniformF{}, CrossUniformF{}, CrossUniformF{}}, PMin: 0.5, Alpha: 0.3},
+		&CrossAdaptive{Operators: []Crossover{CrossUniformF{}}, PMin: -0.1, Alpha: 0.3},
+		&CrossAdaptive{PMin: 0.1, Alpha: 0.3},
+		&MutAdaptive{Operators: []Mutator{MutNormalF{1, 1}, MutNormalF{1, 1}}, PMin: 0.6, Alpha: 0.3},
+		&MutAdaptive{Operators: []Mutator{MutNormalF{1, 1}}, PMin: 0.1, Alpha: 0},
+	}
+	for _, op := range operators {
+		if op.Validate() == nil {
+			t.Errorf("%T should be invalid", op)
+		}
+	}
+	var mut = &MutAdaptive{Operators: []Mutator{MutNormalF{1, 1}, MutNormalF{1, 1}}, PMin: 0.5, Alpha: 1}
+	if err := mut.Validate(); err != nil {
+		t.Error(err)
+	}
+	// The GA checks the operators of it's model
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 10,
+		NbrGenes:       2,
+		Ff:             ff,
+		Initializer:    InitUniformF{-5.0, 5.0},
+		Model:          ModMutationOnly{NbrParents: 2, Selector: SelTournament{NbParticipants: 2}, NbrOffsprings: 1, Mutator: operators[3].(Mutator)},
+	}
+	if ga.Validate() == nil {
+		t.Error("The GA should check the adaptive mutator")
+	}
+}
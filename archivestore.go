@@ -0,0 +1,497 @@
+package gago
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// An ArchiveStore holds the members of a HallOfFame or of a ParetoArchive
+// outside of the archive, which then only keeps in memory what it needs to
+// compare the individuals: their genomes and their cases are read from the
+// store when the members are requested. Hence very long runs with large
+// archives don't grow the memory of the process, and with a persistent store
+// the members survive a crash of the process. MemoryArchiveStore,
+// FileArchiveStore and SQLArchiveStore are provided, other backends only have
+// to implement the interface.
+//
+// The members are identified by the hash of their genome, see HashGenome. The
+// store only has to keep the last individual put under a key, the binary
+// encoding of the individuals is used by the persistent stores, hence the IDs,
+// birth generations, origins and fidelities are only kept while the archive is
+// alive.
+type ArchiveStore interface {
+	Put(key string, indi Individual) error
+	Get(key string) (Individual, error)
+	Delete(key string) error
+	Keys() ([]string, error)
+}
+
+// Return the key of a genome in an ArchiveStore, an empty string is returned
+// if the genome can't be encoded.
+func storeKey(genome Genome) string {
+	var hash, err = HashGenome(genome)
+	if err != nil {
+		return ""
+	}
+	return hash
+}
+
+// Return the part of an individual that an archive backed by a store keeps in
+// memory.
+func stubIndividual(indi Individual) Individual {
+	indi.Genome = nil
+	indi.Cases = nil
+	return indi
+}
+
+// Read the genome and the cases of a member from a store.
+func loadMember(store ArchiveStore, key string, stub Individual) (Individual, error) {
+	var indi, err = store.Get(key)
+	if err != nil {
+		return stub, err
+	}
+	stub.Genome, stub.Cases = indi.Genome, indi.Cases
+	return stub, nil
+}
+
+// Delete every individual held by a store.
+func clearStore(store ArchiveStore) error {
+	var keys, err = store.Keys()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err = store.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Encode an individual in the binary format.
+func encodeIndividual(indi Individual) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		bw  = binaryWriter{w: &buf}
+	)
+	bw.individual(indi)
+	return buf.Bytes(), bw.err
+}
+
+// Decode an individual written by encodeIndividual.
+func decodeIndividual(data []byte) (Individual, error) {
+	var (
+		br   = binaryReader{r: bufio.NewReader(bytes.NewReader(data))}
+		indi = br.individual()
+	)
+	return indi, br.err
+}
+
+// MemoryArchiveStore is an ArchiveStore that keeps the members in memory, it
+// doesn't save any memory but it allows checking that a backend behaves like
+// the archive without a store. It has to be used through a pointer.
+type MemoryArchiveStore struct {
+	mu    sync.RWMutex
+	indis map[string]Individual
+}
+
+// Put stores a copy of an individual under a key.
+func (ms *MemoryArchiveStore) Put(key string, indi Individual) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.indis == nil {
+		ms.indis = make(map[string]Individual)
+	}
+	ms.indis[key] = copyIndividual(indi)
+	return nil
+}
+
+// Get returns a copy of the individual stored under a key.
+func (ms *MemoryArchiveStore) Get(key string) (Individual, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	var indi, ok = ms.indis[key]
+	if !ok {
+		return Individual{}, fmt.Errorf("no individual is stored under the key %s", key)
+	}
+	return copyIndividual(indi), nil
+}
+
+// Delete removes the individual stored under a key.
+func (ms *MemoryArchiveStore) Delete(key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.indis, key)
+	return nil
+}
+
+// Keys returns the sorted keys of the stored individuals.
+func (ms *MemoryArchiveStore) Keys() ([]string, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	var keys = make([]string, 0, len(ms.indis))
+	for key := range ms.indis {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// The operations recorded in the log of a FileArchiveStore.
+const (
+	opDelete byte = iota
+	opPut
+)
+
+// FileArchiveStore is an ArchiveStore that appends each operation to a log
+// file and only keeps in memory the position of each stored individual in the
+// file. The log is replayed when the store is opened, a record that was only
+// partially written because the process crashed is discarded, hence the store
+// is always left in the state of the last complete operation. If Sync is true
+// the file is synced after each operation so that the operations also survive
+// a crash of the system, at the expense of speed. The file keeps growing as
+// members are replaced, Compact rewrites it with the stored individuals only.
+// A FileArchiveStore is created with OpenFileArchiveStore and should be closed
+// once the runs are over.
+type FileArchiveStore struct {
+	Sync    bool
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	size    int64            // Size of the complete records of the file
+	offsets map[string]int64 // Position of the encoding of each stored individual
+	lengths map[string]int   // Length of the encoding of each stored individual
+}
+
+// OpenFileArchiveStore opens the FileArchiveStore persisted in the file at
+// path, the file is created if it doesn't exist.
+func OpenFileArchiveStore(path string) (*FileArchiveStore, error) {
+	var file, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	var fs = &FileArchiveStore{path: path, file: file}
+	if err = fs.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	// Discard the record that was partially written
+	if err = file.Truncate(fs.size); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err = file.Seek(fs.size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return fs, nil
+}
+
+// Read the records of the file and index the stored individuals. A record is
+// made of an operation, a key and for a put the length and the encoding of the
+// individual.
+func (fs *FileArchiveStore) replay() error {
+	fs.offsets = make(map[string]int64)
+	fs.lengths = make(map[string]int)
+	var (
+		counter = &countingReader{r: fs.file}
+		br      = &binaryReader{r: bufio.NewReader(counter)}
+	)
+	for {
+		var op = br.byte()
+		var key = br.string()
+		if br.err != nil {
+			return tornRecord(br.err)
+		}
+		switch op {
+		case opDelete:
+			delete(fs.offsets, key)
+			delete(fs.lengths, key)
+		case opPut:
+			var n = br.length()
+			if br.err != nil {
+				return tornRecord(br.err)
+			}
+			// The individual starts where the buffered data that hasn't been
+			// read yet starts
+			var offset = counter.n - int64(br.r.Buffered())
+			if _, err := br.r.Discard(n); err != nil {
+				return tornRecord(err)
+			}
+			fs.offsets[key] = offset
+			fs.lengths[key] = n
+		default:
+			return fmt.Errorf("the record at position %d of %s has an unknown operation %d", fs.size, fs.path, op)
+		}
+		fs.size = counter.n - int64(br.r.Buffered())
+	}
+}
+
+// Return the error met while reading a record, reaching the end of the file
+// isn't an error because the record was only partially written.
+func tornRecord(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil
+	}
+	return err
+}
+
+// A countingReader counts the bytes read from a reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	var n, err = cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// Append a record to the file in a single write.
+func (fs *FileArchiveStore) append(op byte, key string, data []byte) error {
+	var (
+		buf bytes.Buffer
+		bw  = binaryWriter{w: &buf}
+	)
+	bw.write([]byte{op})
+	bw.string(key)
+	if op == opPut {
+		bw.uvarint(uint64(len(data)))
+	}
+	var offset = fs.size + int64(buf.Len())
+	bw.write(data)
+	if _, err := fs.file.Write(buf.Bytes()); err != nil {
+		// Discard what was written so that the next records follow the last
+		// complete one
+		fs.file.Truncate(fs.size)
+		fs.file.Seek(fs.size, io.SeekStart)
+		return err
+	}
+	if fs.Sync {
+		if err := fs.file.Sync(); err != nil {
+			return err
+		}
+	}
+	fs.size += int64(buf.Len())
+	if op == opPut {
+		fs.offsets[key] = offset
+		fs.lengths[key] = len(data)
+	} else {
+		delete(fs.offsets, key)
+		delete(fs.lengths, key)
+	}
+	return nil
+}
+
+// Put appends an individual to the file.
+func (fs *FileArchiveStore) Put(key string, indi Individual) error {
+	var data, err = encodeIndividual(indi)
+	if err != nil {
+		return err
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.append(opPut, key, data)
+}
+
+// Get reads the individual stored under a key from the file.
+func (fs *FileArchiveStore) Get(key string) (Individual, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	var offset, ok = fs.offsets[key]
+	if !ok {
+		return Individual{}, fmt.Errorf("no individual is stored under the key %s", key)
+	}
+	var data = make([]byte, fs.lengths[key])
+	if _, err := fs.file.ReadAt(data, offset); err != nil {
+		return Individual{}, err
+	}
+	return decodeIndividual(data)
+}
+
+// Delete appends the removal of the individual stored under a key to the
+// file.
+func (fs *FileArchiveStore) Delete(key string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if _, ok := fs.offsets[key]; !ok {
+		return nil
+	}
+	return fs.append(opDelete, key, nil)
+}
+
+// Keys returns the sorted keys of the stored individuals.
+func (fs *FileArchiveStore) Keys() ([]string, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	var keys = make([]string, 0, len(fs.offsets))
+	for key := range fs.offsets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Compact rewrites the file so that it only contains the stored individuals.
+// The new file is written next to the old one and then renamed, hence a crash
+// during the compaction leaves the old file untouched.
+func (fs *FileArchiveStore) Compact() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	var keys = make([]string, 0, len(fs.offsets))
+	for key := range fs.offsets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var tmp, err = os.OpenFile(fs.path+".tmp", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	var compacted = &FileArchiveStore{
+		Sync:    fs.Sync,
+		path:    fs.path,
+		file:    tmp,
+		offsets: make(map[string]int64),
+		lengths: make(map[string]int),
+	}
+	for _, key := range keys {
+		var data = make([]byte, fs.lengths[key])
+		if _, err = fs.file.ReadAt(data, fs.offsets[key]); err == nil {
+			err = compacted.append(opPut, key, data)
+		}
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return err
+		}
+	}
+	if err = tmp.Sync(); err == nil {
+		err = os.Rename(tmp.Name(), fs.path)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	fs.file.Close()
+	fs.file, fs.size, fs.offsets, fs.lengths = tmp, compacted.size, compacted.offsets, compacted.lengths
+	return nil
+}
+
+// Close closes the file of the store.
+func (fs *FileArchiveStore) Close() error {
+	return fs.file.Close()
+}
+
+// SQLArchiveStore is an ArchiveStore that keeps the members in a table of an
+// SQL database, which is opened by the caller with the driver of it's choice,
+// for example an SQLite driver. The table is named Table, or archive if Table
+// is empty, and is created by the first operation if it doesn't exist. It has
+// a hash column that holds the keys and an individual column that holds the
+// binary encoding of the individuals. Each operation is committed before it
+// returns, hence the members persist as soon as they are added to the
+// archive. An SQLArchiveStore has to be used through a pointer.
+type SQLArchiveStore struct {
+	DB      *sql.DB
+	Table   string
+	mu      sync.Mutex
+	created bool
+}
+
+// Return the name of the table and create it if it doesn't exist.
+func (ss *SQLArchiveStore) table() (string, error) {
+	var table = ss.Table
+	if table == "" {
+		table = "archive"
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if !ss.created {
+		var _, err = ss.DB.Exec("CREATE TABLE IF NOT EXISTS " + table + " (hash TEXT PRIMARY KEY, individual BLOB)")
+		if err != nil {
+			return "", err
+		}
+		ss.created = true
+	}
+	return table, nil
+}
+
+// Put replaces the individual stored under a key within a transaction.
+func (ss *SQLArchiveStore) Put(key string, indi Individual) error {
+	var data, err = encodeIndividual(indi)
+	if err != nil {
+		return err
+	}
+	table, err := ss.table()
+	if err != nil {
+		return err
+	}
+	tx, err := ss.DB.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM "+table+" WHERE hash = ?", key); err == nil {
+		_, err = tx.Exec("INSERT INTO "+table+" (hash, individual) VALUES (?, ?)", key, data)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Get reads the individual stored under a key.
+func (ss *SQLArchiveStore) Get(key string) (Individual, error) {
+	var table, err = ss.table()
+	if err != nil {
+		return Individual{}, err
+	}
+	var data []byte
+	err = ss.DB.QueryRow("SELECT individual FROM "+table+" WHERE hash = ?", key).Scan(&data)
+	if err == sql.ErrNoRows {
+		return Individual{}, fmt.Errorf("no individual is stored under the key %s", key)
+	}
+	if err != nil {
+		return Individual{}, err
+	}
+	return decodeIndividual(data)
+}
+
+// Delete removes the individual stored under a key.
+func (ss *SQLArchiveStore) Delete(key string) error {
+	var table, err = ss.table()
+	if err != nil {
+		return err
+	}
+	_, err = ss.DB.Exec("DELETE FROM "+table+" WHERE hash = ?", key)
+	return err
+}
+
+// Keys returns the sorted keys of the stored individuals.
+func (ss *SQLArchiveStore) Keys() ([]string, error) {
+	var table, err = ss.table()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := ss.DB.Query("SELECT hash FROM " + table + " ORDER BY hash")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
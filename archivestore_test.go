@@ -0,0 +1,225 @@
+package gago
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func openTestFileArchiveStore(t *testing.T) (*FileArchiveStore, string) {
+	var path = filepath.Join(t.TempDir(), "archive")
+	var store, err = OpenFileArchiveStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store, path
+}
+
+func openTestSQLArchiveStore(t *testing.T) *SQLArchiveStore {
+	var d = &memDriver{tables: make(map[string][]map[string]driver.Value)}
+	sql.Register("gago_archive_"+t.Name(), d)
+	var db, err = sql.Open("gago_archive_"+t.Name(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &SQLArchiveStore{DB: db}
+}
+
+func TestArchiveStores(t *testing.T) {
+	var (
+		fs, _  = openTestFileArchiveStore(t)
+		stores = []ArchiveStore{&MemoryArchiveStore{}, fs, openTestSQLArchiveStore(t)}
+		a      = Individual{Genome: Genome{1.0, 2}, Fitness: 3, Evaluated: true, Cases: []float64{4}}
+		b      = Individual{Genome: Genome{"b"}, Fitness: 5, Evaluated: true, Objectives: []float64{5, 6}}
+	)
+	for _, store := range stores {
+		if err := store.Put("a", a); err != nil {
+			t.Fatal(err)
+		}
+		store.Put("b", a)
+		store.Put("b", b)
+		var indi, err = store.Get("b")
+		if err != nil || !reflect.DeepEqual(indi, b) {
+			t.Errorf("%T: expected %v, got %v (%v)", store, b, indi, err)
+		}
+		if indi, err = store.Get("a"); err != nil || !reflect.DeepEqual(indi, a) {
+			t.Errorf("%T: expected %v, got %v (%v)", store, a, indi, err)
+		}
+		if keys, _ := store.Keys(); !reflect.DeepEqual(keys, []string{"a", "b"}) {
+			t.Errorf("%T: expected the keys [a b], got %v", store, keys)
+		}
+		if err = store.Delete("a"); err != nil {
+			t.Error(err)
+		}
+		if _, err = store.Get("a"); err == nil {
+			t.Errorf("%T: a deleted individual shouldn't be found", store)
+		}
+		if keys, _ := store.Keys(); !reflect.DeepEqual(keys, []string{"b"}) {
+			t.Errorf("%T: expected the keys [b], got %v", store, keys)
+		}
+	}
+}
+
+func TestFileArchiveStoreReopen(t *testing.T) {
+	var store, path = openTestFileArchiveStore(t)
+	for i := 0; i < 5; i++ {
+		store.Put(string('a'+rune(i)), Individual{Genome: Genome{float64(i)}, Fitness: float64(i)})
+	}
+	store.Delete("b")
+	store.Close()
+	// Simulate a crash in the middle of the last write
+	var info, _ = os.Stat(path)
+	if err := os.Truncate(path, info.Size()-2); err != nil {
+		t.Fatal(err)
+	}
+	store, err := OpenFileArchiveStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	// The deletion was torn, hence b is still stored
+	if keys, _ := store.Keys(); !reflect.DeepEqual(keys, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Expected the keys [a b c d e], got %v", keys)
+	}
+	// The torn record is overwritten by the next one
+	store.Put("f", Individual{Genome: Genome{5.0}, Fitness: 5})
+	store.Close()
+	if store, err = OpenFileArchiveStore(path); err != nil {
+		t.Fatal(err)
+	}
+	if indi, err := store.Get("f"); err != nil || indi.Fitness != 5 {
+		t.Errorf("Expected the individual f, got %v (%v)", indi, err)
+	}
+	if indi, err := store.Get("d"); err != nil || indi.Fitness != 3 {
+		t.Errorf("Expected the individual d, got %v (%v)", indi, err)
+	}
+}
+
+func TestFileArchiveStoreCompact(t *testing.T) {
+	var store, path = openTestFileArchiveStore(t)
+	for i := 0; i < 50; i++ {
+		store.Put(string('a'+rune(i%3)), Individual{Genome: Genome{float64(i)}, Fitness: float64(i)})
+	}
+	var before, _ = os.Stat(path)
+	if err := store.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	var after, _ = os.Stat(path)
+	if after.Size() >= before.Size() {
+		t.Errorf("The file should shrink, got %d bytes instead of %d", after.Size(), before.Size())
+	}
+	if indi, err := store.Get("c"); err != nil || indi.Fitness != 47 {
+		t.Errorf("Expected the last individual put under c, got %v (%v)", indi, err)
+	}
+	// The store can still be written to and reopened
+	store.Put("d", Individual{Genome: Genome{0.0}, Fitness: 1})
+	store.Close()
+	store, err := OpenFileArchiveStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys, _ := store.Keys(); len(keys) != 4 {
+		t.Errorf("Expected 4 keys, got %v", keys)
+	}
+}
+
+func TestHallOfFameStore(t *testing.T) {
+	var (
+		fs, _ = openTestFileArchiveStore(t)
+		rng   = rand.New(rand.NewSource(42))
+		indis = make(Individuals, 200)
+	)
+	for i := range indis {
+		// Some genomes are drawn several times
+		indis[i] = Individual{Genome: Genome{float64(rng.Intn(50))}, Fitness: rng.Float64(), Evaluated: true}
+	}
+	for _, store := range []ArchiveStore{&MemoryArchiveStore{}, fs} {
+		var (
+			plain  = &HallOfFame{Size: 10}
+			stored = &HallOfFame{Size: 10, Store: store}
+		)
+		for _, indi := range indis {
+			if plain.Add(indi) != stored.Add(indi) {
+				t.Fatalf("%T: the hall of fame should behave as without a store", store)
+			}
+		}
+		if !reflect.DeepEqual(plain.Members(), stored.Members()) {
+			t.Errorf("%T: expected the members %v, got %v", store, plain.Members(), stored.Members())
+		}
+		if keys, _ := store.Keys(); len(keys) != stored.Len() {
+			t.Errorf("%T: the store should only hold the %d members, got %d", store, stored.Len(), len(keys))
+		}
+		for _, member := range stored.members {
+			if member.Genome != nil {
+				t.Errorf("%T: the genomes should only be kept in the store", store)
+			}
+		}
+		// Restore the members in a new hall of fame with a smaller size
+		var restored = &HallOfFame{Size: 5, Store: store}
+		if err := restored.Restore(); err != nil {
+			t.Fatal(err)
+		}
+		var members = restored.Members()
+		if !reflect.DeepEqual(members, plain.Members()[:5]) {
+			t.Errorf("%T: expected the restored members %v, got %v", store, plain.Members()[:5], members)
+		}
+		if keys, _ := store.Keys(); len(keys) != 5 {
+			t.Errorf("%T: the members that weren't restored should be deleted, got %d keys", store, len(keys))
+		}
+		if restored.Err() != nil {
+			t.Error(restored.Err())
+		}
+		restored.reset()
+		if keys, _ := store.Keys(); len(keys) != 0 {
+			t.Errorf("%T: the store should be emptied", store)
+		}
+	}
+	if err := (&HallOfFame{Size: 1}).Restore(); err == nil {
+		t.Error("Restore should fail without a store")
+	}
+}
+
+func TestParetoArchiveStore(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(42))
+		store = &MemoryArchiveStore{}
+		plain = &ParetoArchive{Epsilon: 0.05}
+		arch  = &ParetoArchive{Epsilon: 0.05, Store: store}
+	)
+	for i := 0; i < 500; i++ {
+		var x = rng.Float64()
+		var indi = Individual{Genome: Genome{x, i}, Objectives: []float64{x, 1 - x*rng.Float64()}, Evaluated: true}
+		if plain.Add(indi) != arch.Add(indi) {
+			t.Fatal("The archive should behave as without a store")
+		}
+	}
+	if !reflect.DeepEqual(plain.Front(), arch.Front()) {
+		t.Errorf("Expected the front %v, got %v", plain.Front(), arch.Front())
+	}
+	if keys, _ := store.Keys(); len(keys) != arch.Len() {
+		t.Errorf("The store should only hold the %d archived individuals, got %d", arch.Len(), len(keys))
+	}
+	// An individual whose genome is already archived
+	if arch.Add(arch.Front()[0]) {
+		t.Error("An archived genome shouldn't be added again")
+	}
+	// Restore the front in a new archive
+	var restored = &ParetoArchive{Epsilon: 0.05, Store: store}
+	if err := restored.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(restored.Front(), plain.Front()) {
+		t.Errorf("Expected the restored front %v, got %v", plain.Front(), restored.Front())
+	}
+	// The clone keeps the individuals in memory
+	var clone = arch.clone()
+	if clone.Store != nil || !reflect.DeepEqual(clone.Front(), plain.Front()) {
+		t.Error("The clone should hold the archived individuals")
+	}
+}
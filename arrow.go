@@ -0,0 +1,606 @@
+package gago
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// An ArrowExporter writes snapshots of a GA as Apache Arrow IPC streams, the
+// columnar format used by pyarrow, pandas and Polars. A stream is read with
+// pyarrow.ipc.open_stream(f).read_pandas() or with polars.read_ipc_stream and
+// can be converted to Parquet by either of them. Export is meant to be called
+// after each generation, or at any interval, and appends a record batch to
+// Individuals and to Stats; either writer can be nil. The schema of each stream
+// is written by the first call and Close ends the streams. The tables have the
+// same columns as the ones of CSVExporter, the generations, ranks and other
+// counters are 64 bit integers and the fitnesses, objectives and statistics
+// are 64 bit floats. The type of each gene column is set by the first
+// snapshot: float64 and Vector genes give float columns, int genes give
+// integer columns, bool genes give boolean columns and the other genes give
+// string columns written with fmt.Sprint. Missing objectives and genes, as well
+// as genes whose type doesn't match the type of their column, are nulls.
+// Export returns an error if the GA has no individuals, for example if it
+// hasn't been initialized. An ArrowExporter has to be used through a pointer.
+type ArrowExporter struct {
+	Individuals  io.Writer
+	Stats        io.Writer
+	indis        []arrowColumn // Columns of the individuals table, nil until the schema is written
+	stats        []arrowColumn // Columns of the statistics table, nil until the schema is written
+	nbObjectives int
+}
+
+// The types of the Arrow columns.
+type arrowType byte
+
+const (
+	arrowInt64 arrowType = iota
+	arrowFloat64
+	arrowBool
+	arrowUtf8
+)
+
+// An arrowColumn holds the values of a column of a record batch, a nil value
+// is a null.
+type arrowColumn struct {
+	name   string
+	kind   arrowType
+	values []interface{}
+}
+
+// Return the type of the column that holds a gene.
+func geneType(gene interface{}) arrowType {
+	switch gene.(type) {
+	case float64:
+		return arrowFloat64
+	case int:
+		return arrowInt64
+	case bool:
+		return arrowBool
+	default:
+		return arrowUtf8
+	}
+}
+
+// Convert a value to the type of a column, nil is returned if the value
+// doesn't have the type of the column.
+func (kind arrowType) convert(value interface{}) interface{} {
+	switch x := value.(type) {
+	case nil:
+		return nil
+	case float64:
+		if kind == arrowFloat64 {
+			return x
+		}
+	case int:
+		if kind == arrowInt64 {
+			return int64(x)
+		}
+	case bool:
+		if kind == arrowBool {
+			return x
+		}
+	}
+	if kind == arrowUtf8 {
+		return fmt.Sprint(value)
+	}
+	return nil
+}
+
+// Return the genes of a genome, the values of a Vector are returned
+// separately.
+func geneValues(genome Genome) []interface{} {
+	if len(genome) == 1 {
+		if v, ok := genome[0].(Vector); ok {
+			var values = make([]interface{}, len(v))
+			for i, x := range v {
+				values[i] = x
+			}
+			return values
+		}
+	}
+	return genome
+}
+
+// Export writes a snapshot of a GA.
+func (exp *ArrowExporter) Export(ga *GA) error {
+	if exp.Individuals != nil {
+		if err := exp.exportIndividuals(ga); err != nil {
+			return err
+		}
+	}
+	if exp.Stats != nil {
+		if err := exp.exportStats(ga); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the end of the streams, the streams can be read without it but
+// some readers expect it.
+func (exp *ArrowExporter) Close() error {
+	for _, w := range []io.Writer{exp.Individuals, exp.Stats} {
+		if w == nil {
+			continue
+		}
+		if _, err := w.Write([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Append a record batch with a row per individual to the individuals stream.
+func (exp *ArrowExporter) exportIndividuals(ga *GA) error {
+	// Write the schema and fix the columns
+	if exp.indis == nil {
+		var first, err = firstIndividual(ga)
+		if err != nil {
+			return err
+		}
+		var columns = []arrowColumn{
+			{name: "generation", kind: arrowInt64},
+			{name: "population", kind: arrowInt64},
+			{name: "rank", kind: arrowInt64},
+			{name: "name", kind: arrowUtf8},
+			{name: "id", kind: arrowInt64},
+			{name: "birth", kind: arrowInt64},
+			{name: "origin", kind: arrowInt64},
+			{name: "fitness", kind: arrowFloat64},
+		}
+		for i := range first.Objectives {
+			columns = append(columns, arrowColumn{name: "objective_" + strconv.Itoa(i), kind: arrowFloat64})
+		}
+		for i, gene := range geneValues(first.Genome) {
+			columns = append(columns, arrowColumn{name: "gene_" + strconv.Itoa(i), kind: geneType(gene)})
+		}
+		if err := writeArrowSchema(exp.Individuals, columns); err != nil {
+			return err
+		}
+		exp.indis = columns
+		exp.nbObjectives = len(first.Objectives)
+	}
+	var (
+		columns      = exp.indis
+		nbObjectives = exp.nbObjectives
+	)
+	for i := range columns {
+		columns[i].values = columns[i].values[:0]
+	}
+	for p, pop := range ga.Populations {
+		for r, indi := range pop.Individuals {
+			var row = []interface{}{
+				int64(ga.Generations),
+				int64(p),
+				int64(r),
+				indi.Name,
+				int64(indi.ID),
+				int64(indi.Birth),
+				int64(indi.Origin),
+				indi.Fitness,
+			}
+			for i := 0; i < nbObjectives; i++ {
+				if i < len(indi.Objectives) {
+					row = append(row, indi.Objectives[i])
+				} else {
+					row = append(row, nil)
+				}
+			}
+			var genes = geneValues(indi.Genome)
+			for i := 0; i < len(columns)-8-nbObjectives; i++ {
+				if i < len(genes) {
+					row = append(row, columns[len(row)].kind.convert(genes[i]))
+				} else {
+					row = append(row, nil)
+				}
+			}
+			for i, value := range row {
+				columns[i].values = append(columns[i].values, value)
+			}
+		}
+	}
+	return writeArrowBatch(exp.Individuals, columns)
+}
+
+// Append a record batch with the statistics of the GA to the statistics
+// stream.
+func (exp *ArrowExporter) exportStats(ga *GA) error {
+	if exp.stats == nil {
+		var columns = []arrowColumn{
+			{name: "generation", kind: arrowInt64},
+			{name: "evaluations", kind: arrowInt64},
+			{name: "duration", kind: arrowFloat64},
+			{name: "best", kind: arrowFloat64},
+			{name: "mean", kind: arrowFloat64},
+			{name: "variance", kind: arrowFloat64},
+			{name: "front_size", kind: arrowInt64},
+			{name: "hypervolume", kind: arrowFloat64},
+			{name: "igd", kind: arrowFloat64},
+		}
+		if err := writeArrowSchema(exp.Stats, columns); err != nil {
+			return err
+		}
+		exp.stats = columns
+	}
+	var (
+		stats = ga.Stats()
+		row   = []interface{}{
+			int64(stats.Generations),
+			int64(stats.Evaluations),
+			stats.Duration.Seconds(),
+			stats.Best,
+			stats.Mean,
+			stats.Variance,
+			int64(stats.FrontSize),
+			stats.Hypervolume,
+			stats.IGD,
+		}
+	)
+	for i, value := range row {
+		exp.stats[i].values = append(exp.stats[i].values[:0], value)
+	}
+	return writeArrowBatch(exp.Stats, exp.stats)
+}
+
+// The identifiers used by the Arrow flatbuffers schema.
+const (
+	arrowV5              = 4 // MetadataVersion.V5
+	arrowSchemaHeader    = 1 // MessageHeader.Schema
+	arrowRecordBatch     = 3 // MessageHeader.RecordBatch
+	arrowTypeInt         = 2 // Type.Int
+	arrowTypeFloat       = 3 // Type.FloatingPoint
+	arrowTypeUtf8        = 5 // Type.Utf8
+	arrowTypeBool        = 6 // Type.Bool
+	arrowDoublePrecision = 2 // Precision.DOUBLE
+)
+
+// Write an encapsulated message: a continuation marker, the size of the
+// metadata, the metadata padded to 8 bytes and the body.
+func writeArrowMessage(w io.Writer, metadata, body []byte) error {
+	var (
+		size = (len(metadata) + 7) &^ 7
+		msg  = make([]byte, 8+size, 8+size+len(body))
+	)
+	binary.LittleEndian.PutUint32(msg, 0xffffffff)
+	binary.LittleEndian.PutUint32(msg[4:], uint32(size))
+	copy(msg[8:], metadata)
+	msg = append(msg, body...)
+	var _, err = w.Write(msg)
+	return err
+}
+
+// Build a Message table around a header and return the metadata.
+func arrowMessage(b *flatBuilder, headerType byte, header int, bodyLength int) []byte {
+	b.startTable(5)
+	b.addInt64(3, int64(bodyLength))
+	b.addOffsetField(2, header)
+	b.addInt16(0, arrowV5)
+	b.addUint8(1, headerType)
+	return b.finish(b.endTable())
+}
+
+// Write a Schema message describing columns.
+func writeArrowSchema(w io.Writer, columns []arrowColumn) error {
+	var (
+		b      = newFlatBuilder()
+		fields = make([]int, len(columns))
+	)
+	for i, col := range columns {
+		var (
+			name     = b.createString(col.name)
+			typeID   byte
+			typ      int
+			children = b.createOffsets(nil)
+		)
+		switch col.kind {
+		case arrowInt64:
+			typeID = arrowTypeInt
+			b.startTable(2)
+			b.addInt32(0, 64)
+			b.addBool(1, true)
+		case arrowFloat64:
+			typeID = arrowTypeFloat
+			b.startTable(1)
+			b.addInt16(0, arrowDoublePrecision)
+		case arrowBool:
+			typeID = arrowTypeBool
+			b.startTable(0)
+		case arrowUtf8:
+			typeID = arrowTypeUtf8
+			b.startTable(0)
+		}
+		typ = b.endTable()
+		b.startTable(7)
+		b.addOffsetField(0, name)
+		b.addOffsetField(3, typ)
+		b.addOffsetField(5, children)
+		b.addBool(1, true)
+		b.addUint8(2, typeID)
+		fields[i] = b.endTable()
+	}
+	var vector = b.createOffsets(fields)
+	b.startTable(4)
+	b.addOffsetField(1, vector)
+	var schema = b.endTable()
+	return writeArrowMessage(w, arrowMessage(b, arrowSchemaHeader, schema, 0), nil)
+}
+
+// The body of a record batch, each buffer is padded to 8 bytes.
+type arrowBody struct {
+	data    []byte
+	buffers [][2]int64 // Offset and length of each buffer
+}
+
+// Append a buffer to the body.
+func (body *arrowBody) add(buf []byte) {
+	body.buffers = append(body.buffers, [2]int64{int64(len(body.data)), int64(len(buf))})
+	body.data = append(body.data, buf...)
+	for len(body.data)%8 != 0 {
+		body.data = append(body.data, 0)
+	}
+}
+
+// Return a bitmap where the i-th bit is set if set(i) is true.
+func arrowBitmap(n int, set func(i int) bool) []byte {
+	var bitmap = make([]byte, (n+7)/8)
+	for i := 0; i < n; i++ {
+		if set(i) {
+			bitmap[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return bitmap
+}
+
+// Append the buffers of a column to a body and return the number of nulls.
+func (col arrowColumn) encode(body *arrowBody) int {
+	var (
+		n         = len(col.values)
+		nullCount int
+	)
+	for _, value := range col.values {
+		if value == nil {
+			nullCount++
+		}
+	}
+	// The validity bitmap can be omitted if there are no nulls
+	if nullCount > 0 {
+		body.add(arrowBitmap(n, func(i int) bool { return col.values[i] != nil }))
+	} else {
+		body.add(nil)
+	}
+	switch col.kind {
+	case arrowInt64, arrowFloat64:
+		var buf = make([]byte, 8*n)
+		for i, value := range col.values {
+			switch x := value.(type) {
+			case int64:
+				binary.LittleEndian.PutUint64(buf[8*i:], uint64(x))
+			case float64:
+				binary.LittleEndian.PutUint64(buf[8*i:], math.Float64bits(x))
+			}
+		}
+		body.add(buf)
+	case arrowBool:
+		body.add(arrowBitmap(n, func(i int) bool { return col.values[i] == true }))
+	case arrowUtf8:
+		var (
+			offsets = make([]byte, 4*(n+1))
+			data    []byte
+		)
+		for i, value := range col.values {
+			if s, ok := value.(string); ok {
+				data = append(data, s...)
+			}
+			binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(len(data)))
+		}
+		body.add(offsets)
+		body.add(data)
+	}
+	return nullCount
+}
+
+// Write a RecordBatch message with the values of columns, which all have the
+// same number of values.
+func writeArrowBatch(w io.Writer, columns []arrowColumn) error {
+	var (
+		body   arrowBody
+		nodes  = make([][2]int64, len(columns))
+		length int
+	)
+	if len(columns) > 0 {
+		length = len(columns[0].values)
+	}
+	for i, col := range columns {
+		nodes[i] = [2]int64{int64(len(col.values)), int64(col.encode(&body))}
+	}
+	var (
+		b       = newFlatBuilder()
+		fnodes  = b.createStructs(nodes)
+		buffers = b.createStructs(body.buffers)
+	)
+	b.startTable(5)
+	b.addInt64(0, int64(length))
+	b.addOffsetField(1, fnodes)
+	b.addOffsetField(2, buffers)
+	var batch = b.endTable()
+	return writeArrowMessage(w, arrowMessage(b, arrowRecordBatch, batch, len(body.data)), body.data)
+}
+
+// A flatBuilder builds a flatbuffer, the serialization format of the Arrow
+// metadata. As in the reference implementation the buffer is built from it's
+// end towards it's start, hence an object is written after the objects it
+// refers to and the references are forward offsets. The objects are identified
+// by their distance to the end of the buffer.
+type flatBuilder struct {
+	buf      []byte
+	head     int   // Position of the first byte that was written
+	minAlign int   // Largest alignment required by a value
+	fields   []int // Positions of the fields of the table being built, 0 if absent
+	start    int   // Position at which the table being built starts
+}
+
+// Return a new flatbuffer builder.
+func newFlatBuilder() *flatBuilder {
+	return &flatBuilder{buf: make([]byte, 256), head: 256, minAlign: 1}
+}
+
+// Return the number of bytes written so far.
+func (b *flatBuilder) offset() int {
+	return len(b.buf) - b.head
+}
+
+// Pad the buffer so that a value of size bytes written after additional bytes
+// is aligned, and make room for it.
+func (b *flatBuilder) prep(size, additional int) {
+	if size > b.minAlign {
+		b.minAlign = size
+	}
+	var padding = -(b.offset() + additional) & (size - 1)
+	for b.head < padding+size+additional {
+		var (
+			written = b.offset()
+			buf     = make([]byte, 2*len(b.buf))
+		)
+		copy(buf[len(buf)-written:], b.buf[b.head:])
+		b.buf, b.head = buf, len(buf)-written
+	}
+	for i := 0; i < padding; i++ {
+		b.head--
+		b.buf[b.head] = 0
+	}
+}
+
+func (b *flatBuilder) putUint8(x uint8) {
+	b.head--
+	b.buf[b.head] = x
+}
+
+func (b *flatBuilder) putUint16(x uint16) {
+	b.head -= 2
+	binary.LittleEndian.PutUint16(b.buf[b.head:], x)
+}
+
+func (b *flatBuilder) putUint32(x uint32) {
+	b.head -= 4
+	binary.LittleEndian.PutUint32(b.buf[b.head:], x)
+}
+
+func (b *flatBuilder) putUint64(x uint64) {
+	b.head -= 8
+	binary.LittleEndian.PutUint64(b.buf[b.head:], x)
+}
+
+// Write an offset to an object written before.
+func (b *flatBuilder) putOffset(object int) {
+	b.prep(4, 0)
+	b.putUint32(uint32(b.offset() + 4 - object))
+}
+
+// Write a string and return it's position.
+func (b *flatBuilder) createString(s string) int {
+	b.prep(4, len(s)+1)
+	b.putUint8(0)
+	b.head -= len(s)
+	copy(b.buf[b.head:], s)
+	b.putUint32(uint32(len(s)))
+	return b.offset()
+}
+
+// Write a vector of offsets to objects and return it's position.
+func (b *flatBuilder) createOffsets(objects []int) int {
+	b.prep(4, 4*len(objects))
+	for i := len(objects) - 1; i >= 0; i-- {
+		b.putOffset(objects[i])
+	}
+	b.putUint32(uint32(len(objects)))
+	return b.offset()
+}
+
+// Write a vector of structs made of two 64 bit integers, such as the FieldNode
+// and Buffer structs of Arrow, and return it's position.
+func (b *flatBuilder) createStructs(structs [][2]int64) int {
+	b.prep(8, 16*len(structs))
+	for i := len(structs) - 1; i >= 0; i-- {
+		b.putUint64(uint64(structs[i][1]))
+		b.putUint64(uint64(structs[i][0]))
+	}
+	b.putUint32(uint32(len(structs)))
+	return b.offset()
+}
+
+// Start a table with n fields, the objects the table refers to have to be
+// written beforehand.
+func (b *flatBuilder) startTable(n int) {
+	b.fields = make([]int, n)
+	b.start = b.offset()
+}
+
+func (b *flatBuilder) addUint8(field int, x uint8) {
+	b.prep(1, 0)
+	b.putUint8(x)
+	b.fields[field] = b.offset()
+}
+
+func (b *flatBuilder) addBool(field int, x bool) {
+	if x {
+		b.addUint8(field, 1)
+	} else {
+		b.addUint8(field, 0)
+	}
+}
+
+func (b *flatBuilder) addInt16(field int, x int16) {
+	b.prep(2, 0)
+	b.putUint16(uint16(x))
+	b.fields[field] = b.offset()
+}
+
+func (b *flatBuilder) addInt32(field int, x int32) {
+	b.prep(4, 0)
+	b.putUint32(uint32(x))
+	b.fields[field] = b.offset()
+}
+
+func (b *flatBuilder) addInt64(field int, x int64) {
+	b.prep(8, 0)
+	b.putUint64(uint64(x))
+	b.fields[field] = b.offset()
+}
+
+func (b *flatBuilder) addOffsetField(field int, object int) {
+	b.putOffset(object)
+	b.fields[field] = b.offset()
+}
+
+// End the table being built, write it's vtable and return it's position. The
+// vtable is written right before the table.
+func (b *flatBuilder) endTable() int {
+	// The table starts with the offset to it's vtable
+	b.prep(4, 0)
+	b.putUint32(0)
+	var table = b.offset()
+	b.prep(2, 2*(len(b.fields)+2))
+	for i := len(b.fields) - 1; i >= 0; i-- {
+		var position uint16
+		if b.fields[i] != 0 {
+			position = uint16(table - b.fields[i])
+		}
+		b.putUint16(position)
+	}
+	b.putUint16(uint16(table - b.start))
+	b.putUint16(uint16(2 * (len(b.fields) + 2)))
+	// The vtable is found by subtracting the offset from the table's position
+	binary.LittleEndian.PutUint32(b.buf[len(b.buf)-table:], uint32(b.offset()-table))
+	b.fields = nil
+	return table
+}
+
+// Write the offset to the root table and return the flatbuffer.
+func (b *flatBuilder) finish(root int) []byte {
+	b.prep(b.minAlign, 4)
+	b.putOffset(root)
+	return b.buf[b.head:]
+}
@@ -0,0 +1,288 @@
+package gago
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+)
+
+// A flatTable reads a flatbuffer table, it's the counterpart of flatBuilder.
+type flatTable struct {
+	buf []byte
+	pos int
+}
+
+func flatRoot(buf []byte) flatTable {
+	return flatTable{buf, int(binary.LittleEndian.Uint32(buf))}
+}
+
+// Return the position of a field, 0 if it's absent.
+func (t flatTable) field(i int) int {
+	var (
+		vtable = t.pos - int(int32(binary.LittleEndian.Uint32(t.buf[t.pos:])))
+		size   = int(binary.LittleEndian.Uint16(t.buf[vtable:]))
+	)
+	if 4+2*i >= size {
+		return 0
+	}
+	if off := int(binary.LittleEndian.Uint16(t.buf[vtable+4+2*i:])); off != 0 {
+		return t.pos + off
+	}
+	return 0
+}
+
+func (t flatTable) uint8(i int) uint8 {
+	if p := t.field(i); p != 0 {
+		return t.buf[p]
+	}
+	return 0
+}
+
+func (t flatTable) int16(i int) int16 {
+	if p := t.field(i); p != 0 {
+		return int16(binary.LittleEndian.Uint16(t.buf[p:]))
+	}
+	return 0
+}
+
+func (t flatTable) int64(i int) int64 {
+	if p := t.field(i); p != 0 {
+		return int64(binary.LittleEndian.Uint64(t.buf[p:]))
+	}
+	return 0
+}
+
+// Follow the offset stored in a field.
+func (t flatTable) deref(i int) int {
+	var p = t.field(i)
+	return p + int(binary.LittleEndian.Uint32(t.buf[p:]))
+}
+
+func (t flatTable) table(i int) flatTable {
+	return flatTable{t.buf, t.deref(i)}
+}
+
+func (t flatTable) string(i int) string {
+	var p = t.deref(i)
+	return string(t.buf[p+4 : p+4+int(binary.LittleEndian.Uint32(t.buf[p:]))])
+}
+
+// Return the length and the position of the first element of a vector.
+func (t flatTable) vector(i int) (int, int) {
+	var p = t.deref(i)
+	return int(binary.LittleEndian.Uint32(t.buf[p:])), p + 4
+}
+
+func (t flatTable) tables(i int) []flatTable {
+	var (
+		n, p   = t.vector(i)
+		tables = make([]flatTable, n)
+	)
+	for j := range tables {
+		var q = p + 4*j
+		tables[j] = flatTable{t.buf, q + int(binary.LittleEndian.Uint32(t.buf[q:]))}
+	}
+	return tables
+}
+
+func (t flatTable) structs(i int) [][2]int64 {
+	var (
+		n, p    = t.vector(i)
+		structs = make([][2]int64, n)
+	)
+	for j := range structs {
+		structs[j][0] = int64(binary.LittleEndian.Uint64(t.buf[p+16*j:]))
+		structs[j][1] = int64(binary.LittleEndian.Uint64(t.buf[p+16*j+8:]))
+	}
+	return structs
+}
+
+// Read the messages of an Arrow stream and return the columns of the schema
+// and the values of each record batch.
+func readArrowStream(t *testing.T, r io.Reader) ([]arrowColumn, [][]arrowColumn) {
+	var (
+		schema  []arrowColumn
+		batches [][]arrowColumn
+		header  = make([]byte, 8)
+	)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			t.Fatal("The stream wasn't closed:", err)
+		}
+		var size = int(binary.LittleEndian.Uint32(header[4:]))
+		if binary.LittleEndian.Uint32(header) != 0xffffffff || size%8 != 0 {
+			t.Fatalf("Wrong message prefix %v", header)
+		}
+		if size == 0 {
+			return schema, batches
+		}
+		var metadata = make([]byte, size)
+		io.ReadFull(r, metadata)
+		var msg = flatRoot(metadata)
+		if msg.int16(0) != arrowV5 {
+			t.Fatalf("Wrong metadata version %d", msg.int16(0))
+		}
+		var body = make([]byte, msg.int64(3))
+		io.ReadFull(r, body)
+		switch msg.uint8(1) {
+		case arrowSchemaHeader:
+			for _, field := range msg.table(2).tables(1) {
+				var col = arrowColumn{name: field.string(0)}
+				switch field.uint8(2) {
+				case arrowTypeInt:
+					col.kind = arrowInt64
+				case arrowTypeFloat:
+					col.kind = arrowFloat64
+					if field.table(3).int16(0) != arrowDoublePrecision {
+						t.Errorf("Column %s isn't a double", col.name)
+					}
+				case arrowTypeBool:
+					col.kind = arrowBool
+				case arrowTypeUtf8:
+					col.kind = arrowUtf8
+				default:
+					t.Fatalf("Unexpected type %d", field.uint8(2))
+				}
+				schema = append(schema, col)
+			}
+		case arrowRecordBatch:
+			var (
+				batch   = msg.table(2)
+				nodes   = batch.structs(1)
+				buffers = batch.structs(2)
+				columns = make([]arrowColumn, len(schema))
+				buffer  = func() []byte {
+					var b = buffers[0]
+					buffers = buffers[1:]
+					if b[0]%8 != 0 {
+						t.Errorf("Buffer at %d isn't aligned", b[0])
+					}
+					return body[b[0] : b[0]+b[1]]
+				}
+				bit = func(bitmap []byte, i int) bool { return bitmap[i/8]&(1<<uint(i%8)) != 0 }
+			)
+			for c, col := range schema {
+				var (
+					n        = int(nodes[c][0])
+					validity = buffer()
+					values   = buffer()
+				)
+				if int(batch.int64(0)) != n {
+					t.Errorf("Column %s has %d values instead of %d", col.name, n, batch.int64(0))
+				}
+				col.values = make([]interface{}, n)
+				for i := range col.values {
+					if len(validity) > 0 && !bit(validity, i) {
+						continue
+					}
+					switch col.kind {
+					case arrowInt64:
+						col.values[i] = int64(binary.LittleEndian.Uint64(values[8*i:]))
+					case arrowFloat64:
+						col.values[i] = math.Float64frombits(binary.LittleEndian.Uint64(values[8*i:]))
+					case arrowBool:
+						col.values[i] = bit(values, i)
+					}
+				}
+				if col.kind == arrowUtf8 {
+					var data = buffer()
+					for i := range col.values {
+						if len(validity) == 0 || bit(validity, i) {
+							var from, to = binary.LittleEndian.Uint32(values[4*i:]), binary.LittleEndian.Uint32(values[4*i+4:])
+							col.values[i] = string(data[from:to])
+						}
+					}
+				}
+				columns[c] = col
+			}
+			batches = append(batches, columns)
+		default:
+			t.Fatalf("Unexpected message type %d", msg.uint8(1))
+		}
+	}
+}
+
+func TestArrowExporter(t *testing.T) {
+	var (
+		g = GA{
+			NbrPopulations: 2,
+			NbrIndividuals: 5,
+			NbrGenes:       nbGenes,
+			Initializer:    initializer,
+			Ff:             ff,
+			Model:          model,
+		}
+		indis, stats bytes.Buffer
+		exp          = ArrowExporter{Individuals: &indis, Stats: &stats}
+	)
+	g.Initialize()
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			g.Enhance()
+		}
+		if err := exp.Export(&g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := exp.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var schema, batches = readArrowStream(t, &indis)
+	if len(schema) != 8+nbGenes || schema[3].name != "name" || schema[3].kind != arrowUtf8 ||
+		schema[8].name != "gene_0" || schema[8].kind != arrowFloat64 {
+		t.Errorf("Unexpected schema %v", schema)
+	}
+	if len(batches) != 3 {
+		t.Fatalf("Expected 3 record batches, got %d", len(batches))
+	}
+	var last = batches[2]
+	if len(last[0].values) != 10 || last[0].values[9] != int64(g.Generations) || last[1].values[9] != int64(1) || last[2].values[9] != int64(4) {
+		t.Errorf("Unexpected last batch %v", last)
+	}
+	var best = g.Populations[0].Individuals[0]
+	if last[3].values[0] != best.Name || last[7].values[0] != best.Fitness || last[8].values[0] != best.Genome[0] {
+		t.Error("The first individual wasn't exported")
+	}
+	schema, batches = readArrowStream(t, &stats)
+	if len(schema) != 9 || len(batches) != 3 || batches[2][0].values[0] != int64(g.Generations) {
+		t.Errorf("Unexpected statistics %v", batches)
+	}
+}
+
+func TestArrowExporterColumns(t *testing.T) {
+	var (
+		indis bytes.Buffer
+		exp   = ArrowExporter{Individuals: &indis}
+		g     = GA{Populations: Populations{{Individuals: Individuals{
+			{Genome: Genome{1, true, "a"}, Objectives: []float64{0.5}},
+			{Genome: Genome{2.5, false}},
+		}}}}
+	)
+	if err := exp.Export(&g); err != nil {
+		t.Fatal(err)
+	}
+	exp.Close()
+	var schema, batches = readArrowStream(t, &indis)
+	var kinds = []arrowType{arrowFloat64, arrowInt64, arrowBool, arrowUtf8}
+	for i, kind := range kinds {
+		if schema[8+i].kind != kind {
+			t.Errorf("Column %s has type %d instead of %d", schema[8+i].name, schema[8+i].kind, kind)
+		}
+	}
+	var (
+		columns  = batches[0]
+		expected = [][]interface{}{{0.5, nil}, {int64(1), nil}, {true, false}, {"a", nil}}
+	)
+	for i, values := range expected {
+		for j, value := range values {
+			if columns[8+i].values[j] != value {
+				t.Errorf("Expected %v in column %s, got %v", value, columns[8+i].name, columns[8+i].values[j])
+			}
+		}
+	}
+	if err := (&ArrowExporter{Individuals: &indis}).Export(&GA{}); err == nil {
+		t.Error("Exporting a GA without individuals should fail")
+	}
+}
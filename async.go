@@ -0,0 +1,141 @@
+package gago
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// AsyncSteadyState evolves the populations of a GA without synchronizing
+// generations, which is useful when the duration of an evaluation varies a lot
+// from one individual to another. Offsprings are produced through selection,
+// crossover and mutation and are dispatched to NbrWorkers goroutines that
+// evaluate them concurrently. As soon as an offspring has been evaluated it
+// replaces the worst individual of the population it was bred in, if it is
+// fitter, and a new offspring is dispatched. Hence the workers are never idle
+// waiting for the slowest evaluation of a generation. The populations are
+// taken in turn to breed the offsprings.
+type AsyncSteadyState struct {
+	Selector   Selector
+	Crossover  Crossover
+	Mutator    Mutator
+	MutRate    float64
+	NbrWorkers int
+}
+
+// An offspring waiting to be evaluated along with the index of the population
+// it belongs to and the order in which it was dispatched.
+type asyncJob struct {
+	pop  int
+	seq  int
+	indi Individual
+}
+
+// Breed an offspring in the i-th population of a GA.
+func (ass AsyncSteadyState) breed(ga *GA, i int) asyncJob {
+	var (
+		pop        = &ga.Populations[i]
+		parents, _ = ass.Selector.Apply(2, pop.Individuals, pop.rng)
+		o, _       = ass.Crossover.Apply(parents[0], parents[1], pop.rng)
+	)
+	if ass.Mutator != nil && pop.rng.Float64() < ass.MutRate {
+		o.Mutate(ass.Mutator, pop.rng)
+	}
+	return asyncJob{pop: i, indi: o}
+}
+
+// Apply asynchronous steady-state evolution to an initialized GA until
+// nbOffsprings offsprings have been integrated into the populations.
+func (ass AsyncSteadyState) Apply(ga *GA, nbOffsprings int) {
+	var (
+		start   = time.Now()
+		jobs    = make(chan asyncJob)
+		results = make(chan asyncJob)
+	)
+	// Start the workers, each one evaluates offsprings until the jobs channel
+	// is closed
+	for w := 0; w < ass.NbrWorkers; w++ {
+		go func() {
+			for job := range jobs {
+				job.indi.Evaluate(ga.Populations[job.pop].ff)
+				results <- job
+			}
+		}()
+	}
+	// Dispatch one offspring per worker, the populations are only modified by
+	// the current goroutine hence they don't have to be locked
+	var (
+		dispatched, integrated, next int
+		dispatch                     = func() {
+			var job = ass.breed(ga, next)
+			job.seq = dispatched
+			jobs <- job
+			next = (next + 1) % len(ga.Populations)
+			dispatched++
+		}
+		// The offsprings are integrated in the recorded order when an event
+		// log is replayed
+		pending = make(map[int]asyncJob)
+		done    = make(map[int]bool)
+	)
+	for dispatched < min(ass.NbrWorkers, nbOffsprings) {
+		dispatch()
+	}
+	for integrated < nbOffsprings {
+		var job asyncJob
+		if ga.EventLog != nil {
+			job = ga.EventLog.receive(results, pending, dispatched, done)
+			done[job.seq] = true
+		} else {
+			job = <-results
+		}
+		var (
+			indis = ga.Populations[job.pop].Individuals
+			worst = 0
+		)
+		integrated++
+		for i := range indis {
+			if less(ga.Comparator, indis[worst], indis[i]) {
+				worst = i
+			}
+		}
+		ga.stampIndividual(&job.indi, job.pop)
+		if less(ga.Comparator, job.indi, indis[worst]) {
+			indis[worst] = job.indi
+		}
+		if less(ga.Comparator, job.indi, ga.Best()) {
+			ga.setBest(job.indi)
+			ga.Stagnation = 0
+		}
+		if dispatched < nbOffsprings {
+			dispatch()
+		}
+	}
+	close(jobs)
+	for i := range ga.Populations {
+		ga.Populations[i].Individuals.SortWith(ga.Comparator)
+	}
+	ga.Evaluations = int(atomic.LoadInt64(ga.evaluations))
+	ga.Duration += time.Since(start)
+}
+
+// Validate the parameters to verify they are coherent.
+func (ass AsyncSteadyState) Validate() error {
+	// Check the selection method presence
+	if ass.Selector == nil {
+		return errors.New("'Selector' cannot be nil")
+	}
+	// Check the crossover method presence
+	if ass.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if ass.Mutator != nil && (ass.MutRate < 0 || ass.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	// Check the number of workers
+	if ass.NbrWorkers < 1 {
+		return errors.New("'NbrWorkers' should be higher or equal to 1")
+	}
+	return nil
+}
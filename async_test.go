@@ -0,0 +1,51 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestAsyncSteadyState(t *testing.T) {
+	var (
+		g = GA{
+			NbrPopulations: 2,
+			NbrIndividuals: 10,
+			NbrGenes:       2,
+			Initializer:    initializer,
+			// The duration of an evaluation varies from one individual to another
+			Ff: Float64Function{func(X []float64) float64 {
+				time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+				return X[0]*X[0] + X[1]*X[1]
+			}},
+			Model: model,
+		}
+		ass = AsyncSteadyState{
+			Selector:   SelTournament{NbParticipants: 3},
+			Crossover:  CrossUniformF{},
+			Mutator:    MutNormalF{0.5, 1},
+			MutRate:    0.5,
+			NbrWorkers: 4,
+		}
+	)
+	if err := ass.Validate(); err != nil {
+		t.Error(err)
+	}
+	g.Initialize()
+	var initial = g.Best().Fitness
+	ass.Apply(&g, 100)
+	if g.Evaluations > 20+100 {
+		t.Error("More offsprings were evaluated than requested")
+	}
+	if g.Best().Fitness > initial {
+		t.Error("The best individual got worse")
+	}
+	for _, pop := range g.Populations {
+		if len(pop.Individuals) != 10 {
+			t.Error("The size of a population was modified")
+		}
+		if pop.Individuals[0].Fitness < g.Best().Fitness {
+			t.Error("The best individual wasn't updated")
+		}
+	}
+}
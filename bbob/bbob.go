@@ -0,0 +1,263 @@
+// Package bbob provides functions of the noiseless BBOB suite of the COCO
+// platform through gago's fitness functions, so that the results of a
+// configuration can be compared with the published literature. The functions
+// follow the definitions of the suite but the instances don't match COCO's:
+// they are drawn by gago's generator, hence the optimums and the rotations of
+// an instance differ from the ones of the COCO instance with the same number.
+// Only the measures that depend on the distance to the optimal value, such as
+// the number of evaluations needed to reach a target precision, can be
+// compared with the literature.
+//
+// A Problem is identified by the number of it's function in the suite, it's
+// dimension and an instance number. The instances of a function differ by the
+// position of the optimum, by the optimal value and by the rotations of the
+// search space, they are drawn from a random number generator seeded with the
+// function, the dimension and the instance, hence an instance is the same in
+// every run. The functions have the same transformations of the search space
+// and the same boundary penalties as in the suite. The search domain is
+// [-5, 5] in every dimension, as in the suite.
+//
+// The implemented functions are the following:
+//
+//	1  sphere
+//	2  separable ellipsoid
+//	3  separable Rastrigin
+//	8  Rosenbrock, from dimension 2 on
+//	10 ellipsoid
+//	12 bent cigar
+//	15 Rastrigin
+package bbob
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/MaxHalford/gago"
+)
+
+// Lower and Upper are the bounds of the search domain.
+const (
+	Lower = -5
+	Upper = 5
+)
+
+// Functions lists the numbers of the implemented functions.
+var Functions = []int{1, 2, 3, 8, 10, 12, 15}
+
+// A Problem is an instance of a function of the BBOB suite in a given
+// dimension. It's created with New.
+type Problem struct {
+	Function  int
+	Dimension int
+	Instance  int
+	Xopt      []float64 // Position of the optimum
+	Fopt      float64   // Optimal value
+	r, q      [][]float64
+}
+
+// New returns the instance of a function of the BBOB suite in a given
+// dimension.
+func New(function, dimension, instance int) (Problem, error) {
+	// Check the dimension
+	if dimension < 1 {
+		return Problem{}, errors.New("'dimension' should be higher or equal to 1")
+	}
+	// Check the function
+	var implemented = false
+	for _, f := range Functions {
+		implemented = implemented || f == function
+	}
+	if !implemented {
+		return Problem{}, fmt.Errorf("function %d isn't implemented", function)
+	}
+	// The Rosenbrock function is constant in dimension 1
+	if function == 8 && dimension < 2 {
+		return Problem{}, errors.New("the Rosenbrock function requires a dimension of at least 2")
+	}
+	var (
+		rng = rand.New(rand.NewSource(int64(function)*1000003 + int64(instance)*1009 + int64(dimension)))
+		p   = Problem{
+			Function:  function,
+			Dimension: dimension,
+			Instance:  instance,
+			Xopt:      gago.RandomShift(dimension, -4, 4, rng),
+		}
+	)
+	// The optimal value is drawn from a Cauchy distribution, rounded to the
+	// second decimal and clipped to [-1000, 1000]
+	var fopt = math.Round(100*math.Tan(math.Pi*(rng.Float64()-0.5))) / 100
+	p.Fopt = math.Max(-1000, math.Min(1000, fopt))
+	// The optimum of the Rosenbrock function is brought closer to the center
+	if function == 8 {
+		for i := range p.Xopt {
+			p.Xopt[i] *= 0.75
+		}
+	}
+	p.r = gago.RandomRotation(dimension, rng)
+	p.q = gago.RandomRotation(dimension, rng)
+	return p, nil
+}
+
+// Return i/(n-1), or 0 if n is 1, which scales the coordinates along the
+// dimensions.
+func ratio(i, n int) float64 {
+	if n < 2 {
+		return 0
+	}
+	return float64(i) / float64(n-1)
+}
+
+// Rotate a vector.
+func rotate(m [][]float64, x []float64) []float64 {
+	var y = make([]float64, len(m))
+	for i, row := range m {
+		for j, v := range row {
+			y[i] += v * x[j]
+		}
+	}
+	return y
+}
+
+// Apply the oscillation transformation T_osz, which introduces small and
+// smooth irregularities.
+func oscillate(x []float64) []float64 {
+	var y = make([]float64, len(x))
+	for i, xi := range x {
+		if xi == 0 {
+			continue
+		}
+		var (
+			h      = math.Log(math.Abs(xi))
+			c1, c2 = 5.5, 3.1
+		)
+		if xi > 0 {
+			c1, c2 = 10, 7.9
+		}
+		y[i] = math.Copysign(math.Exp(h+0.049*(math.Sin(c1*h)+math.Sin(c2*h))), xi)
+	}
+	return y
+}
+
+// Apply the asymmetric transformation T_asy with parameter beta, which breaks
+// the symmetry of the positive coordinates.
+func asymmetrize(x []float64, beta float64) []float64 {
+	var y = make([]float64, len(x))
+	for i, xi := range x {
+		y[i] = xi
+		if xi > 0 {
+			y[i] = math.Pow(xi, 1+beta*ratio(i, len(x))*math.Sqrt(xi))
+		}
+	}
+	return y
+}
+
+// Scale the coordinates by the diagonal matrix Lambda^alpha.
+func condition(x []float64, alpha float64) []float64 {
+	var y = make([]float64, len(x))
+	for i, xi := range x {
+		y[i] = xi * math.Pow(alpha, 0.5*ratio(i, len(x)))
+	}
+	return y
+}
+
+// The ellipsoid function with a conditioning of 10^6.
+func ellipsoid(z []float64) float64 {
+	var sum float64
+	for i, zi := range z {
+		sum += math.Pow(10, 6*ratio(i, len(z))) * zi * zi
+	}
+	return sum
+}
+
+// The Rastrigin function.
+func rastrigin(z []float64) float64 {
+	var sum float64
+	for _, zi := range z {
+		sum += zi*zi - 10*math.Cos(2*math.Pi*zi)
+	}
+	return 10*float64(len(z)) + sum
+}
+
+// The boundary penalty f_pen, which sums the squared distances of the
+// coordinates to the search domain.
+func penalty(x []float64) float64 {
+	var sum float64
+	for _, xi := range x {
+		if d := math.Abs(xi) - Upper; d > 0 {
+			sum += d * d
+		}
+	}
+	return sum
+}
+
+// Evaluate returns the value of the problem's function at x, the optimal
+// value being Fopt at Xopt.
+func (p Problem) Evaluate(x []float64) float64 {
+	var z = make([]float64, len(x))
+	for i := range x {
+		z[i] = x[i] - p.Xopt[i]
+	}
+	var f float64
+	switch p.Function {
+	case 1:
+		for _, zi := range z {
+			f += zi * zi
+		}
+	case 2:
+		f = ellipsoid(oscillate(z))
+	case 3:
+		f = rastrigin(condition(asymmetrize(oscillate(z), 0.2), 10)) + 100*penalty(x)
+	case 8:
+		var scale = math.Max(1, math.Sqrt(float64(len(z)))/8)
+		for i := range z {
+			z[i] = scale*z[i] + 1
+		}
+		for i := 0; i < len(z)-1; i++ {
+			f += 100*math.Pow(z[i]*z[i]-z[i+1], 2) + math.Pow(z[i]-1, 2)
+		}
+	case 10:
+		f = ellipsoid(oscillate(rotate(p.r, z)))
+	case 12:
+		z = rotate(p.r, asymmetrize(rotate(p.r, z), 0.5))
+		f = z[0] * z[0]
+		for _, zi := range z[1:] {
+			f += 1e6 * zi * zi
+		}
+	case 15:
+		z = asymmetrize(oscillate(rotate(p.r, z)), 0.2)
+		f = rastrigin(rotate(p.r, condition(rotate(p.q, z), 10)))
+	}
+	return f + p.Fopt
+}
+
+// FitnessFunction returns the problem as a fitness function of floating point
+// genes.
+func (p Problem) FitnessFunction() gago.FitnessFunction {
+	return gago.Float64Function{Image: p.Evaluate}
+}
+
+// GA returns a GA for the problem built by gago.NewFloatGA on the search
+// domain, which is a reasonable baseline.
+func (p Problem) GA() gago.GA {
+	return gago.NewFloatGA(p.Dimension, Lower, Upper, p.Evaluate)
+}
+
+// Target returns the value under which the problem is solved with a given
+// precision, which is Fopt plus the precision. The suite usually uses
+// precisions from 1e2 down to 1e-8.
+func (p Problem) Target(precision float64) float64 {
+	return p.Fopt + precision
+}
+
+// Solved returns a success criterion for an Experiment that is met once the
+// best fitness reaches the target of a given precision. Together with the
+// StopOnSuccess field of the Experiment the runs stop at the target, hence the
+// expected running time of the experiment is comparable with the literature.
+func (p Problem) Solved(precision float64) func(gago.Stats) bool {
+	var target = p.Target(precision)
+	return func(stats gago.Stats) bool {
+		return stats.Best <= target
+	}
+}
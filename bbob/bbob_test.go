@@ -0,0 +1,111 @@
+package bbob
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MaxHalford/gago"
+)
+
+func TestOptimum(t *testing.T) {
+	for _, function := range Functions {
+		for _, dimension := range []int{1, 2, 10} {
+			var p, err = New(function, dimension, 1)
+			if function == 8 && dimension == 1 {
+				if err == nil {
+					t.Error("The Rosenbrock function should be rejected in dimension 1")
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f := p.Evaluate(p.Xopt); math.Abs(f-p.Fopt) > 1e-9 {
+				t.Errorf("Function %d in dimension %d: expected %f at the optimum, got %f", function, dimension, p.Fopt, f)
+			}
+			// Any other point is worse
+			var x = make([]float64, dimension)
+			for i := range x {
+				x[i] = p.Xopt[i] + 0.5
+			}
+			if f := p.Evaluate(x); f <= p.Fopt {
+				t.Errorf("Function %d in dimension %d: expected a value above %f, got %f", function, dimension, p.Fopt, f)
+			}
+			for _, xi := range p.Xopt {
+				if xi < -4 || xi > 4 {
+					t.Errorf("Function %d: the optimum %v is out of bounds", function, p.Xopt)
+				}
+			}
+		}
+	}
+}
+
+func TestInstances(t *testing.T) {
+	var (
+		a, _ = New(15, 5, 1)
+		b, _ = New(15, 5, 1)
+		c, _ = New(15, 5, 2)
+		x    = []float64{1, 2, 3, 4, 5}
+	)
+	if a.Evaluate(x) != b.Evaluate(x) {
+		t.Error("An instance should be the same every time")
+	}
+	if a.Evaluate(x) == c.Evaluate(x) || a.Fopt == c.Fopt {
+		t.Error("Different instances should differ")
+	}
+	if _, err := New(4, 5, 1); err == nil {
+		t.Error("An unimplemented function should be rejected")
+	}
+	if _, err := New(1, 0, 1); err == nil {
+		t.Error("A dimension of 0 should be rejected")
+	}
+}
+
+func TestSolved(t *testing.T) {
+	var p, _ = New(1, 2, 1)
+	var exp = gago.Experiment{
+		NewGA:          p.GA,
+		Runs:           3,
+		Seed:           42,
+		MaxGenerations: 1000,
+		Success:        p.Solved(1e-2),
+		StopOnSuccess:  true,
+	}
+	var result, err = exp.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SuccessRate != 1 {
+		t.Errorf("Expected every run to reach the target, got a success rate of %f", result.SuccessRate)
+	}
+	for _, stats := range result.Stats {
+		if stats.Generations == 1000 {
+			t.Error("The runs should stop at the target")
+		}
+	}
+	if result.ERT <= 0 || math.IsInf(result.ERT, 1) {
+		t.Errorf("Expected a finite expected running time, got %f", result.ERT)
+	}
+}
+
+func TestPenalty(t *testing.T) {
+	if f := penalty([]float64{-7, 5, 6, 0}); f != 5 {
+		t.Errorf("Expected a penalty of 5, got %f", f)
+	}
+	// The separable Rastrigin function is penalized outside of the domain
+	var (
+		p, _   = New(3, 2, 1)
+		inside = []float64{5, p.Xopt[1]}
+		out    = []float64{6, p.Xopt[1]}
+		f      = func(x []float64) float64 {
+			var z = []float64{x[0] - p.Xopt[0], x[1] - p.Xopt[1]}
+			return rastrigin(condition(asymmetrize(oscillate(z), 0.2), 10)) + p.Fopt
+		}
+	)
+	if p.Evaluate(inside) != f(inside) {
+		t.Error("The function shouldn't be penalized within the domain")
+	}
+	if math.Abs(p.Evaluate(out)-f(out)-100) > 1e-9 {
+		t.Errorf("Expected a penalty of 100, got %f", p.Evaluate(out)-f(out))
+	}
+}
@@ -0,0 +1,88 @@
+package gago
+
+import (
+	"fmt"
+	"math"
+)
+
+// Binary genomes are made of bool genes. A Gray code is a binary encoding
+// where two successive integers only differ by one bit, hence flipping a bit of
+// a Gray coded genome produces a small change in the decoded value, which isn't
+// the case with a regular binary encoding.
+
+// GrayEncode converts an integer to it's Gray code.
+func GrayEncode(n uint64) uint64 {
+	return n ^ (n >> 1)
+}
+
+// GrayDecode converts a Gray code back to the integer it represents.
+func GrayDecode(g uint64) uint64 {
+	var n = g
+	for shift := uint(1); shift < 64; shift <<= 1 {
+		n ^= n >> shift
+	}
+	return n
+}
+
+// Convert a slice of bits, most significant bit first, to an integer.
+func bitsToUint(bits []bool) uint64 {
+	var n uint64
+	for _, bit := range bits {
+		n <<= 1
+		if bit {
+			n |= 1
+		}
+	}
+	return n
+}
+
+// Convert an integer to a slice of n bits, most significant bit first.
+func uintToBits(x uint64, n int) []bool {
+	var bits = make([]bool, n)
+	for i := n - 1; i >= 0; i-- {
+		bits[i] = x&1 == 1
+		x >>= 1
+	}
+	return bits
+}
+
+// Check a number of bits can be held by the uint64 of a Gray code.
+func checkGrayBits(n int) error {
+	if n < 1 || n > 64 {
+		return fmt.Errorf("the number of bits should belong to [1, 64], got %d", n)
+	}
+	return nil
+}
+
+// DecodeGrayF maps a Gray coded slice of bits to a floating point number in
+// the [lower, upper] range. The range is divided into 2^n - 1 equal steps
+// where n is the number of bits, which should belong to [1, 64]. DecodeGrayF
+// panics otherwise.
+func DecodeGrayF(bits []bool, lower, upper float64) float64 {
+	if err := checkGrayBits(len(bits)); err != nil {
+		panic(err)
+	}
+	var max = math.Pow(2, float64(len(bits))) - 1
+	return lower + float64(GrayDecode(bitsToUint(bits)))/max*(upper-lower)
+}
+
+// DecodeGrayI maps a Gray coded slice of bits to an integer in the [lower,
+// upper] range. The decoded value is scaled to the range and then rounded.
+func DecodeGrayI(bits []bool, lower, upper int) int {
+	return int(math.Floor(DecodeGrayF(bits, float64(lower), float64(upper)) + 0.5))
+}
+
+// EncodeGrayF maps a floating point number in the [lower, upper] range to a
+// Gray coded slice of n bits. It is the inverse of DecodeGrayF up to the
+// precision allowed by n bits, n should belong to [1, 64].
+func EncodeGrayF(x, lower, upper float64, n int) []bool {
+	if err := checkGrayBits(n); err != nil {
+		panic(err)
+	}
+	var (
+		max  = math.Pow(2, float64(n)) - 1
+		step = math.Floor((x-lower)/(upper-lower)*max + 0.5)
+	)
+	step = math.Max(0, math.Min(max, step))
+	return uintToBits(GrayEncode(uint64(step)), n)
+}
@@ -0,0 +1,51 @@
+package gago
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGrayCode(t *testing.T) {
+	for n := uint64(0); n < 1000; n++ {
+		// Check the encoding can be reversed
+		if GrayDecode(GrayEncode(n)) != n {
+			t.Error("GrayDecode isn't the inverse of GrayEncode")
+		}
+		// Check successive integers differ by one bit
+		var diff = GrayEncode(n) ^ GrayEncode(n+1)
+		if diff&(diff-1) != 0 {
+			t.Error("Successive Gray codes differ by more than one bit")
+		}
+	}
+}
+
+func TestGrayF(t *testing.T) {
+	var (
+		lower = -5.0
+		upper = 5.0
+		n     = 16
+		step  = (upper - lower) / (math.Pow(2, float64(n)) - 1)
+	)
+	for _, x := range []float64{-5, -1.3, 0, 2.7, 5} {
+		var decoded = DecodeGrayF(EncodeGrayF(x, lower, upper, n), lower, upper)
+		if math.Abs(decoded-x) > step {
+			t.Error("DecodeGrayF isn't the inverse of EncodeGrayF")
+		}
+	}
+	// Check the bounds are reached
+	if DecodeGrayF([]bool{false, false, false}, lower, upper) != lower {
+		t.Error("DecodeGrayF didn't map zero to the lower bound")
+	}
+	if DecodeGrayF(uintToBits(GrayEncode(7), 3), lower, upper) != upper {
+		t.Error("DecodeGrayF didn't map the highest code to the upper bound")
+	}
+}
+
+func TestGrayI(t *testing.T) {
+	for x := 0; x < 8; x++ {
+		var bits = uintToBits(GrayEncode(uint64(x)), 3)
+		if DecodeGrayI(bits, 10, 17) != 10+x {
+			t.Error("DecodeGrayI didn't work as expected")
+		}
+	}
+}
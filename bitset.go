@@ -0,0 +1,215 @@
+package gago
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+)
+
+// A Bitset packs a binary genome into 64 bit words, which is a lot faster and
+// smaller than a genome made of one bool gene per bit. A packed genome holds a
+// single gene which is a Bitset, the operators that are suffixed with Bitset
+// work on such genomes. Words are shared between individuals, hence the
+// operators never modify the words of an existing Bitset but create new ones.
+// The bits beyond N are always 0.
+type Bitset struct {
+	N     int
+	Words []uint64
+}
+
+// NewBitset returns a Bitset of n bits set to 0.
+func NewBitset(n int) Bitset {
+	return Bitset{N: n, Words: make([]uint64, (n+63)/64)}
+}
+
+// BitsetOf returns the Bitset contained in an individual's genome.
+func BitsetOf(indi Individual) Bitset {
+	return indi.Genome[0].(Bitset)
+}
+
+// Mask of the bits of the last word that belong to the Bitset.
+func (b Bitset) lastMask() uint64 {
+	if r := uint(b.N % 64); r != 0 {
+		return 1<<r - 1
+	}
+	return math.MaxUint64
+}
+
+// Get returns the value of bit i.
+func (b Bitset) Get(i int) bool {
+	return b.Words[i/64]&(1<<uint(i%64)) != 0
+}
+
+// Set sets the value of bit i. The words are modified in place, hence Set
+// should only be called on a Bitset that isn't shared, for example a copy.
+func (b Bitset) Set(i int, v bool) {
+	if v {
+		b.Words[i/64] |= 1 << uint(i%64)
+	} else {
+		b.Words[i/64] &^= 1 << uint(i%64)
+	}
+}
+
+// Copy returns a Bitset that doesn't share it's words with the original.
+func (b Bitset) Copy() Bitset {
+	var c = Bitset{N: b.N, Words: make([]uint64, len(b.Words))}
+	copy(c.Words, b.Words)
+	return c
+}
+
+// OnesCount returns the number of bits set to 1, for example to compute the
+// OneMax function.
+func (b Bitset) OnesCount() int {
+	var n int
+	for _, w := range b.Words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Distance returns the number of bits at which two Bitsets differ.
+func (b Bitset) Distance(c Bitset) int {
+	var n int
+	for i, w := range b.Words {
+		n += bits.OnesCount64(w ^ c.Words[i])
+	}
+	return n
+}
+
+// Bools unpacks the Bitset into a slice of bools.
+func (b Bitset) Bools() []bool {
+	var bools = make([]bool, b.N)
+	for i := range bools {
+		bools[i] = b.Get(i)
+	}
+	return bools
+}
+
+// BitsetFunction is for functions that take a packed binary genome as input.
+type BitsetFunction struct {
+	Image func(Bitset) float64
+}
+
+// Apply the fitness function wrapped in BitsetFunction.
+func (ff BitsetFunction) apply(genome Genome) float64 {
+	return ff.Image(genome[0].(Bitset))
+}
+
+// DistBitset counts the number of bits at which two packed binary genomes
+// differ.
+type DistBitset struct{}
+
+// Apply the Bitset Hamming distance.
+func (dist DistBitset) Apply(a, b Individual) float64 {
+	return float64(BitsetOf(a).Distance(BitsetOf(b)))
+}
+
+// InitBitset generates packed binary genomes of N random bits, the genomes
+// should contain a single gene.
+type InitBitset struct {
+	N int
+}
+
+// Apply the InitBitset initializer.
+func (init InitBitset) Apply(indi *Individual, rng *rand.Rand) {
+	var b = NewBitset(init.N)
+	for i := range b.Words {
+		b.Words[i] = rng.Uint64()
+	}
+	if len(b.Words) > 0 {
+		b.Words[len(b.Words)-1] &= b.lastMask()
+	}
+	indi.Genome[0] = b
+}
+
+// Make an offspring containing a Bitset.
+func makeBitsetIndividual(b Bitset, rng *rand.Rand) Individual {
+	var indi = makeIndividual(1, rng)
+	indi.Genome[0] = b
+	return indi
+}
+
+// Mix two words, the bits set in the mask are taken from a and the others are
+// taken from b.
+func mix(a, b, mask uint64) uint64 {
+	return a&mask | b&^mask
+}
+
+// CrossUniformBitset exchanges each bit of two packed binary genomes with
+// probability 0.5, a whole word is processed at once with a random mask.
+type CrossUniformBitset struct{}
+
+// Apply uniform crossover to packed binary genomes.
+func (cross CrossUniformBitset) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var (
+		b1 = BitsetOf(p1)
+		b2 = BitsetOf(p2)
+		o1 = NewBitset(b1.N)
+		o2 = NewBitset(b1.N)
+	)
+	for i := range b1.Words {
+		var mask = rng.Uint64()
+		o1.Words[i] = mix(b1.Words[i], b2.Words[i], mask)
+		o2.Words[i] = mix(b2.Words[i], b1.Words[i], mask)
+	}
+	return makeBitsetIndividual(o1, rng), makeBitsetIndividual(o2, rng)
+}
+
+// CrossPointBitset exchanges the bits of two packed binary genomes that come
+// after a random crossover point.
+type CrossPointBitset struct{}
+
+// Apply one point crossover to packed binary genomes.
+func (cross CrossPointBitset) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var (
+		b1 = BitsetOf(p1)
+		b2 = BitsetOf(p2)
+		o1 = NewBitset(b1.N)
+		o2 = NewBitset(b1.N)
+		p  = rng.Intn(b1.N + 1)
+	)
+	for i := range b1.Words {
+		var mask uint64
+		switch {
+		case (i+1)*64 <= p:
+			mask = math.MaxUint64
+		case i*64 < p:
+			mask = 1<<uint(p-i*64) - 1
+		}
+		o1.Words[i] = mix(b1.Words[i], b2.Words[i], mask)
+		o2.Words[i] = mix(b2.Words[i], b1.Words[i], mask)
+	}
+	return makeBitsetIndividual(o1, rng), makeBitsetIndividual(o2, rng)
+}
+
+// MutFlipBitset flips each bit of a packed binary genome with probability
+// Rate. Instead of drawing a random number for each bit, the gaps between
+// flipped bits are drawn from a geometric distribution and the flips are
+// applied with XOR masks.
+type MutFlipBitset struct {
+	Rate float64
+}
+
+// Apply flip mutation to a packed binary genome.
+func (mut MutFlipBitset) Apply(indi *Individual, rng *rand.Rand) {
+	if mut.Rate <= 0 {
+		return
+	}
+	var (
+		b    = BitsetOf(*indi).Copy()
+		logq = math.Log1p(-mut.Rate)
+	)
+	for i := -1; ; {
+		// Number of bits to skip before the next flip
+		if mut.Rate < 1 {
+			i += geometricSkip(logq, b.N, rng) + 1
+		} else {
+			i++
+		}
+		if i >= b.N {
+			break
+		}
+		b.Words[i/64] ^= 1 << uint(i%64)
+	}
+	indi.Genome[0] = b
+}
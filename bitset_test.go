@@ -0,0 +1,178 @@
+package gago
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestBitsetGetSet(t *testing.T) {
+	var b = NewBitset(100)
+	if len(b.Words) != 2 {
+		t.Errorf("Expected 2 words, got %d", len(b.Words))
+	}
+	for _, i := range []int{0, 63, 64, 99} {
+		b.Set(i, true)
+		if !b.Get(i) {
+			t.Errorf("Bit %d wasn't set", i)
+		}
+	}
+	if b.OnesCount() != 4 {
+		t.Errorf("Expected 4 ones, got %d", b.OnesCount())
+	}
+	b.Set(63, false)
+	if b.Get(63) || b.OnesCount() != 3 {
+		t.Error("Bit 63 wasn't unset")
+	}
+	var c = b.Copy()
+	c.Set(1, true)
+	if b.Get(1) {
+		t.Error("Modifying a copy modified the original")
+	}
+	if b.Distance(c) != 1 || (DistBitset{}).Apply(Individual{Genome: Genome{b}}, Individual{Genome: Genome{c}}) != 1 {
+		t.Error("Wrong distance between Bitsets")
+	}
+	var bools = b.Bools()
+	if len(bools) != 100 || !bools[0] || bools[1] || !bools[99] {
+		t.Error("Bools didn't unpack the Bitset")
+	}
+}
+
+// Check the bits beyond N are 0.
+func checkPadding(t *testing.T, b Bitset) {
+	if b.Words[len(b.Words)-1]&^b.lastMask() != 0 {
+		t.Error("The bits beyond N should be 0")
+	}
+}
+
+func TestBitsetOperators(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		p1   = makeIndividual(1, rng)
+		p2   = makeIndividual(1, rng)
+		init = InitBitset{N: 130}
+	)
+	init.Apply(&p1, rng)
+	init.Apply(&p2, rng)
+	checkPadding(t, BitsetOf(p1))
+	var (
+		b1 = BitsetOf(p1).Copy()
+		b2 = BitsetOf(p2).Copy()
+	)
+	for _, cross := range []Crossover{CrossUniformBitset{}, CrossPointBitset{}} {
+		var o1, o2 = cross.Apply(p1, p2, rng)
+		// Each bit of the offsprings comes from one parent and the other
+		// offspring gets the other parent's bit
+		for i := 0; i < 130; i++ {
+			var x, y = BitsetOf(o1).Get(i), BitsetOf(o2).Get(i)
+			if !(x == b1.Get(i) && y == b2.Get(i) || x == b2.Get(i) && y == b1.Get(i)) {
+				t.Errorf("%T produced an inconsistent bit at position %d", cross, i)
+				break
+			}
+		}
+		checkPadding(t, BitsetOf(o1))
+	}
+	// Flip every bit, the genome shares it's words with the first parent
+	var indi = Individual{Genome: Genome{BitsetOf(p1)}}
+	MutFlipBitset{Rate: 1}.Apply(&indi, rng)
+	if BitsetOf(indi).Distance(b1) != 130 {
+		t.Error("MutFlipBitset with a rate of 1 should flip every bit")
+	}
+	checkPadding(t, BitsetOf(indi))
+	if BitsetOf(p1).Distance(b1) != 0 {
+		t.Error("MutFlipBitset modified the words of the original genome")
+	}
+	// Flip a fraction of the bits
+	indi = Individual{Genome: Genome{NewBitset(10000)}}
+	MutFlipBitset{Rate: 0.1}.Apply(&indi, rng)
+	if n := BitsetOf(indi).OnesCount(); n < 800 || n > 1200 {
+		t.Errorf("Expected around 1000 flipped bits, got %d", n)
+	}
+}
+
+func TestBitsetGA(t *testing.T) {
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 30,
+		NbrGenes:       1,
+		Ff: BitsetFunction{
+			Image: func(b Bitset) float64 { return float64(b.N - b.OnesCount()) },
+		},
+		Initializer: InitBitset{N: 100},
+		Model: ModGenerational{
+			Selector:  SelTournament{NbParticipants: 3},
+			Crossover: CrossUniformBitset{},
+			Mutator:   MutFlipBitset{Rate: 0.01},
+			MutRate:   0.5,
+		},
+	}
+	ga.Initialize()
+	var initial = ga.Best().Fitness
+	for i := 0; i < 20; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness >= initial {
+		t.Error("OneMax didn't improve")
+	}
+}
+
+func TestEncodeBitset(t *testing.T) {
+	var (
+		b   = NewBitset(70)
+		buf bytes.Buffer
+	)
+	b.Set(3, true)
+	b.Set(69, true)
+	if err := EncodeIndividuals(&buf, Individuals{Individual{Genome: Genome{b}}}); err != nil {
+		t.Fatal(err)
+	}
+	var decoded, err = DecodeIndividuals(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d = BitsetOf(decoded[0])
+	if d.N != 70 || d.Distance(b) != 0 {
+		t.Error("The Bitset wasn't decoded correctly")
+	}
+}
+
+// Benchmark a generation's worth of crossovers and mutations on 1024 bit
+// genomes, packed and unpacked.
+
+func BenchmarkOperatorsBitset(b *testing.B) {
+	var (
+		rng = rand.New(rand.NewSource(42))
+		p1  = makeIndividual(1, rng)
+		p2  = makeIndividual(1, rng)
+	)
+	InitBitset{N: 1024}.Apply(&p1, rng)
+	InitBitset{N: 1024}.Apply(&p2, rng)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var o1, _ = CrossUniformBitset{}.Apply(p1, p2, rng)
+		MutFlipBitset{Rate: 0.01}.Apply(&o1, rng)
+		BitsetOf(o1).OnesCount()
+	}
+}
+
+func BenchmarkOperatorsB(b *testing.B) {
+	var (
+		rng = rand.New(rand.NewSource(42))
+		p1  = makeIndividual(1024, rng)
+		p2  = makeIndividual(1024, rng)
+	)
+	InitUniformB{}.Apply(&p1, rng)
+	InitUniformB{}.Apply(&p2, rng)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var o1, _ = CrossUniform{}.Apply(p1, p2, rng)
+		MutFlipB{Rate: 0.01}.Apply(&o1, rng)
+		var n int
+		for _, gene := range o1.Genome {
+			if gene.(bool) {
+				n++
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package gago
+
+import "errors"
+
+// The key under which the cell of an individual is stored in it's metadata.
+const cellKey = "cell"
+
+// ModCellular implements the cellular model, also called the fine-grained
+// model. The individuals live in the cells of a toroidal grid of Width columns
+// and only mate with their neighbours. The neighbourhood of a cell is made of
+// the cell and of it's 4 adjacent cells (von Neumann neighbourhood) or of it's
+// 8 surrounding cells if Moore is true (Moore neighbourhood). For each cell two
+// parents are chosen within the neighbourhood by Selector, the first offspring
+// of their crossover is mutated with probability MutRate and replaces the
+// individual of the cell if it isn't worse. If Synchronous is true every cell
+// is updated from the previous grid, otherwise the cells are updated one after
+// the other and each cell sees the updates of the previous cells. Small
+// neighbourhoods slow down the spread of good individuals, which preserves
+// diversity.
+//
+// The GA sorts the individuals of a population after each generation, hence
+// the cell of each individual is stored in it's metadata and the grid is
+// rebuilt before each generation. Individuals without a cell, for example the
+// initial ones, take the empty cells in order. The Selector has to work with
+// the 5 or 9 individuals of a neighbourhood, for example a tournament
+// shouldn't have more participants than that.
+type ModCellular struct {
+	Width       int
+	Moore       bool
+	Selector    Selector
+	Crossover   Crossover
+	Mutator     Mutator
+	MutRate     float64
+	Synchronous bool
+}
+
+// Arrange the individuals of a population on the grid according to their
+// cells.
+func arrangeCells(indis Individuals) Individuals {
+	var (
+		grid     = make(Individuals, len(indis))
+		occupied = make([]bool, len(indis))
+		homeless Individuals
+	)
+	for _, indi := range indis {
+		var cell, ok = indi.Meta(cellKey).(int)
+		if ok && cell < len(grid) && !occupied[cell] {
+			grid[cell] = indi
+			occupied[cell] = true
+		} else {
+			homeless = append(homeless, indi)
+		}
+	}
+	for i := range grid {
+		if !occupied[i] {
+			grid[i] = homeless[0]
+			grid[i].SetMeta(cellKey, i)
+			homeless = homeless[1:]
+		}
+	}
+	return grid
+}
+
+// Return the individuals of the neighbourhood of a cell, the grid wraps
+// around it's edges. The last row of the grid can be incomplete, in which case
+// the missing cells are skipped.
+func (mod ModCellular) neighbours(grid Individuals, cell int) Individuals {
+	var (
+		height   = (len(grid) + mod.Width - 1) / mod.Width
+		row, col = cell / mod.Width, cell % mod.Width
+		offsets  = [][2]int{{0, 0}, {-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+		indis    Individuals
+	)
+	if mod.Moore {
+		offsets = append(offsets, [2]int{-1, -1}, [2]int{-1, 1}, [2]int{1, -1}, [2]int{1, 1})
+	}
+	for _, offset := range offsets {
+		var (
+			r = (row + offset[0] + height) % height
+			c = (col + offset[1] + mod.Width) % mod.Width
+			i = r*mod.Width + c
+		)
+		if i < len(grid) {
+			indis = append(indis, grid[i])
+		}
+	}
+	return indis
+}
+
+// Produce the offspring of a cell and return the individual that should
+// occupy the cell.
+func (mod ModCellular) update(grid Individuals, cell int, pop *Population) Individual {
+	var (
+		parents, _   = mod.Selector.Apply(2, mod.neighbours(grid, cell), pop.rng)
+		offspring, _ = mod.Crossover.Apply(parents[0], parents[1], pop.rng)
+	)
+	if mod.Mutator != nil && pop.rng.Float64() < mod.MutRate {
+		offspring.Mutate(mod.Mutator, pop.rng)
+	}
+	offspring.Evaluate(pop.ff)
+	if less(pop.cmp, grid[cell], offspring) {
+		return grid[cell]
+	}
+	offspring.SetMeta(cellKey, cell)
+	return offspring
+}
+
+// Apply the cellular model to a population.
+func (mod ModCellular) Apply(pop *Population) {
+	var grid = arrangeCells(pop.Individuals)
+	if mod.Synchronous {
+		var next = make(Individuals, len(grid))
+		for i := range grid {
+			next[i] = mod.update(grid, i, pop)
+		}
+		grid = next
+	} else {
+		for i := range grid {
+			grid[i] = mod.update(grid, i, pop)
+		}
+	}
+	pop.Individuals = grid
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModCellular) Validate() error {
+	// Check the width of the grid
+	if mod.Width < 1 {
+		return errors.New("'Width' should be higher or equal to 1")
+	}
+	// Check the selection method presence
+	if mod.Selector == nil {
+		return errors.New("'Selector' cannot be nil")
+	}
+	// Check the crossover method presence
+	if mod.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if mod.Mutator != nil && (mod.MutRate < 0 || mod.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	return nil
+}
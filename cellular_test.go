@@ -0,0 +1,89 @@
+package gago
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestArrangeCells(t *testing.T) {
+	var indis = make(Individuals, 4)
+	for i := range indis {
+		indis[i].Name = string(rune('a' + i))
+	}
+	indis[0].SetMeta(cellKey, 2)
+	indis[3].SetMeta(cellKey, 2)
+	// The first individual keeps it's cell, the others take the empty cells in
+	// order
+	var grid = arrangeCells(indis)
+	for i, name := range []string{"b", "c", "a", "d"} {
+		if grid[i].Name != name {
+			t.Errorf("Expected %s in cell %d, got %s", name, i, grid[i].Name)
+		}
+		if cell, _ := grid[i].Meta(cellKey).(int); cell != i {
+			t.Errorf("The individual of cell %d thinks it's in cell %d", i, cell)
+		}
+	}
+}
+
+func TestCellularNeighbours(t *testing.T) {
+	var grid = make(Individuals, 12)
+	for i := range grid {
+		grid[i].Fitness = float64(i)
+	}
+	var testCases = []struct {
+		mod        ModCellular
+		cell       int
+		neighbours []float64
+	}{
+		// The grid has 4 columns and 3 rows, it wraps around
+		{ModCellular{Width: 4}, 0, []float64{0, 1, 3, 4, 8}},
+		{ModCellular{Width: 4, Moore: true}, 5, []float64{0, 1, 2, 4, 5, 6, 8, 9, 10}},
+		// The last row is incomplete
+		{ModCellular{Width: 5}, 11, []float64{1, 6, 10, 11}},
+	}
+	for _, testCase := range testCases {
+		var fitnesses = testCase.mod.neighbours(grid, testCase.cell).getFitnesses()
+		sort.Float64s(fitnesses)
+		if len(fitnesses) != len(testCase.neighbours) {
+			t.Errorf("Expected the neighbours %v, got %v", testCase.neighbours, fitnesses)
+			continue
+		}
+		for i := range fitnesses {
+			if fitnesses[i] != testCase.neighbours[i] {
+				t.Errorf("Expected the neighbours %v, got %v", testCase.neighbours, fitnesses)
+				break
+			}
+		}
+	}
+}
+
+func TestModCellular(t *testing.T) {
+	for _, synchronous := range []bool{false, true} {
+		var (
+			pop = makePopulation(16, 2, ff, initializer)
+			mod = ModCellular{
+				Width:       4,
+				Selector:    SelTournament{NbParticipants: 2},
+				Crossover:   CrossUniformF{},
+				Mutator:     MutNormalF{Rate: 0.5, Std: 1},
+				MutRate:     0.5,
+				Synchronous: synchronous,
+			}
+			best = make([]float64, 16)
+		)
+		pop.Individuals.Evaluate(ff)
+		mod.Apply(&pop)
+		for i, indi := range pop.Individuals {
+			best[i] = indi.Fitness
+		}
+		// Shuffle the population as the GA would by sorting it, each
+		// individual goes back to it's cell and no cell gets worse
+		pop.Individuals.Sort()
+		mod.Apply(&pop)
+		for i, indi := range pop.Individuals {
+			if indi.Fitness > best[i] {
+				t.Errorf("Cell %d got worse", i)
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package gago
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// CheckpointVersion is the version of the checkpoint format written by
+// SaveCheckpoint and WriteCheckpoint. The version is increased each time the
+// format changes, the checkpoints written with older versions are migrated
+// forward when they are read, hence long-lived experiment archives can still
+// be loaded after the library is upgraded.
+//
+// Version 1 checkpoints have no header, they contain the counters, the best
+// individual and the individuals of each population. Version 2 checkpoints
+// start with a header made of a magic string and the version, and also
+// contain the number of restarts and the stagnation of the GA and of each
+// population.
+const CheckpointVersion = 2
+
+// The magic string that starts the checkpoints written with a version higher
+// than 1. A version 1 checkpoint could only start with it if the name of the
+// best individual was 79 characters long.
+const checkpointMagic = "GAGOCKPT"
+
+// A Checkpoint is the state of a GA saved by SaveCheckpoint. The parameters of
+// the GA aren't part of the state, nor are the IDs, birth generations and
+// origins of the individuals. Populations holds the individuals of each
+// population, PopStagnation the stagnation of each population.
+type Checkpoint struct {
+	Version       int
+	Generations   int
+	Evaluations   int
+	Duration      time.Duration
+	Restarts      int
+	Stagnation    int
+	Best          Individual
+	Populations   []Individuals
+	PopStagnation []int
+}
+
+// A checkpointFormat reads and writes the body of the checkpoints of a
+// version, which is what follows the header.
+type checkpointFormat struct {
+	read  func(br *binaryReader) Checkpoint
+	write func(bw *binaryWriter, cp Checkpoint)
+}
+
+// The registry of the checkpoint formats, indexed by version. Each format but
+// the last one has a migration to the next version.
+var checkpointFormats = map[int]checkpointFormat{
+	1: {readCheckpointV1, writeCheckpointV1},
+	2: {readCheckpointV2, writeCheckpointV2},
+}
+
+// The migrations of the checkpoints of a version to the next version, indexed
+// by the version they migrate from.
+var checkpointMigrations = map[int]func(cp *Checkpoint){
+	// Version 1 doesn't record the restarts and the stagnation, which are
+	// considered to be 0 as if the run started when the checkpoint is loaded
+	1: func(cp *Checkpoint) {
+		cp.Restarts = 0
+		cp.Stagnation = 0
+		cp.PopStagnation = make([]int, len(cp.Populations))
+	},
+}
+
+func readCheckpointV1(br *binaryReader) Checkpoint {
+	var cp = Checkpoint{
+		Generations: int(br.uvarint()),
+		Evaluations: int(br.uvarint()),
+		Duration:    time.Duration(br.varint()),
+		Best:        br.individual(),
+	}
+	cp.Populations = make([]Individuals, br.length())
+	for i := range cp.Populations {
+		cp.Populations[i] = make(Individuals, br.length())
+		for j := range cp.Populations[i] {
+			cp.Populations[i][j] = br.individual()
+			if br.err != nil {
+				return cp
+			}
+		}
+	}
+	return cp
+}
+
+func writeCheckpointV1(bw *binaryWriter, cp Checkpoint) {
+	bw.uvarint(uint64(cp.Generations))
+	bw.uvarint(uint64(cp.Evaluations))
+	bw.varint(int64(cp.Duration))
+	bw.individual(cp.Best)
+	bw.uvarint(uint64(len(cp.Populations)))
+	for _, indis := range cp.Populations {
+		bw.uvarint(uint64(len(indis)))
+		for _, indi := range indis {
+			bw.individual(indi)
+		}
+	}
+}
+
+func readCheckpointV2(br *binaryReader) Checkpoint {
+	var cp = readCheckpointV1(br)
+	cp.Restarts = int(br.uvarint())
+	cp.Stagnation = int(br.uvarint())
+	cp.PopStagnation = make([]int, len(cp.Populations))
+	for i := range cp.PopStagnation {
+		cp.PopStagnation[i] = int(br.uvarint())
+	}
+	return cp
+}
+
+func writeCheckpointV2(bw *binaryWriter, cp Checkpoint) {
+	writeCheckpointV1(bw, cp)
+	bw.uvarint(uint64(cp.Restarts))
+	bw.uvarint(uint64(cp.Stagnation))
+	for i := range cp.Populations {
+		var stagnation int
+		if i < len(cp.PopStagnation) {
+			stagnation = cp.PopStagnation[i]
+		}
+		bw.uvarint(uint64(stagnation))
+	}
+}
+
+// Write a checkpoint in the format of a given version.
+func writeCheckpoint(w io.Writer, cp Checkpoint, version int) error {
+	var format, ok = checkpointFormats[version]
+	if !ok {
+		return fmt.Errorf("unknown checkpoint version %d", version)
+	}
+	var (
+		buf = bufio.NewWriter(w)
+		bw  = &binaryWriter{w: buf}
+	)
+	if version > 1 {
+		bw.write([]byte(checkpointMagic))
+		bw.uvarint(uint64(version))
+	}
+	format.write(bw, cp)
+	if bw.err != nil {
+		return bw.err
+	}
+	return buf.Flush()
+}
+
+// WriteCheckpoint writes a checkpoint in the binary format of the current
+// CheckpointVersion.
+func WriteCheckpoint(w io.Writer, cp Checkpoint) error {
+	return writeCheckpoint(w, cp, CheckpointVersion)
+}
+
+// ReadCheckpoint reads a checkpoint written with any version of the format
+// and migrates it to the current CheckpointVersion. The version it was written
+// with is lost, the Version of the returned checkpoint is always the current
+// one. An error is returned if the checkpoint was written by a newer version
+// of the library.
+func ReadCheckpoint(r io.Reader) (Checkpoint, error) {
+	var (
+		br      = &binaryReader{r: bufio.NewReader(r)}
+		version = 1
+	)
+	if header, _ := br.r.Peek(len(checkpointMagic)); string(header) == checkpointMagic {
+		br.r.Discard(len(checkpointMagic))
+		version = int(br.uvarint())
+		if br.err != nil {
+			return Checkpoint{}, br.err
+		}
+	}
+	var format, ok = checkpointFormats[version]
+	if !ok {
+		if version > CheckpointVersion {
+			return Checkpoint{}, fmt.Errorf("the checkpoint was written with version %d of the format, which is newer than the supported version %d", version, CheckpointVersion)
+		}
+		return Checkpoint{}, fmt.Errorf("unknown checkpoint version %d", version)
+	}
+	var cp = format.read(br)
+	if br.err != nil {
+		return Checkpoint{}, br.err
+	}
+	// Migrate the checkpoint one version at a time
+	for ; version < CheckpointVersion; version++ {
+		checkpointMigrations[version](&cp)
+	}
+	cp.Version = CheckpointVersion
+	return cp, nil
+}
+
+// Return the state of the GA.
+func (ga *GA) checkpoint() Checkpoint {
+	var cp = Checkpoint{
+		Version:       CheckpointVersion,
+		Generations:   ga.Generations,
+		Evaluations:   ga.Evaluations,
+		Duration:      ga.Duration,
+		Restarts:      ga.Restarts,
+		Stagnation:    ga.Stagnation,
+		Best:          ga.Best(),
+		Populations:   make([]Individuals, len(ga.Populations)),
+		PopStagnation: make([]int, len(ga.Populations)),
+	}
+	for i, pop := range ga.Populations {
+		cp.Populations[i] = pop.Individuals
+		cp.PopStagnation[i] = pop.Stagnation
+	}
+	return cp
+}
+
+// SaveCheckpoint writes the state of the GA in the binary format so that the
+// run can be resumed later on with LoadCheckpoint. The state contains the
+// individuals of each population, the best individual and the counters of the
+// GA. The parameters of the GA aren't saved, they have to be provided again
+// when the checkpoint is loaded. Neither are the IDs, birth generations and
+// origins of the individuals, which are stamped anew when the checkpoint is
+// loaded. The checkpoint is written with the current CheckpointVersion.
+func (ga *GA) SaveCheckpoint(w io.Writer) error {
+	return WriteCheckpoint(w, ga.checkpoint())
+}
+
+// LoadCheckpoint restores the state of a GA written by SaveCheckpoint, with
+// the current or an older version of the library, see ReadCheckpoint. The
+// parameters of the GA, such as the fitness function and the model, have to
+// be set beforehand, LoadCheckpoint replaces the call to Initialize.
+func (ga *GA) LoadCheckpoint(r io.Reader) error {
+	if err := ga.Validate(); err != nil {
+		return err
+	}
+	var cp, err = ReadCheckpoint(r)
+	if err != nil {
+		return err
+	}
+	ga.restore(cp)
+	return nil
+}
+
+// Restore the state of the GA from a checkpoint.
+func (ga *GA) restore(cp Checkpoint) {
+	var (
+		ff   = ga.countedFunction()
+		pops = make(Populations, len(cp.Populations))
+	)
+	for i, indis := range cp.Populations {
+		var src = rand.NewSource(time.Now().UnixNano() + int64(i))
+		pops[i] = Population{
+			Individuals: indis,
+			Stagnation:  cp.PopStagnation[i],
+			rng:         rand.New(src),
+			src:         src,
+			ff:          ff,
+			cmp:         ga.Comparator,
+		}
+	}
+	atomic.StoreInt64(ga.evaluations, int64(cp.Evaluations))
+	ga.Generations = cp.Generations
+	ga.Evaluations = cp.Evaluations
+	ga.Duration = cp.Duration
+	ga.Restarts = cp.Restarts
+	ga.Stagnation = cp.Stagnation
+	ga.Populations = pops
+	ga.NbrPopulations = len(pops)
+	ga.lastID = 0
+	ga.stamp()
+	ga.setBest(cp.Best)
+}
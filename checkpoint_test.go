@@ -0,0 +1,133 @@
+package gago
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func newCheckpointGA() GA {
+	return GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+	}
+}
+
+func TestCheckpointVersions(t *testing.T) {
+	var g = newCheckpointGA()
+	g.Initialize()
+	for i := 0; i < 3; i++ {
+		g.Enhance()
+	}
+	g.Restarts = 2
+	g.Populations[0].Stagnation = 4
+	var saved = g.checkpoint()
+	for version := 1; version <= CheckpointVersion; version++ {
+		var buf bytes.Buffer
+		if err := writeCheckpoint(&buf, saved, version); err != nil {
+			t.Fatal(err)
+		}
+		var cp, err = ReadCheckpoint(&buf)
+		if err != nil {
+			t.Fatalf("Version %d: %v", version, err)
+		}
+		if cp.Version != CheckpointVersion {
+			t.Errorf("Version %d: the checkpoint should be migrated to the current version", version)
+		}
+		if cp.Generations != saved.Generations || cp.Evaluations != saved.Evaluations || cp.Duration != saved.Duration {
+			t.Errorf("Version %d: the counters weren't read back", version)
+		}
+		if !reflect.DeepEqual(cp.Best.Genome, saved.Best.Genome) || len(cp.Populations) != len(saved.Populations) {
+			t.Errorf("Version %d: the individuals weren't read back", version)
+		}
+		for i := range cp.Populations {
+			for j, indi := range cp.Populations[i] {
+				if !reflect.DeepEqual(indi.Genome, saved.Populations[i][j].Genome) || indi.Fitness != saved.Populations[i][j].Fitness {
+					t.Errorf("Version %d: the populations weren't read back", version)
+				}
+			}
+		}
+		if len(cp.PopStagnation) != len(cp.Populations) {
+			t.Errorf("Version %d: there should be a stagnation per population", version)
+		}
+		// Version 1 doesn't record the restarts and the stagnation
+		var restarts, stagnation = saved.Restarts, saved.PopStagnation[0]
+		if version == 1 {
+			restarts, stagnation = 0, 0
+		}
+		if cp.Restarts != restarts || cp.PopStagnation[0] != stagnation {
+			t.Errorf("Version %d: expected %d restarts and a stagnation of %d, got %d and %d", version, restarts, stagnation, cp.Restarts, cp.PopStagnation[0])
+		}
+	}
+}
+
+func TestCheckpointLegacy(t *testing.T) {
+	// A version 1 checkpoint written before the format was versioned
+	var data, _ = hex.DecodeString("030a0a0161000000000000f03f010100000000000000f03f000000000000000000000001010161000000000000f03f010100000000000000f03f0000000000000000000000")
+	var g = newCheckpointGA()
+	if err := g.LoadCheckpoint(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	if g.Generations != 3 || g.Evaluations != 10 || g.Duration != 5 {
+		t.Error("The counters weren't restored")
+	}
+	if best := g.Best(); best.Name != "a" || best.Fitness != 1 || best.Genome[0] != 1.0 {
+		t.Error("The best individual wasn't restored")
+	}
+	if len(g.Populations) != 1 || len(g.Populations[0].Individuals) != 1 {
+		t.Error("The populations weren't restored")
+	}
+}
+
+func TestCheckpointRestore(t *testing.T) {
+	var g = newCheckpointGA()
+	g.Initialize()
+	g.Enhance()
+	g.Restarts = 1
+	g.Stagnation = 3
+	var buf bytes.Buffer
+	if err := g.SaveCheckpoint(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte(checkpointMagic)) {
+		t.Error("The checkpoint should start with the header")
+	}
+	var restored = newCheckpointGA()
+	if err := restored.LoadCheckpoint(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Restarts != 1 || restored.Stagnation != 3 {
+		t.Error("The restarts and the stagnation weren't restored")
+	}
+	for i, pop := range restored.Populations {
+		if pop.Stagnation != g.Populations[i].Stagnation {
+			t.Error("The stagnation of the populations wasn't restored")
+		}
+	}
+}
+
+func TestCheckpointErrors(t *testing.T) {
+	var testCases = [][]byte{
+		// Newer version
+		append([]byte(checkpointMagic), CheckpointVersion+1),
+		// Unknown version
+		append([]byte(checkpointMagic), 0),
+		// Truncated header
+		[]byte(checkpointMagic),
+		// Truncated body
+		append([]byte(checkpointMagic), CheckpointVersion, 3),
+	}
+	for _, data := range testCases {
+		if _, err := ReadCheckpoint(bytes.NewReader(data)); err == nil {
+			t.Errorf("Expected an error for %x", data)
+		}
+	}
+	if err := writeCheckpoint(&bytes.Buffer{}, Checkpoint{}, 0); err == nil {
+		t.Error("Writing an unknown version should return an error")
+	}
+}
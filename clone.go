@@ -0,0 +1,126 @@
+package gago
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sync/atomic"
+)
+
+// Copy the state of a random number source, an error is returned if the
+// source isn't a pointer to a struct, in which case it's state can't be
+// copied.
+func cloneSource(src rand.Source) (rand.Source, error) {
+	var v = reflect.ValueOf(src)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("the random number source of a population can't be copied")
+	}
+	var clone = reflect.New(v.Elem().Type())
+	clone.Elem().Set(v.Elem())
+	return clone.Interface().(rand.Source), nil
+}
+
+// Clone returns a deep copy of an initialized GA, which makes it possible to
+// branch a run: the clone can be evolved with other parameters for a few
+// generations and compared with the original, which is left untouched. The
+// populations, including the state of their random number generators, the
+// counters, the best individual and the hall of fame, the history, the
+// lineage, the archive, the tabu list and the pressure monitor are copied,
+// hence the clone and the original can be evolved concurrently and a clone
+// evolved with the same parameters produces the same individuals as the
+// original.
+//
+// The fitness function, the initializer, the models and the other operators
+// are shared with the original. They can be replaced on the clone before
+// evolving it, for example to try another mutation rate. Models that keep a
+// state for each population, such as ModPBIL, start from a new state for the
+// populations of the clone. A GA that records an EventLog can't be cloned.
+func (ga *GA) Clone() (*GA, error) {
+	if ga.evaluations == nil {
+		return nil, errors.New("the GA should be initialized before being cloned")
+	}
+	if ga.EventLog != nil {
+		return nil, errors.New("a GA that records an event log can't be cloned")
+	}
+	var clone = &GA{
+		Ff:              ga.Ff,
+		Initializer:     ga.Initializer,
+		MigFrequency:    ga.MigFrequency,
+		Migrator:        ga.Migrator,
+		Model:           ga.Model,
+		NbrClusters:     ga.NbrClusters,
+		NbrGenes:        ga.NbrGenes,
+		NbrIndividuals:  ga.NbrIndividuals,
+		NbrPopulations:  ga.NbrPopulations,
+		Comparator:      ga.Comparator,
+		Debug:           ga.Debug,
+		Deduplicate:     ga.Deduplicate,
+		Guard:           ga.Guard,
+		MemoryLimit:     ga.MemoryLimit,
+		Models:          append([]Model(nil), ga.Models...),
+		Profile:         ga.Profile,
+		Ranker:          ga.Ranker,
+		Restarter:       ga.Restarter,
+		Seed:            ga.Seed,
+		Shards:          ga.Shards,
+		Sizer:           ga.Sizer,
+		StagnationLimit: ga.StagnationLimit,
+		Duration:        ga.Duration,
+		Evaluations:     ga.Evaluations,
+		Generations:     ga.Generations,
+		Restarts:        ga.Restarts,
+		Stagnation:      ga.Stagnation,
+		evaluations:     new(int64),
+		ipopSize:        ga.ipopSize,
+		lastID:          ga.lastID,
+	}
+	// Copy the optional components that keep a state
+	if ga.Archive != nil {
+		clone.Archive = ga.Archive.clone()
+	}
+	if ga.HallOfFame != nil {
+		clone.HallOfFame = ga.HallOfFame.clone()
+	}
+	if ga.History != nil {
+		clone.History = ga.History.clone()
+	}
+	if ga.Lineage != nil {
+		clone.Lineage = ga.Lineage.clone()
+	}
+	if ga.Pressure != nil {
+		clone.Pressure = ga.Pressure.clone()
+	}
+	if ga.Tabu != nil {
+		clone.Tabu = ga.Tabu.clone()
+	}
+	// Copy the counters
+	atomic.StoreInt64(clone.evaluations, atomic.LoadInt64(ga.evaluations))
+	if ga.profiler != nil {
+		clone.profiler = ga.profiler.clone()
+	}
+	// Copy the populations
+	var ff = countedFunction{ga.Ff, clone.evaluations, clone.profiler, ga.Deduplicate, ga.Guard}
+	clone.Populations = make(Populations, len(ga.Populations))
+	for i, pop := range ga.Populations {
+		var src, err = cloneSource(pop.src)
+		if err != nil {
+			return nil, err
+		}
+		clone.Populations[i] = Population{
+			Individuals: make(Individuals, len(pop.Individuals)),
+			Duration:    pop.Duration,
+			Stagnation:  pop.Stagnation,
+			rng:         rand.New(src),
+			src:         src,
+			ff:          ff,
+			cmp:         pop.cmp,
+		}
+		for j, indi := range pop.Individuals {
+			clone.Populations[i].Individuals[j] = copyIndividual(indi)
+		}
+	}
+	if best, ok := ga.best.Load().(Individual); ok {
+		clone.setBest(best)
+	}
+	return clone, nil
+}
@@ -0,0 +1,89 @@
+package gago
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	var original = GA{
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          model,
+		NbrGenes:       nbGenes,
+		NbrIndividuals: nbIndividuals,
+		NbrPopulations: 2,
+		Seed:           42,
+		HallOfFame:     &HallOfFame{Size: 3},
+		History:        &BestHistory{},
+		Lineage:        &Lineage{},
+		Tabu:           &TabuList{Generations: 2},
+	}
+	original.Initialize()
+	for i := 0; i < 3; i++ {
+		original.Enhance()
+	}
+	var clone, err = original.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.Generations != original.Generations || clone.Evaluations != original.Evaluations {
+		t.Error("The counters should be copied")
+	}
+	if clone.Best().Fitness != original.Best().Fitness {
+		t.Error("The best individual should be copied")
+	}
+	// Both branches should evolve the same individuals
+	for i := 0; i < 3; i++ {
+		original.Enhance()
+		clone.Enhance()
+	}
+	for i := range original.Populations {
+		for j, indi := range original.Populations[i].Individuals {
+			if !reflect.DeepEqual(indi.Genome, clone.Populations[i].Individuals[j].Genome) {
+				t.Fatal("A clone should evolve the same individuals as the original")
+			}
+		}
+	}
+	if len(clone.HallOfFame.Members()) != len(original.HallOfFame.Members()) {
+		t.Error("The hall of fame should be copied")
+	}
+	// Modifying the clone shouldn't modify the original
+	var genome = original.Populations[0].Individuals[0].Genome
+	var gene = genome[0]
+	clone.Populations[0].Individuals[0].Genome[0] = gene.(float64) + 1
+	if genome[0] != gene {
+		t.Error("The clone shouldn't share the genomes of the original")
+	}
+	var evaluations = original.Evaluations
+	clone.Enhance()
+	if original.Evaluations != evaluations {
+		t.Error("The clone shouldn't share the counters of the original")
+	}
+	if len(clone.Lineage.Nodes()) == len(original.Lineage.Nodes()) {
+		t.Error("The clone shouldn't share the lineage of the original")
+	}
+	if len(clone.History.Snapshots()) == len(original.History.Snapshots()) {
+		t.Error("The clone shouldn't share the history of the original")
+	}
+}
+
+func TestCloneErrors(t *testing.T) {
+	var uninitialized = GA{Ff: ff, Initializer: initializer, Model: model}
+	if _, err := uninitialized.Clone(); err == nil {
+		t.Error("Cloning a GA that isn't initialized should return an error")
+	}
+	var logged = GA{
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          model,
+		NbrGenes:       nbGenes,
+		NbrIndividuals: nbIndividuals,
+		NbrPopulations: 1,
+		EventLog:       &EventLog{},
+	}
+	logged.Initialize()
+	if _, err := logged.Clone(); err == nil {
+		t.Error("Cloning a GA that records an event log should return an error")
+	}
+}
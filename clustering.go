@@ -20,7 +20,9 @@ func (pop Population) cluster(k int) Populations {
 		pops[i] = Population{
 			Individuals: pop.Individuals[a:b],
 			rng:         pop.rng,
+			src:         pop.src,
 			ff:          pop.ff,
+			cmp:         pop.cmp,
 		}
 	}
 	return pops
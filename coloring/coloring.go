@@ -0,0 +1,158 @@
+// Package coloring helps solving graph coloring and, more generally,
+// assignment problems with gago. Each gene is the color, or the value, that is
+// assigned to a node of a graph and the fitness is the number of edges whose
+// nodes are assigned the same color.
+package coloring
+
+import (
+	"math/rand"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A Graph is stored as adjacency lists, Adjacency[i] contains the neighbours of
+// node i.
+type Graph struct {
+	Adjacency [][]int
+}
+
+// NewGraph builds an undirected graph with nbNodes nodes from a list of edges.
+func NewGraph(nbNodes int, edges [][2]int) Graph {
+	var graph = Graph{Adjacency: make([][]int, nbNodes)}
+	for _, edge := range edges {
+		graph.Adjacency[edge[0]] = append(graph.Adjacency[edge[0]], edge[1])
+		graph.Adjacency[edge[1]] = append(graph.Adjacency[edge[1]], edge[0])
+	}
+	return graph
+}
+
+// NbNodes returns the number of nodes of the graph.
+func (graph Graph) NbNodes() int {
+	return len(graph.Adjacency)
+}
+
+// Conflicts counts the edges whose nodes have the same color.
+func (graph Graph) Conflicts(colors []int) int {
+	var n int
+	for i, neighbours := range graph.Adjacency {
+		for _, j := range neighbours {
+			if j > i && colors[i] == colors[j] {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Count the neighbours of a node that have a given color.
+func (graph Graph) conflicts(genome gago.Genome, node, color int) int {
+	var n int
+	for _, j := range graph.Adjacency[node] {
+		if genome[j].(int) == color {
+			n++
+		}
+	}
+	return n
+}
+
+// Colors returns the colors contained in an individual's genome.
+func Colors(indi gago.Individual) []int {
+	var colors = make([]int, len(indi.Genome))
+	for i, gene := range indi.Genome {
+		colors[i] = gene.(int)
+	}
+	return colors
+}
+
+// A Problem consists in coloring the nodes of a Graph with NbColors colors so
+// that no edge links two nodes of the same color.
+type Problem struct {
+	Graph    Graph
+	NbColors int
+}
+
+// Fitness returns the fitness function to minimize, which is the number of
+// conflicting edges.
+func (p Problem) Fitness() gago.IntFunction {
+	return gago.IntFunction{
+		Image: func(colors []int) float64 {
+			return float64(p.Graph.Conflicts(colors))
+		},
+	}
+}
+
+// MutConflict applies the min-conflicts heuristic: a node that is in conflict
+// with one of it's neighbours is chosen at random and it is given the color
+// that minimizes the number of conflicts it's involved in, ties being broken
+// at random. With probability Noise the node is given a random color instead,
+// which helps escaping local optima. Nothing happens if there are no
+// conflicts.
+type MutConflict struct {
+	Problem Problem
+	Noise   float64
+}
+
+// Apply conflict directed mutation.
+func (mut MutConflict) Apply(indi *gago.Individual, rng *rand.Rand) {
+	var (
+		graph      = mut.Problem.Graph
+		conflicted []int
+	)
+	for i := range indi.Genome {
+		if graph.conflicts(indi.Genome, i, indi.Genome[i].(int)) > 0 {
+			conflicted = append(conflicted, i)
+		}
+	}
+	if len(conflicted) == 0 {
+		return
+	}
+	var node = conflicted[rng.Intn(len(conflicted))]
+	if rng.Float64() < mut.Noise {
+		indi.Genome[node] = rng.Intn(mut.Problem.NbColors)
+		return
+	}
+	var (
+		best []int
+		min  = -1
+	)
+	for color := 0; color < mut.Problem.NbColors; color++ {
+		var n = graph.conflicts(indi.Genome, node, color)
+		if min == -1 || n < min {
+			min = n
+			best = best[:0]
+		}
+		if n == min {
+			best = append(best, color)
+		}
+	}
+	indi.Genome[node] = best[rng.Intn(len(best))]
+}
+
+// GA returns a GA configuration for coloring the graph. Random colorings are
+// evolved with uniform crossover and conflict directed mutation. As with the
+// presets, the configuration is a starting point that can be tuned.
+func (p Problem) GA() gago.GA {
+	return gago.GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 50,
+		NbrGenes:       p.Graph.NbNodes(),
+		Ff:             p.Fitness(),
+		Initializer: gago.InitUniformI{
+			Lower: 0,
+			Upper: p.NbColors - 1,
+		},
+		Model: gago.ModGenerational{
+			Selector: gago.SelTournament{
+				NbParticipants: 3,
+			},
+			Crossover: gago.CrossUniform{},
+			Mutator: MutConflict{
+				Problem: p,
+				Noise:   0.1,
+			},
+			MutRate: 0.8,
+		},
+		Migrator:     gago.MigShuffle{},
+		MigFrequency: 10,
+	}
+}
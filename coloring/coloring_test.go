@@ -0,0 +1,64 @@
+package coloring
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+// The Petersen graph has a chromatic number of 3.
+var petersen = NewGraph(10, [][2]int{
+	{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+	{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+	{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5},
+})
+
+func TestConflicts(t *testing.T) {
+	var graph = NewGraph(3, [][2]int{{0, 1}, {1, 2}, {2, 0}})
+	if n := graph.Conflicts([]int{0, 0, 0}); n != 3 {
+		t.Errorf("Expected 3 conflicts, got %d", n)
+	}
+	if n := graph.Conflicts([]int{0, 1, 0}); n != 1 {
+		t.Errorf("Expected 1 conflict, got %d", n)
+	}
+	if n := graph.Conflicts([]int{0, 1, 2}); n != 0 {
+		t.Errorf("Expected no conflicts, got %d", n)
+	}
+}
+
+func TestMutConflict(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		p    = Problem{Graph: NewGraph(3, [][2]int{{0, 1}, {1, 2}}), NbColors: 2}
+		mut  = MutConflict{Problem: p}
+		indi = gago.Individual{Genome: gago.Genome{0, 0, 0}}
+	)
+	// Recoloring the middle node removes both conflicts, recoloring an end
+	// node removes one
+	mut.Apply(&indi, rng)
+	if n := p.Graph.Conflicts(Colors(indi)); n > 1 {
+		t.Errorf("MutConflict didn't reduce the number of conflicts, got %d", n)
+	}
+	// A coloring without conflicts isn't modified
+	indi = gago.Individual{Genome: gago.Genome{0, 1, 0}}
+	mut.Apply(&indi, rng)
+	if indi.Genome[1] != 1 {
+		t.Error("MutConflict modified a coloring without conflicts")
+	}
+}
+
+func TestGA(t *testing.T) {
+	var ga = Problem{Graph: petersen, NbColors: 3}.GA()
+	if err := ga.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ga.Initialize()
+	for i := 0; i < 30 && ga.Best().Fitness > 0; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness != 0 {
+		t.Errorf("Expected a coloring without conflicts, got %f conflicts", ga.Best().Fitness)
+	}
+}
@@ -0,0 +1,127 @@
+package gago
+
+import (
+	"math"
+	"sort"
+)
+
+// A Comparator defines the order of individuals, Less returns true if a is
+// better than b. By default individuals are ordered by fitness, a Comparator
+// makes it possible to take other criteria into account, for example the size
+// of the genomes to apply parsimony pressure. Less should define a strict weak
+// ordering for the sorts to be meaningful. A nil Comparator stands for the
+// default order wherever a Comparator is expected.
+type Comparator interface {
+	Less(a, b Individual) bool
+}
+
+// Compare two fitnesses, NaN is worse than any other fitness so that a
+// fitness function that returns NaN can't corrupt the order of the
+// individuals.
+func lessFitness(a, b float64) bool {
+	return a < b || (math.IsNaN(b) && !math.IsNaN(a))
+}
+
+// Check if an individual is better than another according to a Comparator,
+// the fitnesses are compared if the Comparator is nil.
+func less(cmp Comparator, a, b Individual) bool {
+	if cmp == nil {
+		return lessFitness(a.Fitness, b.Fitness)
+	}
+	return cmp.Less(a, b)
+}
+
+// Sort individuals with a Comparator along with their indexes, indexes can be
+// nil.
+type byComparator struct {
+	indis   Individuals
+	indexes []int
+	cmp     Comparator
+}
+
+func (b byComparator) Len() int           { return len(b.indis) }
+func (b byComparator) Less(i, j int) bool { return less(b.cmp, b.indis[i], b.indis[j]) }
+func (b byComparator) Swap(i, j int) {
+	b.indis[i], b.indis[j] = b.indis[j], b.indis[i]
+	if b.indexes != nil {
+		b.indexes[i], b.indexes[j] = b.indexes[j], b.indexes[i]
+	}
+}
+
+// SortWith sorts the individuals from the best to the worst according to a
+// Comparator, a nil Comparator sorts them by fitness like Sort.
+func (indis Individuals) SortWith(cmp Comparator) {
+	if cmp == nil {
+		indis.Sort()
+		return
+	}
+	sort.Stable(byComparator{indis, nil, cmp})
+}
+
+// CompFitness orders individuals by fitness, which is the default order.
+type CompFitness struct{}
+
+// Less compares the fitnesses, NaN being the worst fitness.
+func (cmp CompFitness) Less(a, b Individual) bool {
+	return lessFitness(a.Fitness, b.Fitness)
+}
+
+// CompLexicographic orders individuals by their objectives, as set by an
+// ObjectivesFunction, taken in order: the second objective is only compared if
+// the first ones are equal, and so on. Two objectives that differ by less than
+// the Tolerance of the objective, if given, are considered equal. The
+// fitnesses are compared if the objectives are all equal.
+type CompLexicographic struct {
+	Tolerances []float64
+}
+
+// Less compares the objectives one after the other.
+func (cmp CompLexicographic) Less(a, b Individual) bool {
+	for i := 0; i < len(a.Objectives) && i < len(b.Objectives); i++ {
+		var tol float64
+		if i < len(cmp.Tolerances) {
+			tol = cmp.Tolerances[i]
+		}
+		if math.Abs(a.Objectives[i]-b.Objectives[i]) > tol || (tol == 0 && a.Objectives[i] != b.Objectives[i]) {
+			return a.Objectives[i] < b.Objectives[i]
+		}
+	}
+	return lessFitness(a.Fitness, b.Fitness)
+}
+
+// CompParsimony orders individuals by fitness and breaks ties with the size
+// of the individuals, which is called lexicographic parsimony pressure and
+// favors small solutions when they are as good as large ones. Size returns
+// the size of an individual, the length of the genome is used if Size is nil.
+// Two fitnesses that differ by at most Tolerance are considered equal; note
+// that a positive Tolerance doesn't define a strict weak ordering, hence sorts
+// are only approximate.
+type CompParsimony struct {
+	Size      func(indi Individual) float64
+	Tolerance float64
+}
+
+// Less compares the fitnesses and then the sizes.
+func (cmp CompParsimony) Less(a, b Individual) bool {
+	if math.Abs(a.Fitness-b.Fitness) > cmp.Tolerance {
+		return a.Fitness < b.Fitness
+	}
+	return sizeOf(cmp.Size, a) < sizeOf(cmp.Size, b)
+}
+
+// CompConstrained orders individuals with the feasibility rules of Deb: a
+// feasible individual is better than an infeasible one, among infeasible
+// individuals the one with the lowest violation is better and feasible
+// individuals are compared with Comparator, or by fitness if Comparator is
+// nil. The violations are set by a ConstrainedFunction.
+type CompConstrained struct {
+	Comparator Comparator
+}
+
+// Less compares the violations and then the individuals.
+func (cmp CompConstrained) Less(a, b Individual) bool {
+	if a.Feasible() && b.Feasible() {
+		return less(cmp.Comparator, a, b)
+	}
+	return math.Max(a.Violation, 0) < math.Max(b.Violation, 0)
+}
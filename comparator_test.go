@@ -0,0 +1,155 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// Order individuals by decreasing fitness.
+type compMaximize struct{}
+
+func (cmp compMaximize) Less(a, b Individual) bool { return a.Fitness > b.Fitness }
+
+func TestSortWith(t *testing.T) {
+	var indis = Individuals{
+		{Genome: Genome{1, 2, 3}, Fitness: 2},
+		{Genome: Genome{1}, Fitness: 2},
+		{Genome: Genome{1, 2}, Fitness: 1},
+	}
+	indis.SortWith(nil)
+	if indis[0].Fitness != 1 {
+		t.Error("A nil Comparator should sort by fitness")
+	}
+	indis.SortWith(CompParsimony{})
+	if len(indis[0].Genome) != 2 || len(indis[1].Genome) != 1 || len(indis[2].Genome) != 3 {
+		t.Errorf("Wrong parsimony order: %v", indis)
+	}
+	indis.SortWith(compMaximize{})
+	if indis[0].Fitness != 2 || indis[2].Fitness != 1 {
+		t.Errorf("Wrong custom order: %v", indis)
+	}
+}
+
+func TestCompLexicographic(t *testing.T) {
+	var testCases = []struct {
+		cmp  CompLexicographic
+		a, b Individual
+		less bool
+	}{
+		{CompLexicographic{}, Individual{Objectives: []float64{1, 5}}, Individual{Objectives: []float64{2, 0}}, true},
+		{CompLexicographic{}, Individual{Objectives: []float64{1, 5}}, Individual{Objectives: []float64{1, 4}}, false},
+		{CompLexicographic{}, Individual{Objectives: []float64{1, 1}, Fitness: 0}, Individual{Objectives: []float64{1, 1}, Fitness: 1}, true},
+		{CompLexicographic{Tolerances: []float64{0.5}}, Individual{Objectives: []float64{1.2, 3}}, Individual{Objectives: []float64{1, 4}}, true},
+		{CompLexicographic{Tolerances: []float64{0.1}}, Individual{Objectives: []float64{1.2, 3}}, Individual{Objectives: []float64{1, 4}}, false},
+		{CompLexicographic{}, Individual{Fitness: 1}, Individual{Fitness: 2}, true},
+	}
+	for _, test := range testCases {
+		if test.cmp.Less(test.a, test.b) != test.less {
+			t.Errorf("Expected Less(%v, %v) to be %v", test.a.Objectives, test.b.Objectives, test.less)
+		}
+	}
+}
+
+func TestCompParsimony(t *testing.T) {
+	var (
+		small = Individual{Genome: Genome{1}, Fitness: 1.05}
+		large = Individual{Genome: Genome{1, 2, 3}, Fitness: 1}
+	)
+	if (CompParsimony{}).Less(small, large) {
+		t.Error("The fitness should prevail without tolerance")
+	}
+	if !(CompParsimony{Tolerance: 0.1}).Less(small, large) {
+		t.Error("The size should break ties within the tolerance")
+	}
+	var cmp = CompParsimony{
+		Size:      func(indi Individual) float64 { return -float64(len(indi.Genome)) },
+		Tolerance: 0.1,
+	}
+	if !cmp.Less(large, small) {
+		t.Error("The Size function should be used")
+	}
+}
+
+func TestCompConstrained(t *testing.T) {
+	var (
+		cmp        = CompConstrained{}
+		feasible   = Individual{Fitness: 10}
+		better     = Individual{Fitness: 1}
+		infeasible = Individual{Fitness: 0, Violation: 1}
+		worse      = Individual{Fitness: -1, Violation: 2}
+	)
+	// Feasible individuals are compared by fitness
+	if !cmp.Less(better, feasible) || cmp.Less(feasible, better) {
+		t.Error("Feasible individuals should be compared by fitness")
+	}
+	// Feasible individuals beat infeasible ones
+	if !cmp.Less(feasible, infeasible) || cmp.Less(infeasible, feasible) {
+		t.Error("A feasible individual should beat an infeasible one")
+	}
+	// The violations of infeasible individuals are compared
+	if !cmp.Less(infeasible, worse) || cmp.Less(worse, infeasible) {
+		t.Error("The lowest violation should win")
+	}
+	// The feasible individuals are compared with the Comparator
+	cmp.Comparator = CompParsimony{}
+	if !cmp.Less(Individual{Genome: Genome{1}}, Individual{Genome: Genome{1, 2}}) {
+		t.Error("The Comparator wasn't used")
+	}
+}
+
+func TestComparatorSelection(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indis = Individuals{
+			{Genome: Genome{1, 2, 3}, Fitness: 1},
+			{Genome: Genome{1}, Fitness: 1},
+			{Genome: Genome{1, 2}, Fitness: 1},
+		}
+	)
+	var winners, _ = SelTournament{NbParticipants: 3, Comparator: CompParsimony{}}.Apply(5, indis, rng)
+	for _, winner := range winners {
+		if len(winner.Genome) != 1 {
+			t.Error("The smallest individual should win the tournaments")
+		}
+	}
+	winners, _ = SelExponentialRanking{Base: 1e-9, Comparator: compMaximize{}}.Apply(5, Individuals{{Fitness: 1}, {Fitness: 3}, {Fitness: 2}}, rng)
+	for _, winner := range winners {
+		if winner.Fitness != 3 {
+			t.Error("The individual ranked first by the Comparator should be chosen")
+		}
+	}
+}
+
+func TestComparatorGA(t *testing.T) {
+	var ga = GA{
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          ModGenerational{Selector: SelTournament{NbParticipants: 3, Comparator: compMaximize{}}, Crossover: CrossUniformF{}},
+		NbrGenes:       nbGenes,
+		NbrIndividuals: nbIndividuals,
+		NbrPopulations: 2,
+		Comparator:     compMaximize{},
+		HallOfFame:     &HallOfFame{Size: 3, Comparator: compMaximize{}},
+	}
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	for _, pop := range ga.Populations {
+		for i := 1; i < len(pop.Individuals); i++ {
+			if pop.Individuals[i].Fitness > pop.Individuals[i-1].Fitness {
+				t.Fatal("The populations should be sorted with the Comparator")
+			}
+		}
+		if pop.Individuals[0].Fitness > ga.Best().Fitness {
+			t.Error("The best individual should be found with the Comparator")
+		}
+	}
+	if ga.Best().Fitness <= 0 {
+		t.Error("The GA should maximize the fitness")
+	}
+	if ga.HallOfFame.Members()[0].Fitness != ga.Best().Fitness {
+		t.Error("The hall of fame should use it's Comparator")
+	}
+}
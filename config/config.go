@@ -0,0 +1,596 @@
+// Package config builds GAs from declarative configuration files, which makes
+// experiments reproducible artifacts that can be versioned and shared rather
+// than code changes. A configuration file is written in a subset of TOML, see
+// Parse and Load, or in a subset of YAML, see ParseYAML and LoadYAML, both
+// describe the same tables. The top-level keys set the fields of the GA with their names written
+// in snake case, for example nbr_individuals for NbrIndividuals, and the
+// termination of the experiment:
+//
+//	fitness = "sphere"      # name of a fitness function given to Load
+//	nbr_populations = 2
+//	nbr_individuals = 30
+//	nbr_genes = 4
+//	mig_frequency = 10
+//	max_generations = 100   # stop after 100 generations
+//	max_duration = "30s"    # or after 30 seconds
+//	max_evaluations = 50000 # or after 50000 evaluations
+//
+//	[initializer]
+//	type = "InitUniformF"
+//	lower = -10
+//	upper = 10
+//
+//	[model]
+//	type = "ModGenerational"
+//	mut_rate = 0.5
+//	selector = { type = "SelTournament", nb_participants = 3 }
+//	crossover = { type = "CrossUniformF" }
+//	mutator = { type = "MutNormalF", rate = 0.5, std = 1 }
+//
+//	[migrator]
+//	type = "MigShuffle"
+//
+// Each operator is a table whose type key is the name of a type registered in
+// Types and whose other keys set the fields of the operator. Operators that
+// aren't structs, such as CrossSequence or TopMatrix, are set with a values
+// key. A list of models, or of any other operators, is written as an array of
+// tables. In YAML the operators are mappings and the arrays of tables are
+// sequences of mappings:
+//
+//	model:
+//	  type: ModGenerational
+//	  selector: {type: SelTournament, nb_participants: 3}
+//	models:
+//	  - type: ModSteadyState
+//	    mut_rate: 0.5
+//
+// Fitness functions can't be described in a file, hence they are given to Load
+// and referred to by their name.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+// Types maps the names that can be used in configuration files to values of
+// the corresponding types. Custom operators can be added before calling Load.
+// Types whose methods have pointer receivers are registered with a pointer.
+var Types = map[string]interface{}{
+	// Initializers
+	"InitUniformF":        gago.InitUniformF{},
+	"InitGaussianF":       gago.InitGaussianF{},
+	"InitUniformI":        gago.InitUniformI{},
+	"InitUniformB":        gago.InitUniformB{},
+	"InitUniformS":        gago.InitUniformS{},
+	"InitUniqueS":         gago.InitUniqueS{},
+	"InitPermutationI":    gago.InitPermutationI{},
+	"InitKnapsackB":       gago.InitKnapsackB{},
+	"InitHaltonF":         &gago.InitHaltonF{},
+	"InitSobolF":          &gago.InitSobolF{},
+	"InitLatinHypercubeF": &gago.InitLatinHypercubeF{},
+	"InitMixed":           gago.InitMixed{},
+	"InitBitset":          gago.InitBitset{},
+	"InitUniformVector":   gago.InitUniformVector{},
+	"InitSampler":         gago.InitSampler{},
+	// Selectors
+	"SelTournament":         gago.SelTournament{},
+	"SelElitism":            gago.SelElitism{},
+	"SelRandom":             gago.SelRandom{},
+	"SelDiverse":            gago.SelDiverse{},
+	"StochasticRanking":     gago.StochasticRanking{},
+	"SelLexicase":           gago.SelLexicase{},
+	"SelEpsilonLexicase":    gago.SelEpsilonLexicase{},
+	"SelLinearRanking":      gago.SelLinearRanking{},
+	"SelExponentialRanking": gago.SelExponentialRanking{},
+	"SelIncestPrevention":   gago.SelIncestPrevention{},
+	"SelAssortative":        gago.SelAssortative{},
+	"SelDoubleTournament":   gago.SelDoubleTournament{},
+	// Pairers
+	"PairAdjacent":  gago.PairAdjacent{},
+	"PairRandom":    gago.PairRandom{},
+	"PairBestWorst": gago.PairBestWorst{},
+	"PairDistance":  gago.PairDistance{},
+	// Crossovers
+	"CrossPoint":            gago.CrossPoint{},
+	"CrossUniform":          gago.CrossUniform{},
+	"CrossUniformF":         gago.CrossUniformF{},
+	"CrossArithmeticF":      gago.CrossArithmeticF{},
+	"CrossHeuristicF":       gago.CrossHeuristicF{},
+	"CrossBLXF":             gago.CrossBLXF{},
+	"CrossMixed":            gago.CrossMixed{},
+	"CrossSegmentI":         gago.CrossSegmentI{},
+	"CrossPMX":              gago.CrossPMX{},
+	"CrossUniformBitset":    gago.CrossUniformBitset{},
+	"CrossPointBitset":      gago.CrossPointBitset{},
+	"CrossUniformVector":    gago.CrossUniformVector{},
+	"CrossArithmeticVector": gago.CrossArithmeticVector{},
+	"CrossProb":             gago.CrossProb{},
+	"CrossPipeline":         gago.CrossPipeline{},
+	"CrossSequence":         gago.CrossSequence{},
+	"CrossChoice":           gago.CrossChoice{},
+	"CrossAdaptive":         &gago.CrossAdaptive{},
+	"CrossRepair":           gago.CrossRepair{},
+	"CrossLimit":            gago.CrossLimit{},
+	// Multi-parent crossovers
+	"CrossProportionateF": gago.CrossProportionateF{},
+	"CrossRankMuF":        gago.CrossRankMuF{},
+	// Mutators
+	"MutNormalF":        gago.MutNormalF{},
+	"MutGaussianF":      gago.MutGaussianF{},
+	"MutGaussianGenesF": gago.MutGaussianGenesF{},
+	"MutMirroredF":      gago.MutMirroredF{},
+	"MutMixed":          gago.MutMixed{},
+	"MutFlipB":          gago.MutFlipB{},
+	"MutFlipGenesB":     gago.MutFlipGenesB{},
+	"MutSplice":         gago.MutSplice{},
+	"MutPermute":        gago.MutPermute{},
+	"MutUniformS":       gago.MutUniformS{},
+	"MutFlipBitset":     gago.MutFlipBitset{},
+	"MutNormalVector":   gago.MutNormalVector{},
+	"MutProb":           gago.MutProb{},
+	"MutPipeline":       gago.MutPipeline{},
+	"MutSequence":       gago.MutSequence{},
+	"MutReset":          gago.MutReset{},
+	"MutChoice":         gago.MutChoice{},
+	"MutAdaptive":       &gago.MutAdaptive{},
+	"MutGuided":         &gago.MutGuided{},
+	"MutRepair":         gago.MutRepair{},
+	"MutLimit":          gago.MutLimit{},
+	// Models
+	"ModGenerational": gago.ModGenerational{},
+	"ModSteadyState":  gago.ModSteadyState{},
+	"ModDownToSize":   gago.ModDownToSize{},
+	"ModRing":         gago.ModRing{},
+	"ModSimAnn":       gago.ModSimAnn{},
+	"ModMutationOnly": gago.ModMutationOnly{},
+	"ModMultiParent":  gago.ModMultiParent{},
+	"ModMOEAD":        gago.ModMOEAD{},
+	"ModClearing":     gago.ModClearing{},
+	"ModCrowding":     gago.ModCrowding{},
+	"ModSpeciation":   gago.ModSpeciation{},
+	"ModNSGA2":        gago.ModNSGA2{},
+	"ModNSGA3":        gago.ModNSGA3{},
+	"ModMemetic":      gago.ModMemetic{},
+	"ModSexual":       gago.ModSexual{},
+	"ModCellular":     gago.ModCellular{},
+	"ModSurrogate":    gago.ModSurrogate{},
+	"ModRegularized":  gago.ModRegularized{},
+	"ModUMDA":         gago.ModUMDA{},
+	"ModPBIL":         &gago.ModPBIL{},
+	"ModACO":          &gago.ModACO{},
+	"ModHarmony":      gago.ModHarmony{},
+	"ModABC":          gago.ModABC{},
+	// Migrators and topologies
+	"MigShuffle":     gago.MigShuffle{},
+	"MigTopology":    gago.MigTopology{},
+	"MigArchipelago": &gago.MigArchipelago{},
+	"MigAdaptive":    &gago.MigAdaptive{},
+	"TopRing":        gago.TopRing{},
+	"TopComplete":    gago.TopComplete{},
+	"TopMatrix":      gago.TopMatrix{},
+	"TopGraph":       gago.TopGraph{},
+	// Distance metrics
+	"DistEuclidean":  gago.DistEuclidean{},
+	"DistHamming":    gago.DistHamming{},
+	"DistKendallTau": gago.DistKendallTau{},
+	"DistSwap":       gago.DistSwap{},
+	"DistBitset":     gago.DistBitset{},
+	"DistVector":     gago.DistVector{},
+	"DistMixed":      gago.DistMixed{},
+	// Local searchers and repairers
+	"LocalTwoOpt":   gago.LocalTwoOpt{},
+	"LocalThreeOpt": gago.LocalThreeOpt{},
+	"LocalOrOpt":    gago.LocalOrOpt{},
+	"LocalGradient": gago.LocalGradient{},
+	"RepSumI":       gago.RepSumI{},
+	"RepKnapsackB":  gago.RepKnapsackB{},
+	"RepClipF":      gago.RepClipF{},
+	"RepMixed":      gago.RepMixed{},
+	// Comparators
+	"CompFitness":       gago.CompFitness{},
+	"CompLexicographic": gago.CompLexicographic{},
+	"CompParsimony":     gago.CompParsimony{},
+	"CompConstrained":   gago.CompConstrained{},
+	"EpsilonConstraint": &gago.EpsilonConstraint{},
+	// Scalarizers, restarters and sizers
+	"ScalWeightedSum": gago.ScalWeightedSum{},
+	"ScalTchebycheff": gago.ScalTchebycheff{},
+	"ScalASF":         gago.ScalASF{},
+	"RestartFull":     gago.RestartFull{},
+	"RestartPartial":  gago.RestartPartial{},
+	"RestartIPOP":     gago.RestartIPOP{},
+	"SizeSawTooth":    gago.SizeSawTooth{},
+	"SizeLinear":      gago.SizeLinear{},
+	// Gene samplers
+	"SampleUniformF":    gago.SampleUniformF{},
+	"SampleLogUniformF": gago.SampleLogUniformF{},
+	"SampleUniformI":    gago.SampleUniformI{},
+	"SampleGrid":        gago.SampleGrid{},
+	"SamplePerGene":     gago.SamplePerGene{},
+}
+
+// The fields of a GA that are generated at runtime or set by Load.
+var runtimeFields = map[string]bool{
+	"Ff":          true,
+	"Duration":    true,
+	"Evaluations": true,
+	"Generations": true,
+	"Populations": true,
+	"Restarts":    true,
+	"Stagnation":  true,
+}
+
+// An Experiment is a GA together with the criteria that end it's run.
+type Experiment struct {
+	GA             gago.GA
+	MaxGenerations int
+	MaxDuration    time.Duration
+	MaxEvaluations int
+}
+
+// Validate the experiment to verify the GA is valid and that the run ends.
+func (exp Experiment) Validate() error {
+	// Check the GA
+	if err := exp.GA.Validate(); err != nil {
+		return err
+	}
+	// Check the termination criteria
+	if exp.MaxGenerations < 0 || exp.MaxDuration < 0 || exp.MaxEvaluations < 0 {
+		return errors.New("'max_generations', 'max_duration' and 'max_evaluations' should be positive")
+	}
+	if exp.MaxGenerations == 0 && exp.MaxDuration == 0 && exp.MaxEvaluations == 0 {
+		return errors.New("one of 'max_generations', 'max_duration' and 'max_evaluations' should be provided")
+	}
+	return nil
+}
+
+// Run initializes the GA and runs generations until one of the termination
+// criteria is met, then returns the statistics of the GA.
+func (exp *Experiment) Run() gago.Stats {
+	exp.GA.Initialize()
+	var start = time.Now()
+	for (exp.MaxGenerations == 0 || exp.GA.Generations < exp.MaxGenerations) &&
+		(exp.MaxDuration == 0 || time.Since(start) < exp.MaxDuration) &&
+		(exp.MaxEvaluations == 0 || exp.GA.Evaluations < exp.MaxEvaluations) {
+		exp.GA.Enhance()
+	}
+	return exp.GA.Stats()
+}
+
+// Load reads an experiment from a configuration file. The fitness key of the
+// file refers to one of the given functions. The returned experiment is
+// validated, errors mention the key or the line at fault.
+func Load(r io.Reader, functions map[string]gago.FitnessFunction) (Experiment, error) {
+	var table, err = Parse(r)
+	if err != nil {
+		return Experiment{}, err
+	}
+	return Build(table, functions)
+}
+
+// Build an experiment from the tables returned by Parse.
+func Build(table map[string]interface{}, functions map[string]gago.FitnessFunction) (Experiment, error) {
+	var exp Experiment
+	// Extract the keys that aren't fields of the GA
+	var fields = make(map[string]interface{})
+	for key, value := range table {
+		fields[key] = value
+	}
+	if value, ok := fields["fitness"]; ok {
+		delete(fields, "fitness")
+		var name, ok = value.(string)
+		if !ok {
+			return exp, fmt.Errorf("fitness: expected a string, got %s", describe(value))
+		}
+		var ff, found = functions[name]
+		if !found {
+			return exp, fmt.Errorf("fitness: unknown function '%s', expected one of %s", name, listKeys(functions))
+		}
+		exp.GA.Ff = ff
+	}
+	var limits = []struct {
+		key string
+		dst interface{}
+	}{
+		{"max_generations", &exp.MaxGenerations},
+		{"max_duration", &exp.MaxDuration},
+		{"max_evaluations", &exp.MaxEvaluations},
+	}
+	for _, limit := range limits {
+		if value, ok := fields[limit.key]; ok {
+			delete(fields, limit.key)
+			if err := decode(limit.key, value, reflect.ValueOf(limit.dst).Elem()); err != nil {
+				return exp, err
+			}
+		}
+	}
+	// Set the fields of the GA
+	if err := decodeStruct("", fields, reflect.ValueOf(&exp.GA).Elem(), runtimeFields); err != nil {
+		return exp, err
+	}
+	if exp.GA.Ff == nil {
+		return exp, fmt.Errorf("fitness: missing, expected one of %s", listKeys(functions))
+	}
+	if err := exp.Validate(); err != nil {
+		return exp, fmt.Errorf("invalid experiment: %s", err)
+	}
+	return exp, nil
+}
+
+// Join the path of a key with the key.
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// Describe the type of a parsed value for error messages.
+func describe(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "a string"
+	case int64:
+		return "an integer"
+	case float64:
+		return "a float"
+	case bool:
+		return "a boolean"
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "a table"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// Return the sorted keys of a map as a comma separated list.
+func listKeys(m interface{}) string {
+	var keys []string
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
+// Convert the name of a field to the snake case key used in configuration
+// files, for example NbParticipants becomes nb_participants.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, c := range name {
+		var upper = c >= 'A' && c <= 'Z'
+		if upper {
+			// Only split before an upper case letter that starts a word
+			var (
+				prevLower = i > 0 && name[i-1] >= 'a' && name[i-1] <= 'z'
+				nextLower = i > 0 && i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z' &&
+					name[i-1] >= 'A' && name[i-1] <= 'Z'
+			)
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// Set the fields of a struct from a table, the keys of skip are field names
+// that can't be set.
+func decodeStruct(path string, table map[string]interface{}, dst reflect.Value, skip map[string]bool) error {
+	var (
+		t      = dst.Type()
+		fields = make(map[string]int)
+		keys   []string
+	)
+	for i := 0; i < t.NumField(); i++ {
+		var field = t.Field(i)
+		if field.PkgPath != "" || skip[field.Name] {
+			continue
+		}
+		var key = snakeCase(field.Name)
+		fields[key] = i
+		keys = append(keys, key)
+	}
+	// Go through the keys in order so that errors are deterministic
+	var names []string
+	for key := range table {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	for _, key := range names {
+		var i, ok = fields[key]
+		if !ok {
+			if len(keys) == 0 {
+				return fmt.Errorf("%s: unknown parameter, %s has no parameters", join(path, key), t.Name())
+			}
+			return fmt.Errorf("%s: unknown parameter, expected one of %s", join(path, key), strings.Join(keys, ", "))
+		}
+		if err := decode(join(path, key), table[key], dst.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Build an operator from a table whose type key names one of the Types. The
+// operator has to be assignable to t.
+func decodeOperator(path string, table map[string]interface{}, t reflect.Type) (reflect.Value, error) {
+	var name, ok = table["type"].(string)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%s: missing 'type', expected one of %s", path, listTypes(t))
+	}
+	zero, ok := Types[name]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%s.type: unknown type '%s', expected one of %s", path, name, listTypes(t))
+	}
+	var operatorType = reflect.TypeOf(zero)
+	if !operatorType.AssignableTo(t) {
+		return reflect.Value{}, fmt.Errorf("%s.type: %s is not a %s, expected one of %s", path, name, t.Name(), listTypes(t))
+	}
+	var fields = make(map[string]interface{})
+	for key, value := range table {
+		if key != "type" {
+			fields[key] = value
+		}
+	}
+	var operator reflect.Value
+	if operatorType.Kind() == reflect.Ptr {
+		operator = reflect.New(operatorType.Elem())
+	} else {
+		operator = reflect.New(operatorType)
+	}
+	if err := decodeInto(path, fields, operator.Elem()); err != nil {
+		return reflect.Value{}, err
+	}
+	if operatorType.Kind() == reflect.Ptr {
+		return operator, nil
+	}
+	return operator.Elem(), nil
+}
+
+// Set an operator that is either a struct or a type whose value is given by
+// the values key.
+func decodeInto(path string, fields map[string]interface{}, dst reflect.Value) error {
+	if dst.Kind() == reflect.Struct {
+		return decodeStruct(path, fields, dst, nil)
+	}
+	for key := range fields {
+		if key != "values" {
+			return fmt.Errorf("%s: unknown parameter, expected values", join(path, key))
+		}
+	}
+	if value, ok := fields["values"]; ok {
+		return decode(join(path, "values"), value, dst)
+	}
+	return nil
+}
+
+// Return the names of the Types that are assignable to t.
+func listTypes(t reflect.Type) string {
+	var names []string
+	for name, zero := range Types {
+		if reflect.TypeOf(zero).AssignableTo(t) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Set a value from a parsed value.
+func decode(path string, value interface{}, dst reflect.Value) error {
+	var mismatch = func(expected string) error {
+		return fmt.Errorf("%s: expected %s, got %s", path, expected, describe(value))
+	}
+	// Durations are written as strings such as "1m30s"
+	if dst.Type() == reflect.TypeOf(time.Duration(0)) {
+		var s, ok = value.(string)
+		if !ok {
+			return mismatch("a duration such as \"1m30s\"")
+		}
+		var d, err = time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("%s: %s", path, err)
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+	// Values of an empty interface, such as genes, are set as they are except
+	// for integers which are genes of type int
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		if i, ok := value.(int64); ok {
+			value = int(i)
+		}
+		dst.Set(reflect.ValueOf(value))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Interface:
+		var table, ok = value.(map[string]interface{})
+		if !ok {
+			return mismatch("a table with a 'type' key")
+		}
+		var operator, err = decodeOperator(path, table, dst.Type())
+		if err != nil {
+			return err
+		}
+		dst.Set(operator)
+	case reflect.Ptr:
+		var elem = reflect.New(dst.Type().Elem())
+		if err := decode(path, value, elem.Elem()); err != nil {
+			return err
+		}
+		dst.Set(elem)
+	case reflect.Struct:
+		var table, ok = value.(map[string]interface{})
+		if !ok {
+			return mismatch("a table")
+		}
+		return decodeStruct(path, table, dst, nil)
+	case reflect.Slice:
+		var array, ok = value.([]interface{})
+		if !ok {
+			return mismatch("an array")
+		}
+		var slice = reflect.MakeSlice(dst.Type(), len(array), len(array))
+		for i, elem := range array {
+			if err := decode(fmt.Sprintf("%s[%d]", path, i), elem, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+	case reflect.Bool:
+		var b, ok = value.(bool)
+		if !ok {
+			return mismatch("a boolean")
+		}
+		dst.SetBool(b)
+	case reflect.String:
+		var s, ok = value.(string)
+		if !ok {
+			return mismatch("a string")
+		}
+		dst.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i, ok = value.(int64)
+		if !ok {
+			return mismatch("an integer")
+		}
+		if dst.OverflowInt(i) {
+			return fmt.Errorf("%s: %d is out of range", path, i)
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var i, ok = value.(int64)
+		if !ok {
+			return mismatch("an integer")
+		}
+		if i < 0 || dst.OverflowUint(uint64(i)) {
+			return fmt.Errorf("%s: %d is out of range", path, i)
+		}
+		dst.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		switch x := value.(type) {
+		case float64:
+			dst.SetFloat(x)
+		case int64:
+			dst.SetFloat(float64(x))
+		default:
+			return mismatch("a number")
+		}
+	default:
+		return fmt.Errorf("%s: values of type %s can't be set from a configuration file", path, dst.Type())
+	}
+	return nil
+}
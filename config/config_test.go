@@ -0,0 +1,272 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+var functions = map[string]gago.FitnessFunction{
+	"sphere": gago.Float64Function{Image: func(X []float64) float64 {
+		var sum float64
+		for _, x := range X {
+			sum += x * x
+		}
+		return sum
+	}},
+}
+
+const experimentFile = `
+fitness = "sphere"
+nbr_populations = 2
+nbr_individuals = 20
+nbr_genes = 3
+mig_frequency = 5
+max_generations = 10
+max_duration = "1m"
+
+[initializer]
+type = "InitUniformF"
+lower = -5
+upper = 5
+
+[model]
+type = "ModGenerational"
+mut_rate = 0.5
+selector = { type = "SelTournament", nb_participants = 3 }
+crossover = { type = "CrossUniformF" }
+mutator = { type = "MutNormalF", rate = 0.5, std = 1 }
+
+[[models]]
+type = "ModSteadyState"
+keep_best = true
+mut_rate = 0.5
+
+[models.selector]
+type = "SelElitism"
+
+[models.crossover]
+type = "CrossSequence"
+values = [{ type = "CrossUniformF" }, { type = "CrossArithmeticF" }]
+
+[models.mutator]
+type = "MutNormalF"
+rate = 0.5
+std = 1
+
+[migrator]
+type = "MigTopology"
+topology = { type = "TopMatrix", values = [[0, 1], [0.5, 0]] }
+`
+
+func TestLoad(t *testing.T) {
+	var exp, err = Load(strings.NewReader(experimentFile), functions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp.GA.NbrPopulations != 2 || exp.GA.NbrIndividuals != 20 || exp.GA.NbrGenes != 3 || exp.GA.MigFrequency != 5 {
+		t.Error("The sizes of the GA were not set correctly")
+	}
+	if exp.MaxGenerations != 10 || exp.MaxDuration != time.Minute || exp.MaxEvaluations != 0 {
+		t.Error("The termination criteria were not set correctly")
+	}
+	if !reflect.DeepEqual(exp.GA.Initializer, gago.InitUniformF{Lower: -5, Upper: 5}) {
+		t.Errorf("Wrong initializer: %v", exp.GA.Initializer)
+	}
+	var model = gago.ModGenerational{
+		Selector:  gago.SelTournament{NbParticipants: 3},
+		Crossover: gago.CrossUniformF{},
+		Mutator:   gago.MutNormalF{Rate: 0.5, Std: 1},
+		MutRate:   0.5,
+	}
+	if !reflect.DeepEqual(exp.GA.Model, model) {
+		t.Errorf("Wrong model: %v", exp.GA.Model)
+	}
+	if len(exp.GA.Models) != 1 {
+		t.Fatalf("Expected 1 model, got %d", len(exp.GA.Models))
+	}
+	var steadyState, ok = exp.GA.Models[0].(gago.ModSteadyState)
+	if !ok || !steadyState.KeepBest {
+		t.Errorf("Wrong model: %v", exp.GA.Models[0])
+	}
+	if !reflect.DeepEqual(steadyState.Crossover, gago.CrossSequence{gago.CrossUniformF{}, gago.CrossArithmeticF{}}) {
+		t.Errorf("Wrong crossover: %v", steadyState.Crossover)
+	}
+	if !reflect.DeepEqual(exp.GA.Migrator, gago.MigTopology{Topology: gago.TopMatrix{{0, 1}, {0.5, 0}}}) {
+		t.Errorf("Wrong migrator: %v", exp.GA.Migrator)
+	}
+	// Run the experiment
+	var stats = exp.Run()
+	if stats.Generations != 10 {
+		t.Errorf("Expected 10 generations, got %d", stats.Generations)
+	}
+}
+
+func TestLoadPointerTypes(t *testing.T) {
+	var file = `
+fitness = "sphere"
+nbr_populations = 4
+nbr_individuals = 10
+nbr_genes = 2
+mig_frequency = 2
+max_evaluations = 200
+initializer = { type = "InitSobolF", lower = -1, upper = 1 }
+
+[model]
+type = "ModGenerational"
+selector = { type = "SelElitism" }
+crossover = { type = "CrossUniformF" }
+
+[migrator]
+type = "MigArchipelago"
+size = 2
+intra = { type = "MigShuffle" }
+inter = { type = "MigTopology", topology = { type = "TopRing", rate = 1 } }
+`
+	var exp, err = Load(strings.NewReader(file), functions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := exp.GA.Initializer.(*gago.InitSobolF); !ok {
+		t.Errorf("Expected a *InitSobolF, got %T", exp.GA.Initializer)
+	}
+	var mig, ok = exp.GA.Migrator.(*gago.MigArchipelago)
+	if !ok || mig.Size != 2 {
+		t.Errorf("Wrong migrator: %v", exp.GA.Migrator)
+	}
+	if stats := exp.Run(); stats.Evaluations < 200 {
+		t.Errorf("Expected at least 200 evaluations, got %d", stats.Evaluations)
+	}
+}
+
+func TestLoadSampler(t *testing.T) {
+	var file = `
+fitness = "sphere"
+nbr_populations = 1
+nbr_individuals = 10
+nbr_genes = 2
+max_generations = 1
+
+[initializer]
+type = "InitSampler"
+sampler = { type = "SamplePerGene", values = [{ type = "SampleLogUniformF", lower = 0.001, upper = 0.1 }, { type = "SampleGrid", values = [16, 32, 64] }] }
+
+[model]
+type = "ModGenerational"
+selector = { type = "SelElitism" }
+crossover = { type = "CrossUniformF" }
+`
+	var exp, err = Load(strings.NewReader(file), functions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var init, ok = exp.GA.Initializer.(gago.InitSampler)
+	if !ok {
+		t.Fatalf("Expected an InitSampler, got %T", exp.GA.Initializer)
+	}
+	var samplers = init.Sampler.(gago.SamplePerGene)
+	if len(samplers) != 2 {
+		t.Fatalf("Expected 2 samplers, got %d", len(samplers))
+	}
+	if grid := samplers[1].(gago.SampleGrid); len(grid.Values) != 3 || grid.Values[0] != 16 {
+		t.Errorf("Wrong grid: %v", grid.Values)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	var base = `
+fitness = "sphere"
+nbr_populations = 1
+nbr_individuals = 10
+nbr_genes = 2
+max_generations = 5
+initializer = { type = "InitUniformF", lower = -1, upper = 1 }
+`
+	var testCases = []struct {
+		file, err string
+	}{
+		{base + `model = { type = "ModGenerational", selector = { type = "SelElitism" }, crossover = { type = "CrossUniformF" } }`, ""},
+		{base + `model = { type = "ModGenerational", selector = { type = "SelElitism" }, crossover = { type = "CrossUniformF" } }` + "\nbad", "line 9"},
+		{strings.Replace(base, `"sphere"`, `"rastrigin"`, 1) + `model = { type = "ModSteadyState" }`, "fitness: unknown function 'rastrigin', expected one of sphere"},
+		{base + `model = { type = "ModFoo" }`, "model.type: unknown type 'ModFoo'"},
+		{base + `model = { type = "SelElitism" }`, "model.type: SelElitism is not a Model"},
+		{base + `model = { selector = { type = "SelElitism" } }`, "model: missing 'type', expected one of "},
+		{base + `model = "ModGenerational"`, "model: expected a table with a 'type' key, got a string"},
+		{base + `model = { type = "ModGenerational", mut_rat = 0.5 }`, "model.mut_rat: unknown parameter, expected one of selector, pairer, crossover, mutator, mut_rate, reuse"},
+		{base + `model = { type = "ModGenerational", selector = { type = "SelElitism", size = 2 } }`, "model.selector.size: unknown parameter, SelElitism has no parameters"},
+		{base + `model = { type = "ModGenerational", mut_rate = "high" }`, "model.mut_rate: expected a number, got a string"},
+		{base + `model = { type = "ModGenerational", selector = { type = "SelTournament", nb_participants = 2.5 } }`, "model.selector.nb_participants: expected an integer, got a float"},
+		{base + `generations = 3`, "generations: unknown parameter"},
+		{base + `model = { type = "ModGenerational", crossover = { type = "CrossUniformF" } }`, "invalid experiment: 'Selector' cannot be nil"},
+		{strings.Replace(base, "max_generations = 5", "max_duration = 5", 1), "max_duration: expected a duration"},
+		{strings.Replace(base, "max_generations = 5", "", 1) + `model = { type = "ModGenerational", selector = { type = "SelElitism" }, crossover = { type = "CrossUniformF" } }`, "one of 'max_generations', 'max_duration' and 'max_evaluations' should be provided"},
+	}
+	for _, test := range testCases {
+		var _, err = Load(strings.NewReader(test.file), functions)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Expected an error containing %q, got %v", test.err, err)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	var testCases = []struct {
+		name, key string
+	}{
+		{"Rate", "rate"},
+		{"NbParticipants", "nb_participants"},
+		{"MutRate", "mut_rate"},
+		{"IGD", "igd"},
+		{"IGDScore", "igd_score"},
+	}
+	for _, test := range testCases {
+		if key := snakeCase(test.name); key != test.key {
+			t.Errorf("Expected %s, got %s", test.key, key)
+		}
+	}
+}
+
+func TestTypes(t *testing.T) {
+	var interfaces = []reflect.Type{
+		reflect.TypeOf((*gago.Initializer)(nil)).Elem(),
+		reflect.TypeOf((*gago.Selector)(nil)).Elem(),
+		reflect.TypeOf((*gago.Pairer)(nil)).Elem(),
+		reflect.TypeOf((*gago.Crossover)(nil)).Elem(),
+		reflect.TypeOf((*gago.MultiCrossover)(nil)).Elem(),
+		reflect.TypeOf((*gago.Mutator)(nil)).Elem(),
+		reflect.TypeOf((*gago.Model)(nil)).Elem(),
+		reflect.TypeOf((*gago.Migrator)(nil)).Elem(),
+		reflect.TypeOf((*gago.Topology)(nil)).Elem(),
+		reflect.TypeOf((*gago.DistanceMetric)(nil)).Elem(),
+		reflect.TypeOf((*gago.LocalSearcher)(nil)).Elem(),
+		reflect.TypeOf((*gago.Repairer)(nil)).Elem(),
+		reflect.TypeOf((*gago.Scalarizer)(nil)).Elem(),
+		reflect.TypeOf((*gago.Restarter)(nil)).Elem(),
+		reflect.TypeOf((*gago.PopulationSizer)(nil)).Elem(),
+		reflect.TypeOf((*gago.Comparator)(nil)).Elem(),
+		reflect.TypeOf((*gago.GeneSampler)(nil)).Elem(),
+	}
+	for name, zero := range Types {
+		var implements bool
+		for _, it := range interfaces {
+			if reflect.TypeOf(zero).Implements(it) {
+				implements = true
+			}
+		}
+		if !implements {
+			t.Errorf("%s doesn't implement any operator interface", name)
+		}
+		if !strings.HasSuffix(reflect.TypeOf(zero).String(), "."+name) {
+			t.Errorf("%s is registered as %T", name, zero)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The configuration files are written in a subset of TOML which covers what
+// is needed to describe a GA: comments, tables ([a.b]), arrays of tables
+// ([[a.b]]) and key/value pairs whose values are strings, integers, floats,
+// booleans, arrays and inline tables. Integers are decimal, without leading
+// zeros, unless they are prefixed with 0x, 0o or 0b. Multi-line strings,
+// multi-line arrays, dates and dotted keys are not supported. Tables are
+// parsed into map[string]interface{}, arrays into []interface{}, integers into
+// int64 and floats into float64.
+
+// A ParseError tells where a configuration file is malformed.
+type ParseError struct {
+	Line int
+	Msg  string
+}
+
+func (err ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", err.Line, err.Msg)
+}
+
+// Parse reads a configuration file into nested tables.
+func Parse(r io.Reader) (map[string]interface{}, error) {
+	var (
+		root    = make(map[string]interface{})
+		current = root
+		scanner = bufio.NewScanner(r)
+		line    int
+	)
+	for scanner.Scan() {
+		line++
+		var text = strings.TrimSpace(stripComment(scanner.Text()))
+		if text == "" {
+			continue
+		}
+		var err error
+		switch {
+		case strings.HasPrefix(text, "[["):
+			if !strings.HasSuffix(text, "]]") {
+				return nil, ParseError{line, "unterminated array of tables header"}
+			}
+			current, err = appendTable(root, strings.TrimSpace(text[2:len(text)-2]))
+		case strings.HasPrefix(text, "["):
+			if !strings.HasSuffix(text, "]") {
+				return nil, ParseError{line, "unterminated table header"}
+			}
+			current, err = getTable(root, strings.TrimSpace(text[1:len(text)-1]))
+		default:
+			err = parseKeyValue(text, current)
+		}
+		if err != nil {
+			return nil, ParseError{line, err.Error()}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// Remove the comment at the end of a line, if any.
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// Split a table name into it's keys.
+func splitPath(path string) ([]string, error) {
+	var keys = strings.Split(path, ".")
+	for i, key := range keys {
+		keys[i] = strings.TrimSpace(key)
+		if !isBareKey(keys[i]) {
+			return nil, fmt.Errorf("invalid table name '%s'", path)
+		}
+	}
+	return keys, nil
+}
+
+// Check a key only contains letters, digits, underscores and dashes.
+func isBareKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// Return the table at the end of a path, the missing tables are created. The
+// last element of an array of tables is used when the path goes through one.
+func getTable(root map[string]interface{}, path string) (map[string]interface{}, error) {
+	var keys, err = splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	var table = root
+	for _, key := range keys {
+		switch value := table[key].(type) {
+		case nil:
+			var child = make(map[string]interface{})
+			table[key] = child
+			table = child
+		case map[string]interface{}:
+			table = value
+		case []interface{}:
+			if len(value) == 0 {
+				return nil, fmt.Errorf("'%s' is an empty array, not a table", key)
+			}
+			var last, ok = value[len(value)-1].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("'%s' is not a table", key)
+			}
+			table = last
+		default:
+			return nil, fmt.Errorf("'%s' is not a table", key)
+		}
+	}
+	return table, nil
+}
+
+// Append a new table to the array of tables at the end of a path.
+func appendTable(root map[string]interface{}, path string) (map[string]interface{}, error) {
+	var keys, err = splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	var parent = root
+	if len(keys) > 1 {
+		if parent, err = getTable(root, strings.Join(keys[:len(keys)-1], ".")); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		key   = keys[len(keys)-1]
+		table = make(map[string]interface{})
+	)
+	switch value := parent[key].(type) {
+	case nil:
+		parent[key] = []interface{}{table}
+	case []interface{}:
+		parent[key] = append(value, table)
+	default:
+		return nil, fmt.Errorf("'%s' is not an array of tables", key)
+	}
+	return table, nil
+}
+
+// Parse a key/value pair into a table.
+func parseKeyValue(text string, table map[string]interface{}) error {
+	var i = strings.IndexByte(text, '=')
+	if i < 0 {
+		return fmt.Errorf("expected 'key = value', got '%s'", text)
+	}
+	var key = strings.TrimSpace(text[:i])
+	if !isBareKey(key) {
+		return fmt.Errorf("invalid key '%s'", key)
+	}
+	if _, ok := table[key]; ok {
+		return fmt.Errorf("duplicate key '%s'", key)
+	}
+	var value, rest, err = parseValue(strings.TrimSpace(text[i+1:]))
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(rest) != "" {
+		return fmt.Errorf("unexpected '%s' after the value of '%s'", strings.TrimSpace(rest), key)
+	}
+	table[key] = value
+	return nil
+}
+
+// Parse the value at the beginning of a string and return the rest of the
+// string.
+func parseValue(s string) (interface{}, string, error) {
+	s = strings.TrimLeft(s, " \t")
+	if s == "" {
+		return nil, "", fmt.Errorf("missing value")
+	}
+	switch s[0] {
+	case '"':
+		// Find the closing quote, skipping the escaped characters
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == '"' {
+				var value, err = strconv.Unquote(s[:i+1])
+				return value, s[i+1:], err
+			}
+		}
+		return nil, "", fmt.Errorf("unterminated string")
+	case '\'':
+		var i = strings.IndexByte(s[1:], '\'')
+		if i < 0 {
+			return nil, "", fmt.Errorf("unterminated string")
+		}
+		return s[1 : i+1], s[i+2:], nil
+	case '[':
+		return parseArray(s[1:])
+	case '{':
+		return parseInlineTable(s[1:])
+	}
+	// Read up to the next delimiter
+	var end = strings.IndexAny(s, ",]} \t")
+	if end < 0 {
+		end = len(s)
+	}
+	var token = s[:end]
+	switch token {
+	case "true":
+		return true, s[end:], nil
+	case "false":
+		return false, s[end:], nil
+	case "inf", "+inf", "-inf", "nan", "+nan", "-nan":
+		var f, _ = strconv.ParseFloat(token, 64)
+		return f, s[end:], nil
+	}
+	var clean = strings.Replace(token, "_", "", -1)
+	// Decimal numbers can't have leading zeros, hence 010 isn't read as an
+	// octal number
+	var digits = strings.TrimLeft(clean, "+-")
+	if len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9' {
+		return nil, "", fmt.Errorf("invalid value '%s', leading zeros are not allowed", token)
+	}
+	if i, err := parseInt(clean); err == nil {
+		return i, s[end:], nil
+	}
+	if f, err := strconv.ParseFloat(clean, 64); err == nil {
+		return f, s[end:], nil
+	}
+	return nil, "", fmt.Errorf("invalid value '%s'", token)
+}
+
+// Parse an integer, which is decimal unless it's prefixed with 0x, 0o or 0b.
+func parseInt(s string) (int64, error) {
+	var digits = strings.TrimLeft(s, "+-")
+	if len(digits) > 1 && digits[0] == '0' && strings.IndexByte("xob", digits[1]) >= 0 {
+		return strconv.ParseInt(s, 0, 64)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// Parse the elements of an array whose opening bracket has been consumed.
+func parseArray(s string) (interface{}, string, error) {
+	var array = []interface{}{}
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if strings.HasPrefix(s, "]") {
+			return array, s[1:], nil
+		}
+		var value, rest, err = parseValue(s)
+		if err != nil {
+			return nil, "", err
+		}
+		array = append(array, value)
+		s = strings.TrimLeft(rest, " \t")
+		switch {
+		case strings.HasPrefix(s, ","):
+			s = s[1:]
+		case strings.HasPrefix(s, "]"):
+		default:
+			return nil, "", fmt.Errorf("unterminated array")
+		}
+	}
+}
+
+// Parse the pairs of an inline table whose opening brace has been consumed.
+func parseInlineTable(s string) (interface{}, string, error) {
+	var table = make(map[string]interface{})
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if strings.HasPrefix(s, "}") {
+			return table, s[1:], nil
+		}
+		var i = strings.IndexByte(s, '=')
+		if i < 0 {
+			return nil, "", fmt.Errorf("unterminated inline table")
+		}
+		var key = strings.TrimSpace(s[:i])
+		if !isBareKey(key) {
+			return nil, "", fmt.Errorf("invalid key '%s'", key)
+		}
+		var value, rest, err = parseValue(s[i+1:])
+		if err != nil {
+			return nil, "", err
+		}
+		table[key] = value
+		s = strings.TrimLeft(rest, " \t")
+		switch {
+		case strings.HasPrefix(s, ","):
+			s = s[1:]
+		case strings.HasPrefix(s, "}"):
+		default:
+			return nil, "", fmt.Errorf("unterminated inline table")
+		}
+	}
+}
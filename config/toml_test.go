@@ -0,0 +1,112 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	var (
+		file = `
+# A comment
+name = "a # b" # Another comment
+literal = 'C:\path'
+count = 1_000
+rate = 0.5
+exp = 1e-3
+hex = 0x10
+oct = 0o10
+bin = 0b10
+zero = 0
+neg = -2
+flag = true
+list = [1, 2.5, "three", [4, 5]]
+empty = []
+inline = { type = "TopRing", rate = 0.5 }
+
+[a.b]
+c = false
+
+[[models]]
+type = "ModGenerational"
+
+[[models]]
+type = "ModSteadyState"
+
+[models.selector]
+type = "SelElitism"
+`
+		expected = map[string]interface{}{
+			"name":    "a # b",
+			"literal": `C:\path`,
+			"count":   int64(1000),
+			"rate":    0.5,
+			"exp":     0.001,
+			"hex":     int64(16),
+			"oct":     int64(8),
+			"bin":     int64(2),
+			"zero":    int64(0),
+			"neg":     int64(-2),
+			"flag":    true,
+			"list":    []interface{}{int64(1), 2.5, "three", []interface{}{int64(4), int64(5)}},
+			"empty":   []interface{}{},
+			"inline":  map[string]interface{}{"type": "TopRing", "rate": 0.5},
+			"a": map[string]interface{}{
+				"b": map[string]interface{}{"c": false},
+			},
+			"models": []interface{}{
+				map[string]interface{}{"type": "ModGenerational"},
+				map[string]interface{}{
+					"type":     "ModSteadyState",
+					"selector": map[string]interface{}{"type": "SelElitism"},
+				},
+			},
+		}
+	)
+	var table, err = Parse(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(table, expected) {
+		t.Errorf("Expected %v, got %v", expected, table)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var testCases = []struct {
+		file string
+		line int
+	}{
+		{"a = 1\nb", 2},
+		{"a = 1\na = 2", 2},
+		{"\n\na = \"unterminated", 3},
+		{"a = [1, 2", 1},
+		{"a = {b = 1", 1},
+		{"a = 1 2", 1},
+		{"a = nope", 1},
+		{"[a", 1},
+		{"[[a]", 1},
+		{"[a..b]", 1},
+		{"a = 1\n[a]", 2},
+		{"[a]\n[[a]]", 2},
+		{"bad key = 1", 1},
+		{"a = []\n[a]", 2},
+		{"a = []\n[a.b]", 2},
+		{"a = [1]\n[a]", 2},
+		{"a = 010", 1},
+		{"a = -007", 1},
+		{"a = 01.5", 1},
+	}
+	for _, test := range testCases {
+		var _, err = Parse(strings.NewReader(test.file))
+		var parseErr, ok = err.(ParseError)
+		if !ok {
+			t.Errorf("Expected a ParseError for %q, got %v", test.file, err)
+			continue
+		}
+		if parseErr.Line != test.line {
+			t.Errorf("Expected an error at line %d for %q, got %v", test.line, test.file, err)
+		}
+	}
+}
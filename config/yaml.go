@@ -0,0 +1,359 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/MaxHalford/gago"
+)
+
+// The configuration files can also be written in a subset of YAML which
+// describes the same tables as the TOML files: comments, block mappings and
+// sequences, whose items can be compact mappings (- type: SelElitism), and
+// flow mappings ({a: 1}) and sequences ([1, 2]) written on a single line. The
+// scalars are strings, plain or quoted, integers, floats and booleans.
+// Multi-line scalars, anchors, tags, null values and documents other than the
+// first one are not supported. Keys only contain letters, digits, underscores
+// and dashes, as in the TOML files.
+
+// A yamlLine is a line of a YAML file without it's indentation and comment.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// ParseYAML reads a YAML configuration file into the same nested tables as
+// Parse.
+func ParseYAML(r io.Reader) (map[string]interface{}, error) {
+	var (
+		lines   []yamlLine
+		scanner = bufio.NewScanner(r)
+		number  int
+	)
+	for scanner.Scan() {
+		number++
+		var text = strings.TrimRight(stripYAMLComment(scanner.Text()), " \t")
+		var trimmed = strings.TrimLeft(text, " ")
+		if trimmed == "" || len(lines) == 0 && trimmed == "---" {
+			continue
+		}
+		if trimmed[0] == '\t' {
+			return nil, ParseError{number, "tabs can't be used for indentation"}
+		}
+		if trimmed == "---" || trimmed == "..." {
+			return nil, ParseError{number, "only a single document is supported"}
+		}
+		lines = append(lines, yamlLine{number, len(text) - len(trimmed), trimmed})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	var root = make(map[string]interface{})
+	if len(lines) == 0 {
+		return root, nil
+	}
+	var p = yamlParser{lines: lines}
+	if isSequenceItem(lines[0].text) {
+		return nil, ParseError{lines[0].number, "expected a mapping at the top level"}
+	}
+	if err := p.parseMapping(lines[0].indent, root); err != nil {
+		return nil, err
+	}
+	if p.i < len(lines) {
+		return nil, ParseError{lines[p.i].number, "unexpected indentation"}
+	}
+	return root, nil
+}
+
+// LoadYAML reads an experiment from a YAML configuration file, in the same
+// way as Load.
+func LoadYAML(r io.Reader, functions map[string]gago.FitnessFunction) (Experiment, error) {
+	var table, err = ParseYAML(r)
+	if err != nil {
+		return Experiment{}, err
+	}
+	return Build(table, functions)
+}
+
+// Remove the comment at the end of a line, if any. A comment starts with a #
+// at the beginning of the line or after a space.
+func stripYAMLComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		case (c == '"' || c == '\'') && (i == 0 || strings.IndexByte(" \t[{,:", text[i-1]) >= 0):
+			quote = c
+		}
+	}
+	return text
+}
+
+// Check if a line is an item of a block sequence.
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// Split a "key: value" line, ok is false if the line isn't a pair.
+func splitPair(text string) (key, value string, ok bool) {
+	var i = strings.IndexByte(text, ':')
+	if i < 0 || !isBareKey(strings.TrimSpace(text[:i])) {
+		return "", "", false
+	}
+	if i+1 < len(text) && text[i+1] != ' ' {
+		return "", "", false
+	}
+	return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+}
+
+// A yamlParser goes through the lines of a YAML file.
+type yamlParser struct {
+	lines []yamlLine
+	i     int
+}
+
+// Parse the value of a key or of a sequence item whose text is empty, the
+// value is the block that follows, which is indented deeper than indent. The
+// items of a sequence that is the value of a key can also have the indentation
+// of the key.
+func (p *yamlParser) parseBlock(number, indent int, key bool) (interface{}, error) {
+	if p.i >= len(p.lines) {
+		return nil, ParseError{number, "missing value"}
+	}
+	var next = p.lines[p.i]
+	switch {
+	case isSequenceItem(next.text) && (next.indent > indent || key && next.indent == indent):
+		var sequence = []interface{}{}
+		return sequence, p.parseSequence(next.indent, &sequence)
+	case next.indent > indent:
+		var mapping = make(map[string]interface{})
+		return mapping, p.parseMapping(next.indent, mapping)
+	}
+	return nil, ParseError{number, "missing value"}
+}
+
+// Parse the pairs of a block mapping whose keys are indented by indent.
+func (p *yamlParser) parseMapping(indent int, mapping map[string]interface{}) error {
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent && !isSequenceItem(p.lines[p.i].text) {
+		var line = p.lines[p.i]
+		var key, text, ok = splitPair(line.text)
+		if !ok {
+			return ParseError{line.number, fmt.Sprintf("expected 'key: value', got '%s'", line.text)}
+		}
+		if _, ok := mapping[key]; ok {
+			return ParseError{line.number, fmt.Sprintf("duplicate key '%s'", key)}
+		}
+		p.i++
+		var (
+			value interface{}
+			err   error
+		)
+		if text == "" {
+			value, err = p.parseBlock(line.number, indent, true)
+		} else {
+			value, err = parseYAMLValue(line.number, text)
+		}
+		if err != nil {
+			return err
+		}
+		mapping[key] = value
+	}
+	if p.i < len(p.lines) && p.lines[p.i].indent > indent {
+		return ParseError{p.lines[p.i].number, "unexpected indentation"}
+	}
+	return nil
+}
+
+// Parse the items of a block sequence whose dashes are indented by indent.
+func (p *yamlParser) parseSequence(indent int, sequence *[]interface{}) error {
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent && isSequenceItem(p.lines[p.i].text) {
+		var (
+			line  = p.lines[p.i]
+			text  = strings.TrimLeft(line.text[1:], " ")
+			value interface{}
+			err   error
+		)
+		switch _, _, ok := splitPair(text); {
+		case text == "":
+			p.i++
+			value, err = p.parseBlock(line.number, indent, false)
+		case ok:
+			// The item is a mapping whose first key follows the dash, the
+			// line is replaced by the pair so that it lines up with the
+			// following keys
+			var mapping = make(map[string]interface{})
+			p.lines[p.i] = yamlLine{line.number, line.indent + len(line.text) - len(text), text}
+			value, err = mapping, p.parseMapping(p.lines[p.i].indent, mapping)
+		default:
+			p.i++
+			value, err = parseYAMLValue(line.number, text)
+		}
+		if err != nil {
+			return err
+		}
+		*sequence = append(*sequence, value)
+	}
+	if p.i < len(p.lines) && p.lines[p.i].indent > indent {
+		return ParseError{p.lines[p.i].number, "unexpected indentation"}
+	}
+	return nil
+}
+
+// Parse a value written on a single line.
+func parseYAMLValue(number int, text string) (interface{}, error) {
+	var value, rest, err = parseFlow(text, "")
+	if err == nil && strings.TrimSpace(rest) != "" {
+		err = fmt.Errorf("unexpected '%s' after the value", strings.TrimSpace(rest))
+	}
+	if err != nil {
+		return nil, ParseError{number, err.Error()}
+	}
+	return value, nil
+}
+
+// Parse the value at the beginning of a string and return the rest of the
+// string. Plain scalars end at one of the delimiters.
+func parseFlow(s string, delimiters string) (interface{}, string, error) {
+	s = strings.TrimLeft(s, " ")
+	if s == "" {
+		return nil, "", fmt.Errorf("missing value")
+	}
+	switch s[0] {
+	case '"':
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == '"' {
+				var value, err = strconv.Unquote(s[:i+1])
+				return value, s[i+1:], err
+			}
+		}
+		return nil, "", fmt.Errorf("unterminated string")
+	case '\'':
+		// Quotes are escaped by doubling them
+		var value strings.Builder
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				value.WriteByte(s[i])
+			} else if i+1 < len(s) && s[i+1] == '\'' {
+				value.WriteByte('\'')
+				i++
+			} else {
+				return value.String(), s[i+1:], nil
+			}
+		}
+		return nil, "", fmt.Errorf("unterminated string")
+	case '[':
+		return parseFlowSequence(s[1:])
+	case '{':
+		return parseFlowMapping(s[1:])
+	case '&', '*', '!', '|', '>':
+		return nil, "", fmt.Errorf("'%c' is not supported", s[0])
+	}
+	var end = strings.IndexAny(s, delimiters)
+	if end < 0 {
+		end = len(s)
+	}
+	var value, err = parseScalar(strings.TrimSpace(s[:end]))
+	return value, s[end:], err
+}
+
+// Parse a plain scalar, which is a string unless it's a boolean or a number.
+func parseScalar(s string) (interface{}, error) {
+	switch s {
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case "null", "Null", "NULL", "~":
+		return nil, fmt.Errorf("null values are not supported")
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1), nil
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1), nil
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN(), nil
+	}
+	// Strings such as "nan" or "infinity" are not numbers
+	if !strings.ContainsAny(s, "0123456789") {
+		return s, nil
+	}
+	if i, err := parseInt(s); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, nil
+	}
+	return s, nil
+}
+
+// Parse the items of a flow sequence whose opening bracket has been consumed.
+func parseFlowSequence(s string) (interface{}, string, error) {
+	var sequence = []interface{}{}
+	for {
+		s = strings.TrimLeft(s, " ")
+		if strings.HasPrefix(s, "]") {
+			return sequence, s[1:], nil
+		}
+		var value, rest, err = parseFlow(s, ",]")
+		if err != nil {
+			return nil, "", err
+		}
+		sequence = append(sequence, value)
+		s = strings.TrimLeft(rest, " ")
+		switch {
+		case strings.HasPrefix(s, ","):
+			s = s[1:]
+		case strings.HasPrefix(s, "]"):
+		default:
+			return nil, "", fmt.Errorf("unterminated sequence")
+		}
+	}
+}
+
+// Parse the pairs of a flow mapping whose opening brace has been consumed.
+func parseFlowMapping(s string) (interface{}, string, error) {
+	var mapping = make(map[string]interface{})
+	for {
+		s = strings.TrimLeft(s, " ")
+		if strings.HasPrefix(s, "}") {
+			return mapping, s[1:], nil
+		}
+		var i = strings.IndexByte(s, ':')
+		if i < 0 {
+			return nil, "", fmt.Errorf("unterminated mapping")
+		}
+		var key = strings.TrimSpace(s[:i])
+		if !isBareKey(key) {
+			return nil, "", fmt.Errorf("invalid key '%s'", key)
+		}
+		if _, ok := mapping[key]; ok {
+			return nil, "", fmt.Errorf("duplicate key '%s'", key)
+		}
+		var value, rest, err = parseFlow(s[i+1:], ",}")
+		if err != nil {
+			return nil, "", err
+		}
+		mapping[key] = value
+		s = strings.TrimLeft(rest, " ")
+		switch {
+		case strings.HasPrefix(s, ","):
+			s = s[1:]
+		case strings.HasPrefix(s, "}"):
+		default:
+			return nil, "", fmt.Errorf("unterminated mapping")
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	var (
+		file = `
+---
+# A comment
+name: "a # b" # Another comment
+literal: 'it''s C:\path'
+plain: a#b c
+url: http://example.com
+count: 1000
+rate: 0.5
+exp: 1e-3
+hex: 0x10
+neg: -2
+inf: -.inf
+flag: true
+list: [1, 2.5, three, [4, 5]]
+empty: []
+inline: {type: TopRing, rate: 0.5}
+a:
+  b:
+    c: false
+models:
+- type: ModGenerational
+- type: ModSteadyState
+  selector:
+    type: SelElitism
+genes:
+  - 1
+  -
+    - x
+    - 'y'
+`
+		expected = map[string]interface{}{
+			"name":    "a # b",
+			"literal": `it's C:\path`,
+			"plain":   "a#b c",
+			"url":     "http://example.com",
+			"count":   int64(1000),
+			"rate":    0.5,
+			"exp":     0.001,
+			"hex":     int64(16),
+			"neg":     int64(-2),
+			"inf":     math.Inf(-1),
+			"flag":    true,
+			"list":    []interface{}{int64(1), 2.5, "three", []interface{}{int64(4), int64(5)}},
+			"empty":   []interface{}{},
+			"inline":  map[string]interface{}{"type": "TopRing", "rate": 0.5},
+			"a": map[string]interface{}{
+				"b": map[string]interface{}{"c": false},
+			},
+			"models": []interface{}{
+				map[string]interface{}{"type": "ModGenerational"},
+				map[string]interface{}{
+					"type":     "ModSteadyState",
+					"selector": map[string]interface{}{"type": "SelElitism"},
+				},
+			},
+			"genes": []interface{}{int64(1), []interface{}{"x", "y"}},
+		}
+	)
+	var table, err = ParseYAML(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(table, expected) {
+		t.Errorf("Expected %v, got %v", expected, table)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	var testCases = []struct {
+		file string
+		line int
+	}{
+		{"a: 1\nb", 2},
+		{"a: 1\na: 2", 2},
+		{"\n\na: \"unterminated", 3},
+		{"a: [1, 2", 1},
+		{"a: {b: 1", 1},
+		{"a: {b: 1, b: 2}", 1},
+		{"a: 1\n  b: 2", 2},
+		{"a:\nb: 1", 1},
+		{"a: ~", 1},
+		{"a: &anchor 1", 1},
+		{"- 1", 1},
+		{"a:\n\t- 1", 2},
+		{"a: 1\n---\nb: 2", 2},
+		{"bad key: 1", 1},
+	}
+	for _, test := range testCases {
+		var _, err = ParseYAML(strings.NewReader(test.file))
+		var parseErr, ok = err.(ParseError)
+		if !ok {
+			t.Errorf("Expected a ParseError for %q, got %v", test.file, err)
+			continue
+		}
+		if parseErr.Line != test.line {
+			t.Errorf("Expected an error at line %d for %q, got %v", test.line, test.file, err)
+		}
+	}
+}
+
+// The YAML version of experimentFile.
+const experimentYAML = `
+fitness: sphere
+nbr_populations: 2
+nbr_individuals: 20
+nbr_genes: 3
+mig_frequency: 5
+max_generations: 10
+max_duration: 1m
+
+initializer:
+  type: InitUniformF
+  lower: -5
+  upper: 5
+
+model:
+  type: ModGenerational
+  mut_rate: 0.5
+  selector: {type: SelTournament, nb_participants: 3}
+  crossover: {type: CrossUniformF}
+  mutator: {type: MutNormalF, rate: 0.5, std: 1}
+
+models:
+  - type: ModSteadyState
+    keep_best: true
+    mut_rate: 0.5
+    selector:
+      type: SelElitism
+    crossover:
+      type: CrossSequence
+      values: [{type: CrossUniformF}, {type: CrossArithmeticF}]
+    mutator:
+      type: MutNormalF
+      rate: 0.5
+      std: 1
+
+migrator:
+  type: MigTopology
+  topology: {type: TopMatrix, values: [[0, 1], [0.5, 0]]}
+`
+
+func TestLoadYAML(t *testing.T) {
+	var (
+		toml, _   = Parse(strings.NewReader(experimentFile))
+		yaml, err = ParseYAML(strings.NewReader(experimentYAML))
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(toml, yaml) {
+		t.Errorf("The YAML file doesn't describe the same tables as the TOML file: %v", yaml)
+	}
+	exp, err := LoadYAML(strings.NewReader(experimentYAML), functions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := exp.Run(); stats.Generations != 10 {
+		t.Errorf("Expected 10 generations, got %d", stats.Generations)
+	}
+}
@@ -0,0 +1,72 @@
+package gago
+
+// NewFloatGA returns a GA for minimizing a function of dim real variables that
+// belong to [lower, upper]. The initial population is spread over the domain
+// with a Halton sequence, offsprings are produced by a BLX-0.5 crossover and
+// each of their genes is shifted with probability 1/dim by a gaussian
+// perturbation whose standard deviation is a tenth of the domain's width. The
+// genes are clipped to the domain after each crossover and mutation. The sizes
+// of the populations and of the tournaments are picked by ScaleLog, see
+// NewScaledGA. Every field of the returned GA can be changed before calling
+// Initialize.
+func NewFloatGA(dim int, lower, upper float64, fitness func([]float64) float64) GA {
+	var clip = RepClipF{Lower: lower, Upper: upper}
+	return NewScaledGA(
+		dim,
+		Float64Function{Image: fitness},
+		&InitHaltonF{Lower: lower, Upper: upper},
+		CrossRepair{
+			Crossover: CrossBLXF{Alpha: 0.5, Beta: 0.5},
+			Repairer:  clip,
+		},
+		func(rate float64) Mutator {
+			return MutRepair{
+				Mutator:  MutGaussianF{Rate: rate, Std: (upper - lower) / 10},
+				Repairer: clip,
+			}
+		},
+		nil,
+	)
+}
+
+// NewPermutationGA returns a GA for minimizing a function of the permutations
+// of the integers from 0 to n-1, which covers ordering problems such as the
+// travelling salesman problem. Offsprings are produced by partially mapped
+// crossover and are then mutated either by swapping two genes or by splicing
+// the genome. The sizes of the populations and of the tournaments are picked
+// by ScaleLog, see NewScaledGA. Every field of the returned GA can be changed
+// before calling Initialize.
+func NewPermutationGA(n int, fitness func([]int) float64) GA {
+	return NewScaledGA(
+		n,
+		IntFunction{Image: fitness},
+		InitPermutationI{},
+		CrossPMX{},
+		func(rate float64) Mutator {
+			return MutChoice{Mutators: []Mutator{MutPermute{Max: 1}, MutSplice{}}}
+		},
+		nil,
+	)
+}
+
+// NewMixedGA returns a GA for minimizing a function of continuous, integer and
+// categorical variables, see Variable. The initial genes are drawn uniformly
+// within the bounds of their variables, offsprings are produced by a BLX-0.5
+// crossover and each of their genes is perturbed with probability 1/n, n being
+// the number of variables. The genes always stay within their domains and the
+// integer genes stay integral, the categorical genes are given to the fitness
+// function as the indexes of their labels. The sizes of the populations and of
+// the tournaments are picked by ScaleLog, see NewScaledGA. Every field of the
+// returned GA can be changed before calling Initialize.
+func NewMixedGA(variables []Variable, fitness func([]float64) float64) GA {
+	return NewScaledGA(
+		len(variables),
+		MixedFunction{Image: fitness, Variables: variables},
+		InitMixed{Variables: variables},
+		CrossMixed{Variables: variables, Alpha: 0.5},
+		func(rate float64) Mutator {
+			return MutMixed{Variables: variables, Rate: rate}
+		},
+		nil,
+	)
+}
@@ -0,0 +1,99 @@
+package gago
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewFloatGA(t *testing.T) {
+	var ga = NewFloatGA(3, 2, 5, func(X []float64) float64 {
+		var sum float64
+		for _, x := range X {
+			sum += (x - 3) * (x - 3)
+		}
+		return sum
+	})
+	ga.Seed = 42
+	if err := ga.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ga.Initialize()
+	var initial = ga.Best().Fitness
+	for i := 0; i < 30; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness > initial || ga.Best().Fitness > 0.1 {
+		t.Errorf("Expected a fitness close to 0, got %f", ga.Best().Fitness)
+	}
+	// The genes stay within the bounds
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			for _, gene := range indi.Genome {
+				if x := gene.(float64); x < 2 || x > 5 || math.IsNaN(x) {
+					t.Fatalf("Gene %f is out of bounds", x)
+				}
+			}
+		}
+	}
+}
+
+func TestNewPermutationGA(t *testing.T) {
+	// Count the genes that aren't at their place
+	var ga = NewPermutationGA(8, func(P []int) float64 {
+		var misplaced float64
+		for i, p := range P {
+			if p != i {
+				misplaced++
+			}
+		}
+		return misplaced
+	})
+	ga.Seed = 42
+	if err := ga.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ga.Initialize()
+	var initial = ga.Best().Fitness
+	for i := 0; i < 30; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness >= initial {
+		t.Errorf("The best fitness didn't improve from %f", initial)
+	}
+	// The genomes are still permutations
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			var seen = make([]bool, 8)
+			for _, gene := range indi.Genome {
+				seen[gene.(int)] = true
+			}
+			for _, s := range seen {
+				if !s {
+					t.Fatalf("%v isn't a permutation", indi.Genome)
+				}
+			}
+		}
+	}
+}
+
+func TestNewMixedGA(t *testing.T) {
+	var ga = NewMixedGA(variables, func(X []float64) float64 {
+		return X[0]*X[0] + math.Abs(X[1]-2) + X[2]
+	})
+	ga.Seed = 42
+	if err := ga.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ga.Initialize()
+	for i := 0; i < 30; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness > 0.1 {
+		t.Errorf("Expected a fitness close to 0, got %f", ga.Best().Fitness)
+	}
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			checkMixed(t, indi.Genome)
+		}
+	}
+}
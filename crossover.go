@@ -1,73 +1,504 @@
 package gago
 
 import (
+	"errors"
+	"math"
 	"math/rand"
 	"sort"
 )
 
 // Crossover generates new individuals called "offsprings" are by mixing the
-// genomes of two parents.
+// genomes of two parents. The parents are passed as complete individuals,
+// hence operators can read their Fitness to bias the offsprings towards the
+// fittest parent. The Fitness of a parent is only meaningful if it's Evaluated
+// property is true, which is always the case in the provided models but isn't
+// for the intermediate offsprings of a CrossPipeline.
 type Crossover interface {
 	Apply(p1 Individual, p2 Individual, rng *rand.Rand) (o1 Individual, o2 Individual)
 }
 
+// A MultiCrossover generates an offspring by combining any number of parents.
+// The parents are given sorted from the best to the worst, hence the index of
+// a parent is it's rank among the parents, which allows operators to weigh the
+// parents by rank. See ModMultiParent.
+type MultiCrossover interface {
+	ApplyMulti(parents Individuals, rng *rand.Rand) Individual
+}
+
+// A CrossoverInto is a crossover that can also write the offsprings into
+// existing individuals instead of allocating new ones, which allows models to
+// reuse the memory of the previous generation. ApplyInto has to overwrite
+// every gene of the offsprings.
+type CrossoverInto interface {
+	Crossover
+	ApplyInto(p1 Individual, p2 Individual, o1 *Individual, o2 *Individual, rng *rand.Rand)
+}
+
+// Prepare an existing individual to receive an offspring of nbGenes genes, the
+// genome is reused if it has the right length. The individual keeps it's name.
+func prepareOffspring(o *Individual, nbGenes int) {
+	if len(o.Genome) != nbGenes {
+		o.Genome = make(Genome, nbGenes)
+	}
+	o.Fitness = math.Inf(1)
+	o.Evaluated = false
+	o.ID, o.Birth, o.Origin = 0, 0, 0
+	o.Cases = nil
+	o.Objectives = nil
+	o.Metadata = nil
+	o.failed = false
+	o.err = nil
+}
+
+// Compute the boundaries of blocks of genes along a genome of n genes. Blocks
+// contains the sizes of consecutive blocks starting from the first gene, for
+// example {2, 3} means the first two genes form a block and the next three
+// genes form another one. Genes that aren't covered by the blocks form blocks
+// of their own. The returned boundaries start with 0 and end with n. The sizes
+// should be higher or equal to 1, see checkBlocks.
+func blockBounds(blocks []int, n int) []int {
+	var (
+		bounds = []int{0}
+		end    = 0
+	)
+	for _, size := range blocks {
+		if end+size >= n {
+			end = n
+			break
+		}
+		end += size
+		bounds = append(bounds, end)
+	}
+	for end++; end < n; end++ {
+		bounds = append(bounds, end)
+	}
+	return append(bounds, n)
+}
+
+// Check the sizes of blocks of genes given to blockBounds.
+func checkBlocks(blocks []int) error {
+	for _, size := range blocks {
+		if size < 1 {
+			return errors.New("'Blocks' should only contain sizes higher or equal to 1")
+		}
+	}
+	return nil
+}
+
 // CrossPoint selects identical random points on each parent's genome and
 // exchanges mirroring segments. It generalizes one-point crossover and
-// two-point crossover to n-point crossover.
+// two-point crossover to n-point crossover. If Blocks is provided the points
+// are only chosen at the boundaries of the blocks, hence genes belonging to the
+// same block are always inherited together. NbPoints is clipped to the number
+// of available points, for example a NbPoints larger than the number of genes
+// chooses every point.
 type CrossPoint struct {
 	NbPoints int
+	Blocks   []int // Sizes of consecutive blocks of genes, see blockBounds
 }
 
 // Apply n-point crossover.
 func (cross CrossPoint) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
-	// Choose n random points along the genome
+	var o1, o2 = makeIndividual(len(p1.Genome), rng), makeIndividual(len(p1.Genome), rng)
+	cross.ApplyInto(p1, p2, &o1, &o2, rng)
+	return o1, o2
+}
+
+// ApplyInto applies n-point crossover and writes the offsprings into o1 and o2.
+func (cross CrossPoint) ApplyInto(p1 Individual, p2 Individual, o1 *Individual, o2 *Individual, rng *rand.Rand) {
+	var nbGenes = len(p1.Genome)
+	prepareOffspring(o1, nbGenes)
+	prepareOffspring(o2, nbGenes)
+	var points = crossPoints(cross.NbPoints, blockBounds(cross.Blocks, nbGenes), rng)
+	crossSegments(p1.Genome, p2.Genome, o1.Genome, o2.Genome, points)
+}
+
+// Validate the blocks of a CrossPoint.
+func (cross CrossPoint) Validate() error {
+	return checkBlocks(cross.Blocks)
+}
+
+// Choose n random points among the bounds of the blocks of a genome, the
+// points are sorted and start and end with the first and last bounds. n is
+// clipped to the number of bounds that can be picked.
+func crossPoints(n int, bounds []int, rng *rand.Rand) []int {
 	var (
-		points, _ = randomInts(cross.NbPoints, 0, len(p1.Genome), rng)
-		nbGenes   = len(p1.Genome)
-		o1        = makeIndividual(nbGenes, rng)
-		o2        = makeIndividual(nbGenes, rng)
-		// Use a switch to know which parent to copy onto each offspring
-		s = true
+		picks, _ = randomInts(clipInt(n, 0, len(bounds)-1), 0, len(bounds)-1, rng)
+		points   = make([]int, len(picks), len(picks)+2)
 	)
+	for i, pick := range picks {
+		points[i] = bounds[pick]
+	}
 	// Sort the points
 	sort.Ints(points)
 	// Add the start and end of the genome points
 	points = append([]int{0}, points...)
-	points = append(points, nbGenes)
+	return append(points, bounds[len(bounds)-1])
+}
+
+// Exchange the mirroring segments delimited by the points of two parents, the
+// segments are alternatively copied from each parent. The functions that
+// follow are generic so that they are shared by the crossovers of classic
+// genomes and the crossovers of typed genomes.
+func crossSegments[G any](p1, p2, o1, o2 []G, points []int) {
+	// Use a switch to know which parent to copy onto each offspring
+	var s = true
 	for i := 0; i < len(points)-1; i++ {
 		if s {
-			copy(o1.Genome[points[i]:points[i+1]], p1.Genome[points[i]:points[i+1]])
-			copy(o2.Genome[points[i]:points[i+1]], p2.Genome[points[i]:points[i+1]])
+			copy(o1[points[i]:points[i+1]], p1[points[i]:points[i+1]])
+			copy(o2[points[i]:points[i+1]], p2[points[i]:points[i+1]])
 		} else {
-			copy(o1.Genome[points[i]:points[i+1]], p2.Genome[points[i]:points[i+1]])
-			copy(o2.Genome[points[i]:points[i+1]], p1.Genome[points[i]:points[i+1]])
+			copy(o1[points[i]:points[i+1]], p2[points[i]:points[i+1]])
+			copy(o2[points[i]:points[i+1]], p1[points[i]:points[i+1]])
 		}
 		// Alternate for the new copying
-		s = !true
+		s = !s
+	}
+}
+
+// Exchange the blocks delimited by bounds of two parents with probability
+// 0.5, each gene is a block of it's own if bounds is nil.
+func crossBlocks[G any](p1, p2, o1, o2 []G, bounds []int, rng *rand.Rand) {
+	if bounds == nil {
+		for i := range p1 {
+			if rng.Float64() < 0.5 {
+				o1[i], o2[i] = p2[i], p1[i]
+			} else {
+				o1[i], o2[i] = p1[i], p2[i]
+			}
+		}
+		return
+	}
+	for b := 0; b < len(bounds)-1; b++ {
+		var swap = rng.Float64() < 0.5
+		for i := bounds[b]; i < bounds[b+1]; i++ {
+			if swap {
+				o1[i], o2[i] = p2[i], p1[i]
+			} else {
+				o1[i], o2[i] = p1[i], p2[i]
+			}
+		}
+	}
+}
+
+// Choose a random crossover point p of PMX such that 0 < p < (n - 1). There is
+// no such point if the genomes have less than 3 genes, in which case 0 is
+// returned and the offsprings are copies of the parents.
+func pmxPoint(n int, rng *rand.Rand) int {
+	if n < 3 {
+		return 0
+	}
+	return rng.Intn(n-2) + 1
+}
+
+// Paste the genes of each parent up to point p onto a copy of the other
+// parent, each gene that is replaced is permuted with the gene that is pasted.
+func crossPMX[G comparable](p1, p2, o1, o2 []G, p int) {
+	copy(o1, p1)
+	copy(o2, p2)
+	for i := 0; i < p; i++ {
+		// Find where the second parent's gene is in the first offspring's genome
+		var a = getIndex(p2[i], o1)
+		// Swap the genes
+		o1[a], o1[i] = o1[i], p2[i]
+		// Find where the first parent's gene is in the second offspring's genome
+		var b = getIndex(p1[i], o2)
+		// Swap the genes
+		o2[b], o2[i] = o2[i], p1[i]
+	}
+}
+
+// CrossUniform exchanges each gene of the parents with probability 0.5. It
+// works for any type of gene and is the usual bit-level crossover for binary
+// genomes. If Blocks is provided then whole blocks of genes are exchanged
+// instead of single genes.
+type CrossUniform struct {
+	Blocks []int // Sizes of consecutive blocks of genes, see blockBounds
+}
+
+// Apply uniform crossover.
+func (cross CrossUniform) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = makeIndividual(len(p1.Genome), rng), makeIndividual(len(p1.Genome), rng)
+	cross.ApplyInto(p1, p2, &o1, &o2, rng)
+	return o1, o2
+}
+
+// ApplyInto applies uniform crossover and writes the offsprings into o1 and o2.
+func (cross CrossUniform) ApplyInto(p1 Individual, p2 Individual, o1 *Individual, o2 *Individual, rng *rand.Rand) {
+	var nbGenes = len(p1.Genome)
+	prepareOffspring(o1, nbGenes)
+	prepareOffspring(o2, nbGenes)
+	var bounds []int
+	if cross.Blocks != nil {
+		bounds = blockBounds(cross.Blocks, nbGenes)
 	}
+	crossBlocks(p1.Genome, p2.Genome, o1.Genome, o2.Genome, bounds, rng)
+}
+
+// Validate the blocks of a CrossUniform.
+func (cross CrossUniform) Validate() error {
+	return checkBlocks(cross.Blocks)
+}
+
+// CrossMask exchanges the genes of the parents according to a mask generated
+// by Mask for each crossover. The mask contains a boolean for each gene, the
+// genes for which it's true are exchanged. Mask is the place to encode which
+// genes should travel together, for example by always returning the same value
+// for the genes that encode a same feature. It works for any type of gene.
+type CrossMask struct {
+	Mask func(n int, rng *rand.Rand) []bool
+}
+
+// Apply mask crossover.
+func (cross CrossMask) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = makeIndividual(len(p1.Genome), rng), makeIndividual(len(p1.Genome), rng)
+	cross.ApplyInto(p1, p2, &o1, &o2, rng)
 	return o1, o2
 }
 
+// ApplyInto applies mask crossover and writes the offsprings into o1 and o2.
+func (cross CrossMask) ApplyInto(p1 Individual, p2 Individual, o1 *Individual, o2 *Individual, rng *rand.Rand) {
+	var nbGenes = len(p1.Genome)
+	prepareOffspring(o1, nbGenes)
+	prepareOffspring(o2, nbGenes)
+	for i, swap := range cross.Mask(nbGenes, rng) {
+		if swap {
+			o1.Genome[i], o2.Genome[i] = p2.Genome[i], p1.Genome[i]
+		} else {
+			o1.Genome[i], o2.Genome[i] = p1.Genome[i], p2.Genome[i]
+		}
+	}
+}
+
 // CrossUniformF crossover combines two individuals (the parents) into one
 // (the offspring). Each parent's contribution to the Genome is determined by
 // the value of a probability p. Each offspring receives a proportion of both of
 // it's parents genomes. The new values are located in the hyper-rectangle
-// defined between both parent's position in Cartesian space.
-type CrossUniformF struct{}
+// defined between both parent's position in Cartesian space. If Blocks is
+// provided then the same probability is used for every gene in a block.
+type CrossUniformF struct {
+	Blocks []int // Sizes of consecutive blocks of genes, see blockBounds
+}
 
 // Apply uniform float crossover.
 func (cross CrossUniformF) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = makeIndividual(len(p1.Genome), rng), makeIndividual(len(p1.Genome), rng)
+	cross.ApplyInto(p1, p2, &o1, &o2, rng)
+	return o1, o2
+}
+
+// ApplyInto applies uniform float crossover and writes the offsprings into o1
+// and o2.
+func (cross CrossUniformF) ApplyInto(p1 Individual, p2 Individual, o1 *Individual, o2 *Individual, rng *rand.Rand) {
+	var nbGenes = len(p1.Genome)
+	prepareOffspring(o1, nbGenes)
+	prepareOffspring(o2, nbGenes)
+	// Each gene is a block of it's own if there are no blocks
+	if cross.Blocks == nil {
+		for i := range p1.Genome {
+			var p = rng.Float64()
+			o1.Genome[i] = p*p1.Genome[i].(float64) + (1-p)*p2.Genome[i].(float64)
+			o2.Genome[i] = (1-p)*p1.Genome[i].(float64) + p*p2.Genome[i].(float64)
+		}
+		return
+	}
+	var bounds = blockBounds(cross.Blocks, nbGenes)
+	// For every block of genes
+	for b := 0; b < len(bounds)-1; b++ {
+		// Pick a random number between 0 and 1
+		var p = rng.Float64()
+		for i := bounds[b]; i < bounds[b+1]; i++ {
+			o1.Genome[i] = p*p1.Genome[i].(float64) + (1-p)*p2.Genome[i].(float64)
+			o2.Genome[i] = (1-p)*p1.Genome[i].(float64) + p*p2.Genome[i].(float64)
+		}
+	}
+}
+
+// Validate the blocks of a CrossUniformF.
+func (cross CrossUniformF) Validate() error {
+	return checkBlocks(cross.Blocks)
+}
+
+// CrossArithmeticF crossover produces offsprings that are fixed linear
+// combinations of the parents. The first offspring receives a proportion Alpha
+// of the first parent's genome and a proportion 1 - Alpha of the second
+// parent's genome, the second offspring receives the opposite proportions.
+// Only works for floating point values.
+type CrossArithmeticF struct {
+	Alpha float64
+}
+
+// Apply arithmetic float crossover.
+func (cross CrossArithmeticF) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	return crossBoxed[float64](CrossArithmeticOf[float64](cross), p1, p2, rng)
+}
+
+// CrossArithmeticOf is the generic version of CrossArithmeticF.
+type CrossArithmeticOf[G Float] struct {
+	Alpha float64
+}
+
+// Apply arithmetic crossover to generic individuals.
+func (cross CrossArithmeticOf[G]) Apply(p1, p2 IndividualOf[G], rng *rand.Rand) (IndividualOf[G], IndividualOf[G]) {
 	var (
 		nbGenes = len(p1.Genome)
-		o1      = makeIndividual(nbGenes, rng)
-		o2      = makeIndividual(nbGenes, rng)
+		o1      = MakeIndividualOf[G](nbGenes, rng)
+		o2      = MakeIndividualOf[G](nbGenes, rng)
+		alpha   = G(cross.Alpha)
 	)
-	// For every gene
 	for i := 0; i < nbGenes; i++ {
-		// Pick a random number between 0 and 1
-		var p = rng.Float64()
-		o1.Genome[i] = p*p1.Genome[i].(float64) + (1-p)*p2.Genome[i].(float64)
-		o2.Genome[i] = (1-p)*p1.Genome[i].(float64) + p*p2.Genome[i].(float64)
+		var (
+			a = p1.Genome[i]
+			b = p2.Genome[i]
+		)
+		o1.Genome[i] = alpha*a + (1-alpha)*b
+		o2.Genome[i] = (1-alpha)*a + alpha*b
+	}
+	return o1, o2
+}
+
+// Order two parents based on their fitness, the fittest parent is returned
+// first. A parent that hasn't been evaluated is considered less fit than a
+// parent that has been.
+func fitterFirst[G any](p1, p2 IndividualOf[G]) (IndividualOf[G], IndividualOf[G]) {
+	if p2.Evaluated && (!p1.Evaluated || p2.Fitness < p1.Fitness) {
+		return p2, p1
+	}
+	return p1, p2
+}
+
+// CrossHeuristicF crossover uses the fitness of the parents to produce
+// offsprings that extrapolate beyond the fittest parent in the direction going
+// from the least fit parent to the fittest one. Each offspring is located at
+// best + r * (best - worst) where r is a random number in [0, 1) drawn for each
+// offspring. Only works for floating point values.
+type CrossHeuristicF struct{}
+
+// Apply heuristic float crossover.
+func (cross CrossHeuristicF) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	return crossBoxed[float64](CrossHeuristicOf[float64]{}, p1, p2, rng)
+}
+
+// CrossHeuristicOf is the generic version of CrossHeuristicF.
+type CrossHeuristicOf[G Float] struct{}
+
+// Apply heuristic crossover to generic individuals.
+func (cross CrossHeuristicOf[G]) Apply(p1, p2 IndividualOf[G], rng *rand.Rand) (IndividualOf[G], IndividualOf[G]) {
+	var (
+		nbGenes     = len(p1.Genome)
+		best, worst = fitterFirst(p1, p2)
+		o1          = MakeIndividualOf[G](nbGenes, rng)
+		o2          = MakeIndividualOf[G](nbGenes, rng)
+		r1          = G(rng.Float64())
+		r2          = G(rng.Float64())
+	)
+	for i := 0; i < nbGenes; i++ {
+		var (
+			b = best.Genome[i]
+			w = worst.Genome[i]
+		)
+		o1.Genome[i] = b + r1*(b-w)
+		o2.Genome[i] = b + r2*(b-w)
+	}
+	return o1, o2
+}
+
+// CrossBLXF (blend crossover) draws each of the offspring's genes uniformly in
+// an interval spanned by the parents' genes and extended on both sides. If d is
+// the distance between the parents' genes, the interval is extended by Alpha*d
+// beyond the fittest parent's gene and by Beta*d beyond the least fit parent's
+// gene. Choosing Alpha > Beta biases the offsprings towards the fittest parent,
+// choosing Alpha = Beta gives the usual BLX-alpha crossover. Only works for
+// floating point values.
+type CrossBLXF struct {
+	Alpha, Beta float64
+}
+
+// Apply blend float crossover.
+func (cross CrossBLXF) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	return crossBoxed[float64](CrossBLXOf[float64](cross), p1, p2, rng)
+}
+
+// CrossBLXOf is the generic version of CrossBLXF.
+type CrossBLXOf[G Float] struct {
+	Alpha, Beta float64
+}
+
+// Apply blend crossover to generic individuals.
+func (cross CrossBLXOf[G]) Apply(p1, p2 IndividualOf[G], rng *rand.Rand) (IndividualOf[G], IndividualOf[G]) {
+	var (
+		nbGenes     = len(p1.Genome)
+		best, worst = fitterFirst(p1, p2)
+		o1          = MakeIndividualOf[G](nbGenes, rng)
+		o2          = MakeIndividualOf[G](nbGenes, rng)
+		// Express the interval relatively to the fittest parent's gene
+		lower = -cross.Alpha
+		upper = 1 + cross.Beta
+	)
+	for i := 0; i < nbGenes; i++ {
+		var (
+			b = best.Genome[i]
+			w = worst.Genome[i]
+		)
+		o1.Genome[i] = b + G(lower+rng.Float64()*(upper-lower))*(w-b)
+		o2.Genome[i] = b + G(lower+rng.Float64()*(upper-lower))*(w-b)
+	}
+	return o1, o2
+}
+
+// CrossSegmentI crossover picks a random segment along the parents' integer
+// genomes. Outside of the segment the offsprings are copies of the parents.
+// Inside the segment each of the offsprings' genes is drawn uniformly between
+// the values of the parents' genes, the result is then clipped to the [Lower,
+// Upper] range. If a Repairer is provided it is applied to each offspring in
+// order to enforce the constraints of the problem.
+type CrossSegmentI struct {
+	Lower, Upper int
+	Repairer     Repairer
+}
+
+// Apply segment integer crossover.
+func (cross CrossSegmentI) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = crossBoxed[int](CrossSegmentOf[int]{cross.Lower, cross.Upper}, p1, p2, rng)
+	if cross.Repairer != nil {
+		cross.Repairer.Apply(&o1, rng)
+		cross.Repairer.Apply(&o2, rng)
+	}
+	return o1, o2
+}
+
+// CrossSegmentOf is the generic version of CrossSegmentI, without a Repairer.
+type CrossSegmentOf[G Integer] struct {
+	Lower, Upper G
+}
+
+// Apply segment crossover to generic individuals.
+func (cross CrossSegmentOf[G]) Apply(p1, p2 IndividualOf[G], rng *rand.Rand) (IndividualOf[G], IndividualOf[G]) {
+	var (
+		nbGenes = len(p1.Genome)
+		o1      = MakeIndividualOf[G](nbGenes, rng)
+		o2      = MakeIndividualOf[G](nbGenes, rng)
+	)
+	if nbGenes == 0 {
+		return o1, o2
+	}
+	// Choose the segment [start, end)
+	var (
+		start = rng.Intn(nbGenes)
+		end   = start + 1 + rng.Intn(nbGenes-start)
+	)
+	copy(o1.Genome, p1.Genome)
+	copy(o2.Genome, p2.Genome)
+	for i := start; i < end; i++ {
+		var (
+			a = p1.Genome[i]
+			b = p2.Genome[i]
+		)
+		if a > b {
+			a, b = b, a
+		}
+		o1.Genome[i] = clipInt(a+G(rng.Intn(int(b-a)+1)), cross.Lower, cross.Upper)
+		o2.Genome[i] = clipInt(a+G(rng.Intn(int(b-a)+1)), cross.Lower, cross.Upper)
 	}
 	return o1, o2
 }
@@ -78,12 +509,86 @@ func (cross CrossUniformF) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (
 // equal to 1, this is done by normalizing each weight by the sum of the
 // generated weights. With this crossover method the CrossSize can be set to any
 // positive integer, in other words any number of individuals can be combined to
-// generate an offspring. Only works for floating point values.
+// generate an offspring. Only the first NbParents parents are combined if
+// NbParents is lower than the number of parents, every parent is combined if
+// it's 0. Only works for floating point values.
 type CrossProportionateF struct {
 	// Should be any integer above or equal to two
 	NbParents int
 }
 
+// ApplyMulti applies proportionate crossover.
+func (cross CrossProportionateF) ApplyMulti(parents Individuals, rng *rand.Rand) Individual {
+	if cross.NbParents > 0 && cross.NbParents < len(parents) {
+		parents = parents[:cross.NbParents]
+	}
+	var (
+		weights = make([]float64, len(parents))
+		total   float64
+	)
+	for i := range weights {
+		weights[i] = rng.Float64()
+		total += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= total
+	}
+	return weightedAverage(parents, weights, rng)
+}
+
+// CrossRankMuF implements the weighted recombination of evolution strategies
+// such as CMA-ES: the offspring is the weighted average of the Mu best parents,
+// the weight of the parent of rank i (starting from 1) being proportional to
+// ln(Mu + 1/2) - ln(i). Hence the better a parent is the more it contributes
+// to the offspring, and the weights decrease slower than the ranks. Every
+// parent is used if Mu is higher than the number of parents, half of them if
+// Mu is 0. The offspring doesn't depend on rng, it's usually mutated afterwards
+// with a gaussian mutation to sample around the weighted average. Only works
+// for floating point values.
+type CrossRankMuF struct {
+	Mu int
+}
+
+// ApplyMulti applies rank-mu weighted recombination.
+func (cross CrossRankMuF) ApplyMulti(parents Individuals, rng *rand.Rand) Individual {
+	var mu = cross.Mu
+	if mu == 0 {
+		mu = max(1, len(parents)/2)
+	}
+	mu = min(mu, len(parents))
+	return weightedAverage(parents[:mu], rankMuWeights(mu), rng)
+}
+
+// Compute the log-rank weights of mu parents, which sum up to 1.
+func rankMuWeights(mu int) []float64 {
+	var (
+		weights = make([]float64, mu)
+		total   float64
+	)
+	for i := range weights {
+		weights[i] = math.Log(float64(mu)+0.5) - math.Log(float64(i+1))
+		total += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= total
+	}
+	return weights
+}
+
+// Generate an offspring whose genes are the weighted averages of the genes of
+// the parents.
+func weightedAverage(parents Individuals, weights []float64, rng *rand.Rand) Individual {
+	var offspring = makeIndividual(len(parents[0].Genome), rng)
+	for i := range offspring.Genome {
+		var gene float64
+		for j, parent := range parents {
+			gene += weights[j] * parent.Genome[i].(float64)
+		}
+		offspring.Genome[i] = gene
+	}
+	return offspring
+}
+
 // CrossPMX (Partially Mapped Crossover) randomly picks a crossover point. The
 // offsprings are generated by copying one of the parents and then copying the
 // other parent's values up to the crossover point. Each gene that is replaced
@@ -91,7 +596,8 @@ type CrossProportionateF struct {
 // offsprings are generated in such a way (because there are two parents). This
 // crossover method ensures the offspring's genomes are composed of unique
 // genes, which is particularly useful for permutation problems such as the
-// Traveling Salesman Problem (TSP).
+// Traveling Salesman Problem (TSP). Genomes of less than 3 genes can't be
+// crossed, the offsprings are then copies of the parents.
 type CrossPMX struct{}
 
 // Apply partially mixed crossover.
@@ -101,24 +607,82 @@ func (c CrossPMX) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individua
 		o1      = makeIndividual(nbGenes, rng)
 		o2      = makeIndividual(nbGenes, rng)
 	)
-	copy(o1.Genome, p1.Genome)
-	copy(o2.Genome, p2.Genome)
-	// Choose a random crossover point p such that 0 < p < (nbGenes - 1)
-	var (
-		p = rng.Intn(nbGenes-2) + 1
-		a int
-		b int
-	)
-	// Paste the father's genome up to the crossover point
-	for i := 0; i < p; i++ {
-		// Find where the second parent's gene is in the first offspring's genome
-		a = getIndex(p2.Genome[i], o1.Genome)
-		// Swap the genes
-		o1.Genome[a], o1.Genome[i] = o1.Genome[i], p2.Genome[i]
-		// Find where the first parent's gene is in the second offspring's genome
-		b = getIndex(p1.Genome[i], o2.Genome)
-		// Swap the genes
-		o2.Genome[b], o2.Genome[i] = o2.Genome[i], p1.Genome[i]
+	crossPMX(p1.Genome, p2.Genome, o1.Genome, o2.Genome, pmxPoint(nbGenes, rng))
+	return o1, o2
+}
+
+// CrossProb applies a crossover operator with probability Prob, otherwise the
+// offsprings are clones of the parents.
+type CrossProb struct {
+	Crossover Crossover
+	Prob      float64
+}
+
+// Apply probabilistic crossover.
+func (cross CrossProb) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	if rng.Float64() < cross.Prob {
+		return cross.Crossover.Apply(p1, p2, rng)
+	}
+	return p1.clone(rng), p2.clone(rng)
+}
+
+// CrossPipeline tries several crossover operators in sequence, each one with
+// it's own probability. The offsprings produced by an operator are used as the
+// parents of the next operator. If no operator fires the offsprings are clones
+// of the parents.
+type CrossPipeline []CrossProb
+
+// Apply each crossover operator of the pipeline.
+func (pipe CrossPipeline) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = p1.clone(rng), p2.clone(rng)
+	for _, cross := range pipe {
+		if rng.Float64() < cross.Prob {
+			o1, o2 = cross.Crossover.Apply(o1, o2, rng)
+		}
 	}
 	return o1, o2
 }
+
+// CrossSequence applies several crossover operators in sequence, the
+// offsprings produced by an operator are used as the parents of the next one.
+// It's the same as a CrossPipeline where every probability is 1.
+type CrossSequence []Crossover
+
+// Apply each crossover operator of the sequence.
+func (seq CrossSequence) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = p1.clone(rng), p2.clone(rng)
+	for _, cross := range seq {
+		o1, o2 = cross.Apply(o1, o2, rng)
+	}
+	return o1, o2
+}
+
+// CrossChoice applies one of several crossover operators, each time picked at
+// random with a probability proportional to it's weight. If Weights is nil the
+// operators are picked uniformly, otherwise it should contain a non-negative
+// weight for each operator and the weights shouldn't all be 0. The operators
+// can themselves be combinations, for example a CrossSequence or a CrossProb.
+type CrossChoice struct {
+	Crossovers []Crossover
+	Weights    []float64
+}
+
+// Apply one of the crossover operators.
+func (choice CrossChoice) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	return choice.Crossovers[pickWeighted(len(choice.Crossovers), choice.Weights, rng)].Apply(p1, p2, rng)
+}
+
+// Validate the operators and the weights of a CrossChoice.
+func (choice CrossChoice) Validate() error {
+	if err := checkWeights("Crossovers", len(choice.Crossovers), choice.Weights); err != nil {
+		return err
+	}
+	for _, cross := range choice.Crossovers {
+		if v, ok := cross.(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -1,7 +1,9 @@
 package gago
 
 import (
+	"math"
 	"math/rand"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -13,6 +15,25 @@ var crossovers = []struct {
 	{CrossPoint{NbPoints: 2}, InitUniformF{-5.0, 5.0}},
 	{CrossUniformF{}, InitUniformF{-5.0, 5.0}},
 	{CrossPMX{}, InitUniqueS{[]string{"A", "B", "C", "D"}}},
+	{CrossUniform{}, InitUniformB{}},
+	{CrossArithmeticF{0.3}, InitUniformF{-5.0, 5.0}},
+	{CrossHeuristicF{}, InitUniformF{-5.0, 5.0}},
+	{CrossBLXF{0.5, 0.5}, InitUniformF{-5.0, 5.0}},
+	{CrossSegmentI{0, 9, RepSumI{20, 0, 9}}, InitUniformI{0, 9}},
+	{CrossProb{CrossUniformF{}, 0.5}, InitUniformF{-5.0, 5.0}},
+	{CrossPipeline{{CrossPoint{NbPoints: 1}, 0.5}, {CrossUniformF{}, 0.5}}, InitUniformF{-5.0, 5.0}},
+	{CrossMask{randomMask}, InitUniformB{}},
+	{CrossSequence{CrossPoint{NbPoints: 1}, CrossUniformF{}}, InitUniformF{-5.0, 5.0}},
+	{CrossChoice{Crossovers: []Crossover{CrossPoint{NbPoints: 1}, CrossUniformF{}}, Weights: []float64{1, 2}}, InitUniformF{-5.0, 5.0}},
+}
+
+// A mask where each gene is exchanged with probability 0.5.
+func randomMask(n int, rng *rand.Rand) []bool {
+	var mask = make([]bool, n)
+	for i := range mask {
+		mask[i] = rng.Float64() < 0.5
+	}
+	return mask
 }
 
 func TestCrossovers(t *testing.T) {
@@ -21,7 +42,7 @@ func TestCrossovers(t *testing.T) {
 		rng      = rand.New(src)
 		nbIndis  = 5
 		nbGenes  = 4
-		selector = SelTournament{2}
+		selector = SelTournament{NbParticipants: 2}
 	)
 	for _, c := range crossovers {
 		var indis = makeIndividuals(nbIndis, nbGenes, rng)
@@ -48,3 +69,342 @@ func TestCrossovers(t *testing.T) {
 		}
 	}
 }
+
+func TestCrossProbClones(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = makeIndividuals(2, 4, rng)
+		init  = InitUniformF{-5.0, 5.0}
+		cross = CrossProb{CrossUniformF{}, 0}
+	)
+	for i := range indis {
+		init.Apply(&indis[i], rng)
+	}
+	var o1, o2 = cross.Apply(indis[0], indis[1], rng)
+	// Check the offsprings are copies of the parents
+	for i := range o1.Genome {
+		if o1.Genome[i] != indis[0].Genome[i] || o2.Genome[i] != indis[1].Genome[i] {
+			t.Error("CrossProb didn't clone the parents")
+		}
+	}
+	// Check the offsprings don't share genomes with the parents
+	o1.Genome[0] = 42.0
+	if indis[0].Genome[0] == 42.0 {
+		t.Error("CrossProb offspring shares it's genome with a parent")
+	}
+}
+
+func TestBlockBounds(t *testing.T) {
+	var testCases = []struct {
+		blocks []int
+		n      int
+		bounds []int
+	}{
+		{nil, 3, []int{0, 1, 2, 3}},
+		{[]int{2}, 4, []int{0, 2, 3, 4}},
+		{[]int{2, 2}, 4, []int{0, 2, 4}},
+		{[]int{1, 5}, 4, []int{0, 1, 4}},
+	}
+	for _, testCase := range testCases {
+		var bounds = blockBounds(testCase.blocks, testCase.n)
+		if !reflect.DeepEqual(bounds, testCase.bounds) {
+			t.Errorf("blockBounds returned %v instead of %v", bounds, testCase.bounds)
+		}
+	}
+}
+
+func TestCheckBlocks(t *testing.T) {
+	for _, cross := range []interface{ Validate() error }{
+		CrossPoint{NbPoints: 2, Blocks: []int{2, 0}},
+		CrossUniform{Blocks: []int{-1}},
+		CrossUniformF{Blocks: []int{3, 0, 1}},
+	} {
+		if cross.Validate() == nil {
+			t.Errorf("%+v should be rejected", cross)
+		}
+	}
+	if err := (CrossPoint{NbPoints: 2, Blocks: []int{2, 1}}).Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrossBlocks(t *testing.T) {
+	var (
+		src        = rand.NewSource(time.Now().UnixNano())
+		rng        = rand.New(src)
+		blocks     = []int{3, 2, 3}
+		bounds     = blockBounds(blocks, 8)
+		p1         = makeIndividual(8, rng)
+		p2         = makeIndividual(8, rng)
+		crossovers = []Crossover{
+			CrossPoint{NbPoints: 2, Blocks: blocks},
+			CrossUniform{Blocks: blocks},
+			CrossUniformF{Blocks: blocks},
+		}
+	)
+	for i := range p1.Genome {
+		p1.Genome[i] = 0.0
+		p2.Genome[i] = 1.0
+	}
+	for _, cross := range crossovers {
+		for n := 0; n < 10; n++ {
+			var o1, o2 = cross.Apply(p1, p2, rng)
+			// Check the genes of each block are inherited together
+			for _, offspring := range []Individual{o1, o2} {
+				for b := 0; b < len(bounds)-1; b++ {
+					for i := bounds[b]; i < bounds[b+1]; i++ {
+						if offspring.Genome[i] != offspring.Genome[bounds[b]] {
+							t.Error("Crossover didn't respect the block boundaries")
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCrossMask(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		p1    = Individual{Genome: Genome{"a", "b", "c", "d"}}
+		p2    = Individual{Genome: Genome{"A", "B", "C", "D"}}
+		cross = CrossMask{func(n int, rng *rand.Rand) []bool {
+			return []bool{true, false, false, true}
+		}}
+		o1, o2 = cross.Apply(p1, p2, rng)
+	)
+	if !reflect.DeepEqual(o1.Genome, Genome{"A", "b", "c", "D"}) ||
+		!reflect.DeepEqual(o2.Genome, Genome{"a", "B", "C", "d"}) {
+		t.Errorf("CrossMask produced %v and %v", o1.Genome, o2.Genome)
+	}
+}
+
+func TestCrossoverInto(t *testing.T) {
+	var (
+		rng        = rand.New(rand.NewSource(time.Now().UnixNano()))
+		p1         = makeIndividual(6, rng)
+		p2         = makeIndividual(6, rng)
+		crossovers = []CrossoverInto{
+			CrossPoint{NbPoints: 2},
+			CrossUniform{},
+			CrossUniformF{},
+			CrossUniformF{Blocks: []int{2, 4}},
+			CrossMask{randomMask},
+		}
+	)
+	for i := range p1.Genome {
+		p1.Genome[i] = 0.0
+		p2.Genome[i] = 1.0
+	}
+	for _, cross := range crossovers {
+		var (
+			o1    = Individual{Genome: make(Genome, 6), Fitness: 42, Evaluated: true, Name: "o1"}
+			o2    = Individual{Genome: make(Genome, 3), Fitness: 42, Evaluated: true, Name: "o2"}
+			first = &o1.Genome[0]
+		)
+		cross.ApplyInto(p1, p2, &o1, &o2, rng)
+		// Check the genome of the right length has been reused
+		if &o1.Genome[0] != first {
+			t.Error("ApplyInto didn't reuse the genome of the offspring")
+		}
+		for _, o := range []Individual{o1, o2} {
+			if len(o.Genome) != 6 {
+				t.Error("ApplyInto produced a genome of invalid length")
+			}
+			if o.Evaluated || !math.IsInf(o.Fitness, 1) {
+				t.Error("ApplyInto didn't reset the fitness of the offspring")
+			}
+			for i := range o.Genome {
+				var gene = o.Genome[i].(float64)
+				if gene < 0 || gene > 1 {
+					t.Error("ApplyInto produced a gene outside of the parents' range")
+				}
+			}
+		}
+		if o1.Name != "o1" || o2.Name != "o2" {
+			t.Error("ApplyInto changed the names of the offsprings")
+		}
+	}
+}
+
+func TestCrossHeuristicF(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		p1    = makeIndividual(1, rng)
+		p2    = makeIndividual(1, rng)
+		cross = CrossHeuristicF{}
+	)
+	p1.Genome[0], p1.Fitness, p1.Evaluated = 1.0, 1.0, true
+	p2.Genome[0], p2.Fitness, p2.Evaluated = 0.0, 0.0, true
+	for i := 0; i < 10; i++ {
+		var o1, o2 = cross.Apply(p1, p2, rng)
+		// The offsprings should be on the side of the fittest parent
+		for _, offspring := range []Individual{o1, o2} {
+			var x = offspring.Genome[0].(float64)
+			if x > 0 || x <= -1 {
+				t.Error("CrossHeuristicF didn't extrapolate from the fittest parent")
+			}
+		}
+	}
+}
+
+func TestCrossBLXF(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		p1    = makeIndividual(1, rng)
+		p2    = makeIndividual(1, rng)
+		cross = CrossBLXF{Alpha: 0.5, Beta: 0}
+	)
+	p1.Genome[0], p1.Fitness, p1.Evaluated = 1.0, 1.0, true
+	p2.Genome[0], p2.Fitness, p2.Evaluated = 0.0, 0.0, true
+	for i := 0; i < 10; i++ {
+		var o1, o2 = cross.Apply(p1, p2, rng)
+		// The interval should only be extended beyond the fittest parent
+		for _, offspring := range []Individual{o1, o2} {
+			var x = offspring.Genome[0].(float64)
+			if x < -0.5 || x > 1 {
+				t.Error("CrossBLXF didn't respect the biased interval")
+			}
+		}
+	}
+}
+
+func BenchmarkCrossovers(b *testing.B) {
+	var rng = rand.New(rand.NewSource(42))
+	for _, c := range crossovers {
+		var p1, p2 = makeIndividual(4, rng), makeIndividual(4, rng)
+		c.init.Apply(&p1, rng)
+		c.init.Apply(&p2, rng)
+		b.Run(reflect.TypeOf(c.crossover).Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.crossover.Apply(p1, p2, rng)
+			}
+		})
+	}
+}
+
+// A crossover that counts how many times it has been applied.
+type crossCounter struct {
+	count *int
+}
+
+func (cross crossCounter) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	*cross.count++
+	return p1.clone(rng), p2.clone(rng)
+}
+
+func TestCrossCombinators(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(time.Now().UnixNano()))
+		p1     = makeIndividual(2, rng)
+		p2     = makeIndividual(2, rng)
+		a, b   int
+		choice = CrossChoice{
+			Crossovers: []Crossover{crossCounter{&a}, crossCounter{&b}},
+			Weights:    []float64{3, 1},
+		}
+	)
+	for i := 0; i < 1000; i++ {
+		choice.Apply(p1, p2, rng)
+	}
+	if a < 650 || a > 850 || a+b != 1000 {
+		t.Errorf("Expected the first crossover to be applied around 750 times, got %d", a)
+	}
+	a, b = 0, 0
+	CrossSequence{crossCounter{&a}, CrossProb{crossCounter{&b}, 0}, crossCounter{&a}}.Apply(p1, p2, rng)
+	if a != 2 || b != 0 {
+		t.Error("CrossSequence didn't apply each crossover once")
+	}
+}
+
+func TestChoiceValidate(t *testing.T) {
+	var (
+		cross = []Crossover{CrossUniformF{}, CrossUniformF{}}
+		mut   = []Mutator{MutNormalF{Rate: 0.5, Std: 1}, MutNormalF{Rate: 0.5, Std: 1}}
+		valid = [][]float64{nil, {1, 0}, {0.5, 2}}
+		wrong = [][]float64{{1}, {1, 1, 1}, {1, -1}, {0, 0}, {1, math.NaN()}}
+	)
+	for _, weights := range valid {
+		if (CrossChoice{cross, weights}).Validate() != nil || (MutChoice{mut, weights}).Validate() != nil {
+			t.Errorf("The weights %v should be valid", weights)
+		}
+	}
+	for _, weights := range wrong {
+		if (CrossChoice{cross, weights}).Validate() == nil || (MutChoice{mut, weights}).Validate() == nil {
+			t.Errorf("The weights %v should be rejected", weights)
+		}
+	}
+	if (CrossChoice{}).Validate() == nil || (MutChoice{}).Validate() == nil {
+		t.Error("A choice without operators should be rejected")
+	}
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 10,
+		NbrGenes:       2,
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          ModGenerational{Selector: SelTournament{NbParticipants: 3}, Crossover: CrossChoice{Crossovers: cross, Weights: []float64{1}}},
+	}
+	if ga.Validate() == nil {
+		t.Error("The GA should check the weights of it's operators")
+	}
+}
+
+func TestCrossRankMuF(t *testing.T) {
+	var (
+		rng     = rand.New(rand.NewSource(time.Now().UnixNano()))
+		parents = Individuals{
+			{Genome: Genome{0.0, 4.0}},
+			{Genome: Genome{1.0, 3.0}},
+			{Genome: Genome{2.0, 2.0}},
+			{Genome: Genome{3.0, 1.0}},
+		}
+		weights = rankMuWeights(3)
+	)
+	if math.Abs(weights[0]+weights[1]+weights[2]-1) > 1e-12 || weights[0] <= weights[1] || weights[1] <= weights[2] {
+		t.Errorf("The weights should decrease and sum up to 1, got %v", weights)
+	}
+	var testCases = []struct {
+		mu       int
+		expected float64
+	}{
+		{1, 0},
+		{3, weights[1] + 2*weights[2]},
+		// Half of the parents
+		{0, rankMuWeights(2)[1]},
+		// Every parent
+		{10, CrossRankMuF{Mu: 4}.ApplyMulti(parents, rng).Genome[0].(float64)},
+	}
+	for _, tc := range testCases {
+		var offspring = CrossRankMuF{Mu: tc.mu}.ApplyMulti(parents, rng)
+		if x := offspring.Genome[0].(float64); math.Abs(x-tc.expected) > 1e-12 {
+			t.Errorf("Mu %d: expected %f, got %f", tc.mu, tc.expected, x)
+		}
+		// The weights sum up to 1
+		if sum := offspring.Genome[0].(float64) + offspring.Genome[1].(float64); math.Abs(sum-4) > 1e-12 {
+			t.Errorf("Mu %d: the genes should sum up to 4, got %f", tc.mu, sum)
+		}
+	}
+}
+
+func TestCrossProportionateF(t *testing.T) {
+	var (
+		rng     = rand.New(rand.NewSource(time.Now().UnixNano()))
+		parents = Individuals{
+			{Genome: Genome{0.0}},
+			{Genome: Genome{1.0}},
+			{Genome: Genome{10.0}},
+		}
+	)
+	for i := 0; i < 20; i++ {
+		if x := (CrossProportionateF{}).ApplyMulti(parents, rng).Genome[0].(float64); x < 0 || x > 10 {
+			t.Errorf("The offspring should lie within the parents, got %f", x)
+		}
+		if x := (CrossProportionateF{NbParents: 2}).ApplyMulti(parents, rng).Genome[0].(float64); x < 0 || x > 1 {
+			t.Errorf("Only the first two parents should be combined, got %f", x)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package gago
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"reflect"
+	"sync/atomic"
+)
+
+// A GenomeValidator checks that the genome of an individual is valid, for
+// example that it's a permutation or that it's genes are finite. Validators
+// are used by Debug to catch the operators that produce invalid genomes.
+type GenomeValidator interface {
+	Validate(indi Individual) error
+}
+
+// ValidatorFunc turns a function into a GenomeValidator.
+type ValidatorFunc func(indi Individual) error
+
+// Validate an individual by calling the function.
+func (f ValidatorFunc) Validate(indi Individual) error {
+	return f(indi)
+}
+
+// ValidPermutation checks that the genes of a genome are distinct, which is
+// expected from the operators that handle permutations such as CrossPMX and
+// MutPermute.
+type ValidPermutation struct{}
+
+// Validate an individual.
+func (v ValidPermutation) Validate(indi Individual) error {
+	var seen = make(map[interface{}]int)
+	for i, gene := range indi.Genome {
+		var key = gene
+		if gene != nil && !reflect.TypeOf(gene).Comparable() {
+			key = fmt.Sprint(gene)
+		}
+		if j, ok := seen[key]; ok {
+			return fmt.Errorf("genes %d and %d are both %v", j, i, gene)
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+// ValidFinite checks that the float64 genes of a genome, as well as the values
+// of it's Vector genes, are neither NaN nor infinite.
+type ValidFinite struct{}
+
+// Validate an individual.
+func (v ValidFinite) Validate(indi Individual) error {
+	var finite = func(i int, x float64) error {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return fmt.Errorf("gene %d is %v", i, x)
+		}
+		return nil
+	}
+	for i, gene := range indi.Genome {
+		switch x := gene.(type) {
+		case float64:
+			if err := finite(i, x); err != nil {
+				return err
+			}
+		case Vector:
+			for _, y := range x {
+				if err := finite(i, y); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ValidAll checks an individual with each validator in turn and returns the
+// first error.
+type ValidAll []GenomeValidator
+
+// Validate an individual.
+func (v ValidAll) Validate(indi Individual) error {
+	for _, validator := range v {
+		if err := validator.Validate(indi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Debug checks the offsprings produced by the crossovers and the mutators of
+// the models with Validator right after each operator is applied, which
+// catches the operators that produce invalid genomes, for example duplicate
+// genes in a permutation or NaN floats, before the invalid genomes spread.
+// The first invalid genome causes a panic whose message names the operator,
+// unless Logger isn't nil in which case each invalid genome is logged and the
+// run goes on. The operators nested inside other operators, for example the
+// mutators of a MutPipeline, are checked as a whole.
+//
+// Checking each offspring slows the GA down, hence Debug is meant to be used
+// while developing operators. Debug has to be used through a pointer.
+type Debug struct {
+	Validator GenomeValidator
+	Logger    *log.Logger
+	failures  int64
+}
+
+// Failures returns the number of invalid genomes that were logged.
+func (d *Debug) Failures() int {
+	return int(atomic.LoadInt64(&d.failures))
+}
+
+// Check an individual produced by an operator.
+func (d *Debug) check(op interface{}, indi Individual) {
+	var err = d.Validator.Validate(indi)
+	if err == nil {
+		return
+	}
+	err = fmt.Errorf("%s produced an invalid genome: %v", operatorName(op), err)
+	if d.Logger == nil {
+		panic(err)
+	}
+	atomic.AddInt64(&d.failures, 1)
+	d.Logger.Println(err)
+}
+
+// The crossovers and the mutators of the model are wrapped so that the
+// offsprings they produce are checked.
+
+type debuggedCrossover struct {
+	Crossover
+	d *Debug
+}
+
+func (cross debuggedCrossover) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = cross.Crossover.Apply(p1, p2, rng)
+	cross.d.check(cross.Crossover, o1)
+	cross.d.check(cross.Crossover, o2)
+	return o1, o2
+}
+
+func (cross debuggedCrossover) unwrap() interface{} {
+	return cross.Crossover
+}
+
+// A debugged crossover that can write into existing individuals, which keeps
+// the memory reuse of ModGenerational working when the GA is debugged.
+type debuggedCrossoverInto struct {
+	debuggedCrossover
+	into CrossoverInto
+}
+
+func (cross debuggedCrossoverInto) ApplyInto(p1 Individual, p2 Individual, o1 *Individual, o2 *Individual, rng *rand.Rand) {
+	cross.into.ApplyInto(p1, p2, o1, o2, rng)
+	cross.d.check(cross.Crossover, *o1)
+	cross.d.check(cross.Crossover, *o2)
+}
+
+type debuggedMutator struct {
+	Mutator
+	d *Debug
+}
+
+func (mut debuggedMutator) Apply(indi *Individual, rng *rand.Rand) {
+	mut.Mutator.Apply(indi, rng)
+	mut.d.check(mut.Mutator, *indi)
+}
+
+func (mut debuggedMutator) unwrap() interface{} {
+	return mut.Mutator
+}
+
+// A debugged mutator that mutates sibling offsprings at once, which keeps the
+// mirrored sampling of MutMirroredF working when the GA is debugged.
+type debuggedPairMutator struct {
+	debuggedMutator
+	pair PairMutator
+}
+
+func (mut debuggedPairMutator) ApplyPair(indi1 *Individual, indi2 *Individual, rng *rand.Rand) {
+	mut.pair.ApplyPair(indi1, indi2, rng)
+	mut.d.check(mut.Mutator, *indi1)
+	mut.d.check(mut.Mutator, *indi2)
+}
+
+// Return a copy of a model where the offsprings of the crossovers and the
+// mutators are checked.
+func debugModel(model Model, d *Debug) Model {
+	return wrapModel(model, wrappers{
+		crossover: func(cross Crossover) Crossover {
+			var debugged = debuggedCrossover{cross, d}
+			if into, ok := cross.(CrossoverInto); ok {
+				return debuggedCrossoverInto{debugged, into}
+			}
+			return debugged
+		},
+		mutator: func(mut Mutator) Mutator {
+			var debugged = debuggedMutator{mut, d}
+			if pair, ok := mut.(PairMutator); ok {
+				return debuggedPairMutator{debugged, pair}
+			}
+			return debugged
+		},
+	})
+}
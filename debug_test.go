@@ -0,0 +1,146 @@
+package gago
+
+import (
+	"bytes"
+	"log"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestValidPermutation(t *testing.T) {
+	if err := (ValidPermutation{}).Validate(Individual{Genome: Genome{"a", "b", "c"}}); err != nil {
+		t.Error(err)
+	}
+	if err := (ValidPermutation{}).Validate(Individual{Genome: Genome{1, 2, 1}}); err == nil {
+		t.Error("Duplicate genes should be invalid")
+	}
+}
+
+func TestValidFinite(t *testing.T) {
+	var testCases = []struct {
+		genome Genome
+		valid  bool
+	}{
+		{Genome{1.0, -2.0, "a"}, true},
+		{Genome{1.0, math.NaN()}, false},
+		{Genome{math.Inf(-1)}, false},
+		{Genome{Vector{1, math.Inf(1)}}, false},
+	}
+	for i, tc := range testCases {
+		if err := (ValidFinite{}).Validate(Individual{Genome: tc.genome}); (err == nil) != tc.valid {
+			t.Errorf("Test %d: expected the genome to be valid: %t", i, tc.valid)
+		}
+	}
+	var all = ValidAll{ValidFinite{}, ValidPermutation{}}
+	if all.Validate(Individual{Genome: Genome{1.0, 1.0}}) == nil {
+		t.Error("ValidAll should apply every validator")
+	}
+}
+
+// A mutator that produces NaN genes.
+type mutNaN struct{}
+
+func (mut mutNaN) Apply(indi *Individual, rng *rand.Rand) {
+	indi.Genome[0] = math.NaN()
+}
+
+func newDebuggedGA(debug *Debug) GA {
+	return GA{
+		NbrPopulations: 1,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model: ModGenerational{
+			Selector:  SelTournament{NbParticipants: 3},
+			Crossover: CrossUniformF{},
+			Mutator:   mutNaN{},
+			MutRate:   1,
+		},
+		Debug: debug,
+	}
+}
+
+func TestDebugPanic(t *testing.T) {
+	var (
+		g   = newDebuggedGA(&Debug{Validator: ValidFinite{}})
+		pop = makePopulation(nbIndividuals, nbGenes, ff, initializer)
+	)
+	pop.Individuals.Evaluate(ff)
+	defer func() {
+		var r = recover()
+		if r == nil {
+			t.Fatal("An invalid genome should cause a panic")
+		}
+		if !strings.Contains(r.(error).Error(), "gago.mutNaN") {
+			t.Errorf("The panic should name the operator, got %v", r)
+		}
+	}()
+	// The populations are evolved in separate goroutines by Enhance, hence
+	// the model is applied directly to recover from the panic
+	debugModel(g.Model, g.Debug).Apply(&pop)
+}
+
+func TestDebugLogger(t *testing.T) {
+	var (
+		buf   bytes.Buffer
+		debug = &Debug{Validator: ValidFinite{}, Logger: log.New(&buf, "", 0)}
+		g     = newDebuggedGA(debug)
+	)
+	g.Initialize()
+	g.Enhance()
+	if debug.Failures() == 0 || !strings.Contains(buf.String(), "gago.mutNaN produced an invalid genome") {
+		t.Error("The invalid genomes should have been logged")
+	}
+	// Valid operators shouldn't be reported
+	debug = &Debug{Validator: ValidFinite{}, Logger: log.New(&buf, "", 0)}
+	g = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+		Debug:          debug,
+	}
+	g.Initialize()
+	g.Enhance()
+	if debug.Failures() != 0 {
+		t.Error("Valid genomes shouldn't be reported")
+	}
+}
+
+func TestOperatorName(t *testing.T) {
+	var (
+		p   = &profiler{}
+		mut = profiledMutator{tracedMutator{debuggedMutator{MutNormalF{}, &Debug{}}, &Lineage{}}, p, nil}
+	)
+	if name := operatorName(mut); name != "gago.MutNormalF" {
+		t.Errorf("Expected gago.MutNormalF, got %s", name)
+	}
+}
+
+func TestDebugPairMutator(t *testing.T) {
+	var (
+		buf      bytes.Buffer
+		debug    = &Debug{Validator: ValidFinite{}, Logger: log.New(&buf, "", 0)}
+		mirrored = debugModel(ModGenerational{Mutator: MutMirroredF{Mutator: mutNaN{}}}, debug).(ModGenerational)
+	)
+	var pair, ok = mirrored.Mutator.(PairMutator)
+	if !ok {
+		t.Fatal("The debugged mutator should still implement PairMutator")
+	}
+	var (
+		indi1 = Individual{Genome: Genome{1.0, 2.0}}
+		indi2 = Individual{Genome: Genome{1.0, 2.0}}
+	)
+	pair.ApplyPair(&indi1, &indi2, nil)
+	if debug.Failures() != 2 {
+		t.Errorf("Both offsprings should have been checked, got %d failures", debug.Failures())
+	}
+	if !strings.Contains(buf.String(), "gago.MutMirroredF produced an invalid genome") {
+		t.Errorf("The failures should name the mutator, got %q", buf.String())
+	}
+}
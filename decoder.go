@@ -0,0 +1,156 @@
+package gago
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// A Decoder maps a genome, which is the genotype that the genetic operators
+// evolve, to the phenotype that the fitness function evaluates. For example a
+// bitstring can be decoded into a vector of floats, a normalized genome into
+// values in user units or a list of integer codons into a program.
+type Decoder interface {
+	Decode(genome Genome) interface{}
+}
+
+// DecoderFunc turns a function into a Decoder.
+type DecoderFunc func(genome Genome) interface{}
+
+// Decode a genome by calling the function.
+func (f DecoderFunc) Decode(genome Genome) interface{} {
+	return f(genome)
+}
+
+// GrayDecoder decodes a binary genome into a []float64, each consecutive group
+// of Bits genes is decoded into a floating point number in the [Lower, Upper]
+// range with DecodeGrayF, as in GrayFunction. Bits should belong to [1, 64]
+// and the number of genes should be a multiple of Bits, Decode panics
+// otherwise.
+type GrayDecoder struct {
+	Bits         int
+	Lower, Upper float64
+}
+
+// Validate the parameters of a GrayDecoder.
+func (dec GrayDecoder) Validate() error {
+	// Check the number of bits
+	if dec.Bits < 1 || dec.Bits > 64 {
+		return errors.New("'Bits' should belong to [1, 64]")
+	}
+	return nil
+}
+
+// Decode a binary genome.
+func (dec GrayDecoder) Decode(genome Genome) interface{} {
+	if err := dec.Validate(); err != nil {
+		panic(err)
+	}
+	if len(genome)%dec.Bits != 0 {
+		panic(fmt.Errorf("the genome has %d genes, which isn't a multiple of %d bits", len(genome), dec.Bits))
+	}
+	var (
+		decoded = make([]float64, len(genome)/dec.Bits)
+		bits    = make([]bool, dec.Bits)
+	)
+	for i := range decoded {
+		for j := range bits {
+			bits[j] = genome[i*dec.Bits+j].(bool)
+		}
+		decoded[i] = DecodeGrayF(bits, dec.Lower, dec.Upper)
+	}
+	return decoded
+}
+
+// Decode a normalized genome into a []float64 in user units, see Denormalize.
+func (n Normalizer) Decode(genome Genome) interface{} {
+	return n.Denormalize(genome)
+}
+
+// A phenotypeEntry is a decoded genome held by the cache of a
+// DecodedFunction.
+type phenotypeEntry struct {
+	key       string
+	phenotype interface{}
+}
+
+// DecodedFunction is for functions that evaluate a phenotype rather than a
+// genome, each genome is decoded with Decoder before calling Image. Decoding
+// can be expensive, for example when building a program or simulating a
+// development process, hence the phenotypes of the last CacheSize distinct
+// genomes are cached; nothing is cached if CacheSize is 0. The genomes are
+// identified by their binary encoding, the genomes whose genes can't be
+// encoded are never cached. Phenotype returns the phenotype of a genome, for
+// example to inspect the best individual.
+//
+// DecodedFunction is safe for concurrent use and has to be used through a
+// pointer.
+type DecodedFunction struct {
+	Decoder   Decoder
+	Image     func(phenotype interface{}) float64
+	CacheSize int
+	mu        sync.Mutex
+	entries   map[string]*list.Element
+	order     *list.List // Cached phenotypes from the most to the least recently used
+	hits      int
+	misses    int
+}
+
+// Phenotype returns the phenotype of a genome, from the cache if possible.
+func (ff *DecodedFunction) Phenotype(genome Genome) interface{} {
+	if ff.CacheSize == 0 {
+		return ff.Decoder.Decode(genome)
+	}
+	var key = genomeKey(genome)
+	if key == "" {
+		return ff.Decoder.Decode(genome)
+	}
+	ff.mu.Lock()
+	if ff.entries == nil {
+		ff.entries = make(map[string]*list.Element)
+		ff.order = list.New()
+	}
+	if e, ok := ff.entries[key]; ok {
+		ff.order.MoveToFront(e)
+		ff.hits++
+		ff.mu.Unlock()
+		return e.Value.(*phenotypeEntry).phenotype
+	}
+	ff.misses++
+	ff.mu.Unlock()
+	// Decode without holding the lock, the genome might be decoded twice by
+	// concurrent evaluations
+	var phenotype = ff.Decoder.Decode(genome)
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	if _, ok := ff.entries[key]; !ok {
+		ff.entries[key] = ff.order.PushFront(&phenotypeEntry{key, phenotype})
+		for ff.order.Len() > ff.CacheSize {
+			var oldest = ff.order.Back()
+			ff.order.Remove(oldest)
+			delete(ff.entries, oldest.Value.(*phenotypeEntry).key)
+		}
+	}
+	return phenotype
+}
+
+// Hits returns the number of phenotypes that were served by the cache.
+func (ff *DecodedFunction) Hits() int {
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	return ff.hits
+}
+
+// Misses returns the number of phenotypes that had to be decoded while the
+// cache was enabled.
+func (ff *DecodedFunction) Misses() int {
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	return ff.misses
+}
+
+// Apply the fitness function wrapped in DecodedFunction.
+func (ff *DecodedFunction) apply(genome Genome) float64 {
+	return ff.Image(ff.Phenotype(genome))
+}
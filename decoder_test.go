@@ -0,0 +1,134 @@
+package gago
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGrayDecoder(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(time.Now().UnixNano()))
+		dec    = GrayDecoder{Bits: 4, Lower: -1, Upper: 1}
+		genome = make(Genome, 8)
+	)
+	for i := range genome {
+		genome[i] = rng.Float64() < 0.5
+	}
+	var (
+		decoded = dec.Decode(genome).([]float64)
+		ff      = GrayFunction{Bits: 4, Lower: -1, Upper: 1, Image: func(x []float64) float64 { return x[0] + 10*x[1] }}
+	)
+	if len(decoded) != 2 || ff.apply(genome) != decoded[0]+10*decoded[1] {
+		t.Errorf("GrayDecoder and GrayFunction disagree: %v", decoded)
+	}
+	if x := dec.Decode(Genome{true, false, false, false, false, false, false, false}).([]float64); x[0] != 1 || x[1] != -1 {
+		t.Errorf("Unexpected decoding %v", x)
+	}
+}
+
+func TestGrayDecoderBits(t *testing.T) {
+	for _, bits := range []int{0, 65} {
+		if (GrayDecoder{Bits: bits}).Validate() == nil || (GrayFunction{Bits: bits}).Validate() == nil {
+			t.Errorf("%d bits should be rejected", bits)
+		}
+	}
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 10,
+		NbrGenes:       10,
+		Ff:             GrayFunction{Bits: 4, Image: func(x []float64) float64 { return 0 }},
+		Initializer:    InitUniformB{},
+		Model:          model,
+	}
+	if ga.Validate() == nil {
+		t.Error("The number of genes should be a multiple of the number of bits")
+	}
+	for _, decode := range []func(){
+		func() { GrayDecoder{Bits: 4}.Decode(make(Genome, 6)) },
+		func() { GrayDecoder{Bits: 0}.Decode(make(Genome, 6)) },
+		func() { DecodeGrayF(nil, 0, 1) },
+		func() { DecodeGrayF(make([]bool, 65), 0, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Decoding invalid bits should panic")
+				}
+			}()
+			decode()
+		}()
+	}
+}
+
+func TestDecodedFunction(t *testing.T) {
+	var (
+		decodings int
+		mu        sync.Mutex
+		ff        = &DecodedFunction{
+			Decoder: DecoderFunc(func(genome Genome) interface{} {
+				mu.Lock()
+				decodings++
+				mu.Unlock()
+				return len(genome)
+			}),
+			Image:     func(phenotype interface{}) float64 { return float64(phenotype.(int)) },
+			CacheSize: 2,
+		}
+		a, b, c = Genome{1.0}, Genome{1.0, 2.0}, Genome{1.0, 2.0, 3.0}
+	)
+	if ff.apply(a) != 1 || ff.apply(b) != 2 || ff.apply(a) != 1 {
+		t.Error("Wrong fitness")
+	}
+	if decodings != 2 || ff.Hits() != 1 || ff.Misses() != 2 {
+		t.Errorf("Expected 2 decodings and 1 hit, got %d and %d", decodings, ff.Hits())
+	}
+	// c evicts b, which is the least recently used
+	ff.apply(c)
+	ff.apply(a)
+	if decodings != 3 {
+		t.Errorf("Expected a to still be cached, got %d decodings", decodings)
+	}
+	ff.apply(b)
+	if decodings != 4 {
+		t.Errorf("Expected b to have been evicted, got %d decodings", decodings)
+	}
+	if ff.Phenotype(c) != 3 {
+		t.Error("Wrong phenotype")
+	}
+	// Without cache each genome is decoded
+	ff = &DecodedFunction{Decoder: ff.Decoder, Image: ff.Image}
+	decodings = 0
+	ff.apply(a)
+	ff.apply(a)
+	if decodings != 2 || ff.Hits() != 0 {
+		t.Errorf("Expected 2 decodings without cache, got %d", decodings)
+	}
+}
+
+func TestDecodedFunctionGA(t *testing.T) {
+	var ga = GA{
+		Ff: &DecodedFunction{
+			Decoder:   Normalizer{Lower: []float64{-5, -5}, Upper: []float64{5, 5}},
+			Image:     func(phenotype interface{}) float64 { return sphere.Image(phenotype.([]float64)) },
+			CacheSize: 100,
+		},
+		Initializer:    InitSampler{SampleUniformF{0, 1}},
+		Model:          model,
+		NbrGenes:       2,
+		NbrPopulations: 2,
+		NbrIndividuals: 10,
+	}
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	var (
+		best = ga.Best()
+		x    = ga.Ff.(*DecodedFunction).Phenotype(best.Genome).([]float64)
+	)
+	if best.Fitness != sphere.Image(x) {
+		t.Errorf("The fitness %f doesn't match the phenotype %v", best.Fitness, x)
+	}
+}
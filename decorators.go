@@ -0,0 +1,224 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// The following fitness functions decorate another fitness function, which
+// makes it possible to build test setups without modifying the objective:
+// PenaltyFunction adds a penalty, NoisyFunction adds noise, LogFunction takes
+// the logarithm of the fitness and ShiftedFunction and RotatedFunction
+// transform the search space as is done with benchmark functions. They can be
+// nested, for example to shift and then rotate a function, which is what
+// RandomBenchmark does. A decorated function is only seen through it's fitness,
+// hence the errors on each case, the objectives or the failures of the
+// decorated function are not available to the GA.
+
+// PenaltyFunction adds the value returned by Penalty to the fitness computed
+// by Function, for example to penalize the violation of a constraint. Penalty
+// should return 0 for a feasible genome.
+type PenaltyFunction struct {
+	Function FitnessFunction
+	Penalty  func(genome Genome) float64
+}
+
+// Apply the fitness function wrapped in PenaltyFunction and add the penalty.
+func (ff PenaltyFunction) apply(genome Genome) float64 {
+	return ff.Function.apply(genome) + ff.Penalty(genome)
+}
+
+// NoisyFunction adds a random value sampled from a normal distribution
+// centered on 0 and with standard deviation Std to the fitness computed by
+// Function, which is useful to check that a configuration is robust to noisy
+// evaluations. The noise is drawn from a random number generator seeded with
+// Seed, or with the current time if Seed is 0. The generator is shared by the
+// populations, hence NoisyFunction has to be used through a pointer.
+type NoisyFunction struct {
+	Function FitnessFunction
+	Std      float64
+	Seed     int64
+	mu       sync.Mutex
+	rng      *rand.Rand
+}
+
+// Apply the fitness function wrapped in NoisyFunction and add noise.
+func (ff *NoisyFunction) apply(genome Genome) float64 {
+	var fitness = ff.Function.apply(genome)
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	if ff.rng == nil {
+		var seed = ff.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		ff.rng = rand.New(rand.NewSource(seed))
+	}
+	return fitness + ff.rng.NormFloat64()*ff.Std
+}
+
+// LogFunction takes the natural logarithm of the fitness computed by Function
+// plus Offset. The order of the individuals doesn't change but the
+// differences between small fitnesses are magnified, which helps the
+// selectors that depend on the fitness values rather than on the ranks.
+// Offset should make the argument of the logarithm positive, otherwise the
+// fitness is -Inf or NaN.
+type LogFunction struct {
+	Function FitnessFunction
+	Offset   float64
+}
+
+// Apply the fitness function wrapped in LogFunction and take the logarithm.
+func (ff LogFunction) apply(genome Genome) float64 {
+	return math.Log(ff.Function.apply(genome) + ff.Offset)
+}
+
+// ShiftedFunction moves the optimum of a function of floating point genes by
+// Shift: Function is evaluated at x - Shift, hence if the optimum of Function
+// is at the origin the optimum of the ShiftedFunction is at Shift. Benchmark
+// functions are often shifted so that an algorithm can't benefit from the
+// optimum being at the center of the search space. Shift should have as many
+// values as there are genes.
+type ShiftedFunction struct {
+	Function FitnessFunction
+	Shift    []float64
+}
+
+// Apply the fitness function wrapped in ShiftedFunction to the shifted genome.
+func (ff ShiftedFunction) apply(genome Genome) float64 {
+	var shifted = make(Genome, len(genome))
+	for i, gene := range genome {
+		shifted[i] = gene.(float64) - ff.Shift[i]
+	}
+	return ff.Function.apply(shifted)
+}
+
+// RotatedFunction evaluates a function of floating point genes at Matrix * x,
+// Matrix being given row by row. Rotating a separable benchmark function
+// creates dependencies between the variables, which defeats the operators
+// that optimize each gene on it's own. RandomRotation returns a suitable
+// matrix. Matrix should be square with as many rows as there are genes.
+type RotatedFunction struct {
+	Function FitnessFunction
+	Matrix   [][]float64
+}
+
+// Apply the fitness function wrapped in RotatedFunction to the rotated genome.
+func (ff RotatedFunction) apply(genome Genome) float64 {
+	var rotated = make(Genome, len(ff.Matrix))
+	for i, row := range ff.Matrix {
+		var y float64
+		for j, m := range row {
+			y += m * genome[j].(float64)
+		}
+		rotated[i] = y
+	}
+	return ff.Function.apply(rotated)
+}
+
+// RandomRotation returns a random n by n rotation matrix, which is obtained by
+// orthonormalizing a matrix of normally distributed values with the
+// Gram-Schmidt process.
+func RandomRotation(n int, rng *rand.Rand) [][]float64 {
+	var matrix = make([][]float64, n)
+	for i := range matrix {
+		for {
+			matrix[i] = make([]float64, n)
+			for j := range matrix[i] {
+				matrix[i][j] = rng.NormFloat64()
+			}
+			// Remove the projections on the previous rows
+			for _, row := range matrix[:i] {
+				var dot = dotProduct(matrix[i], row)
+				for j := range row {
+					matrix[i][j] -= dot * row[j]
+				}
+			}
+			// Normalize the row, a row that is almost null is drawn again
+			var norm = math.Sqrt(dotProduct(matrix[i], matrix[i]))
+			if norm > 1e-10 {
+				for j := range matrix[i] {
+					matrix[i][j] /= norm
+				}
+				break
+			}
+		}
+	}
+	return matrix
+}
+
+// RandomShift returns a random shift vector of n values drawn uniformly in
+// [lower, upper], to be used by ShiftedFunction. The bounds are usually a
+// fraction of the search domain, for example 80%, so that the shifted optimum
+// stays inside it.
+func RandomShift(n int, lower, upper float64, rng *rand.Rand) []float64 {
+	var shift = make([]float64, n)
+	for i := range shift {
+		shift[i] = lower + rng.Float64()*(upper-lower)
+	}
+	return shift
+}
+
+// RandomTransform returns a random n by n linear transform R * D * Q, to be
+// used by RotatedFunction, where R and Q are random rotations and D is a
+// diagonal matrix whose values go from 1 to the square root of condition on a
+// logarithmic scale. The transformed sphere function is thus an ill-conditioned
+// ellipsoid whose Hessian has a condition number of condition, a condition of 1
+// or less gives a rotation.
+func RandomTransform(n int, condition float64, rng *rand.Rand) [][]float64 {
+	var (
+		r = RandomRotation(n, rng)
+		q = RandomRotation(n, rng)
+	)
+	if condition <= 1 || n < 2 {
+		return r
+	}
+	// Scale the columns of R to obtain R * D
+	for j := 0; j < n; j++ {
+		var scale = math.Pow(condition, 0.5*float64(j)/float64(n-1))
+		for i := range r {
+			r[i][j] *= scale
+		}
+	}
+	return matrixProduct(r, q)
+}
+
+// RandomBenchmark returns a randomly shifted and rotated version of a fitness
+// function of n floating point genes, f(M * (x - shift)), whose optimum at the
+// origin is moved to a random point in [lower, upper]. Comparing operators on
+// such functions prevents the operators that exploit the separability of a
+// function or the position of it's optimum from being favored. The returned
+// function is a ShiftedFunction wrapping a RotatedFunction, hence the shift and
+// the matrix can be retrieved.
+func RandomBenchmark(ff FitnessFunction, n int, lower, upper float64, rng *rand.Rand) ShiftedFunction {
+	return ShiftedFunction{
+		Function: RotatedFunction{Function: ff, Matrix: RandomRotation(n, rng)},
+		Shift:    RandomShift(n, lower, upper, rng),
+	}
+}
+
+// Return the product of two matrices given row by row, the number of columns
+// of a should be the number of rows of b.
+func matrixProduct(a, b [][]float64) [][]float64 {
+	var product = make([][]float64, len(a))
+	for i, row := range a {
+		product[i] = make([]float64, len(b[0]))
+		for k, x := range row {
+			for j, y := range b[k] {
+				product[i][j] += x * y
+			}
+		}
+	}
+	return product
+}
+
+// Return the dot product of two vectors of the same length.
+func dotProduct(a, b []float64) float64 {
+	var dot float64
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return dot
+}
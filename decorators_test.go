@@ -0,0 +1,157 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// The sphere function, whose optimum is at the origin.
+var sphere = Float64Function{func(X []float64) float64 {
+	var sum float64
+	for _, x := range X {
+		sum += x * x
+	}
+	return sum
+}}
+
+func TestPenaltyFunction(t *testing.T) {
+	var ff = PenaltyFunction{
+		Function: sphere,
+		Penalty: func(genome Genome) float64 {
+			if genome[0].(float64) < 0 {
+				return 100
+			}
+			return 0
+		},
+	}
+	if ff.apply(Genome{1.0, 1.0}) != 2 || ff.apply(Genome{-1.0, 1.0}) != 102 {
+		t.Error("PenaltyFunction didn't add the penalty")
+	}
+}
+
+func TestNoisyFunction(t *testing.T) {
+	var (
+		a = &NoisyFunction{Function: sphere, Std: 1, Seed: 42}
+		b = &NoisyFunction{Function: sphere, Std: 1, Seed: 42}
+	)
+	var sum float64
+	for i := 0; i < 1000; i++ {
+		var fitness = a.apply(Genome{1.0})
+		if fitness != b.apply(Genome{1.0}) {
+			t.Fatal("NoisyFunction isn't reproducible with the same seed")
+		}
+		sum += fitness
+	}
+	if mean := sum / 1000; math.Abs(mean-1) > 0.2 {
+		t.Errorf("Expected a mean fitness close to 1, got %f", mean)
+	}
+	if (&NoisyFunction{Function: sphere}).apply(Genome{1.0}) != 1 {
+		t.Error("NoisyFunction added noise with a standard deviation of 0")
+	}
+}
+
+func TestLogFunction(t *testing.T) {
+	var ff = LogFunction{Function: sphere, Offset: 1}
+	if math.Abs(ff.apply(Genome{1.0, 1.0})-math.Log(3)) > 1e-10 {
+		t.Error("LogFunction didn't take the logarithm of the fitness")
+	}
+}
+
+func TestShiftedFunction(t *testing.T) {
+	var ff = ShiftedFunction{Function: sphere, Shift: []float64{1, -2}}
+	if ff.apply(Genome{1.0, -2.0}) != 0 || ff.apply(Genome{0.0, 0.0}) != 5 {
+		t.Error("ShiftedFunction didn't move the optimum")
+	}
+}
+
+func TestRotatedFunction(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(42))
+		matrix = RandomRotation(4, rng)
+	)
+	// The rows of a rotation matrix are orthonormal
+	for i := range matrix {
+		for j := range matrix {
+			var expected = 0.0
+			if i == j {
+				expected = 1
+			}
+			if math.Abs(dotProduct(matrix[i], matrix[j])-expected) > 1e-10 {
+				t.Fatalf("Rows %d and %d aren't orthonormal", i, j)
+			}
+		}
+	}
+	// A rotation preserves the sphere function and the decorators can be
+	// nested
+	var ff = RotatedFunction{
+		Function: ShiftedFunction{Function: sphere, Shift: []float64{1, 1, 1, 1}},
+		Matrix:   matrix,
+	}
+	var genome = Genome{0.5, -1.0, 2.0, 0.0}
+	var rotated = RotatedFunction{Function: sphere, Matrix: matrix}
+	if math.Abs(rotated.apply(genome)-sphere.apply(genome)) > 1e-10 {
+		t.Error("The rotation didn't preserve the norm of the genome")
+	}
+	if ff.apply(genome) == sphere.apply(genome) {
+		t.Error("The nested decorators didn't transform the genome")
+	}
+}
+
+func TestRandomTransform(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(42))
+		matrix = RandomTransform(5, 1e4, rng)
+	)
+	// The eigenvalues of M^T M go from 1 to the condition, hence the
+	// transformed sphere function is bounded by the norm of the genome
+	// times 1 and times the condition
+	var lowest, highest = math.Inf(1), math.Inf(-1)
+	for i := 0; i < 1000; i++ {
+		var genome = make(Genome, 5)
+		var norm float64
+		for j := range genome {
+			var x = rng.NormFloat64()
+			genome[j] = x
+			norm += x * x
+		}
+		var ratio = RotatedFunction{Function: sphere, Matrix: matrix}.apply(genome) / norm
+		lowest = math.Min(lowest, ratio)
+		highest = math.Max(highest, ratio)
+	}
+	if lowest < 1-1e-9 || highest > 1e4+1e-6 || highest/lowest < 100 {
+		t.Errorf("Expected ratios between 1 and 10000, got %f and %f", lowest, highest)
+	}
+	// A condition of 1 gives a rotation
+	matrix = RandomTransform(3, 1, rng)
+	if math.Abs(dotProduct(matrix[0], matrix[0])-1) > 1e-10 {
+		t.Error("Expected a rotation")
+	}
+}
+
+func TestRandomBenchmark(t *testing.T) {
+	var (
+		rng = rand.New(rand.NewSource(42))
+		ff  = RandomBenchmark(sphere, 3, -4, 4, rng)
+	)
+	var optimum = make(Genome, 3)
+	for i, x := range ff.Shift {
+		if x < -4 || x > 4 {
+			t.Fatalf("The shift %f is out of bounds", x)
+		}
+		optimum[i] = x
+	}
+	if ff.apply(optimum) > 1e-20 {
+		t.Error("The optimum should be at the shift")
+	}
+	if _, ok := ff.Function.(RotatedFunction); !ok {
+		t.Error("The benchmark should be rotated")
+	}
+}
+
+func TestMatrixProduct(t *testing.T) {
+	var product = matrixProduct([][]float64{{1, 2}, {3, 4}}, [][]float64{{0, 1}, {1, 0}})
+	if product[0][0] != 2 || product[0][1] != 1 || product[1][0] != 4 || product[1][1] != 3 {
+		t.Errorf("Expected [[2 1] [4 3]], got %v", product)
+	}
+}
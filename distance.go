@@ -0,0 +1,130 @@
+package gago
+
+import "math"
+
+// A DistanceMetric computes the distance between the genomes of two
+// individuals. Distances are used by operators that need to know how similar
+// individuals are, for example niching methods.
+type DistanceMetric interface {
+	Apply(a, b Individual) float64
+}
+
+// DistEuclidean computes the Euclidean distance between two genomes. Only
+// works for floating point values.
+type DistEuclidean struct{}
+
+// Apply the Euclidean distance.
+func (dist DistEuclidean) Apply(a, b Individual) float64 {
+	var sum float64
+	for i := range a.Genome {
+		sum += math.Pow(a.Genome[i].(float64)-b.Genome[i].(float64), 2)
+	}
+	return math.Sqrt(sum)
+}
+
+// DistHamming counts the number of positions at which two genomes have
+// different genes. It works for any type of comparable gene.
+type DistHamming struct{}
+
+// Apply the Hamming distance.
+func (dist DistHamming) Apply(a, b Individual) float64 {
+	var count float64
+	for i := range a.Genome {
+		if a.Genome[i] != b.Genome[i] {
+			count++
+		}
+	}
+	return count
+}
+
+// Compute the position of each gene of a's genome in b's genome, both genomes
+// being permutations of the same genes.
+func positions(a, b Individual) []int {
+	var (
+		index = make(map[interface{}]int, len(b.Genome))
+		pos   = make([]int, len(a.Genome))
+	)
+	for i, gene := range b.Genome {
+		index[gene] = i
+	}
+	for i, gene := range a.Genome {
+		pos[i] = index[gene]
+	}
+	return pos
+}
+
+// DistKendallTau counts the number of pairs of genes that are not in the same
+// order in two genomes, which is the number of swaps of adjacent genes
+// required to transform one genome into the other. Both genomes have to be
+// permutations of the same comparable genes.
+type DistKendallTau struct{}
+
+// Apply the Kendall tau distance.
+func (dist DistKendallTau) Apply(a, b Individual) float64 {
+	var pos = positions(a, b)
+	return float64(inversions(pos, make([]int, len(pos))))
+}
+
+// Count the number of inversions in a slice of integers with a merge sort, the
+// slice is sorted in the process.
+func inversions(s, buffer []int) int {
+	if len(s) < 2 {
+		return 0
+	}
+	var (
+		mid   = len(s) / 2
+		count = inversions(s[:mid], buffer[:mid]) + inversions(s[mid:], buffer[mid:])
+		i, j  = 0, mid
+	)
+	for k := range buffer {
+		if j == len(s) || (i < mid && s[i] <= s[j]) {
+			buffer[k] = s[i]
+			i++
+		} else {
+			buffer[k] = s[j]
+			j++
+			count += mid - i
+		}
+	}
+	copy(s, buffer)
+	return count
+}
+
+// DistSwap counts the minimum number of swaps of any two genes required to
+// transform one genome into the other. Both genomes have to be permutations of
+// the same comparable genes.
+type DistSwap struct{}
+
+// Apply the swap distance.
+func (dist DistSwap) Apply(a, b Individual) float64 {
+	var (
+		pos     = positions(a, b)
+		visited = make([]bool, len(pos))
+		cycles  int
+	)
+	// Each cycle of length l requires l - 1 swaps
+	for i := range pos {
+		if !visited[i] {
+			cycles++
+			for j := i; !visited[j]; j = pos[j] {
+				visited[j] = true
+			}
+		}
+	}
+	return float64(len(pos) - cycles)
+}
+
+// Diversity returns the mean distance between each pair of individuals, it is
+// 0 if there are less than two individuals.
+func (indis Individuals) Diversity(metric DistanceMetric) float64 {
+	if len(indis) < 2 {
+		return 0
+	}
+	var total float64
+	for i := range indis {
+		for j := i + 1; j < len(indis); j++ {
+			total += metric.Apply(indis[i], indis[j])
+		}
+	}
+	return total / float64(len(indis)*(len(indis)-1)/2)
+}
@@ -0,0 +1,52 @@
+package gago
+
+import "testing"
+
+func TestDistances(t *testing.T) {
+	var testCases = []struct {
+		metric   DistanceMetric
+		a, b     Genome
+		distance float64
+	}{
+		{DistEuclidean{}, Genome{0.0, 0.0}, Genome{3.0, 4.0}, 5},
+		{DistEuclidean{}, Genome{1.0, 2.0}, Genome{1.0, 2.0}, 0},
+		{DistHamming{}, Genome{"a", "b", "c"}, Genome{"a", "c", "b"}, 2},
+		{DistHamming{}, Genome{true, false}, Genome{true, false}, 0},
+		{DistKendallTau{}, Genome{0, 1, 2, 3}, Genome{0, 1, 2, 3}, 0},
+		{DistKendallTau{}, Genome{0, 1, 2, 3}, Genome{1, 0, 2, 3}, 1},
+		{DistKendallTau{}, Genome{0, 1, 2, 3}, Genome{3, 2, 1, 0}, 6},
+		{DistKendallTau{}, Genome{"a", "b", "c"}, Genome{"c", "a", "b"}, 2},
+		{DistSwap{}, Genome{0, 1, 2, 3}, Genome{0, 1, 2, 3}, 0},
+		{DistSwap{}, Genome{0, 1, 2, 3}, Genome{3, 2, 1, 0}, 2},
+		{DistSwap{}, Genome{0, 1, 2, 3}, Genome{1, 2, 3, 0}, 3},
+		{DistSwap{}, Genome{"a", "b", "c"}, Genome{"c", "a", "b"}, 2},
+	}
+	for _, testCase := range testCases {
+		var (
+			a = Individual{Genome: testCase.a}
+			b = Individual{Genome: testCase.b}
+		)
+		if testCase.metric.Apply(a, b) != testCase.distance {
+			t.Error("Distance metric didn't work as expected")
+		}
+		// Check the metric is symmetric
+		if testCase.metric.Apply(a, b) != testCase.metric.Apply(b, a) {
+			t.Error("Distance metric isn't symmetric")
+		}
+	}
+}
+
+func TestDiversity(t *testing.T) {
+	var indis = Individuals{
+		Individual{Genome: Genome{0, 1, 2}},
+		Individual{Genome: Genome{0, 2, 1}},
+		Individual{Genome: Genome{2, 1, 0}},
+	}
+	// The pairwise Kendall tau distances are 1, 3 and 2
+	if d := indis.Diversity(DistKendallTau{}); d != 2 {
+		t.Errorf("Expected a diversity of 2, got %f", d)
+	}
+	if d := indis[:1].Diversity(DistKendallTau{}); d != 0 {
+		t.Errorf("A single individual should have a diversity of 0, got %f", d)
+	}
+}
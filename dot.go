@@ -0,0 +1,84 @@
+package gago
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WriteDOT writes the island topology of a GA in the DOT language of Graphviz,
+// which helps debugging structured population setups, for example with
+// "dot -Tsvg". Each population is a node labelled with the fitness of it's best
+// individual and it's mean fitness, the nodes are colored from green for the
+// population with the best individual to red for the population with the
+// worst best individual. Each edge of the topology is labelled with it's rate.
+// If flows isn't nil, such as the Flows of a MigTopology, the edges are also
+// labelled with the number of migrants that were sent along them and their
+// width is proportional to it, the edges along which no migrant was sent are
+// dashed.
+func WriteDOT(w io.Writer, pops Populations, topology Topology, flows *MigrationFlows) error {
+	var (
+		bw              = bufio.NewWriter(w)
+		edges           = topology.Edges(len(pops))
+		lowest, highest = math.Inf(1), math.Inf(-1)
+		maxCount        int
+	)
+	for _, pop := range pops {
+		if len(pop.Individuals) > 0 {
+			lowest = math.Min(lowest, pop.Individuals[0].Fitness)
+			highest = math.Max(highest, pop.Individuals[0].Fitness)
+		}
+	}
+	if flows != nil {
+		for _, edge := range edges {
+			maxCount = max(maxCount, flows.Count(edge.From, edge.To))
+		}
+	}
+	fmt.Fprintln(bw, "digraph topology {")
+	fmt.Fprintln(bw, "\tnode [shape=box, style=filled];")
+	for i, pop := range pops {
+		if len(pop.Individuals) == 0 {
+			fmt.Fprintf(bw, "\t%d [label=\"population %d\\nempty\", fillcolor=\"0 0 0.9\"];\n", i, i)
+			continue
+		}
+		// Green is the hue 1/3 and red is the hue 0
+		var (
+			best = pop.Individuals[0].Fitness
+			hue  = 1.0 / 3
+		)
+		if highest > lowest {
+			hue = (highest - best) / (highest - lowest) / 3
+		}
+		fmt.Fprintf(
+			bw,
+			"\t%d [label=\"population %d\\nbest %s\\nmean %s\", fillcolor=\"%.3f 0.4 1\"];\n",
+			i, i, formatFloat(best), formatFloat(pop.Individuals.FitnessMean()), hue,
+		)
+	}
+	for _, edge := range edges {
+		var label = "rate " + formatFloat(edge.Rate)
+		if flows == nil {
+			fmt.Fprintf(bw, "\t%d -> %d [label=\"%s\"];\n", edge.From, edge.To, label)
+			continue
+		}
+		var (
+			count = flows.Count(edge.From, edge.To)
+			width = 1.0
+			style = "solid"
+		)
+		if maxCount > 0 {
+			width += 4 * float64(count) / float64(maxCount)
+		}
+		if count == 0 {
+			style = "dashed"
+		}
+		fmt.Fprintf(
+			bw,
+			"\t%d -> %d [label=\"%s\\n%d migrants\", penwidth=%.2f, style=%s];\n",
+			edge.From, edge.To, label, count, width, style,
+		)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
@@ -0,0 +1,53 @@
+package gago
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	var (
+		flows    = &MigrationFlows{}
+		topology = TopGraph{{From: 0, To: 1, Rate: 0.1}, {From: 1, To: 2, Rate: 0.2}, {From: 2, To: 0}}
+		ga       = GA{
+			NbrPopulations: 3,
+			NbrIndividuals: nbIndividuals,
+			NbrGenes:       nbGenes,
+			Ff:             ff,
+			Initializer:    initializer,
+			Model:          model,
+			Migrator:       MigTopology{Topology: topology, Flows: flows},
+			MigFrequency:   1,
+		}
+	)
+	ga.Initialize()
+	for i := 0; i < 4; i++ {
+		ga.Enhance()
+	}
+	if flows.Count(0, 1) != 4*3 || flows.Count(1, 2) != 4*6 || flows.Count(2, 0) != 0 {
+		t.Errorf("Expected 12, 24 and 0 migrants, got %d, %d and %d", flows.Count(0, 1), flows.Count(1, 2), flows.Count(2, 0))
+	}
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, ga.Populations, topology, flows); err != nil {
+		t.Fatal(err)
+	}
+	var dot = buf.String()
+	for _, expected := range []string{
+		"digraph topology {",
+		"population 2\\nbest ",
+		"0 -> 1 [label=\"rate 0.1\\n12 migrants\"",
+		"1 -> 2 [label=\"rate 0.2\\n24 migrants\", penwidth=5.00, style=solid]",
+		"2 -> 0 [label=\"rate 0\\n0 migrants\", penwidth=1.00, style=dashed]",
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("Expected the graph to contain %q, got\n%s", expected, dot)
+		}
+	}
+	// Without flows the edges only have their rates
+	buf.Reset()
+	WriteDOT(&buf, ga.Populations, topology, nil)
+	if strings.Contains(buf.String(), "migrants") {
+		t.Error("The edges shouldn't be labelled with migrants without flows")
+	}
+}
@@ -0,0 +1,214 @@
+package gago
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// A GeneDrift describes how the distribution of a gene differs between two
+// snapshots A and B of a population. A gene is numeric if all it's values are
+// float64, int or bool genes, bools counting as 0 or 1, in which case it's mean
+// and standard deviation are given and the distance between the two
+// distributions is the Kolmogorov-Smirnov statistic. Otherwise the distance is
+// the total variation distance between the frequencies of the values. Both
+// distances belong to [0, 1], 0 meaning that the distributions are identical.
+// The mode of a snapshot is the frequency of the most common value of the
+// gene, a mode of 1 means that the gene has converged.
+type GeneDrift struct {
+	Gene     int     `json:"gene"`
+	Numeric  bool    `json:"numeric"`
+	MeanA    float64 `json:"mean_a"`
+	MeanB    float64 `json:"mean_b"`
+	StdA     float64 `json:"std_a"`
+	StdB     float64 `json:"std_b"`
+	ModeA    float64 `json:"mode_a"`
+	ModeB    float64 `json:"mode_b"`
+	Distance float64 `json:"distance"`
+}
+
+// A DriftReport compares the genes of two snapshots of a population, for
+// example the individuals of a run at two generations or the final individuals
+// of two runs. A genome that holds a single Vector is treated as a genome of
+// float64 genes.
+type DriftReport struct {
+	SizeA int         `json:"size_a"`
+	SizeB int         `json:"size_b"`
+	Genes []GeneDrift `json:"genes"`
+}
+
+// Return the genes of a genome, the values of a Vector are returned
+// separately.
+func snapshotGenes(genome Genome) Genome {
+	if len(genome) == 1 {
+		if v, ok := genome[0].(Vector); ok {
+			var genes = make(Genome, len(v))
+			for i, x := range v {
+				genes[i] = x
+			}
+			return genes
+		}
+	}
+	return genome
+}
+
+// Convert a gene to a number if possible.
+func geneNumber(gene interface{}) (float64, bool) {
+	switch x := gene.(type) {
+	case float64:
+		return x, true
+	case int:
+		return float64(x), true
+	case bool:
+		if x {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// Return the frequency of the most common value and the frequency of each
+// value, the values that can't be compared are identified by their string
+// representation.
+func geneFrequencies(values []interface{}) (float64, map[interface{}]float64) {
+	var (
+		counts = make(map[interface{}]int)
+		freqs  = make(map[interface{}]float64)
+		mode   int
+	)
+	for _, v := range values {
+		if v != nil && !reflect.TypeOf(v).Comparable() {
+			v = fmt.Sprint(v)
+		}
+		counts[v]++
+		mode = max(mode, counts[v])
+	}
+	for v, c := range counts {
+		freqs[v] = float64(c) / float64(len(values))
+	}
+	return float64(mode) / float64(len(values)), freqs
+}
+
+// Compute the Kolmogorov-Smirnov statistic between two samples, which is the
+// largest difference between their empirical distribution functions.
+func ksStatistic(a, b []float64) float64 {
+	a, b = append([]float64{}, a...), append([]float64{}, b...)
+	sort.Float64s(a)
+	sort.Float64s(b)
+	var (
+		i, j int
+		d    float64
+	)
+	for i < len(a) && j < len(b) {
+		var x = math.Min(a[i], b[j])
+		for i < len(a) && a[i] == x {
+			i++
+		}
+		for j < len(b) && b[j] == x {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/float64(len(a))-float64(j)/float64(len(b))))
+	}
+	return d
+}
+
+// Compare the distribution of a gene in two snapshots.
+func geneDrift(gene int, a, b []interface{}) GeneDrift {
+	var drift = GeneDrift{Gene: gene, Numeric: true}
+	var fa, fb = make([]float64, len(a)), make([]float64, len(b))
+	for i, v := range a {
+		var ok bool
+		if fa[i], ok = geneNumber(v); !ok {
+			drift.Numeric = false
+		}
+	}
+	for i, v := range b {
+		var ok bool
+		if fb[i], ok = geneNumber(v); !ok {
+			drift.Numeric = false
+		}
+	}
+	var freqsA, freqsB map[interface{}]float64
+	drift.ModeA, freqsA = geneFrequencies(a)
+	drift.ModeB, freqsB = geneFrequencies(b)
+	if drift.Numeric {
+		drift.MeanA, drift.MeanB = mean(fa), mean(fb)
+		drift.StdA = math.Sqrt(math.Max(variance(fa), 0))
+		drift.StdB = math.Sqrt(math.Max(variance(fb), 0))
+		drift.Distance = ksStatistic(fa, fb)
+		return drift
+	}
+	for v, p := range freqsA {
+		drift.Distance += math.Abs(p-freqsB[v]) / 2
+	}
+	for v, p := range freqsB {
+		if _, ok := freqsA[v]; !ok {
+			drift.Distance += p / 2
+		}
+	}
+	return drift
+}
+
+// Drift compares the genes of two snapshots, position by position. A position
+// is only compared if both snapshots have individuals whose genome is long
+// enough, the other individuals are left out for that position.
+func Drift(a, b Individuals) (DriftReport, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return DriftReport{}, errors.New("the snapshots should not be empty")
+	}
+	var (
+		report  = DriftReport{SizeA: len(a), SizeB: len(b)}
+		columns = func(indis Individuals) [][]interface{} {
+			var cols [][]interface{}
+			for _, indi := range indis {
+				for i, gene := range snapshotGenes(indi.Genome) {
+					if i == len(cols) {
+						cols = append(cols, nil)
+					}
+					cols[i] = append(cols[i], gene)
+				}
+			}
+			return cols
+		}
+		colsA, colsB = columns(a), columns(b)
+	)
+	for i := 0; i < len(colsA) && i < len(colsB); i++ {
+		report.Genes = append(report.Genes, geneDrift(i, colsA[i], colsB[i]))
+	}
+	return report, nil
+}
+
+// WriteJSON writes the report as a JSON document.
+func (report DriftReport) WriteJSON(w io.Writer) error {
+	var enc = json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteCSV writes the report as a CSV table with a row per gene.
+func (report DriftReport) WriteCSV(w io.Writer) error {
+	var cw = csv.NewWriter(w)
+	cw.Write([]string{"gene", "numeric", "mean_a", "mean_b", "std_a", "std_b", "mode_a", "mode_b", "distance"})
+	for _, g := range report.Genes {
+		cw.Write([]string{
+			strconv.Itoa(g.Gene),
+			strconv.FormatBool(g.Numeric),
+			formatFloat(g.MeanA),
+			formatFloat(g.MeanB),
+			formatFloat(g.StdA),
+			formatFloat(g.StdB),
+			formatFloat(g.ModeA),
+			formatFloat(g.ModeB),
+			formatFloat(g.Distance),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
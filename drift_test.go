@@ -0,0 +1,95 @@
+package gago
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestKSStatistic(t *testing.T) {
+	var testCases = []struct {
+		a, b []float64
+		d    float64
+	}{
+		{[]float64{1, 2, 3}, []float64{1, 2, 3}, 0},
+		{[]float64{1, 2}, []float64{3, 4}, 1},
+		{[]float64{1, 2, 3, 4}, []float64{3, 4, 5, 6}, 0.5},
+	}
+	for _, test := range testCases {
+		if d := ksStatistic(test.a, test.b); math.Abs(d-test.d) > 1e-10 {
+			t.Errorf("Expected %f, got %f", test.d, d)
+		}
+	}
+}
+
+func TestDrift(t *testing.T) {
+	var (
+		a = Individuals{
+			{Genome: Genome{0.0, "x", true}},
+			{Genome: Genome{2.0, "y", false}},
+		}
+		b = Individuals{
+			{Genome: Genome{4.0, "x", true}},
+			{Genome: Genome{4.0, "x", true, 1.0}},
+		}
+		report, err = Drift(a, b)
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Genes) != 3 {
+		t.Fatalf("Expected 3 genes, got %d", len(report.Genes))
+	}
+	var g = report.Genes[0]
+	if !g.Numeric || g.MeanA != 1 || g.MeanB != 4 || g.StdA != 1 || g.StdB != 0 || g.Distance != 1 || g.ModeB != 1 {
+		t.Errorf("Unexpected drift of the float64 gene %+v", g)
+	}
+	g = report.Genes[1]
+	if g.Numeric || g.ModeA != 0.5 || g.ModeB != 1 || math.Abs(g.Distance-0.5) > 1e-10 {
+		t.Errorf("Unexpected drift of the string gene %+v", g)
+	}
+	if g = report.Genes[2]; !g.Numeric || g.MeanA != 0.5 || g.MeanB != 1 {
+		t.Errorf("Unexpected drift of the bool gene %+v", g)
+	}
+	if _, err = Drift(a, nil); err == nil {
+		t.Error("An empty snapshot should return an error")
+	}
+}
+
+func TestDriftVector(t *testing.T) {
+	var report, _ = Drift(
+		Individuals{{Genome: Genome{Vector{1, 2}}}},
+		Individuals{{Genome: Genome{Vector{1, 3}}}},
+	)
+	if len(report.Genes) != 2 || report.Genes[0].Distance != 0 || report.Genes[1].Distance != 1 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestDriftReportWrite(t *testing.T) {
+	var report, _ = Drift(
+		Individuals{{Genome: Genome{1.0, "a"}}},
+		Individuals{{Genome: Genome{2.0, "b"}}},
+	)
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded DriftReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Genes) != 2 || decoded.Genes[0].MeanB != 2 {
+		t.Errorf("Unexpected decoded report %+v", decoded)
+	}
+	buf.Reset()
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "gene,numeric") || lines[1] != "0,true,1,2,0,0,1,1,1" {
+		t.Errorf("Unexpected CSV %q", lines)
+	}
+}
@@ -0,0 +1,291 @@
+package gago
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// A marginals is a probability model where the genes are independent. A bool
+// gene is true with probability mean, a float64 gene follows a normal
+// distribution of mean mean and standard deviation std. The other genes are
+// copied from a template genome when sampling.
+type marginals struct {
+	kinds []byte // 'b' for a bool gene, 'f' for a float64 gene, 0 otherwise
+	mean  []float64
+	std   []float64
+}
+
+// Estimate the marginal distribution of each gene of a set of individuals.
+func estimateMarginals(indis Individuals) *marginals {
+	var (
+		n = len(indis[0].Genome)
+		m = &marginals{
+			kinds: make([]byte, n),
+			mean:  make([]float64, n),
+			std:   make([]float64, n),
+		}
+	)
+	for i, gene := range indis[0].Genome {
+		switch gene.(type) {
+		case bool:
+			m.kinds[i] = 'b'
+		case float64:
+			m.kinds[i] = 'f'
+		}
+	}
+	var values = make([]float64, len(indis))
+	for i, kind := range m.kinds {
+		if kind == 0 {
+			continue
+		}
+		for j, indi := range indis {
+			switch gene := indi.Genome[i].(type) {
+			case bool:
+				values[j] = 0
+				if gene {
+					values[j] = 1
+				}
+			case float64:
+				values[j] = gene
+			}
+		}
+		m.mean[i] = mean(values)
+		if kind == 'f' {
+			m.std[i] = math.Sqrt(math.Max(variance(values), 0))
+		}
+	}
+	return m
+}
+
+// Move the model toward another model with a learning rate.
+func (m *marginals) blend(target *marginals, alpha float64) {
+	for i := range m.mean {
+		m.mean[i] += alpha * (target.mean[i] - m.mean[i])
+		m.std[i] += alpha * (target.std[i] - m.std[i])
+	}
+}
+
+// Keep the probabilities of the bool genes within [margin, 1-margin] and the
+// standard deviations of the float64 genes above minStd.
+func (m *marginals) bound(margin, minStd float64) {
+	for i, kind := range m.kinds {
+		switch kind {
+		case 'b':
+			m.mean[i] = math.Min(math.Max(m.mean[i], margin), 1-margin)
+		case 'f':
+			m.std[i] = math.Max(m.std[i], minStd)
+		}
+	}
+}
+
+// Sample a genome from the model, the genes that aren't modelled are copied
+// from a template.
+func (m *marginals) sample(template Genome, rng *rand.Rand) Genome {
+	var genome = make(Genome, len(m.kinds))
+	for i, kind := range m.kinds {
+		switch kind {
+		case 'b':
+			genome[i] = rng.Float64() < m.mean[i]
+		case 'f':
+			genome[i] = m.mean[i] + m.std[i]*rng.NormFloat64()
+		default:
+			genome[i] = template[i]
+		}
+	}
+	return genome
+}
+
+// Replace the individuals of a population, except the elites, by individuals
+// sampled from a model. The templates are chosen among the best individuals.
+func sampleMarginals(pop *Population, m *marginals, sorted Individuals, elitism, nbBest int) {
+	var indis = make(Individuals, len(pop.Individuals))
+	copy(indis, sorted[:elitism])
+	for i := elitism; i < len(indis); i++ {
+		indis[i] = makeIndividual(0, pop.rng)
+		indis[i].Genome = m.sample(sorted[pop.rng.Intn(nbBest)].Genome, pop.rng)
+	}
+	pop.Individuals = indis
+}
+
+// Return a copy of the individuals of a population sorted with it's
+// Comparator.
+func sortedIndividuals(pop *Population) Individuals {
+	var sorted = make(Individuals, len(pop.Individuals))
+	copy(sorted, pop.Individuals)
+	sorted.SortWith(pop.cmp)
+	return sorted
+}
+
+// ModUMDA implements the univariate marginal distribution algorithm, an
+// estimation of distribution algorithm. Instead of applying genetic operators
+// the model selects the best Fraction of the individuals, half of them if
+// Fraction is 0, estimates the distribution of each gene over them and samples
+// the next generation from these distributions, except for the Elitism best
+// individuals which are kept. The bool genes follow a Bernoulli distribution,
+// whose probability is kept within [1/n, 1-1/n] for n genes so that no gene
+// gets fixed, and the float64 genes follow a normal distribution, whose
+// standard deviation is kept above MinStd. The other genes are copied from one
+// of the selected individuals. The float64 genes are not bounded, a Repairer
+// can be used to bring them back within the search space.
+type ModUMDA struct {
+	Fraction float64
+	Elitism  int
+	MinStd   float64
+}
+
+// Apply UMDA to a population.
+func (mod ModUMDA) Apply(pop *Population) {
+	var fraction = mod.Fraction
+	if fraction == 0 {
+		fraction = 0.5
+	}
+	var (
+		sorted = sortedIndividuals(pop)
+		k      = max(1, int(fraction*float64(len(sorted))))
+		m      = estimateMarginals(sorted[:k])
+	)
+	m.bound(1/float64(len(m.kinds)), mod.MinStd)
+	sampleMarginals(pop, m, sorted, min(mod.Elitism, len(sorted)), k)
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModUMDA) Validate() error {
+	// Check the fraction of selected individuals
+	if mod.Fraction < 0 || mod.Fraction > 1 {
+		return errors.New("'Fraction' should belong to the [0, 1] interval")
+	}
+	// Check the number of elites
+	if mod.Elitism < 0 {
+		return errors.New("'Elitism' should be higher or equal to 0")
+	}
+	// Check the minimum standard deviation
+	if mod.MinStd < 0 {
+		return errors.New("'MinStd' should be higher or equal to 0")
+	}
+	return nil
+}
+
+// ModPBIL implements population-based incremental learning, an estimation of
+// distribution algorithm that keeps a model of each population from one
+// generation to the next, as opposed to ModUMDA. The model starts as the
+// distribution of the genes of the initial population. At each generation it
+// moves toward the distribution of the NbrBest best individuals, the best one
+// if NbrBest is 0, with learning rate Alpha, 0.1 if Alpha is 0. Each gene of
+// the model is then mutated with probability MutProb: the probability of a bool
+// gene moves toward 0 or 1 by MutShift and the mean of a float64 gene moves by
+// MutShift times it's standard deviation, MutShift being 0.05 if it's 0.
+// Finally the next generation is sampled from the model, except for the
+// Elitism best individuals which are kept. The distributions are the same as
+// in ModUMDA, the bool probabilities are kept within [1/n, 1-1/n] and the
+// standard deviations above MinStd, which should be set because the standard
+// deviations shrink as the model learns from the best individuals.
+//
+// The model of a population is replaced when the population is created again,
+// for example when the GA is initialized. ModPBIL keeps the models of the
+// populations, hence it has to be used through a pointer.
+type ModPBIL struct {
+	NbrBest  int
+	Alpha    float64
+	MutProb  float64
+	MutShift float64
+	Elitism  int
+	MinStd   float64
+	mu       sync.Mutex
+	models   map[*rand.Rand]*marginals // Model of each population, identified by it's generator
+}
+
+// Return the model of a population, the model is created if the population
+// doesn't have one. The lock has to be held.
+func (mod *ModPBIL) model(pop *Population, sorted Individuals) *marginals {
+	if mod.models == nil {
+		mod.models = make(map[*rand.Rand]*marginals)
+	}
+	var m, ok = mod.models[pop.rng]
+	if !ok || len(m.kinds) != len(sorted[0].Genome) {
+		m = estimateMarginals(sorted)
+		mod.models[pop.rng] = m
+	}
+	return m
+}
+
+// Apply PBIL to a population.
+func (mod *ModPBIL) Apply(pop *Population) {
+	mod.mu.Lock()
+	defer mod.mu.Unlock()
+	var (
+		sorted = sortedIndividuals(pop)
+		m      = mod.model(pop, sorted)
+		nbBest = min(max(mod.NbrBest, 1), len(sorted))
+		alpha  = mod.Alpha
+		shift  = mod.MutShift
+	)
+	if alpha == 0 {
+		alpha = 0.1
+	}
+	if shift == 0 {
+		shift = 0.05
+	}
+	m.blend(estimateMarginals(sorted[:nbBest]), alpha)
+	for i, kind := range m.kinds {
+		if pop.rng.Float64() >= mod.MutProb {
+			continue
+		}
+		switch kind {
+		case 'b':
+			var target = 0.0
+			if pop.rng.Float64() < 0.5 {
+				target = 1
+			}
+			m.mean[i] += shift * (target - m.mean[i])
+		case 'f':
+			m.mean[i] += shift * m.std[i] * pop.rng.NormFloat64()
+		}
+	}
+	m.bound(1/float64(len(m.kinds)), mod.MinStd)
+	sampleMarginals(pop, m, sorted, min(mod.Elitism, len(sorted)), nbBest)
+}
+
+// Model returns the probability of each bool gene being true, or the mean of
+// each float64 gene, along with the standard deviation of each float64 gene, of
+// the model of a population; nil is returned if the population doesn't have a
+// model.
+func (mod *ModPBIL) Model(pop Population) ([]float64, []float64) {
+	mod.mu.Lock()
+	defer mod.mu.Unlock()
+	var m, ok = mod.models[pop.rng]
+	if !ok {
+		return nil, nil
+	}
+	return append([]float64{}, m.mean...), append([]float64{}, m.std...)
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod *ModPBIL) Validate() error {
+	// Check the number of best individuals
+	if mod.NbrBest < 0 {
+		return errors.New("'NbrBest' should be higher or equal to 0")
+	}
+	// Check the learning rate
+	if mod.Alpha < 0 || mod.Alpha > 1 {
+		return errors.New("'Alpha' should belong to the [0, 1] interval")
+	}
+	// Check the mutation probability
+	if mod.MutProb < 0 || mod.MutProb > 1 {
+		return errors.New("'MutProb' should belong to the [0, 1] interval")
+	}
+	// Check the mutation shift
+	if mod.MutShift < 0 || mod.MutShift > 1 {
+		return errors.New("'MutShift' should belong to the [0, 1] interval")
+	}
+	// Check the number of elites
+	if mod.Elitism < 0 {
+		return errors.New("'Elitism' should be higher or equal to 0")
+	}
+	// Check the minimum standard deviation
+	if mod.MinStd < 0 {
+		return errors.New("'MinStd' should be higher or equal to 0")
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEstimateMarginals(t *testing.T) {
+	var m = estimateMarginals(Individuals{
+		{Genome: Genome{true, 1.0, "a"}},
+		{Genome: Genome{true, 3.0, "b"}},
+		{Genome: Genome{false, 5.0, "c"}},
+		{Genome: Genome{true, 7.0, "d"}},
+	})
+	if string(m.kinds) != "bf\x00" {
+		t.Errorf("Unexpected kinds %q", m.kinds)
+	}
+	if m.mean[0] != 0.75 || m.mean[1] != 4 || math.Abs(m.std[1]-math.Sqrt(5)) > 1e-10 {
+		t.Errorf("Unexpected model %v %v", m.mean, m.std)
+	}
+	m.bound(0.1, 10)
+	m.mean[0] = 1
+	m.bound(0.1, 10)
+	if m.mean[0] != 0.9 || m.std[1] != 10 {
+		t.Error("The model wasn't bounded")
+	}
+	var genome = m.sample(Genome{false, 0.0, "z"}, rand.New(rand.NewSource(42)))
+	if _, ok := genome[0].(bool); !ok || genome[2] != "z" {
+		t.Errorf("Unexpected sample %v", genome)
+	}
+}
+
+func TestModUMDA(t *testing.T) {
+	var (
+		pop = makePopulation(20, 3, ff, initializer)
+		mod = ModUMDA{Fraction: 0.2, Elitism: 2, MinStd: 0.01}
+	)
+	pop.Individuals.Evaluate(pop.ff)
+	pop.Individuals.SortWith(nil)
+	var best = append(Individuals{}, pop.Individuals[:4]...)
+	mod.Apply(&pop)
+	if len(pop.Individuals) != 20 {
+		t.Error("The size of the population was modified")
+	}
+	if pop.Individuals[0].Name != best[0].Name || pop.Individuals[1].Name != best[1].Name {
+		t.Error("The elites weren't kept")
+	}
+	// The samples are drawn around the selected individuals
+	for _, indi := range pop.Individuals[2:] {
+		if indi.Evaluated {
+			t.Error("A sampled individual is marked as evaluated")
+		}
+		for i, gene := range indi.Genome {
+			var lower, upper = math.Inf(1), math.Inf(-1)
+			for _, b := range best {
+				lower = math.Min(lower, b.Genome[i].(float64))
+				upper = math.Max(upper, b.Genome[i].(float64))
+			}
+			if x := gene.(float64); x < lower-5*(upper-lower)-1 || x > upper+5*(upper-lower)+1 {
+				t.Errorf("The gene %f is far from the selected individuals", x)
+			}
+		}
+	}
+}
+
+func TestModPBIL(t *testing.T) {
+	var (
+		pop = makePopulation(10, 4, ff, InitUniformB{})
+		mod = &ModPBIL{Alpha: 0.5}
+	)
+	for i := range pop.Individuals {
+		for j := range pop.Individuals[i].Genome {
+			pop.Individuals[i].Genome[j] = i%2 == 0
+		}
+		pop.Individuals[i].Fitness = float64(i)
+	}
+	if mean, _ := mod.Model(pop); mean != nil {
+		t.Error("The population shouldn't have a model yet")
+	}
+	mod.Apply(&pop)
+	// The model starts at 0.5 and moves halfway toward the best individual,
+	// whose genes are all true
+	var mean, _ = mod.Model(pop)
+	for _, p := range mean {
+		if p != 0.75 {
+			t.Errorf("Expected a probability of 0.75, got %f", p)
+		}
+	}
+	// Another population has it's own model
+	var other = makePopulation(10, 4, ff, InitUniformB{})
+	if mean, _ := mod.Model(other); mean != nil {
+		t.Error("The other population shouldn't have a model yet")
+	}
+}
+
+func TestModPBILOneMax(t *testing.T) {
+	var ga = GA{
+		Ff: GenomeFunction{func(genome Genome) float64 {
+			var zeros float64
+			for _, gene := range genome {
+				if !gene.(bool) {
+					zeros++
+				}
+			}
+			return zeros
+		}},
+		Initializer:    InitUniformB{},
+		Model:          &ModPBIL{NbrBest: 2, Alpha: 0.2, MutProb: 0.02},
+		NbrPopulations: 2,
+		NbrIndividuals: 20,
+		NbrGenes:       20,
+	}
+	ga.Initialize()
+	for i := 0; i < 50; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness > 2 {
+		t.Errorf("Expected PBIL to solve OneMax, got a fitness of %f", ga.Best().Fitness)
+	}
+}
+
+func TestEDAValidate(t *testing.T) {
+	var valid = []Model{ModUMDA{}, ModUMDA{Fraction: 0.3, Elitism: 1, MinStd: 0.1}, &ModPBIL{}}
+	for _, model := range valid {
+		if model.Validate() != nil {
+			t.Errorf("Expected %+v to be valid", model)
+		}
+	}
+	var invalid = []Model{
+		ModUMDA{Fraction: 1.5},
+		ModUMDA{Elitism: -1},
+		ModUMDA{MinStd: -1},
+		&ModPBIL{NbrBest: -1},
+		&ModPBIL{Alpha: 2},
+		&ModPBIL{MutProb: -0.1},
+		&ModPBIL{MutShift: 2},
+		&ModPBIL{Elitism: -1},
+		&ModPBIL{MinStd: -1},
+	}
+	for _, model := range invalid {
+		if model.Validate() == nil {
+			t.Errorf("Expected an error for %+v", model)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package gago
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// Individuals can be written in a compact binary format which is a lot smaller
+// and faster to process than JSON. Integers are written as varints and
+// floating point numbers as their 8 byte IEEE 754 representation. Each gene is
+// preceded by a byte indicating it's type, only float64, int, bool, string,
+// Bitset and Vector genes are supported. The same goes for the values of the
+// metadata.
+
+// The type tags of the genes.
+const (
+	tagFloat64 byte = iota
+	tagInt
+	tagBool
+	tagString
+	tagBitset
+	tagVector
+)
+
+// A binaryWriter writes values in the binary format and keeps the first error.
+type binaryWriter struct {
+	w   io.Writer
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func (bw *binaryWriter) write(p []byte) {
+	if bw.err == nil {
+		_, bw.err = bw.w.Write(p)
+	}
+}
+
+func (bw *binaryWriter) uvarint(x uint64) {
+	bw.write(bw.buf[:binary.PutUvarint(bw.buf[:], x)])
+}
+
+func (bw *binaryWriter) varint(x int64) {
+	bw.write(bw.buf[:binary.PutVarint(bw.buf[:], x)])
+}
+
+func (bw *binaryWriter) float64(x float64) {
+	binary.LittleEndian.PutUint64(bw.buf[:8], math.Float64bits(x))
+	bw.write(bw.buf[:8])
+}
+
+func (bw *binaryWriter) bool(b bool) {
+	if b {
+		bw.write([]byte{1})
+	} else {
+		bw.write([]byte{0})
+	}
+}
+
+func (bw *binaryWriter) string(s string) {
+	bw.uvarint(uint64(len(s)))
+	bw.write([]byte(s))
+}
+
+func (bw *binaryWriter) individual(indi Individual) {
+	bw.string(indi.Name)
+	bw.float64(indi.Fitness)
+	bw.bool(indi.Evaluated)
+	bw.genome(indi.Genome)
+	bw.floats(indi.Cases)
+	bw.floats(indi.Objectives)
+	bw.float64(indi.Violation)
+	bw.metadata(indi.Metadata)
+}
+
+func (bw *binaryWriter) genome(genome Genome) {
+	bw.uvarint(uint64(len(genome)))
+	for _, gene := range genome {
+		bw.gene(gene)
+	}
+}
+
+func (bw *binaryWriter) gene(gene interface{}) {
+	switch g := gene.(type) {
+	case float64:
+		bw.write([]byte{tagFloat64})
+		bw.float64(g)
+	case int:
+		bw.write([]byte{tagInt})
+		bw.varint(int64(g))
+	case bool:
+		bw.write([]byte{tagBool})
+		bw.bool(g)
+	case string:
+		bw.write([]byte{tagString})
+		bw.string(g)
+	case Bitset:
+		bw.write([]byte{tagBitset})
+		bw.uvarint(uint64(g.N))
+		bw.uvarint(uint64(len(g.Words)))
+		for _, w := range g.Words {
+			binary.LittleEndian.PutUint64(bw.buf[:8], w)
+			bw.write(bw.buf[:8])
+		}
+	case Vector:
+		bw.write([]byte{tagVector})
+		bw.floats(g)
+	default:
+		if bw.err == nil {
+			bw.err = fmt.Errorf("values of type %T can't be encoded", gene)
+		}
+	}
+}
+
+// The metadata is written with sorted keys so that the encoding of an
+// individual is deterministic. The values are encoded like genes.
+func (bw *binaryWriter) metadata(metadata map[string]interface{}) {
+	var keys = make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	bw.uvarint(uint64(len(keys)))
+	for _, key := range keys {
+		bw.string(key)
+		bw.gene(metadata[key])
+	}
+}
+
+func (bw *binaryWriter) floats(xs []float64) {
+	bw.uvarint(uint64(len(xs)))
+	for _, x := range xs {
+		bw.float64(x)
+	}
+}
+
+// A binaryReader reads values in the binary format and keeps the first error.
+type binaryReader struct {
+	r   *bufio.Reader
+	buf [8]byte
+	err error
+}
+
+func (br *binaryReader) read(p []byte) {
+	if br.err == nil {
+		_, br.err = io.ReadFull(br.r, p)
+	}
+}
+
+func (br *binaryReader) byte() byte {
+	br.read(br.buf[:1])
+	return br.buf[0]
+}
+
+func (br *binaryReader) uvarint() uint64 {
+	if br.err != nil {
+		return 0
+	}
+	var x uint64
+	x, br.err = binary.ReadUvarint(br.r)
+	return x
+}
+
+func (br *binaryReader) varint() int64 {
+	if br.err != nil {
+		return 0
+	}
+	var x int64
+	x, br.err = binary.ReadVarint(br.r)
+	return x
+}
+
+func (br *binaryReader) float64() float64 {
+	br.read(br.buf[:8])
+	return math.Float64frombits(binary.LittleEndian.Uint64(br.buf[:8]))
+}
+
+func (br *binaryReader) bool() bool {
+	return br.byte() == 1
+}
+
+// Lengths are checked against a limit so that corrupted data doesn't cause
+// huge allocations.
+const maxEncodedLength = 1 << 28
+
+func (br *binaryReader) length() int {
+	var n = br.uvarint()
+	if n > maxEncodedLength && br.err == nil {
+		br.err = errors.New("encoded length is too large")
+	}
+	if br.err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+func (br *binaryReader) string() string {
+	var p = make([]byte, br.length())
+	br.read(p)
+	return string(p)
+}
+
+func (br *binaryReader) bitset() Bitset {
+	var b = Bitset{N: br.length()}
+	b.Words = make([]uint64, br.length())
+	for i := range b.Words {
+		br.read(br.buf[:8])
+		b.Words[i] = binary.LittleEndian.Uint64(br.buf[:8])
+	}
+	return b
+}
+
+func (br *binaryReader) individual() Individual {
+	var indi = Individual{
+		Name:      br.string(),
+		Fitness:   br.float64(),
+		Evaluated: br.bool(),
+	}
+	indi.Genome = make(Genome, br.length())
+	for i := range indi.Genome {
+		indi.Genome[i] = br.gene()
+		if br.err != nil {
+			return indi
+		}
+	}
+	indi.Cases = br.floats()
+	indi.Objectives = br.floats()
+	indi.Violation = br.float64()
+	indi.Metadata = br.metadata()
+	return indi
+}
+
+func (br *binaryReader) gene() interface{} {
+	switch tag := br.byte(); tag {
+	case tagFloat64:
+		return br.float64()
+	case tagInt:
+		return int(br.varint())
+	case tagBool:
+		return br.bool()
+	case tagString:
+		return br.string()
+	case tagBitset:
+		return br.bitset()
+	case tagVector:
+		return Vector(br.floats())
+	default:
+		if br.err == nil {
+			br.err = fmt.Errorf("unknown type tag %d", tag)
+		}
+		return nil
+	}
+}
+
+func (br *binaryReader) metadata() map[string]interface{} {
+	var n = br.length()
+	if n == 0 {
+		return nil
+	}
+	var metadata = make(map[string]interface{}, n)
+	for i := 0; i < n && br.err == nil; i++ {
+		var key = br.string()
+		metadata[key] = br.gene()
+	}
+	return metadata
+}
+
+func (br *binaryReader) floats() []float64 {
+	var n = br.length()
+	if n == 0 {
+		return nil
+	}
+	var xs = make([]float64, n)
+	for i := range xs {
+		xs[i] = br.float64()
+	}
+	return xs
+}
+
+// EncodeIndividuals writes individuals in the binary format.
+func EncodeIndividuals(w io.Writer, indis Individuals) error {
+	var (
+		buf = bufio.NewWriter(w)
+		bw  = &binaryWriter{w: buf}
+	)
+	bw.uvarint(uint64(len(indis)))
+	for _, indi := range indis {
+		bw.individual(indi)
+	}
+	if bw.err != nil {
+		return bw.err
+	}
+	return buf.Flush()
+}
+
+// DecodeIndividuals reads individuals written by EncodeIndividuals.
+func DecodeIndividuals(r io.Reader) (Individuals, error) {
+	var (
+		br    = &binaryReader{r: bufio.NewReader(r)}
+		indis = make(Individuals, br.length())
+	)
+	for i := range indis {
+		indis[i] = br.individual()
+	}
+	return indis, br.err
+}
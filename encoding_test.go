@@ -0,0 +1,180 @@
+package gago
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestEncodeIndividuals(t *testing.T) {
+	var indis = Individuals{
+		Individual{Genome: Genome{1.5, 2, true, "a"}, Fitness: 3, Evaluated: true, Name: "x"},
+		Individual{Genome: Genome{}, Fitness: -1, Name: "y", Cases: []float64{1, 2}, Objectives: []float64{3}, Violation: 0.5},
+	}
+	var buf bytes.Buffer
+	if err := EncodeIndividuals(&buf, indis); err != nil {
+		t.Fatal(err)
+	}
+	var decoded, err = DecodeIndividuals(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(indis) {
+		t.Fatal("The wrong number of individuals was decoded")
+	}
+	for i, indi := range decoded {
+		if indi.Name != indis[i].Name || indi.Fitness != indis[i].Fitness ||
+			indi.Evaluated != indis[i].Evaluated || len(indi.Cases) != len(indis[i].Cases) ||
+			len(indi.Objectives) != len(indis[i].Objectives) || indi.Violation != indis[i].Violation {
+			t.Error("An individual wasn't decoded correctly")
+		}
+		for j, gene := range indi.Genome {
+			if gene != indis[i].Genome[j] {
+				t.Error("A gene wasn't decoded correctly")
+			}
+		}
+	}
+}
+
+func TestEncodeMetadata(t *testing.T) {
+	var (
+		indi = Individual{Genome: Genome{1.0}}
+		buf  bytes.Buffer
+	)
+	indi.SetMeta("age", 3)
+	indi.SetMeta("species", "b")
+	if err := EncodeIndividuals(&buf, Individuals{indi}); err != nil {
+		t.Fatal(err)
+	}
+	var decoded, err = DecodeIndividuals(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded[0].Meta("age") != 3 || decoded[0].Meta("species") != "b" || len(decoded[0].Metadata) != 2 {
+		t.Error("The metadata wasn't decoded correctly")
+	}
+	// Check values that can't be encoded are reported
+	indi.SetMeta("parent", &indi)
+	if err := EncodeIndividuals(&buf, Individuals{indi}); err == nil {
+		t.Error("Encoding metadata of an unsupported type should fail")
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if EncodeIndividuals(&buf, Individuals{Individual{Genome: Genome{[]int{1}}}}) == nil {
+		t.Error("Encoding an unsupported gene type should return an error")
+	}
+	if _, err := DecodeIndividuals(bytes.NewReader([]byte{2, 1})); err == nil {
+		t.Error("Decoding truncated data should return an error")
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	var g = GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+	}
+	g.Initialize()
+	g.Enhance()
+	var buf bytes.Buffer
+	if err := g.SaveCheckpoint(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var restored = GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+	}
+	if err := restored.LoadCheckpoint(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Generations != g.Generations || restored.Evaluations != g.Evaluations {
+		t.Error("The counters weren't restored")
+	}
+	if restored.Best().Fitness != g.Best().Fitness {
+		t.Error("The best individual wasn't restored")
+	}
+	for i, pop := range restored.Populations {
+		for j, indi := range pop.Individuals {
+			if indi.Fitness != g.Populations[i].Individuals[j].Fitness {
+				t.Error("The populations weren't restored")
+			}
+		}
+	}
+	// The restored GA can be run
+	restored.Enhance()
+	if restored.Generations != g.Generations+1 || restored.Evaluations <= g.Evaluations {
+		t.Error("The restored GA didn't resume the run")
+	}
+}
+
+// Generate a population of float64 genomes for the benchmarks.
+func benchIndividuals() Individuals {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indis = makeIndividuals(1000, 50, rng)
+	)
+	for i := range indis {
+		InitUniformF{Lower: -1, Upper: 1}.Apply(&indis[i], rng)
+		indis[i].Fitness = rng.Float64()
+		indis[i].Evaluated = true
+	}
+	return indis
+}
+
+func BenchmarkEncodeBinary(b *testing.B) {
+	var (
+		indis = benchIndividuals()
+		buf   bytes.Buffer
+	)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		EncodeIndividuals(&buf, indis)
+	}
+	b.ReportMetric(float64(buf.Len()), "bytes")
+}
+
+func BenchmarkEncodeJSON(b *testing.B) {
+	var (
+		indis = benchIndividuals()
+		buf   bytes.Buffer
+	)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		json.NewEncoder(&buf).Encode(indis)
+	}
+	b.ReportMetric(float64(buf.Len()), "bytes")
+}
+
+func BenchmarkDecodeBinary(b *testing.B) {
+	var buf bytes.Buffer
+	EncodeIndividuals(&buf, benchIndividuals())
+	var data = buf.Bytes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DecodeIndividuals(bytes.NewReader(data))
+	}
+}
+
+func BenchmarkDecodeJSON(b *testing.B) {
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(benchIndividuals())
+	var data = buf.Bytes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var indis Individuals
+		json.NewDecoder(bytes.NewReader(data)).Decode(&indis)
+	}
+}
@@ -0,0 +1,97 @@
+package gago
+
+import (
+	"math"
+	"sort"
+	"sync/atomic"
+)
+
+// A scheduledComparator is a Comparator that changes as the run progresses, the
+// GA updates it before each generation with the number of generations and
+// evaluations done so far.
+type scheduledComparator interface {
+	schedule(generations, evaluations int, pops Populations)
+}
+
+// EpsilonConstraint is a Comparator that implements the epsilon constrained
+// method of Takahama and Sakai. The individuals whose violation is at most the
+// current level epsilon are compared as if they were feasible, with Comparator
+// or by fitness if Comparator is nil, the other individuals are compared by
+// violation. The level goes from Initial to 0 following
+//
+//	epsilon = Initial * (1 - t/T)^Exponent
+//
+// where t is the number of generations and T is Generations, or where t is the
+// number of evaluations and T is Evaluations if Evaluations is not 0. Tying the
+// schedule to the termination criterion of a run, for example the
+// MaxEvaluations of an Experiment, ensures the run ends with feasible
+// solutions while it could cross infeasible regions early on. The Exponent is
+// 2 if it's 0. If Initial is 0 the level starts at the violation of the
+// individual at the first quintile of the initial populations, ordered by
+// violation. The violations are set by a ConstrainedFunction.
+//
+// The GA updates the level before each generation when an EpsilonConstraint is
+// it's Comparator, hence it has to be used through a pointer; the same pointer
+// can be given to the operators that take a Comparator, such as
+// SelTournament. The current level is reported by the statistics of the GA.
+type EpsilonConstraint struct {
+	Initial     float64
+	Generations int
+	Evaluations int
+	Exponent    float64
+	Comparator  Comparator
+	initial     float64       // Initial level, measured on the initial populations if Initial is 0
+	level       atomic.Uint64 // Bits of the current level, it's read concurrently by the populations
+}
+
+// Level returns the current constraint tolerance.
+func (ec *EpsilonConstraint) Level() float64 {
+	return math.Float64frombits(ec.level.Load())
+}
+
+// Less compares the violations above the current level and then the
+// individuals.
+func (ec *EpsilonConstraint) Less(a, b Individual) bool {
+	var (
+		level = ec.Level()
+		va    = math.Max(a.Violation, 0)
+		vb    = math.Max(b.Violation, 0)
+	)
+	if va <= level && vb <= level {
+		return less(ec.Comparator, a, b)
+	}
+	return va < vb
+}
+
+// Update the level, the initial level is measured on the populations at
+// generation 0 if Initial is 0.
+func (ec *EpsilonConstraint) schedule(generations, evaluations int, pops Populations) {
+	if generations == 0 {
+		ec.initial = ec.Initial
+		if ec.initial == 0 {
+			var violations []float64
+			for _, pop := range pops {
+				for _, indi := range pop.Individuals {
+					violations = append(violations, math.Max(indi.Violation, 0))
+				}
+			}
+			if len(violations) > 0 {
+				sort.Float64s(violations)
+				ec.initial = violations[len(violations)/5]
+			}
+		}
+	}
+	var progress float64
+	switch {
+	case ec.Evaluations > 0:
+		progress = float64(evaluations) / float64(ec.Evaluations)
+	case ec.Generations > 0:
+		progress = float64(generations) / float64(ec.Generations)
+	}
+	var exponent = ec.Exponent
+	if exponent == 0 {
+		exponent = 2
+	}
+	var level = ec.initial * math.Pow(math.Max(1-progress, 0), exponent)
+	ec.level.Store(math.Float64bits(level))
+}
@@ -0,0 +1,77 @@
+package gago
+
+import "testing"
+
+func TestEpsilonConstraintLess(t *testing.T) {
+	var ec = &EpsilonConstraint{Initial: 1, Generations: 10}
+	ec.schedule(0, 0, nil)
+	var (
+		a = Individual{Fitness: 1, Violation: 0.5}
+		b = Individual{Fitness: 2}
+	)
+	// Both individuals are within the tolerance
+	if !ec.Less(a, b) || ec.Less(b, a) {
+		t.Error("The individuals should be compared by fitness")
+	}
+	// Only the second individual is within the tolerance
+	ec.schedule(5, 0, nil)
+	if ec.Less(a, b) || !ec.Less(b, a) {
+		t.Error("The individuals should be compared by violation")
+	}
+}
+
+func TestEpsilonConstraintSchedule(t *testing.T) {
+	var ec = &EpsilonConstraint{Initial: 4, Generations: 10, Exponent: 1}
+	for i, level := range []float64{4, 2, 0, 0} {
+		var generation = 5 * i
+		ec.schedule(generation, 0, nil)
+		if ec.Level() != level {
+			t.Errorf("Expected a level of %f at generation %d, got %f", level, generation, ec.Level())
+		}
+	}
+	// The evaluations take precedence over the generations
+	ec.Evaluations = 100
+	ec.schedule(0, 75, nil)
+	if ec.Level() != 1 {
+		t.Errorf("Expected a level of 1, got %f", ec.Level())
+	}
+	// The initial level is measured on the populations
+	ec = &EpsilonConstraint{Generations: 10}
+	var pop = Population{Individuals: make(Individuals, 10)}
+	for i := range pop.Individuals {
+		pop.Individuals[i].Violation = float64(9 - i)
+	}
+	ec.schedule(0, 0, Populations{pop})
+	if ec.Level() != 2 {
+		t.Errorf("Expected an initial level of 2, got %f", ec.Level())
+	}
+}
+
+func TestEpsilonConstraintGA(t *testing.T) {
+	var ec = &EpsilonConstraint{Generations: 15}
+	var ga = GA{
+		NbrPopulations: 2,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Ff: ConstrainedFunction{
+			Function: ff,
+			Violation: func(genome Genome) float64 {
+				// The first gene should be positive
+				return max(-genome[0].(float64), 0)
+			},
+		},
+		Initializer: initializer,
+		Model:       model,
+		Comparator:  ec,
+	}
+	ga.Initialize()
+	for i := 0; i < 20; i++ {
+		ga.Enhance()
+	}
+	if ga.Stats().Epsilon != 0 {
+		t.Error("The tolerance should be 0 after the scheduled generations")
+	}
+	if !ga.Best().Feasible() {
+		t.Error("The best individual should be feasible")
+	}
+}
@@ -40,7 +40,7 @@ func main() {
 	ga.Initialize()
 	// Enhancement
 	for i := 0; i < 1000; i++ {
-		fmt.Println(ga.Best.Fitness)
+		fmt.Println(ga.Best().Fitness)
 		ga.Enhance()
 	}
 }
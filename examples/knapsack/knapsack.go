@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A 0/1 knapsack problem: the items are either put in the knapsack or not,
+// the value of the selected items has to be maximized without their weight
+// exceeding the capacity.
+type problem struct {
+	weights, values []float64
+	capacity        float64
+}
+
+// Generate n items whose weights and values are integers, the capacity is
+// half the total weight.
+func makeProblem(n int, rng *rand.Rand) problem {
+	var p = problem{weights: make([]float64, n), values: make([]float64, n)}
+	for i := range p.weights {
+		p.weights[i] = float64(1 + rng.Intn(30))
+		p.values[i] = float64(1 + rng.Intn(50))
+		p.capacity += p.weights[i] / 2
+	}
+	return p
+}
+
+// The fitness is the opposite of the value of the selected items because
+// gago minimizes. The operators repair the genomes, hence the constraint
+// doesn't have to be penalized.
+func (p problem) value(selected []bool) float64 {
+	var value float64
+	for i, s := range selected {
+		if s {
+			value += p.values[i]
+		}
+	}
+	return -value
+}
+
+// Compute the best value with dynamic programming, which is possible because
+// the weights are integers.
+func (p problem) optimum() float64 {
+	var best = make([]float64, int(p.capacity)+1)
+	for i, w := range p.weights {
+		for c := len(best) - 1; c >= int(w); c-- {
+			if v := best[c-int(w)] + p.values[i]; v > best[c] {
+				best[c] = v
+			}
+		}
+	}
+	return best[len(best)-1]
+}
+
+// The initial genomes are greedy solutions with some items left out, the
+// offsprings produced by the crossover and the mutator are repaired by
+// dropping the items with the lowest value to weight ratio until they fit.
+func newGA(p problem) gago.GA {
+	var repairer = gago.RepKnapsackB{Weights: p.weights, Values: p.values, Capacity: p.capacity}
+	return gago.GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 60,
+		NbrGenes:       len(p.weights),
+		Ff:             gago.BoolFunction{Image: p.value},
+		Initializer: gago.InitKnapsackB{
+			Weights:  p.weights,
+			Values:   p.values,
+			Capacity: p.capacity,
+			Prob:     0.8,
+		},
+		Model: gago.ModSteadyState{
+			Selector:  gago.SelTournament{NbParticipants: 3},
+			Crossover: gago.CrossRepair{Crossover: gago.CrossUniform{}, Repairer: repairer},
+			Mutator:   gago.MutRepair{Mutator: gago.MutFlipB{Rate: 0.05}, Repairer: repairer},
+			MutRate:   0.5,
+			KeepBest:  true,
+		},
+		Migrator:     gago.MigShuffle{},
+		MigFrequency: 20,
+		Deduplicate:  true,
+		Seed:         42,
+	}
+}
+
+// Run the GA on a random problem and compare the best selection with the
+// optimum, the selected items and the statistics are written to out.
+func run(out io.Writer) (gago.Stats, problem, error) {
+	var (
+		p  = makeProblem(50, rand.New(rand.NewSource(42)))
+		ga = newGA(p)
+	)
+	ga.Initialize()
+	var stats = ga.EnhanceBudget(20000)
+	var weight float64
+	for i, gene := range ga.Best().Genome {
+		if gene.(bool) {
+			weight += p.weights[i]
+			fmt.Fprintf(out, "Item %d: weight %g, value %g\n", i, p.weights[i], p.values[i])
+		}
+	}
+	if weight > p.capacity {
+		return stats, p, fmt.Errorf("the selected items weigh %g, which exceeds the capacity of %g", weight, p.capacity)
+	}
+	fmt.Fprintf(out, "Weight: %g/%g\nValue: %g (optimum %g)\n%s", weight, p.capacity, -stats.Best, p.optimum(), stats)
+	return stats, p, nil
+}
+
+func main() {
+	if _, _, err := run(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
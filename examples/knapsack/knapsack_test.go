@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestOptimum(t *testing.T) {
+	var p = problem{
+		weights:  []float64{5, 4, 6, 3},
+		values:   []float64{10, 40, 30, 50},
+		capacity: 10,
+	}
+	if opt := p.optimum(); opt != 90 {
+		t.Errorf("Expected 90, got %g", opt)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	var stats, p, err = run(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if -stats.Best < 0.99*p.optimum() {
+		t.Errorf("Expected a value close to %g, got %g", p.optimum(), -stats.Best)
+	}
+}
+
+func TestMakeProblem(t *testing.T) {
+	var p = makeProblem(10, rand.New(rand.NewSource(1)))
+	if len(p.weights) != 10 || len(p.values) != 10 || p.capacity <= 0 {
+		t.Error("The problem should have 10 items and a capacity")
+	}
+}
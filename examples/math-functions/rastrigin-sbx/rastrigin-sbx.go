@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/MaxHalford/gago"
+	"github.com/MaxHalford/gago/config"
+)
+
+// Rastrigin minimum is 0 reached in (0, ..., 0)
+// Recommended search domain is [-5.12, 5.12]
+func rastrigin(X []float64) float64 {
+	var sum = 10 * float64(len(X))
+	for _, x := range X {
+		sum += x*x - 10*math.Cos(2*math.Pi*x)
+	}
+	return sum
+}
+
+// CrossSBX implements the simulated binary crossover of Deb and Agrawal, which
+// spreads the offsprings around the parents like a one point crossover does
+// on binary strings. The higher Eta is the closer the offsprings are to their
+// parents. The genes are crossed with probability Rate, and clipped to
+// [Lower, Upper].
+type CrossSBX struct {
+	Eta          float64
+	Rate         float64
+	Lower, Upper float64
+}
+
+// Apply simulated binary crossover.
+func (cross CrossSBX) Apply(p1 gago.Individual, p2 gago.Individual, rng *rand.Rand) (gago.Individual, gago.Individual) {
+	var (
+		g1 = make(gago.Genome, len(p1.Genome))
+		g2 = make(gago.Genome, len(p2.Genome))
+	)
+	for i := range g1 {
+		var x1, x2 = p1.Genome[i].(float64), p2.Genome[i].(float64)
+		if rng.Float64() < cross.Rate {
+			var (
+				u    = rng.Float64()
+				beta = math.Pow(2*u, 1/(cross.Eta+1))
+			)
+			if u > 0.5 {
+				beta = math.Pow(1/(2*(1-u)), 1/(cross.Eta+1))
+			}
+			x1, x2 = 0.5*((1+beta)*x1+(1-beta)*x2), 0.5*((1-beta)*x1+(1+beta)*x2)
+		}
+		g1[i], g2[i] = clip(x1, cross.Lower, cross.Upper), clip(x2, cross.Lower, cross.Upper)
+	}
+	return gago.Individual{Genome: g1, Fitness: math.Inf(1)}, gago.Individual{Genome: g2, Fitness: math.Inf(1)}
+}
+
+// MutPolynomial implements the polynomial mutation of Deb and Goyal. Each
+// gene is mutated with probability Rate, 1 over the number of genes if Rate
+// is 0, by adding a perturbation drawn from a polynomial distribution whose
+// spread is the width of [Lower, Upper]. The higher Eta is the smaller the
+// perturbations are.
+type MutPolynomial struct {
+	Eta          float64
+	Rate         float64
+	Lower, Upper float64
+}
+
+// Apply polynomial mutation.
+func (mut MutPolynomial) Apply(indi *gago.Individual, rng *rand.Rand) {
+	var rate = mut.Rate
+	if rate == 0 {
+		rate = 1 / float64(len(indi.Genome))
+	}
+	for i, gene := range indi.Genome {
+		if rng.Float64() >= rate {
+			continue
+		}
+		var (
+			u     = rng.Float64()
+			delta = math.Pow(2*u, 1/(mut.Eta+1)) - 1
+		)
+		if u >= 0.5 {
+			delta = 1 - math.Pow(2*(1-u), 1/(mut.Eta+1))
+		}
+		indi.Genome[i] = clip(gene.(float64)+delta*(mut.Upper-mut.Lower), mut.Lower, mut.Upper)
+	}
+}
+
+// Restrict a value to [lower, upper].
+func clip(x, lower, upper float64) float64 {
+	return math.Max(lower, math.Min(upper, x))
+}
+
+// The GA is described by a configuration file, the custom operators are
+// referred to by the names they are registered with in config.Types.
+const configuration = `
+fitness = "rastrigin"
+nbr_populations = 2
+nbr_individuals = 100
+nbr_genes = 5
+mig_frequency = 10
+seed = 42
+max_generations = 300
+
+[initializer]
+type = "InitUniformF"
+lower = -5.12
+upper = 5.12
+
+[model]
+type = "ModDownToSize"
+nbr_offsprings = 100
+mut_rate = 1
+selector_a = { type = "SelTournament", nb_participants = 2 }
+crossover = { type = "CrossSBX", eta = 15, rate = 0.9, lower = -5.12, upper = 5.12 }
+mutator = { type = "MutPolynomial", eta = 20, lower = -5.12, upper = 5.12 }
+selector_b = { type = "SelTournament", nb_participants = 4 }
+
+[migrator]
+type = "MigShuffle"
+
+[history]
+every = 50
+`
+
+// Load the configuration and run the experiment it describes, the statistics
+// of the run and the best genome every 50 generations are written to out.
+func run(out io.Writer) (gago.Stats, error) {
+	config.Types["CrossSBX"] = CrossSBX{}
+	config.Types["MutPolynomial"] = MutPolynomial{}
+	var exp, err = config.Load(strings.NewReader(configuration), map[string]gago.FitnessFunction{
+		"rastrigin": gago.Float64Function{Image: rastrigin},
+	})
+	if err != nil {
+		return gago.Stats{}, err
+	}
+	var stats = exp.Run()
+	fmt.Fprint(out, stats)
+	for _, snapshot := range stats.History {
+		fmt.Fprintf(out, "Generation %d: %.4f %.3f\n", snapshot.Generation, snapshot.Fitness, snapshot.Genome)
+	}
+	return stats, nil
+}
+
+func main() {
+	if _, err := run(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/MaxHalford/gago"
+)
+
+func TestOperators(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(42))
+		p1    = gago.Individual{Genome: gago.Genome{-5.0, 0.0, 5.0}}
+		p2    = gago.Individual{Genome: gago.Genome{5.0, 0.0, -5.0}}
+		cross = CrossSBX{Eta: 2, Rate: 1, Lower: -5.12, Upper: 5.12}
+		mut   = MutPolynomial{Eta: 2, Rate: 1, Lower: -5.12, Upper: 5.12}
+	)
+	for i := 0; i < 100; i++ {
+		var o1, o2 = cross.Apply(p1, p2, rng)
+		mut.Apply(&o1, rng)
+		for _, o := range []gago.Individual{o1, o2} {
+			for _, gene := range o.Genome {
+				if x := gene.(float64); x < -5.12 || x > 5.12 {
+					t.Fatalf("Gene %g is out of bounds", x)
+				}
+			}
+		}
+		// Identical genes are left untouched by the crossover
+		if o2.Genome[1] != 0.0 {
+			t.Error("The offsprings of identical genes should be identical")
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	var stats, err = run(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Best > 0.01 {
+		t.Errorf("Expected a fitness close to 0, got %g", stats.Best)
+	}
+	if len(stats.History) != 7 {
+		t.Errorf("Expected 7 snapshots, got %d", len(stats.History))
+	}
+}
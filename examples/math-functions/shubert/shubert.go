@@ -28,5 +28,5 @@ func main() {
 		ga.Enhance()
 	}
 	// Display the best obtained solution
-	fmt.Printf("The best obtained solution is %f\n", ga.Best.Fitness)
+	fmt.Printf("The best obtained solution is %f\n", ga.Best().Fitness)
 }
@@ -25,6 +25,6 @@ func main() {
 	for i := 0; i < 10; i++ {
 		ga.Enhance()
 		// Display the current best solution
-		fmt.Printf("The best obtained solution is %f\n", ga.Best.Fitness)
+		fmt.Printf("The best obtained solution is %f\n", ga.Best().Fitness)
 	}
 }
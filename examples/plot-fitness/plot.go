@@ -57,7 +57,7 @@ func main() {
 		ga.Enhance()
 		// Store the best fitness for plotting
 		best[i].X = float64(i)
-		best[i].Y = ga.Best.Fitness
+		best[i].Y = ga.Best().Fitness
 	}
 	// Graph the fitnesses
 	graph(best)
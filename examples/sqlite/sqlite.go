@@ -0,0 +1,95 @@
+//go:build sqlite
+
+// This example depends on the modernc.org/sqlite driver, hence it's only built
+// with the sqlite build tag: go run -tags sqlite ./examples/sqlite
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/MaxHalford/gago"
+	"github.com/MaxHalford/gago/presets"
+	_ "modernc.org/sqlite"
+)
+
+// Sphere minimum is 0 reached in (0, ..., 0)
+func sphere(X []float64) float64 {
+	var sum float64
+	for _, x := range X {
+		sum += x * x
+	}
+	return sum
+}
+
+// Run a GA for a number of generations and export each generation.
+func export(ga *gago.GA, exp *gago.SQLExporter, generations int) error {
+	for i := 0; i < generations; i++ {
+		ga.Enhance()
+		if err := exp.Export(ga); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run a GA whose generations are exported to an SQLite database at path. The
+// run is interrupted halfway and resumed from a checkpoint, as a long run
+// would be, and keeps being exported under the same name. The runs and the
+// best fitness of each generation are then read back from the database.
+func run(path string, out io.Writer) ([]float64, error) {
+	var db, err = sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	var (
+		ga         = presets.Float64(4, sphere)
+		checkpoint bytes.Buffer
+	)
+	ga.Initialize()
+	if err = export(&ga, &gago.SQLExporter{DB: db, Run: "sphere"}, 10); err != nil {
+		return nil, err
+	}
+	if err = ga.SaveCheckpoint(&checkpoint); err != nil {
+		return nil, err
+	}
+	// Resume the run with a new GA and a new exporter
+	var resumed = presets.Float64(4, sphere)
+	if err = resumed.LoadCheckpoint(&checkpoint); err != nil {
+		return nil, err
+	}
+	var exp = &gago.SQLExporter{DB: db, Run: "sphere"}
+	if err = export(&resumed, exp, 10); err != nil {
+		return nil, err
+	}
+	runs, err := exp.Runs()
+	if err != nil {
+		return nil, err
+	}
+	bests, err := exp.Bests("sphere")
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(out, "Runs: %v\n", runs)
+	for i, best := range bests {
+		fmt.Fprintf(out, "Generation %d: %g\n", i+1, best)
+	}
+	return bests, nil
+}
+
+func main() {
+	var dir, err = os.MkdirTemp("", "gago")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if _, err = run(filepath.Join(dir, "runs.db"), os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build sqlite
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var (
+		out        bytes.Buffer
+		bests, err = run(filepath.Join(t.TempDir(), "runs.db"), &out)
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bests) != 20 {
+		t.Fatalf("Expected 20 generations, got %d", len(bests))
+	}
+	if bests[19] > bests[0] {
+		t.Error("The best fitness shouldn't increase")
+	}
+}
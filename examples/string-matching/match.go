@@ -38,5 +38,5 @@ func main() {
 		ga.Enhance()
 	}
 	// Extract the genome of the best individual
-	fmt.Println(ga.Best.Genome)
+	fmt.Println(ga.Best().Genome)
 }
@@ -81,12 +81,12 @@ func main() {
 	ga.Initialize()
 	// Enhance
 	for i := 0; i < 10000; i++ {
-		fmt.Println(ga.Best.Fitness)
+		fmt.Println(ga.Best().Fitness)
 		ga.Enhance()
 	}
 	// Extract the genome of the best individual
 	var points = make([]string, len(names))
-	for i, gene := range ga.Best.Genome {
+	for i, gene := range ga.Best().Genome {
 		points[i] = gene.(string)
 	}
 	graph(points)
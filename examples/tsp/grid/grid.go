@@ -75,12 +75,12 @@ func main() {
 		ga.Enhance()
 	}
 	var optimal = float64((size + 1) * (size - 1))
-	fmt.Printf("Obtained %f\n", ga.Best.Fitness)
+	fmt.Printf("Obtained %f\n", ga.Best().Fitness)
 	fmt.Printf("Optimal is %d\n", int(optimal))
-	fmt.Printf("Off by %f percent\n", 100*(ga.Best.Fitness-optimal)/optimal)
+	fmt.Printf("Off by %f percent\n", 100*(ga.Best().Fitness-optimal)/optimal)
 	// Extract the genome of the best individual
 	var points = make([]string, len(names))
-	for i, gene := range ga.Best.Genome {
+	for i, gene := range ga.Best().Genome {
 		points[i] = gene.(string)
 	}
 	graph(points)
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+
+	"github.com/MaxHalford/gago"
+)
+
+// The cities lie on a circle, hence the shortest tour visits them in the
+// order of their angle and it's length is known.
+const nbCities = 40
+
+type city struct {
+	x, y float64
+}
+
+// Place n cities on the unit circle in a random order.
+func makeCities(n int, rng *rand.Rand) []city {
+	var cities = make([]city, n)
+	for i, j := range rng.Perm(n) {
+		var angle = 2 * math.Pi * float64(j) / float64(n)
+		cities[i] = city{math.Cos(angle), math.Sin(angle)}
+	}
+	return cities
+}
+
+// Length of the shortest tour, which is the perimeter of a regular polygon
+// with n sides.
+func optimum(n int) float64 {
+	return 2 * float64(n) * math.Sin(math.Pi/float64(n))
+}
+
+// Length of the tour that visits the cities in the given order and goes back
+// to the first one.
+func tourLength(cities []city, tour []int) float64 {
+	var length float64
+	for i := range tour {
+		var a, b = cities[tour[i]], cities[tour[(i+1)%len(tour)]]
+		length += math.Hypot(a.x-b.x, a.y-b.y)
+	}
+	return length
+}
+
+// The tours are permutations crossed with PMX, the offsprings are improved
+// with 2-opt local search which removes the crossings of a tour.
+func newGA(cities []city) gago.GA {
+	return gago.GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 50,
+		NbrGenes:       len(cities),
+		Ff: gago.IntFunction{
+			Image: func(tour []int) float64 { return tourLength(cities, tour) },
+		},
+		Initializer: gago.InitPermutationI{},
+		Model: gago.ModMemetic{
+			Model: gago.ModGenerational{
+				Selector:  gago.SelTournament{NbParticipants: 3},
+				Crossover: gago.CrossPMX{},
+				Mutator:   gago.MutPermute{Max: 2},
+				MutRate:   0.3,
+			},
+			LocalSearcher: gago.LocalTwoOpt{MaxEvaluations: 5 * len(cities)},
+			Rate:          0.1,
+		},
+		Migrator:     gago.MigShuffle{},
+		MigFrequency: 10,
+		HallOfFame:   &gago.HallOfFame{Size: 3},
+		History:      &gago.BestHistory{Every: 10},
+		Seed:         42,
+	}
+}
+
+// Run the GA until it finds the shortest tour or stagnates, the report of the
+// run and the length of the best tour every 10 generations are written to out.
+func run(out io.Writer) (gago.Stats, error) {
+	var (
+		cities = makeCities(nbCities, rand.New(rand.NewSource(42)))
+		target = optimum(nbCities) + 1e-6
+		ga     = newGA(cities)
+	)
+	ga.Initialize()
+	var stats, _, err = ga.Run(context.Background(), gago.RunOptions{
+		MaxGenerations: 300,
+		MaxStagnation:  50,
+		Success:        func(stats gago.Stats) bool { return stats.Best <= target },
+		TrapInterrupts: true,
+		Report:         out,
+	})
+	if err != nil {
+		return stats, err
+	}
+	fmt.Fprintf(out, "Optimum: %g\n", optimum(nbCities))
+	for _, snapshot := range stats.History {
+		fmt.Fprintf(out, "Generation %d: %.4f\n", snapshot.Generation, snapshot.Fitness)
+	}
+	fmt.Fprintf(out, "Best tour: %v\n", ga.Best().Genome)
+	return stats, nil
+}
+
+func main() {
+	if _, err := run(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	var stats, err = run(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Best > 1.05*optimum(nbCities) {
+		t.Errorf("Expected a tour close to %g, got %g", optimum(nbCities), stats.Best)
+	}
+	if !strings.Contains(out.String(), "Termination:") || len(stats.History) == 0 {
+		t.Error("The report and the history should be written")
+	}
+}
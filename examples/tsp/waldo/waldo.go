@@ -83,10 +83,10 @@ func main() {
 	for i := 0; i < 1000; i++ {
 		ga.Enhance()
 	}
-	fmt.Println(ga.Best.Fitness)
+	fmt.Println(ga.Best().Fitness)
 	// Extract the genome of the best individual
 	var points = make([]string, len(names))
-	for i, gene := range ga.Best.Genome {
+	for i, gene := range ga.Best().Genome {
 		points[i] = gene.(string)
 	}
 	graph(points)
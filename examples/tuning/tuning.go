@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+
+	"github.com/MaxHalford/gago"
+)
+
+// Rastrigin minimum is 0 reached in (0, ..., 0)
+// Recommended search domain is [-5.12, 5.12]
+func rastrigin(X []float64) float64 {
+	var sum = 10 * float64(len(X))
+	for _, x := range X {
+		sum += x*x - 10*math.Cos(2*math.Pi*x)
+	}
+	return sum
+}
+
+// The parameters of the GA that are tuned: the crossover, the number of
+// participants of the tournaments and the standard deviation of the mutation.
+var parameters = []gago.Parameter{
+	{Name: "crossover", Values: []interface{}{
+		gago.CrossUniformF{},
+		gago.CrossArithmeticF{},
+		gago.CrossBLXF{Alpha: 0.3, Beta: 0.3},
+	}},
+	{Name: "participants", Lower: 2, Upper: 10, Integer: true},
+	{Name: "std", Lower: 0.01, Upper: 2},
+}
+
+// Build the GA that corresponds to a setting of the parameters.
+func newGA(setting gago.Setting) gago.GA {
+	return gago.GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 40,
+		NbrGenes:       5,
+		Ff:             gago.Float64Function{Image: rastrigin},
+		Initializer:    gago.InitUniformF{Lower: -5.12, Upper: 5.12},
+		Model: gago.ModGenerational{
+			Selector:  gago.SelTournament{NbParticipants: setting.Int("participants")},
+			Crossover: setting["crossover"].(gago.Crossover),
+			Mutator:   gago.MutGaussianF{Rate: 0.5, Std: setting.Float("std")},
+			MutRate:   0.5,
+		},
+	}
+}
+
+// Tune the GA and then compare the best setting with a naive one over
+// independent runs, the results are written to out.
+func run(out io.Writer) (best, naive gago.ExperimentResult, err error) {
+	var tuner = gago.Tuner{
+		Parameters:     parameters,
+		NewGA:          newGA,
+		Budget:         200,
+		NbElites:       2,
+		MaxEvaluations: 4000,
+		Seed:           42,
+	}
+	tuning, err := tuner.Run()
+	if err != nil {
+		return best, naive, err
+	}
+	fmt.Fprintf(out, "Best setting after %d runs and %d iterations:\n", tuning.Runs, tuning.Iterations)
+	for _, param := range parameters {
+		fmt.Fprintf(out, "  %s: %#v\n", param.Name, tuning.Best[param.Name])
+	}
+	// Compare the best setting with a naive setting on new seeds
+	var compare = func(setting gago.Setting) (gago.ExperimentResult, error) {
+		return gago.Experiment{
+			NewGA:          func() gago.GA { return newGA(setting) },
+			Runs:           20,
+			Seed:           7,
+			MaxEvaluations: 4000,
+		}.Run()
+	}
+	if best, err = compare(tuning.Best); err != nil {
+		return best, naive, err
+	}
+	if naive, err = compare(gago.Setting{"crossover": gago.CrossUniformF{}, "participants": 2, "std": 2.0}); err != nil {
+		return best, naive, err
+	}
+	fmt.Fprintf(out, "Mean best fitness with the tuned setting: %g\n", best.Mean)
+	fmt.Fprintf(out, "Mean best fitness with a naive setting: %g\n", naive.Mean)
+	return best, naive, nil
+}
+
+func main() {
+	if _, _, err := run(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	var best, naive, err = run(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best.Mean >= naive.Mean {
+		t.Errorf("The tuned setting should beat the naive one, got %g and %g", best.Mean, naive.Mean)
+	}
+}
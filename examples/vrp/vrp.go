@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/MaxHalford/gago/vrp"
+)
+
+func main() {
+	// Generate random customers around a depot located at the center
+	var (
+		rng     = rand.New(rand.NewSource(42))
+		points  = [][2]float64{{50, 50}}
+		demands = []float64{0}
+	)
+	for i := 0; i < 30; i++ {
+		points = append(points, [2]float64{100 * rng.Float64(), 100 * rng.Float64()})
+		demands = append(demands, float64(1+rng.Intn(9)))
+	}
+	var problem = vrp.Problem{
+		Distances: vrp.Euclidean(points),
+		Demands:   demands,
+		Capacity:  40,
+	}
+	var ga = problem.GA()
+	ga.Initialize()
+	for i := 1; i <= 100; i++ {
+		ga.Enhance()
+		if i%10 == 0 {
+			fmt.Printf("Generation %d: %.2f\n", i, ga.Best().Fitness)
+		}
+	}
+	for i, route := range problem.Routes(ga.Best()) {
+		fmt.Printf("Route %d: %v\n", i+1, route)
+	}
+}
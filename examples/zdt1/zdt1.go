@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/MaxHalford/gago"
+)
+
+// ZDT1 is a bi-objective problem of Zitzler, Deb and Thiele whose variables
+// belong to [0, 1]. It's Pareto front is convex, it's reached when every
+// variable but the first one is 0 and then f2 = 1 - sqrt(f1).
+const nbVariables = 10
+
+func zdt1(X []float64) []float64 {
+	var g float64
+	for _, x := range X[1:] {
+		g += x
+	}
+	g = 1 + 9*g/float64(len(X)-1)
+	return []float64{X[0], g * (1 - math.Sqrt(X[0]/g))}
+}
+
+// Sample n points of the Pareto front, which are used to compute the IGD of
+// the archived front.
+func paretoFront(n int) [][]float64 {
+	var front = make([][]float64, n)
+	for i := range front {
+		var f1 = float64(i) / float64(n-1)
+		front[i] = []float64{f1, 1 - math.Sqrt(f1)}
+	}
+	return front
+}
+
+// The hypervolume of the Pareto front with regard to the reference point
+// (1.1, 1.1), which is the area of the square minus the area under the front.
+const optimalHypervolume = 1.1*1.1 - 1.0/3
+
+func newGA() gago.GA {
+	var clip = gago.RepClipF{Lower: 0, Upper: 1}
+	return gago.GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 100,
+		NbrGenes:       nbVariables,
+		Ff:             gago.ObjectivesFunction{Image: func(genome gago.Genome) []float64 { return zdt1(floats(genome)) }},
+		Initializer:    gago.InitUniformF{Lower: 0, Upper: 1},
+		Model: gago.ModNSGA2{
+			Crossover: gago.CrossRepair{Crossover: gago.CrossBLXF{Alpha: 0.1, Beta: 0.1}, Repairer: clip},
+			Mutator:   gago.MutRepair{Mutator: gago.MutNormalF{Rate: 1.0 / nbVariables, Std: 0.1}, Repairer: clip},
+			MutRate:   1,
+		},
+		Archive: &gago.ParetoArchive{
+			Epsilon:        0.01,
+			ReferencePoint: []float64{1.1, 1.1},
+			ReferenceFront: paretoFront(100),
+		},
+		Seed: 42,
+	}
+}
+
+// Convert a genome of float64s to a slice.
+func floats(genome gago.Genome) []float64 {
+	var X = make([]float64, len(genome))
+	for i, gene := range genome {
+		X[i] = gene.(float64)
+	}
+	return X
+}
+
+// Run NSGA-II until the hypervolume of the archived front is within 1% of the
+// optimum, the archived front is written to out sorted by the first
+// objective.
+func run(out io.Writer) (gago.Stats, gago.Individuals, error) {
+	var ga = newGA()
+	ga.Initialize()
+	var stats, reason, err = ga.Run(context.Background(), gago.RunOptions{
+		MaxGenerations: 500,
+		Success:        func(stats gago.Stats) bool { return stats.Hypervolume >= 0.99*optimalHypervolume },
+	})
+	if err != nil {
+		return stats, nil, err
+	}
+	var front = ga.Archive.Front()
+	sort.Slice(front, func(i, j int) bool { return front[i].Objectives[0] < front[j].Objectives[0] })
+	fmt.Fprintf(out, "f1,f2\n")
+	for _, indi := range front {
+		fmt.Fprintf(out, "%.4f,%.4f\n", indi.Objectives[0], indi.Objectives[1])
+	}
+	fmt.Fprintf(out, "Termination: %s\nGenerations: %d\nFront size: %d\nHypervolume: %.4f (optimum %.4f)\nIGD: %.4f\n",
+		reason, stats.Generations, stats.FrontSize, stats.Hypervolume, optimalHypervolume, stats.IGD)
+	return stats, front, nil
+}
+
+func main() {
+	if _, _, err := run(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
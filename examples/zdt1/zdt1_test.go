@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestZDT1(t *testing.T) {
+	// The points whose variables but the first one are 0 lie on the front
+	for _, point := range paretoFront(5) {
+		var X = make([]float64, nbVariables)
+		X[0] = point[0]
+		if f := zdt1(X); math.Abs(f[0]-point[0]) > 1e-12 || math.Abs(f[1]-point[1]) > 1e-12 {
+			t.Errorf("Expected %v, got %v", point, f)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	var stats, front, err = run(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Hypervolume < 0.99*optimalHypervolume || stats.IGD > 0.02 {
+		t.Errorf("The front should be close to the Pareto front, got an hypervolume of %g and an IGD of %g", stats.Hypervolume, stats.IGD)
+	}
+	for i := 1; i < len(front); i++ {
+		if front[i].Objectives[1] > front[i-1].Objectives[1] {
+			t.Fatal("The archived individuals shouldn't dominate each other")
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package gago
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// An Experiment runs a GA configuration several times with different seeds,
+// because a single run of a stochastic algorithm says little about how good
+// the configuration is. NewGA returns a fresh GA for each run, the Seed of the
+// GA is overwritten. Each run stops once it has run MaxGenerations
+// generations, spent MaxEvaluations evaluations or lasted MaxDuration,
+// whichever comes first; at least one of them has to be provided. Runs are
+// executed Parallel at a time, one per CPU if Parallel is 0. A run is
+// successful if Success returns true for it's final statistics, for example
+// if the best fitness is below a target. If StopOnSuccess is true a run also
+// stops as soon as Success returns true for it's statistics, which is how
+// benchmark suites measure the number of evaluations needed to reach a target
+// precision, see ExperimentResult.ERT. The seeds of the runs are drawn from
+// Seed, hence an experiment with a non-zero Seed is reproducible as long as
+// the operators only use the random number generators they are given.
+type Experiment struct {
+	NewGA          func() GA
+	Runs           int
+	Seed           int64
+	MaxGenerations int
+	MaxEvaluations int
+	MaxDuration    time.Duration
+	Parallel       int
+	Success        func(stats Stats) bool
+	StopOnSuccess  bool
+}
+
+// ExperimentResult summarizes the runs of an Experiment. The best fitness and
+// the statistics of each run are given in the order of the runs.
+type ExperimentResult struct {
+	Seeds       []int64
+	Bests       []float64
+	Stats       []Stats
+	Mean        float64 // Mean of the best fitnesses
+	Median      float64
+	Std         float64 // Standard deviation of the best fitnesses
+	Min         float64
+	Max         float64
+	SuccessRate float64 // Proportion of successful runs, 0 if Success is nil
+	// Expected running time, which is the total number of evaluations of the
+	// runs divided by the number of successful runs, +Inf if no run succeeded
+	// and 0 if Success is nil
+	ERT float64
+}
+
+// Validate the experiment to verify the parameters are coherent.
+func (exp Experiment) Validate() error {
+	// Check the GA generator presence
+	if exp.NewGA == nil {
+		return errors.New("'NewGA' cannot be nil")
+	}
+	// Check the number of runs
+	if exp.Runs < 1 {
+		return errors.New("'Runs' should be higher or equal to 1")
+	}
+	// Check the termination criteria
+	if err := exp.checkTermination(); err != nil {
+		return err
+	}
+	// Check the success criterion is provided if the runs stop on success
+	if exp.StopOnSuccess && exp.Success == nil {
+		return errors.New("'Success' cannot be nil if 'StopOnSuccess' is true")
+	}
+	// Check the number of parallel runs
+	if exp.Parallel < 0 {
+		return errors.New("'Parallel' should be higher or equal to 0")
+	}
+	// Check the GA
+	return exp.NewGA().Validate()
+}
+
+// Check the termination criteria are positive and that at least one of them
+// is provided.
+func (exp Experiment) checkTermination() error {
+	if exp.MaxGenerations < 0 || exp.MaxEvaluations < 0 || exp.MaxDuration < 0 {
+		return errors.New("'MaxGenerations', 'MaxEvaluations' and 'MaxDuration' should be positive")
+	}
+	if exp.MaxGenerations == 0 && exp.MaxEvaluations == 0 && exp.MaxDuration == 0 {
+		return errors.New("one of 'MaxGenerations', 'MaxEvaluations' and 'MaxDuration' should be provided")
+	}
+	return nil
+}
+
+// Run a GA until one of the termination criteria of the experiment is met.
+func (exp Experiment) run(ga *GA) Stats {
+	ga.Initialize()
+	var opts = RunOptions{
+		MaxGenerations: exp.MaxGenerations,
+		MaxEvaluations: exp.MaxEvaluations,
+		MaxDuration:    exp.MaxDuration,
+	}
+	if exp.StopOnSuccess {
+		opts.Success = exp.Success
+	}
+	var stats, _, _ = ga.Run(context.Background(), opts)
+	return stats
+}
+
+// Run the experiment and summarize it's runs.
+func (exp Experiment) Run() (ExperimentResult, error) {
+	if err := exp.Validate(); err != nil {
+		return ExperimentResult{}, err
+	}
+	var result = ExperimentResult{
+		Seeds: make([]int64, exp.Runs),
+		Bests: make([]float64, exp.Runs),
+		Stats: make([]Stats, exp.Runs),
+	}
+	// Draw the seed of each run, a seed of 0 would mean using the current time
+	var seed = exp.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	var rng = rand.New(rand.NewSource(seed))
+	for i := range result.Seeds {
+		for result.Seeds[i] == 0 {
+			result.Seeds[i] = rng.Int63()
+		}
+	}
+	// Execute the runs
+	var parallel = exp.Parallel
+	if parallel == 0 {
+		parallel = runtime.NumCPU()
+	}
+	var (
+		wg     sync.WaitGroup
+		tokens = make(chan struct{}, parallel)
+	)
+	for i := 0; i < exp.Runs; i++ {
+		wg.Add(1)
+		tokens <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-tokens
+				wg.Done()
+			}()
+			var ga = exp.NewGA()
+			ga.Seed = result.Seeds[i]
+			result.Stats[i] = exp.run(&ga)
+			result.Bests[i] = result.Stats[i].Best
+		}(i)
+	}
+	wg.Wait()
+	// Aggregate the best fitnesses
+	result.Mean = mean(result.Bests)
+	result.Median = median(result.Bests)
+	result.Std = math.Sqrt(math.Max(variance(result.Bests), 0))
+	result.Min, result.Max = math.Inf(1), math.Inf(-1)
+	for _, best := range result.Bests {
+		result.Min = math.Min(result.Min, best)
+		result.Max = math.Max(result.Max, best)
+	}
+	if exp.Success != nil {
+		var successes, evaluations int
+		for _, stats := range result.Stats {
+			if exp.Success(stats) {
+				successes++
+			}
+			evaluations += stats.Evaluations
+		}
+		result.SuccessRate = float64(successes) / float64(exp.Runs)
+		result.ERT = math.Inf(1)
+		if successes > 0 {
+			result.ERT = float64(evaluations) / float64(successes)
+		}
+	}
+	return result, nil
+}
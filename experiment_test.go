@@ -0,0 +1,122 @@
+package gago
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newExperimentGA() GA {
+	return GA{
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          model,
+		NbrGenes:       nbGenes,
+		NbrIndividuals: nbIndividuals,
+		NbrPopulations: 2,
+	}
+}
+
+func TestExperiment(t *testing.T) {
+	var exp = Experiment{
+		NewGA:          newExperimentGA,
+		Runs:           6,
+		Seed:           1,
+		MaxGenerations: 5,
+		Parallel:       2,
+		Success:        func(stats Stats) bool { return stats.Best < 0 },
+	}
+	var result, err = exp.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Bests) != exp.Runs || len(result.Stats) != exp.Runs || len(result.Seeds) != exp.Runs {
+		t.Fatal("Expected a result per run")
+	}
+	for i, stats := range result.Stats {
+		if stats.Generations != exp.MaxGenerations {
+			t.Errorf("Expected %d generations, got %d", exp.MaxGenerations, stats.Generations)
+		}
+		if stats.Best != result.Bests[i] {
+			t.Error("Bests should be the best fitness of each run")
+		}
+		for j := 0; j < i; j++ {
+			if result.Seeds[i] == result.Seeds[j] {
+				t.Error("Runs should have different seeds")
+			}
+		}
+	}
+	if !(result.Min <= result.Median && result.Median <= result.Max) ||
+		!(result.Min <= result.Mean && result.Mean <= result.Max) {
+		t.Error("The summary of the best fitnesses is incoherent")
+	}
+	if result.Std < 0 {
+		t.Error("The standard deviation should be positive")
+	}
+	if result.SuccessRate != 1 {
+		t.Errorf("Expected a success rate of 1, got %f", result.SuccessRate)
+	}
+	// An experiment with the same seed is reproducible
+	var again, _ = exp.Run()
+	if !reflect.DeepEqual(result.Bests, again.Bests) {
+		t.Error("Experiments with the same seed should give the same results")
+	}
+}
+
+func TestExperimentValidate(t *testing.T) {
+	var invalid = newExperimentGA()
+	invalid.NbrGenes = 0
+	var testCases = []Experiment{
+		{Runs: 1, MaxGenerations: 1},
+		{NewGA: newExperimentGA, MaxGenerations: 1},
+		{NewGA: newExperimentGA, Runs: 1},
+		{NewGA: newExperimentGA, Runs: 1, MaxGenerations: -1},
+		{NewGA: newExperimentGA, Runs: 1, MaxGenerations: 1, Parallel: -1},
+		{NewGA: func() GA { return invalid }, Runs: 1, MaxGenerations: 1},
+	}
+	for _, exp := range testCases {
+		if exp.Validate() == nil {
+			t.Errorf("Expected an error for %+v", exp)
+		}
+		if _, err := exp.Run(); err == nil {
+			t.Error("Run should fail for an invalid experiment")
+		}
+	}
+}
+
+func TestExperimentStopOnSuccess(t *testing.T) {
+	var exp = Experiment{
+		NewGA: func() GA {
+			return GA{
+				NbrPopulations: 1,
+				NbrIndividuals: nbIndividuals,
+				NbrGenes:       nbGenes,
+				Ff:             ff,
+				Initializer:    initializer,
+				Model:          model,
+			}
+		},
+		Runs:           4,
+		Seed:           42,
+		MaxGenerations: 50,
+		Success:        func(stats Stats) bool { return stats.Generations >= 10 },
+		StopOnSuccess:  true,
+	}
+	var result, err = exp.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var evaluations int
+	for _, stats := range result.Stats {
+		if stats.Generations != 10 {
+			t.Errorf("Expected the run to stop after 10 generations, got %d", stats.Generations)
+		}
+		evaluations += stats.Evaluations
+	}
+	if result.ERT != float64(evaluations)/4 {
+		t.Errorf("Expected an ERT of %f, got %f", float64(evaluations)/4, result.ERT)
+	}
+	exp.Success = nil
+	if exp.Validate() == nil {
+		t.Error("StopOnSuccess without Success should be rejected")
+	}
+}
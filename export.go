@@ -0,0 +1,167 @@
+package gago
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// A CSVExporter writes snapshots of a GA as CSV tables, which can be loaded
+// directly by analysis tools such as pandas or Polars and converted to
+// columnar formats such as Parquet. Export is meant to be called after each
+// generation, or at any interval, and appends one row per individual to
+// Individuals and one row to Stats; either writer can be nil. The header of
+// each table is written by the first call. The individuals table has a column
+// for the generation, the population, the rank within the population, the
+// name, the ID, the birth generation, the origin population, the fitness, each
+// objective and each gene. The IDs make it possible to follow an individual
+// from one snapshot to the next, for example across migrations. A genome that
+// holds a single Vector has a column per value. The number of objective and
+// gene columns is set by the first snapshot, missing values are left empty and
+// additional values are left out. Genes that aren't numbers are written with
+// fmt.Sprint. Export returns an error if the GA has no individuals, for example
+// if it hasn't been initialized.
+type CSVExporter struct {
+	Individuals  io.Writer
+	Stats        io.Writer
+	indis        *csv.Writer
+	stats        *csv.Writer
+	nbObjectives int
+	nbGenes      int
+}
+
+// Format a float with the minimal number of digits that represent it exactly.
+func formatFloat(x float64) string {
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
+
+// Return the values of a genome as strings, the values of a Vector are
+// written separately.
+func geneStrings(genome Genome) []string {
+	if len(genome) == 1 {
+		if v, ok := genome[0].(Vector); ok {
+			var values = make([]string, len(v))
+			for i, x := range v {
+				values[i] = formatFloat(x)
+			}
+			return values
+		}
+	}
+	var values = make([]string, len(genome))
+	for i, gene := range genome {
+		if x, ok := gene.(float64); ok {
+			values[i] = formatFloat(x)
+		} else {
+			values[i] = fmt.Sprint(gene)
+		}
+	}
+	return values
+}
+
+// Return n values out of a slice, padded with empty strings if it's too short.
+func fitColumns(values []string, n int) []string {
+	var fitted = make([]string, n)
+	copy(fitted, values)
+	return fitted
+}
+
+// Return the first individual of a GA, which sets the columns of the exported
+// tables. An error is returned if the GA has no individuals, for example
+// because it hasn't been initialized.
+func firstIndividual(ga *GA) (Individual, error) {
+	for _, pop := range ga.Populations {
+		if len(pop.Individuals) > 0 {
+			return pop.Individuals[0], nil
+		}
+	}
+	return Individual{}, errors.New("the GA has no individuals to export")
+}
+
+// Export writes a snapshot of a GA.
+func (exp *CSVExporter) Export(ga *GA) error {
+	if exp.Individuals != nil {
+		if err := exp.exportIndividuals(ga); err != nil {
+			return err
+		}
+	}
+	if exp.Stats != nil {
+		if err := exp.exportStats(ga); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Append a row per individual to the individuals table.
+func (exp *CSVExporter) exportIndividuals(ga *GA) error {
+	// Write the header and fix the number of columns
+	if exp.indis == nil {
+		var first, err = firstIndividual(ga)
+		if err != nil {
+			return err
+		}
+		exp.indis = csv.NewWriter(exp.Individuals)
+		exp.nbObjectives = len(first.Objectives)
+		exp.nbGenes = len(geneStrings(first.Genome))
+		var header = []string{"generation", "population", "rank", "name", "id", "birth", "origin", "fitness"}
+		for i := 0; i < exp.nbObjectives; i++ {
+			header = append(header, "objective_"+strconv.Itoa(i))
+		}
+		for i := 0; i < exp.nbGenes; i++ {
+			header = append(header, "gene_"+strconv.Itoa(i))
+		}
+		exp.indis.Write(header)
+	}
+	for p, pop := range ga.Populations {
+		for r, indi := range pop.Individuals {
+			var (
+				objectives = make([]string, len(indi.Objectives))
+				row        = []string{
+					strconv.Itoa(ga.Generations),
+					strconv.Itoa(p),
+					strconv.Itoa(r),
+					indi.Name,
+					strconv.Itoa(indi.ID),
+					strconv.Itoa(indi.Birth),
+					strconv.Itoa(indi.Origin),
+					formatFloat(indi.Fitness),
+				}
+			)
+			for i, objective := range indi.Objectives {
+				objectives[i] = formatFloat(objective)
+			}
+			row = append(row, fitColumns(objectives, exp.nbObjectives)...)
+			row = append(row, fitColumns(geneStrings(indi.Genome), exp.nbGenes)...)
+			exp.indis.Write(row)
+		}
+	}
+	exp.indis.Flush()
+	return exp.indis.Error()
+}
+
+// Append the statistics of the GA to the statistics table.
+func (exp *CSVExporter) exportStats(ga *GA) error {
+	if exp.stats == nil {
+		exp.stats = csv.NewWriter(exp.Stats)
+		exp.stats.Write([]string{
+			"generation", "evaluations", "duration", "best", "mean", "variance",
+			"front_size", "hypervolume", "igd",
+		})
+	}
+	var stats = ga.Stats()
+	exp.stats.Write([]string{
+		strconv.Itoa(stats.Generations),
+		strconv.Itoa(stats.Evaluations),
+		formatFloat(stats.Duration.Seconds()),
+		formatFloat(stats.Best),
+		formatFloat(stats.Mean),
+		formatFloat(stats.Variance),
+		strconv.Itoa(stats.FrontSize),
+		formatFloat(stats.Hypervolume),
+		formatFloat(stats.IGD),
+	})
+	exp.stats.Flush()
+	return exp.stats.Error()
+}
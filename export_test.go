@@ -0,0 +1,87 @@
+package gago
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestCSVExporter(t *testing.T) {
+	var (
+		g = GA{
+			NbrPopulations: 2,
+			NbrIndividuals: 5,
+			NbrGenes:       nbGenes,
+			Initializer:    initializer,
+			Ff:             ff,
+			Model:          model,
+		}
+		indis, stats bytes.Buffer
+		exp          = CSVExporter{Individuals: &indis, Stats: &stats}
+	)
+	g.Initialize()
+	for i := 0; i < 3; i++ {
+		if err := exp.Export(&g); err != nil {
+			t.Fatal(err)
+		}
+		g.Enhance()
+	}
+	var rows, err = csv.NewReader(&indis).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A header and 10 individuals per snapshot
+	if len(rows) != 31 {
+		t.Errorf("Expected 31 rows, got %d", len(rows))
+	}
+	if len(rows[0]) != 8+nbGenes || rows[0][4] != "id" || rows[0][8] != "gene_0" {
+		t.Errorf("Unexpected header %v", rows[0])
+	}
+	if rows[1][4] == "0" || rows[1][6] != "0" {
+		t.Errorf("The individuals weren't stamped %v", rows[1])
+	}
+	if rows[30][0] != "2" || rows[30][1] != "1" || rows[30][2] != "4" {
+		t.Errorf("Unexpected last row %v", rows[30])
+	}
+	if rows, _ = csv.NewReader(&stats).ReadAll(); len(rows) != 4 || rows[3][0] != "2" {
+		t.Errorf("Unexpected statistics %v", rows)
+	}
+}
+
+func TestGeneStrings(t *testing.T) {
+	var testCases = []struct {
+		genome  Genome
+		strings []string
+	}{
+		{Genome{0.5, 2.0}, []string{"0.5", "2"}},
+		{Genome{Vector{1, 0.25}}, []string{"1", "0.25"}},
+		{Genome{"a", true, 3}, []string{"a", "true", "3"}},
+	}
+	for _, testCase := range testCases {
+		var strings = geneStrings(testCase.genome)
+		if len(strings) != len(testCase.strings) {
+			t.Errorf("Expected %v, got %v", testCase.strings, strings)
+			continue
+		}
+		for i := range strings {
+			if strings[i] != testCase.strings[i] {
+				t.Errorf("Expected %v, got %v", testCase.strings, strings)
+				break
+			}
+		}
+	}
+}
+
+func TestCSVExporterEmpty(t *testing.T) {
+	var (
+		indis bytes.Buffer
+		exp   = CSVExporter{Individuals: &indis}
+	)
+	// The GA isn't initialized
+	if err := exp.Export(&GA{}); err == nil {
+		t.Error("Exporting a GA without individuals should fail")
+	}
+	if err := exp.Export(&GA{Populations: Populations{{}}}); err == nil {
+		t.Error("Exporting a GA with an empty population should fail")
+	}
+}
@@ -0,0 +1,125 @@
+package gago
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// A fidelityFunction is a fitness function that can be evaluated at several
+// fidelities, the individuals are evaluated at the current base fidelity and
+// the best of them are promoted to higher fidelities.
+type fidelityFunction interface {
+	fidelity() int
+	applyFidelity(genome Genome, fidelity int) float64
+	promote(indis Individuals, counted countedFunction)
+}
+
+// A scheduledFunction is a fitness function that changes as the run
+// progresses, the GA updates it before each generation like a
+// scheduledComparator.
+type scheduledFunction interface {
+	schedule(generations, evaluations int, pops Populations)
+}
+
+// FidelityFunction is for functions that take a fidelity parameter, such as a
+// simulation whose resolution or number of steps can be chosen, or a model
+// trained for a given number of epochs. The fidelities go from 0, the cheapest,
+// to Levels-1, the most accurate. The individuals are evaluated at a base
+// fidelity which starts at 0 and is increased every Generations generations,
+// it stays at 0 if Generations is 0. The best individuals are then promoted to
+// higher fidelities in the manner of asynchronous successive halving: after
+// each generation of a population, the individuals that are among the best
+// 1/Eta of the individuals evaluated at their fidelity are evaluated again at
+// the next fidelity, Eta being 3 if it's 0. The individuals whose fidelity is
+// below the base fidelity are evaluated again at the base fidelity. Hence the
+// elites are evaluated accurately whereas most of the offsprings are only
+// evaluated cheaply. The fidelity at which an individual was evaluated is
+// stored in it's Fidelity field and the re-evaluations are counted as
+// evaluations.
+//
+// The individuals are sorted by fitness whatever their fidelity, hence a
+// low-fidelity fitness should be an estimate of the high-fidelity fitness
+// rather than a bound. The GA updates the base fidelity before each generation
+// when a FidelityFunction is it's fitness function, hence it has to be used
+// through a pointer.
+type FidelityFunction struct {
+	Image       func(genome Genome, fidelity int) float64
+	Levels      int
+	Generations int
+	Eta         int
+	base        int64
+}
+
+// Apply the function wrapped in FidelityFunction at the base fidelity.
+func (ff *FidelityFunction) apply(genome Genome) float64 {
+	return ff.Image(genome, ff.fidelity())
+}
+
+// Return the base fidelity.
+func (ff *FidelityFunction) fidelity() int {
+	return int(atomic.LoadInt64(&ff.base))
+}
+
+// Apply the function wrapped in FidelityFunction at a given fidelity.
+func (ff *FidelityFunction) applyFidelity(genome Genome, fidelity int) float64 {
+	return ff.Image(genome, fidelity)
+}
+
+// Update the base fidelity with the number of generations.
+func (ff *FidelityFunction) schedule(generations, evaluations int, pops Populations) {
+	var base = 0
+	if ff.Generations > 0 {
+		base = min(generations/ff.Generations, ff.Levels-1)
+	}
+	atomic.StoreInt64(&ff.base, int64(max(base, 0)))
+}
+
+// Evaluate an individual again at a given fidelity.
+func (ff *FidelityFunction) reevaluate(indi *Individual, fidelity int, counted countedFunction) {
+	var start = counted.profiler.now()
+	indi.Fitness = ff.Image(indi.Genome, fidelity)
+	indi.Fidelity = fidelity
+	counted.guard.check(indi)
+	counted.record(1, start)
+	countEvaluations(1)
+}
+
+// Promote the best individuals of each fidelity to the next fidelity.
+func (ff *FidelityFunction) promote(indis Individuals, counted countedFunction) {
+	var (
+		base = ff.fidelity()
+		eta  = ff.Eta
+	)
+	if eta == 0 {
+		eta = 3
+	}
+	// Bring the individuals up to the base fidelity
+	for i := range indis {
+		if indis[i].Fidelity < base {
+			ff.reevaluate(&indis[i], base, counted)
+		}
+	}
+	for fidelity := base; fidelity < ff.Levels-1; fidelity++ {
+		var rung []int
+		for i, indi := range indis {
+			if indi.Fidelity == fidelity {
+				rung = append(rung, i)
+			}
+		}
+		sort.SliceStable(rung, func(a, b int) bool {
+			return indis[rung[a]].Fitness < indis[rung[b]].Fitness
+		})
+		for _, i := range rung[:len(rung)/eta] {
+			ff.reevaluate(&indis[i], fidelity+1, counted)
+		}
+	}
+}
+
+// Promote the individuals to higher fidelities if the fitness function is a
+// FidelityFunction.
+func (indis Individuals) promote(ff FitnessFunction) {
+	var f, counted = uncount(ff)
+	if mf, ok := f.(fidelityFunction); ok {
+		mf.promote(indis, counted)
+	}
+}
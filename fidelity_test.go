@@ -0,0 +1,96 @@
+package gago
+
+import "testing"
+
+// A function whose fitness is the first gene at the highest fidelity and is
+// offset by 10 for each missing level of fidelity.
+func newFidelityFunction() *FidelityFunction {
+	return &FidelityFunction{
+		Image: func(genome Genome, fidelity int) float64 {
+			return genome[0].(float64) + 10*float64(2-fidelity)
+		},
+		Levels: 3,
+	}
+}
+
+func TestFidelityPromotion(t *testing.T) {
+	var (
+		ff      = newFidelityFunction()
+		counted = countedFunction{ff: ff, count: new(int64)}
+		indis   = make(Individuals, 9)
+	)
+	for i := range indis {
+		indis[i].Genome = Genome{float64(i)}
+	}
+	indis.Evaluate(counted)
+	for _, indi := range indis {
+		if indi.Fidelity != 0 || indi.Fitness != indi.Genome[0].(float64)+20 {
+			t.Error("The individuals weren't evaluated at the base fidelity")
+		}
+	}
+	indis.promote(counted)
+	// The 3 best individuals reach fidelity 1 and the best of them fidelity 2
+	var expected = []int{2, 1, 1, 0, 0, 0, 0, 0, 0}
+	for i, indi := range indis {
+		if indi.Fidelity != expected[i] {
+			t.Errorf("Expected individual %d to have fidelity %d, got %d", i, expected[i], indi.Fidelity)
+		}
+		if indi.Fitness != indi.Genome[0].(float64)+10*float64(2-indi.Fidelity) {
+			t.Error("A promoted individual wasn't evaluated again")
+		}
+	}
+	if *counted.count != 9+3+1 {
+		t.Errorf("Expected 13 evaluations, got %d", *counted.count)
+	}
+}
+
+func TestFidelitySchedule(t *testing.T) {
+	var ff = newFidelityFunction()
+	ff.Generations = 2
+	for _, c := range []struct{ generations, base int }{{0, 0}, {1, 0}, {2, 1}, {5, 2}, {100, 2}} {
+		ff.schedule(c.generations, 0, nil)
+		if ff.fidelity() != c.base {
+			t.Errorf("Expected a base fidelity of %d at generation %d, got %d", c.base, c.generations, ff.fidelity())
+		}
+	}
+	// The individuals below the base fidelity are brought up to it
+	var indis = Individuals{{Genome: Genome{1.0}}, {Genome: Genome{2.0}}}
+	indis.promote(ff)
+	for _, indi := range indis {
+		if indi.Fidelity != 2 || indi.Fitness != indi.Genome[0].(float64) {
+			t.Error("The individuals weren't evaluated at the base fidelity")
+		}
+	}
+}
+
+func TestFidelityGA(t *testing.T) {
+	var (
+		ga = NewFloatGA(1, -5, 5, func(x []float64) float64 { return 0 })
+		ff = newFidelityFunction()
+	)
+	ff.Generations = 3
+	ga.Ff = ff
+	ga.Initialize()
+	var promoted = false
+	for _, indi := range ga.Populations[0].Individuals {
+		promoted = promoted || indi.Fidelity > 0
+	}
+	if !promoted {
+		t.Error("No individual was promoted")
+	}
+	for i := 0; i < 6; i++ {
+		ga.Enhance()
+	}
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			if indi.Fidelity != 2 {
+				t.Error("The individuals weren't brought up to the base fidelity")
+			}
+		}
+	}
+	// Initializing again resets the base fidelity
+	ga.Initialize()
+	if ff.fidelity() != 0 {
+		t.Error("The base fidelity wasn't reset")
+	}
+}
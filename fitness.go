@@ -1,11 +1,44 @@
 package gago
 
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
 // FitnessFunction wraps user defined functions in order to generalize other
 // functions.
 type FitnessFunction interface {
 	apply(genome Genome) float64
 }
 
+// A casesFunction is a fitness function that measures an error on each case of
+// a set of test cases, which is required by lexicase selection. The fitness of
+// an individual is the sum of it's errors.
+type casesFunction interface {
+	applyCases(genome Genome) []float64
+}
+
+// A batchFunction is a fitness function that evaluates several genomes at
+// once, which is preferred over evaluating each genome on it's own when
+// evaluating a slice of individuals.
+type batchFunction interface {
+	applyBatch(genomes []Genome) []float64
+}
+
+// GenomeFunction is for functions that take the genome as is, which is useful
+// for genomes containing custom types, for example the trees used in genetic
+// programming.
+type GenomeFunction struct {
+	Image func(Genome) float64
+}
+
+// Apply the fitness function wrapped in GenomeFunction.
+func (ff GenomeFunction) apply(genome Genome) float64 {
+	return ff.Image(genome)
+}
+
 // Float64Function is for functions with floating point slices as input.
 type Float64Function struct {
 	Image func([]float64) float64
@@ -33,3 +66,225 @@ func (ff StringFunction) apply(genome Genome) float64 {
 	}
 	return ff.Image(casted)
 }
+
+// IntFunction is for functions with integer slices as input.
+type IntFunction struct {
+	Image func([]int) float64
+}
+
+// Apply the fitness function wrapped in IntFunction.
+func (ff IntFunction) apply(genome Genome) float64 {
+	var casted = make([]int, len(genome))
+	for i := range genome {
+		casted[i] = genome[i].(int)
+	}
+	return ff.Image(casted)
+}
+
+// BoolFunction is for functions with boolean slices as input.
+type BoolFunction struct {
+	Image func([]bool) float64
+}
+
+// Apply the fitness function wrapped in BoolFunction.
+func (ff BoolFunction) apply(genome Genome) float64 {
+	var casted = make([]bool, len(genome))
+	for i := range genome {
+		casted[i] = genome[i].(bool)
+	}
+	return ff.Image(casted)
+}
+
+// GrayFunction is for functions with floating point slices as input that are
+// optimized with a binary genome. Each consecutive group of Bits genes is
+// decoded into a floating point number in the [Lower, Upper] range with
+// DecodeGrayF, hence the number of genes should be a multiple of Bits. Bits
+// should belong to [1, 64].
+type GrayFunction struct {
+	Bits         int
+	Lower, Upper float64
+	Image        func([]float64) float64
+}
+
+// Validate the parameters of a GrayFunction.
+func (ff GrayFunction) Validate() error {
+	return GrayDecoder{Bits: ff.Bits}.Validate()
+}
+
+// Apply the fitness function wrapped in GrayFunction.
+func (ff GrayFunction) apply(genome Genome) float64 {
+	var dec = GrayDecoder{Bits: ff.Bits, Lower: ff.Lower, Upper: ff.Upper}
+	return ff.Image(dec.Decode(genome).([]float64))
+}
+
+// An objectivesFunction is a fitness function that measures several
+// objectives, the fitness of an individual is obtained by aggregating it's
+// objectives.
+type objectivesFunction interface {
+	applyObjectives(genome Genome) []float64
+	aggregate(objectives []float64) float64
+}
+
+// CasesFunction is for functions that measure the error of a genome on each of
+// a set of test cases, as is usual in program synthesis. The errors are stored
+// in the Cases field of each individual and the fitness is their sum.
+type CasesFunction struct {
+	Image func(Genome) []float64
+}
+
+// Apply the fitness function wrapped in CasesFunction.
+func (ff CasesFunction) apply(genome Genome) float64 {
+	return sum(ff.Image(genome))
+}
+
+// Compute the errors on each case with the function wrapped in CasesFunction.
+func (ff CasesFunction) applyCases(genome Genome) []float64 {
+	return ff.Image(genome)
+}
+
+// ObjectivesFunction is for multi-objective problems, the function returns the
+// value of each objective, all of which have to be minimized. The objectives
+// are stored in the Objectives field of each individual and are used by the
+// Pareto machinery, the fitness is their sum so that single-objective
+// operators can still be used.
+type ObjectivesFunction struct {
+	Image func(Genome) []float64
+}
+
+// Apply the fitness function wrapped in ObjectivesFunction.
+func (ff ObjectivesFunction) apply(genome Genome) float64 {
+	return sum(ff.Image(genome))
+}
+
+// Compute the objectives with the function wrapped in ObjectivesFunction.
+func (ff ObjectivesFunction) applyObjectives(genome Genome) []float64 {
+	return ff.Image(genome)
+}
+
+// Aggregate the objectives computed by ObjectivesFunction by summing them.
+func (ff ObjectivesFunction) aggregate(objectives []float64) float64 {
+	return sum(objectives)
+}
+
+// ConstrainedFunction is for problems with constraints, Violation returns the
+// total violation of the constraints by a genome, which is 0 if the genome is
+// feasible. The fitness is computed by Function, which can provide the errors
+// on each case or the objectives. The violation is stored in the Violation
+// field of each individual, it's taken into account by CompConstrained and by
+// the NSGA models.
+type ConstrainedFunction struct {
+	Function  FitnessFunction
+	Violation func(genome Genome) float64
+}
+
+// Apply the fitness function wrapped in ConstrainedFunction.
+func (ff ConstrainedFunction) apply(genome Genome) float64 {
+	return ff.Function.apply(genome)
+}
+
+// A failingFunction is a fitness function that can fail, the second value
+// tells if the individual should be replaced because it's evaluation failed
+// and the error is the one that made the evaluation fail.
+type failingFunction interface {
+	applyFailing(genome Genome) (float64, bool, error)
+}
+
+// ErrFunction is for functions that can fail, for example simulations that
+// occasionally crash. A failed evaluation is retried up to Retries times, if it
+// still fails then the individual is assigned the worst possible fitness, +Inf.
+// If Regenerate is true then the individual is also replaced by a new random
+// individual at the end of the generation; a replacement whose evaluation
+// fails is replaced at the end of the next generation. OnError, if provided,
+// is called with every error, for example to log them.
+type ErrFunction struct {
+	Image      func(Genome) (float64, error)
+	Retries    int
+	Regenerate bool
+	OnError    func(genome Genome, err error)
+}
+
+// Apply the fitness function wrapped in ErrFunction.
+func (ff ErrFunction) apply(genome Genome) float64 {
+	var fitness, _, _ = ff.applyFailing(genome)
+	return fitness
+}
+
+// Apply the fitness function wrapped in ErrFunction and retry it if it fails.
+func (ff ErrFunction) applyFailing(genome Genome) (float64, bool, error) {
+	var err error
+	for attempt := 0; attempt <= ff.Retries; attempt++ {
+		var fitness float64
+		if fitness, err = ff.Image(genome); err == nil {
+			return fitness, false, nil
+		}
+		if ff.OnError != nil {
+			ff.OnError(genome, err)
+		}
+	}
+	return math.Inf(1), ff.Regenerate, err
+}
+
+// BatchFunction is for functions that evaluate a slice of genomes in a single
+// call and return the fitness of each genome in the same order, for example
+// when the evaluation is vectorized on a GPU or sent as one remote call. The
+// individuals of a population that need to be evaluated are sent together,
+// evaluating a single individual amounts to a batch of size one.
+type BatchFunction struct {
+	Image func([]Genome) []float64
+}
+
+// Apply the fitness function wrapped in BatchFunction to a single genome.
+func (ff BatchFunction) apply(genome Genome) float64 {
+	return ff.applyBatch([]Genome{genome})[0]
+}
+
+// Apply the fitness function wrapped in BatchFunction to several genomes. A
+// batch function that doesn't return one fitness per genome is a programming
+// error which would leave individuals unevaluated, hence it panics.
+func (ff BatchFunction) applyBatch(genomes []Genome) []float64 {
+	var fitnesses = ff.Image(genomes)
+	if len(fitnesses) != len(genomes) {
+		panic(fmt.Errorf("the batch function returned %d fitnesses for %d genomes", len(fitnesses), len(genomes)))
+	}
+	return fitnesses
+}
+
+// countedFunction wraps a fitness function and counts the number of times it is
+// applied. The counter is shared by every copy of the wrapper, hence the
+// populations of a GA all increment the same counter. The time spent
+// evaluating is also accumulated if the GA is profiled. The wrapper also tells
+// if the identical genomes of a batch should be evaluated once and how the
+// fitnesses that aren't finite should be handled.
+type countedFunction struct {
+	ff          FitnessFunction
+	count       *int64
+	profiler    *profiler
+	deduplicate bool
+	guard       *FitnessGuard
+}
+
+// Apply the wrapped fitness function and increment the counter.
+func (cf countedFunction) apply(genome Genome) float64 {
+	var start = cf.profiler.now()
+	defer cf.record(1, start)
+	return cf.ff.apply(genome)
+}
+
+// Record n evaluations which started at start.
+func (cf countedFunction) record(n int, start time.Time) {
+	if cf.count != nil {
+		atomic.AddInt64(cf.count, int64(n))
+	}
+	if cf.profiler != nil {
+		cf.profiler.add(&cf.profiler.evaluation, start)
+	}
+}
+
+// Return the fitness function behind the counting wrapper along with the
+// wrapper, the wrapper is empty if the fitness function isn't counted.
+func uncount(ff FitnessFunction) (FitnessFunction, countedFunction) {
+	if counted, ok := ff.(countedFunction); ok {
+		return counted.ff, counted
+	}
+	return ff, countedFunction{}
+}
@@ -1,6 +1,10 @@
 package gago
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"testing"
+)
 
 func TestFloat64Function(t *testing.T) {
 	var ff = Float64Function{func(X []float64) float64 {
@@ -39,3 +43,239 @@ func TestStringFunction(t *testing.T) {
 		t.Error("Problem with StringFunction")
 	}
 }
+
+func TestIntFunction(t *testing.T) {
+	var ff = IntFunction{func(X []int) float64 {
+		var sum int
+		for _, x := range X {
+			sum += x
+		}
+		return float64(sum)
+	}}
+	if ff.apply(Genome{1, 2, 3}) != 6.0 {
+		t.Error("Problem with IntFunction")
+	}
+}
+
+func TestGrayFunction(t *testing.T) {
+	var (
+		ff = GrayFunction{
+			Bits:  3,
+			Lower: 0,
+			Upper: 7,
+			Image: func(X []float64) float64 {
+				return X[0] + X[1]
+			},
+		}
+		genome = Genome{}
+	)
+	// Encode 2 and 5
+	for _, bit := range append(uintToBits(GrayEncode(2), 3), uintToBits(GrayEncode(5), 3)...) {
+		genome = append(genome, bit)
+	}
+	if ff.apply(genome) != 7.0 {
+		t.Error("Problem with GrayFunction")
+	}
+}
+
+func TestCasesFunction(t *testing.T) {
+	var (
+		ff = CasesFunction{func(genome Genome) []float64 {
+			var errors = make([]float64, len(genome))
+			for i, gene := range genome {
+				errors[i] = gene.(float64)
+			}
+			return errors
+		}}
+		indi = Individual{Genome: Genome{1.0, 2.0, 3.0}}
+	)
+	indi.Evaluate(ff)
+	if indi.Fitness != 6.0 || len(indi.Cases) != 3 || indi.Cases[2] != 3.0 {
+		t.Error("Problem with CasesFunction")
+	}
+}
+
+func TestGenomeFunction(t *testing.T) {
+	var ff = GenomeFunction{func(genome Genome) float64 {
+		return float64(len(genome))
+	}}
+	if ff.apply(Genome{"a", 1, true}) != 3.0 {
+		t.Error("Problem with GenomeFunction")
+	}
+}
+
+func TestCountedFunction(t *testing.T) {
+	var (
+		count int64
+		cases = CasesFunction{func(genome Genome) []float64 {
+			return []float64{1, 2}
+		}}
+		ffs = []FitnessFunction{
+			countedFunction{ff: cases, count: &count},
+			countedFunction{ff: GenomeFunction{func(genome Genome) float64 { return 3 }}, count: &count},
+		}
+	)
+	for _, ff := range ffs {
+		var indi = Individual{Genome: Genome{1.0}}
+		indi.Evaluate(ff)
+		indi.Evaluate(ff)
+		if indi.Fitness != 3 {
+			t.Error("countedFunction altered the fitness")
+		}
+	}
+	if count != 2 {
+		t.Error("countedFunction didn't count the evaluations")
+	}
+	var indi = Individual{Genome: Genome{1.0}}
+	indi.Evaluate(ffs[0])
+	if len(indi.Cases) != 2 {
+		t.Error("countedFunction hid the cases of a CasesFunction")
+	}
+}
+
+func TestBatchFunction(t *testing.T) {
+	var (
+		calls int
+		ff    = BatchFunction{func(genomes []Genome) []float64 {
+			calls++
+			var fitnesses = make([]float64, len(genomes))
+			for i, genome := range genomes {
+				fitnesses[i] = genome[0].(float64)
+			}
+			return fitnesses
+		}}
+		count int64
+		indis = Individuals{
+			Individual{Genome: Genome{1.0}},
+			Individual{Genome: Genome{2.0}, Fitness: 42, Evaluated: true},
+			Individual{Genome: Genome{3.0}},
+		}
+	)
+	indis.Evaluate(countedFunction{ff: ff, count: &count})
+	if calls != 1 {
+		t.Error("The individuals weren't evaluated in a single batch")
+	}
+	if count != 2 {
+		t.Error("The evaluations of the batch weren't counted")
+	}
+	if indis[0].Fitness != 1 || indis[1].Fitness != 42 || indis[2].Fitness != 3 {
+		t.Error("The fitnesses weren't assigned to the right individuals")
+	}
+	var indi = Individual{Genome: Genome{4.0}}
+	indi.Evaluate(ff)
+	if indi.Fitness != 4 {
+		t.Error("Problem with BatchFunction on a single individual")
+	}
+}
+
+func TestBatchFunctionLength(t *testing.T) {
+	for _, n := range []int{1, 3} {
+		var ff = BatchFunction{func(genomes []Genome) []float64 {
+			return make([]float64, n)
+		}}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("A batch function returning %d fitnesses for 2 genomes should panic", n)
+				}
+			}()
+			var indis = Individuals{{Genome: Genome{1.0}}, {Genome: Genome{2.0}}}
+			indis.Evaluate(ff)
+		}()
+	}
+}
+
+func TestConstrainedFunction(t *testing.T) {
+	var (
+		ff = ConstrainedFunction{
+			Function: ObjectivesFunction{func(genome Genome) []float64 {
+				return []float64{genome[0].(float64), -genome[0].(float64)}
+			}},
+			Violation: func(genome Genome) float64 {
+				return math.Max(genome[0].(float64)-1, 0)
+			},
+		}
+		indis = Individuals{{Genome: Genome{3.0}}, {Genome: Genome{0.5}}, {Genome: Genome{3.0}}}
+	)
+	indis.Evaluate(countedFunction{ff: ff, count: new(int64), deduplicate: true})
+	if indis[0].Violation != 2 || indis[1].Violation != 0 || indis[2].Violation != 2 {
+		t.Errorf("Wrong violations %f, %f and %f", indis[0].Violation, indis[1].Violation, indis[2].Violation)
+	}
+	if indis[0].Feasible() || !indis[1].Feasible() {
+		t.Error("Feasible returned the wrong value")
+	}
+	// The objectives are still computed
+	if len(indis[0].Objectives) != 2 || indis[0].Fitness != 0 {
+		t.Error("The wrapped function wasn't applied")
+	}
+}
+
+func TestErrFunction(t *testing.T) {
+	var (
+		calls  int
+		errs   int
+		failed = errors.New("failed")
+		ff     = ErrFunction{
+			Image: func(genome Genome) (float64, error) {
+				calls++
+				if calls < 3 {
+					return 0, failed
+				}
+				return 1, nil
+			},
+			OnError: func(genome Genome, err error) { errs++ },
+		}
+	)
+	// The evaluation fails twice and there is a single retry
+	ff.Retries = 1
+	var indi = Individual{Genome: Genome{}}
+	indi.Evaluate(ff)
+	if !math.IsInf(indi.Fitness, 1) || indi.failed || errs != 2 {
+		t.Error("A failed evaluation should be assigned the worst fitness")
+	}
+	if indi.err != failed {
+		t.Error("The error of a failed evaluation should be kept")
+	}
+	// The evaluation succeeds at the third attempt
+	calls = 0
+	ff.Retries = 2
+	ff.Regenerate = true
+	indi = Individual{Genome: Genome{}}
+	indi.Evaluate(ff)
+	if indi.Fitness != 1 || indi.failed {
+		t.Error("The evaluation wasn't retried")
+	}
+	// The evaluation always fails and the individual is flagged
+	calls = -100
+	indi = Individual{Genome: Genome{}}
+	indi.Evaluate(ff)
+	if !indi.failed {
+		t.Error("The individual should have been flagged for regeneration")
+	}
+}
+
+func TestRegenerate(t *testing.T) {
+	var (
+		ff = ErrFunction{
+			Image: func(genome Genome) (float64, error) {
+				if genome[0].(float64) < 0 {
+					return 0, errors.New("negative")
+				}
+				return genome[0].(float64), nil
+			},
+			Regenerate: true,
+		}
+		pop = makePopulation(5, 1, ff, InitUniformF{Lower: 0, Upper: 1})
+	)
+	pop.Individuals[2].Genome[0] = -1.0
+	pop.Individuals.Evaluate(pop.ff)
+	if !pop.Individuals[2].failed {
+		t.Fatal("The individual should have been flagged for regeneration")
+	}
+	pop.regenerate(1, InitUniformF{Lower: 0, Upper: 1})
+	for _, indi := range pop.Individuals {
+		if indi.failed || math.IsInf(indi.Fitness, 1) {
+			t.Error("The failed individual wasn't regenerated")
+		}
+	}
+}
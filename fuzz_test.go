@@ -0,0 +1,196 @@
+package gago
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// The fuzz targets throw random genomes and parameters at the operators to
+// check they don't panic and that they produce offsprings of the right
+// length. The seed corpus is run by go test, the targets are fuzzed with
+// go test -fuzz=FuzzCrossPoint for example.
+
+// Build two parents of the same length from fuzzed bytes, the first parent's
+// genes are the bytes and the second parent's genes are the bytes in reverse
+// order.
+func fuzzParents(data []byte, gene func(b byte) interface{}) (Individual, Individual) {
+	var p1, p2 = Individual{Genome: make(Genome, len(data))}, Individual{Genome: make(Genome, len(data))}
+	for i, b := range data {
+		p1.Genome[i] = gene(b)
+		p2.Genome[len(data)-1-i] = gene(b)
+	}
+	return p1, p2
+}
+
+func intGene(b byte) interface{}     { return int(b) }
+func floatGene(b byte) interface{}   { return float64(b) / 16 }
+func boolGene(b byte) interface{}    { return b%2 == 0 }
+func stringGene(b byte) interface{}  { return string(rune('a' + b%26)) }
+func fuzzRand(seed int64) *rand.Rand { return rand.New(rand.NewSource(seed)) }
+
+// Check the offsprings of a crossover have the same length as the parents.
+func checkOffsprings(t *testing.T, p1, o1, o2 Individual) {
+	if len(o1.Genome) != len(p1.Genome) || len(o2.Genome) != len(p1.Genome) {
+		t.Errorf("Expected offsprings of length %d, got %d and %d", len(p1.Genome), len(o1.Genome), len(o2.Genome))
+	}
+}
+
+func FuzzCrossPoint(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4, 5}, 2, []byte{}, int64(1))
+	f.Add([]byte{1, 2}, 10, []byte{}, int64(2))
+	f.Add([]byte{}, 1, []byte{}, int64(3))
+	f.Add([]byte{1, 2, 3, 4, 5, 6}, -1, []byte{2, 3, 9}, int64(4))
+	f.Fuzz(func(t *testing.T, data []byte, nbPoints int, blocks []byte, seed int64) {
+		var (
+			p1, p2 = fuzzParents(data, intGene)
+			cross  = CrossPoint{NbPoints: nbPoints}
+		)
+		for _, b := range blocks {
+			cross.Blocks = append(cross.Blocks, int(b%8)+1)
+		}
+		var o1, o2 = cross.Apply(p1, p2, fuzzRand(seed))
+		checkOffsprings(t, p1, o1, o2)
+		var (
+			g1 = IndividualOf[int]{Genome: make([]int, len(data))}
+			g2 = IndividualOf[int]{Genome: make([]int, len(data))}
+		)
+		var c1, c2 = CrossPointOf[int]{NbPoints: nbPoints}.Apply(g1, g2, fuzzRand(seed))
+		if len(c1.Genome) != len(data) || len(c2.Genome) != len(data) {
+			t.Error("CrossPointOf produced offsprings of the wrong length")
+		}
+	})
+}
+
+func FuzzCrossUniform(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4}, []byte{}, int64(1))
+	f.Add([]byte{}, []byte{1}, int64(2))
+	f.Add([]byte{1, 2, 3, 4, 5}, []byte{2, 10}, int64(3))
+	f.Fuzz(func(t *testing.T, data []byte, blocks []byte, seed int64) {
+		var (
+			p1, p2 = fuzzParents(data, boolGene)
+			cross  = CrossUniform{}
+		)
+		for _, b := range blocks {
+			cross.Blocks = append(cross.Blocks, int(b%8)+1)
+		}
+		var o1, o2 = cross.Apply(p1, p2, fuzzRand(seed))
+		checkOffsprings(t, p1, o1, o2)
+		p1, p2 = fuzzParents(data, floatGene)
+		o1, o2 = CrossUniformF{Blocks: cross.Blocks}.Apply(p1, p2, fuzzRand(seed))
+		checkOffsprings(t, p1, o1, o2)
+	})
+}
+
+func FuzzCrossFloat(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, 0.5, int64(1))
+	f.Add([]byte{}, 0.0, int64(2))
+	f.Add([]byte{255}, -1.0, int64(3))
+	f.Fuzz(func(t *testing.T, data []byte, alpha float64, seed int64) {
+		var p1, p2 = fuzzParents(data, floatGene)
+		for _, cross := range []Crossover{
+			CrossArithmeticF{},
+			CrossHeuristicF{},
+			CrossBLXF{Alpha: alpha},
+		} {
+			var o1, o2 = cross.Apply(p1, p2, fuzzRand(seed))
+			checkOffsprings(t, p1, o1, o2)
+		}
+	})
+}
+
+func FuzzCrossSegmentI(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, 0, 10, int64(1))
+	f.Add([]byte{}, 0, 10, int64(2))
+	f.Add([]byte{5}, 10, 0, int64(3))
+	f.Fuzz(func(t *testing.T, data []byte, lower, upper int, seed int64) {
+		var (
+			p1, p2 = fuzzParents(data, intGene)
+			o1, o2 = CrossSegmentI{Lower: lower, Upper: upper}.Apply(p1, p2, fuzzRand(seed))
+		)
+		checkOffsprings(t, p1, o1, o2)
+	})
+}
+
+func FuzzCrossPMX(f *testing.F) {
+	f.Add(uint8(5), int64(1))
+	f.Add(uint8(0), int64(2))
+	f.Add(uint8(1), int64(3))
+	f.Add(uint8(2), int64(4))
+	f.Fuzz(func(t *testing.T, n uint8, seed int64) {
+		var (
+			rng = fuzzRand(seed)
+			p1  = Individual{Genome: make(Genome, n)}
+			p2  = Individual{Genome: make(Genome, n)}
+		)
+		for i, j := range rng.Perm(int(n)) {
+			p1.Genome[i] = i
+			p2.Genome[j] = i
+		}
+		var o1, o2 = CrossPMX{}.Apply(p1, p2, rng)
+		checkOffsprings(t, p1, o1, o2)
+		// The offsprings are permutations
+		for _, o := range []Individual{o1, o2} {
+			var seen = make([]bool, n)
+			for _, gene := range o.Genome {
+				seen[gene.(int)] = true
+			}
+			for _, s := range seen {
+				if !s {
+					t.Errorf("CrossPMX produced %v which isn't a permutation", o.Genome)
+					break
+				}
+			}
+		}
+		var g1, g2 = CrossPMXOf[int]{}.Apply(IndividualOf[int]{Genome: rng.Perm(int(n))}, IndividualOf[int]{Genome: rng.Perm(int(n))}, rng)
+		if len(g1.Genome) != int(n) || len(g2.Genome) != int(n) {
+			t.Error("CrossPMXOf produced offsprings of the wrong length")
+		}
+	})
+}
+
+func FuzzMutators(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4}, 2, 0.5, int64(1))
+	f.Add([]byte{}, 0, 0.0, int64(2))
+	f.Add([]byte{1}, -3, 1.0, int64(3))
+	f.Add([]byte{1, 2}, 1, 2.0, int64(4))
+	f.Fuzz(func(t *testing.T, data []byte, max int, rate float64, seed int64) {
+		var testCases = []struct {
+			mut  Mutator
+			gene func(b byte) interface{}
+		}{
+			{MutNormalF{Rate: rate, Std: 1}, floatGene},
+			{MutFlipB{Rate: rate}, boolGene},
+			{MutSplice{}, intGene},
+			{MutPermute{Max: max}, intGene},
+			{MutUniformS{Corpus: []string{"a", "b"}}, stringGene},
+			{MutUniformS{}, stringGene},
+		}
+		for _, test := range testCases {
+			var (
+				indi, _ = fuzzParents(data, test.gene)
+				genes   = make(map[interface{}]int)
+			)
+			for _, gene := range indi.Genome {
+				genes[gene]++
+			}
+			test.mut.Apply(&indi, fuzzRand(seed))
+			if len(indi.Genome) != len(data) {
+				t.Errorf("%T changed the length of the genome from %d to %d", test.mut, len(data), len(indi.Genome))
+			}
+			// MutSplice and MutPermute only reorder the genes
+			switch test.mut.(type) {
+			case MutSplice, MutPermute:
+				var after = make(map[interface{}]int)
+				for _, gene := range indi.Genome {
+					after[gene]++
+				}
+				if !reflect.DeepEqual(genes, after) {
+					t.Errorf("%T didn't preserve the genes", test.mut)
+				}
+			}
+		}
+		var indi = IndividualOf[int]{Genome: make([]int, len(data))}
+		MutPermuteOf[int]{Max: max}.Apply(&indi, fuzzRand(seed))
+	})
+}
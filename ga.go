@@ -2,9 +2,11 @@ package gago
 
 import (
 	"errors"
+	"fmt"
 	"log"
+	"math"
 	"math/rand"
-	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -22,11 +24,40 @@ type GA struct {
 	NbrIndividuals int // Initial number of individuals in each population
 	NbrPopulations int // Number of populations
 
+	// Optional parameters
+	Archive         *ParetoArchive   // Archive of the non-dominated individuals, updated at each generation
+	Comparator      Comparator       // Order of the individuals, used to sort the populations and to find the best individual
+	Debug           *Debug           // Checks the genomes produced by each crossover and mutation, which helps finding faulty operators
+	Deduplicate     bool             // Evaluate the individuals of a generation that have the same genome only once
+	EventLog        *EventLog        // Record of the random numbers drawn during the run, which can be replayed
+	Guard           *FitnessGuard    // Handling of the fitnesses that are NaN or infinite
+	HallOfFame      *HallOfFame      // Best individuals found during the run, updated at each generation
+	History         *BestHistory     // Genome of the best individual recorded every few generations
+	Lineage         *Lineage         // Record of how each individual was created
+	MemoryLimit     int64            // Number of bytes the individuals are allowed to use according to EstimateMemory, checked by Validate
+	Models          []Model          // Model of each population, the i-th population uses the model i modulo the number of models
+	Pressure        *PressureMonitor // Estimate of the selection pressure of each population, updated at each generation
+	Profile         bool             // Measure the time spent in each phase of the generation loop, see Timings
+	Ranker          Ranker           // Order of the populations applied after sorting them, for example StochasticRanking
+	Restarter       Restarter        // Restart policy applied when the GA stagnates
+	Seed            int64            // Seed of the random number generators of the populations, the current time is used if 0
+	Shards          int              // Number of OS threads the populations are evolved on, one goroutine per population if 0
+	Sizer           PopulationSizer  // Schedule of the number of individuals in each population
+	StagnationLimit int              // Number of generations without improvement after which the Restarter is applied
+	Tabu            *TabuList        // Genomes of the last generations that the offsprings are not allowed to have
+
 	// Parameters that are generated at runtime
-	Best        Individual // Overall best individual (dummy initialization at the beginning)
 	Duration    time.Duration
+	Evaluations int // Number of times the fitness function was applied by the populations
 	Generations int
 	Populations Populations
+	Restarts    int // Number of times the Restarter has been applied
+	Stagnation  int // Number of generations since the best individual last improved
+	evaluations *int64
+	ipopSize    int // Number of individuals in each population set by RestartIPOP, 0 until it's applied
+	profiler    *profiler
+	best        atomic.Value // Overall best individual, accessed through the Best method
+	lastID      int          // ID given to the last individual that joined a population
 }
 
 // Validate the parameters of a GA to ensure it will run correctly. Some
@@ -36,22 +67,51 @@ func (ga GA) Validate() error {
 	if ga.Ff == nil {
 		return errors.New("'Ff' cannot be nil")
 	}
+	// Check the fitness function, if it can be validated
+	if ff, ok := ga.Ff.(interface{ Validate() error }); ok {
+		if err := ff.Validate(); err != nil {
+			return err
+		}
+	}
+	// Check the genomes can be split into the groups of bits of a
+	// GrayFunction
+	if gray, ok := ga.Ff.(GrayFunction); ok && ga.NbrGenes%gray.Bits != 0 {
+		return fmt.Errorf("'NbrGenes' should be a multiple of the %d bits of the GrayFunction", gray.Bits)
+	}
 	// Check the initialization method presence
 	if ga.Initializer == nil {
 		return errors.New("'Initializer' cannot be nil")
 	}
+	// Check the Sobol sequence can handle the number of genes
+	if _, ok := ga.Initializer.(*InitSobolF); ok {
+		if err := checkSobolDim(ga.NbrGenes); err != nil {
+			return err
+		}
+	}
+	// Check the initializer, if it can be validated
+	if init, ok := ga.Initializer.(interface{ Validate() error }); ok {
+		if err := init.Validate(); err != nil {
+			return err
+		}
+	}
 	// Check the migration frequency in the presence of a migrator
 	if ga.Migrator != nil && ga.MigFrequency < 1 {
 		return errors.New("'MigFrequency' should be strictly higher than 0")
 	}
-	// Check the model presence
-	if ga.Model == nil {
+	// Check the model presence, the model isn't required if each population
+	// has it's own
+	if ga.Model == nil && len(ga.Models) == 0 {
 		return errors.New("'Model' cannot be nil")
 	}
-	// Check the model is valid
-	var modelErr = ga.Model.Validate()
-	if modelErr != nil {
-		return modelErr
+	// Check the models are valid
+	for _, model := range append([]Model{ga.Model}, ga.Models...) {
+		if model == nil {
+			continue
+		}
+		var modelErr = model.Validate()
+		if modelErr != nil {
+			return modelErr
+		}
 	}
 	// Check the number of clusters
 	if ga.NbrClusters < 0 {
@@ -61,6 +121,18 @@ func (ga GA) Validate() error {
 	if ga.NbrGenes < 1 {
 		return errors.New("'NbrGenes' should be higher or equal to 1")
 	}
+	// Check the per gene parameters of the mutators match the number of genes
+	for _, model := range append([]Model{ga.Model}, ga.Models...) {
+		if model == nil {
+			continue
+		}
+		if err := checkModelGenes(model, ga.NbrGenes); err != nil {
+			return err
+		}
+		if err := checkModelOperators(model); err != nil {
+			return err
+		}
+	}
 	// Check the number of individuals
 	if ga.NbrIndividuals < 2 {
 		return errors.New("'NbrIndividuals' should be higher or equal to 2")
@@ -69,6 +141,18 @@ func (ga GA) Validate() error {
 	if ga.NbrPopulations < 1 {
 		return errors.New("'NbrPopulations' should be higher or equal to 1")
 	}
+	// Check the number of shards
+	if ga.Shards < 0 {
+		return errors.New("'Shards' should be higher or equal to 1 if provided")
+	}
+	// Check the stagnation limit in the presence of a restarter
+	if ga.Restarter != nil && ga.StagnationLimit < 1 {
+		return errors.New("'StagnationLimit' should be higher or equal to 1")
+	}
+	// Check the individuals fit in the memory budget
+	if err := ga.checkMemory(); err != nil {
+		return err
+	}
 	// No error
 	return nil
 }
@@ -85,42 +169,233 @@ func (ga *GA) Initialize() {
 	// Reset the number of generations and the elapsed duration
 	ga.Generations = 0
 	ga.Duration = 0
+	ga.Restarts = 0
+	ga.Stagnation = 0
+	ga.ipopSize = 0
+	// Count the evaluations made by the populations and number the individuals
+	// from 1
+	ga.Evaluations = 0
+	ga.lastID = 0
+	var ff = ga.countedFunction()
+	// Reset the fitness function if it's scheduled
+	ga.scheduleFunction()
+	// Start a new lineage and a new hall of fame
+	if ga.Lineage != nil {
+		ga.Lineage.reset()
+	}
+	if ga.HallOfFame != nil {
+		ga.HallOfFame.reset()
+	}
+	if ga.Pressure != nil {
+		ga.Pressure.reset()
+	}
+	if ga.History != nil {
+		ga.History.reset()
+	}
+	if ga.Tabu != nil {
+		ga.Tabu.reset()
+	}
+	if ga.Guard != nil {
+		ga.Guard.reset()
+	}
+	// Draw the seed of each population
+	var seeds = make([]int64, ga.NbrPopulations)
+	for i := range seeds {
+		seeds[i] = time.Now().UnixNano() + int64(i)
+	}
+	if ga.Seed != 0 {
+		var rng = rand.New(rand.NewSource(ga.Seed))
+		for i := range seeds {
+			seeds[i] = rng.Int63()
+		}
+	}
+	// Create the random number sources, which record or replay the values
+	// they produce if there is an event log
+	var sources = make([]rand.Source, ga.NbrPopulations)
+	for i := range sources {
+		sources[i] = rand.NewSource(seeds[i])
+	}
+	if ga.EventLog != nil {
+		sources = ga.EventLog.start(sources)
+	}
 	// Create the populations
 	ga.Populations = make([]Population, ga.NbrPopulations)
-	var wg sync.WaitGroup
-	for i := range ga.Populations {
-		wg.Add(1)
-		go func(j int) {
-			defer wg.Done()
-			// Generate a population
-			ga.Populations[j] = makePopulation(
-				ga.NbrIndividuals,
-				ga.NbrGenes,
-				ga.Ff,
-				ga.Initializer,
-			)
-			// Evaluate it's individuals
-			ga.Populations[j].Individuals.Evaluate(ga.Ff)
-			// Sort it's individuals
-			ga.Populations[j].Individuals.Sort()
-		}(i)
-	}
-	wg.Wait()
-	// Best individual (dummy initialization)
-	ga.Best = makeIndividual(ga.NbrGenes, rand.New(rand.NewSource(time.Now().UnixNano())))
+	ga.parallel(func(j int) {
+		// Generate a population
+		ga.Populations[j] = makeSourcedPopulation(
+			ga.NbrIndividuals,
+			ga.NbrGenes,
+			ff,
+			ga.Initializer,
+			sources[j],
+		)
+		ga.Populations[j].cmp = ga.Comparator
+		// Record the individuals in the lineage
+		if ga.Lineage != nil {
+			for k := range ga.Populations[j].Individuals {
+				ga.Lineage.record(&ga.Populations[j].Individuals[k], "initialization")
+			}
+		}
+		// Evaluate it's individuals
+		ga.Populations[j].Individuals.Evaluate(ff)
+		ga.Populations[j].regenerate(ga.NbrGenes, ga.Initializer)
+		ga.Populations[j].Individuals.promote(ff)
+		// Sort it's individuals
+		ga.Populations[j].Individuals.SortWith(ga.Comparator)
+		ga.rank(&ga.Populations[j])
+	})
+	// Adapt a scheduled Comparator to the initial populations and sort them
+	// again with it
+	if ga.schedule() {
+		for i := range ga.Populations {
+			ga.Populations[i].Individuals.SortWith(ga.Comparator)
+			ga.rank(&ga.Populations[i])
+		}
+	}
+	ga.stamp()
+	ga.updateTabu()
+	// Archive the non-dominated individuals and the best individuals
+	ga.updateArchive()
+	ga.updateHallOfFame()
+	// Start from the best individual of the first population, a dummy
+	// individual with an infinite fitness might not be worse according to the
+	// Comparator
+	ga.setBest(ga.Populations[0].Individuals[0])
 	// Find the best individual
 	ga.findBest()
+	ga.updatePressure()
+	ga.Evaluations = int(atomic.LoadInt64(ga.evaluations))
+	ga.updateHistory()
+}
+
+// Return a fitness function that counts the evaluations made by the
+// populations, the counter and the profiler of the GA are reset.
+func (ga *GA) countedFunction() countedFunction {
+	ga.evaluations = new(int64)
+	ga.profiler = nil
+	if ga.Profile {
+		ga.profiler = newProfiler(ga.Ff)
+	}
+	return countedFunction{ga.Ff, ga.evaluations, ga.profiler, ga.Deduplicate, ga.Guard}
+}
+
+// Best returns the overall best individual. The best individual is published
+// atomically, hence Best can be called from another goroutine while Enhance is
+// running, for example to report the progress of a run. The returned
+// individual doesn't share it's genome with the individuals of the
+// populations.
+func (ga *GA) Best() Individual {
+	if best, ok := ga.best.Load().(Individual); ok {
+		return best
+	}
+	return Individual{Fitness: math.Inf(1)}
+}
+
+// Publish a copy of an individual as the overall best individual.
+func (ga *GA) setBest(indi Individual) {
+	var genome = make(Genome, len(indi.Genome))
+	copy(genome, indi.Genome)
+	indi.Genome = genome
+	ga.best.Store(indi)
+}
+
+// Update the archive, if there is one, with the individuals of each population.
+func (ga *GA) updateArchive() {
+	if ga.Archive != nil {
+		for _, pop := range ga.Populations {
+			ga.Archive.Update(pop.Individuals)
+		}
+	}
+}
+
+// Rank the individuals of a population with the Ranker, if there is one.
+func (ga *GA) rank(pop *Population) {
+	if ga.Ranker != nil {
+		ga.Ranker.Rank(pop.Individuals, pop.rng)
+	}
+}
+
+// Update the Comparator and the fitness function if they change as the run
+// progresses, true is returned if the Comparator does.
+func (ga *GA) schedule() bool {
+	ga.scheduleFunction()
+	var sc, ok = ga.Comparator.(scheduledComparator)
+	if ok {
+		sc.schedule(ga.Generations, int(atomic.LoadInt64(ga.evaluations)), ga.Populations)
+	}
+	return ok
+}
+
+// Update the fitness function if it changes as the run progresses.
+func (ga *GA) scheduleFunction() {
+	if sf, ok := ga.Ff.(scheduledFunction); ok {
+		sf.schedule(ga.Generations, int(atomic.LoadInt64(ga.evaluations)), ga.Populations)
+	}
+}
+
+// Update the estimate of the selection pressure, if there is one, with the
+// current generation.
+func (ga *GA) updatePressure() {
+	if ga.Pressure != nil {
+		ga.Pressure.update(ga.Populations)
+	}
+}
+
+// Record the best individual in the history, if there is one.
+func (ga *GA) updateHistory() {
+	if ga.History != nil {
+		ga.History.update(ga)
+	}
+}
+
+// Give an ID, a birth generation and an origin to an individual that joined
+// the p-th population.
+func (ga *GA) stampIndividual(indi *Individual, p int) {
+	ga.lastID++
+	indi.ID = ga.lastID
+	indi.Birth = ga.Generations
+	indi.Origin = p
+}
+
+// Stamp the individuals of the populations that don't have an ID yet, which
+// are the individuals that joined the populations since the last call.
+func (ga *GA) stamp() {
+	for p := range ga.Populations {
+		var indis = ga.Populations[p].Individuals
+		for i := range indis {
+			if indis[i].ID == 0 {
+				ga.stampIndividual(&indis[i], p)
+			}
+		}
+	}
 }
 
 // Find the best individual in each population and then compare the best overall
-// individual to the current best individual.
-func (ga *GA) findBest() {
+// individual to the current best individual. Returns true if the current best
+// individual was improved upon.
+func (ga *GA) findBest() bool {
+	var (
+		best     = ga.Best()
+		improved = false
+	)
 	for _, pop := range ga.Populations {
-		var best = pop.Individuals[0]
-		if best.Fitness < ga.Best.Fitness {
-			ga.Best = best
+		if less(ga.Comparator, pop.Individuals[0], best) {
+			best = pop.Individuals[0]
+			improved = true
 		}
 	}
+	if improved {
+		ga.setBest(best)
+	}
+	return improved
+}
+
+// Return the model of the i-th population.
+func (ga GA) populationModel(i int) Model {
+	if len(ga.Models) == 0 {
+		return ga.Model
+	}
+	return ga.Models[i%len(ga.Models)]
 }
 
 // Enhance each population in the GA. The population level operations are done
@@ -128,41 +403,158 @@ func (ga *GA) findBest() {
 // run, the GA level operations are run.
 func (ga *GA) Enhance() {
 	var start = time.Now()
+	// Remember the timings so far to measure the ones of this generation
+	var timings Timings
+	if ga.profiler != nil {
+		timings = ga.profiler.timings()
+	}
 	// Increment the generations counter at the beginning to not migrate at generation 0
 	ga.Generations++
+	// Update the Comparator and the fitness function if they're scheduled,
+	// before they're used by the migrator and the models
+	ga.schedule()
 	// Migrate the individuals between the populations if there are enough
 	// populations, there is a migrator and the migration frequency divides the
-	// generation count
-	if ga.NbrPopulations > 1 && ga.Migrator != nil && ga.Generations%ga.MigFrequency == 0 {
+	// generation count; a single population can still exchange individuals
+	// with the populations of other processes through a RemoteMigrator
+	if ga.Migrator != nil && (ga.NbrPopulations > 1 || isRemote(ga.Migrator)) && ga.Generations%ga.MigFrequency == 0 {
 		ga.Migrator.Apply(ga.Populations)
 	}
-	// Use a wait group to enhance the populations in parallel
-	var wg sync.WaitGroup
-	for i := range ga.Populations {
-		wg.Add(1)
-		go func(j int) {
-			defer wg.Done()
-			// Apply clustering if a number of clusters has been given
-			if ga.NbrClusters > 0 {
-				var clusters = ga.Populations[j].cluster(ga.NbrClusters)
-				for k := range clusters {
-					// Apply the evolution model to the cluster
-					ga.Model.Apply(&clusters[k])
-				}
-				// Merge each cluster back into the original population
-				ga.Populations[j].Individuals = clusters.merge()
-			} else {
-				// Else apply the evolution model to the entire population
-				ga.Model.Apply(&ga.Populations[j])
-			}
-			// Evaluate and sort
-			ga.Populations[j].Individuals.Evaluate(ga.Ff)
-			ga.Populations[j].Individuals.Sort()
-			ga.Populations[j].Duration += time.Since(start)
-		}(i)
+	// Update the guided mutators with the populations, record the offsprings
+	// in the lineage, time the operators of the models and check their
+	// offsprings if required
+	var models = make([]Model, len(ga.Populations))
+	if ga.Lineage != nil {
+		ga.Lineage.setGeneration(ga.Generations)
+	}
+	for i := range models {
+		models[i] = guideModel(ga.populationModel(i), i, ga.Populations[i].Individuals)
+		models[i] = bindModel(models[i], ga.Populations[i].ff)
+		if ga.Lineage != nil {
+			models[i] = traceModel(models[i], ga.Lineage)
+		}
+		if ga.profiler != nil {
+			models[i] = profileModel(models[i], ga.profiler)
+		}
+		if ga.Debug != nil {
+			models[i] = debugModel(models[i], ga.Debug)
+		}
 	}
-	wg.Wait()
+	// Enhance the populations in parallel
+	ga.parallel(func(j int) {
+		var (
+			model  = models[j]
+			before = copyIndividual(ga.Populations[j].Individuals[0])
+		)
+		// Apply clustering if a number of clusters has been given
+		if ga.NbrClusters > 0 {
+			var clusters = ga.Populations[j].cluster(ga.NbrClusters)
+			for k := range clusters {
+				// Apply the evolution model to the cluster
+				model.Apply(&clusters[k])
+			}
+			// Merge each cluster back into the original population
+			ga.Populations[j].Individuals = clusters.merge()
+		} else {
+			// Else apply the evolution model to the entire population
+			model.Apply(&ga.Populations[j])
+		}
+		// Replace the offsprings whose genome is tabu
+		if ga.Tabu != nil {
+			ga.Tabu.filter(&ga.Populations[j], ga.NbrGenes, ga.Initializer)
+		}
+		// Evaluate and sort
+		ga.Populations[j].Individuals.Evaluate(ga.Populations[j].ff)
+		ga.Populations[j].regenerate(ga.NbrGenes, ga.Initializer)
+		ga.Populations[j].Individuals.promote(ga.Populations[j].ff)
+		ga.Populations[j].Individuals.SortWith(ga.Comparator)
+		// Check if the best individual of the population improved
+		if less(ga.Comparator, ga.Populations[j].Individuals[0], before) {
+			ga.Populations[j].Stagnation = 0
+		} else {
+			ga.Populations[j].Stagnation++
+		}
+		ga.rank(&ga.Populations[j])
+		// Resize the population if a schedule has been given
+		if ga.Sizer != nil {
+			ga.Populations[j].resize(ga.Sizer.Apply(ga.Generations), ga.NbrGenes, ga.Initializer)
+		}
+		ga.Populations[j].Duration += time.Since(start)
+	})
+	ga.stamp()
+	ga.updateTabu()
+	// Archive the non-dominated individuals and the best individuals
+	ga.updateArchive()
+	ga.updateHallOfFame()
 	// Check if there is an individual that is better than the current one
-	ga.findBest()
+	if ga.findBest() {
+		ga.Stagnation = 0
+	} else {
+		ga.Stagnation++
+	}
+	// Restart the GA if it has stagnated for too long
+	if ga.Restarter != nil && ga.Stagnation >= ga.StagnationLimit {
+		ga.Restarter.Apply(ga)
+		ga.Restarts++
+		ga.Stagnation = 0
+		ga.stamp()
+		ga.findBest()
+	}
+	ga.updatePressure()
+	ga.Evaluations = int(atomic.LoadInt64(ga.evaluations))
+	ga.updateHistory()
+	if ga.profiler != nil {
+		ga.profiler.generation = ga.profiler.timings().sub(timings)
+	}
 	ga.Duration += time.Since(start)
 }
+
+// EnhanceBudget runs generations until the fitness function has been applied
+// at least maxEvaluations times since the GA was initialized and returns the
+// statistics of the GA. The budget is checked between generations, hence it
+// can be exceeded by the number of evaluations of the last generation.
+// Expressing the budget in evaluations rather than in generations makes runs
+// comparable across models and population sizes. EnhanceBudget also stops
+// after a generation that didn't evaluate any individual, for example because
+// the model only keeps evaluated individuals or because every genome was
+// found in a cache, since the budget would never be exhausted otherwise.
+func (ga *GA) EnhanceBudget(maxEvaluations int) Stats {
+	for ga.Evaluations < maxEvaluations {
+		var evaluations = ga.Evaluations
+		ga.Enhance()
+		if ga.Evaluations == evaluations {
+			break
+		}
+	}
+	return ga.Stats()
+}
+
+// EnhanceFor runs generations until d has elapsed and returns the statistics
+// of the GA. A generation is never interrupted, hence the last generation is
+// completed even if it ends after the deadline.
+func (ga *GA) EnhanceFor(d time.Duration) Stats {
+	var deadline = time.Now().Add(d)
+	for time.Now().Before(deadline) {
+		ga.Enhance()
+	}
+	return ga.Stats()
+}
+
+// EvaluateAll evaluates the individuals of each population that haven't been
+// evaluated yet, the populations being evaluated in parallel, and returns the
+// fitnesses and the errors of the individuals of each population, see
+// Population.Evaluate: the j-th fitness of the i-th slice is the fitness of
+// the j-th individual of the i-th population. The evaluations are counted but
+// the populations aren't sorted and the best individual isn't updated, which
+// is left to the loops that use EvaluateAll instead of Enhance.
+func (ga *GA) EvaluateAll() ([][]float64, [][]error) {
+	var (
+		fitnesses = make([][]float64, len(ga.Populations))
+		errs      = make([][]error, len(ga.Populations))
+	)
+	ga.parallel(func(i int) {
+		fitnesses[i], errs[i] = ga.Populations[i].Evaluate(nil)
+	})
+	ga.Evaluations = int(atomic.LoadInt64(ga.evaluations))
+	return fitnesses, errs
+}
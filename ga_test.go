@@ -1,7 +1,9 @@
 package gago
 
 import (
+	"errors"
 	"math"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -68,7 +70,7 @@ func TestValidationNbrClusters(t *testing.T) {
 	if ga.Validate() == nil {
 		t.Error("Invalid number of clusters didn't return an error")
 	}
-	ga.NbrGenes = nbGenes
+	ga.NbrClusters = 0
 }
 
 func TestValidationNbrGenes(t *testing.T) {
@@ -113,6 +115,41 @@ func TestValidationModel(t *testing.T) {
 	if ga.Validate() == nil {
 		t.Error("Nil model didn't return an error")
 	}
+	// Check the models of the populations replace the model
+	ga.Models = []Model{model}
+	if ga.Validate() != nil {
+		t.Error("Nil model returned an error although the populations have models")
+	}
+	// Check the models of the populations are validated
+	ga.Models = []Model{ModGenerational{}}
+	if ga.Validate() == nil {
+		t.Error("Invalid population model didn't return an error")
+	}
+	ga.Models = nil
+	ga.Model = model
+}
+
+func TestValidationPerGeneMutator(t *testing.T) {
+	// Check the per gene parameters of the mutators match the number of genes
+	var rates = make([]float64, nbGenes+1)
+	ga.Model = ModGenerational{
+		Selector:  SelTournament{NbParticipants: 3},
+		Crossover: CrossUniformF{},
+		Mutator:   MutGaussianGenesF{Rates: rates, Stds: []float64{1}},
+		MutRate:   0.5,
+	}
+	if ga.Validate() == nil {
+		t.Error("Invalid number of mutation rates didn't return an error")
+	}
+	ga.Model = ModGenerational{
+		Selector:  SelTournament{NbParticipants: 3},
+		Crossover: CrossUniformF{},
+		Mutator:   MutGaussianGenesF{Rates: rates[:nbGenes], Stds: []float64{1}},
+		MutRate:   0.5,
+	}
+	if ga.Validate() != nil {
+		t.Error("Valid mutation rates returned an error")
+	}
 	ga.Model = model
 }
 
@@ -134,6 +171,15 @@ func TestValidationMigFrequency(t *testing.T) {
 	ga.MigFrequency = migFrequency
 }
 
+func TestValidationStagnationLimit(t *testing.T) {
+	// Check the stagnation limit in the presence of a restarter
+	ga.Restarter = RestartFull{}
+	if ga.Validate() == nil {
+		t.Error("Invalid stagnation limit didn't return an error")
+	}
+	ga.Restarter = nil
+}
+
 func TestSizes(t *testing.T) {
 	// Number of Populations
 	if len(ga.Populations) != nbPopulations {
@@ -178,7 +224,7 @@ func TestSorted(t *testing.T) {
 func TestBest(t *testing.T) {
 	for _, pop := range ga.Populations {
 		for _, indi := range pop.Individuals {
-			if ga.Best.Fitness > indi.Fitness {
+			if ga.Best().Fitness > indi.Fitness {
 				t.Error("The current best individual is not the overall best")
 			}
 		}
@@ -188,11 +234,12 @@ func TestBest(t *testing.T) {
 func TestFindBest(t *testing.T) {
 	ga.Populations[0].Individuals[0].Fitness = math.Inf(-1)
 	ga.findBest()
-	if ga.Best.Fitness != math.Inf(-1) {
+	if ga.Best().Fitness != math.Inf(-1) {
 		t.Error("findBest didn't work")
 	}
-	ga.Best.Fitness = 42
-	if ga.Populations[0].Individuals[0].Fitness == 42 {
+	var best = ga.Best()
+	best.Genome[0] = 42.0
+	if ga.Populations[0].Individuals[0].Genome[0] == 42.0 {
 		t.Error("Best individual is linked to an individual")
 	}
 }
@@ -216,8 +263,264 @@ func TestDuration(t *testing.T) {
 	}
 }
 
+func TestEvaluations(t *testing.T) {
+	var g = GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+	}
+	g.Initialize()
+	if g.Evaluations != nbPopulations*nbIndividuals {
+		t.Error("Initialize didn't count the evaluations of the initial individuals")
+	}
+	g.EnhanceBudget(1000)
+	if g.Evaluations < 1000 {
+		t.Error("EnhanceBudget stopped before exhausting the budget")
+	}
+	if g.Evaluations >= 1000+nbPopulations*nbIndividuals {
+		t.Error("EnhanceBudget ran more generations than necessary")
+	}
+	g.Initialize()
+	if g.Evaluations != nbPopulations*nbIndividuals {
+		t.Error("Initialize didn't reset the evaluations counter")
+	}
+}
+
+// A modKeep keeps the individuals of a population as they are, hence it
+// doesn't evaluate anything.
+type modKeep struct{}
+
+func (mod modKeep) Apply(pop *Population) {}
+func (mod modKeep) Validate() error       { return nil }
+
+func TestEnhanceBudgetWithoutEvaluations(t *testing.T) {
+	var g = GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          modKeep{},
+	}
+	g.Initialize()
+	g.EnhanceBudget(1000)
+	if g.Generations != 1 {
+		t.Errorf("EnhanceBudget should stop after a generation without evaluations, it ran %d generations", g.Generations)
+	}
+}
+
+func TestBestConcurrentAccess(t *testing.T) {
+	var (
+		g = GA{
+			NbrPopulations: nbPopulations,
+			NbrIndividuals: nbIndividuals,
+			NbrGenes:       nbGenes,
+			Initializer:    initializer,
+			Ff:             ff,
+			Model:          model,
+		}
+		done = make(chan struct{})
+	)
+	if g.Best().Fitness != math.Inf(1) {
+		t.Error("The best individual of an uninitialized GA should have an infinite fitness")
+	}
+	g.Initialize()
+	go func() {
+		for i := 0; i < 10; i++ {
+			g.Enhance()
+		}
+		close(done)
+	}()
+	var previous = g.Best().Fitness
+	for {
+		select {
+		case <-done:
+			return
+		default:
+			var best = g.Best()
+			if best.Fitness > previous {
+				t.Fatal("The best individual got worse")
+			}
+			previous = best.Fitness
+		}
+	}
+}
+
+func TestPopulationEvaluate(t *testing.T) {
+	var (
+		failure = errors.New("failure")
+		errFF   = ErrFunction{
+			Image: func(genome Genome) (float64, error) {
+				if genome[0].(float64) > 0 {
+					return 0, failure
+				}
+				return genome[0].(float64), nil
+			},
+		}
+		pop = makePopulation(10, nbGenes, errFF, initializer)
+	)
+	var fitnesses, errs = pop.Evaluate(nil)
+	if len(fitnesses) != len(pop.Individuals) || len(errs) != len(pop.Individuals) {
+		t.Fatal("The results should be aligned with the individuals")
+	}
+	for i, indi := range pop.Individuals {
+		if fitnesses[i] != indi.Fitness || !indi.Evaluated {
+			t.Error("The fitnesses should be the ones of the individuals")
+		}
+		if (indi.Genome[0].(float64) > 0) != (errs[i] == failure) {
+			t.Error("The errors should be the ones of the failed evaluations")
+		}
+	}
+	// Another fitness function can be given
+	pop.Individuals[0].Evaluated = false
+	if fitnesses, _ = pop.Evaluate(ff); fitnesses[0] != ff.apply(pop.Individuals[0].Genome) {
+		t.Error("The given fitness function should be used")
+	}
+}
+
+func TestEvaluateAll(t *testing.T) {
+	var g = GA{
+		NbrPopulations: 3,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+	}
+	g.Initialize()
+	var evaluations = g.Evaluations
+	// Reset the first individual of each population
+	for i := range g.Populations {
+		g.Populations[i].Individuals[0].Evaluated = false
+	}
+	var fitnesses, errs = g.EvaluateAll()
+	if len(fitnesses) != 3 || len(errs) != 3 {
+		t.Fatal("There should be a slice per population")
+	}
+	for i, pop := range g.Populations {
+		for j, indi := range pop.Individuals {
+			if fitnesses[i][j] != indi.Fitness || errs[i][j] != nil {
+				t.Error("The fitnesses should be aligned with the individuals")
+			}
+		}
+	}
+	if g.Evaluations != evaluations+3 {
+		t.Errorf("Expected %d evaluations, got %d", evaluations+3, g.Evaluations)
+	}
+}
+
 func BenchmarkEnhance(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		ga.Enhance()
 	}
 }
+
+func BenchmarkEnhanceProfiled(b *testing.B) {
+	var g = GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+		Profile:        true,
+	}
+	g.Initialize()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Enhance()
+	}
+}
+
+func TestSeed(t *testing.T) {
+	var newGA = func() GA {
+		return GA{
+			Ff:             ff,
+			Initializer:    initializer,
+			Model:          model,
+			NbrGenes:       nbGenes,
+			NbrIndividuals: nbIndividuals,
+			NbrPopulations: 2,
+			Seed:           42,
+		}
+	}
+	var ga1, ga2 = newGA(), newGA()
+	ga1.Initialize()
+	ga2.Initialize()
+	for i := 0; i < 5; i++ {
+		ga1.Enhance()
+		ga2.Enhance()
+	}
+	for i := range ga1.Populations {
+		for j, indi := range ga1.Populations[i].Individuals {
+			if !reflect.DeepEqual(indi.Genome, ga2.Populations[i].Individuals[j].Genome) {
+				t.Fatal("GAs with the same seed should evolve the same individuals")
+			}
+		}
+	}
+	if reflect.DeepEqual(ga1.Populations[0].Individuals[0].Genome, ga1.Populations[1].Individuals[0].Genome) {
+		t.Error("Populations should have different seeds")
+	}
+}
+
+func TestStamp(t *testing.T) {
+	var ga = GA{
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          ModSteadyState{Selector: SelTournament{NbParticipants: 3}, Crossover: CrossUniformF{}, KeepBest: true},
+		NbrGenes:       nbGenes,
+		NbrIndividuals: nbIndividuals,
+		NbrPopulations: 2,
+		Migrator:       MigShuffle{},
+		MigFrequency:   2,
+	}
+	ga.Initialize()
+	var initial = make(map[int]Individual)
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			initial[indi.ID] = indi
+		}
+	}
+	for i := 0; i < 4; i++ {
+		ga.Enhance()
+	}
+	// A population can hold several copies of an individual, which share it's
+	// ID, hence the IDs are checked against the names
+	var (
+		names    = make(map[int]string)
+		migrated bool
+	)
+	for p, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			if name, ok := names[indi.ID]; indi.ID < 1 || indi.ID > ga.lastID || ok && name != indi.Name {
+				t.Errorf("Individual %s has an invalid ID %d", indi.Name, indi.ID)
+			}
+			names[indi.ID] = indi.Name
+			if indi.Birth < 0 || indi.Birth > ga.Generations || indi.Origin < 0 || indi.Origin > 1 {
+				t.Errorf("Individual %s has an invalid stamp", indi.Name)
+			}
+			// The individuals that survived since the initialization kept
+			// their stamp
+			if original, ok := initial[indi.ID]; ok && (indi.Birth != 0 || indi.Name != original.Name) {
+				t.Errorf("Individual %s didn't keep it's stamp", indi.Name)
+			}
+			if indi.Origin != p {
+				migrated = true
+			}
+		}
+	}
+	if !migrated {
+		t.Error("The migrated individuals should keep their origin")
+	}
+	if len(initial) != 2*nbIndividuals {
+		t.Error("Each individual should have it's own ID")
+	}
+	// A clone is a new individual
+	var clone = ga.Populations[0].Individuals[0].clone(ga.Populations[0].rng)
+	if clone.ID != 0 || clone.Birth != 0 || clone.Origin != 0 {
+		t.Error("A clone shouldn't keep the stamp of the original")
+	}
+}
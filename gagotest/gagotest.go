@@ -0,0 +1,179 @@
+// Package gagotest provides utilities for testing user-defined operators. The
+// checks apply an operator to genomes produced by a Generator and verify a
+// property holds, for example that a crossover produces valid permutations or
+// that a mutator keeps the genes within their bounds. Each check returns an
+// error describing the first genome that violates the property, hence they
+// can be used in tests as follows:
+//
+//	var gen = gagotest.Permutations(10)
+//	if err := gagotest.CheckCrossoverPermutations(MyCrossover{}, gen, rng); err != nil {
+//		t.Error(err)
+//	}
+package gagotest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+
+	"github.com/MaxHalford/gago"
+)
+
+// Trials is the number of times each check applies an operator.
+var Trials = 100
+
+// Count the occurrences of each gene of a genome, the genes have to be
+// comparable.
+func countGenes(genome gago.Genome) map[interface{}]int {
+	var counts = make(map[interface{}]int)
+	for _, gene := range genome {
+		counts[gene]++
+	}
+	return counts
+}
+
+// Copy a genome so that it can be compared after an operator was applied.
+func copyGenome(genome gago.Genome) gago.Genome {
+	var clone = make(gago.Genome, len(genome))
+	copy(clone, genome)
+	return clone
+}
+
+// CheckCrossoverPermutations checks that a crossover produces offsprings that
+// are permutations of the genes of the parents when the parents are
+// permutations of the same genes, which is what Permutations generates. It
+// also checks the parents aren't modified.
+func CheckCrossoverPermutations(cross gago.Crossover, gen Generator, rng *rand.Rand) error {
+	for i := 0; i < Trials; i++ {
+		var (
+			g1 = gen(rng)
+			g2 = make(gago.Genome, len(g1))
+		)
+		// The second parent is a shuffled version of the first one
+		for j, k := range rng.Perm(len(g1)) {
+			g2[j] = g1[k]
+		}
+		var (
+			c1, c2 = copyGenome(g1), copyGenome(g2)
+			counts = countGenes(g1)
+			o1, o2 = cross.Apply(Individual(g1), Individual(g2), rng)
+		)
+		if !reflect.DeepEqual(g1, c1) || !reflect.DeepEqual(g2, c2) {
+			return fmt.Errorf("the parents %v and %v were modified", c1, c2)
+		}
+		for _, o := range []gago.Individual{o1, o2} {
+			if !reflect.DeepEqual(countGenes(o.Genome), counts) {
+				return fmt.Errorf("the parents %v and %v produced %v which isn't a permutation of their genes", c1, c2, o.Genome)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckCrossoverLengths checks that a crossover produces offsprings that have
+// as many genes as the parents, which is the case of most crossovers on fixed
+// length genomes.
+func CheckCrossoverLengths(cross gago.Crossover, gen Generator, rng *rand.Rand) error {
+	for i := 0; i < Trials; i++ {
+		var (
+			g1, g2 = gen(rng), gen(rng)
+			o1, o2 = cross.Apply(Individual(copyGenome(g1)), Individual(copyGenome(g2)), rng)
+		)
+		if len(o1.Genome) != len(g1) || len(o2.Genome) != len(g2) {
+			return fmt.Errorf("the parents %v and %v produced offsprings of lengths %d and %d", g1, g2, len(o1.Genome), len(o2.Genome))
+		}
+	}
+	return nil
+}
+
+// Convert a numeric gene to a float64, the second value is false if the gene
+// isn't numeric.
+func toFloat(gene interface{}) (float64, bool) {
+	switch x := gene.(type) {
+	case float64:
+		return x, true
+	case int:
+		return float64(x), true
+	}
+	return 0, false
+}
+
+// CheckMutatorBounds checks that a mutator keeps the numeric genes, which are
+// float64 or int genes, within [lower, upper]. The genomes produced by gen
+// should respect the bounds.
+func CheckMutatorBounds(mut gago.Mutator, gen Generator, lower, upper float64, rng *rand.Rand) error {
+	for i := 0; i < Trials; i++ {
+		var (
+			genome = gen(rng)
+			before = copyGenome(genome)
+			indi   = Individual(genome)
+		)
+		mut.Apply(&indi, rng)
+		for _, gene := range indi.Genome {
+			var x, ok = toFloat(gene)
+			if !ok {
+				return fmt.Errorf("the mutation of %v produced %v which isn't numeric", before, gene)
+			}
+			if x < lower || x > upper {
+				return fmt.Errorf("the mutation of %v produced %v which is out of [%v, %v]", before, gene, lower, upper)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckCrossoverDeterminism checks that a crossover produces the same
+// offsprings when it's given the same parents and a random number generator
+// with the same seed, which is required for the runs to be reproducible. An
+// operator that uses the global random number generator or a map iteration
+// order fails the check.
+func CheckCrossoverDeterminism(cross gago.Crossover, gen Generator, rng *rand.Rand) error {
+	for i := 0; i < Trials; i++ {
+		var (
+			g1, g2   = gen(rng), gen(rng)
+			seed     = rng.Int63()
+			o1, o2   = cross.Apply(Individual(copyGenome(g1)), Individual(copyGenome(g2)), rand.New(rand.NewSource(seed)))
+			o1b, o2b = cross.Apply(Individual(copyGenome(g1)), Individual(copyGenome(g2)), rand.New(rand.NewSource(seed)))
+		)
+		if !reflect.DeepEqual(o1.Genome, o1b.Genome) || !reflect.DeepEqual(o2.Genome, o2b.Genome) {
+			return fmt.Errorf("the parents %v and %v produced different offsprings with the same seed", g1, g2)
+		}
+	}
+	return nil
+}
+
+// CheckMutatorDeterminism checks that a mutator produces the same genome when
+// it's given the same individual and a random number generator with the same
+// seed.
+func CheckMutatorDeterminism(mut gago.Mutator, gen Generator, rng *rand.Rand) error {
+	for i := 0; i < Trials; i++ {
+		var (
+			genome = gen(rng)
+			seed   = rng.Int63()
+			a      = Individual(copyGenome(genome))
+			b      = Individual(copyGenome(genome))
+		)
+		mut.Apply(&a, rand.New(rand.NewSource(seed)))
+		mut.Apply(&b, rand.New(rand.NewSource(seed)))
+		if !reflect.DeepEqual(a.Genome, b.Genome) {
+			return fmt.Errorf("the mutation of %v produced different genomes with the same seed", genome)
+		}
+	}
+	return nil
+}
+
+// CheckInitializerBounds checks that an initializer produces numeric genes
+// within [lower, upper] for genomes of n genes.
+func CheckInitializerBounds(init gago.Initializer, n int, lower, upper float64, rng *rand.Rand) error {
+	for i := 0; i < Trials; i++ {
+		var indi = Individual(make(gago.Genome, n))
+		init.Apply(&indi, rng)
+		for _, gene := range indi.Genome {
+			var x, ok = toFloat(gene)
+			if !ok || x < lower || x > upper {
+				return fmt.Errorf("the initializer produced %v which isn't a number in [%v, %v]", gene, lower, upper)
+			}
+		}
+	}
+	return nil
+}
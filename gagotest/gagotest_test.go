@@ -0,0 +1,92 @@
+package gagotest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+// mutOutOfBounds doubles the first gene of a genome.
+type mutOutOfBounds struct{}
+
+func (mut mutOutOfBounds) Apply(indi *gago.Individual, rng *rand.Rand) {
+	indi.Genome[0] = 2 * indi.Genome[0].(float64)
+}
+
+// mutGlobal uses the global random number generator instead of the one it's
+// given.
+type mutGlobal struct{}
+
+func (mut mutGlobal) Apply(indi *gago.Individual, rng *rand.Rand) {
+	indi.Genome[0] = rand.Float64()
+}
+
+func TestCheckCrossoverPermutations(t *testing.T) {
+	var (
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+		gen = Permutations(8)
+	)
+	if err := CheckCrossoverPermutations(gago.CrossPMX{}, gen, rng); err != nil {
+		t.Error(err)
+	}
+	// Point crossover doesn't preserve permutations
+	if err := CheckCrossoverPermutations(gago.CrossPoint{NbPoints: 1}, gen, rng); err == nil {
+		t.Error("CrossPoint shouldn't preserve permutations")
+	}
+}
+
+func TestCheckCrossoverLengths(t *testing.T) {
+	var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	if err := CheckCrossoverLengths(gago.CrossUniformF{}, Float64s(5, -1, 1), rng); err != nil {
+		t.Error(err)
+	}
+	var cross = gago.CrossLimit{Crossover: gago.CrossPoint{NbPoints: 1}, MaxSize: 10}
+	if err := CheckCrossoverLengths(cross, Ints(5, 0, 9), rng); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckMutatorBounds(t *testing.T) {
+	var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	if err := CheckMutatorBounds(gago.MutPermute{Max: 3}, Ints(6, -5, 5), -5, 5, rng); err != nil {
+		t.Error(err)
+	}
+	if err := CheckMutatorBounds(mutOutOfBounds{}, Float64s(3, 0.5, 1), 0, 1, rng); err == nil {
+		t.Error("Expected an error for a mutator that doesn't respect the bounds")
+	}
+	if err := CheckMutatorBounds(gago.MutFlipB{}, Bools(3), 0, 1, rng); err == nil {
+		t.Error("Expected an error for non-numeric genes")
+	}
+}
+
+func TestCheckDeterminism(t *testing.T) {
+	var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	if err := CheckCrossoverDeterminism(gago.CrossPMX{}, Permutations(6), rng); err != nil {
+		t.Error(err)
+	}
+	if err := CheckCrossoverDeterminism(gago.CrossUniformF{}, Float64s(4, 0, 1), rng); err != nil {
+		t.Error(err)
+	}
+	if err := CheckMutatorDeterminism(gago.MutNormalF{Rate: 0.5, Std: 1}, Float64s(4, 0, 1), rng); err != nil {
+		t.Error(err)
+	}
+	if err := CheckMutatorDeterminism(mutGlobal{}, Float64s(4, 0, 1), rng); err == nil {
+		t.Error("Expected an error for a mutator that uses the global generator")
+	}
+}
+
+func TestCheckInitializerBounds(t *testing.T) {
+	var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	if err := CheckInitializerBounds(gago.InitUniformF{Lower: -2, Upper: 3}, 5, -2, 3, rng); err != nil {
+		t.Error(err)
+	}
+	if err := CheckInitializerBounds(gago.InitGaussianF{Mean: 0, Std: 1}, 5, -0.1, 0.1, rng); err == nil {
+		t.Error("Expected an error for an initializer that doesn't respect the bounds")
+	}
+	if err := CheckInitializerBounds(gago.InitUniformI{Lower: 0, Upper: math.MaxInt8}, 5, 0, math.MaxInt8, rng); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,83 @@
+package gagotest
+
+import (
+	"math/rand"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A Generator produces random genomes, it's used to feed operators with
+// arbitrary inputs.
+type Generator func(rng *rand.Rand) gago.Genome
+
+// Float64s generates genomes of n float64 genes uniformly drawn from
+// [lower, upper].
+func Float64s(n int, lower, upper float64) Generator {
+	return func(rng *rand.Rand) gago.Genome {
+		var genome = make(gago.Genome, n)
+		for i := range genome {
+			genome[i] = lower + rng.Float64()*(upper-lower)
+		}
+		return genome
+	}
+}
+
+// Ints generates genomes of n int genes uniformly drawn from [lower, upper].
+func Ints(n, lower, upper int) Generator {
+	return func(rng *rand.Rand) gago.Genome {
+		var genome = make(gago.Genome, n)
+		for i := range genome {
+			genome[i] = lower + rng.Intn(upper-lower+1)
+		}
+		return genome
+	}
+}
+
+// Bools generates genomes of n bool genes that are true with probability 0.5.
+func Bools(n int) Generator {
+	return func(rng *rand.Rand) gago.Genome {
+		var genome = make(gago.Genome, n)
+		for i := range genome {
+			genome[i] = rng.Float64() < 0.5
+		}
+		return genome
+	}
+}
+
+// Strings generates genomes of n string genes uniformly drawn from a corpus.
+func Strings(n int, corpus []string) Generator {
+	return func(rng *rand.Rand) gago.Genome {
+		var genome = make(gago.Genome, n)
+		for i := range genome {
+			genome[i] = corpus[rng.Intn(len(corpus))]
+		}
+		return genome
+	}
+}
+
+// Permutations generates random permutations of the integers 0 to n-1, each
+// one being an int gene.
+func Permutations(n int) Generator {
+	return func(rng *rand.Rand) gago.Genome {
+		var genome = make(gago.Genome, n)
+		for i, p := range rng.Perm(n) {
+			genome[i] = p
+		}
+		return genome
+	}
+}
+
+// VariableLength generates genomes whose length is uniformly drawn from
+// [min, max], gen returns the Generator used for a given length. It's useful
+// to check that an operator handles every genome length, including the
+// smallest ones.
+func VariableLength(min, max int, gen func(n int) Generator) Generator {
+	return func(rng *rand.Rand) gago.Genome {
+		return gen(min + rng.Intn(max-min+1))(rng)
+	}
+}
+
+// Individual returns an unevaluated individual with a given genome.
+func Individual(genome gago.Genome) gago.Individual {
+	return gago.Individual{Genome: genome}
+}
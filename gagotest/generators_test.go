@@ -0,0 +1,49 @@
+package gagotest
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestGenerators(t *testing.T) {
+	var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 20; i++ {
+		for _, gene := range Float64s(5, -1, 1)(rng) {
+			if x := gene.(float64); x < -1 || x > 1 {
+				t.Error("Float64s generated a gene out of bounds")
+			}
+		}
+		for _, gene := range Ints(5, 2, 3)(rng) {
+			if x := gene.(int); x < 2 || x > 3 {
+				t.Error("Ints generated a gene out of bounds")
+			}
+		}
+		for _, gene := range Strings(5, []string{"a", "b"})(rng) {
+			if s := gene.(string); s != "a" && s != "b" {
+				t.Error("Strings generated a gene that isn't in the corpus")
+			}
+		}
+		if len(Bools(4)(rng)) != 4 {
+			t.Error("Bools didn't generate the right number of genes")
+		}
+		var (
+			genome = Permutations(6)(rng)
+			ints   = make([]int, len(genome))
+		)
+		for j, gene := range genome {
+			ints[j] = gene.(int)
+		}
+		sort.Ints(ints)
+		for j, x := range ints {
+			if x != j {
+				t.Errorf("Permutations generated %v", genome)
+				break
+			}
+		}
+		if n := len(VariableLength(1, 3, Bools)(rng)); n < 1 || n > 3 {
+			t.Errorf("VariableLength generated a genome of length %d", n)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package gago
+
+import (
+	"math/rand"
+)
+
+// LocalGradient improves the float64 genes of an individual with gradient
+// descent, which is cheap and effective once the GA has found the basin of a
+// good solution of a differentiable problem. Each of the Steps steps, 5 if
+// Steps is 0, moves the genes against the gradient of the fitness function by
+// the learning rate, which starts at LearningRate, 0.01 if LearningRate is 0,
+// and is halved each time a step doesn't improve the individual. The gradient
+// is computed by Gradient if it's not nil, Gradient receives the float64 genes
+// in order and returns the partial derivative with respect to each of them.
+// Otherwise the gradient is estimated with central finite differences of width
+// Epsilon, 1e-6 if Epsilon is 0, which costs two evaluations per gene. The
+// other genes are left unchanged. The search stops after MaxEvaluations
+// evaluations, there is no limit if MaxEvaluations is 0.
+//
+// The improved genome replaces the original one, which is called Lamarckian
+// learning. Combined with ModMemetic and it's Elites field only the best
+// individuals of each generation are refined.
+type LocalGradient struct {
+	Steps          int
+	LearningRate   float64
+	Epsilon        float64
+	Gradient       func(x []float64) []float64
+	MaxEvaluations int
+}
+
+// Estimate the gradient of the fitness function at a genome with central
+// finite differences with respect to the genes at the given positions.
+func (ls LocalGradient) estimate(s *search, genome Genome, positions []int, eps float64) []float64 {
+	var (
+		grad = make([]float64, len(positions))
+		g    = make(Genome, len(genome))
+	)
+	copy(g, genome)
+	var evaluate = func() float64 {
+		var indi = Individual{Genome: g}
+		indi.Evaluate(s.ff)
+		s.evaluations++
+		return indi.Fitness
+	}
+	for k, i := range positions {
+		var x = genome[i].(float64)
+		g[i] = x + eps
+		var up = evaluate()
+		g[i] = x - eps
+		var down = evaluate()
+		g[i] = x
+		grad[k] = (up - down) / (2 * eps)
+	}
+	return grad
+}
+
+// Apply gradient descent to an individual.
+func (ls LocalGradient) Apply(indi *Individual, ff FitnessFunction, rng *rand.Rand) {
+	var steps, rate, eps = ls.Steps, ls.LearningRate, ls.Epsilon
+	if steps == 0 {
+		steps = 5
+	}
+	if rate == 0 {
+		rate = 0.01
+	}
+	if eps == 0 {
+		eps = 1e-6
+	}
+	var (
+		s         = newSearch(indi, ff, ls.MaxEvaluations)
+		positions []int
+	)
+	for i, gene := range indi.Genome {
+		if _, ok := gene.(float64); ok {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) == 0 {
+		return
+	}
+	for step := 0; step < steps && !s.exhausted(); step++ {
+		var grad []float64
+		if ls.Gradient != nil {
+			var x = make([]float64, len(positions))
+			for k, i := range positions {
+				x[k] = indi.Genome[i].(float64)
+			}
+			grad = ls.Gradient(x)
+		} else {
+			grad = ls.estimate(s, indi.Genome, positions, eps)
+		}
+		if s.exhausted() {
+			return
+		}
+		var genome = make(Genome, len(indi.Genome))
+		copy(genome, indi.Genome)
+		for k, i := range positions {
+			if k < len(grad) {
+				genome[i] = genome[i].(float64) - rate*grad[k]
+			}
+		}
+		if !s.try(genome) {
+			rate /= 2
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestLocalGradient(t *testing.T) {
+	var (
+		rng      = rand.New(rand.NewSource(time.Now().UnixNano()))
+		searches = []LocalGradient{
+			{Steps: 20, LearningRate: 0.1},
+			{
+				Steps:        20,
+				LearningRate: 0.1,
+				Gradient: func(x []float64) []float64 {
+					var grad = make([]float64, len(x))
+					for i := range x {
+						grad[i] = 2 * x[i]
+					}
+					return grad
+				},
+			},
+		}
+	)
+	for _, ls := range searches {
+		var indi = Individual{Genome: Genome{1.0, -2.0, 3.0}}
+		indi.Evaluate(sphere)
+		ls.Apply(&indi, sphere, rng)
+		if indi.Fitness > 0.01*14 {
+			t.Errorf("Expected gradient descent to approach 0, got %f", indi.Fitness)
+		}
+		if indi.Fitness != sphere.apply(indi.Genome) {
+			t.Error("The fitness wasn't kept up to date")
+		}
+	}
+}
+
+func TestLocalGradientSkipsOtherGenes(t *testing.T) {
+	var (
+		rng = rand.New(rand.NewSource(42))
+		ff  = GenomeFunction{func(genome Genome) float64 {
+			var x = genome[1].(float64)
+			return x * x
+		}}
+		indi = Individual{Genome: Genome{"a", 1.0}}
+	)
+	LocalGradient{Steps: 10, LearningRate: 0.2}.Apply(&indi, ff, rng)
+	if indi.Genome[0] != "a" {
+		t.Error("A string gene was modified")
+	}
+	if indi.Fitness >= 1 {
+		t.Error("The float64 gene wasn't improved")
+	}
+}
+
+func TestLocalGradientBudget(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(42))
+		count int
+		ff    = Float64Function{func(x []float64) float64 {
+			count++
+			return sphere.Image(x)
+		}}
+		indi = Individual{Genome: Genome{1.0, 2.0, 3.0}}
+	)
+	indi.Evaluate(ff)
+	count = 0
+	LocalGradient{Steps: 100, MaxEvaluations: 10}.Apply(&indi, ff, rng)
+	if count > 16 {
+		t.Errorf("Expected at most 16 evaluations, got %d", count)
+	}
+}
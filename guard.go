@@ -0,0 +1,135 @@
+package gago
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// NonFiniteAction tells a FitnessGuard what to do with an individual whose
+// fitness, or one of whose objectives or case errors, is NaN or infinite.
+type NonFiniteAction int
+
+// The actions a FitnessGuard can take.
+const (
+	// The values that aren't finite are replaced by +Inf, the worst fitness
+	NonFiniteWorst NonFiniteAction = iota
+	// The individual is evaluated again, up to Retries times, which suits
+	// noisy simulations; the values are then replaced by +Inf if they still
+	// aren't finite
+	NonFiniteRetry
+	// The values are replaced by +Inf and the run is stopped, see Err
+	NonFiniteError
+)
+
+// A FitnessGuard protects a GA from fitness functions that return NaN or
+// infinite values, which would otherwise corrupt the order of the individuals
+// and the selection. The fitness, the objectives and the case errors of each
+// evaluated individual are checked and handled according to Action. OnNonFinite, if it
+// isn't nil, is called with the genome of each individual whose fitness isn't
+// finite, for example to log it.
+//
+// With NonFiniteError the first individual whose fitness isn't finite is
+// recorded as an error returned by Err, the generation is completed and Run
+// stops with the Failed termination reason. A FitnessGuard is safe for
+// concurrent use and has to be used through a pointer.
+type FitnessGuard struct {
+	Action      NonFiniteAction
+	Retries     int // Number of evaluations made again with NonFiniteRetry, 1 if 0
+	OnNonFinite func(genome Genome, fitness float64)
+	count       int64
+	mu          sync.Mutex
+	err         error
+}
+
+// Check if an individual has a fitness, objectives or case errors that aren't
+// finite.
+func nonFinite(indi Individual) bool {
+	if math.IsNaN(indi.Fitness) || math.IsInf(indi.Fitness, 0) {
+		return true
+	}
+	for _, values := range [][]float64{indi.Objectives, indi.Cases} {
+		for _, v := range values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Return a copy of a slice where the values that aren't finite are replaced
+// by +Inf, nil is returned for a nil slice.
+func worstIfNonFinite(values []float64) []float64 {
+	if values == nil {
+		return nil
+	}
+	var sanitized = make([]float64, len(values))
+	for i, v := range values {
+		sanitized[i] = v
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			sanitized[i] = math.Inf(1)
+		}
+	}
+	return sanitized
+}
+
+// Return true if an individual evaluated n times should be evaluated again.
+// The guard can be nil so that the evaluation doesn't have to check if there
+// is one.
+func (guard *FitnessGuard) retry(indi Individual, n int) bool {
+	if guard == nil || guard.Action != NonFiniteRetry || !nonFinite(indi) {
+		return false
+	}
+	var retries = guard.Retries
+	if retries == 0 {
+		retries = 1
+	}
+	return n <= retries
+}
+
+// Handle an evaluated individual whose fitness, objectives or case errors
+// aren't finite.
+func (guard *FitnessGuard) check(indi *Individual) {
+	if guard == nil || !nonFinite(*indi) {
+		return
+	}
+	atomic.AddInt64(&guard.count, 1)
+	if guard.OnNonFinite != nil {
+		guard.OnNonFinite(indi.Genome, indi.Fitness)
+	}
+	if guard.Action == NonFiniteError {
+		guard.mu.Lock()
+		if guard.err == nil {
+			guard.err = fmt.Errorf("the fitness of the genome %v is %v", indi.Genome, indi.Fitness)
+		}
+		guard.mu.Unlock()
+	}
+	if math.IsNaN(indi.Fitness) || math.IsInf(indi.Fitness, 0) {
+		indi.Fitness = math.Inf(1)
+	}
+	indi.Objectives = worstIfNonFinite(indi.Objectives)
+	indi.Cases = worstIfNonFinite(indi.Cases)
+}
+
+// Count returns the number of individuals whose fitness wasn't finite.
+func (guard *FitnessGuard) Count() int {
+	return int(atomic.LoadInt64(&guard.count))
+}
+
+// Err returns the error recorded with the NonFiniteError action, nil is
+// returned if every fitness was finite.
+func (guard *FitnessGuard) Err() error {
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+	return guard.err
+}
+
+// Forget the individuals met so far.
+func (guard *FitnessGuard) reset() {
+	guard.mu.Lock()
+	guard.err = nil
+	guard.mu.Unlock()
+	atomic.StoreInt64(&guard.count, 0)
+}
@@ -0,0 +1,138 @@
+package gago
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSortNaN(t *testing.T) {
+	var indis = Individuals{
+		{Fitness: math.NaN()},
+		{Fitness: 2},
+		{Fitness: math.NaN()},
+		{Fitness: math.Inf(1)},
+		{Fitness: 1},
+	}
+	indis.Sort()
+	if indis[0].Fitness != 1 || indis[1].Fitness != 2 || !math.IsInf(indis[2].Fitness, 1) {
+		t.Error("NaN fitnesses should be sorted after the other ones")
+	}
+	if !math.IsNaN(indis[3].Fitness) || !math.IsNaN(indis[4].Fitness) {
+		t.Error("NaN fitnesses should be the worst")
+	}
+}
+
+// Return a GA whose fitness function returns NaN when the first gene is
+// positive.
+func newGuardedGA(guard *FitnessGuard) GA {
+	return GA{
+		NbrPopulations: 2,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff: Float64Function{
+			Image: func(X []float64) float64 {
+				if X[0] > 0 {
+					return math.NaN()
+				}
+				return X[0]
+			},
+		},
+		Model: model,
+		Guard: guard,
+	}
+}
+
+func TestGuardWorst(t *testing.T) {
+	var (
+		guard = &FitnessGuard{}
+		g     = newGuardedGA(guard)
+	)
+	g.Initialize()
+	for i := 0; i < 5; i++ {
+		g.Enhance()
+	}
+	if guard.Count() == 0 {
+		t.Error("The NaN fitnesses should have been counted")
+	}
+	for _, pop := range g.Populations {
+		for _, indi := range pop.Individuals {
+			if math.IsNaN(indi.Fitness) {
+				t.Fatal("NaN fitnesses should have been replaced")
+			}
+		}
+	}
+	if g.Best().Fitness > 0 {
+		t.Error("The best individual should have a finite fitness")
+	}
+}
+
+func TestGuardRetry(t *testing.T) {
+	var (
+		calls int64
+		noisy = Float64Function{
+			Image: func(X []float64) float64 {
+				// Every other evaluation fails
+				if atomic.AddInt64(&calls, 1)%2 == 1 {
+					return math.NaN()
+				}
+				return 1
+			},
+		}
+		guard = &FitnessGuard{Action: NonFiniteRetry}
+		indi  = Individual{Genome: Genome{0.0}}
+		count int64
+	)
+	indi.Evaluate(countedFunction{ff: noisy, count: &count, guard: guard})
+	if indi.Fitness != 1 || count != 2 {
+		t.Errorf("The evaluation should have been retried, got %v after %d evaluations", indi.Fitness, count)
+	}
+	// The fitness is the worst once the retries are exhausted
+	var nan = Float64Function{Image: func(X []float64) float64 { return math.NaN() }}
+	indi = Individual{Genome: Genome{0.0}}
+	count = 0
+	guard.Retries = 3
+	indi.Evaluate(countedFunction{ff: nan, count: &count, guard: guard})
+	if !math.IsInf(indi.Fitness, 1) || count != 4 {
+		t.Errorf("Expected +Inf after 4 evaluations, got %v after %d", indi.Fitness, count)
+	}
+}
+
+func TestGuardObjectives(t *testing.T) {
+	var (
+		objs = []float64{1, math.NaN()}
+		indi = Individual{Fitness: 1, Objectives: objs}
+	)
+	(&FitnessGuard{}).check(&indi)
+	if !math.IsInf(indi.Objectives[1], 1) || !math.IsNaN(objs[1]) {
+		t.Error("The objectives should have been replaced without being modified in place")
+	}
+}
+
+func TestGuardCases(t *testing.T) {
+	var indi = Individual{Fitness: 1, Cases: []float64{1, math.NaN(), math.Inf(-1)}}
+	(&FitnessGuard{}).check(&indi)
+	if indi.Cases[0] != 1 || !math.IsInf(indi.Cases[1], 1) || !math.IsInf(indi.Cases[2], 1) {
+		t.Error("The case errors should have been replaced")
+	}
+}
+
+func TestGuardError(t *testing.T) {
+	var (
+		guard = &FitnessGuard{Action: NonFiniteError}
+		g     = newGuardedGA(guard)
+	)
+	g.Initialize()
+	var _, reason, err = g.Run(context.Background(), RunOptions{MaxGenerations: 5})
+	if reason != Failed || err == nil || err != guard.Err() {
+		t.Error("The run should have failed because of the NaN fitnesses")
+	}
+	// Initializing the GA again forgets the error
+	g.Ff = ff
+	g.Initialize()
+	if guard.Err() != nil || guard.Count() != 0 {
+		t.Error("The guard should have been reset")
+	}
+}
@@ -0,0 +1,166 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// MutGuided is a mutator for floating point genes that moves the genes toward
+// a probability model of the best individuals, in the manner of the
+// estimation of distribution algorithms such as PBIL. The model holds a mean
+// and a standard deviation per gene, it's learnt from the best Fraction of the
+// individuals of a population, half of them if Fraction is 0. Before each
+// generation the model of each population moves toward the means and the
+// standard deviations of it's best individuals with learning rate Alpha, 0.2 if
+// Alpha is 0, and the standard deviations are kept above MinStd so that the
+// model doesn't collapse. Each gene is mutated with probability Rate: a value y
+// is sampled from the model and the gene x becomes x + Weight*(y-x), hence a
+// Weight of 1 samples the gene from the model; a Weight of 0 is treated as 1.
+// The genes that aren't float64 are left unchanged.
+//
+// The GA updates the model of a population when MutGuided is the Mutator of
+// the population's Model, or of a model wrapped by it, hence each population
+// has it's own model; a MutGuided nested in another mutator isn't updated.
+// Applied outside of a GA, MutGuided uses the model of the first population,
+// which can be learnt with Learn. MutGuided has to be used through a pointer.
+type MutGuided struct {
+	Rate     float64
+	Weight   float64
+	Fraction float64
+	Alpha    float64
+	MinStd   float64
+	mu       sync.Mutex
+	means    [][]float64 // Means of the model of each population
+	stds     [][]float64 // Standard deviations of the model of each population
+}
+
+// A guidedMutator applies MutGuided with the model of a population as it was
+// at the beginning of the generation.
+type guidedMutator struct {
+	mut       *MutGuided
+	mean, std []float64
+}
+
+// Learn updates the model of the p-th population with it's individuals, which
+// should be sorted from the best to the worst.
+func (mut *MutGuided) Learn(p int, indis Individuals) {
+	mut.learn(p, indis)
+}
+
+// Update the model of the p-th population and return a mutator that uses it.
+func (mut *MutGuided) learn(p int, indis Individuals) guidedMutator {
+	mut.mu.Lock()
+	defer mut.mu.Unlock()
+	var fraction, alpha = mut.Fraction, mut.Alpha
+	if fraction == 0 {
+		fraction = 0.5
+	}
+	if alpha == 0 {
+		alpha = 0.2
+	}
+	for len(mut.means) <= p {
+		mut.means = append(mut.means, nil)
+		mut.stds = append(mut.stds, nil)
+	}
+	if len(indis) == 0 {
+		return guidedMutator{mut, mut.means[p], mut.stds[p]}
+	}
+	// Compute the mean and the standard deviation of each gene over the best
+	// individuals
+	var (
+		best  = indis[:max(1, int(fraction*float64(len(indis))))]
+		n     = len(indis[0].Genome)
+		mean  = make([]float64, n)
+		std   = make([]float64, n)
+		count = make([]float64, n)
+	)
+	for _, indi := range best {
+		for i, gene := range indi.Genome {
+			if x, ok := gene.(float64); ok && i < n {
+				mean[i] += x
+				count[i]++
+			}
+		}
+	}
+	for i := range mean {
+		if count[i] > 0 {
+			mean[i] /= count[i]
+		}
+	}
+	for _, indi := range best {
+		for i, gene := range indi.Genome {
+			if x, ok := gene.(float64); ok && i < n {
+				std[i] += (x - mean[i]) * (x - mean[i])
+			}
+		}
+	}
+	for i := range std {
+		if count[i] > 0 {
+			std[i] = math.Sqrt(std[i] / count[i])
+		}
+	}
+	// Move the model toward the best individuals, a new model is replaced
+	if len(mut.means[p]) != n {
+		mut.means[p], mut.stds[p] = mean, std
+	} else {
+		for i := range mean {
+			mut.means[p][i] += alpha * (mean[i] - mut.means[p][i])
+			mut.stds[p][i] += alpha * (std[i] - mut.stds[p][i])
+		}
+	}
+	for i := range mut.stds[p] {
+		mut.stds[p][i] = math.Max(mut.stds[p][i], mut.MinStd)
+	}
+	return guidedMutator{
+		mut,
+		append([]float64{}, mut.means[p]...),
+		append([]float64{}, mut.stds[p]...),
+	}
+}
+
+// Model returns the mean and the standard deviation of each gene of the model
+// of the p-th population, nil if the model hasn't been learnt yet.
+func (mut *MutGuided) Model(p int) ([]float64, []float64) {
+	mut.mu.Lock()
+	defer mut.mu.Unlock()
+	if p >= len(mut.means) || mut.means[p] == nil {
+		return nil, nil
+	}
+	return append([]float64{}, mut.means[p]...), append([]float64{}, mut.stds[p]...)
+}
+
+// Apply MutGuided with the model of the first population.
+func (mut *MutGuided) Apply(indi *Individual, rng *rand.Rand) {
+	var mean, std = mut.Model(0)
+	guidedMutator{mut, mean, std}.Apply(indi, rng)
+}
+
+// Apply MutGuided with the model of a population.
+func (gm guidedMutator) Apply(indi *Individual, rng *rand.Rand) {
+	var weight = gm.mut.Weight
+	if weight == 0 {
+		weight = 1
+	}
+	for i, gene := range indi.Genome {
+		var x, ok = gene.(float64)
+		if !ok || i >= len(gm.mean) || rng.Float64() >= gm.mut.Rate {
+			continue
+		}
+		var y = gm.mean[i] + gm.std[i]*rng.NormFloat64()
+		indi.Genome[i] = x + weight*(y-x)
+	}
+}
+
+// Return a copy of a model whose MutGuided mutators use the model of the p-th
+// population, which is first updated with the population's individuals.
+func guideModel(model Model, p int, indis Individuals) Model {
+	return wrapModel(model, wrappers{
+		mutator: func(mut Mutator) Mutator {
+			if guided, ok := mut.(*MutGuided); ok {
+				return guided.learn(p, indis)
+			}
+			return mut
+		},
+	})
+}
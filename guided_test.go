@@ -0,0 +1,98 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMutGuidedLearn(t *testing.T) {
+	var (
+		mut   = &MutGuided{Fraction: 0.5, Alpha: 0.5}
+		indis = Individuals{
+			{Genome: Genome{1.0, "a"}},
+			{Genome: Genome{3.0, "b"}},
+			{Genome: Genome{10.0, "c"}},
+			{Genome: Genome{20.0, "d"}},
+		}
+	)
+	if mean, _ := mut.Model(0); mean != nil {
+		t.Error("The model shouldn't exist before being learnt")
+	}
+	// The first model is learnt from the 2 best individuals
+	mut.Learn(0, indis)
+	var mean, std = mut.Model(0)
+	if mean[0] != 2 || std[0] != 1 || mean[1] != 0 {
+		t.Errorf("Unexpected model %v %v", mean, std)
+	}
+	// The next models move toward the best individuals
+	indis[0].Genome[0], indis[1].Genome[0] = 5.0, 7.0
+	mut.Learn(0, indis)
+	if mean, std = mut.Model(0); mean[0] != 4 || std[0] != 1 {
+		t.Errorf("Unexpected model %v %v", mean, std)
+	}
+	// The other populations have their own model
+	mut.Learn(2, indis[2:])
+	if mean, _ = mut.Model(1); mean != nil {
+		t.Error("The model of the second population shouldn't exist")
+	}
+	if mean, std = mut.Model(2); mean[0] != 10 || std[0] != 0 {
+		t.Errorf("Unexpected model %v %v", mean, std)
+	}
+	mut.MinStd = 0.5
+	mut.Learn(2, indis[2:])
+	if _, std = mut.Model(2); std[0] != 0.5 {
+		t.Error("The standard deviation should be at least MinStd")
+	}
+}
+
+func TestMutGuidedApply(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(42))
+		mut  = &MutGuided{Rate: 1, MinStd: 0.01}
+		indi = Individual{Genome: Genome{100.0, "a"}}
+	)
+	// Without a model the genome is unchanged
+	mut.Apply(&indi, rng)
+	if indi.Genome[0] != 100.0 {
+		t.Error("The genome was mutated without a model")
+	}
+	mut.Learn(0, Individuals{{Genome: Genome{1.0, "b"}}, {Genome: Genome{1.0, "c"}}})
+	mut.Apply(&indi, rng)
+	if x := indi.Genome[0].(float64); math.Abs(x-1) > 0.1 || indi.Genome[1] != "a" {
+		t.Errorf("The gene wasn't sampled from the model, got %v", indi.Genome)
+	}
+	// Halfway toward the model
+	mut.Weight = 0.5
+	indi.Genome[0] = 101.0
+	mut.Apply(&indi, rng)
+	if x := indi.Genome[0].(float64); math.Abs(x-51) > 0.1 {
+		t.Errorf("Expected the gene to move halfway toward the model, got %f", x)
+	}
+}
+
+func TestMutGuidedGA(t *testing.T) {
+	var (
+		ga  = NewFloatGA(3, -5, 5, func(x []float64) float64 { return x[0]*x[0] + x[1]*x[1] + x[2]*x[2] })
+		mut = &MutGuided{Rate: 0.5, Weight: 0.5, MinStd: 0.01}
+	)
+	ga.NbrPopulations = 2
+	ga.Model = ModGenerational{
+		Selector:  SelTournament{NbParticipants: 3},
+		Crossover: CrossUniformF{},
+		Mutator:   mut,
+		MutRate:   0.5,
+	}
+	ga.Initialize()
+	for i := 0; i < 20; i++ {
+		ga.Enhance()
+	}
+	for p := range ga.Populations {
+		if mean, _ := mut.Model(p); len(mean) != 3 {
+			t.Errorf("The model of population %d wasn't learnt", p)
+		}
+	}
+	if ga.Best().Fitness > 1 {
+		t.Errorf("Expected the GA to get close to the optimum, got %f", ga.Best().Fitness)
+	}
+}
@@ -0,0 +1,320 @@
+package gago
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// A HallOfFame keeps track of the Size best individuals found during a run,
+// individuals are compared with Comparator or by fitness if Comparator is nil.
+// Individuals that have the same genome are only kept once, genomes are
+// compared through their binary encoding hence individuals whose genes can't
+// be encoded are always considered different. A HallOfFame is safe for
+// concurrent use and has to be used through a pointer.
+//
+// Store is optional, if it's set the genomes and the cases of the members are
+// kept in it instead of in memory, see ArchiveStore. Individuals whose genes
+// can't be encoded are then ignored and Comparator isn't given the genomes and
+// the cases of the members. Store is emptied when the GA is initialized,
+// Restore reads the members back when a run is resumed.
+type HallOfFame struct {
+	Size       int
+	Comparator Comparator
+	Store      ArchiveStore
+	mu         sync.Mutex
+	members    Individuals // Sorted from the best to the worst
+	keys       []string    // Encoding of the genome of each member, empty if it can't be encoded, or it's hash with a Store
+	err        error       // First error returned by Store
+}
+
+// Return the binary encoding of a genome, an empty string is returned if the
+// genome can't be encoded.
+func genomeKey(genome Genome) string {
+	var (
+		buf bytes.Buffer
+		bw  = binaryWriter{w: &buf}
+	)
+	bw.genome(genome)
+	if bw.err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// Add an individual to the hall of fame if it's better than one of the
+// members. If a member has the same genome it's replaced only if the
+// individual is better. Returns true if the individual was added.
+// Individuals that haven't been evaluated are ignored.
+func (hof *HallOfFame) Add(indi Individual) bool {
+	if !indi.Evaluated || hof.Size < 1 {
+		return false
+	}
+	hof.mu.Lock()
+	defer hof.mu.Unlock()
+	var key string
+	if hof.Store != nil {
+		if key = storeKey(indi.Genome); key == "" {
+			return false
+		}
+	} else {
+		key = genomeKey(indi.Genome)
+	}
+	// Find the member with the same genome, it's only replaced if it's worse
+	var same = -1
+	if key != "" {
+		for i, k := range hof.keys {
+			if k == key {
+				if !less(hof.Comparator, indi, hof.members[i]) {
+					return false
+				}
+				same = i
+				break
+			}
+		}
+	}
+	if same < 0 && len(hof.members) >= hof.Size && !less(hof.Comparator, indi, hof.members[len(hof.members)-1]) {
+		return false
+	}
+	var member = copyIndividual(indi)
+	if hof.Store != nil {
+		// The member with the same genome is overwritten in the store
+		if err := hof.Store.Put(key, indi); err != nil {
+			hof.fail(err)
+			return false
+		}
+		member = stubIndividual(indi)
+	}
+	if same >= 0 {
+		hof.remove(same)
+	}
+	// Insert the individual so that the members stay sorted
+	var i = sort.Search(len(hof.members), func(i int) bool {
+		return less(hof.Comparator, indi, hof.members[i])
+	})
+	hof.members = append(hof.members, Individual{})
+	hof.keys = append(hof.keys, "")
+	copy(hof.members[i+1:], hof.members[i:])
+	copy(hof.keys[i+1:], hof.keys[i:])
+	hof.members[i], hof.keys[i] = member, key
+	if len(hof.members) > hof.Size {
+		hof.evict(len(hof.members) - 1)
+	}
+	return true
+}
+
+// Remove the i-th member.
+func (hof *HallOfFame) remove(i int) {
+	hof.members = append(hof.members[:i], hof.members[i+1:]...)
+	hof.keys = append(hof.keys[:i], hof.keys[i+1:]...)
+}
+
+// Remove the i-th member and delete it from the store.
+func (hof *HallOfFame) evict(i int) {
+	if hof.Store != nil {
+		if err := hof.Store.Delete(hof.keys[i]); err != nil {
+			hof.fail(err)
+		}
+	}
+	hof.remove(i)
+}
+
+// Record the first error returned by the store.
+func (hof *HallOfFame) fail(err error) {
+	if hof.err == nil {
+		hof.err = err
+	}
+}
+
+// Update adds each individual in a slice of individuals to the hall of fame.
+func (hof *HallOfFame) Update(indis Individuals) {
+	for _, indi := range indis {
+		hof.Add(indi)
+	}
+}
+
+// Members returns a copy of the members sorted from the best to the worst.
+// With a Store the members are read from it, the members that can't be read
+// are left out, see Err.
+func (hof *HallOfFame) Members() Individuals {
+	hof.mu.Lock()
+	defer hof.mu.Unlock()
+	return hof.copyMembers()
+}
+
+// Return a copy of the members, the lock has to be held.
+func (hof *HallOfFame) copyMembers() Individuals {
+	var members = make(Individuals, 0, len(hof.members))
+	for i, member := range hof.members {
+		if hof.Store == nil {
+			members = append(members, copyIndividual(member))
+			continue
+		}
+		var indi, err = loadMember(hof.Store, hof.keys[i], member)
+		if err != nil {
+			hof.fail(err)
+			continue
+		}
+		members = append(members, indi)
+	}
+	return members
+}
+
+// Len returns the number of members.
+func (hof *HallOfFame) Len() int {
+	hof.mu.Lock()
+	defer hof.mu.Unlock()
+	return len(hof.members)
+}
+
+// Err returns the first error returned by Store, nil is returned if there is
+// no Store or if it never failed. An individual that couldn't be put in Store
+// isn't added to the hall of fame.
+func (hof *HallOfFame) Err() error {
+	hof.mu.Lock()
+	defer hof.mu.Unlock()
+	return hof.err
+}
+
+// Restore replaces the members with the individuals held by Store, which is
+// useful to resume a run that was interrupted, for example after it's
+// checkpoint was loaded with LoadCheckpoint. The best Size individuals are
+// kept and the others are deleted from Store. The IDs, birth generations and
+// origins of the members aren't restored.
+func (hof *HallOfFame) Restore() error {
+	if hof.Store == nil {
+		return errors.New("'Store' should be set")
+	}
+	hof.mu.Lock()
+	defer hof.mu.Unlock()
+	var keys, err = hof.Store.Keys()
+	if err != nil {
+		return err
+	}
+	var indis = make(Individuals, len(keys))
+	for i, key := range keys {
+		if indis[i], err = hof.Store.Get(key); err != nil {
+			return err
+		}
+	}
+	var order = make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(hof.Comparator, indis[order[i]], indis[order[j]])
+	})
+	hof.members, hof.keys = nil, nil
+	for _, i := range order {
+		if len(hof.members) < hof.Size {
+			hof.members = append(hof.members, stubIndividual(indis[i]))
+			hof.keys = append(hof.keys, keys[i])
+		} else if err = hof.Store.Delete(keys[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove every member.
+func (hof *HallOfFame) reset() {
+	hof.mu.Lock()
+	hof.members, hof.keys, hof.err = nil, nil, nil
+	if hof.Store != nil {
+		hof.err = clearStore(hof.Store)
+	}
+	hof.mu.Unlock()
+}
+
+// Return a copy of the hall of fame that doesn't share it's members. The copy
+// of a hall of fame backed by a store keeps it's members in memory.
+func (hof *HallOfFame) clone() *HallOfFame {
+	hof.mu.Lock()
+	defer hof.mu.Unlock()
+	var (
+		members = hof.copyMembers()
+		keys    = make([]string, len(members))
+	)
+	for i, member := range members {
+		keys[i] = genomeKey(member.Genome)
+	}
+	return &HallOfFame{
+		Size:       hof.Size,
+		Comparator: hof.Comparator,
+		members:    members,
+		keys:       keys,
+	}
+}
+
+// Update the hall of fame, if there is one, with the individuals of each
+// population.
+func (ga *GA) updateHallOfFame() {
+	if ga.HallOfFame != nil {
+		for _, pop := range ga.Populations {
+			ga.HallOfFame.Update(pop.Individuals)
+		}
+	}
+}
+
+// Reset starts a new run that is seeded with the keepBest best individuals of
+// the previous run, which is useful for iterated runs on a problem that
+// changes slowly. The individuals are taken from the hall of fame if the GA has
+// one, otherwise from the current populations. The GA is then initialized as
+// with Initialize, which resets the counters, the statistics, the hall of fame
+// and the lineage, and the kept individuals replace the worst individuals of
+// the populations in turn. The kept individuals lose their metadata and are
+// evaluated again, because the fitness function may have changed since they
+// were evaluated; these evaluations are counted in the new run.
+func (ga *GA) Reset(keepBest int) {
+	// Gather the individuals to keep
+	var kept Individuals
+	if ga.HallOfFame != nil {
+		kept = ga.HallOfFame.Members()
+	} else {
+		for _, pop := range ga.Populations {
+			for _, indi := range pop.Individuals {
+				kept = append(kept, copyIndividual(indi))
+			}
+		}
+		kept.SortWith(ga.Comparator)
+	}
+	if len(kept) > keepBest {
+		kept = kept[:keepBest]
+	}
+	ga.Initialize()
+	if len(kept) == 0 {
+		return
+	}
+	// Replace the worst individuals of each population in turn
+	var injected = make([]int, len(ga.Populations))
+	for i, indi := range kept {
+		var (
+			p   = i % len(ga.Populations)
+			pop = &ga.Populations[p]
+			j   = len(pop.Individuals) - 1 - injected[p]
+		)
+		if j < 0 {
+			continue
+		}
+		indi.Metadata = nil
+		indi.Evaluated = false
+		indi.ID, indi.Birth, indi.Origin = 0, 0, 0
+		if ga.Lineage != nil {
+			ga.Lineage.record(&indi, "reset")
+		}
+		pop.Individuals[j] = indi
+		injected[p]++
+	}
+	for i := range ga.Populations {
+		var pop = &ga.Populations[i]
+		pop.Individuals.Evaluate(pop.ff)
+		pop.Individuals.SortWith(pop.cmp)
+	}
+	ga.stamp()
+	ga.updateArchive()
+	ga.updateHallOfFame()
+	ga.findBest()
+	ga.Evaluations = int(atomic.LoadInt64(ga.evaluations))
+}
@@ -0,0 +1,141 @@
+package gago
+
+import "testing"
+
+func TestHallOfFameAdd(t *testing.T) {
+	var (
+		hof  = &HallOfFame{Size: 3}
+		indi = func(x, fitness float64) Individual {
+			return Individual{Genome: Genome{x}, Fitness: fitness, Evaluated: true}
+		}
+	)
+	for i, fitness := range []float64{5, 3, 4, 1} {
+		hof.Add(indi(float64(i), fitness))
+	}
+	var expected = []float64{1, 3, 4}
+	var members = hof.Members()
+	if len(members) != len(expected) {
+		t.Fatalf("Expected %d members, got %d", len(expected), len(members))
+	}
+	for i, member := range members {
+		if member.Fitness != expected[i] {
+			t.Errorf("Expected fitness %f, got %f", expected[i], member.Fitness)
+		}
+	}
+	// An individual that isn't better than the members
+	if hof.Add(indi(10, 4)) {
+		t.Error("An individual as good as the worst member should not be added")
+	}
+	// An individual that hasn't been evaluated
+	if hof.Add(Individual{Genome: Genome{11.0}, Fitness: 0}) {
+		t.Error("An individual that hasn't been evaluated should not be added")
+	}
+	// Individuals with the same genome as a member
+	if hof.Add(indi(1, 3.5)) {
+		t.Error("A worse individual with the same genome as a member should not be added")
+	}
+	if !hof.Add(indi(1, 2)) {
+		t.Error("A better individual with the same genome as a member should replace it")
+	}
+	members = hof.Members()
+	if hof.Len() != 3 || members[1].Fitness != 2 || members[2].Fitness != 4 {
+		t.Errorf("Wrong members: %v", members)
+	}
+	// The members don't share their genomes
+	members[0].Genome[0] = 42.0
+	if hof.Members()[0].Genome[0] == 42.0 {
+		t.Error("Members should return copies")
+	}
+}
+
+func TestHallOfFameGA(t *testing.T) {
+	var ga = GA{
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          model,
+		NbrGenes:       nbGenes,
+		NbrIndividuals: nbIndividuals,
+		NbrPopulations: 2,
+		HallOfFame:     &HallOfFame{Size: 5},
+	}
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	var members = ga.HallOfFame.Members()
+	if len(members) != 5 {
+		t.Fatalf("Expected 5 members, got %d", len(members))
+	}
+	if members[0].Fitness != ga.Best().Fitness {
+		t.Error("The first member should be the best individual")
+	}
+	for i := 1; i < len(members); i++ {
+		if members[i].Fitness < members[i-1].Fitness {
+			t.Error("The members should be sorted by fitness")
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	var shift float64
+	var ff = Float64Function{func(X []float64) float64 { return X[0] + X[1] + shift }}
+	for _, hof := range []*HallOfFame{nil, {Size: 5}} {
+		var ga = GA{
+			Ff:             ff,
+			Initializer:    initializer,
+			Model:          model,
+			NbrGenes:       nbGenes,
+			NbrIndividuals: nbIndividuals,
+			NbrPopulations: 2,
+			HallOfFame:     hof,
+			Lineage:        &Lineage{},
+		}
+		ga.Initialize()
+		for i := 0; i < 10; i++ {
+			ga.Enhance()
+		}
+		// The best individual of the populations is kept, the overall best
+		// individual might have been lost by the model
+		var best = ga.Best()
+		if hof == nil {
+			best = ga.Populations[0].Individuals[0]
+			if other := ga.Populations[1].Individuals[0]; other.Fitness < best.Fitness {
+				best = other
+			}
+		}
+		// The problem changes slowly
+		shift = 0.1
+		ga.Reset(3)
+		if ga.Generations != 0 || ga.Stagnation != 0 {
+			t.Error("The counters should be reset")
+		}
+		if ga.Evaluations != 2*nbIndividuals+3 {
+			t.Errorf("Expected %d evaluations, got %d", 2*nbIndividuals+3, ga.Evaluations)
+		}
+		var expected = best.Genome[0].(float64) + best.Genome[1].(float64) + shift
+		if ga.Best().Fitness > expected {
+			t.Errorf("Expected a best fitness of at most %f, got %f", expected, ga.Best().Fitness)
+		}
+		var found bool
+		for _, pop := range ga.Populations {
+			if len(pop.Individuals) != nbIndividuals {
+				t.Error("The populations should keep their size")
+			}
+			for _, indi := range pop.Individuals {
+				if indi.Fitness == expected && indi.Genome[0] == best.Genome[0] {
+					found = true
+					if id, _ := LineageID(indi); ga.Lineage.Nodes()[id].Operator != "reset" {
+						t.Error("The kept individuals should be recorded in the lineage")
+					}
+				}
+			}
+		}
+		if !found {
+			t.Error("The best individual should have been kept and evaluated again")
+		}
+		if hof != nil && hof.Members()[0].Fitness != ga.Best().Fitness {
+			t.Error("The hall of fame should be restarted with the new run")
+		}
+		shift = 0
+	}
+}
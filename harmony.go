@@ -0,0 +1,107 @@
+package gago
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// ModHarmony implements harmony search, a metaheuristic inspired by musicians
+// improvising together. The population is the harmony memory. At each of the
+// NbrImprovisations steps of a generation, 1 if NbrImprovisations is 0, a new
+// harmony is improvised gene by gene: with probability HMCR, 0.9 if HMCR is 0,
+// the gene is taken from a random member of the memory, otherwise it's taken
+// from a random genome produced by Initializer. A gene taken from the memory
+// is then pitch adjusted with probability PAR, 0.3 if PAR is 0: a float64 gene
+// moves uniformly within Bandwidth, 0.01 if Bandwidth is 0, and an int gene
+// moves by 1 in either direction; the other genes are left as they are. The
+// new harmony is evaluated and replaces the worst member of the memory if it's
+// better.
+type ModHarmony struct {
+	HMCR              float64
+	PAR               float64
+	Bandwidth         float64
+	NbrImprovisations int
+	Initializer       Initializer
+}
+
+// Adjust the pitch of a gene.
+func pitch(gene interface{}, bandwidth float64, rng *rand.Rand) interface{} {
+	switch x := gene.(type) {
+	case float64:
+		return x + bandwidth*(2*rng.Float64()-1)
+	case int:
+		if rng.Float64() < 0.5 {
+			return x - 1
+		}
+		return x + 1
+	}
+	return gene
+}
+
+// Apply harmony search to a population.
+func (mod ModHarmony) Apply(pop *Population) {
+	var hmcr, par, bandwidth, nbr = mod.HMCR, mod.PAR, mod.Bandwidth, mod.NbrImprovisations
+	if hmcr == 0 {
+		hmcr = 0.9
+	}
+	if par == 0 {
+		par = 0.3
+	}
+	if bandwidth == 0 {
+		bandwidth = 0.01
+	}
+	if nbr == 0 {
+		nbr = 1
+	}
+	var nbGenes = len(pop.Individuals[0].Genome)
+	for i := 0; i < nbr; i++ {
+		var harmony = makeIndividual(nbGenes, pop.rng)
+		mod.Initializer.Apply(&harmony, pop.rng)
+		for j := range harmony.Genome {
+			var member = pop.Individuals[pop.rng.Intn(len(pop.Individuals))]
+			if j >= len(member.Genome) || pop.rng.Float64() >= hmcr {
+				continue
+			}
+			harmony.Genome[j] = member.Genome[j]
+			if pop.rng.Float64() < par {
+				harmony.Genome[j] = pitch(harmony.Genome[j], bandwidth, pop.rng)
+			}
+		}
+		harmony.Evaluate(pop.ff)
+		// Replace the worst member of the memory
+		var worst = 0
+		for j := range pop.Individuals {
+			if less(pop.cmp, pop.Individuals[worst], pop.Individuals[j]) {
+				worst = j
+			}
+		}
+		if less(pop.cmp, harmony, pop.Individuals[worst]) {
+			pop.Individuals[worst] = harmony
+		}
+	}
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModHarmony) Validate() error {
+	// Check the memory considering rate
+	if mod.HMCR < 0 || mod.HMCR > 1 {
+		return errors.New("'HMCR' should belong to the [0, 1] interval")
+	}
+	// Check the pitch adjusting rate
+	if mod.PAR < 0 || mod.PAR > 1 {
+		return errors.New("'PAR' should belong to the [0, 1] interval")
+	}
+	// Check the bandwidth
+	if mod.Bandwidth < 0 {
+		return errors.New("'Bandwidth' should be higher or equal to 0")
+	}
+	// Check the number of improvisations
+	if mod.NbrImprovisations < 0 {
+		return errors.New("'NbrImprovisations' should be higher or equal to 0")
+	}
+	// Check the initializer presence
+	if mod.Initializer == nil {
+		return errors.New("'Initializer' cannot be nil")
+	}
+	return nil
+}
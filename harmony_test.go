@@ -0,0 +1,59 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPitch(t *testing.T) {
+	var rng = rand.New(rand.NewSource(42))
+	for i := 0; i < 20; i++ {
+		if x := pitch(1.0, 0.5, rng).(float64); x < 0.5 || x > 1.5 {
+			t.Errorf("The pitch of 1 moved to %f", x)
+		}
+		if x := pitch(3, 0.5, rng).(int); x != 2 && x != 4 {
+			t.Errorf("The pitch of 3 moved to %d", x)
+		}
+	}
+	if pitch("a", 0.5, rng) != "a" {
+		t.Error("A string gene shouldn't be adjusted")
+	}
+}
+
+func TestModHarmony(t *testing.T) {
+	var (
+		pop = makePopulation(10, 3, ff, initializer)
+		mod = ModHarmony{NbrImprovisations: 50, Initializer: initializer}
+	)
+	pop.Individuals.Evaluate(pop.ff)
+	pop.Individuals.Sort()
+	var before = pop.Individuals[0].Fitness
+	mod.Apply(&pop)
+	if len(pop.Individuals) != 10 {
+		t.Error("The size of the population was modified")
+	}
+	pop.Individuals.Sort()
+	if pop.Individuals[0].Fitness > before {
+		t.Error("The best harmony was lost")
+	}
+	for _, indi := range pop.Individuals {
+		if !indi.Evaluated {
+			t.Error("A harmony wasn't evaluated")
+		}
+	}
+}
+
+func TestModHarmonyValidate(t *testing.T) {
+	var mod = ModHarmony{Initializer: initializer}
+	if mod.Validate() != nil {
+		t.Error("Should not have returned an error")
+	}
+	mod.HMCR = 1.5
+	if mod.Validate() == nil {
+		t.Error("Should have returned an error")
+	}
+	mod = ModHarmony{}
+	if mod.Validate() == nil {
+		t.Error("Should have returned an error")
+	}
+}
@@ -0,0 +1,116 @@
+package gago
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// A BestSnapshot is a copy of the overall best individual at a given
+// generation.
+type BestSnapshot struct {
+	Generation  int
+	Evaluations int
+	Fitness     float64
+	Genome      Genome
+}
+
+// A BestHistory records the genome of the overall best individual every Every
+// generations, and at every generation if Every is 0, starting with the
+// initial populations. Unlike the fitness of the best individual, which is
+// summarized by the statistics, the history shows how the best solution
+// itself evolves, for example to animate a route or a curve as the run goes
+// on. The snapshots are reported by the History field of the statistics
+// returned by the Stats method of the GA and can be written as a CSV table
+// with WriteCSV, which Run does if RunOptions.History is set. The genomes are
+// copied, but genes that reference values, such as Vector genes, share these
+// values with the individuals. A BestHistory is safe for concurrent use and
+// has to be used through a pointer.
+type BestHistory struct {
+	Every     int
+	mu        sync.Mutex
+	snapshots []BestSnapshot
+}
+
+// Record the best individual of the GA if the generation is a multiple of
+// Every.
+func (h *BestHistory) update(ga *GA) {
+	var every = h.Every
+	if every < 1 {
+		every = 1
+	}
+	if ga.Generations%every != 0 {
+		return
+	}
+	var best = copyIndividual(ga.Best())
+	h.mu.Lock()
+	h.snapshots = append(h.snapshots, BestSnapshot{
+		Generation:  ga.Generations,
+		Evaluations: ga.Evaluations,
+		Fitness:     best.Fitness,
+		Genome:      best.Genome,
+	})
+	h.mu.Unlock()
+}
+
+// Snapshots returns the recorded snapshots, from the oldest to the newest.
+func (h *BestHistory) Snapshots() []BestSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]BestSnapshot(nil), h.snapshots...)
+}
+
+// WriteCSV writes the snapshots as a CSV table with a column for the
+// generation, the number of evaluations, the fitness and each gene. Like with
+// CSVExporter, a genome that holds a single Vector has a column per value and
+// genes that aren't numbers are written with fmt.Sprint. The number of gene
+// columns is set by the longest genome.
+func (h *BestHistory) WriteCSV(w io.Writer) error {
+	var (
+		snapshots = h.Snapshots()
+		genes     = make([][]string, len(snapshots))
+		nbGenes   int
+	)
+	for i, snapshot := range snapshots {
+		genes[i] = geneStrings(snapshot.Genome)
+		if len(genes[i]) > nbGenes {
+			nbGenes = len(genes[i])
+		}
+	}
+	var (
+		cw     = csv.NewWriter(w)
+		header = []string{"generation", "evaluations", "fitness"}
+	)
+	for i := 0; i < nbGenes; i++ {
+		header = append(header, "gene_"+strconv.Itoa(i))
+	}
+	cw.Write(header)
+	for i, snapshot := range snapshots {
+		var row = []string{
+			strconv.Itoa(snapshot.Generation),
+			strconv.Itoa(snapshot.Evaluations),
+			formatFloat(snapshot.Fitness),
+		}
+		cw.Write(append(row, fitColumns(genes[i], nbGenes)...))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Forget the recorded snapshots.
+func (h *BestHistory) reset() {
+	h.mu.Lock()
+	h.snapshots = nil
+	h.mu.Unlock()
+}
+
+// Return a copy of the history with the same snapshots.
+func (h *BestHistory) clone() *BestHistory {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return &BestHistory{
+		Every:     h.Every,
+		snapshots: append([]BestSnapshot(nil), h.snapshots...),
+	}
+}
@@ -0,0 +1,88 @@
+package gago
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+)
+
+func TestBestHistory(t *testing.T) {
+	var g = newRunGA()
+	g.History = &BestHistory{Every: 3}
+	g.Initialize()
+	for i := 0; i < 7; i++ {
+		g.Enhance()
+	}
+	var snapshots = g.History.Snapshots()
+	if len(snapshots) != 3 {
+		t.Fatalf("Expected 3 snapshots, got %d", len(snapshots))
+	}
+	for i, snapshot := range snapshots {
+		if snapshot.Generation != 3*i {
+			t.Errorf("Expected generation %d, got %d", 3*i, snapshot.Generation)
+		}
+		if len(snapshot.Genome) != nbGenes || snapshot.Fitness != ff.apply(snapshot.Genome) {
+			t.Error("The snapshot should hold the genome of the best individual")
+		}
+		if i > 0 && (snapshot.Fitness > snapshots[i-1].Fitness || snapshot.Evaluations <= snapshots[i-1].Evaluations) {
+			t.Error("The best individual shouldn't get worse")
+		}
+	}
+	if len(g.Stats().History) != 3 {
+		t.Error("The statistics should report the history")
+	}
+	// The history is reset by Initialize
+	g.Initialize()
+	if len(g.History.Snapshots()) != 1 {
+		t.Error("The history should be reset")
+	}
+}
+
+func TestBestHistoryWriteCSV(t *testing.T) {
+	var history = &BestHistory{
+		snapshots: []BestSnapshot{
+			{Generation: 0, Evaluations: 10, Fitness: 2, Genome: Genome{1.5, 0.5}},
+			{Generation: 1, Evaluations: 20, Fitness: 1, Genome: Genome{1.0}},
+		},
+	}
+	var buf bytes.Buffer
+	if err := history.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var rows, err = csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected = [][]string{
+		{"generation", "evaluations", "fitness", "gene_0", "gene_1"},
+		{"0", "10", "2", "1.5", "0.5"},
+		{"1", "20", "1", "1", ""},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("Expected %d rows, got %d", len(expected), len(rows))
+	}
+	for i := range rows {
+		for j := range rows[i] {
+			if rows[i][j] != expected[i][j] {
+				t.Errorf("Expected %q at row %d column %d, got %q", expected[i][j], i, j, rows[i][j])
+			}
+		}
+	}
+}
+
+func TestRunHistory(t *testing.T) {
+	var (
+		g       = newRunGA()
+		history bytes.Buffer
+	)
+	g.History = &BestHistory{}
+	g.Initialize()
+	if _, _, err := g.Run(context.Background(), RunOptions{MaxGenerations: 4, History: &history}); err != nil {
+		t.Fatal(err)
+	}
+	var rows, _ = csv.NewReader(&history).ReadAll()
+	if len(rows) != 6 {
+		t.Errorf("Expected a header and 5 rows, got %d rows", len(rows))
+	}
+}
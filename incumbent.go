@@ -0,0 +1,59 @@
+package gago
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// IncumbentFunction is for functions whose cost accumulates during the
+// evaluation, such as a simulation that sums a cost over time steps, and that
+// can abort once the cost exceeds the fitness of the incumbent, the best
+// individual evaluated so far. Image receives the genome along with the
+// fitness of the incumbent, which is +Inf before the first evaluation, and
+// can return the partial cost as soon as it's higher than the incumbent's
+// fitness; the partial cost is a lower bound of the fitness, hence the aborted
+// individual still ranks below the incumbent.
+//
+// The incumbent is shared by the populations, which evaluate their
+// individuals in parallel, and it's updated atomically after each evaluation,
+// hence an evaluation benefits from the improvements found during the same
+// generation by the other populations. The incumbent is the individual with
+// the lowest fitness, whatever the Comparator of the GA, and it's reset when
+// the GA is initialized. Incumbent returns it's fitness, for example to be
+// read by evaluators that run in another goroutine. IncumbentFunction has to
+// be used through a pointer.
+type IncumbentFunction struct {
+	Image func(genome Genome, incumbent float64) float64
+	// Bits of the incumbent's fitness XORed with the bits of +Inf, so that
+	// the zero value stands for +Inf
+	incumbent atomic.Uint64
+}
+
+var infBits = math.Float64bits(math.Inf(1))
+
+// Incumbent returns the fitness of the best individual evaluated so far.
+func (ff *IncumbentFunction) Incumbent() float64 {
+	return math.Float64frombits(ff.incumbent.Load() ^ infBits)
+}
+
+// Apply the function wrapped in IncumbentFunction and update the incumbent.
+func (ff *IncumbentFunction) apply(genome Genome) float64 {
+	var fitness = ff.Image(genome, ff.Incumbent())
+	for {
+		var old = ff.incumbent.Load()
+		if !(fitness < math.Float64frombits(old^infBits)) {
+			break
+		}
+		if ff.incumbent.CompareAndSwap(old, math.Float64bits(fitness)^infBits) {
+			break
+		}
+	}
+	return fitness
+}
+
+// Reset the incumbent when the GA is initialized.
+func (ff *IncumbentFunction) schedule(generations, evaluations int, pops Populations) {
+	if generations == 0 {
+		ff.incumbent.Store(0)
+	}
+}
@@ -0,0 +1,89 @@
+package gago
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Return a function that sums the squares of the genes and aborts once the sum
+// exceeds the incumbent, along with the number of genes it looked at.
+func newIncumbentFunction() (*IncumbentFunction, *int64) {
+	var steps = new(int64)
+	return &IncumbentFunction{
+		Image: func(genome Genome, incumbent float64) float64 {
+			var cost float64
+			for _, gene := range genome {
+				atomic.AddInt64(steps, 1)
+				cost += gene.(float64) * gene.(float64)
+				if cost > incumbent {
+					break
+				}
+			}
+			return cost
+		},
+	}, steps
+}
+
+func TestIncumbentFunction(t *testing.T) {
+	var ff, steps = newIncumbentFunction()
+	if !math.IsInf(ff.Incumbent(), 1) {
+		t.Error("The incumbent should be +Inf before the first evaluation")
+	}
+	if ff.apply(Genome{1.0, 1.0}) != 2 || ff.Incumbent() != 2 {
+		t.Error("The incumbent wasn't updated")
+	}
+	// The evaluation is aborted after the first gene
+	*steps = 0
+	if ff.apply(Genome{3.0, 1.0, 1.0}) != 9 || *steps != 1 {
+		t.Error("The evaluation wasn't aborted")
+	}
+	if ff.Incumbent() != 2 {
+		t.Error("The incumbent was replaced by a worse individual")
+	}
+	ff.apply(Genome{0.0, 0.0})
+	if ff.Incumbent() != 0 {
+		t.Error("The incumbent should be 0")
+	}
+	ff.schedule(0, 0, nil)
+	if !math.IsInf(ff.Incumbent(), 1) {
+		t.Error("The incumbent wasn't reset")
+	}
+}
+
+func TestIncumbentFunctionConcurrent(t *testing.T) {
+	var (
+		ff, _ = newIncumbentFunction()
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ff.apply(Genome{float64(i)})
+		}(i)
+	}
+	wg.Wait()
+	if ff.Incumbent() != 0 {
+		t.Errorf("Expected an incumbent of 0, got %f", ff.Incumbent())
+	}
+}
+
+func TestIncumbentGA(t *testing.T) {
+	var (
+		ga        = NewFloatGA(4, -5, 5, func(x []float64) float64 { return 0 })
+		ff, steps = newIncumbentFunction()
+	)
+	ga.Ff = ff
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	if ff.Incumbent() != ga.Best().Fitness {
+		t.Error("The incumbent isn't the best individual")
+	}
+	if int(*steps) >= ga.Evaluations*4 {
+		t.Error("No evaluation was aborted")
+	}
+}
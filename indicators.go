@@ -0,0 +1,111 @@
+package gago
+
+import (
+	"math"
+	"sort"
+)
+
+// Hypervolume computes the volume of the objective space that is dominated by
+// a front and bounded by a reference point, all the objectives being
+// minimized. The larger the hypervolume the better the front, both in terms of
+// convergence and of spread. Points that don't dominate the reference point
+// don't contribute to the hypervolume. The computation slices the objective
+// space along the last objective and recurses on the remaining objectives,
+// which is exact but exponential in the number of objectives, hence it is
+// meant for problems with a handful of objectives.
+func Hypervolume(front [][]float64, ref []float64) float64 {
+	var points = make([][]float64, 0, len(front))
+	for _, point := range front {
+		if below(point, ref) {
+			points = append(points, point)
+		}
+	}
+	return hypervolume(points, ref)
+}
+
+// Check if each coordinate of a is strictly lower than the same coordinate of
+// b.
+func below(a, b []float64) bool {
+	for i := range a {
+		if a[i] >= b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Compute the hypervolume of points that all strictly dominate the reference
+// point.
+func hypervolume(points [][]float64, ref []float64) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+	var d = len(ref) - 1
+	if d == 0 {
+		var min = points[0][0]
+		for _, point := range points[1:] {
+			min = math.Min(min, point[0])
+		}
+		return ref[0] - min
+	}
+	var sorted = make([][]float64, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][d] < sorted[j][d] })
+	var volume float64
+	for i, point := range sorted {
+		var upper = ref[d]
+		if i < len(sorted)-1 {
+			upper = sorted[i+1][d]
+		}
+		if upper <= point[d] {
+			continue
+		}
+		// Every point below the slice contributes to it's cross-section
+		var section = make([][]float64, i+1)
+		for j := range section {
+			section[j] = sorted[j][:d]
+		}
+		volume += hypervolume(section, ref[:d]) * (upper - point[d])
+	}
+	return volume
+}
+
+// IGD computes the inverted generational distance of a front with regard to a
+// reference front, which is usually a sampling of the true Pareto front. It is
+// the mean distance from each reference point to the closest point of the
+// front, hence the lower the IGD the better the front. The IGD of an empty
+// front, or with regard to an empty reference front, is +Inf.
+func IGD(front, reference [][]float64) float64 {
+	if len(front) == 0 || len(reference) == 0 {
+		return math.Inf(1)
+	}
+	var total float64
+	for _, r := range reference {
+		var closest = math.Inf(1)
+		for _, point := range front {
+			closest = math.Min(closest, euclidean(point, r))
+		}
+		total += closest
+	}
+	return total / float64(len(reference))
+}
+
+// Compute the euclidean distance between two vectors of objectives.
+func euclidean(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += math.Pow(a[i]-b[i], 2)
+	}
+	return math.Sqrt(sum)
+}
+
+// Objectives returns the objectives of each individual, the objectives of an
+// individual that wasn't evaluated by an ObjectivesFunction consist of it's
+// fitness.
+func (indis Individuals) Objectives() [][]float64 {
+	var objs = make([][]float64, len(indis))
+	for i, indi := range indis {
+		objs[i] = objectives(indi)
+	}
+	return objs
+}
@@ -0,0 +1,56 @@
+package gago
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHypervolume(t *testing.T) {
+	var testCases = []struct {
+		front  [][]float64
+		ref    []float64
+		volume float64
+	}{
+		{[][]float64{}, []float64{1, 1}, 0},
+		{[][]float64{{0, 0}}, []float64{1, 1}, 1},
+		{[][]float64{{0, 1}, {1, 0}}, []float64{2, 2}, 3},
+		{[][]float64{{0, 1}, {1, 0}, {1, 1}}, []float64{2, 2}, 3},
+		{[][]float64{{0, 1}, {3, 0}}, []float64{2, 2}, 2},
+		{[][]float64{{0, 0, 0}}, []float64{1, 2, 3}, 6},
+		{[][]float64{{0, 1, 1}, {1, 0, 1}, {1, 1, 0}}, []float64{2, 2, 2}, 4},
+		{[][]float64{{1}, {0.5}}, []float64{2}, 1.5},
+	}
+	for _, test := range testCases {
+		if v := Hypervolume(test.front, test.ref); math.Abs(v-test.volume) > 1e-10 {
+			t.Errorf("Expected a hypervolume of %f, got %f", test.volume, v)
+		}
+	}
+}
+
+func TestIGD(t *testing.T) {
+	var reference = [][]float64{{0, 1}, {1, 0}}
+	if igd := IGD(reference, reference); igd != 0 {
+		t.Errorf("The IGD of the reference front should be 0, got %f", igd)
+	}
+	if igd := IGD([][]float64{{0, 1}}, reference); math.Abs(igd-math.Sqrt(2)/2) > 1e-10 {
+		t.Errorf("Expected an IGD of %f, got %f", math.Sqrt(2)/2, igd)
+	}
+	if !math.IsInf(IGD([][]float64{}, reference), 1) {
+		t.Error("The IGD of an empty front should be +Inf")
+	}
+	if !math.IsInf(IGD(reference, [][]float64{}), 1) {
+		t.Error("The IGD with regard to an empty reference front should be +Inf")
+	}
+}
+
+func TestStatsIndicators(t *testing.T) {
+	var archive = &ParetoArchive{
+		ReferencePoint: []float64{2, 2},
+		ReferenceFront: [][]float64{{0, 1}, {1, 0}},
+	}
+	archive.Update(Individuals{makeObjectives(0, 1), makeObjectives(1, 0)})
+	var stats = GA{Archive: archive}.Stats()
+	if stats.FrontSize != 2 || stats.Hypervolume != 3 || stats.IGD != 0 {
+		t.Errorf("Wrong front statistics: %+v", stats)
+	}
+}
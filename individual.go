@@ -1,14 +1,27 @@
 package gago
 
 import (
+	"bytes"
 	"math"
 	"math/rand"
 	"sort"
+	"sync"
 )
 
 // EVALUATIONS tracks the total number of times the fitness function was evaluated
 var EVALUATIONS = 0
 
+// Populations are evaluated in parallel, hence EVALUATIONS is incremented
+// while holding a lock.
+var evaluationsMutex sync.Mutex
+
+// Increment EVALUATIONS by n.
+func countEvaluations(n int) {
+	evaluationsMutex.Lock()
+	EVALUATIONS += n
+	evaluationsMutex.Unlock()
+}
+
 // A Genome contains genes
 type Genome []interface{}
 
@@ -17,36 +30,179 @@ type Genome []interface{}
 // floating point numbers. The fitness is the individual's phenotype and is
 // represented by a floating point number.
 type Individual struct {
-	Genome    Genome
-	Fitness   float64
-	Evaluated bool
-	Name      string
+	Genome     Genome
+	Fitness    float64
+	Evaluated  bool
+	Name       string
+	ID         int       // Identifier that is unique within a run, 0 until the individual joins a population of a GA
+	Birth      int       // Generation in which the individual joined a population
+	Origin     int       // Index of the population the individual was born in
+	Cases      []float64 // Error on each test case, only set by a CasesFunction
+	Objectives []float64 // Value of each objective, only set by an ObjectivesFunction
+	Violation  float64   // Total violation of the constraints, only set by a ConstrainedFunction
+	Fidelity   int       // Fidelity at which the individual was evaluated, only set by a FidelityFunction
+	// Extra information attached to the individual by operators or callbacks,
+	// for example it's age or it's species, see SetMeta
+	Metadata map[string]interface{}
+	failed   bool  // The evaluation failed and the individual should be regenerated, see ErrFunction
+	err      error // Error that made the last evaluation fail, see ErrFunction
+}
+
+// SetMeta attaches a value to an individual under a key. Individuals are
+// copied by value, for example by the selectors, hence the copies of an
+// individual share it's metadata map. SetMeta copies the map before modifying
+// it so that the copies aren't affected, the map shouldn't be modified
+// directly.
+func (indi *Individual) SetMeta(key string, value interface{}) {
+	var metadata = make(map[string]interface{}, len(indi.Metadata)+1)
+	for k, v := range indi.Metadata {
+		metadata[k] = v
+	}
+	metadata[key] = value
+	indi.Metadata = metadata
+}
+
+// Meta returns the value attached to an individual under a key, nil is
+// returned if there is no such value.
+func (indi Individual) Meta(key string) interface{} {
+	return indi.Metadata[key]
 }
 
 // Generate a new individual.
 func makeIndividual(nbGenes int, rng *rand.Rand) Individual {
 	return Individual{
-		Genome:    make([]interface{}, nbGenes),
+		Genome:    newGenome(nbGenes),
 		Fitness:   math.Inf(1),
 		Evaluated: false,
 		Name:      randomString(6, rng),
 	}
 }
 
+// Genomes that are no longer referenced by any individual are kept in a pool
+// to relieve the garbage collector, the genes of a pooled genome are nil. The
+// pool contains batches of genomes rather than single genomes so that putting
+// a generation back in the pool only costs one allocation.
+var genomePool sync.Pool
+
+// Get a genome of nbGenes genes from the pool, a new genome is allocated if the
+// pool is empty or if the pooled genome is too small.
+func newGenome(nbGenes int) Genome {
+	if nbGenes > 0 {
+		if batch, ok := genomePool.Get().(*[]Genome); ok {
+			var g = (*batch)[len(*batch)-1]
+			*batch = (*batch)[:len(*batch)-1]
+			if len(*batch) > 0 {
+				genomePool.Put(batch)
+			}
+			if cap(g) >= nbGenes {
+				return g[:nbGenes]
+			}
+		}
+	}
+	return make(Genome, nbGenes)
+}
+
+// Put the genomes of individuals back in the pool. This should only be done if
+// the genomes aren't referenced anywhere else, including by other individuals.
+func (indis Individuals) recycle() {
+	var batch = make([]Genome, 0, len(indis))
+	for i := range indis {
+		var g = indis[i].Genome[:cap(indis[i].Genome)]
+		indis[i].Genome = nil
+		if len(g) == 0 {
+			continue
+		}
+		for j := range g {
+			g[j] = nil
+		}
+		batch = append(batch, g)
+	}
+	if len(batch) > 0 {
+		genomePool.Put(&batch)
+	}
+}
+
+// Clone an individual by copying it's genome so that the clone can be modified
+// without altering the original individual. The clone is given a new name and
+// is a new individual, hence it doesn't keep the ID of the original.
+func (indi Individual) clone(rng *rand.Rand) Individual {
+	var clone = indi
+	clone.Genome = newGenome(len(indi.Genome))
+	copy(clone.Genome, indi.Genome)
+	clone.Name = randomString(6, rng)
+	clone.ID, clone.Birth, clone.Origin = 0, 0, 0
+	return clone
+}
+
 // Evaluate the fitness of an individual.
 func (indi *Individual) Evaluate(ff FitnessFunction) {
 	// Don't evaluate individuals that have already been evaluated
 	if indi.Evaluated == false {
-		indi.Fitness = ff.apply(indi.Genome)
-		EVALUATIONS++
+		var (
+			f, counted = uncount(ff)
+			start      = counted.profiler.now()
+			guard      = counted.guard
+			n          = 1
+		)
+		// The failed evaluations of a failing function are already handled,
+		// their fitness is +Inf on purpose
+		if _, ok := f.(failingFunction); ok {
+			guard = nil
+		}
+		indi.apply(f)
+		// Evaluate the individual again if the fitness isn't finite and the
+		// guard says so
+		for ; guard.retry(*indi, n); n++ {
+			indi.apply(f)
+		}
+		guard.check(indi)
+		counted.record(n, start)
+		countEvaluations(n)
 	}
 	indi.Evaluated = true
 }
 
-// Mutate applies a mutator to an individual and sets it's `Evaluated` property to `false`.
+// Apply a fitness function, which isn't counted, to an individual.
+func (indi *Individual) apply(f FitnessFunction) {
+	// Constrained fitness functions also provide the violation of the
+	// constraints, the fitness is computed by the function they wrap
+	if cf, ok := f.(ConstrainedFunction); ok {
+		indi.Violation = cf.Violation(indi.Genome)
+		f = cf.Function
+	}
+	switch f := f.(type) {
+	// Case based fitness functions also provide the error on each case
+	case casesFunction:
+		indi.Cases = f.applyCases(indi.Genome)
+		indi.Fitness = sum(indi.Cases)
+	// Multi-objective fitness functions provide the value of each objective
+	case objectivesFunction:
+		indi.Objectives = f.applyObjectives(indi.Genome)
+		indi.Fitness = f.aggregate(indi.Objectives)
+	// Multi-fidelity fitness functions evaluate at the base fidelity
+	case fidelityFunction:
+		indi.Fidelity = f.fidelity()
+		indi.Fitness = f.applyFidelity(indi.Genome, indi.Fidelity)
+	// Failing fitness functions tell if the individual should be replaced
+	case failingFunction:
+		indi.Fitness, indi.failed, indi.err = f.applyFailing(indi.Genome)
+	default:
+		indi.Fitness = f.apply(indi.Genome)
+	}
+}
+
+// Feasible returns true if the individual doesn't violate the constraints of
+// the problem, see ConstrainedFunction.
+func (indi Individual) Feasible() bool {
+	return indi.Violation <= 0
+}
+
+// Mutate applies a mutator to an individual and sets it's `Evaluated` property
+// to `false`. The property is set before applying the mutator so that mutators
+// that evaluate the individual themselves don't cause a second evaluation.
 func (indi *Individual) Mutate(mutator Mutator, rng *rand.Rand) {
-	mutator.Apply(indi, rng)
 	indi.Evaluated = false
+	mutator.Apply(indi, rng)
 }
 
 // Individuals type is necessary for sorting and selection purposes.
@@ -61,15 +217,134 @@ func makeIndividuals(nbIndis, nbGenes int, rng *rand.Rand) Individuals {
 	return indis
 }
 
-// Evaluate each individual
+// Evaluate each individual. If the fitness function evaluates batches of
+// genomes then the individuals that haven't been evaluated are sent in a
+// single batch. If the GA deduplicates evaluations then the individuals that
+// have the same genome are only evaluated once.
 func (indis Individuals) Evaluate(ff FitnessFunction) {
+	if _, counted := uncount(ff); counted.deduplicate {
+		indis.evaluateDistinct(ff)
+		return
+	}
+	indis.evaluate(ff)
+}
+
+// Evaluate the individuals whose genomes are distinct and share the results
+// with the individuals that have the same genome. Genomes are identified by
+// their binary encoding, which is exact, the individuals whose genes can't be
+// encoded are always evaluated.
+func (indis Individuals) evaluateDistinct(ff FitnessFunction) {
+	var (
+		buf      bytes.Buffer
+		bw       = binaryWriter{w: &buf}
+		firsts   = make(map[string]int)
+		distinct Individuals
+		origins  []int
+		sources  = make([]int, len(indis))
+	)
+	for i, indi := range indis {
+		sources[i] = -1
+		if indi.Evaluated {
+			continue
+		}
+		buf.Reset()
+		bw.err = nil
+		bw.genome(indi.Genome)
+		if bw.err == nil {
+			if j, ok := firsts[string(buf.Bytes())]; ok {
+				sources[i] = j
+				continue
+			}
+			firsts[buf.String()] = len(distinct)
+		}
+		sources[i] = len(distinct)
+		distinct = append(distinct, indi)
+		origins = append(origins, i)
+	}
+	distinct.evaluate(ff)
+	for i, j := range sources {
+		switch {
+		case j < 0:
+		case origins[j] == i:
+			indis[i] = distinct[j]
+		default:
+			indis[i].Fitness = distinct[j].Fitness
+			indis[i].Cases = distinct[j].Cases
+			indis[i].Objectives = distinct[j].Objectives
+			indis[i].Violation = distinct[j].Violation
+			indis[i].Fidelity = distinct[j].Fidelity
+			indis[i].failed = distinct[j].failed
+			indis[i].err = distinct[j].err
+			indis[i].Evaluated = true
+		}
+	}
+}
+
+// Evaluate each individual without deduplicating the genomes.
+func (indis Individuals) evaluate(ff FitnessFunction) {
+	var f, counted = uncount(ff)
+	if bf, ok := f.(batchFunction); ok {
+		var (
+			indexes []int
+			genomes []Genome
+		)
+		for i, indi := range indis {
+			if !indi.Evaluated {
+				indexes = append(indexes, i)
+				genomes = append(genomes, indi.Genome)
+			}
+		}
+		if len(genomes) == 0 {
+			return
+		}
+		var start = counted.profiler.now()
+		for j, fitness := range bf.applyBatch(genomes) {
+			indis[indexes[j]].Fitness = fitness
+			indis[indexes[j]].Evaluated = true
+			counted.guard.check(&indis[indexes[j]])
+		}
+		counted.record(len(genomes), start)
+		countEvaluations(len(genomes))
+		return
+	}
 	for i := range indis {
 		indis[i].Evaluate(ff)
 	}
 }
 
+// Return the genomes of the individuals.
+func (indis Individuals) genomes() []Genome {
+	var genomes = make([]Genome, len(indis))
+	for i, indi := range indis {
+		genomes[i] = indi.Genome
+	}
+	return genomes
+}
+
+// Count the individuals that haven't been evaluated.
+func (indis Individuals) unevaluated() int {
+	var n int
+	for _, indi := range indis {
+		if !indi.Evaluated {
+			n++
+		}
+	}
+	return n
+}
+
 // Mutate is a convenience function for mutating each individual in a slice of individuals.
+// If the mutator is a PairMutator the individuals are mutated two at a time,
+// consecutive individuals being considered as siblings, see mutateSiblings.
 func (indis Individuals) Mutate(mutator Mutator, mutRate float64, rng *rand.Rand) {
+	if _, ok := mutator.(PairMutator); ok {
+		for i := 0; i+1 < len(indis); i += 2 {
+			mutateSiblings(&indis[i], &indis[i+1], mutator, mutRate, rng)
+		}
+		if len(indis)%2 == 1 && rng.Float64() < mutRate {
+			indis[len(indis)-1].Mutate(mutator, rng)
+		}
+		return
+	}
 	for i := range indis {
 		if rng.Float64() < mutRate {
 			indis[i].Mutate(mutator, rng)
@@ -77,11 +352,30 @@ func (indis Individuals) Mutate(mutator Mutator, mutRate float64, rng *rand.Rand
 	}
 }
 
+// Mutate two sibling offsprings, each one with probability mutRate. If the
+// mutator is a PairMutator both offsprings are mutated at once with
+// probability mutRate.
+func mutateSiblings(indi1, indi2 *Individual, mutator Mutator, mutRate float64, rng *rand.Rand) {
+	if pair, ok := mutator.(PairMutator); ok {
+		if rng.Float64() < mutRate {
+			indi1.Evaluated, indi2.Evaluated = false, false
+			pair.ApplyPair(indi1, indi2, rng)
+		}
+		return
+	}
+	if rng.Float64() < mutRate {
+		indi1.Mutate(mutator, rng)
+	}
+	if rng.Float64() < mutRate {
+		indi2.Mutate(mutator, rng)
+	}
+}
+
 // Sort the individuals of a population in ascending order based on their
 // fitness. The convention is that we always want to minimize a function. A
 // function f(x) can be function maximized by minimizing -f(x) or 1/f(x).
 func (indis Individuals) Len() int           { return len(indis) }
-func (indis Individuals) Less(i, j int) bool { return indis[i].Fitness < indis[j].Fitness }
+func (indis Individuals) Less(i, j int) bool { return lessFitness(indis[i].Fitness, indis[j].Fitness) }
 func (indis Individuals) Swap(i, j int)      { indis[i], indis[j] = indis[j], indis[i] }
 
 // Sort is a convenience method for calling the Sort method of the sort package
@@ -72,9 +72,9 @@ func TestIndividualsSort(t *testing.T) {
 func TestGetFitnesses(t *testing.T) {
 	var (
 		indis = Individuals{
-			Individual{nil, 0.0, false, "a"},
-			Individual{nil, 1.0, false, "b"},
-			Individual{nil, 2.0, false, "c"},
+			Individual{Fitness: 0.0, Name: "a"},
+			Individual{Fitness: 1.0, Name: "b"},
+			Individual{Fitness: 2.0, Name: "c"},
 		}
 		target    = []float64{0.0, 1.0, 2.0}
 		fitnesses = indis.getFitnesses()
@@ -92,15 +92,15 @@ func TestFitnessMean(t *testing.T) {
 		mean  float64
 	}{
 		{Individuals{
-			Individual{nil, 1.0, false, "a"},
+			Individual{Fitness: 1.0, Name: "a"},
 		}, 1.0},
 		{Individuals{
-			Individual{nil, 1.0, false, "a"},
-			Individual{nil, 2.0, false, "b"},
+			Individual{Fitness: 1.0, Name: "a"},
+			Individual{Fitness: 2.0, Name: "b"},
 		}, 1.5},
 		{Individuals{
-			Individual{nil, -1.0, false, "a"},
-			Individual{nil, 1.0, false, "b"},
+			Individual{Fitness: -1.0, Name: "a"},
+			Individual{Fitness: 1.0, Name: "b"},
 		}, 0.0},
 	}
 	for _, testCase := range testCases {
@@ -116,15 +116,15 @@ func TestFitnessVariance(t *testing.T) {
 		variance float64
 	}{
 		{Individuals{
-			Individual{nil, 1.0, false, "a"},
+			Individual{Fitness: 1.0, Name: "a"},
 		}, 0.0},
 		{Individuals{
-			Individual{nil, -1.0, false, "a"},
-			Individual{nil, 1.0, false, "b"},
+			Individual{Fitness: -1.0, Name: "a"},
+			Individual{Fitness: 1.0, Name: "b"},
 		}, 1.0},
 		{Individuals{
-			Individual{nil, -2.0, false, "a"},
-			Individual{nil, 2.0, false, "b"},
+			Individual{Fitness: -2.0, Name: "a"},
+			Individual{Fitness: 2.0, Name: "b"},
 		}, 4.0},
 	}
 	for _, testCase := range testCases {
@@ -133,3 +133,123 @@ func TestFitnessVariance(t *testing.T) {
 		}
 	}
 }
+
+func TestUnevaluated(t *testing.T) {
+	var indis = Individuals{
+		Individual{Evaluated: true},
+		Individual{Evaluated: false},
+		Individual{Evaluated: false},
+	}
+	if indis.unevaluated() != 2 {
+		t.Error("unevaluated didn't count the unevaluated individuals")
+	}
+}
+
+func TestRecycle(t *testing.T) {
+	var indis = makeIndividuals(3, 4, rand.New(rand.NewSource(time.Now().UnixNano())))
+	for i := range indis {
+		for j := range indis[i].Genome {
+			indis[i].Genome[j] = 42
+		}
+	}
+	indis.recycle()
+	for _, indi := range indis {
+		if indi.Genome != nil {
+			t.Error("Recycling didn't detach the genome of an individual")
+		}
+	}
+	// Whether the genome comes from the pool or not it should be empty
+	for _, n := range []int{0, 2, 4, 8} {
+		var genome = newGenome(n)
+		if len(genome) != n {
+			t.Error("newGenome returned a genome of invalid length")
+		}
+		for _, gene := range genome {
+			if gene != nil {
+				t.Error("newGenome returned a genome that isn't empty")
+			}
+		}
+	}
+}
+
+func TestEvaluateDistinct(t *testing.T) {
+	var (
+		count int64
+		calls int
+		ff    = countedFunction{
+			ff: GenomeFunction{func(genome Genome) float64 {
+				calls++
+				return float64(len(genome))
+			}},
+			count:       &count,
+			deduplicate: true,
+		}
+		indis = Individuals{
+			{Genome: Genome{1, true}},
+			{Genome: Genome{1, true}},
+			{Genome: Genome{1, false}},
+			{Genome: Genome{1, true}, Evaluated: true, Fitness: 42},
+			{Genome: Genome{struct{}{}}},
+			{Genome: Genome{struct{}{}}},
+		}
+	)
+	indis.Evaluate(ff)
+	// The genomes that can't be encoded are evaluated separately
+	if calls != 4 || count != 4 {
+		t.Errorf("Expected 4 evaluations, got %d", calls)
+	}
+	for i, indi := range indis {
+		if !indi.Evaluated {
+			t.Errorf("Individual %d wasn't evaluated", i)
+		}
+	}
+	if indis[1].Fitness != 2 || indis[3].Fitness != 42 {
+		t.Error("The fitnesses weren't shared correctly")
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	var g = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 50,
+		NbrGenes:       3,
+		Initializer:    InitUniformB{},
+		Ff: GenomeFunction{func(genome Genome) float64 {
+			return 0
+		}},
+		Model: ModGenerational{
+			Selector:  SelTournament{NbParticipants: 3},
+			Crossover: CrossUniform{},
+		},
+		Deduplicate: true,
+	}
+	g.Initialize()
+	g.Enhance()
+	// There are only 8 distinct genomes of 3 bits
+	if g.Evaluations > 16 {
+		t.Errorf("Expected at most 16 evaluations, got %d", g.Evaluations)
+	}
+}
+
+func TestSetMeta(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indi = makeIndividual(2, rng)
+	)
+	if indi.Meta("age") != nil {
+		t.Error("A new individual shouldn't have metadata")
+	}
+	indi.SetMeta("age", 1)
+	var (
+		copied = indi
+		clone  = indi.clone(rng)
+	)
+	copied.SetMeta("age", 2)
+	clone.SetMeta("species", 7)
+	if indi.Meta("age") != 1 || indi.Meta("species") != nil {
+		t.Error("Modifying the metadata of a copy modified the original")
+	}
+	if copied.Meta("age") != 2 || clone.Meta("age") != 1 || clone.Meta("species") != 7 {
+		t.Error("The metadata didn't survive copying")
+	}
+}
@@ -1,6 +1,9 @@
 package gago
 
-import "math/rand"
+import (
+	"math/rand"
+	"sync"
+)
 
 // The Initializer is here to create the first generation of individuals in a
 // population. It applies to an individual level and instantiates it's genome gene by
@@ -41,6 +44,29 @@ func (init InitGaussianF) Apply(indi *Individual, rng *rand.Rand) {
 	}
 }
 
+// InitUniformI generates random integers x such that lower <= x <= upper.
+type InitUniformI struct {
+	Lower, Upper int
+}
+
+// Apply the InitUniformI initializer.
+func (init InitUniformI) Apply(indi *Individual, rng *rand.Rand) {
+	for i := range indi.Genome {
+		indi.Genome[i] = init.Lower + rng.Intn(init.Upper-init.Lower+1)
+	}
+}
+
+// InitUniformB generates random bits, each bit has a probability of 0.5 of
+// being set.
+type InitUniformB struct{}
+
+// Apply the InitUniformB initializer.
+func (init InitUniformB) Apply(indi *Individual, rng *rand.Rand) {
+	for i := range indi.Genome {
+		indi.Genome[i] = rng.Float64() < 0.5
+	}
+}
+
 // InitUniformS generates random string slices based on a given corpus.
 type InitUniformS struct {
 	Corpus []string
@@ -69,3 +95,138 @@ func (init InitUniqueS) Apply(indi *Individual, rng *rand.Rand) {
 		indi.Genome[i] = strings[i]
 	}
 }
+
+// InitPermutationI generates permutation genomes, each genome contains the
+// integers from 0 to the number of genes minus 1 in a random order. Such
+// genomes can be used for ordering problems, for example with CrossPMX and
+// MutPermute, and they can be compared with DistKendallTau and DistSwap.
+type InitPermutationI struct{}
+
+// Apply the InitPermutationI initializer.
+func (init InitPermutationI) Apply(indi *Individual, rng *rand.Rand) {
+	for i, j := range rng.Perm(len(indi.Genome)) {
+		indi.Genome[i] = j
+	}
+}
+
+// InitKnapsackB generates boolean genomes that select items to put in a
+// knapsack, gene i indicates if the item of weight Weights[i] and of value
+// Values[i] is selected. The items are considered by decreasing value to
+// weight ratio and each one is selected with probability Prob if it fits in
+// the remaining Capacity. A Prob of 1 produces the greedy solution, a lower
+// Prob produces varied solutions that are still reasonably good.
+type InitKnapsackB struct {
+	Weights, Values []float64
+	Capacity        float64
+	Prob            float64
+}
+
+// Apply the InitKnapsackB initializer.
+func (init InitKnapsackB) Apply(indi *Individual, rng *rand.Rand) {
+	var weight float64
+	for i := range indi.Genome {
+		indi.Genome[i] = false
+	}
+	for _, i := range ratioOrder(init.Weights, init.Values) {
+		if weight+init.Weights[i] <= init.Capacity && rng.Float64() < init.Prob {
+			indi.Genome[i] = true
+			weight += init.Weights[i]
+		}
+	}
+}
+
+// InitHaltonF generates floating points x such that lower <= x < upper by
+// walking through a Halton sequence. Successive individuals receive successive
+// points of the sequence, which means the initial population covers the search
+// space more uniformly than with InitUniformF. The initializer keeps track of
+// the index of the sequence, hence it has to be used through a pointer.
+type InitHaltonF struct {
+	Lower, Upper float64
+	mu           sync.Mutex
+	index        uint64
+}
+
+// Apply the InitHaltonF initializer.
+func (init *InitHaltonF) Apply(indi *Individual, rng *rand.Rand) {
+	// The first point of the sequence is the origin, hence it is skipped
+	init.mu.Lock()
+	init.index++
+	var index = init.index
+	init.mu.Unlock()
+	for i, x := range haltonPoint(index, len(indi.Genome)) {
+		indi.Genome[i] = init.Lower + x*(init.Upper-init.Lower)
+	}
+}
+
+// InitLatinHypercubeF generates floating points x such that lower <= x < upper
+// by Latin hypercube sampling. The genomes are generated by batches of Size
+// genomes: within a batch the range of each gene is split into Size intervals
+// of the same width and each interval receives the gene of exactly one genome.
+// Hence each batch covers every dimension evenly, which isn't guaranteed with
+// InitUniformF. Size is usually the number of individuals of a population, a
+// new batch starts once Size genomes have been generated. The initializer
+// keeps track of the current batch, hence it has to be used through a pointer.
+type InitLatinHypercubeF struct {
+	Lower, Upper float64
+	Size         int
+	mu           sync.Mutex
+	strata       [][]int // Permutation of the intervals of each gene within the current batch
+	next         int     // Index of the next genome within the current batch
+}
+
+// Apply the InitLatinHypercubeF initializer.
+func (init *InitLatinHypercubeF) Apply(indi *Individual, rng *rand.Rand) {
+	var size = max(init.Size, 1)
+	init.mu.Lock()
+	// Start a new batch
+	if init.next == 0 || len(init.strata) != len(indi.Genome) {
+		init.strata = make([][]int, len(indi.Genome))
+		for i := range init.strata {
+			init.strata[i] = rng.Perm(size)
+		}
+		init.next = 0
+	}
+	var (
+		k      = init.next
+		strata = init.strata
+	)
+	init.next = (init.next + 1) % size
+	init.mu.Unlock()
+	for i := range indi.Genome {
+		var x = (float64(strata[i][k]) + rng.Float64()) / float64(size)
+		indi.Genome[i] = init.Lower + x*(init.Upper-init.Lower)
+	}
+}
+
+// InitSobolF generates floating points x such that lower <= x < upper by
+// walking through a Sobol sequence. It works in the same way as InitHaltonF but
+// behaves better in high dimensions. The number of genes can't exceed
+// SobolMaxDim.
+type InitSobolF struct {
+	Lower, Upper float64
+	mu           sync.Mutex
+	index        uint64
+	directions   [][sobolBits]uint32
+}
+
+// Apply the InitSobolF initializer.
+func (init *InitSobolF) Apply(indi *Individual, rng *rand.Rand) {
+	var dim = len(indi.Genome)
+	if err := checkSobolDim(dim); err != nil {
+		panic(err)
+	}
+	init.mu.Lock()
+	// Compute the direction numbers the first time they are needed
+	if len(init.directions) != dim {
+		init.directions = sobolDirections(dim)
+	}
+	init.index++
+	var (
+		index      = init.index
+		directions = init.directions
+	)
+	init.mu.Unlock()
+	for i, x := range sobolPoint(index, directions) {
+		indi.Genome[i] = init.Lower + x*(init.Upper-init.Lower)
+	}
+}
@@ -114,3 +114,118 @@ func TestStringUnique(t *testing.T) {
 		}
 	}
 }
+
+func TestPermutationI(t *testing.T) {
+	var (
+		src  = rand.NewSource(time.Now().UnixNano())
+		rng  = rand.New(src)
+		indi = makeIndividual(10, rng)
+		seen = make([]bool, 10)
+	)
+	InitPermutationI{}.Apply(&indi, rng)
+	// Check the genome contains each integer once
+	for _, gene := range indi.Genome {
+		var i, ok = gene.(int)
+		if !ok || i < 0 || i >= 10 || seen[i] {
+			t.Error("PermutationI didn't generate a permutation")
+			return
+		}
+		seen[i] = true
+	}
+}
+
+func TestKnapsackB(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indi = makeIndividual(4, rng)
+		init = InitKnapsackB{
+			Weights:  []float64{1, 2, 3, 4},
+			Values:   []float64{4, 4, 3, 2},
+			Capacity: 5,
+			Prob:     1,
+		}
+	)
+	init.Apply(&indi, rng)
+	// The greedy solution selects the items by decreasing ratio
+	for i, gene := range []bool{true, true, false, false} {
+		if indi.Genome[i] != gene {
+			t.Errorf("Expected %v, got %v", []bool{true, true, false, false}, indi.Genome)
+			break
+		}
+	}
+	// The capacity is always respected
+	init.Prob = 0.5
+	for i := 0; i < 20; i++ {
+		init.Apply(&indi, rng)
+		var weight float64
+		for j, gene := range indi.Genome {
+			if gene.(bool) {
+				weight += init.Weights[j]
+			}
+		}
+		if weight > init.Capacity {
+			t.Error("KnapsackB exceeded the capacity")
+		}
+	}
+}
+
+func TestQuasiRandomF(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		lower = -5.0
+		upper = 5.0
+		inits = []Initializer{
+			&InitHaltonF{Lower: lower, Upper: upper},
+			&InitSobolF{Lower: lower, Upper: upper},
+		}
+	)
+	for _, init := range inits {
+		var (
+			a = makeIndividual(4, rng)
+			b = makeIndividual(4, rng)
+		)
+		init.Apply(&a, rng)
+		init.Apply(&b, rng)
+		for i := range a.Genome {
+			// Check if gene is between boundaries
+			if a.Genome[i].(float64) < lower || upper <= a.Genome[i].(float64) {
+				t.Error("Quasi-random gene is out of bounds")
+			}
+		}
+		// Check successive individuals receive different points
+		if a.Genome[0] == b.Genome[0] {
+			t.Error("Quasi-random initializer didn't move along the sequence")
+		}
+	}
+}
+
+func TestLatinHypercubeF(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		size  = 10
+		init  = &InitLatinHypercubeF{Lower: -5, Upper: 5, Size: size}
+		indis = makeIndividuals(2*size, 3, rng)
+	)
+	for i := range indis {
+		init.Apply(&indis[i], rng)
+	}
+	// Each batch has a gene in each interval of each dimension
+	for b := 0; b < 2; b++ {
+		for j := 0; j < 3; j++ {
+			var seen = make([]bool, size)
+			for _, indi := range indis[b*size : (b+1)*size] {
+				var x = indi.Genome[j].(float64)
+				if x < -5 || x >= 5 {
+					t.Fatalf("Gene %f is out of bounds", x)
+				}
+				seen[int((x+5)/10*float64(size))] = true
+			}
+			for k, ok := range seen {
+				if !ok {
+					t.Errorf("Batch %d: no gene %d falls in interval %d", b, j, k)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package island
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A Codec serializes the migrants exchanged between nodes.
+type Codec interface {
+	Encode(w io.Writer, indis gago.Individuals) error
+	Decode(r io.Reader) (gago.Individuals, error)
+}
+
+// GobCodec serializes migrants with encoding/gob. Genes that aren't of a basic
+// type have to be registered with gob.Register.
+type GobCodec struct{}
+
+// Encode migrants with gob.
+func (codec GobCodec) Encode(w io.Writer, indis gago.Individuals) error {
+	return gob.NewEncoder(w).Encode(indis)
+}
+
+// Decode migrants with gob.
+func (codec GobCodec) Decode(r io.Reader) (gago.Individuals, error) {
+	var indis gago.Individuals
+	var err = gob.NewDecoder(r).Decode(&indis)
+	return indis, err
+}
+
+// JSONCodec serializes migrants with encoding/json, which is handy for
+// exchanging migrants with programs written in other languages. Numeric genes
+// are decoded as float64, hence JSONCodec is only suited to floating point,
+// string and boolean genomes.
+type JSONCodec struct{}
+
+// Encode migrants with JSON.
+func (codec JSONCodec) Encode(w io.Writer, indis gago.Individuals) error {
+	return json.NewEncoder(w).Encode(indis)
+}
+
+// Decode migrants with JSON.
+func (codec JSONCodec) Decode(r io.Reader) (gago.Individuals, error) {
+	var indis gago.Individuals
+	var err = json.NewDecoder(r).Decode(&indis)
+	return indis, err
+}
+
+// BinaryCodec serializes migrants with the compact binary format of
+// gago.EncodeIndividuals, which is the smallest and fastest codec but only
+// supports float64, int, bool and string genes.
+type BinaryCodec struct{}
+
+// Encode migrants in the binary format.
+func (codec BinaryCodec) Encode(w io.Writer, indis gago.Individuals) error {
+	return gago.EncodeIndividuals(w, indis)
+}
+
+// Decode migrants in the binary format.
+func (codec BinaryCodec) Decode(r io.Reader) (gago.Individuals, error) {
+	return gago.DecodeIndividuals(r)
+}
@@ -0,0 +1,38 @@
+package island
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MaxHalford/gago"
+)
+
+func TestCodecs(t *testing.T) {
+	var indis = gago.Individuals{
+		gago.Individual{Genome: gago.Genome{1.5, -2.0}, Fitness: 3, Evaluated: true, Name: "a"},
+		gago.Individual{Genome: gago.Genome{0.0, 4.25}, Fitness: 4, Evaluated: true, Name: "b"},
+	}
+	for _, codec := range []Codec{GobCodec{}, JSONCodec{}, BinaryCodec{}} {
+		var buf bytes.Buffer
+		if err := codec.Encode(&buf, indis); err != nil {
+			t.Fatal(err)
+		}
+		var decoded, err = codec.Decode(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded) != 2 {
+			t.Fatal("The codec didn't decode every individual")
+		}
+		for i, indi := range decoded {
+			if indi.Fitness != indis[i].Fitness || indi.Name != indis[i].Name || !indi.Evaluated {
+				t.Error("The codec didn't preserve the individual")
+			}
+			for j, gene := range indi.Genome {
+				if gene != indis[i].Genome[j] {
+					t.Error("The codec didn't preserve the genome")
+				}
+			}
+		}
+	}
+}
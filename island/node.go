@@ -0,0 +1,192 @@
+// Package island spreads the populations of a GA over several processes or
+// machines. Each process runs it's own GA whose Migrator is a Node, nodes send
+// migrants to their peers over TCP and integrate the migrants they receive at
+// each migration. The exchanges are asynchronous, a node never waits for it's
+// peers to reach the same generation.
+package island
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A Node is a gago.Migrator that exchanges migrants with other processes. At
+// each migration the NbMigrants best individuals of each local population are
+// sent to every peer and the migrants received since the previous migration
+// replace the worst individuals of randomly chosen local populations, which
+// are then sorted again. The migrants keep the fitness computed by the node
+// that sent them, hence every node should solve the same problem. Peers
+// contains the addresses the node sends migrants to, which defines the
+// topology; see Ring and Complete. A Node has to be used through a pointer
+// and started with Start before the GA is run.
+type Node struct {
+	Addr       string        // Address to listen on, for example ":4000"
+	Peers      []string      // Addresses of the nodes migrants are sent to
+	NbMigrants int           // Number of migrants sent by each population
+	Codec      Codec         // Serialization of the migrants, defaults to GobCodec
+	Timeout    time.Duration // Timeout for sending migrants, defaults to 5 seconds
+	listener   net.Listener
+	mu         sync.Mutex
+	inbox      gago.Individuals
+	err        error
+}
+
+// Return the codec of the node.
+func (node *Node) codec() Codec {
+	if node.Codec == nil {
+		return GobCodec{}
+	}
+	return node.Codec
+}
+
+// Start listening for migrants.
+func (node *Node) Start() error {
+	var listener, err = net.Listen("tcp", node.Addr)
+	if err != nil {
+		return err
+	}
+	node.listener = listener
+	go node.serve()
+	return nil
+}
+
+// Address returns the address the node is listening on, which is useful when
+// Addr requests a random port.
+func (node *Node) Address() string {
+	return node.listener.Addr().String()
+}
+
+// Close stops listening for migrants.
+func (node *Node) Close() error {
+	return node.listener.Close()
+}
+
+// Err returns the last error that occurred while exchanging migrants, the
+// errors don't interrupt the GA.
+func (node *Node) Err() error {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	return node.err
+}
+
+// Record an error.
+func (node *Node) fail(err error) {
+	node.mu.Lock()
+	node.err = err
+	node.mu.Unlock()
+}
+
+// Accept connections and store the received migrants in the inbox.
+func (node *Node) serve() {
+	for {
+		var conn, err = node.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			var migrants, err = node.codec().Decode(conn)
+			if err != nil {
+				node.fail(err)
+				return
+			}
+			node.mu.Lock()
+			node.inbox = append(node.inbox, migrants...)
+			node.mu.Unlock()
+		}()
+	}
+}
+
+// Send migrants to a peer.
+func (node *Node) send(peer string, migrants gago.Individuals) error {
+	var timeout = node.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	var conn, err = net.DialTimeout("tcp", peer, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+	return node.codec().Encode(conn, migrants)
+}
+
+// Apply sends migrants to the peers and integrates the received migrants.
+func (node *Node) Apply(pops gago.Populations) {
+	// The populations are sorted, hence the best individuals come first
+	var migrants gago.Individuals
+	for _, pop := range pops {
+		var n = node.NbMigrants
+		if n > len(pop.Individuals) {
+			n = len(pop.Individuals)
+		}
+		migrants = append(migrants, pop.Individuals[:n]...)
+	}
+	var wg sync.WaitGroup
+	for _, peer := range node.Peers {
+		wg.Add(1)
+		go func(peer string) {
+			defer wg.Done()
+			if err := node.send(peer, migrants); err != nil {
+				node.fail(err)
+			}
+		}(peer)
+	}
+	wg.Wait()
+	// Replace the worst individuals with the received migrants
+	node.mu.Lock()
+	var received = node.inbox
+	node.inbox = nil
+	node.mu.Unlock()
+	var replaced = make([]int, len(pops))
+	for _, migrant := range received {
+		// The population is drawn with the generator of the populations so
+		// that the runs can be seeded and replayed
+		var i int
+		if len(pops) > 1 {
+			i = pops[0].Rand().Intn(len(pops))
+		}
+		var indis = pops[i].Individuals
+		if replaced[i] < len(indis) {
+			indis[len(indis)-1-replaced[i]] = migrant
+			replaced[i]++
+		}
+	}
+	// The migrants may be better than the individuals they didn't replace
+	for i := range pops {
+		if replaced[i] > 0 {
+			pops[i].Sort()
+		}
+	}
+}
+
+// Remote tells the GA that the node exchanges migrants with other processes,
+// hence it is applied even if the GA has a single population.
+func (node *Node) Remote() bool {
+	return true
+}
+
+// Ring returns the peers of the i-th node out of nodes arranged in a ring,
+// each node sends migrants to the next one.
+func Ring(addrs []string, i int) []string {
+	if len(addrs) < 2 {
+		return nil
+	}
+	return []string{addrs[(i+1)%len(addrs)]}
+}
+
+// Complete returns the peers of the i-th node out of nodes that are all
+// connected with each other.
+func Complete(addrs []string, i int) []string {
+	var peers []string
+	for j, addr := range addrs {
+		if j != i {
+			peers = append(peers, addr)
+		}
+	}
+	return peers
+}
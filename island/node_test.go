@@ -0,0 +1,100 @@
+package island
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MaxHalford/gago"
+	"github.com/MaxHalford/gago/presets"
+)
+
+func TestTopologies(t *testing.T) {
+	var addrs = []string{"a", "b", "c"}
+	if peers := Ring(addrs, 2); len(peers) != 1 || peers[0] != "a" {
+		t.Error("Ring didn't connect the last node to the first one")
+	}
+	if peers := Complete(addrs, 1); len(peers) != 2 || peers[0] != "a" || peers[1] != "c" {
+		t.Error("Complete didn't connect a node to every other node")
+	}
+}
+
+func TestExchange(t *testing.T) {
+	var (
+		a = &Node{Addr: "127.0.0.1:0", NbMigrants: 2}
+		b = &Node{Addr: "127.0.0.1:0", NbMigrants: 2}
+	)
+	for _, node := range []*Node{a, b} {
+		if err := node.Start(); err != nil {
+			t.Fatal(err)
+		}
+		defer node.Close()
+	}
+	a.Peers = []string{b.Address()}
+	var (
+		popsA = gago.Populations{{Individuals: gago.Individuals{
+			{Genome: gago.Genome{1.0}, Fitness: 1, Evaluated: true},
+			{Genome: gago.Genome{2.0}, Fitness: 2, Evaluated: true},
+			{Genome: gago.Genome{3.0}, Fitness: 3, Evaluated: true},
+		}}}
+		popsB = gago.Populations{{Individuals: gago.Individuals{
+			{Genome: gago.Genome{4.0}, Fitness: 4, Evaluated: true},
+			{Genome: gago.Genome{5.0}, Fitness: 5, Evaluated: true},
+			{Genome: gago.Genome{6.0}, Fitness: 6, Evaluated: true},
+		}}}
+	)
+	a.Apply(popsA)
+	if a.Err() != nil {
+		t.Fatal(a.Err())
+	}
+	// Wait for b to receive the migrants
+	for i := 0; i < 100; i++ {
+		b.mu.Lock()
+		var n = len(b.inbox)
+		b.mu.Unlock()
+		if n == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	b.Apply(popsB)
+	var genes = make(map[float64]bool)
+	for _, indi := range popsB[0].Individuals {
+		genes[indi.Genome[0].(float64)] = true
+	}
+	if !genes[1] || !genes[2] || !genes[4] {
+		t.Error("The migrants didn't replace the worst individuals")
+	}
+	if popsB[0].Individuals[0].Fitness != 1 {
+		t.Error("The population wasn't sorted after receiving the migrants")
+	}
+}
+
+func TestGA(t *testing.T) {
+	var (
+		nodes = []*Node{{Addr: "127.0.0.1:0", NbMigrants: 1}, {Addr: "127.0.0.1:0", NbMigrants: 1}}
+		addrs []string
+	)
+	for _, node := range nodes {
+		if err := node.Start(); err != nil {
+			t.Fatal(err)
+		}
+		defer node.Close()
+		addrs = append(addrs, node.Address())
+	}
+	for i, node := range nodes {
+		node.Peers = Ring(addrs, i)
+		var ga = presets.Float64(2, func(X []float64) float64 {
+			return X[0]*X[0] + X[1]*X[1]
+		})
+		ga.NbrPopulations = 1
+		ga.Migrator = node
+		ga.MigFrequency = 1
+		ga.Initialize()
+		for j := 0; j < 5; j++ {
+			ga.Enhance()
+		}
+		if node.Err() != nil {
+			t.Error(node.Err())
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Package jobshop helps solving job-shop scheduling problems with gago. A
+// schedule is encoded with the operation-based representation of Bierwirth:
+// each job appears in the genome as many times as it has operations and the
+// k-th occurrence of a job stands for it's k-th operation. Any such genome can
+// be decoded into a feasible schedule, hence the usual permutation mutators
+// can be used along with the precedence preserving CrossGOX crossover.
+package jobshop
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/MaxHalford/gago"
+)
+
+// An Operation is processed on a Machine during a given Duration.
+type Operation struct {
+	Machine  int
+	Duration float64
+}
+
+// A Problem is a job-shop scheduling problem, each job is a sequence of
+// operations that have to be processed in order. A machine can only process
+// one operation at a time.
+type Problem struct {
+	Jobs [][]Operation
+}
+
+// NbOperations returns the total number of operations, which is the length of
+// the genomes.
+func (p Problem) NbOperations() int {
+	var n int
+	for _, job := range p.Jobs {
+		n += len(job)
+	}
+	return n
+}
+
+// NbMachines returns the number of machines.
+func (p Problem) NbMachines() int {
+	var n int
+	for _, job := range p.Jobs {
+		for _, op := range job {
+			if op.Machine >= n {
+				n = op.Machine + 1
+			}
+		}
+	}
+	return n
+}
+
+// A Schedule contains the start time of each operation of each job and the
+// makespan, which is the time at which the last operation ends.
+type Schedule struct {
+	Starts   [][]float64
+	Makespan float64
+}
+
+// Decode turns a sequence of jobs into a semi-active schedule, the operations
+// are scheduled in the order given by the sequence and each one starts as soon
+// as the previous operation of it's job and the previous operation of it's
+// machine are done.
+func (p Problem) Decode(sequence []int) Schedule {
+	var (
+		schedule = Schedule{Starts: make([][]float64, len(p.Jobs))}
+		next     = make([]int, len(p.Jobs))        // Next operation of each job
+		jobs     = make([]float64, len(p.Jobs))    // Time at which each job is available
+		machines = make([]float64, p.NbMachines()) // Time at which each machine is available
+	)
+	for j, job := range p.Jobs {
+		schedule.Starts[j] = make([]float64, len(job))
+	}
+	for _, j := range sequence {
+		var (
+			op    = p.Jobs[j][next[j]]
+			start = math.Max(jobs[j], machines[op.Machine])
+		)
+		schedule.Starts[j][next[j]] = start
+		jobs[j] = start + op.Duration
+		machines[op.Machine] = jobs[j]
+		schedule.Makespan = math.Max(schedule.Makespan, jobs[j])
+		next[j]++
+	}
+	return schedule
+}
+
+// Sequence returns the sequence of jobs contained in an individual's genome.
+func Sequence(indi gago.Individual) []int {
+	var sequence = make([]int, len(indi.Genome))
+	for i, gene := range indi.Genome {
+		sequence[i] = gene.(int)
+	}
+	return sequence
+}
+
+// Fitness returns the fitness function to minimize, which is the makespan of
+// the decoded schedule.
+func (p Problem) Fitness() gago.IntFunction {
+	return gago.IntFunction{
+		Image: func(sequence []int) float64 {
+			return p.Decode(sequence).Makespan
+		},
+	}
+}
+
+// InitSequence generates random sequences in which each job appears once per
+// operation.
+type InitSequence struct {
+	Problem Problem
+}
+
+// Apply the InitSequence initializer.
+func (init InitSequence) Apply(indi *gago.Individual, rng *rand.Rand) {
+	var i int
+	for j, job := range init.Problem.Jobs {
+		for range job {
+			indi.Genome[i] = j
+			i++
+		}
+	}
+	rng.Shuffle(len(indi.Genome), func(a, b int) {
+		indi.Genome[a], indi.Genome[b] = indi.Genome[b], indi.Genome[a]
+	})
+}
+
+// CrossGOX implements the generalized order crossover of Bierwirth et al. for
+// genomes in which genes are repeated. A substring of operations is chosen in
+// the donor parent, the same operations, meaning the same occurrences of the
+// same jobs, are removed from the receiver parent and the substring is
+// inserted where the first of them was. The relative order of the operations
+// of both parents is mostly preserved. Each parent acts once as the donor.
+type CrossGOX struct{}
+
+// Identify each gene of a genome by it's value and it's occurrence.
+type occurrence struct {
+	gene interface{}
+	k    int
+}
+
+func occurrences(genome gago.Genome) []occurrence {
+	var (
+		occs   = make([]occurrence, len(genome))
+		counts = make(map[interface{}]int)
+	)
+	for i, gene := range genome {
+		occs[i] = occurrence{gene, counts[gene]}
+		counts[gene]++
+	}
+	return occs
+}
+
+// Produce an offspring by inserting a substring of the donor into the
+// receiver.
+func gox(donor, receiver gago.Genome, rng *rand.Rand) gago.Individual {
+	var (
+		n         = len(donor)
+		length    = n/3 + rng.Intn(n/3+1)
+		start     = rng.Intn(n - length + 1)
+		dOccs     = occurrences(donor)
+		rOccs     = occurrences(receiver)
+		substring = make(map[occurrence]bool, length)
+		genome    = make(gago.Genome, 0, n)
+		inserted  = false
+	)
+	for _, occ := range dOccs[start : start+length] {
+		substring[occ] = true
+	}
+	for i, occ := range rOccs {
+		if !substring[occ] {
+			genome = append(genome, receiver[i])
+			continue
+		}
+		if !inserted {
+			genome = append(genome, donor[start:start+length]...)
+			inserted = true
+		}
+	}
+	return gago.Individual{Genome: genome, Fitness: math.Inf(1)}
+}
+
+// Apply generalized order crossover.
+func (cross CrossGOX) Apply(p1 gago.Individual, p2 gago.Individual, rng *rand.Rand) (gago.Individual, gago.Individual) {
+	return gox(p1.Genome, p2.Genome, rng), gox(p2.Genome, p1.Genome, rng)
+}
+
+// GA returns a GA configuration for minimizing the makespan of the problem. As
+// with the presets, the configuration is a starting point that can be tuned.
+func (p Problem) GA() gago.GA {
+	return gago.GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 50,
+		NbrGenes:       p.NbOperations(),
+		Ff:             p.Fitness(),
+		Initializer:    InitSequence{Problem: p},
+		Model: gago.ModGenerational{
+			Selector: gago.SelTournament{
+				NbParticipants: 3,
+			},
+			Crossover: CrossGOX{},
+			Mutator:   gago.MutPermute{Max: 2},
+			MutRate:   0.3,
+		},
+		Migrator:     gago.MigShuffle{},
+		MigFrequency: 10,
+	}
+}
@@ -0,0 +1,95 @@
+package jobshop
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+// The optimal makespan of this problem is 6, J0 runs on M0 while J1 runs on M1
+// and then they switch machines.
+var problem = Problem{
+	Jobs: [][]Operation{
+		{{Machine: 0, Duration: 3}, {Machine: 1, Duration: 2}},
+		{{Machine: 1, Duration: 2}, {Machine: 0, Duration: 3}},
+	},
+}
+
+func TestDecode(t *testing.T) {
+	var testCases = []struct {
+		sequence []int
+		makespan float64
+	}{
+		{[]int{0, 1, 0, 1}, 6},
+		{[]int{0, 0, 1, 1}, 10},
+		{[]int{1, 1, 0, 0}, 10},
+	}
+	for _, test := range testCases {
+		if m := problem.Decode(test.sequence).Makespan; m != test.makespan {
+			t.Errorf("Expected a makespan of %f for %v, got %f", test.makespan, test.sequence, m)
+		}
+	}
+	var schedule = problem.Decode([]int{0, 1, 0, 1})
+	if schedule.Starts[0][1] != 3 || schedule.Starts[1][1] != 3 {
+		t.Errorf("Wrong start times %v", schedule.Starts)
+	}
+}
+
+func TestNbMachines(t *testing.T) {
+	if problem.NbMachines() != 2 || problem.NbOperations() != 4 {
+		t.Error("Wrong problem dimensions")
+	}
+}
+
+// Count the occurrences of each gene of a genome.
+func counts(genome gago.Genome) map[interface{}]int {
+	var c = make(map[interface{}]int)
+	for _, gene := range genome {
+		c[gene]++
+	}
+	return c
+}
+
+func TestCrossGOX(t *testing.T) {
+	var (
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+		p   = Problem{Jobs: make([][]Operation, 4)}
+		p1  = gago.Individual{Genome: make(gago.Genome, 12)}
+		p2  = gago.Individual{Genome: make(gago.Genome, 12)}
+	)
+	for j := range p.Jobs {
+		p.Jobs[j] = make([]Operation, 3)
+	}
+	var init = InitSequence{Problem: p}
+	for i := 0; i < 20; i++ {
+		init.Apply(&p1, rng)
+		init.Apply(&p2, rng)
+		var o1, o2 = CrossGOX{}.Apply(p1, p2, rng)
+		for _, o := range []gago.Individual{o1, o2} {
+			if len(o.Genome) != 12 {
+				t.Errorf("Expected an offspring of 12 genes, got %d", len(o.Genome))
+			}
+			for j, c := range counts(o.Genome) {
+				if c != 3 {
+					t.Errorf("Job %v appears %d times instead of 3", j, c)
+				}
+			}
+		}
+	}
+}
+
+func TestGA(t *testing.T) {
+	var ga = problem.GA()
+	if err := ga.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness != 6 {
+		t.Errorf("Expected the optimal makespan of 6, got %f", ga.Best().Fitness)
+	}
+}
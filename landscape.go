@@ -0,0 +1,233 @@
+package gago
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Correlation returns the Pearson correlation coefficient between two samples
+// of the same size, NaN is returned if one of them is constant.
+func Correlation(x, y []float64) float64 {
+	if len(x) != len(y) || len(x) < 2 {
+		return math.NaN()
+	}
+	var (
+		mx, my        = mean(x), mean(y)
+		sxy, sxx, syy float64
+	)
+	for i := range x {
+		sxy += (x[i] - mx) * (y[i] - my)
+		sxx += (x[i] - mx) * (x[i] - mx)
+		syy += (y[i] - my) * (y[i] - my)
+	}
+	if sxx == 0 || syy == 0 {
+		return math.NaN()
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
+
+// Autocorrelation returns the correlation between the values of a series that
+// are lag steps apart, NaN is returned if the series is constant or too short.
+func Autocorrelation(series []float64, lag int) float64 {
+	if lag < 0 || len(series) < lag+2 {
+		return math.NaN()
+	}
+	var (
+		m        = mean(series)
+		num, den float64
+	)
+	for i, v := range series {
+		den += (v - m) * (v - m)
+		if i+lag < len(series) {
+			num += (v - m) * (series[i+lag] - m)
+		}
+	}
+	if den == 0 {
+		return math.NaN()
+	}
+	return num / den
+}
+
+// Return the proportion of the inner points of a series that are a strict
+// local minimum or maximum.
+func turningPoints(series []float64) float64 {
+	if len(series) < 3 {
+		return math.NaN()
+	}
+	var count float64
+	for i := 1; i < len(series)-1; i++ {
+		var a, b, c = series[i-1], series[i], series[i+1]
+		if (b < a && b < c) || (b > a && b > c) {
+			count++
+		}
+	}
+	return count / float64(len(series)-2)
+}
+
+// A Landscape describes how the fitness landscape of a problem should be
+// sampled in order to analyse it before running a GA, the analysis helps
+// choosing the operators and their parameters. The genomes are produced by
+// Initializer with NbrGenes genes and evaluated with Ff. The landscape is
+// explored in two ways:
+//
+// - Samples random genomes, 1000 if Samples is 0, are compared with the
+// Optimum, or with the best of them if Optimum is nil, according to Metric.
+// This part is skipped if Metric is nil.
+// - Walks random walks, 10 if Walks is 0, of WalkLength steps, 100 if
+// WalkLength is 0, start from random genomes and apply Mutator at each step.
+// Mutator defines the neighbourhood of a genome, hence it should be the one
+// the GA is going to use.
+//
+// The random number generator is seeded with Seed, the current time is used if
+// Seed is 0.
+type Landscape struct {
+	NbrGenes    int
+	Ff          FitnessFunction
+	Initializer Initializer
+	Mutator     Mutator
+	Metric      DistanceMetric
+	Optimum     Genome
+	Samples     int
+	Walks       int
+	WalkLength  int
+	MaxLag      int // Largest lag for which the autocorrelation is computed, 10 if 0
+	Seed        int64
+}
+
+// A LandscapeReport summarizes the analysis of a fitness landscape.
+//
+// The fitness distance correlation, FDC, is the correlation between the
+// fitness of the random genomes and their distance to the optimum. When
+// minimizing a value close to 1 means that the fitness gets better closer to
+// the optimum and that the problem is easy, a value close to 0 means that the
+// fitness doesn't give any clue and a negative value means that the problem is
+// deceptive.
+//
+// The autocorrelation of the fitness along the random walks measures how much
+// the fitness of a genome says about the fitness of it's neighbours. A smooth
+// landscape has a high autocorrelation and a long correlation length, the
+// number of steps after which the fitnesses become unrelated, whereas a
+// rugged landscape has an autocorrelation close to 0. The ruggedness is the
+// proportion of the steps of the walks at which the fitness changes direction,
+// it's about 2/3 for a random landscape and 0 for a monotonic walk.
+type LandscapeReport struct {
+	FDC               float64   // Fitness distance correlation, NaN if it wasn't computed
+	Autocorrelation   []float64 // Mean autocorrelation over the walks for the lags 1, 2, ..., MaxLag
+	CorrelationLength float64   // -1/ln(|r(1)|), r(1) being the autocorrelation for a lag of 1
+	Ruggedness        float64   // Mean proportion of the steps of the walks that are turning points
+}
+
+// Analyze samples the landscape and returns a report.
+func (ls Landscape) Analyze() (LandscapeReport, error) {
+	// Check the parameters
+	if ls.NbrGenes < 1 {
+		return LandscapeReport{}, errors.New("'NbrGenes' should be higher or equal to 1")
+	}
+	if ls.Ff == nil {
+		return LandscapeReport{}, errors.New("'Ff' cannot be nil")
+	}
+	if ls.Initializer == nil {
+		return LandscapeReport{}, errors.New("'Initializer' cannot be nil")
+	}
+	if ls.Mutator == nil {
+		return LandscapeReport{}, errors.New("'Mutator' cannot be nil")
+	}
+	if ls.Samples < 0 || ls.Walks < 0 || ls.WalkLength < 0 || ls.MaxLag < 0 {
+		return LandscapeReport{}, errors.New("'Samples', 'Walks', 'WalkLength' and 'MaxLag' should be higher or equal to 0")
+	}
+	var (
+		samples, walks, length, maxLag = ls.Samples, ls.Walks, ls.WalkLength, ls.MaxLag
+		seed                           = ls.Seed
+	)
+	if samples == 0 {
+		samples = 1000
+	}
+	if walks == 0 {
+		walks = 10
+	}
+	if length == 0 {
+		length = 100
+	}
+	if maxLag == 0 {
+		maxLag = 10
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	var (
+		rng    = rand.New(rand.NewSource(seed))
+		report = LandscapeReport{
+			FDC:             math.NaN(),
+			Autocorrelation: make([]float64, maxLag),
+		}
+		random = func() Individual {
+			var indi = makeIndividual(ls.NbrGenes, rng)
+			ls.Initializer.Apply(&indi, rng)
+			indi.Evaluate(ls.Ff)
+			return indi
+		}
+	)
+	// Fitness distance correlation
+	if ls.Metric != nil {
+		var indis = make(Individuals, samples)
+		for i := range indis {
+			indis[i] = random()
+		}
+		var optimum = Individual{Genome: ls.Optimum}
+		if ls.Optimum == nil {
+			indis.Sort()
+			optimum, indis = indis[0], indis[1:]
+		}
+		var fitnesses, distances = make([]float64, len(indis)), make([]float64, len(indis))
+		for i, indi := range indis {
+			fitnesses[i] = indi.Fitness
+			distances[i] = ls.Metric.Apply(indi, optimum)
+		}
+		report.FDC = Correlation(fitnesses, distances)
+	}
+	// Random walks
+	var counts = make([]float64, maxLag)
+	for w := 0; w < walks; w++ {
+		var (
+			indi   = random()
+			series = make([]float64, length+1)
+		)
+		series[0] = indi.Fitness
+		for i := 1; i <= length; i++ {
+			indi = indi.clone(rng)
+			indi.Mutate(ls.Mutator, rng)
+			indi.Evaluate(ls.Ff)
+			series[i] = indi.Fitness
+		}
+		for lag := 1; lag <= maxLag; lag++ {
+			if r := Autocorrelation(series, lag); !math.IsNaN(r) {
+				report.Autocorrelation[lag-1] += r
+				counts[lag-1]++
+			}
+		}
+		report.Ruggedness += turningPoints(series) / float64(walks)
+	}
+	for i := range counts {
+		report.Autocorrelation[i] /= counts[i]
+	}
+	report.CorrelationLength = -1 / math.Log(math.Abs(report.Autocorrelation[0]))
+	return report, nil
+}
+
+// String returns a human readable summary of the report.
+func (report LandscapeReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Fitness distance correlation: %.4f\n", report.FDC)
+	fmt.Fprintf(&b, "Correlation length: %.4f\n", report.CorrelationLength)
+	fmt.Fprintf(&b, "Ruggedness: %.4f\n", report.Ruggedness)
+	fmt.Fprint(&b, "Autocorrelation:")
+	for _, r := range report.Autocorrelation {
+		fmt.Fprintf(&b, " %.4f", r)
+	}
+	fmt.Fprintln(&b)
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCorrelation(t *testing.T) {
+	var testCases = []struct {
+		x, y []float64
+		r    float64
+	}{
+		{[]float64{1, 2, 3}, []float64{2, 4, 6}, 1},
+		{[]float64{1, 2, 3}, []float64{3, 2, 1}, -1},
+		{[]float64{1, 2, 3, 4}, []float64{1, 3, 3, 1}, 0},
+	}
+	for _, test := range testCases {
+		if r := Correlation(test.x, test.y); math.Abs(r-test.r) > 1e-10 {
+			t.Errorf("Expected %f, got %f", test.r, r)
+		}
+	}
+	if !math.IsNaN(Correlation([]float64{1, 1}, []float64{1, 2})) {
+		t.Error("The correlation with a constant sample should be NaN")
+	}
+}
+
+func TestAutocorrelation(t *testing.T) {
+	var alternating = []float64{1, -1, 1, -1, 1, -1, 1, -1}
+	if r := Autocorrelation(alternating, 1); r >= -0.8 {
+		t.Errorf("Expected a strongly negative autocorrelation, got %f", r)
+	}
+	if r := Autocorrelation(alternating, 2); r <= 0.6 {
+		t.Errorf("Expected a strongly positive autocorrelation, got %f", r)
+	}
+	if r := Autocorrelation(alternating, 0); math.Abs(r-1) > 1e-10 {
+		t.Errorf("Expected an autocorrelation of 1 for a lag of 0, got %f", r)
+	}
+	if !math.IsNaN(Autocorrelation([]float64{1, 2}, 1)) {
+		t.Error("The autocorrelation of a short series should be NaN")
+	}
+}
+
+func TestTurningPoints(t *testing.T) {
+	if r := turningPoints([]float64{1, 2, 3, 4}); r != 0 {
+		t.Errorf("Expected 0, got %f", r)
+	}
+	if r := turningPoints([]float64{1, 3, 2, 4}); r != 1 {
+		t.Errorf("Expected 1, got %f", r)
+	}
+}
+
+func TestLandscape(t *testing.T) {
+	var (
+		smooth = Landscape{
+			NbrGenes:    2,
+			Ff:          sphere,
+			Initializer: InitUniformF{Lower: -5, Upper: 5},
+			Mutator:     MutGaussianF{Rate: 1, Std: 0.1},
+			Metric:      DistEuclidean{},
+			Optimum:     Genome{0.0, 0.0},
+			Samples:     200,
+			Seed:        42,
+		}
+		rugged = smooth
+	)
+	rugged.Ff = Float64Function{func(x []float64) float64 {
+		return rand.New(rand.NewSource(int64(math.Float64bits(x[0]) ^ math.Float64bits(x[1])))).Float64()
+	}}
+	var s, err = smooth.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.FDC < 0.8 {
+		t.Errorf("The sphere should have a high fitness distance correlation, got %f", s.FDC)
+	}
+	if len(s.Autocorrelation) != 10 || s.Autocorrelation[0] < 0.8 {
+		t.Errorf("The sphere should have a high autocorrelation, got %v", s.Autocorrelation)
+	}
+	r, err := rugged.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(r.FDC) > 0.3 || math.Abs(r.Autocorrelation[0]) > 0.3 {
+		t.Errorf("A random landscape shouldn't be correlated, got %f and %f", r.FDC, r.Autocorrelation[0])
+	}
+	if r.Ruggedness <= s.Ruggedness || r.CorrelationLength >= s.CorrelationLength {
+		t.Error("The random landscape should be more rugged than the sphere")
+	}
+	if !strings.Contains(s.String(), "Ruggedness") {
+		t.Error("The report is missing the ruggedness")
+	}
+	// The best sample is used when the optimum is unknown
+	smooth.Optimum = nil
+	if s, _ = smooth.Analyze(); math.IsNaN(s.FDC) {
+		t.Error("The fitness distance correlation should have been computed")
+	}
+	if _, err = (Landscape{NbrGenes: 2, Ff: sphere, Initializer: InitUniformF{}}).Analyze(); err == nil {
+		t.Error("A landscape without mutator should be invalid")
+	}
+}
@@ -0,0 +1,278 @@
+package gago
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+)
+
+// A Lineage records how the individuals of a GA were created, which is useful
+// for studying the dynamics of evolution. Each individual is given an ID which
+// is stored in it's metadata, see LineageID. A node is recorded for each
+// individual produced by the initializer, a crossover or a mutator of the
+// model, along with the IDs of it's parents and the operator that produced it.
+// A mutation produces a new node whose parent is the individual before the
+// mutation. Individuals produced in other ways, for example by a restarter,
+// don't have an ID until they are used as parents, in which case they are
+// recorded with an unknown origin. The lineage grows with every offspring,
+// hence it should only be recorded for runs of a reasonable size.
+type Lineage struct {
+	mu         sync.Mutex
+	nodes      []LineageNode
+	generation int
+}
+
+// A LineageNode describes how an individual was created.
+type LineageNode struct {
+	ID         int
+	Parents    []int
+	Operator   string // "initialization", "unknown" or the type of the operator, for example "gago.CrossPoint"
+	Generation int
+}
+
+// The key under which the ID of an individual is stored in it's metadata.
+const lineageKey = "lineage"
+
+// LineageID returns the ID of an individual, the second value is false if the
+// individual hasn't been recorded in a lineage.
+func LineageID(indi Individual) (int, bool) {
+	var id, ok = indi.Meta(lineageKey).(int)
+	return id, ok
+}
+
+// Forget the recorded nodes.
+func (lin *Lineage) reset() {
+	lin.mu.Lock()
+	lin.nodes = nil
+	lin.generation = 0
+	lin.mu.Unlock()
+}
+
+// Return a copy of the lineage, the nodes recorded by one of them are not
+// recorded by the other.
+func (lin *Lineage) clone() *Lineage {
+	lin.mu.Lock()
+	defer lin.mu.Unlock()
+	var nodes = make([]LineageNode, len(lin.nodes))
+	for i, node := range lin.nodes {
+		nodes[i] = node
+		nodes[i].Parents = append([]int(nil), node.Parents...)
+	}
+	return &Lineage{nodes: nodes, generation: lin.generation}
+}
+
+// Set the generation of the nodes recorded from now on.
+func (lin *Lineage) setGeneration(generation int) {
+	lin.mu.Lock()
+	lin.generation = generation
+	lin.mu.Unlock()
+}
+
+// Record a new node and assign it's ID to an individual.
+func (lin *Lineage) record(indi *Individual, operator string, parents ...int) int {
+	lin.mu.Lock()
+	var id = len(lin.nodes)
+	lin.nodes = append(lin.nodes, LineageNode{
+		ID:         id,
+		Parents:    parents,
+		Operator:   operator,
+		Generation: lin.generation,
+	})
+	lin.mu.Unlock()
+	indi.SetMeta(lineageKey, id)
+	return id
+}
+
+// Return the ID of an individual, the individual is recorded with an unknown
+// origin if it doesn't have one yet. The individual is a copy, hence the ID of
+// an unrecorded individual isn't kept by the original.
+func (lin *Lineage) id(indi Individual) int {
+	if id, ok := LineageID(indi); ok {
+		return id
+	}
+	return lin.record(&indi, "unknown")
+}
+
+// Nodes returns the nodes recorded so far, ordered by ID.
+func (lin *Lineage) Nodes() []LineageNode {
+	lin.mu.Lock()
+	defer lin.mu.Unlock()
+	var nodes = make([]LineageNode, len(lin.nodes))
+	copy(nodes, lin.nodes)
+	return nodes
+}
+
+// Ancestors returns the IDs of the ancestors of a node, including the node
+// itself, in the order in which they are encountered from the node.
+func (lin *Lineage) Ancestors(id int) []int {
+	var (
+		nodes     = lin.Nodes()
+		seen      = map[int]bool{id: true}
+		ancestors = []int{id}
+	)
+	for i := 0; i < len(ancestors); i++ {
+		for _, parent := range nodes[ancestors[i]].Parents {
+			if !seen[parent] {
+				seen[parent] = true
+				ancestors = append(ancestors, parent)
+			}
+		}
+	}
+	return ancestors
+}
+
+// Return the IDs of the ancestors of a node, including the node itself, that
+// were created during the last depth generations.
+func (lin *Lineage) recentAncestors(id int, depth int) map[int]bool {
+	lin.mu.Lock()
+	defer lin.mu.Unlock()
+	var (
+		since     = lin.generation - depth
+		ancestors = make(map[int]bool)
+		stack     = []int{id}
+	)
+	for len(stack) > 0 {
+		var node = lin.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if ancestors[node.ID] || node.Generation < since {
+			continue
+		}
+		ancestors[node.ID] = true
+		stack = append(stack, node.Parents...)
+	}
+	return ancestors
+}
+
+// WriteDOT writes the lineage as a directed graph in the DOT language of
+// Graphviz, the edges go from the parents to their offsprings.
+func (lin *Lineage) WriteDOT(w io.Writer) error {
+	var bw = bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph lineage {")
+	for _, node := range lin.Nodes() {
+		fmt.Fprintf(bw, "  %d [label=\"%d\\n%s\\ngeneration %d\"];\n", node.ID, node.ID, node.Operator, node.Generation)
+		for _, parent := range node.Parents {
+			fmt.Fprintf(bw, "  %d -> %d;\n", parent, node.ID)
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// WriteGraphML writes the lineage as a directed graph in the GraphML format,
+// the operator and the generation of each node are stored as node data.
+func (lin *Lineage) WriteGraphML(w io.Writer) error {
+	var bw = bufio.NewWriter(w)
+	fmt.Fprintln(bw, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintln(bw, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	fmt.Fprintln(bw, `  <key id="operator" for="node" attr.name="operator" attr.type="string"/>`)
+	fmt.Fprintln(bw, `  <key id="generation" for="node" attr.name="generation" attr.type="int"/>`)
+	fmt.Fprintln(bw, `  <graph id="lineage" edgedefault="directed">`)
+	for _, node := range lin.Nodes() {
+		fmt.Fprintf(bw, "    <node id=\"n%d\">\n", node.ID)
+		fmt.Fprintf(bw, "      <data key=\"operator\">%s</data>\n", node.Operator)
+		fmt.Fprintf(bw, "      <data key=\"generation\">%d</data>\n", node.Generation)
+		fmt.Fprintln(bw, "    </node>")
+		for _, parent := range node.Parents {
+			fmt.Fprintf(bw, "    <edge source=\"n%d\" target=\"n%d\"/>\n", parent, node.ID)
+		}
+	}
+	fmt.Fprintln(bw, "  </graph>")
+	fmt.Fprintln(bw, "</graphml>")
+	return bw.Flush()
+}
+
+// The crossovers and the mutators of the model are wrapped so that the
+// offsprings they produce are recorded.
+
+type tracedCrossover struct {
+	Crossover
+	lin *Lineage
+}
+
+func (cross tracedCrossover) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = cross.Crossover.Apply(p1, p2, rng)
+	cross.trace(p1, p2, &o1, &o2)
+	return o1, o2
+}
+
+func (cross tracedCrossover) trace(p1, p2 Individual, o1, o2 *Individual) {
+	var (
+		id1      = cross.lin.id(p1)
+		id2      = cross.lin.id(p2)
+		operator = operatorName(cross.Crossover)
+	)
+	cross.lin.record(o1, operator, id1, id2)
+	cross.lin.record(o2, operator, id1, id2)
+}
+
+func (cross tracedCrossover) unwrap() interface{} {
+	return cross.Crossover
+}
+
+// A traced crossover that can write into existing individuals, which keeps the
+// memory reuse of ModGenerational working when the lineage is recorded.
+type tracedCrossoverInto struct {
+	tracedCrossover
+	into CrossoverInto
+}
+
+func (cross tracedCrossoverInto) ApplyInto(p1 Individual, p2 Individual, o1 *Individual, o2 *Individual, rng *rand.Rand) {
+	cross.into.ApplyInto(p1, p2, o1, o2, rng)
+	cross.trace(p1, p2, o1, o2)
+}
+
+type tracedMutator struct {
+	Mutator
+	lin *Lineage
+}
+
+func (mut tracedMutator) Apply(indi *Individual, rng *rand.Rand) {
+	var parent = mut.lin.id(*indi)
+	mut.Mutator.Apply(indi, rng)
+	mut.lin.record(indi, operatorName(mut.Mutator), parent)
+}
+
+func (mut tracedMutator) unwrap() interface{} {
+	return mut.Mutator
+}
+
+// A traced mutator that mutates sibling offsprings at once, which keeps the
+// mirrored sampling of MutMirroredF working when the lineage is recorded.
+type tracedPairMutator struct {
+	tracedMutator
+	pair PairMutator
+}
+
+func (mut tracedPairMutator) ApplyPair(indi1 *Individual, indi2 *Individual, rng *rand.Rand) {
+	var (
+		parent1  = mut.lin.id(*indi1)
+		parent2  = mut.lin.id(*indi2)
+		operator = operatorName(mut.Mutator)
+	)
+	mut.pair.ApplyPair(indi1, indi2, rng)
+	mut.lin.record(indi1, operator, parent1)
+	mut.lin.record(indi2, operator, parent2)
+}
+
+// Return a copy of a model where the crossovers and the mutators record their
+// offsprings in a lineage.
+func traceModel(model Model, lin *Lineage) Model {
+	return wrapModel(model, wrappers{
+		crossover: func(cross Crossover) Crossover {
+			var traced = tracedCrossover{cross, lin}
+			if into, ok := cross.(CrossoverInto); ok {
+				return tracedCrossoverInto{traced, into}
+			}
+			return traced
+		},
+		mutator: func(mut Mutator) Mutator {
+			var traced = tracedMutator{mut, lin}
+			if pair, ok := mut.(PairMutator); ok {
+				return tracedPairMutator{traced, pair}
+			}
+			return traced
+		},
+	})
+}
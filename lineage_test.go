@@ -0,0 +1,103 @@
+package gago
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLineage(t *testing.T) {
+	var ga = GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 10,
+		NbrGenes:       nbGenes,
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          model,
+		Lineage:        &Lineage{},
+	}
+	ga.Initialize()
+	for i := 0; i < 3; i++ {
+		ga.Enhance()
+	}
+	var (
+		nodes = ga.Lineage.Nodes()
+		id, _ = LineageID(ga.Populations[0].Individuals[0])
+	)
+	// Each individual of the last generation has an ID
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			if _, ok := LineageID(indi); !ok {
+				t.Error("An individual wasn't recorded in the lineage")
+			}
+		}
+	}
+	// The ancestors of an individual go back to the initialization
+	var initialized bool
+	for _, ancestor := range ga.Lineage.Ancestors(id) {
+		if nodes[ancestor].Operator == "initialization" {
+			initialized = true
+		}
+	}
+	if !initialized {
+		t.Error("The ancestors of an individual don't include an initial individual")
+	}
+	if nodes[id].Generation != 3 {
+		t.Errorf("Expected the individual to be created during generation 3, got %d", nodes[id].Generation)
+	}
+	// Running Initialize again starts a new lineage
+	ga.Initialize()
+	if len(ga.Lineage.Nodes()) != 20 {
+		t.Errorf("Expected 20 nodes after initialization, got %d", len(ga.Lineage.Nodes()))
+	}
+}
+
+func TestLineageExport(t *testing.T) {
+	var (
+		lin  = &Lineage{}
+		p1   = Individual{}
+		p2   = Individual{}
+		buf  bytes.Buffer
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		o, _ = tracedCrossover{CrossUniformF{}, lin}.Apply(p1, p2, rng)
+	)
+	if len(lin.Nodes()) != 4 {
+		t.Errorf("Expected 4 nodes, got %d", len(lin.Nodes()))
+	}
+	if id, _ := LineageID(o); len(lin.Ancestors(id)) != 3 {
+		t.Error("The offspring should have 2 ancestors besides itself")
+	}
+	lin.WriteDOT(&buf)
+	if !strings.HasPrefix(buf.String(), "digraph") || !strings.Contains(buf.String(), "0 -> 2;") {
+		t.Error("Incorrect DOT output")
+	}
+	buf.Reset()
+	lin.WriteGraphML(&buf)
+	if !strings.Contains(buf.String(), "<graphml") || !strings.Contains(buf.String(), `<edge source="n1" target="n3"/>`) {
+		t.Error("Incorrect GraphML output")
+	}
+}
+
+func TestTraceModel(t *testing.T) {
+	var model = traceModel(ModGenerational{Crossover: CrossUniformF{}}, &Lineage{})
+	if _, ok := model.(ModGenerational).Crossover.(CrossoverInto); !ok {
+		t.Error("Tracing a model hid the CrossoverInto implementation of a crossover")
+	}
+	var (
+		lin      = &Lineage{}
+		mirrored = traceModel(ModGenerational{Mutator: MutMirroredF{Mutator: MutNormalF{Rate: 1, Std: 1}}}, lin)
+		pair, ok = mirrored.(ModGenerational).Mutator.(PairMutator)
+	)
+	if !ok {
+		t.Fatal("Tracing a model hid the PairMutator implementation of a mutator")
+	}
+	var indi1, indi2 = Individual{Genome: Genome{1.0}}, Individual{Genome: Genome{2.0}}
+	pair.ApplyPair(&indi1, &indi2, rand.New(rand.NewSource(42)))
+	var id1, _ = LineageID(indi1)
+	var id2, _ = LineageID(indi2)
+	if id1 == id2 || len(lin.Ancestors(id1)) != 2 || len(lin.Ancestors(id2)) != 2 {
+		t.Error("Both mutants should have been recorded")
+	}
+}
@@ -0,0 +1,273 @@
+package gago
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// A LocalSearcher improves an individual by exploring the neighbourhood of
+// it's genome with the fitness function. Combined with a model through
+// ModMemetic it turns a GA into a memetic algorithm.
+type LocalSearcher interface {
+	Apply(indi *Individual, ff FitnessFunction, rng *rand.Rand)
+}
+
+// A search keeps track of the individual being improved and of the number of
+// evaluations spent on it.
+type search struct {
+	indi           *Individual
+	ff             FitnessFunction
+	maxEvaluations int
+	evaluations    int
+}
+
+// Start a local search on an individual, the individual is evaluated if it
+// hasn't been already.
+func newSearch(indi *Individual, ff FitnessFunction, maxEvaluations int) *search {
+	indi.Evaluate(ff)
+	return &search{indi: indi, ff: ff, maxEvaluations: maxEvaluations}
+}
+
+// Check if the search has spent it's budget, there is no budget if
+// maxEvaluations is 0.
+func (s *search) exhausted() bool {
+	return s.maxEvaluations > 0 && s.evaluations >= s.maxEvaluations
+}
+
+// Evaluate a candidate genome and move to it if it improves the individual.
+func (s *search) try(genome Genome) bool {
+	var candidate = Individual{Genome: genome, Name: s.indi.Name}
+	candidate.Evaluate(s.ff)
+	s.evaluations++
+	if candidate.Fitness < s.indi.Fitness {
+		*s.indi = candidate
+		return true
+	}
+	return false
+}
+
+// Copy a genome and reverse the genes between positions i and j included.
+func reversed(genome Genome, i, j int) Genome {
+	var g = make(Genome, len(genome))
+	copy(g, genome)
+	for ; i < j; i, j = i+1, j-1 {
+		g[i], g[j] = g[j], g[i]
+	}
+	return g
+}
+
+// The following local searchers work on permutation genomes, for example the
+// tours of a routing problem. They follow a first-improvement strategy: the
+// first move that improves the fitness is applied and the neighbourhood of the
+// new genome is explored, until no move improves the fitness or until
+// MaxEvaluations evaluations have been spent. There is no limit if
+// MaxEvaluations is 0. The genome is considered as a cycle, a random position
+// is chosen to start exploring the neighbourhood. An individual with an empty
+// genome is only evaluated.
+
+// LocalTwoOpt applies 2-opt moves, each move reverses a segment of the genome.
+// The neighbourhood contains n(n-1)/2 moves for a genome of n genes.
+type LocalTwoOpt struct {
+	MaxEvaluations int
+}
+
+// Apply the 2-opt local search.
+func (ls LocalTwoOpt) Apply(indi *Individual, ff FitnessFunction, rng *rand.Rand) {
+	var (
+		s = newSearch(indi, ff, ls.MaxEvaluations)
+		n = len(indi.Genome)
+	)
+	if n == 0 {
+		return
+	}
+	for improved := true; improved; {
+		improved = false
+		var offset = rng.Intn(n)
+	explore:
+		for k := 0; k < n; k++ {
+			var i = (k + offset) % n
+			for j := i + 1; j < n; j++ {
+				if s.exhausted() {
+					return
+				}
+				if s.try(reversed(indi.Genome, i, j)) {
+					improved = true
+					break explore
+				}
+			}
+		}
+	}
+}
+
+// LocalThreeOpt applies pure 3-opt moves, each move exchanges two consecutive
+// segments of the genome and possibly reverses them. The neighbourhood
+// contains in the order of n^3 moves for a genome of n genes, hence it is
+// usually worth setting MaxEvaluations.
+type LocalThreeOpt struct {
+	MaxEvaluations int
+}
+
+// Apply the 3-opt local search.
+func (ls LocalThreeOpt) Apply(indi *Individual, ff FitnessFunction, rng *rand.Rand) {
+	var (
+		s = newSearch(indi, ff, ls.MaxEvaluations)
+		n = len(indi.Genome)
+	)
+	if n == 0 {
+		return
+	}
+	for improved := true; improved; {
+		improved = false
+		var offset = rng.Intn(n)
+	explore:
+		for l := 0; l < n; l++ {
+			var i = (l + offset) % n
+			// The segments are [i, j) and [j, k)
+			for j := i + 1; j < n; j++ {
+				for k := j + 1; k <= n; k++ {
+					for _, move := range threeOptMoves(indi.Genome, i, j, k) {
+						if s.exhausted() {
+							return
+						}
+						if s.try(move) {
+							improved = true
+							break explore
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// Generate the pure 3-opt moves for the segments [i, j) and [j, k) of a
+// genome, the other reconnections are 2-opt moves.
+func threeOptMoves(genome Genome, i, j, k int) []Genome {
+	var (
+		a  = genome[i:j]
+		b  = genome[j:k]
+		ar = reversed(a, 0, len(a)-1)
+		br = reversed(b, 0, len(b)-1)
+	)
+	var moves = make([]Genome, 0, 4)
+	for _, segments := range [][2]Genome{{b, a}, {br, a}, {b, ar}, {ar, br}} {
+		var g = make(Genome, 0, len(genome))
+		g = append(g, genome[:i]...)
+		g = append(g, segments[0]...)
+		g = append(g, segments[1]...)
+		g = append(g, genome[k:]...)
+		moves = append(moves, g)
+	}
+	return moves
+}
+
+// LocalOrOpt applies Or-opt moves, each move relocates a segment of 1 up to
+// MaxLength consecutive genes to another position of the genome. MaxLength
+// defaults to 3 if it is 0.
+type LocalOrOpt struct {
+	MaxLength      int
+	MaxEvaluations int
+}
+
+// Apply the Or-opt local search.
+func (ls LocalOrOpt) Apply(indi *Individual, ff FitnessFunction, rng *rand.Rand) {
+	var (
+		s         = newSearch(indi, ff, ls.MaxEvaluations)
+		n         = len(indi.Genome)
+		maxLength = ls.MaxLength
+	)
+	if maxLength == 0 {
+		maxLength = 3
+	}
+	if n == 0 {
+		return
+	}
+	for improved := true; improved; {
+		improved = false
+		var offset = rng.Intn(n)
+	explore:
+		for l := 1; l <= maxLength; l++ {
+			for m := 0; m < n; m++ {
+				var i = (m + offset) % n
+				if i+l > n {
+					continue
+				}
+				// Insert the segment [i, i+l) before position p of the rest
+				for p := 0; p <= n-l; p++ {
+					if p == i {
+						continue
+					}
+					if s.exhausted() {
+						return
+					}
+					if s.try(relocated(indi.Genome, i, l, p)) {
+						improved = true
+						break explore
+					}
+				}
+			}
+		}
+	}
+}
+
+// Copy a genome and move the segment of length l starting at position i so
+// that it starts at position p in the new genome.
+func relocated(genome Genome, i, l, p int) Genome {
+	var rest = make(Genome, 0, len(genome)-l)
+	rest = append(rest, genome[:i]...)
+	rest = append(rest, genome[i+l:]...)
+	var g = make(Genome, 0, len(genome))
+	g = append(g, rest[:p]...)
+	g = append(g, genome[i:i+l]...)
+	g = append(g, rest[p:]...)
+	return g
+}
+
+// ModMemetic applies local search after running another model. Each
+// individual undergoes local search with probability Rate, the improved
+// genomes replace the original ones. If Elites is not 0 only the Elites best
+// individuals are candidates, in which case the individuals are evaluated and
+// sorted before the local search.
+type ModMemetic struct {
+	Model         Model
+	LocalSearcher LocalSearcher
+	Rate          float64
+	Elites        int
+}
+
+// Apply the memetic model to a population.
+func (mod ModMemetic) Apply(pop *Population) {
+	mod.Model.Apply(pop)
+	var candidates = pop.Individuals
+	if mod.Elites > 0 {
+		pop.Individuals.Evaluate(pop.ff)
+		pop.Individuals.SortWith(pop.cmp)
+		candidates = pop.Individuals[:min(mod.Elites, len(pop.Individuals))]
+	}
+	for i := range candidates {
+		if pop.rng.Float64() < mod.Rate {
+			mod.LocalSearcher.Apply(&pop.Individuals[i], pop.ff, pop.rng)
+		}
+	}
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModMemetic) Validate() error {
+	// Check the wrapped model presence
+	if mod.Model == nil {
+		return errors.New("'Model' cannot be nil")
+	}
+	// Check the local searcher presence
+	if mod.LocalSearcher == nil {
+		return errors.New("'LocalSearcher' cannot be nil")
+	}
+	// Check the local search rate
+	if mod.Rate < 0 || mod.Rate > 1 {
+		return errors.New("'Rate' should belong to the [0, 1] interval")
+	}
+	// Check the number of elites
+	if mod.Elites < 0 {
+		return errors.New("'Elites' should be higher or equal to 0")
+	}
+	return mod.Model.Validate()
+}
@@ -0,0 +1,183 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// Length of a tour visiting points placed on a circle, the optimal tour visits
+// them in increasing order.
+var tourLength = IntFunction{
+	Image: func(tour []int) float64 {
+		var (
+			n      = float64(len(tour))
+			length float64
+		)
+		for i := range tour {
+			var (
+				a = 2 * math.Pi * float64(tour[i]) / n
+				b = 2 * math.Pi * float64(tour[(i+1)%len(tour)]) / n
+			)
+			length += math.Hypot(math.Cos(a)-math.Cos(b), math.Sin(a)-math.Sin(b))
+		}
+		return length
+	},
+}
+
+func TestReversed(t *testing.T) {
+	var (
+		genome = Genome{0, 1, 2, 3, 4}
+		g      = reversed(genome, 1, 3)
+	)
+	for i, gene := range (Genome{0, 3, 2, 1, 4}) {
+		if g[i] != gene {
+			t.Errorf("Expected %v, got %v", Genome{0, 3, 2, 1, 4}, g)
+			break
+		}
+	}
+	if genome[1] != 1 {
+		t.Error("reversed modified the original genome")
+	}
+}
+
+func TestRelocated(t *testing.T) {
+	var testCases = []struct {
+		i, l, p int
+		output  Genome
+	}{
+		{0, 1, 2, Genome{1, 2, 0, 3, 4}},
+		{3, 2, 0, Genome{3, 4, 0, 1, 2}},
+		{1, 2, 3, Genome{0, 3, 4, 1, 2}},
+	}
+	for _, test := range testCases {
+		var g = relocated(Genome{0, 1, 2, 3, 4}, test.i, test.l, test.p)
+		for i := range g {
+			if g[i] != test.output[i] {
+				t.Errorf("Expected %v, got %v", test.output, g)
+				break
+			}
+		}
+	}
+}
+
+func TestThreeOptMoves(t *testing.T) {
+	var moves = threeOptMoves(Genome{0, 1, 2, 3, 4}, 1, 2, 4)
+	if len(moves) != 4 {
+		t.Errorf("Expected 4 moves, got %d", len(moves))
+	}
+	for _, move := range moves {
+		if len(move) != 5 || move[0] != 0 || move[4] != 4 {
+			t.Errorf("A 3-opt move shouldn't modify the genes outside of the segments, got %v", move)
+		}
+	}
+}
+
+func TestLocalSearchers(t *testing.T) {
+	var (
+		rng      = rand.New(rand.NewSource(time.Now().UnixNano()))
+		searches = []LocalSearcher{
+			LocalTwoOpt{},
+			LocalThreeOpt{MaxEvaluations: 2000},
+			LocalOrOpt{},
+		}
+	)
+	for _, ls := range searches {
+		var indi = makeIndividual(8, rng)
+		InitPermutationI{}.Apply(&indi, rng)
+		indi.Evaluate(tourLength)
+		var before = indi.Fitness
+		ls.Apply(&indi, tourLength, rng)
+		if indi.Fitness > before {
+			t.Errorf("%T made the individual worse", ls)
+		}
+		if !indi.Evaluated || indi.Fitness != tourLength.apply(indi.Genome) {
+			t.Errorf("%T didn't keep the fitness up to date", ls)
+		}
+		// Check the genome is still a permutation
+		var seen = make(map[interface{}]bool)
+		for _, gene := range indi.Genome {
+			seen[gene] = true
+		}
+		if len(seen) != 8 {
+			t.Errorf("%T didn't preserve the permutation", ls)
+		}
+	}
+	// 2-opt solves a tour of points on a circle
+	var indi = makeIndividual(10, rng)
+	InitPermutationI{}.Apply(&indi, rng)
+	LocalTwoOpt{}.Apply(&indi, tourLength, rng)
+	if optimal := 20 * math.Sin(math.Pi/10); math.Abs(indi.Fitness-optimal) > 1e-10 {
+		t.Errorf("2-opt should find the optimal tour of length %f, got %f", optimal, indi.Fitness)
+	}
+}
+
+func TestLocalSearchBudget(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		count int
+		ff    = IntFunction{
+			Image: func(tour []int) float64 {
+				count++
+				return tourLength.Image(tour)
+			},
+		}
+		indi = makeIndividual(10, rng)
+	)
+	InitPermutationI{}.Apply(&indi, rng)
+	indi.Evaluate(ff)
+	LocalThreeOpt{MaxEvaluations: 15}.Apply(&indi, ff, rng)
+	if count > 16 {
+		t.Errorf("Expected at most 16 evaluations, got %d", count)
+	}
+}
+
+func TestLocalSearchEmptyGenome(t *testing.T) {
+	var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	for _, ls := range []LocalSearcher{LocalTwoOpt{}, LocalThreeOpt{}, LocalOrOpt{}} {
+		var indi = Individual{Genome: Genome{}}
+		ls.Apply(&indi, tourLength, rng)
+		if !indi.Evaluated || len(indi.Genome) != 0 {
+			t.Errorf("%T should only evaluate an empty genome", ls)
+		}
+	}
+}
+
+func TestMemeticValidate(t *testing.T) {
+	var testCases = []ModMemetic{
+		{LocalSearcher: LocalTwoOpt{}, Rate: 0.5},
+		{Model: model, Rate: 0.5},
+		{Model: model, LocalSearcher: LocalTwoOpt{}, Rate: 1.5},
+		{Model: model, LocalSearcher: LocalTwoOpt{}, Rate: 0.5, Elites: -1},
+	}
+	for _, mod := range testCases {
+		if mod.Validate() == nil {
+			t.Errorf("%+v shouldn't be valid", mod)
+		}
+	}
+}
+
+func TestMemeticElites(t *testing.T) {
+	var (
+		pop = makePopulation(10, 2, sphere, initializer)
+		mod = ModMemetic{
+			Model:         model,
+			LocalSearcher: LocalGradient{Steps: 10, LearningRate: 0.1},
+			Rate:          1,
+			Elites:        1,
+		}
+	)
+	pop.Individuals.Evaluate(pop.ff)
+	mod.Apply(&pop)
+	for i, indi := range pop.Individuals {
+		if !indi.Evaluated {
+			t.Errorf("The individual %d wasn't evaluated", i)
+		}
+	}
+	for i := 1; i < len(pop.Individuals); i++ {
+		if pop.Individuals[i].Fitness < pop.Individuals[0].Fitness {
+			t.Error("The refined elite should be the best individual")
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package gago
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// Mating restrictions change the way the parents of a crossover are paired.
+// They are selectors that wrap the selector of a model, which pairs parents by
+// selecting two individuals at a time.
+
+// SelIncestPrevention prevents closely related individuals from mating. Each
+// selected individual is selected again, at most Attempts times, as long as
+// it's related to one of the individuals selected before it in the same call.
+// If no unrelated individual is found then the last one is kept. Two
+// individuals are related if they share an ancestor created during the last
+// Depth generations according to Lineage, or if the distance between them
+// according to Metric is lower than Threshold. Either Lineage or Metric can be
+// nil; the Lineage has to be the one recorded by the GA. With a Depth of 2
+// individuals can't mate with their siblings, nor with themselves.
+type SelIncestPrevention struct {
+	Selector  Selector
+	Lineage   *Lineage
+	Depth     int
+	Metric    DistanceMetric
+	Threshold float64
+	Attempts  int
+}
+
+// Apply incest prevention.
+func (sel SelIncestPrevention) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	var (
+		selected  = make(Individuals, n)
+		indexes   = make([]int, n)
+		ancestors = make([]map[int]bool, n)
+	)
+	// The selected individuals are copied because some selectors return a
+	// slice of the population
+	var initial, initialIndexes = sel.Selector.Apply(n, indis, rng)
+	copy(selected, initial)
+	copy(indexes, initialIndexes)
+	for i := range selected {
+		ancestors[i] = sel.ancestors(selected[i])
+		for attempt := 0; attempt < sel.Attempts && sel.related(selected[:i], ancestors[:i], selected[i], ancestors[i]); attempt++ {
+			var candidate, index = sel.Selector.Apply(1, indis, rng)
+			selected[i], indexes[i] = candidate[0], index[0]
+			ancestors[i] = sel.ancestors(selected[i])
+		}
+	}
+	return selected, indexes
+}
+
+// Return the recent ancestors of an individual, nil if they aren't tracked.
+func (sel SelIncestPrevention) ancestors(indi Individual) map[int]bool {
+	if sel.Lineage == nil {
+		return nil
+	}
+	var id, ok = LineageID(indi)
+	if !ok {
+		return nil
+	}
+	return sel.Lineage.recentAncestors(id, sel.Depth)
+}
+
+// Check if an individual is related to one of the given individuals.
+func (sel SelIncestPrevention) related(others Individuals, othersAncestors []map[int]bool, indi Individual, ancestors map[int]bool) bool {
+	for i, other := range others {
+		if sel.Metric != nil && sel.Metric.Apply(indi, other) < sel.Threshold {
+			return true
+		}
+		for id := range ancestors {
+			if othersAncestors[i][id] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SelAssortative pairs individuals according to their similarity. The first
+// individual is chosen by Selector, then for each other individual
+// NbCandidates candidates are chosen by Selector and the one closest to the
+// first individual according to Metric is kept. If Dissimilar is true the
+// farthest candidate is kept instead, which is called disassortative mating.
+// Assortative mating favors the exploitation of the different peaks of a
+// multimodal landscape whereas disassortative mating favors exploration. With
+// less than 2 candidates the mates are chosen at random by Selector.
+type SelAssortative struct {
+	Selector     Selector
+	Metric       DistanceMetric
+	NbCandidates int
+	Dissimilar   bool
+}
+
+// Apply assortative mating.
+func (sel SelAssortative) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	if sel.NbCandidates < 2 || n < 2 {
+		return sel.Selector.Apply(n, indis, rng)
+	}
+	var (
+		selected          = make(Individuals, n)
+		indexes           = make([]int, n)
+		first, firstIndex = sel.Selector.Apply(1, indis, rng)
+	)
+	selected[0], indexes[0] = first[0], firstIndex[0]
+	for j := 1; j < n; j++ {
+		var (
+			candidates, candidateIndexes = sel.Selector.Apply(sel.NbCandidates, indis, rng)
+			best                         = 0
+			bestDist                     = sel.Metric.Apply(selected[0], candidates[0])
+		)
+		for k := 1; k < len(candidates); k++ {
+			var dist = sel.Metric.Apply(selected[0], candidates[k])
+			if (!sel.Dissimilar && dist < bestDist) || (sel.Dissimilar && dist > bestDist) {
+				best, bestDist = k, dist
+			}
+		}
+		selected[j], indexes[j] = candidates[best], candidateIndexes[best]
+	}
+	return selected, indexes
+}
+
+// The key under which the mating pool of an individual is stored in it's
+// metadata.
+const poolKey = "pool"
+
+// PoolOf returns the mating pool of an individual used by ModSexual, 0 for the
+// first pool and 1 for the second one. The second value is false if the
+// individual hasn't been assigned to a pool yet.
+func PoolOf(indi Individual) (int, bool) {
+	var pool, ok = indi.Meta(poolKey).(int)
+	return pool, ok
+}
+
+// Split the individuals of a population into two mating pools. Individuals
+// that don't belong to a pool yet, for example the initial ones, are assigned
+// to the first pool with probability ratio. Each pool is made to contain at
+// least one individual if the population contains at least two.
+func (pop *Population) pools(ratio float64) (Individuals, Individuals) {
+	var pools [2]Individuals
+	for i := range pop.Individuals {
+		var pool, ok = PoolOf(pop.Individuals[i])
+		if !ok {
+			pool = assignPool(&pop.Individuals[i], ratio, pop.rng)
+		}
+		pools[pool] = append(pools[pool], pop.Individuals[i])
+	}
+	// Move an individual from the larger pool to the empty one
+	for p := range pools {
+		var other = 1 - p
+		if len(pools[p]) == 0 && len(pools[other]) > 1 {
+			var (
+				i    = pop.rng.Intn(len(pools[other]))
+				indi = pools[other][i]
+			)
+			indi.SetMeta(poolKey, p)
+			pools[p] = Individuals{indi}
+			pools[other] = append(pools[other][:i:i], pools[other][i+1:]...)
+		}
+	}
+	return pools[0], pools[1]
+}
+
+// Assign an individual to the first pool with probability ratio, otherwise to
+// the second one.
+func assignPool(indi *Individual, ratio float64, rng *rand.Rand) int {
+	var pool = 1
+	if rng.Float64() < ratio {
+		pool = 0
+	}
+	indi.SetMeta(poolKey, pool)
+	return pool
+}
+
+// ModSexual implements sexual selection, the population is split into two
+// mating pools and each offspring has a parent from each pool. The parents are
+// chosen from the first pool by SelectorA and from the second pool by
+// SelectorB, which makes it possible to apply a different selection pressure
+// to each pool, for example a strong pressure on one pool and a random choice
+// in the other one to preserve diversity. Each offspring joins the first pool
+// with probability Ratio, a Ratio of 0 is treated as 0.5. The pool of an
+// individual is stored in it's metadata, see PoolOf. The offsprings replace
+// the population like in the generational model. The pools are smaller than
+// the population, hence the selectors should work with few individuals, for
+// example a tournament shouldn't have more participants than a pool has
+// individuals.
+type ModSexual struct {
+	SelectorA Selector
+	SelectorB Selector
+	Crossover Crossover
+	Mutator   Mutator
+	MutRate   float64
+	Ratio     float64
+}
+
+// Apply the sexual selection model to a population.
+func (mod ModSexual) Apply(pop *Population) {
+	var ratio = mod.Ratio
+	if ratio == 0 {
+		ratio = 0.5
+	}
+	var (
+		a, b       = pop.pools(ratio)
+		offsprings = make(Individuals, 0, len(pop.Individuals))
+	)
+	// Pair the parents within the same pool if the other one is empty
+	if len(a) == 0 {
+		a = b
+	}
+	if len(b) == 0 {
+		b = a
+	}
+	for len(offsprings) < len(pop.Individuals) {
+		var (
+			mother, _ = mod.SelectorA.Apply(1, a, pop.rng)
+			father, _ = mod.SelectorB.Apply(1, b, pop.rng)
+			o1, o2    = mod.Crossover.Apply(mother[0], father[0], pop.rng)
+		)
+		offsprings = append(offsprings, o1)
+		if len(offsprings) < len(pop.Individuals) {
+			offsprings = append(offsprings, o2)
+		}
+	}
+	for i := range offsprings {
+		assignPool(&offsprings[i], ratio, pop.rng)
+	}
+	// Apply mutation to the offsprings
+	if mod.Mutator != nil {
+		offsprings.Mutate(mod.Mutator, mod.MutRate, pop.rng)
+	}
+	// Replace the old population with the new one
+	pop.Individuals = offsprings
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModSexual) Validate() error {
+	// Check the first selection method presence
+	if mod.SelectorA == nil {
+		return errors.New("'SelectorA' cannot be nil")
+	}
+	// Check the second selection method presence
+	if mod.SelectorB == nil {
+		return errors.New("'SelectorB' cannot be nil")
+	}
+	// Check the crossover method presence
+	if mod.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if mod.Mutator != nil && (mod.MutRate < 0 || mod.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	// Check the ratio
+	if mod.Ratio < 0 || mod.Ratio >= 1 {
+		return errors.New("'Ratio' should belong to the [0, 1) interval")
+	}
+	return nil
+}
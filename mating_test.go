@@ -0,0 +1,183 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSelIncestPreventionMetric(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indis = Individuals{
+			Individual{Genome: Genome{0.0}},
+			Individual{Genome: Genome{0.1}},
+			Individual{Genome: Genome{10.0}},
+		}
+		sel = SelIncestPrevention{
+			Selector:  SelTournament{NbParticipants: 1},
+			Metric:    DistEuclidean{},
+			Threshold: 1,
+			Attempts:  100,
+		}
+	)
+	for i := 0; i < 20; i++ {
+		var parents, indexes = sel.Apply(2, indis, rng)
+		if (DistEuclidean{}).Apply(parents[0], parents[1]) < 1 {
+			t.Error("Two similar individuals were paired")
+		}
+		if parents[1].Genome[0] != indis[indexes[1]].Genome[0] {
+			t.Error("The indexes don't match the selected individuals")
+		}
+	}
+}
+
+func TestSelIncestPreventionLineage(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		lin   = &Lineage{}
+		indis = make(Individuals, 4)
+	)
+	for i := range indis {
+		lin.record(&indis[i], "initialization")
+	}
+	// The first two individuals are siblings, the other two are strangers
+	lin.setGeneration(1)
+	lin.record(&indis[0], "cross", 0, 1)
+	lin.record(&indis[1], "cross", 0, 1)
+	lin.setGeneration(2)
+	var sel = SelIncestPrevention{
+		Selector: SelTournament{NbParticipants: 1},
+		Lineage:  lin,
+		Depth:    2,
+		Attempts: 100,
+	}
+	for i := 0; i < 20; i++ {
+		var _, indexes = sel.Apply(2, indis, rng)
+		if indexes[0] == indexes[1] || indexes[0] < 2 && indexes[1] < 2 {
+			t.Error("Two related individuals were paired")
+		}
+	}
+	// Siblings are not related if the common ancestors are too old
+	sel.Depth = 1
+	var related bool
+	for i := 0; i < 100; i++ {
+		var _, indexes = sel.Apply(2, indis, rng)
+		if indexes[0] != indexes[1] && indexes[0] < 2 && indexes[1] < 2 {
+			related = true
+		}
+	}
+	if !related {
+		t.Error("Siblings were never paired with a Depth of 1")
+	}
+}
+
+func TestSelAssortative(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indis = make(Individuals, 20)
+	)
+	for i := range indis {
+		indis[i] = Individual{Genome: Genome{float64(i)}}
+	}
+	// Every individual is a candidate and the first individual is the first
+	// of the slice, hence the mate is either the first or the last individual
+	var sel = SelAssortative{
+		Selector:     SelElitism{},
+		Metric:       DistEuclidean{},
+		NbCandidates: 20,
+	}
+	var _, indexes = sel.Apply(2, indis, rng)
+	if indexes[1] != 0 {
+		t.Error("Assortative mating didn't pair an individual with the closest one")
+	}
+	sel.Dissimilar = true
+	_, indexes = sel.Apply(3, indis, rng)
+	if indexes[1] != 19 || indexes[2] != 19 {
+		t.Error("Disassortative mating didn't pair an individual with the farthest one")
+	}
+	// A single candidate amounts to random mating
+	sel.NbCandidates = 1
+	if parents, _ := sel.Apply(2, indis, rng); len(parents) != 2 {
+		t.Error("Random mating didn't select 2 individuals")
+	}
+}
+
+func TestSelIncestPreventionGA(t *testing.T) {
+	var (
+		lin = &Lineage{}
+		ga  = GA{
+			NbrPopulations: 2,
+			NbrIndividuals: 10,
+			NbrGenes:       nbGenes,
+			Ff:             ff,
+			Initializer:    initializer,
+			Model: ModGenerational{
+				Selector: SelIncestPrevention{
+					Selector: SelTournament{NbParticipants: 3},
+					Lineage:  lin,
+					Depth:    2,
+					Attempts: 5,
+				},
+				Crossover: CrossUniformF{},
+				Mutator:   MutNormalF{Rate: 0.5, Std: 3},
+				MutRate:   0.5,
+			},
+			Lineage: lin,
+		}
+	)
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	if len(lin.Nodes()) <= 20 {
+		t.Error("The lineage didn't grow")
+	}
+}
+
+func TestPools(t *testing.T) {
+	var pop = makePopulation(1000, 2, ff, initializer)
+	// The initial individuals are assigned to a pool according to the ratio
+	var a, b = pop.pools(0.2)
+	if len(a)+len(b) != 1000 || len(a) < 150 || len(a) > 250 {
+		t.Errorf("Expected around 200 individuals in the first pool, got %d", len(a))
+	}
+	for _, indi := range a {
+		if pool, ok := PoolOf(indi); !ok || pool != 0 {
+			t.Error("An individual of the first pool isn't marked as such")
+		}
+	}
+	// The pools are kept from one call to the other
+	if c, _ := pop.pools(0.9); len(c) != len(a) {
+		t.Error("The pools changed")
+	}
+	// Neither pool is left empty
+	pop = makePopulation(2, 2, ff, initializer)
+	for i := range pop.Individuals {
+		pop.Individuals[i].SetMeta(poolKey, 1)
+	}
+	if a, b = pop.pools(0.5); len(a) != 1 || len(b) != 1 {
+		t.Error("A pool was left empty")
+	}
+}
+
+func TestModSexual(t *testing.T) {
+	var (
+		pop = makePopulation(10, 2, ff, initializer)
+		mod = ModSexual{
+			SelectorA: SelLinearRanking{Pressure: 1.8},
+			SelectorB: SelTournament{NbParticipants: 1},
+			Crossover: CrossUniformF{},
+		}
+	)
+	mod.Apply(&pop)
+	for _, indi := range pop.Individuals {
+		if _, ok := PoolOf(indi); !ok {
+			t.Error("An offspring wasn't assigned to a pool")
+		}
+	}
+	mod.Ratio = 1
+	if mod.Validate() == nil {
+		t.Error("A Ratio of 1 should be invalid")
+	}
+}
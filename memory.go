@@ -0,0 +1,113 @@
+package gago
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+// Return an estimate of the number of bytes referenced by a value, excluding
+// the value itself. The values that are referenced several times are only
+// counted once.
+func referencedSize(v reflect.Value, seen map[uintptr]bool) int64 {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		return int64(v.Type().Elem().Size()) + referencedSize(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		// The dynamic value of an interface is boxed unless it's a pointer
+		var elem = v.Elem()
+		if elem.Kind() == reflect.Ptr {
+			return referencedSize(elem, seen)
+		}
+		return int64(elem.Type().Size()) + referencedSize(elem, seen)
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Slice:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		var size = int64(v.Cap()) * int64(v.Type().Elem().Size())
+		for i := 0; i < v.Len(); i++ {
+			size += referencedSize(v.Index(i), seen)
+		}
+		return size
+	case reflect.Array:
+		var size int64
+		for i := 0; i < v.Len(); i++ {
+			size += referencedSize(v.Index(i), seen)
+		}
+		return size
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += referencedSize(v.Field(i), seen)
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		// A map entry roughly costs the size of it's key and it's value
+		var (
+			size = int64(v.Len()) * int64(v.Type().Key().Size()+v.Type().Elem().Size())
+			iter = v.MapRange()
+		)
+		for iter.Next() {
+			size += referencedSize(iter.Key(), seen) + referencedSize(iter.Value(), seen)
+		}
+		return size
+	}
+	return 0
+}
+
+// SizeOf returns an estimate of the number of bytes used by an individual,
+// including it's genome and the values it's genes reference. The estimate
+// ignores the overhead of the memory allocator.
+func SizeOf(indi Individual) int64 {
+	var v = reflect.ValueOf(indi)
+	return int64(v.Type().Size()) + referencedSize(v, make(map[uintptr]bool))
+}
+
+// EstimateMemory returns an estimate of the number of bytes used by the
+// individuals of nbPopulations populations of nbIndividuals individuals that
+// resemble sample. The individuals of two generations are alive at the same
+// time while the offsprings are generated, hence the estimate accounts for
+// twice the number of individuals. The memory used by the models, for example
+// to hold distance matrices, isn't included.
+func EstimateMemory(nbPopulations, nbIndividuals int, sample Individual) int64 {
+	return 2 * int64(nbPopulations) * int64(nbIndividuals) * SizeOf(sample)
+}
+
+// EstimateMemory returns an estimate of the number of bytes used by the
+// individuals of the GA, see EstimateMemory. The sample individual is
+// generated with the Initializer of the GA and isn't evaluated, hence the
+// cases and the objectives set by the fitness function aren't accounted for.
+// The initial number of individuals is used if the GA has a Sizer.
+func (ga GA) EstimateMemory() int64 {
+	var (
+		rng  = rand.New(rand.NewSource(1))
+		indi = makeIndividual(ga.NbrGenes, rng)
+	)
+	ga.Initializer.Apply(&indi, rng)
+	return EstimateMemory(ga.NbrPopulations, ga.NbrIndividuals, indi)
+}
+
+// Check the memory estimate of the GA doesn't exceed it's memory limit.
+func (ga GA) checkMemory() error {
+	if ga.MemoryLimit <= 0 {
+		return nil
+	}
+	if estimate := ga.EstimateMemory(); estimate > ga.MemoryLimit {
+		return fmt.Errorf("the individuals would use about %d bytes, which exceeds 'MemoryLimit' (%d bytes)", estimate, ga.MemoryLimit)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSizeOf(t *testing.T) {
+	var (
+		small = Individual{Genome: Genome{1.0}}
+		large = Individual{Genome: Genome{1.0, 2.0, 3.0, 4.0}}
+		text  = Individual{Genome: Genome{"aaaaaaaaaa"}}
+	)
+	if SizeOf(large)-SizeOf(small) != 3*(16+8) {
+		t.Errorf("Each float64 gene should cost 24 bytes, got %d", (SizeOf(large)-SizeOf(small))/3)
+	}
+	if SizeOf(text) <= SizeOf(small) {
+		t.Error("The bytes of a string gene should be counted")
+	}
+	// A shared value is only counted once
+	var (
+		v      = Vector{1, 2, 3}
+		shared = Individual{Genome: Genome{&v, &v}}
+		single = Individual{Genome: Genome{&v, nil}}
+	)
+	if SizeOf(shared) != SizeOf(single) {
+		t.Error("A value referenced twice should be counted once")
+	}
+}
+
+func TestEstimateMemory(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(1))
+		sample = makeIndividual(nbGenes, rng)
+	)
+	initializer.Apply(&sample, rng)
+	if EstimateMemory(3, 10, sample) != 60*SizeOf(sample) {
+		t.Error("The estimate should account for two generations of individuals")
+	}
+	var g = GA{
+		NbrPopulations: 3,
+		NbrIndividuals: 10,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+	}
+	if g.EstimateMemory() != 60*SizeOf(sample) {
+		t.Error("The GA should estimate it's memory with a sample of it's initializer")
+	}
+}
+
+func TestValidationMemoryLimit(t *testing.T) {
+	var g = GA{
+		NbrPopulations: 4,
+		NbrIndividuals: 1000,
+		NbrGenes:       100,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+		MemoryLimit:    1 << 20,
+	}
+	if g.Validate() == nil {
+		t.Error("A GA exceeding it's memory limit should be invalid")
+	}
+	g.MemoryLimit = 1 << 30
+	if err := g.Validate(); err != nil {
+		t.Error(err)
+	}
+}
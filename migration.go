@@ -1,13 +1,31 @@
 package gago
 
-import "math/rand"
+import (
+	"math"
+	"sync"
+)
 
-// Migrator applies crossover to the GA level, as such it doesn't
-// require an independent random number generator and can use the global one.
+// Migrator applies crossover to the GA level. Random decisions should be made
+// with the random number generators of the populations, see Population.Rand,
+// rather than the global one so that runs can be seeded and replayed.
 type Migrator interface {
 	Apply(Populations)
 }
 
+// A RemoteMigrator is a Migrator that also exchanges individuals with the
+// populations of other processes, see the island package. Unlike the other
+// migrators it is applied even if the GA has a single population.
+type RemoteMigrator interface {
+	Migrator
+	Remote() bool
+}
+
+// Check if a migrator exchanges individuals with other processes.
+func isRemote(mig Migrator) bool {
+	var remote, ok = mig.(RemoteMigrator)
+	return ok && remote.Remote()
+}
+
 // MigShuffle migration exchanges individuals between Populations in a random fashion.
 type MigShuffle struct{}
 
@@ -16,7 +34,7 @@ func (mig MigShuffle) Apply(pops Populations) {
 	for i := 0; i < len(pops); i++ {
 		for j := i + 1; j < len(pops); j++ {
 			// Choose where to split the individuals
-			var split = rand.Intn(len(pops[i].Individuals))
+			var split = pops[i].rng.Intn(len(pops[i].Individuals))
 			// Create a temporary slice of individuals in order to switch
 			var tmp = make([]Individual, len(pops[i].Individuals))
 			copy(tmp, pops[i].Individuals)
@@ -26,3 +44,275 @@ func (mig MigShuffle) Apply(pops Populations) {
 		}
 	}
 }
+
+// An Edge of a migration topology means that population From sends migrants
+// to population To. Rate is the fraction of the individuals of From that
+// migrate along the edge.
+type Edge struct {
+	From int
+	To   int
+	Rate float64
+}
+
+// A Topology describes which populations send migrants to which populations
+// as a directed graph.
+type Topology interface {
+	Edges(nbPopulations int) []Edge
+}
+
+// TopRing connects the populations in a ring, each population sends migrants
+// to the next one.
+type TopRing struct {
+	Rate float64
+}
+
+// Edges of the ring topology.
+func (top TopRing) Edges(nbPopulations int) []Edge {
+	if nbPopulations < 2 {
+		return nil
+	}
+	var edges = make([]Edge, nbPopulations)
+	for i := range edges {
+		edges[i] = Edge{From: i, To: (i + 1) % nbPopulations, Rate: top.Rate}
+	}
+	return edges
+}
+
+// TopComplete connects every population to every other population.
+type TopComplete struct {
+	Rate float64
+}
+
+// Edges of the complete topology.
+func (top TopComplete) Edges(nbPopulations int) []Edge {
+	var edges []Edge
+	for i := 0; i < nbPopulations; i++ {
+		for j := 0; j < nbPopulations; j++ {
+			if i != j {
+				edges = append(edges, Edge{From: i, To: j, Rate: top.Rate})
+			}
+		}
+	}
+	return edges
+}
+
+// TopMatrix is a topology given by an adjacency matrix, the element at row i
+// and column j is the rate at which population i sends migrants to population
+// j. A rate of 0 means there is no edge. The rows and columns beyond the number
+// of populations are ignored.
+type TopMatrix [][]float64
+
+// Edges of the matrix topology.
+func (top TopMatrix) Edges(nbPopulations int) []Edge {
+	var edges []Edge
+	for i := 0; i < len(top) && i < nbPopulations; i++ {
+		for j := 0; j < len(top[i]) && j < nbPopulations; j++ {
+			if top[i][j] > 0 && i != j {
+				edges = append(edges, Edge{From: i, To: j, Rate: top[i][j]})
+			}
+		}
+	}
+	return edges
+}
+
+// TopGraph is a topology given by the edges of a directed graph, each edge
+// having it's own rate. The edges that refer to a population that doesn't
+// exist are ignored.
+type TopGraph []Edge
+
+// Edges of the graph topology.
+func (top TopGraph) Edges(nbPopulations int) []Edge {
+	var edges []Edge
+	for _, edge := range top {
+		if edge.From >= 0 && edge.From < nbPopulations && edge.To >= 0 && edge.To < nbPopulations {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// MigTopology migration sends copies of individuals of each population along
+// the edges of a Topology. The number of migrants of an edge is it's rate times
+// the number of individuals of the sending population, rounded to the nearest
+// integer. The migrants are chosen by the Emigrants selector, for example
+// SelRandom or SelDiverse, and are the best individuals if Emigrants is nil;
+// the selector should choose distinct individuals. The migrants replace the
+// worst individuals of the receiving population, or random individuals if
+// ReplaceRandom is true, which makes it possible for the best individual to be
+// replaced. A population that receives migrants from several populations gets
+// them all. The migrants are chosen before any of them is sent, hence the order
+// of the edges doesn't matter. The migrants sent along each edge are counted in
+// Flows if it isn't nil, see WriteDOT.
+type MigTopology struct {
+	Topology      Topology
+	Emigrants     Selector
+	ReplaceRandom bool
+	Flows         *MigrationFlows
+}
+
+// MigrationFlows counts the migrants that were sent from each population to
+// each other population. It's safe for concurrent use and has to be used
+// through a pointer.
+type MigrationFlows struct {
+	mu     sync.Mutex
+	counts map[[2]int]int
+}
+
+// Add n migrants sent from a population to another.
+func (flows *MigrationFlows) add(from, to, n int) {
+	flows.mu.Lock()
+	defer flows.mu.Unlock()
+	if flows.counts == nil {
+		flows.counts = make(map[[2]int]int)
+	}
+	flows.counts[[2]int{from, to}] += n
+}
+
+// Count returns the number of migrants that were sent from a population to
+// another.
+func (flows *MigrationFlows) Count(from, to int) int {
+	flows.mu.Lock()
+	defer flows.mu.Unlock()
+	return flows.counts[[2]int{from, to}]
+}
+
+// Apply topology migration.
+func (mig MigTopology) Apply(pops Populations) {
+	for i := range pops {
+		pops[i].Individuals.SortWith(pops[i].cmp)
+	}
+	var emigrants = mig.Emigrants
+	if emigrants == nil {
+		emigrants = SelElitism{}
+	}
+	var incoming = make([]Individuals, len(pops))
+	for _, edge := range mig.Topology.Edges(len(pops)) {
+		var (
+			from   = pops[edge.From]
+			n      = int(math.Round(edge.Rate * float64(len(from.Individuals))))
+			chosen Individuals
+		)
+		if n = min(n, len(from.Individuals)); n > 0 {
+			chosen, _ = emigrants.Apply(n, from.Individuals, from.rng)
+		}
+		if mig.Flows != nil {
+			mig.Flows.add(edge.From, edge.To, len(chosen))
+		}
+		for _, indi := range chosen {
+			// The migrant gets it's own genome so that it can be mutated
+			// without affecting the original
+			var genome = newGenome(len(indi.Genome))
+			copy(genome, indi.Genome)
+			indi.Genome = genome
+			incoming[edge.To] = append(incoming[edge.To], indi)
+		}
+	}
+	for i, migrants := range incoming {
+		var indis = pops[i].Individuals
+		if len(migrants) > len(indis) {
+			migrants = migrants[:len(indis)]
+		}
+		if !mig.ReplaceRandom {
+			copy(indis[len(indis)-len(migrants):], migrants)
+			continue
+		}
+		var replaced, _ = randomInts(len(migrants), 0, len(indis), pops[i].rng)
+		for j, migrant := range migrants {
+			indis[replaced[j]] = migrant
+		}
+	}
+}
+
+// MigArchipelago organizes the populations into archipelagos of Size
+// consecutive populations, the last archipelago possibly being smaller. The
+// Intra migrator is applied to the populations of each archipelago. Every
+// InterFrequency migrations the Inter migrator is applied to the first
+// population of each archipelago, which acts as the gateway of it's
+// archipelago. Both migrators can be archipelagos themselves, which allows
+// building hierarchies of any depth. Intra and Inter can be nil to disable
+// either kind of migration. Because it counts the migrations, MigArchipelago
+// has to be used through a pointer.
+type MigArchipelago struct {
+	Size           int // A Size of 0 puts every population in the same archipelago
+	Intra          Migrator
+	Inter          Migrator
+	InterFrequency int // An InterFrequency of 0 is treated as 1
+	migrations     int
+}
+
+// Apply archipelago migration.
+func (mig *MigArchipelago) Apply(pops Populations) {
+	var size = mig.Size
+	if size < 1 {
+		size = len(pops)
+	}
+	// Migrate within each archipelago, the archipelagos are slices of the
+	// populations hence the migrator modifies the populations directly
+	if mig.Intra != nil {
+		for i := 0; i < len(pops); i += size {
+			mig.Intra.Apply(pops[i:min(i+size, len(pops))])
+		}
+	}
+	// Migrate between the archipelagos through their gateways
+	mig.migrations++
+	var frequency = mig.InterFrequency
+	if frequency < 1 {
+		frequency = 1
+	}
+	if mig.Inter == nil || mig.migrations%frequency != 0 {
+		return
+	}
+	var gateways Populations
+	for i := 0; i < len(pops); i += size {
+		gateways = append(gateways, pops[i])
+	}
+	mig.Inter.Apply(gateways)
+	for i := range gateways {
+		pops[i*size] = gateways[i]
+	}
+}
+
+// MigAdaptive applies Migrator at an interval that adapts to the convergence
+// of the populations: the populations migrate as soon as MinInterval
+// generations have passed since the last migration if one of them has
+// stagnated for Patience generations, otherwise they wait for MaxInterval
+// generations. Populations that still improve are thus left to explore on
+// their own whereas a population that is stuck receives new genetic material
+// early. A population stagnates if it's best individual doesn't improve, see
+// Population.Stagnation. The intervals are counted in calls to Apply, hence the
+// MigFrequency of the GA should be 1. Because it counts the generations,
+// MigAdaptive has to be used through a pointer.
+type MigAdaptive struct {
+	Migrator    Migrator
+	MinInterval int // A MinInterval of 0 is treated as 1
+	MaxInterval int // A MaxInterval lower than MinInterval is treated as MinInterval
+	Patience    int // A Patience of 0 is treated as MinInterval
+	since       int // Number of generations since the last migration
+}
+
+// Apply adaptive migration.
+func (mig *MigAdaptive) Apply(pops Populations) {
+	var (
+		minInterval = max(mig.MinInterval, 1)
+		maxInterval = max(mig.MaxInterval, minInterval)
+		patience    = mig.Patience
+	)
+	if patience < 1 {
+		patience = minInterval
+	}
+	mig.since++
+	if mig.since < minInterval {
+		return
+	}
+	var stagnating = false
+	for _, pop := range pops {
+		if pop.Stagnation >= patience {
+			stagnating = true
+			break
+		}
+	}
+	if stagnating || mig.since >= maxInterval {
+		mig.Migrator.Apply(pops)
+		mig.since = 0
+	}
+}
@@ -1,10 +1,18 @@
 package gago
 
-import "testing"
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
 
 var (
 	migrators = []Migrator{
 		MigShuffle{},
+		MigTopology{Topology: TopRing{Rate: 0.5}},
+		MigTopology{Topology: TopComplete{Rate: 0.2}},
+		MigTopology{Topology: TopMatrix{{0, 1, 0, 0}, {0, 0, 0.5, 0}, {0, 0, 0, 0.5}, {0.5, 0, 0, 0}}},
+		&MigArchipelago{Size: 2, Intra: MigShuffle{}, Inter: MigTopology{Topology: TopRing{Rate: 1}}},
 	}
 )
 
@@ -44,3 +52,260 @@ func TestMigSizes(t *testing.T) {
 		}
 	}
 }
+
+// Make populations whose individuals have the index of their population as
+// their only gene and as their fitness, plus their rank within the population.
+func makeIslands(nbPops, nbIndis int) Populations {
+	var pops = make(Populations, nbPops)
+	for i := range pops {
+		pops[i].Individuals = make(Individuals, nbIndis)
+		for j := range pops[i].Individuals {
+			pops[i].Individuals[j] = Individual{
+				Genome:  Genome{float64(i)},
+				Fitness: float64(i) + float64(j)/float64(nbIndis),
+			}
+		}
+	}
+	return pops
+}
+
+func TestTopologies(t *testing.T) {
+	if len(TopRing{}.Edges(5)) != 5 || len(TopRing{}.Edges(1)) != 0 {
+		t.Error("TopRing produced the wrong number of edges")
+	}
+	if len(TopComplete{}.Edges(5)) != 20 {
+		t.Error("TopComplete produced the wrong number of edges")
+	}
+	var matrix = TopMatrix{
+		{0, 0.5, 0},
+		{0, 0, 0.25},
+		{1, 0, 0},
+	}
+	if !reflect.DeepEqual(matrix.Edges(3), []Edge{{0, 1, 0.5}, {1, 2, 0.25}, {2, 0, 1}}) {
+		t.Errorf("TopMatrix produced the edges %v", matrix.Edges(3))
+	}
+	if !reflect.DeepEqual(matrix.Edges(2), []Edge{{0, 1, 0.5}}) {
+		t.Error("TopMatrix didn't ignore the populations that don't exist")
+	}
+	var graph = TopGraph{{0, 1, 0.5}, {2, 0, 1}}
+	if !reflect.DeepEqual(graph.Edges(2), []Edge{{0, 1, 0.5}}) {
+		t.Error("TopGraph didn't ignore the populations that don't exist")
+	}
+}
+
+func TestMigTopology(t *testing.T) {
+	var pops = makeIslands(3, 4)
+	MigTopology{Topology: TopRing{Rate: 0.5}}.Apply(pops)
+	for i, pop := range pops {
+		// The two worst individuals come from the previous population
+		var from = float64((i + 2) % 3)
+		for j, indi := range pop.Individuals {
+			var expected = float64(i)
+			if j >= 2 {
+				expected = from
+			}
+			if indi.Genome[0] != expected {
+				t.Errorf("Population %d has an individual from population %v at position %d", i, indi.Genome[0], j)
+			}
+		}
+	}
+	// The migrants don't share their genome with the original individuals
+	pops[1].Individuals[2].Genome[0] = 42.0
+	if pops[0].Individuals[0].Genome[0] != 0.0 {
+		t.Error("A migrant shares it's genome with the original individual")
+	}
+}
+
+func TestMigTopologyRates(t *testing.T) {
+	var pops = makeIslands(3, 4)
+	// Population 0 sends 1 migrant to population 1 and 2 migrants to
+	// population 2
+	MigTopology{Topology: TopGraph{{0, 1, 0.25}, {0, 2, 0.5}}}.Apply(pops)
+	var counts = make([]int, 3)
+	for i, pop := range pops {
+		for _, indi := range pop.Individuals {
+			if indi.Genome[0] == 0.0 {
+				counts[i]++
+			}
+		}
+	}
+	if !reflect.DeepEqual(counts, []int{4, 1, 2}) {
+		t.Errorf("Expected {4, 1, 2} individuals from population 0, got %v", counts)
+	}
+}
+
+func TestMigTopologyPolicies(t *testing.T) {
+	var newIslands = func() Populations {
+		var pops = makeIslands(2, 6)
+		for i := range pops {
+			pops[i].rng = rand.New(rand.NewSource(int64(i)))
+		}
+		return pops
+	}
+	// Count the migrants received by the second population and check whether
+	// it's best individual is still there
+	var received = func(pops Populations) (int, bool) {
+		var n, kept = 0, false
+		for _, indi := range pops[1].Individuals {
+			if indi.Genome[0] == 0.0 {
+				n++
+			} else if indi.Fitness == 1 {
+				kept = true
+			}
+		}
+		return n, kept
+	}
+	var testCases = []struct {
+		mig   MigTopology
+		first float64 // Fitness of the first migrant
+	}{
+		{MigTopology{Topology: TopGraph{{0, 1, 0.5}}}, 0},
+		{MigTopology{Topology: TopGraph{{0, 1, 0.5}}, Emigrants: SelDiverse{DistEuclidean{}}}, 0},
+		{MigTopology{Topology: TopGraph{{0, 1, 0.5}}, Emigrants: SelRandom{}, ReplaceRandom: true}, -1},
+	}
+	for i, test := range testCases {
+		var pops = newIslands()
+		test.mig.Apply(pops)
+		var n, kept = received(pops)
+		if n != 3 {
+			t.Errorf("Test case %d: expected 3 migrants, got %d", i, n)
+		}
+		if !test.mig.ReplaceRandom && !kept {
+			t.Errorf("Test case %d: the best individual was replaced", i)
+		}
+		if test.first >= 0 && pops[1].Individuals[3].Fitness != test.first {
+			t.Errorf("Test case %d: expected the best individual to migrate first", i)
+		}
+	}
+}
+
+func TestMigArchipelago(t *testing.T) {
+	var (
+		pops = makeIslands(5, 4)
+		mig  = &MigArchipelago{
+			Size:           2,
+			Inter:          MigTopology{Topology: TopRing{Rate: 0.25}},
+			InterFrequency: 2,
+		}
+	)
+	// There is no migration within the archipelagos and the first migration
+	// between the archipelagos happens at the second migration
+	mig.Apply(pops)
+	if pops[2].Individuals[3].Genome[0] != 2.0 {
+		t.Error("Migration between archipelagos happened too early")
+	}
+	mig.Apply(pops)
+	// The gateways are the populations 0, 2 and 4
+	for i, from := range map[int]float64{0: 4, 2: 0, 4: 2} {
+		if pops[i].Individuals[3].Genome[0] != from {
+			t.Errorf("Gateway %d didn't receive a migrant from gateway %v", i, from)
+		}
+	}
+	for _, i := range []int{1, 3} {
+		if pops[i].Individuals[3].Genome[0] != float64(i) {
+			t.Errorf("Population %d isn't a gateway but received a migrant", i)
+		}
+	}
+}
+
+// A countingMigrator counts the number of times it's applied.
+type countingMigrator struct {
+	calls *int
+}
+
+func (mig countingMigrator) Apply(pops Populations) {
+	*mig.calls++
+}
+
+func TestMigAdaptive(t *testing.T) {
+	var (
+		calls int
+		mig   = &MigAdaptive{Migrator: countingMigrator{&calls}, MinInterval: 2, MaxInterval: 5, Patience: 3}
+		pops  = makeIslands(2, 2)
+	)
+	// The populations improve, they migrate every MaxInterval generations
+	for i := 0; i < 10; i++ {
+		mig.Apply(pops)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 migrations, got %d", calls)
+	}
+	// A population stagnates, they migrate every MinInterval generations
+	pops[1].Stagnation = 3
+	for i := 0; i < 10; i++ {
+		mig.Apply(pops)
+	}
+	if calls != 7 {
+		t.Errorf("Expected 7 migrations, got %d", calls)
+	}
+}
+
+// remoteMigrator counts the migrations and exchanges individuals with other
+// processes.
+type remoteMigrator struct {
+	countingMigrator
+}
+
+func (mig remoteMigrator) Remote() bool {
+	return true
+}
+
+func TestMigrateSinglePopulation(t *testing.T) {
+	var (
+		local, remote int
+		migrators     = map[Migrator]*int{
+			countingMigrator{&local}:                  &local,
+			remoteMigrator{countingMigrator{&remote}}: &remote,
+		}
+	)
+	for mig, calls := range migrators {
+		var ga = GA{
+			NbrPopulations: 1,
+			NbrIndividuals: nbIndividuals,
+			NbrGenes:       nbGenes,
+			Ff:             ff,
+			Initializer:    initializer,
+			Model:          model,
+			Migrator:       mig,
+			MigFrequency:   1,
+		}
+		ga.Initialize()
+		for i := 0; i < 3; i++ {
+			ga.Enhance()
+		}
+		if _, ok := mig.(RemoteMigrator); ok && *calls != 3 {
+			t.Errorf("A remote migrator should be applied to a single population, got %d migrations", *calls)
+		} else if !ok && *calls != 0 {
+			t.Errorf("A migrator shouldn't be applied to a single population, got %d migrations", *calls)
+		}
+	}
+}
+
+func TestPopulationStagnation(t *testing.T) {
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          model,
+	}
+	ga.Initialize()
+	for i := 0; i < 20; i++ {
+		var (
+			best       = ga.Populations[0].Individuals[0].Fitness
+			stagnation = ga.Populations[0].Stagnation
+		)
+		ga.Enhance()
+		var pop = ga.Populations[0]
+		if pop.Individuals[0].Fitness < best && pop.Stagnation != 0 {
+			t.Errorf("The population improved but has a stagnation of %d", pop.Stagnation)
+		}
+		if pop.Individuals[0].Fitness >= best && pop.Stagnation != stagnation+1 {
+			t.Errorf("Expected a stagnation of %d, got %d", stagnation+1, pop.Stagnation)
+		}
+	}
+	if ga.Stats().Populations[0].Stagnation != ga.Populations[0].Stagnation {
+		t.Error("Stats didn't report the stagnation of the population")
+	}
+}
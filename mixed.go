@@ -0,0 +1,400 @@
+package gago
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Mixed genomes contain a gene per variable of a problem, a float64 for a
+// continuous variable, an int for an integer variable and a string for a
+// categorical variable. The variables are described by a slice of Variable that
+// is given to each of the mixed operators, which keep the genes within their
+// domains and the integer genes integral. MixedFunction evaluates such genomes
+// as slices of float64. A variable can depend on a Condition, in which case
+// it's gene is only active when the condition is met, the inactive genes are
+// left alone by the operators and ignored by DistMixed. The variables of the
+// mixed operators are checked by ValidateVariables when the GA is validated.
+
+// A Variable of a mixed problem belongs to [Lower, Upper], it's gene is an int
+// if Integer is true and a float64 otherwise. The bounds of an integer variable
+// are rounded inwards. If Labels isn't empty the variable is categorical, it's
+// gene is one of the Labels and the bounds are ignored. The labels of an
+// Ordered variable follow a natural order, for example "low", "medium" and
+// "high", which the mutation takes into account. If Condition isn't nil the
+// variable is only active when the condition is met.
+type Variable struct {
+	Lower, Upper float64
+	Integer      bool
+	Labels       []string
+	Ordered      bool
+	Condition    *Condition
+}
+
+// A Condition is met when the gene at index Gene is active and is one of the
+// Labels, for example an "optimizer" variable whose gene is "adam" activates
+// a "beta1" variable. Gene should be the index of a categorical variable that
+// precedes the conditioned variable, which rules out circular conditions.
+type Condition struct {
+	Gene   int
+	Labels []string
+}
+
+// Return true if the gene of the variable is active in a genome, active holds
+// the activity of the preceding genes.
+func (v Variable) active(genome Genome, active []bool) bool {
+	var c = v.Condition
+	if c == nil {
+		return true
+	}
+	if !active[c.Gene] {
+		return false
+	}
+	for _, label := range c.Labels {
+		if genome[c.Gene] == label {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveGenes returns whether each gene of a mixed genome is active according
+// to the conditions of the variables.
+func ActiveGenes(variables []Variable, genome Genome) []bool {
+	var active = make([]bool, len(variables))
+	for i, v := range variables {
+		active[i] = v.active(genome, active)
+	}
+	return active
+}
+
+// ValidateVariables verifies the variables of a mixed problem describe
+// non-empty domains and that their conditions refer to preceding categorical
+// variables, which the mixed operators rely on.
+func ValidateVariables(variables []Variable) error {
+	for i, v := range variables {
+		if v.categorical() {
+			// Check the labels are unique
+			var seen = make(map[string]bool)
+			for _, label := range v.Labels {
+				if seen[label] {
+					return fmt.Errorf("the label '%s' of variable %d is duplicated", label, i)
+				}
+				seen[label] = true
+			}
+		} else {
+			// Check the bounds
+			if !(v.Lower <= v.Upper) {
+				return fmt.Errorf("'Lower' of variable %d should be lower or equal to 'Upper'", i)
+			}
+			if v.Integer && math.Ceil(v.Lower) > math.Floor(v.Upper) {
+				return fmt.Errorf("the interval of variable %d should contain an integer", i)
+			}
+		}
+		// Check the condition
+		var c = v.Condition
+		if c == nil {
+			continue
+		}
+		if c.Gene < 0 || c.Gene >= i {
+			return fmt.Errorf("the condition of variable %d should refer to a preceding variable", i)
+		}
+		var parent = variables[c.Gene]
+		if !parent.categorical() {
+			return fmt.Errorf("the condition of variable %d should refer to a categorical variable", i)
+		}
+		if len(c.Labels) == 0 {
+			return fmt.Errorf("the condition of variable %d should have labels", i)
+		}
+		for _, label := range c.Labels {
+			if parent.value(label) < 0 {
+				return fmt.Errorf("the label '%s' of the condition of variable %d isn't a label of variable %d", label, i, c.Gene)
+			}
+		}
+	}
+	return nil
+}
+
+// Return true if the variable is categorical.
+func (v Variable) categorical() bool {
+	return len(v.Labels) > 0
+}
+
+// Return the gene of the variable that is closest to a value, the value is
+// clipped to the bounds of the variable and rounded if the variable is an
+// integer. The value of a categorical variable is the index of it's label.
+func (v Variable) gene(x float64) interface{} {
+	if v.categorical() {
+		return v.Labels[clipInt(int(math.Round(x)), 0, len(v.Labels)-1)]
+	}
+	if v.Integer {
+		return int(math.Max(math.Ceil(v.Lower), math.Min(math.Floor(v.Upper), math.Round(x))))
+	}
+	return math.Max(v.Lower, math.Min(v.Upper, x))
+}
+
+// Return the value of a gene of the variable as a float64, the value of a
+// label is it's index, or -1 if the label doesn't belong to the variable.
+func (v Variable) value(gene interface{}) float64 {
+	switch g := gene.(type) {
+	case int:
+		return float64(g)
+	case string:
+		for i, label := range v.Labels {
+			if label == g {
+				return float64(i)
+			}
+		}
+		return -1
+	}
+	return gene.(float64)
+}
+
+// MixedFunction is for functions of mixed genomes, the genes are given as
+// floating points values, the values of the integer genes being integral. The
+// categorical genes are given as the indexes of their labels, which requires
+// the Variables, the Variables can be nil if there are no categorical genes.
+// The inactive genes are given as NaN.
+type MixedFunction struct {
+	Image     func([]float64) float64
+	Variables []Variable
+}
+
+// Apply the fitness function wrapped in MixedFunction.
+func (ff MixedFunction) apply(genome Genome) float64 {
+	var (
+		casted = make([]float64, len(genome))
+		active = ActiveGenes(ff.Variables, genome)
+	)
+	for i, gene := range genome {
+		if i >= len(ff.Variables) {
+			casted[i] = Variable{}.value(gene)
+		} else if active[i] {
+			casted[i] = ff.Variables[i].value(gene)
+		} else {
+			casted[i] = math.NaN()
+		}
+	}
+	return ff.Image(casted)
+}
+
+// InitMixed generates mixed genomes whose genes are drawn uniformly within the
+// domains of their variables. The genomes should have as many genes
+// as there are Variables.
+type InitMixed struct {
+	Variables []Variable
+}
+
+// Apply the InitMixed initializer.
+func (init InitMixed) Apply(indi *Individual, rng *rand.Rand) {
+	for i, v := range init.Variables {
+		switch {
+		case v.categorical():
+			indi.Genome[i] = v.Labels[rng.Intn(len(v.Labels))]
+		case v.Integer:
+			var lower, upper = int(math.Ceil(v.Lower)), int(math.Floor(v.Upper))
+			indi.Genome[i] = lower + rng.Intn(upper-lower+1)
+		default:
+			indi.Genome[i] = v.Lower + rng.Float64()*(v.Upper-v.Lower)
+		}
+	}
+}
+
+// Validate the InitMixed initializer.
+func (init InitMixed) Validate() error {
+	return ValidateVariables(init.Variables)
+}
+
+// CrossMixed applies BLX-alpha crossover to mixed genomes: each numerical gene
+// of the offsprings is drawn uniformly in the interval spanned by the parents'
+// genes, extended on both sides by Alpha times it's width. The genes are then
+// clipped to the bounds of their variables and the integer genes are rounded.
+// Each categorical gene is inherited from one parent or the other, hence the
+// offsprings never leave the domain. A numerical gene that is inactive in one
+// of the parents is copied from the parents as is, the value of an inactive
+// gene being meaningless.
+type CrossMixed struct {
+	Variables []Variable
+	Alpha     float64
+}
+
+// Apply mixed crossover.
+func (cross CrossMixed) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var (
+		nbGenes = len(p1.Genome)
+		o1      = makeIndividual(nbGenes, rng)
+		o2      = makeIndividual(nbGenes, rng)
+		active1 = ActiveGenes(cross.Variables, p1.Genome)
+		active2 = ActiveGenes(cross.Variables, p2.Genome)
+	)
+	for i, v := range cross.Variables {
+		if !v.categorical() && !(active1[i] && active2[i]) {
+			o1.Genome[i], o2.Genome[i] = p1.Genome[i], p2.Genome[i]
+			continue
+		}
+		if v.categorical() {
+			o1.Genome[i], o2.Genome[i] = p1.Genome[i], p2.Genome[i]
+			if rng.Float64() < 0.5 {
+				o1.Genome[i], o2.Genome[i] = p2.Genome[i], p1.Genome[i]
+			}
+			continue
+		}
+		var (
+			a     = v.value(p1.Genome[i])
+			b     = v.value(p2.Genome[i])
+			width = math.Abs(a - b)
+			lower = math.Min(a, b) - cross.Alpha*width
+			upper = math.Max(a, b) + cross.Alpha*width
+		)
+		o1.Genome[i] = v.gene(lower + rng.Float64()*(upper-lower))
+		o2.Genome[i] = v.gene(lower + rng.Float64()*(upper-lower))
+	}
+	return o1, o2
+}
+
+// Validate the CrossMixed crossover.
+func (cross CrossMixed) Validate() error {
+	return ValidateVariables(cross.Variables)
+}
+
+// MutMixed perturbs each gene of a mixed genome with probability Rate. A
+// numerical gene is shifted by a value sampled from a normal distribution
+// centered on 0 and whose standard deviation is Std times the width of the
+// variable's domain, a Std of 0 is treated as 0.1. The step of an integer gene
+// is rounded and is at least 1 in absolute value, otherwise the narrow integer
+// variables would seldom change. The genes are clipped to the bounds of their
+// variables. A categorical gene is replaced by a different label drawn
+// uniformly, or by one of the neighbouring labels if the variable is Ordered.
+// The inactive genes are not mutated.
+type MutMixed struct {
+	Variables []Variable
+	Rate      float64
+	Std       float64
+}
+
+// Apply mixed mutation.
+func (mut MutMixed) Apply(indi *Individual, rng *rand.Rand) {
+	var std = mut.Std
+	if std == 0 {
+		std = 0.1
+	}
+	var active = make([]bool, len(mut.Variables))
+	for i, v := range mut.Variables {
+		// The activity depends on the preceding genes, which might have been
+		// mutated
+		active[i] = v.active(indi.Genome, active)
+		if !active[i] || rng.Float64() >= mut.Rate {
+			continue
+		}
+		if v.categorical() {
+			indi.Genome[i] = v.relabel(indi.Genome[i], rng)
+			continue
+		}
+		var step = rng.NormFloat64() * std * (v.Upper - v.Lower)
+		if v.Integer {
+			step = math.Round(step)
+			if step == 0 {
+				step = 1
+				if rng.Float64() < 0.5 {
+					step = -1
+				}
+			}
+		}
+		indi.Genome[i] = v.gene(v.value(indi.Genome[i]) + step)
+	}
+}
+
+// Validate the MutMixed mutator.
+func (mut MutMixed) Validate() error {
+	return ValidateVariables(mut.Variables)
+}
+
+// RepMixed repairs mixed genomes by clipping each gene to the bounds of it's
+// variable and by rounding the integer genes, the integer genes are converted
+// to ints if they were floating points, which allows using the floating point
+// operators on mixed genomes. A numerical categorical gene is taken as the
+// index of a label, and a label that doesn't belong to it's variable is
+// replaced by the first label.
+type RepMixed struct {
+	Variables []Variable
+}
+
+// Apply mixed repair.
+func (rep RepMixed) Apply(indi *Individual, rng *rand.Rand) {
+	for i, v := range rep.Variables {
+		indi.Genome[i] = v.gene(v.value(indi.Genome[i]))
+	}
+}
+
+// Validate the RepMixed repairer.
+func (rep RepMixed) Validate() error {
+	return ValidateVariables(rep.Variables)
+}
+
+// Return a label of a categorical variable that is different from the gene,
+// the label is a neighbour of the gene's label if the variable is ordered.
+func (v Variable) relabel(gene interface{}, rng *rand.Rand) interface{} {
+	var n = len(v.Labels)
+	if n == 1 {
+		return v.Labels[0]
+	}
+	var i = int(v.value(gene))
+	if i < 0 {
+		return v.Labels[rng.Intn(n)]
+	}
+	if v.Ordered {
+		switch {
+		case i == 0:
+			return v.Labels[1]
+		case i == n-1 || rng.Float64() < 0.5:
+			return v.Labels[i-1]
+		}
+		return v.Labels[i+1]
+	}
+	// Draw among the other labels
+	var j = rng.Intn(n - 1)
+	if j >= i {
+		j++
+	}
+	return v.Labels[j]
+}
+
+// DistMixed computes a distance between mixed genomes in the manner of Gower's
+// distance, it sums the distances between the genes of each variable. The
+// distance between two numerical genes is their difference divided by the
+// width of the domain, the distance between two labels is 1 if they differ or
+// the difference of their indexes divided by the number of labels minus 1 if
+// the variable is Ordered. A gene that is inactive in both genomes doesn't
+// count and a gene that is active in a single genome counts as 1.
+type DistMixed struct {
+	Variables []Variable
+}
+
+// Apply the mixed distance.
+func (dist DistMixed) Apply(a, b Individual) float64 {
+	var (
+		activeA  = ActiveGenes(dist.Variables, a.Genome)
+		activeB  = ActiveGenes(dist.Variables, b.Genome)
+		distance float64
+	)
+	for i, v := range dist.Variables {
+		switch {
+		case !activeA[i] && !activeB[i]:
+		case activeA[i] != activeB[i]:
+			distance++
+		case v.categorical() && !v.Ordered:
+			if a.Genome[i] != b.Genome[i] {
+				distance++
+			}
+		default:
+			var width = v.Upper - v.Lower
+			if v.categorical() {
+				width = float64(len(v.Labels) - 1)
+			}
+			if width > 0 {
+				distance += math.Abs(v.value(a.Genome[i])-v.value(b.Genome[i])) / width
+			}
+		}
+	}
+	return distance
+}
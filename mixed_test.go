@@ -0,0 +1,237 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var variables = []Variable{
+	{Lower: -1, Upper: 1},
+	{Lower: 0.5, Upper: 3.5, Integer: true},
+	{Lower: 0, Upper: 1, Integer: true},
+}
+
+// Check that a genome is within the bounds of the variables and that it's
+// integer genes are ints.
+func checkMixed(t *testing.T, genome Genome) {
+	for i, v := range variables {
+		var x float64
+		if v.Integer {
+			var n, ok = genome[i].(int)
+			if !ok {
+				t.Fatalf("Gene %d should be an int, got %v", i, genome[i])
+			}
+			x = float64(n)
+		} else {
+			x = genome[i].(float64)
+		}
+		if x < v.Lower || x > v.Upper {
+			t.Fatalf("Gene %d is out of bounds: %v", i, genome[i])
+		}
+	}
+}
+
+func TestMixedOperators(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(42))
+		p1, p2 = makeIndividual(3, rng), makeIndividual(3, rng)
+		mut    = MutMixed{Variables: variables, Rate: 1, Std: 1}
+		cross  = CrossMixed{Variables: variables, Alpha: 2}
+	)
+	InitMixed{variables}.Apply(&p1, rng)
+	InitMixed{variables}.Apply(&p2, rng)
+	for i := 0; i < 100; i++ {
+		checkMixed(t, p1.Genome)
+		checkMixed(t, p2.Genome)
+		p1, p2 = cross.Apply(p1, p2, rng)
+		mut.Apply(&p1, rng)
+	}
+	// The steps of the integer genes are at least 1
+	var indi = Individual{Genome: Genome{0.0, 2, 0}}
+	MutMixed{Variables: variables, Rate: 1, Std: 1e-6}.Apply(&indi, rng)
+	if indi.Genome[1] == 2 || indi.Genome[2] != 1 {
+		t.Errorf("The integer genes should have moved, got %v", indi.Genome)
+	}
+}
+
+func TestRepMixed(t *testing.T) {
+	var indi = Individual{Genome: Genome{1.5, 2.6, -3.0}}
+	RepMixed{variables}.Apply(&indi, nil)
+	checkMixed(t, indi.Genome)
+	if indi.Genome[0] != 1.0 || indi.Genome[1] != 3 || indi.Genome[2] != 0 {
+		t.Errorf("Expected the genome [1 3 0], got %v", indi.Genome)
+	}
+}
+
+func TestMixedFunction(t *testing.T) {
+	var ff = MixedFunction{Image: func(X []float64) float64 { return X[0] + X[1] }}
+	if ff.apply(Genome{0.5, 2}) != 2.5 {
+		t.Error("MixedFunction didn't cast the genes")
+	}
+	if ff.apply(Genome{0.5, 2.0}) != 2.5 {
+		t.Error("MixedFunction should accept floating point genes")
+	}
+}
+
+func TestCategoricalVariable(t *testing.T) {
+	var (
+		rng       = rand.New(rand.NewSource(42))
+		optimizer = Variable{Labels: []string{"sgd", "adam", "rmsprop"}}
+		level     = Variable{Labels: []string{"low", "medium", "high"}, Ordered: true}
+		variables = []Variable{optimizer, level}
+		p1, p2    = makeIndividual(2, rng), makeIndividual(2, rng)
+		mut       = MutMixed{Variables: variables, Rate: 1}
+	)
+	InitMixed{variables}.Apply(&p1, rng)
+	InitMixed{variables}.Apply(&p2, rng)
+	for i := 0; i < 100; i++ {
+		p1, p2 = CrossMixed{Variables: variables}.Apply(p1, p2, rng)
+		for j, v := range variables {
+			if v.value(p1.Genome[j]) < 0 || v.value(p2.Genome[j]) < 0 {
+				t.Fatalf("Crossover produced an unknown label: %v %v", p1.Genome, p2.Genome)
+			}
+		}
+		var before = append(Genome{}, p1.Genome...)
+		mut.Apply(&p1, rng)
+		if p1.Genome[0] == before[0] || p1.Genome[1] == before[1] {
+			t.Fatalf("Mutation didn't change the labels: %v", p1.Genome)
+		}
+		// An ordered label only moves to a neighbouring label
+		var step = level.value(p1.Genome[1]) - level.value(before[1])
+		if step != 1 && step != -1 {
+			t.Fatalf("Expected a neighbouring label, went from %v to %v", before[1], p1.Genome[1])
+		}
+	}
+	// Labels are given to the fitness function as indexes and unknown labels
+	// are repaired
+	var indi = Individual{Genome: Genome{"adam", "unknown"}}
+	RepMixed{variables}.Apply(&indi, rng)
+	if indi.Genome[1] != "low" {
+		t.Errorf("Expected the first label, got %v", indi.Genome[1])
+	}
+	var ff = MixedFunction{
+		Image:     func(X []float64) float64 { return 10*X[0] + X[1] },
+		Variables: variables,
+	}
+	if ff.apply(indi.Genome) != 10 {
+		t.Errorf("Expected a fitness of 10, got %f", ff.apply(indi.Genome))
+	}
+}
+
+// An optimizer, a learning rate, a momentum that is only used by adam and a
+// number of restarts that is only used by sgd.
+var conditional = []Variable{
+	{Labels: []string{"sgd", "adam"}},
+	{Lower: 0, Upper: 1},
+	{Lower: 0, Upper: 1, Condition: &Condition{Gene: 0, Labels: []string{"adam"}}},
+	{Lower: 0, Upper: 10, Integer: true, Condition: &Condition{Gene: 0, Labels: []string{"sgd"}}},
+}
+
+func TestActiveGenes(t *testing.T) {
+	var active = ActiveGenes(conditional, Genome{"sgd", 0.1, 0.9, 3})
+	if !active[0] || !active[1] || active[2] || !active[3] {
+		t.Errorf("Expected the activity [true true false true], got %v", active)
+	}
+	active = ActiveGenes(conditional, Genome{"adam", 0.1, 0.9, 3})
+	if !active[2] || active[3] {
+		t.Errorf("Expected the activity [true true true false], got %v", active)
+	}
+	// The inactive genes are given as NaN
+	var ff = MixedFunction{
+		Image: func(X []float64) float64 {
+			if !math.IsNaN(X[2]) {
+				t.Error("The inactive gene should be NaN")
+			}
+			return X[0]
+		},
+		Variables: conditional,
+	}
+	ff.apply(Genome{"sgd", 0.1, 0.9, 3})
+}
+
+func TestConditionalOperators(t *testing.T) {
+	var (
+		rng = rand.New(rand.NewSource(42))
+		p1  = Individual{Genome: Genome{"sgd", 0.1, 0.2, 3}}
+		p2  = Individual{Genome: Genome{"adam", 0.5, 0.8, 5}}
+	)
+	// The inactive genes aren't mutated
+	for i := 0; i < 20; i++ {
+		var mutant = Individual{Genome: append(Genome{}, p1.Genome...)}
+		MutMixed{Variables: conditional, Rate: 1}.Apply(&mutant, rng)
+		if mutant.Genome[0] == "sgd" && mutant.Genome[2] != 0.2 {
+			t.Fatalf("Inactive genes were mutated: %v", mutant.Genome)
+		}
+	}
+	// The genes that are inactive in a parent are copied
+	for i := 0; i < 20; i++ {
+		var o1, o2 = CrossMixed{Variables: conditional, Alpha: 0.5}.Apply(p1, p2, rng)
+		if o1.Genome[2] != 0.2 || o2.Genome[2] != 0.8 || o1.Genome[3] != 3 || o2.Genome[3] != 5 {
+			t.Fatalf("Inactive genes were crossed: %v %v", o1.Genome, o2.Genome)
+		}
+	}
+}
+
+func TestDistMixed(t *testing.T) {
+	var (
+		dist = DistMixed{conditional}
+		a    = Individual{Genome: Genome{"adam", 0.1, 0.2, 3}}
+		b    = Individual{Genome: Genome{"adam", 0.6, 0.2, 3}}
+		c    = Individual{Genome: Genome{"sgd", 0.1, 0.9, 3}}
+		d    = Individual{Genome: Genome{"sgd", 0.1, 0.2, 3}}
+	)
+	if dist.Apply(a, b) != 0.5 {
+		t.Errorf("Expected a distance of 0.5, got %f", dist.Apply(a, b))
+	}
+	// The label differs, the momentum is only active in a and the number of
+	// restarts is only active in c
+	if dist.Apply(a, c) != 3 {
+		t.Errorf("Expected a distance of 3, got %f", dist.Apply(a, c))
+	}
+	// The inactive genes are ignored
+	if dist.Apply(c, d) != 0 {
+		t.Errorf("Expected a distance of 0, got %f", dist.Apply(c, d))
+	}
+}
+
+func TestValidateVariables(t *testing.T) {
+	if err := ValidateVariables(conditional); err != nil {
+		t.Error(err)
+	}
+	var invalid = [][]Variable{
+		{{Lower: 1, Upper: 0}},
+		{{Lower: 0.2, Upper: 0.8, Integer: true}},
+		{{Labels: []string{"a", "a"}}},
+		{{Lower: 0, Upper: 1, Condition: &Condition{Gene: 0, Labels: []string{"a"}}}},
+		{{Labels: []string{"a"}}, {Lower: 0, Upper: 1, Condition: &Condition{Gene: 5, Labels: []string{"a"}}}},
+		{{Lower: 0, Upper: 1}, {Lower: 0, Upper: 1, Condition: &Condition{Gene: 0, Labels: []string{"a"}}}},
+		{{Labels: []string{"a"}}, {Lower: 0, Upper: 1, Condition: &Condition{Gene: 0}}},
+		{{Labels: []string{"a"}}, {Lower: 0, Upper: 1, Condition: &Condition{Gene: 0, Labels: []string{"b"}}}},
+	}
+	for _, variables := range invalid {
+		if err := ValidateVariables(variables); err == nil {
+			t.Errorf("Expected an error for %v", variables)
+		}
+	}
+	// The mixed operators are validated with the GA
+	var ga = GA{
+		Ff:             MixedFunction{Image: func(X []float64) float64 { return X[0] }},
+		Initializer:    InitMixed{invalid[1]},
+		Model:          ModGenerational{Selector: SelElitism{}, Crossover: CrossMixed{Variables: variables}, Mutator: MutMixed{Variables: variables}},
+		NbrPopulations: 1,
+		NbrIndividuals: 10,
+		NbrGenes:       1,
+	}
+	if ga.Validate() == nil {
+		t.Error("The variables of InitMixed should be validated")
+	}
+	ga.Initializer = InitMixed{variables}
+	if err := ga.Validate(); err != nil {
+		t.Error(err)
+	}
+	ga.Model = ModGenerational{Selector: SelElitism{}, Crossover: CrossMixed{Variables: invalid[1]}, Mutator: MutMixed{Variables: variables}}
+	if ga.Validate() == nil {
+		t.Error("The variables of CrossMixed should be validated")
+	}
+}
@@ -36,24 +36,80 @@ func generateOffsprings(n int, indis Individuals, sel Selector, cross Crossover,
 	return offsprings
 }
 
-// ModGenerational implements the generational model.
+// generateOffspringsInto is the same as generateOffsprings except that the
+// offsprings are written into existing individuals.
+func generateOffspringsInto(offsprings, indis Individuals, sel Selector, cross CrossoverInto, rng *rand.Rand) {
+	for i := 0; i < len(offsprings); i += 2 {
+		var parents, _ = sel.Apply(2, indis, rng)
+		if i+1 < len(offsprings) {
+			cross.ApplyInto(parents[0], parents[1], &offsprings[i], &offsprings[i+1], rng)
+		} else {
+			var extra Individual
+			cross.ApplyInto(parents[0], parents[1], &offsprings[i], &extra, rng)
+		}
+	}
+}
+
+// ModGenerational implements the generational model. If Reuse is true and the
+// crossover implements CrossoverInto then the offsprings are written into the
+// individuals of the previous generation instead of being allocated, which
+// relieves the garbage collector for large populations. With other crossovers
+// the genomes of the previous generation are put in a pool from which new
+// genomes are taken. In both cases the genomes of the individuals of a
+// population shouldn't be held onto once the next generation has been
+// produced, they should be copied instead, and the crossover should produce
+// offsprings that don't share their genome with their parents. If Pairer
+// isn't nil the parents are selected at once and then paired by it.
 type ModGenerational struct {
 	Selector  Selector
+	Pairer    Pairer
 	Crossover Crossover
 	Mutator   Mutator
 	MutRate   float64
+	Reuse     bool
 }
 
 // Apply the generational model to a population.
 func (mod ModGenerational) Apply(pop *Population) {
-	// Generate as many offsprings as there are of individuals in the current population
-	var offsprings = generateOffsprings(
-		len(pop.Individuals),
-		pop.Individuals,
-		mod.Selector,
-		mod.Crossover,
-		pop.rng,
-	)
+	var offsprings Individuals
+	if cross, ok := mod.Crossover.(CrossoverInto); ok && mod.Reuse {
+		// Reuse the individuals of the previous generation
+		offsprings = pop.spare
+		for len(offsprings) < len(pop.Individuals) {
+			offsprings = append(offsprings, makeIndividual(0, pop.rng))
+		}
+		offsprings = offsprings[:len(pop.Individuals)]
+		if mod.Pairer != nil {
+			generatePairedOffspringsInto(offsprings, pop.Individuals, mod.Selector, mod.Pairer, cross, pop.rng)
+		} else {
+			generateOffspringsInto(offsprings, pop.Individuals, mod.Selector, cross, pop.rng)
+		}
+		pop.spare = pop.Individuals
+	} else if mod.Pairer != nil {
+		offsprings = generatePairedOffsprings(
+			len(pop.Individuals),
+			pop.Individuals,
+			mod.Selector,
+			mod.Pairer,
+			mod.Crossover,
+			pop.rng,
+		)
+		if mod.Reuse {
+			pop.Individuals.recycle()
+		}
+	} else {
+		// Generate as many offsprings as there are of individuals in the current population
+		offsprings = generateOffsprings(
+			len(pop.Individuals),
+			pop.Individuals,
+			mod.Selector,
+			mod.Crossover,
+			pop.rng,
+		)
+		if mod.Reuse {
+			pop.Individuals.recycle()
+		}
+	}
 	// Apply mutation to the offsprings
 	if mod.Mutator != nil {
 		offsprings.Mutate(mod.Mutator, mod.MutRate, pop.rng)
@@ -96,19 +152,14 @@ func (mod ModSteadyState) Apply(pop *Population) {
 	)
 	// Apply mutation to the offsprings
 	if mod.Mutator != nil {
-		if pop.rng.Float64() < mod.MutRate {
-			mod.Mutator.Apply(&offspring1, pop.rng)
-		}
-		if pop.rng.Float64() < mod.MutRate {
-			mod.Mutator.Apply(&offspring2, pop.rng)
-		}
+		mutateSiblings(&offspring1, &offspring2, mod.Mutator, mod.MutRate, pop.rng)
 	}
 	if mod.KeepBest {
 		// Replace the chosen parents with the best individuals out of the parents and the individuals
 		offspring1.Evaluate(pop.ff)
 		offspring2.Evaluate(pop.ff)
 		var indis = Individuals{parents[0], parents[1], offspring1, offspring2}
-		indis.Sort()
+		indis.SortWith(pop.cmp)
 		pop.Individuals[indexes[0]] = indis[0]
 		pop.Individuals[indexes[1]] = indis[1]
 	} else {
@@ -139,10 +190,12 @@ func (mod ModSteadyState) Validate() error {
 	return nil
 }
 
-// ModDownToSize implements the select down to size model.
+// ModDownToSize implements the select down to size model. If Pairer isn't nil
+// the parents chosen by SelectorA are selected at once and then paired by it.
 type ModDownToSize struct {
 	NbrOffsprings int
 	SelectorA     Selector
+	Pairer        Pairer
 	Crossover     Crossover
 	SelectorB     Selector
 	Mutator       Mutator
@@ -151,13 +204,25 @@ type ModDownToSize struct {
 
 // Apply the steady state model to a population.
 func (mod ModDownToSize) Apply(pop *Population) {
-	var offsprings = generateOffsprings(
-		mod.NbrOffsprings,
-		pop.Individuals,
-		mod.SelectorA,
-		mod.Crossover,
-		pop.rng,
-	)
+	var offsprings Individuals
+	if mod.Pairer != nil {
+		offsprings = generatePairedOffsprings(
+			mod.NbrOffsprings,
+			pop.Individuals,
+			mod.SelectorA,
+			mod.Pairer,
+			mod.Crossover,
+			pop.rng,
+		)
+	} else {
+		offsprings = generateOffsprings(
+			mod.NbrOffsprings,
+			pop.Individuals,
+			mod.SelectorA,
+			mod.Crossover,
+			pop.rng,
+		)
+	}
 	// Apply mutation to the offsprings
 	if mod.Mutator != nil {
 		offsprings.Mutate(mod.Mutator, mod.MutRate, pop.rng)
@@ -213,12 +278,7 @@ func (mod ModRing) Apply(pop *Population) {
 		)
 		// Apply mutation to the offsprings
 		if mod.Mutator != nil {
-			if pop.rng.Float64() < mod.MutRate {
-				mod.Mutator.Apply(&offspring1, pop.rng)
-			}
-			if pop.rng.Float64() < mod.MutRate {
-				mod.Mutator.Apply(&offspring2, pop.rng)
-			}
+			mutateSiblings(&offspring1, &offspring2, mod.Mutator, mod.MutRate, pop.rng)
 		}
 		offspring1.Evaluate(pop.ff)
 		offspring2.Evaluate(pop.ff)
@@ -259,11 +319,11 @@ func (mod ModSimAnn) Apply(pop *Population) {
 	for mod.T > mod.Tmin {
 		for i, indi := range pop.Individuals {
 			// Generate a random neighbour through mutation
-			var neighbour = indi
-			mod.Mutator.Apply(&neighbour, pop.rng)
+			var neighbour = indi.clone(pop.rng)
+			neighbour.Mutate(mod.Mutator, pop.rng)
 			neighbour.Evaluate(pop.ff)
 			// Check if the neighbour is better or not
-			if neighbour.Fitness < indi.Fitness {
+			if less(pop.cmp, neighbour, indi) {
 				pop.Individuals[i] = neighbour
 			} else {
 				// Compute the expectance probability
@@ -299,6 +359,61 @@ func (mod ModSimAnn) Validate() error {
 	return nil
 }
 
+// ModMultiParent implements a generational model where each offspring is
+// generated from NbrParents parents by a MultiCrossover, which receives the
+// parents sorted from the best to the worst. The parents of each offspring are
+// chosen by Selector, with SelElitism every offspring is generated from the
+// NbrParents best individuals, which together with CrossRankMuF and a gaussian
+// mutation makes up the (mu/mu_w, lambda) evolution strategy.
+type ModMultiParent struct {
+	Selector   Selector
+	NbrParents int
+	Crossover  MultiCrossover
+	Mutator    Mutator
+	MutRate    float64
+}
+
+// Apply the multi-parent model to a population.
+func (mod ModMultiParent) Apply(pop *Population) {
+	var offsprings = make(Individuals, len(pop.Individuals))
+	for i := range offsprings {
+		var (
+			selected, _ = mod.Selector.Apply(mod.NbrParents, pop.Individuals, pop.rng)
+			parents     = make(Individuals, len(selected))
+		)
+		// Sort the parents so that their index is their rank
+		copy(parents, selected)
+		parents.SortWith(pop.cmp)
+		offsprings[i] = mod.Crossover.ApplyMulti(parents, pop.rng)
+	}
+	// Apply mutation to the offsprings
+	if mod.Mutator != nil {
+		offsprings.Mutate(mod.Mutator, mod.MutRate, pop.rng)
+	}
+	pop.Individuals = offsprings
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModMultiParent) Validate() error {
+	// Check the selection method presence
+	if mod.Selector == nil {
+		return errors.New("'Selector' cannot be nil")
+	}
+	// Check the number of parents value
+	if mod.NbrParents < 1 {
+		return errors.New("'NbrParents' should be higher than 0")
+	}
+	// Check the crossover method presence
+	if mod.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if mod.Mutator != nil && (mod.MutRate < 0 || mod.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	return nil
+}
+
 // ModMutationOnly implements the mutation only model.
 type ModMutationOnly struct {
 	NbrParents    int
@@ -328,8 +443,8 @@ func (mod ModMutationOnly) Apply(pop *Population) {
 			i++
 		}
 		for j := 0; j < mod.NbrOffsprings; j++ {
-			var offspring = parent
-			mod.Mutator.Apply(&offspring, pop.rng)
+			var offspring = parent.clone(pop.rng)
+			offspring.Mutate(mod.Mutator, pop.rng)
 			offsprings[i] = offspring
 			i++
 		}
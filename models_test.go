@@ -10,8 +10,8 @@ func TestGenerateOffsprings(t *testing.T) {
 	var (
 		N     = []int{0, 1, 3, 10}
 		indis = makeIndividuals(10, 2, rand.New(rand.NewSource(time.Now().UnixNano())))
-		sel   = SelTournament{3}
-		cross = CrossPoint{2}
+		sel   = SelTournament{NbParticipants: 3}
+		cross = CrossPoint{NbPoints: 2}
 		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
 	)
 	for _, n := range N {
@@ -22,6 +22,51 @@ func TestGenerateOffsprings(t *testing.T) {
 	}
 }
 
+func TestGenerateOffspringsInto(t *testing.T) {
+	var (
+		N     = []int{0, 1, 3, 10}
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indis = makeIndividuals(10, 2, rng)
+		sel   = SelTournament{NbParticipants: 3}
+		cross = CrossUniformF{}
+	)
+	for i := range indis {
+		InitUniformF{Lower: -1, Upper: 1}.Apply(&indis[i], rng)
+	}
+	for _, n := range N {
+		var offsprings = makeIndividuals(n, 2, rng)
+		generateOffspringsInto(offsprings, indis, sel, cross, rng)
+		for _, offspring := range offsprings {
+			if _, ok := offspring.Genome[0].(float64); !ok {
+				t.Error("GenerateOffspringsInto didn't fill every offspring")
+			}
+		}
+	}
+}
+
+func TestModGenerationalReuse(t *testing.T) {
+	var (
+		pop = makePopulation(10, 4, Float64Function{func(X []float64) float64 { return X[0] }}, InitUniformF{-1, 1})
+		mod = ModGenerational{
+			Selector:  SelTournament{NbParticipants: 3},
+			Crossover: CrossUniformF{},
+			Reuse:     true,
+		}
+		first = pop.Individuals
+	)
+	mod.Apply(&pop)
+	mod.Apply(&pop)
+	// Check the individuals of the first generation have been reused
+	if &pop.Individuals[0] != &first[0] {
+		t.Error("ModGenerational didn't reuse the previous generation")
+	}
+	for _, indi := range pop.Individuals {
+		if indi.Evaluated {
+			t.Error("A reused individual is still marked as evaluated")
+		}
+	}
+}
+
 func TestConstantSizeModels(t *testing.T) {
 	var (
 		// Testing framework for each model
@@ -40,36 +85,50 @@ func TestConstantSizeModels(t *testing.T) {
 		// Model configurations
 		models = []Model{
 			ModGenerational{
-				Selector:  SelTournament{3},
-				Crossover: CrossPoint{2},
+				Selector:  SelTournament{NbParticipants: 3},
+				Crossover: CrossPoint{NbPoints: 2},
 				Mutator:   MutNormalF{0.1, 1},
 				MutRate:   0.2,
 			},
+			ModGenerational{
+				Selector:  SelTournament{NbParticipants: 3},
+				Crossover: CrossUniformF{},
+				Mutator:   MutNormalF{0.1, 1},
+				MutRate:   0.2,
+				Reuse:     true,
+			},
+			ModGenerational{
+				Selector:  SelTournament{NbParticipants: 3},
+				Crossover: CrossArithmeticF{0.3},
+				Mutator:   MutNormalF{0.1, 1},
+				MutRate:   0.2,
+				Reuse:     true,
+			},
 			ModSteadyState{
-				Selector:  SelTournament{3},
-				Crossover: CrossPoint{2},
+				Selector:  SelTournament{NbParticipants: 3},
+				Crossover: CrossPoint{NbPoints: 2},
 				KeepBest:  false,
 				Mutator:   MutNormalF{0.1, 1},
 				MutRate:   0.2,
 			},
 			ModSteadyState{
-				Selector:  SelTournament{3},
-				Crossover: CrossPoint{2},
+				Selector:  SelTournament{NbParticipants: 3},
+				Crossover: CrossPoint{NbPoints: 2},
 				KeepBest:  true,
 				Mutator:   MutNormalF{0.1, 1},
 				MutRate:   0.2,
 			},
 			ModDownToSize{
 				NbrOffsprings: 5,
-				SelectorA:     SelTournament{3},
-				Crossover:     CrossPoint{2},
+				SelectorA:     SelTournament{NbParticipants: 3},
+				Crossover:     CrossPoint{NbPoints: 2},
 				SelectorB:     SelElitism{},
 				Mutator:       MutNormalF{0.1, 1},
 				MutRate:       0.2,
 			},
 			ModRing{
-				Crossover: CrossPoint{2},
-				Selector:  SelTournament{3},
+				Crossover: CrossPoint{NbPoints: 2},
+				Selector:  SelTournament{NbParticipants: 3},
 				Mutator:   MutNormalF{0.1, 1},
 				MutRate:   0.2,
 			},
@@ -79,16 +138,142 @@ func TestConstantSizeModels(t *testing.T) {
 				Tmin:    1,
 				Alpha:   0.3,
 			},
+			ModCellular{
+				Width:     3,
+				Selector:  SelTournament{NbParticipants: 3},
+				Crossover: CrossUniformF{},
+				Mutator:   MutNormalF{0.1, 1},
+				MutRate:   0.2,
+			},
+			ModCellular{
+				Width:       5,
+				Moore:       true,
+				Selector:    SelTournament{NbParticipants: 3},
+				Crossover:   CrossUniformF{},
+				Mutator:     MutNormalF{0.1, 1},
+				MutRate:     0.2,
+				Synchronous: true,
+			},
+			ModSexual{
+				SelectorA: SelLinearRanking{Pressure: 1.8},
+				SelectorB: SelTournament{NbParticipants: 1},
+				Crossover: CrossUniformF{},
+				Mutator:   MutNormalF{0.1, 1},
+				MutRate:   0.2,
+			},
 			ModMutationOnly{
 				NbrParents:    3,
-				Selector:      SelTournament{2},
+				Selector:      SelTournament{NbParticipants: 2},
 				KeepParents:   false,
 				NbrOffsprings: 2,
 				Mutator:       MutNormalF{0.1, 1},
 			},
+			ModMultiParent{
+				Selector:   SelElitism{},
+				NbrParents: 4,
+				Crossover:  CrossRankMuF{},
+				Mutator:    MutMirroredF{Mutator: MutGaussianF{0.5, 0.1}},
+				MutRate:    1,
+			},
+			ModMultiParent{
+				Selector:   SelTournament{NbParticipants: 2},
+				NbrParents: 3,
+				Crossover:  CrossProportionateF{},
+				Mutator:    MutNormalF{0.1, 1},
+				MutRate:    0.2,
+			},
+			ModCrowding{
+				Crossover: CrossUniformF{},
+				Mutator:   MutNormalF{0.1, 1},
+				MutRate:   0.2,
+				Metric:    DistEuclidean{},
+			},
+			ModClearing{
+				Model: ModGenerational{
+					Selector:  SelTournament{NbParticipants: 3},
+					Crossover: CrossPoint{NbPoints: 2},
+					Mutator:   MutNormalF{0.1, 1},
+					MutRate:   0.2,
+				},
+				Metric:   DistEuclidean{},
+				Radius:   0.5,
+				Capacity: 2,
+			},
+			ModSurrogate{
+				Surrogate:      SurKNN{K: 3, Metric: DistEuclidean{}},
+				NbrOffsprings:  10,
+				NbrEvaluations: 3,
+				Selector:       SelTournament{NbParticipants: 3},
+				Crossover:      CrossPoint{NbPoints: 2},
+				Mutator:        MutNormalF{0.1, 1},
+				MutRate:        0.2,
+			},
+			ModSpeciation{
+				Metric:    DistEuclidean{},
+				Threshold: 0.5,
+				Survival:  0.5,
+				Crossover: CrossUniformF{},
+				Mutator:   MutNormalF{0.1, 1},
+				MutRate:   0.2,
+			},
+			ModNSGA2{
+				Crossover: CrossUniformF{},
+				Mutator:   MutNormalF{0.1, 1},
+				MutRate:   0.2,
+			},
+			ModNSGA3{
+				Divisions: 4,
+				Crossover: CrossUniformF{},
+				Mutator:   MutNormalF{0.1, 1},
+				MutRate:   0.2,
+			},
+			ModMOEAD{
+				Divisions:       9,
+				NbrNeighbours:   3,
+				NbrReplacements: 2,
+				Crossover:       CrossUniformF{},
+				Mutator:         MutNormalF{0.1, 1},
+				MutRate:         0.2,
+			},
+			ModMemetic{
+				Model: ModGenerational{
+					Selector:  SelTournament{NbParticipants: 3},
+					Crossover: CrossPoint{NbPoints: 2},
+					Mutator:   MutNormalF{0.1, 1},
+					MutRate:   0.2,
+				},
+				LocalSearcher: LocalTwoOpt{MaxEvaluations: 5},
+				Rate:          0.2,
+			},
+			ModUMDA{
+				Elitism: 1,
+				MinStd:  0.01,
+			},
+			&ModPBIL{
+				NbrBest: 2,
+				MutProb: 0.1,
+				MinStd:  0.01,
+			},
+			&ModACO{
+				NbrBest: 2,
+				Elitism: 1,
+				Cycle:   true,
+			},
+			ModHarmony{
+				NbrImprovisations: 3,
+				Initializer:       initializer,
+			},
+			ModABC{
+				Initializer: initializer,
+			},
+			ModRegularized{
+				SampleSize:    5,
+				NbrOffsprings: 3,
+				Mutator:       MutNormalF{0.1, 1},
+			},
 			ModMutationOnly{
 				NbrParents:    3,
-				Selector:      SelTournament{2},
+				Selector:      SelTournament{NbParticipants: 2},
 				KeepParents:   true,
 				NbrOffsprings: 2,
 				Mutator:       MutNormalF{0.1, 1},
@@ -114,3 +299,64 @@ func TestConstantSizeModels(t *testing.T) {
 		}
 	}
 }
+
+func benchmarkModGenerational(b *testing.B, nbIndis, nbGenes int, cross Crossover, reuse bool) {
+	var (
+		pop = makePopulation(nbIndis, nbGenes, Float64Function{func(X []float64) float64 { return X[0] }}, InitUniformF{-1, 1})
+		mod = ModGenerational{
+			Selector:  SelTournament{NbParticipants: 3},
+			Crossover: cross,
+			Mutator:   MutNormalF{0.1, 1},
+			MutRate:   0.2,
+			Reuse:     reuse,
+		}
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mod.Apply(&pop)
+	}
+}
+
+func BenchmarkModGenerational(b *testing.B) {
+	benchmarkModGenerational(b, 1000, 100, CrossUniformF{}, false)
+}
+
+func BenchmarkModGenerationalReuse(b *testing.B) {
+	benchmarkModGenerational(b, 1000, 100, CrossUniformF{}, true)
+}
+
+// CrossArithmeticF doesn't implement CrossoverInto, the genomes are recycled
+// through the pool.
+func BenchmarkModGenerationalLarge(b *testing.B) {
+	benchmarkModGenerational(b, 10000, 10, CrossArithmeticF{0.3}, false)
+}
+
+func BenchmarkModGenerationalLargePool(b *testing.B) {
+	benchmarkModGenerational(b, 10000, 10, CrossArithmeticF{0.3}, true)
+}
+
+func TestModMultiParentRanks(t *testing.T) {
+	var (
+		pop = makePopulation(10, 2, Float64Function{func(X []float64) float64 { return X[0]*X[0] + X[1]*X[1] }}, InitUniformF{-1, 1})
+		mod = ModMultiParent{
+			Selector:   SelElitism{},
+			NbrParents: 10,
+			Crossover:  CrossRankMuF{Mu: 1},
+		}
+	)
+	pop.Individuals.Evaluate(pop.ff)
+	pop.Individuals.SortWith(pop.cmp)
+	var best = pop.Individuals[0]
+	// Reverse the population so that the parents are selected in the wrong
+	// order, with a Mu of 1 each offspring is a copy of the best parent
+	for i, j := 0, len(pop.Individuals)-1; i < j; i, j = i+1, j-1 {
+		pop.Individuals[i], pop.Individuals[j] = pop.Individuals[j], pop.Individuals[i]
+	}
+	mod.Apply(&pop)
+	for _, offspring := range pop.Individuals {
+		if offspring.Genome[0] != best.Genome[0] || offspring.Genome[1] != best.Genome[1] {
+			t.Error("Each offspring should be a copy of the best parent")
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package gago
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ModMOEAD implements the MOEA/D algorithm of Zhang and Li, which decomposes a
+// multi-objective problem into as many single-objective subproblems as there
+// are individuals. Each subproblem is defined by a weight vector, generated
+// with WeightVectors and Divisions, and by the Scalarizer which is applied to
+// the objectives translated by the best value found for each objective. The
+// Tchebycheff function is used if the Scalarizer is nil. At each generation
+// each subproblem breeds an offspring from two individuals of it's
+// neighbourhood, which is made of the NbrNeighbours subproblems whose weight
+// vectors are the closest. The offspring then replaces at most NbrReplacements
+// individuals of the neighbourhood that it improves upon, there is no limit if
+// NbrReplacements is 0.
+//
+// The GA sorts the populations between generations, hence the individuals are
+// reassigned to the subproblems at the beginning of each generation, each
+// subproblem taking the unassigned individual that suits it best. The number
+// of individuals should be equal to the number of weight vectors, if it isn't
+// then the weight vectors are shared cyclically.
+type ModMOEAD struct {
+	Divisions       int
+	NbrNeighbours   int
+	NbrReplacements int
+	Scalarizer      Scalarizer
+	Crossover       Crossover
+	Mutator         Mutator
+	MutRate         float64
+}
+
+// Apply MOEA/D to a population.
+func (mod ModMOEAD) Apply(pop *Population) {
+	var (
+		n          = len(pop.Individuals)
+		objs       = pop.Individuals.Objectives()
+		vectors    = WeightVectors(len(objs[0]), mod.Divisions)
+		weights    = make([][]float64, n)
+		ideal      = make([]float64, len(objs[0]))
+		scalarizer = mod.Scalarizer
+	)
+	if scalarizer == nil {
+		scalarizer = ScalTchebycheff{}
+	}
+	for i := range weights {
+		weights[i] = vectors[i%len(vectors)]
+	}
+	for m := range ideal {
+		ideal[m] = math.Inf(1)
+		for _, obj := range objs {
+			ideal[m] = math.Min(ideal[m], obj[m])
+		}
+	}
+	// Scalarize the objectives of an individual for a subproblem
+	var g = func(indi Individual, i int) float64 {
+		var (
+			o = objectives(indi)
+			t = make([]float64, len(o))
+		)
+		for m := range o {
+			t[m] = o[m] - ideal[m]
+		}
+		return scalarizer.Apply(t, weights[i])
+	}
+	// Assign an individual to each subproblem
+	var (
+		subproblems = make(Individuals, n)
+		assigned    = make([]bool, n)
+	)
+	for _, i := range pop.rng.Perm(n) {
+		var best = -1
+		for j, indi := range pop.Individuals {
+			if !assigned[j] && (best == -1 || g(indi, i) < g(pop.Individuals[best], i)) {
+				best = j
+			}
+		}
+		assigned[best] = true
+		subproblems[i] = pop.Individuals[best]
+	}
+	var neighbourhoods = neighbourhoods(weights, mod.NbrNeighbours)
+	for _, i := range pop.rng.Perm(n) {
+		var (
+			b        = neighbourhoods[i]
+			p1       = subproblems[b[pop.rng.Intn(len(b))]]
+			p2       = subproblems[b[pop.rng.Intn(len(b))]]
+			child, _ = mod.Crossover.Apply(p1, p2, pop.rng)
+			replaced = 0
+		)
+		if mod.Mutator != nil && pop.rng.Float64() < mod.MutRate {
+			child.Mutate(mod.Mutator, pop.rng)
+		}
+		child.Evaluate(pop.ff)
+		// Update the ideal point
+		for m, obj := range objectives(child) {
+			ideal[m] = math.Min(ideal[m], obj)
+		}
+		// Replace the neighbours the offspring improves upon, each replaced
+		// neighbour after the first one gets a copy of the offspring so that
+		// the subproblems don't share a genome
+		for _, k := range pop.rng.Perm(len(b)) {
+			var j = b[k]
+			if g(child, j) <= g(subproblems[j], j) {
+				if replaced == 0 {
+					subproblems[j] = child
+				} else {
+					subproblems[j] = child.clone(pop.rng)
+				}
+				replaced++
+				if replaced == mod.NbrReplacements {
+					break
+				}
+			}
+		}
+	}
+	pop.Individuals = subproblems
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModMOEAD) Validate() error {
+	// Check the number of divisions
+	if mod.Divisions < 1 {
+		return errors.New("'Divisions' should be higher or equal to 1")
+	}
+	// Check the number of neighbours
+	if mod.NbrNeighbours < 2 {
+		return errors.New("'NbrNeighbours' should be higher or equal to 2")
+	}
+	// Check the number of replacements
+	if mod.NbrReplacements < 0 {
+		return errors.New("'NbrReplacements' should be positive")
+	}
+	// Check the crossover method presence
+	if mod.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if mod.Mutator != nil && (mod.MutRate < 0 || mod.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	return nil
+}
+
+// Compute the indexes of the k closest weight vectors of each weight vector,
+// the closest one being the weight vector itself.
+func neighbourhoods(weights [][]float64, k int) [][]int {
+	if k > len(weights) {
+		k = len(weights)
+	}
+	var hoods = make([][]int, len(weights))
+	for i := range weights {
+		var (
+			order = make([]int, len(weights))
+			dists = make([]float64, len(weights))
+		)
+		for j := range weights {
+			order[j] = j
+			dists[j] = euclidean(weights[i], weights[j])
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return dists[order[a]] < dists[order[b]]
+		})
+		hoods[i] = order[:k]
+	}
+	return hoods
+}
@@ -0,0 +1,76 @@
+package gago
+
+import "testing"
+
+func TestNeighbourhoods(t *testing.T) {
+	var hoods = neighbourhoods(WeightVectors(2, 4), 3)
+	if len(hoods) != 5 {
+		t.Errorf("Expected 5 neighbourhoods, got %d", len(hoods))
+	}
+	for i, hood := range hoods {
+		if len(hood) != 3 || hood[0] != i {
+			t.Errorf("Neighbourhood %d should contain 3 weight vectors starting with itself, got %v", i, hood)
+		}
+	}
+	if len(neighbourhoods(WeightVectors(2, 1), 3)[0]) != 2 {
+		t.Error("A neighbourhood can't contain more weight vectors than there are")
+	}
+}
+
+func TestMOEAD(t *testing.T) {
+	testNSGA(t, ModMOEAD{
+		Divisions:       39,
+		NbrNeighbours:   5,
+		NbrReplacements: 2,
+		Crossover:       CrossUniformF{},
+		Mutator:         MutNormalF{Rate: 0.5, Std: 0.1},
+		MutRate:         0.5,
+	}, 2)
+}
+
+func TestMOEADValidate(t *testing.T) {
+	var testCases = []ModMOEAD{
+		{Divisions: 0, NbrNeighbours: 2, Crossover: CrossUniformF{}},
+		{Divisions: 2, NbrNeighbours: 1, Crossover: CrossUniformF{}},
+		{Divisions: 2, NbrNeighbours: 2, NbrReplacements: -1, Crossover: CrossUniformF{}},
+		{Divisions: 2, NbrNeighbours: 2},
+	}
+	for _, mod := range testCases {
+		if mod.Validate() == nil {
+			t.Errorf("%+v shouldn't be valid", mod)
+		}
+	}
+}
+
+func TestMOEADCopies(t *testing.T) {
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 20,
+		NbrGenes:       2,
+		Ff: ObjectivesFunction{
+			Image: func(genome Genome) []float64 {
+				return []float64{genome[0].(float64), genome[1].(float64)}
+			},
+		},
+		Initializer: InitUniformF{Lower: 0, Upper: 1},
+		Model: ModMOEAD{
+			Divisions:       19,
+			NbrNeighbours:   10,
+			NbrReplacements: 10,
+			Crossover:       CrossUniformF{},
+		},
+	}
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	// The subproblems replaced by the same offspring shouldn't share it's
+	// genome
+	var genomes = make(map[*interface{}]bool)
+	for _, indi := range ga.Populations[0].Individuals {
+		if genomes[&indi.Genome[0]] {
+			t.Fatal("Two individuals share the same genome")
+		}
+		genomes[&indi.Genome[0]] = true
+	}
+}
@@ -1,12 +1,24 @@
 package gago
 
-import "math/rand"
+import (
+	"fmt"
+	"math/rand"
+)
 
 // Mutator modifies an individual by replacing it's genes with new values.
 type Mutator interface {
 	Apply(indi *Individual, rng *rand.Rand)
 }
 
+// PairMutator is a Mutator that mutates two sibling offsprings at once, which
+// allows correlating their mutations. The models that produce offsprings by
+// pairs call ApplyPair on each pair instead of calling Apply on each
+// offspring, see MutMirroredF.
+type PairMutator interface {
+	Mutator
+	ApplyPair(indi1 *Individual, indi2 *Individual, rng *rand.Rand)
+}
+
 // MutNormalF modifies a float gene if a coin toss is under a defined mutation
 // ate. It does so for each gene. The new gene value is a random value sampled
 // from a normal distribution centered on the gene's current value and with the
@@ -28,12 +40,132 @@ func (mut MutNormalF) Apply(indi *Individual, rng *rand.Rand) {
 	}
 }
 
+// MutGaussianF adds a random value sampled from a normal distribution centered
+// on 0 and with standard deviation Std to each gene with probability Rate.
+// Contrary to MutNormalF the size of the perturbation doesn't depend on the
+// gene's value. Only works for floating point values.
+type MutGaussianF struct {
+	Rate float64 // Mutation rate for each gene
+	Std  float64 // Standard deviation
+}
+
+// Apply gaussian mutation.
+func (mut MutGaussianF) Apply(indi *Individual, rng *rand.Rand) {
+	for i := range indi.Genome {
+		if rng.Float64() < mut.Rate {
+			indi.Genome[i] = indi.Genome[i].(float64) + rng.NormFloat64()*mut.Std
+		}
+	}
+}
+
+// MutGaussianGenesF is a gaussian mutation whose rate and standard deviation
+// are given per gene, which allows mutating dimensions of very different
+// scales sensibly: the i-th gene is mutated with probability Rates[i] by adding
+// a value sampled from a normal distribution centered on 0 and with standard
+// deviation Stds[i]. Rates and Stds should either contain a value per gene or a
+// single value that applies to every gene, the GA checks their length against
+// NbrGenes when it's validated. Only works for floating point values.
+type MutGaussianGenesF struct {
+	Rates []float64
+	Stds  []float64
+}
+
+// Apply per gene gaussian mutation.
+func (mut MutGaussianGenesF) Apply(indi *Individual, rng *rand.Rand) {
+	for i := range indi.Genome {
+		if rng.Float64() < perGene(mut.Rates, i) {
+			indi.Genome[i] = indi.Genome[i].(float64) + rng.NormFloat64()*perGene(mut.Stds, i)
+		}
+	}
+}
+
+// MutMirroredF implements mirrored sampling, also known as antithetic
+// sampling, for additive mutations of floating point genes such as MutGaussianF
+// or MutGaussianGenesF: the first of two sibling offsprings is mutated with
+// Mutator and the opposite of the perturbation it received is added to the
+// second one. Each pair of perturbations is centered on 0, which reduces the
+// sampling noise of the mutations at no extra cost; it's a common variance
+// reduction technique in evolution strategies. Individuals that are mutated
+// on their own, for example because the population size is odd, are mutated
+// with Mutator. Only works for floating point values.
+type MutMirroredF struct {
+	Mutator Mutator
+}
+
+// Apply the mutator to an individual.
+func (mut MutMirroredF) Apply(indi *Individual, rng *rand.Rand) {
+	mut.Mutator.Apply(indi, rng)
+}
+
+// ApplyPair mutates the first individual with the mutator and applies the
+// opposite perturbation to the second individual.
+func (mut MutMirroredF) ApplyPair(indi1 *Individual, indi2 *Individual, rng *rand.Rand) {
+	var before = make([]float64, len(indi1.Genome))
+	for i, gene := range indi1.Genome {
+		before[i] = gene.(float64)
+	}
+	mut.Mutator.Apply(indi1, rng)
+	for i := range indi2.Genome {
+		if i < len(before) {
+			indi2.Genome[i] = indi2.Genome[i].(float64) - (indi1.Genome[i].(float64) - before[i])
+		}
+	}
+}
+
+// Check the number of rates and standard deviations.
+func (mut MutGaussianGenesF) checkGenes(nbGenes int) error {
+	if err := checkPerGene("Rates", mut.Rates, nbGenes); err != nil {
+		return err
+	}
+	return checkPerGene("Stds", mut.Stds, nbGenes)
+}
+
+// MutFlipB flips each bit of a binary genome with probability Rate. Only works
+// for boolean values.
+type MutFlipB struct {
+	Rate float64 // Mutation rate for each bit
+}
+
+// Apply bit flip mutation.
+func (mut MutFlipB) Apply(indi *Individual, rng *rand.Rand) {
+	for i := range indi.Genome {
+		if rng.Float64() < mut.Rate {
+			indi.Genome[i] = !indi.Genome[i].(bool)
+		}
+	}
+}
+
+// MutFlipGenesB flips the i-th bit of a binary genome with probability
+// Rates[i]. Rates should either contain a value per gene or a single value
+// that applies to every gene, the GA checks it's length against NbrGenes when
+// it's validated. Only works for boolean values.
+type MutFlipGenesB struct {
+	Rates []float64
+}
+
+// Apply per gene bit flip mutation.
+func (mut MutFlipGenesB) Apply(indi *Individual, rng *rand.Rand) {
+	for i := range indi.Genome {
+		if rng.Float64() < perGene(mut.Rates, i) {
+			indi.Genome[i] = !indi.Genome[i].(bool)
+		}
+	}
+}
+
+// Check the number of rates.
+func (mut MutFlipGenesB) checkGenes(nbGenes int) error {
+	return checkPerGene("Rates", mut.Rates, nbGenes)
+}
+
 // MutSplice splices a genome in 3 and glues the parts back together in another
-// order.
+// order. Genomes of less than 2 genes are left untouched.
 type MutSplice struct{}
 
 // Apply splice mutation.
 func (mut MutSplice) Apply(indi *Individual, rng *rand.Rand) {
+	if len(indi.Genome) < 2 {
+		return
+	}
 	// Choose where to start and end the splice
 	var (
 		end   = rng.Intn(len(indi.Genome)-1) + 1
@@ -55,7 +187,8 @@ func (mut MutSplice) Apply(indi *Individual, rng *rand.Rand) {
 	)
 }
 
-// MutPermute permutes two genes.
+// MutPermute permutes two genes. A Max lower than 1 is treated as 1 and
+// genomes of less than 2 genes are left untouched.
 type MutPermute struct {
 	// Maximum number of permutation
 	Max int
@@ -63,7 +196,10 @@ type MutPermute struct {
 
 // Apply permutation mutation.
 func (mut MutPermute) Apply(indi *Individual, rng *rand.Rand) {
-	for i := 0; i <= rng.Intn(mut.Max); i++ {
+	if len(indi.Genome) < 2 {
+		return
+	}
+	for i := 0; i <= rng.Intn(max(mut.Max, 1)); i++ {
 		// Choose two points on the genome
 		var (
 			points, _ = randomInts(2, 0, len(indi.Genome), rng)
@@ -75,13 +211,17 @@ func (mut MutPermute) Apply(indi *Individual, rng *rand.Rand) {
 	}
 }
 
-// MutUniformS permutes two genes.
+// MutUniformS replaces a random gene with an element of the corpus. Empty
+// genomes are left untouched, as are all genomes if the corpus is empty.
 type MutUniformS struct {
 	Corpus []string // Corpus to replace genes with
 }
 
-// Apply permutation mutation.
+// Apply uniform string mutation.
 func (mut MutUniformS) Apply(indi *Individual, rng *rand.Rand) {
+	if len(indi.Genome) == 0 || len(mut.Corpus) == 0 {
+		return
+	}
 	// Choose a random element from the corpus
 	var element = mut.Corpus[rng.Intn(len(mut.Corpus))]
 	// Choose a position on the individual's genome
@@ -89,3 +229,168 @@ func (mut MutUniformS) Apply(indi *Individual, rng *rand.Rand) {
 	// Replace the gene at the chosen position with the chosen element
 	indi.Genome[p] = element
 }
+
+// MutProb applies a mutator with probability Prob, otherwise the individual is
+// left untouched.
+type MutProb struct {
+	Mutator Mutator
+	Prob    float64
+}
+
+// Apply probabilistic mutation.
+func (mut MutProb) Apply(indi *Individual, rng *rand.Rand) {
+	if rng.Float64() < mut.Prob {
+		mut.Mutator.Apply(indi, rng)
+	}
+}
+
+// MutPipeline tries several mutators in sequence, each one with it's own
+// probability.
+type MutPipeline []MutProb
+
+// Apply each mutator of the pipeline.
+func (pipe MutPipeline) Apply(indi *Individual, rng *rand.Rand) {
+	for _, mut := range pipe {
+		mut.Apply(indi, rng)
+	}
+}
+
+// MutSequence applies several mutators in sequence. It's the same as a
+// MutPipeline where every probability is 1.
+type MutSequence []Mutator
+
+// Apply each mutator of the sequence.
+func (seq MutSequence) Apply(indi *Individual, rng *rand.Rand) {
+	for _, mut := range seq {
+		mut.Apply(indi, rng)
+	}
+}
+
+// MutChoice applies one of several mutators, each time picked at random with a
+// probability proportional to it's weight. If Weights is nil the mutators are
+// picked uniformly, otherwise it should contain a non-negative weight for each
+// mutator and the weights shouldn't all be 0. The mutators can themselves be
+// combinations, for example a MutSequence or a MutProb.
+type MutChoice struct {
+	Mutators []Mutator
+	Weights  []float64
+}
+
+// Apply one of the mutators.
+func (choice MutChoice) Apply(indi *Individual, rng *rand.Rand) {
+	choice.Mutators[pickWeighted(len(choice.Mutators), choice.Weights, rng)].Apply(indi, rng)
+}
+
+// Validate the mutators and the weights of a MutChoice.
+func (choice MutChoice) Validate() error {
+	if err := checkWeights("Mutators", len(choice.Mutators), choice.Weights); err != nil {
+		return err
+	}
+	for _, mut := range choice.Mutators {
+		if v, ok := mut.(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// A geneChecker is a mutator whose parameters depend on the number of genes.
+type geneChecker interface {
+	checkGenes(nbGenes int) error
+}
+
+// Return the value of a per gene parameter for the i-th gene, a single value
+// applies to every gene and the genes without a value get 0.
+func perGene(values []float64, i int) float64 {
+	switch {
+	case len(values) == 1:
+		return values[0]
+	case i < len(values):
+		return values[i]
+	}
+	return 0
+}
+
+// Check a per gene parameter has either a single value or a value per gene.
+func checkPerGene(name string, values []float64, nbGenes int) error {
+	if len(values) != 1 && len(values) != nbGenes {
+		return fmt.Errorf("'%s' should contain 1 value or %d values, one per gene, got %d", name, nbGenes, len(values))
+	}
+	return nil
+}
+
+// Check the per gene parameters of a mutator and of the mutators it combines.
+func checkMutatorGenes(mut Mutator, nbGenes int) error {
+	switch m := mut.(type) {
+	case geneChecker:
+		return m.checkGenes(nbGenes)
+	case MutProb:
+		return checkMutatorGenes(m.Mutator, nbGenes)
+	case MutRepair:
+		return checkMutatorGenes(m.Mutator, nbGenes)
+	case MutLimit:
+		return checkMutatorGenes(m.Mutator, nbGenes)
+	case MutPipeline:
+		for _, p := range m {
+			if err := checkMutatorGenes(p.Mutator, nbGenes); err != nil {
+				return err
+			}
+		}
+	case MutSequence:
+		for _, s := range m {
+			if err := checkMutatorGenes(s, nbGenes); err != nil {
+				return err
+			}
+		}
+	case MutChoice:
+		for _, c := range m.Mutators {
+			if err := checkMutatorGenes(c, nbGenes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Check the per gene parameters of the mutators of a model.
+func checkModelGenes(model Model, nbGenes int) error {
+	var err error
+	wrapModel(model, wrappers{
+		mutator: func(mut Mutator) Mutator {
+			if err == nil {
+				err = checkMutatorGenes(mut, nbGenes)
+			}
+			return mut
+		},
+	})
+	return err
+}
+
+// Check the operators of a model that can validate their parameters.
+func checkModelOperators(model Model) error {
+	var (
+		err   error
+		check = func(op interface{}) {
+			if v, ok := op.(interface{ Validate() error }); ok && err == nil {
+				err = v.Validate()
+			}
+		}
+	)
+	wrapModel(model, wrappers{
+		selector: func(sel Selector) Selector {
+			check(sel)
+			return sel
+		},
+		crossover: func(cross Crossover) Crossover {
+			check(cross)
+			return cross
+		},
+		mutator: func(mut Mutator) Mutator {
+			check(mut)
+			return mut
+		},
+	})
+	return err
+}
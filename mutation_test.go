@@ -1,7 +1,9 @@
 package gago
 
 import (
+	"math"
 	"math/rand"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -11,6 +13,29 @@ var mutators = []Mutator{
 		Rate: 1,
 		Std:  1,
 	},
+	MutGaussianF{
+		Rate: 1,
+		Std:  1,
+	},
+	MutMirroredF{
+		Mutator: MutGaussianF{Rate: 1, Std: 1},
+	},
+	MutProb{
+		Mutator: MutNormalF{Rate: 1, Std: 1},
+		Prob:    1,
+	},
+	MutPipeline{
+		{Mutator: MutNormalF{Rate: 1, Std: 1}, Prob: 1},
+		{Mutator: MutPermute{Max: 3}, Prob: 0.5},
+	},
+	MutSequence{
+		MutPermute{Max: 3},
+		MutNormalF{Rate: 1, Std: 1},
+	},
+	MutChoice{
+		Mutators: []Mutator{MutNormalF{Rate: 1, Std: 1}, MutPermute{Max: 3}},
+		Weights:  []float64{1, 1},
+	},
 	MutSplice{},
 	MutPermute{
 		Max: 3,
@@ -50,3 +75,129 @@ func TestPotentMutators(t *testing.T) {
 		}
 	}
 }
+
+func TestMutFlipB(t *testing.T) {
+	var (
+		src  = rand.NewSource(time.Now().UnixNano())
+		rng  = rand.New(src)
+		indi = makeIndividual(8, rng)
+		init = InitUniformB{}
+		mut  = MutFlipB{Rate: 1}
+	)
+	init.Apply(&indi, rng)
+	var genome = make(Genome, len(indi.Genome))
+	copy(genome, indi.Genome)
+	mut.Apply(&indi, rng)
+	// Every bit should have been flipped
+	for i := range genome {
+		if genome[i] == indi.Genome[i] {
+			t.Error("MutFlipB didn't flip every bit")
+		}
+	}
+}
+
+func TestMutGaussianGenesF(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indi = Individual{Genome: Genome{0.0, 0.0, 0.0}}
+		mut  = MutGaussianGenesF{Rates: []float64{1, 0, 1}, Stds: []float64{1e-3, 1, 1e3}}
+	)
+	mut.Apply(&indi, rng)
+	if x := indi.Genome[0].(float64); x == 0 || math.Abs(x) > 1 {
+		t.Errorf("The first gene should have moved a little, got %f", x)
+	}
+	if indi.Genome[1] != 0.0 {
+		t.Error("The second gene shouldn't have been mutated")
+	}
+	if indi.Genome[2] == 0.0 {
+		t.Error("The third gene should have been mutated")
+	}
+	// A single value applies to every gene
+	MutGaussianGenesF{Rates: []float64{1}, Stds: []float64{1}}.Apply(&indi, rng)
+	if indi.Genome[1] == 0.0 {
+		t.Error("The second gene should have been mutated")
+	}
+}
+
+func TestMutMirroredF(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		mut   = MutMirroredF{Mutator: MutGaussianF{Rate: 1, Std: 1}}
+		indi1 = Individual{Genome: Genome{1.0, 2.0}}
+		indi2 = Individual{Genome: Genome{-1.0, 0.0}}
+	)
+	mut.ApplyPair(&indi1, &indi2, rng)
+	for i, expected := range []float64{0, 2} {
+		if indi1.Genome[i] == []float64{1, 2}[i] {
+			t.Error("The first individual should have been mutated")
+		}
+		// The perturbations cancel each other out
+		if sum := indi1.Genome[i].(float64) + indi2.Genome[i].(float64); math.Abs(sum-expected) > 1e-12 {
+			t.Errorf("Expected the genes to sum to %f, got %f", expected, sum)
+		}
+	}
+	// The individuals of a slice are mutated by pairs, the last one on it's own
+	var indis = Individuals{
+		{Genome: Genome{0.0}, Evaluated: true},
+		{Genome: Genome{0.0}, Evaluated: true},
+		{Genome: Genome{0.0}, Evaluated: true},
+		{Genome: Genome{0.0}, Evaluated: true},
+		{Genome: Genome{0.0}, Evaluated: true},
+	}
+	indis.Mutate(mut, 1, rng)
+	for i := 0; i < 4; i += 2 {
+		if indis[i].Genome[0].(float64) != -indis[i+1].Genome[0].(float64) {
+			t.Errorf("Individuals %d and %d should have opposite perturbations", i, i+1)
+		}
+	}
+	for _, indi := range indis {
+		if indi.Evaluated || indi.Genome[0] == 0.0 {
+			t.Error("Every individual should have been mutated")
+		}
+	}
+}
+
+func TestMutFlipGenesB(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indi = Individual{Genome: Genome{false, false, false}}
+	)
+	MutFlipGenesB{Rates: []float64{1, 0, 1}}.Apply(&indi, rng)
+	if indi.Genome[0] != true || indi.Genome[1] != false || indi.Genome[2] != true {
+		t.Errorf("Unexpected genome %v", indi.Genome)
+	}
+}
+
+func TestCheckMutatorGenes(t *testing.T) {
+	var testCases = []struct {
+		mut   Mutator
+		valid bool
+	}{
+		{MutGaussianGenesF{Rates: []float64{0.1}, Stds: []float64{1, 2, 3}}, true},
+		{MutGaussianGenesF{Rates: []float64{0.1, 0.2}, Stds: []float64{1}}, false},
+		{MutGaussianGenesF{Rates: []float64{0.1}}, false},
+		{MutFlipGenesB{Rates: []float64{0.1, 0.2, 0.3}}, true},
+		{MutFlipGenesB{}, false},
+		{MutSequence{MutNormalF{0.1, 1}, MutFlipGenesB{Rates: []float64{1, 1}}}, false},
+		{MutProb{Mutator: MutFlipGenesB{Rates: []float64{1, 1}}, Prob: 1}, false},
+		{MutNormalF{0.1, 1}, true},
+	}
+	for _, test := range testCases {
+		if err := checkMutatorGenes(test.mut, 3); (err == nil) != test.valid {
+			t.Errorf("Expected %v to be valid: %v, got %v", test.mut, test.valid, err)
+		}
+	}
+}
+
+func BenchmarkMutators(b *testing.B) {
+	var rng = rand.New(rand.NewSource(42))
+	for _, mut := range mutators {
+		var indi = makeIndividual(100, rng)
+		InitUniformF{-5.0, 5.0}.Apply(&indi, rng)
+		b.Run(reflect.TypeOf(mut).Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				mut.Apply(&indi, rng)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package neat
+
+import (
+	"math"
+
+	"github.com/MaxHalford/gago"
+)
+
+// Distance is the compatibility distance used by NEAT to split the population
+// into species. It is computed as c1*E/N + c2*D/N + c3*W where E is the number
+// of excess connections, D is the number of disjoint connections, W is the
+// mean weight difference of the matching connections and N is the number of
+// connections of the larger network (or 1 if it has less than 20 connections).
+// It implements the gago.DistanceMetric interface.
+type Distance struct {
+	Excess, Disjoint, Weight float64
+}
+
+// Apply the compatibility distance.
+func (dist Distance) Apply(a, b gago.Individual) float64 {
+	var (
+		ca, cb   = Decode(a).Connections, Decode(b).Connections
+		i, j     int
+		disjoint float64
+		matching float64
+		diff     float64
+	)
+	// The connections are sorted by innovation number, hence they can be
+	// aligned by traversing both networks at the same time
+	for i < len(ca) && j < len(cb) {
+		switch {
+		case ca[i].Innovation == cb[j].Innovation:
+			matching++
+			diff += math.Abs(ca[i].Weight - cb[j].Weight)
+			i++
+			j++
+		case ca[i].Innovation < cb[j].Innovation:
+			disjoint++
+			i++
+		default:
+			disjoint++
+			j++
+		}
+	}
+	var (
+		excess = float64(len(ca) - i + len(cb) - j)
+		n      = math.Max(float64(len(ca)), float64(len(cb)))
+	)
+	if n < 20 {
+		n = 1
+	}
+	var distance = (dist.Excess*excess + dist.Disjoint*disjoint) / n
+	if matching > 0 {
+		distance += dist.Weight * diff / matching
+	}
+	return distance
+}
@@ -0,0 +1,54 @@
+package neat
+
+import "sync"
+
+// Innovations keeps track of the structural mutations that occurred during a
+// run so that identical mutations in different networks are given the same
+// innovation numbers and node IDs. A single Innovations has to be shared by
+// every operator of a run, it is safe for concurrent use.
+type Innovations struct {
+	mutex       sync.Mutex
+	connections map[[2]int]int
+	splits      map[int]int
+	innovation  int
+	node        int
+}
+
+// Lazily initialize the maps.
+func (inno *Innovations) init() {
+	if inno.connections == nil {
+		inno.connections = make(map[[2]int]int)
+		inno.splits = make(map[int]int)
+	}
+}
+
+// Return the innovation number of a connection between two nodes.
+func (inno *Innovations) connection(in, out int) int {
+	inno.mutex.Lock()
+	defer inno.mutex.Unlock()
+	inno.init()
+	var key = [2]int{in, out}
+	if innovation, ok := inno.connections[key]; ok {
+		return innovation
+	}
+	inno.connections[key] = inno.innovation
+	inno.innovation++
+	return inno.connections[key]
+}
+
+// Return the ID of the node created by splitting a connection, next is the ID
+// following the highest node ID of the network being mutated.
+func (inno *Innovations) split(innovation, next int) int {
+	inno.mutex.Lock()
+	defer inno.mutex.Unlock()
+	inno.init()
+	if id, ok := inno.splits[innovation]; ok {
+		return id
+	}
+	if next > inno.node {
+		inno.node = next
+	}
+	inno.splits[innovation] = inno.node
+	inno.node++
+	return inno.splits[innovation]
+}
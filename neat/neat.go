@@ -0,0 +1,53 @@
+package neat
+
+import "github.com/MaxHalford/gago"
+
+// A Problem contains what is needed to evolve networks with NEAT. Evaluate
+// returns the fitness of a network, which has to be minimized.
+type Problem struct {
+	NbInputs, NbOutputs int
+	Evaluate            func(net *Network) float64
+}
+
+// Fitness returns the fitness function that decodes and evaluates a network.
+func (problem Problem) Fitness() gago.GenomeFunction {
+	return gago.GenomeFunction{
+		Image: func(genome gago.Genome) float64 {
+			return problem.Evaluate(genome[0].(*Network))
+		},
+	}
+}
+
+// GA returns a GA configuration for evolving networks with NEAT. The
+// parameters are close to the ones of the original paper, as with the presets
+// the configuration is a starting point that can be tuned. Every operator
+// shares the same Innovations.
+func (problem Problem) GA() gago.GA {
+	var innovations = &Innovations{}
+	return gago.GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 150,
+		NbrGenes:       1,
+		Ff:             problem.Fitness(),
+		Initializer: Init{
+			NbInputs:    problem.NbInputs,
+			NbOutputs:   problem.NbOutputs,
+			Innovations: innovations,
+		},
+		Model: gago.ModSpeciation{
+			Metric:    Distance{Excess: 1, Disjoint: 1, Weight: 0.4},
+			Threshold: 3,
+			Survival:  0.2,
+			Crossover: gago.CrossProb{
+				Crossover: Cross{},
+				Prob:      0.75,
+			},
+			Mutator: gago.MutPipeline{
+				{Mutator: MutWeights{Rate: 0.9, Std: 0.5}, Prob: 0.8},
+				{Mutator: MutAddConnection{Innovations: innovations}, Prob: 0.05},
+				{Mutator: MutAddNode{Innovations: innovations}, Prob: 0.03},
+			},
+			MutRate: 1,
+		},
+	}
+}
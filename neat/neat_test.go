@@ -0,0 +1,36 @@
+package neat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGA(t *testing.T) {
+	var (
+		X       = [][]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+		Y       = []float64{0, 1, 1, 0}
+		problem = Problem{
+			NbInputs:  2,
+			NbOutputs: 1,
+			Evaluate: func(net *Network) float64 {
+				var err float64
+				for i, x := range X {
+					err += math.Pow(net.Predict(x)[0]-Y[i], 2)
+				}
+				return err
+			},
+		}
+		ga = problem.GA()
+	)
+	ga.Initialize()
+	var initial = ga.Best().Fitness
+	for i := 0; i < 10; i++ {
+		ga.Enhance()
+		if len(ga.Populations[0].Individuals) != ga.NbrIndividuals {
+			t.Error("Speciation changed the size of the population")
+		}
+	}
+	if ga.Best().Fitness > initial {
+		t.Error("The best network got worse")
+	}
+}
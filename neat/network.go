@@ -0,0 +1,139 @@
+package neat
+
+import "math"
+
+// A NodeKind indicates the role of a node in a network.
+type NodeKind int
+
+// The different kinds of nodes.
+const (
+	Input NodeKind = iota
+	Bias
+	Hidden
+	Output
+)
+
+// A NodeGene is a neuron of a network.
+type NodeGene struct {
+	ID   int
+	Kind NodeKind
+}
+
+// A ConnectionGene is a weighted link between two nodes. The innovation number
+// identifies the structural mutation that created the connection, two
+// connections with the same innovation number have the same historical origin
+// and can be matched during crossover.
+type ConnectionGene struct {
+	In, Out    int
+	Weight     float64
+	Enabled    bool
+	Innovation int
+}
+
+// A Network is a graph genome. The connections are sorted by innovation number
+// and never form a cycle, hence the network is feedforward.
+type Network struct {
+	Nodes       []NodeGene
+	Connections []ConnectionGene
+}
+
+// Copy returns a deep copy of the network.
+func (net *Network) Copy() *Network {
+	var clone = &Network{
+		Nodes:       make([]NodeGene, len(net.Nodes)),
+		Connections: make([]ConnectionGene, len(net.Connections)),
+	}
+	copy(clone.Nodes, net.Nodes)
+	copy(clone.Connections, net.Connections)
+	return clone
+}
+
+// Return the kind of the node with the given ID.
+func (net *Network) kind(id int) NodeKind {
+	for _, node := range net.Nodes {
+		if node.ID == id {
+			return node.Kind
+		}
+	}
+	return Hidden
+}
+
+// Check if there is a connection, enabled or not, between two nodes.
+func (net *Network) connected(in, out int) bool {
+	for _, conn := range net.Connections {
+		if conn.In == in && conn.Out == out {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if there is a path from a node to another node.
+func (net *Network) reaches(from, to int) bool {
+	if from == to {
+		return true
+	}
+	for _, conn := range net.Connections {
+		if conn.In == from && net.reaches(conn.Out, to) {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the ID that follows the highest node ID.
+func (net *Network) nextID() int {
+	var next = 0
+	for _, node := range net.Nodes {
+		if node.ID >= next {
+			next = node.ID + 1
+		}
+	}
+	return next
+}
+
+// Steepened sigmoid used in the original NEAT paper.
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-4.9*x))
+}
+
+// Predict feeds an input forward through the network and returns the value of
+// each output node in the order they appear in the network. The input nodes
+// are assigned the input values in the order they appear in the network and
+// the bias nodes always output 1.
+func (net *Network) Predict(x []float64) []float64 {
+	var (
+		values = make(map[int]float64)
+		inputs = 0
+	)
+	for _, node := range net.Nodes {
+		switch node.Kind {
+		case Input:
+			values[node.ID] = x[inputs]
+			inputs++
+		case Bias:
+			values[node.ID] = 1
+		}
+	}
+	var value func(id int) float64
+	value = func(id int) float64 {
+		if v, ok := values[id]; ok {
+			return v
+		}
+		var sum float64
+		for _, conn := range net.Connections {
+			if conn.Enabled && conn.Out == id {
+				sum += conn.Weight * value(conn.In)
+			}
+		}
+		values[id] = sigmoid(sum)
+		return values[id]
+	}
+	var outputs []float64
+	for _, node := range net.Nodes {
+		if node.Kind == Output {
+			outputs = append(outputs, value(node.ID))
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,43 @@
+package neat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPredict(t *testing.T) {
+	// One input, a bias and an output connected through a hidden node
+	var net = &Network{
+		Nodes: []NodeGene{{0, Input}, {1, Bias}, {2, Output}, {3, Hidden}},
+		Connections: []ConnectionGene{
+			{In: 0, Out: 2, Weight: 1, Enabled: false, Innovation: 0},
+			{In: 1, Out: 2, Weight: -1, Enabled: true, Innovation: 1},
+			{In: 0, Out: 3, Weight: 2, Enabled: true, Innovation: 2},
+			{In: 3, Out: 2, Weight: 1, Enabled: true, Innovation: 3},
+		},
+	}
+	var (
+		hidden = sigmoid(2 * 0.5)
+		output = sigmoid(hidden - 1)
+		y      = net.Predict([]float64{0.5})
+	)
+	if len(y) != 1 || math.Abs(y[0]-output) > 1e-12 {
+		t.Error("Predict didn't feed the input forward correctly")
+	}
+}
+
+func TestReaches(t *testing.T) {
+	var net = &Network{
+		Nodes: []NodeGene{{0, Input}, {1, Hidden}, {2, Output}},
+		Connections: []ConnectionGene{
+			{In: 0, Out: 1, Enabled: true},
+			{In: 1, Out: 2, Enabled: true},
+		},
+	}
+	if !net.reaches(0, 2) {
+		t.Error("The input should reach the output")
+	}
+	if net.reaches(2, 0) {
+		t.Error("The output shouldn't reach the input")
+	}
+}
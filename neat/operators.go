@@ -0,0 +1,194 @@
+package neat
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/MaxHalford/gago"
+)
+
+// Each individual's genome contains a single gene which is a network.
+
+// Decode returns the network contained in an individual's genome.
+func Decode(indi gago.Individual) *Network {
+	return indi.Genome[0].(*Network)
+}
+
+// Create an unevaluated individual containing a network.
+func makeIndividual(net *Network) gago.Individual {
+	return gago.Individual{
+		Genome:  gago.Genome{net},
+		Fitness: math.Inf(1),
+	}
+}
+
+// Init generates minimal networks where every input node and a bias node are
+// directly connected to every output node with random weights drawn from a
+// standard normal distribution. The nodes are numbered in the following order:
+// inputs, bias and then outputs.
+type Init struct {
+	NbInputs, NbOutputs int
+	Innovations         *Innovations
+}
+
+// Apply the Init initializer.
+func (init Init) Apply(indi *gago.Individual, rng *rand.Rand) {
+	var net = &Network{}
+	for i := 0; i < init.NbInputs; i++ {
+		net.Nodes = append(net.Nodes, NodeGene{ID: i, Kind: Input})
+	}
+	net.Nodes = append(net.Nodes, NodeGene{ID: init.NbInputs, Kind: Bias})
+	for i := 0; i < init.NbOutputs; i++ {
+		var out = init.NbInputs + 1 + i
+		net.Nodes = append(net.Nodes, NodeGene{ID: out, Kind: Output})
+		for in := 0; in <= init.NbInputs; in++ {
+			net.Connections = append(net.Connections, ConnectionGene{
+				In:         in,
+				Out:        out,
+				Weight:     rng.NormFloat64(),
+				Enabled:    true,
+				Innovation: init.Innovations.connection(in, out),
+			})
+		}
+	}
+	sortConnections(net.Connections)
+	indi.Genome[0] = net
+}
+
+// Sort connections by innovation number.
+func sortConnections(conns []ConnectionGene) {
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].Innovation < conns[j].Innovation
+	})
+}
+
+// Cross aligns the connections of both parents with their innovation numbers.
+// Matching connections are inherited randomly from either parent whereas
+// disjoint and excess connections are inherited from the fitter parent, which
+// also provides the nodes. Because every connection of an offspring belongs to
+// the fitter parent the offspring is guaranteed to be feedforward.
+type Cross struct{}
+
+// Apply matching-gene crossover.
+func (cross Cross) Apply(p1 gago.Individual, p2 gago.Individual, rng *rand.Rand) (gago.Individual, gago.Individual) {
+	// Make p1 the fitter parent, an unevaluated parent is considered less fit
+	if !p1.Evaluated || (p2.Evaluated && p2.Fitness < p1.Fitness) {
+		p1, p2 = p2, p1
+	}
+	var (
+		fitter = Decode(p1)
+		other  = make(map[int]ConnectionGene)
+	)
+	for _, conn := range Decode(p2).Connections {
+		other[conn.Innovation] = conn
+	}
+	var offspring = func() gago.Individual {
+		var net = fitter.Copy()
+		for i, conn := range net.Connections {
+			if match, ok := other[conn.Innovation]; ok && rng.Float64() < 0.5 {
+				net.Connections[i] = match
+			}
+		}
+		return makeIndividual(net)
+	}
+	return offspring(), offspring()
+}
+
+// MutWeights perturbs the weights of a network with Gaussian noise of standard
+// deviation Std, each weight is perturbed with probability Rate.
+type MutWeights struct {
+	Rate, Std float64
+}
+
+// Apply weight mutation.
+func (mut MutWeights) Apply(indi *gago.Individual, rng *rand.Rand) {
+	var net = Decode(*indi).Copy()
+	for i := range net.Connections {
+		if rng.Float64() < mut.Rate {
+			net.Connections[i].Weight += rng.NormFloat64() * mut.Std
+		}
+	}
+	indi.Genome[0] = net
+}
+
+// MutAddConnection adds a connection with a random weight between two nodes
+// that weren't connected. The connection never ends at an input or a bias node
+// and never creates a cycle. The network is left untouched if no such pair of
+// nodes is found after a few attempts.
+type MutAddConnection struct {
+	Innovations *Innovations
+}
+
+// Apply add-connection mutation.
+func (mut MutAddConnection) Apply(indi *gago.Individual, rng *rand.Rand) {
+	var net = Decode(*indi).Copy()
+	for attempt := 0; attempt < 20; attempt++ {
+		var (
+			in  = net.Nodes[rng.Intn(len(net.Nodes))]
+			out = net.Nodes[rng.Intn(len(net.Nodes))]
+		)
+		if in.Kind == Output || out.Kind == Input || out.Kind == Bias ||
+			net.connected(in.ID, out.ID) || net.reaches(out.ID, in.ID) {
+			continue
+		}
+		net.Connections = append(net.Connections, ConnectionGene{
+			In:         in.ID,
+			Out:        out.ID,
+			Weight:     rng.NormFloat64(),
+			Enabled:    true,
+			Innovation: mut.Innovations.connection(in.ID, out.ID),
+		})
+		sortConnections(net.Connections)
+		break
+	}
+	indi.Genome[0] = net
+}
+
+// MutAddNode splits a random enabled connection in two. The connection is
+// disabled and replaced with a new node, the connection leading to the new node
+// has a weight of 1 and the connection leaving it inherits the weight of the
+// split connection, hence the behavior of the network is mostly preserved.
+type MutAddNode struct {
+	Innovations *Innovations
+}
+
+// Apply add-node mutation.
+func (mut MutAddNode) Apply(indi *gago.Individual, rng *rand.Rand) {
+	var (
+		net     = Decode(*indi).Copy()
+		enabled []int
+	)
+	for i, conn := range net.Connections {
+		if conn.Enabled {
+			enabled = append(enabled, i)
+		}
+	}
+	if len(enabled) > 0 {
+		var (
+			i    = enabled[rng.Intn(len(enabled))]
+			conn = net.Connections[i]
+			id   = mut.Innovations.split(conn.Innovation, net.nextID())
+		)
+		net.Connections[i].Enabled = false
+		net.Nodes = append(net.Nodes, NodeGene{ID: id, Kind: Hidden})
+		net.Connections = append(net.Connections,
+			ConnectionGene{
+				In:         conn.In,
+				Out:        id,
+				Weight:     1,
+				Enabled:    true,
+				Innovation: mut.Innovations.connection(conn.In, id),
+			},
+			ConnectionGene{
+				In:         id,
+				Out:        conn.Out,
+				Weight:     conn.Weight,
+				Enabled:    true,
+				Innovation: mut.Innovations.connection(id, conn.Out),
+			},
+		)
+		sortConnections(net.Connections)
+	}
+	indi.Genome[0] = net
+}
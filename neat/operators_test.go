@@ -0,0 +1,124 @@
+package neat
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+func newIndividual(init Init) gago.Individual {
+	var indi = gago.Individual{Genome: make(gago.Genome, 1)}
+	init.Apply(&indi, rng)
+	return indi
+}
+
+func TestInit(t *testing.T) {
+	var (
+		inno = &Innovations{}
+		init = Init{NbInputs: 3, NbOutputs: 2, Innovations: inno}
+		a    = Decode(newIndividual(init))
+		b    = Decode(newIndividual(init))
+	)
+	if len(a.Nodes) != 6 || len(a.Connections) != 8 {
+		t.Error("Init didn't fully connect the inputs and the bias to the outputs")
+	}
+	for i := range a.Connections {
+		if a.Connections[i].Innovation != b.Connections[i].Innovation {
+			t.Error("Identical connections should share their innovation number")
+		}
+	}
+	if len(a.Predict([]float64{1, 2, 3})) != 2 {
+		t.Error("The network doesn't have the right number of outputs")
+	}
+}
+
+func TestStructuralMutations(t *testing.T) {
+	var (
+		inno = &Innovations{}
+		init = Init{NbInputs: 2, NbOutputs: 1, Innovations: inno}
+		a    = newIndividual(init)
+		b    = newIndividual(init)
+		orig = Decode(a)
+	)
+	MutAddNode{inno}.Apply(&a, rng)
+	var net = Decode(a)
+	if len(net.Nodes) != 5 || len(net.Connections) != 5 {
+		t.Error("MutAddNode didn't split a connection")
+	}
+	if len(orig.Nodes) != 4 {
+		t.Error("MutAddNode modified the original network")
+	}
+	// Splitting the same connection in another network creates the same node
+	var split ConnectionGene
+	for _, conn := range net.Connections {
+		if !conn.Enabled {
+			split = conn
+		}
+	}
+	Decode(b).Connections = []ConnectionGene{split}
+	Decode(b).Connections[0].Enabled = true
+	MutAddNode{inno}.Apply(&b, rng)
+	if Decode(b).Nodes[4].ID != net.Nodes[4].ID {
+		t.Error("The same split should create the same node")
+	}
+	// Adding connections never creates a cycle
+	for i := 0; i < 20; i++ {
+		MutAddNode{inno}.Apply(&a, rng)
+		MutAddConnection{inno}.Apply(&a, rng)
+	}
+	net = Decode(a)
+	for _, conn := range net.Connections {
+		if net.reaches(conn.Out, conn.In) {
+			t.Fatal("MutAddConnection created a cycle")
+		}
+	}
+	net.Predict([]float64{1, 1})
+}
+
+func TestCross(t *testing.T) {
+	var (
+		inno = &Innovations{}
+		init = Init{NbInputs: 2, NbOutputs: 1, Innovations: inno}
+		p1   = newIndividual(init)
+		p2   = newIndividual(init)
+	)
+	MutAddNode{inno}.Apply(&p1, rng)
+	p1.Fitness, p1.Evaluated = 1, true
+	p2.Fitness, p2.Evaluated = 2, true
+	var o1, o2 = Cross{}.Apply(p1, p2, rng)
+	for _, o := range []gago.Individual{o1, o2} {
+		var net = Decode(o)
+		if len(net.Nodes) != len(Decode(p1).Nodes) || len(net.Connections) != len(Decode(p1).Connections) {
+			t.Error("The offspring should inherit the structure of the fitter parent")
+		}
+		for i, conn := range net.Connections {
+			if conn.Innovation != Decode(p1).Connections[i].Innovation {
+				t.Error("The offspring's connections aren't aligned with the fitter parent")
+			}
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	var (
+		inno = &Innovations{}
+		init = Init{NbInputs: 2, NbOutputs: 1, Innovations: inno}
+		a    = newIndividual(init)
+		b    = newIndividual(init)
+		dist = Distance{Excess: 1, Disjoint: 1, Weight: 0}
+	)
+	if dist.Apply(a, a) != 0 {
+		t.Error("The distance of a network to itself should be 0")
+	}
+	if dist.Apply(a, b) != 0 {
+		t.Error("Networks with the same structure should have a null structural distance")
+	}
+	MutAddNode{inno}.Apply(&a, rng)
+	if dist.Apply(a, b) != 2 || dist.Apply(b, a) != 2 {
+		t.Error("The distance should count the two excess connections")
+	}
+}
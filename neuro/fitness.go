@@ -0,0 +1,58 @@
+package neuro
+
+import (
+	"math"
+
+	"github.com/MaxHalford/gago"
+)
+
+// MeanSquaredError computes the mean squared error of a network on a set of
+// inputs X and target outputs Y.
+func MeanSquaredError(net *Network, X, Y [][]float64) float64 {
+	var total float64
+	for i, x := range X {
+		for j, y := range net.Predict(x) {
+			total += math.Pow(y-Y[i][j], 2)
+		}
+	}
+	return total / float64(len(X))
+}
+
+// Fitness returns a fitness function that decodes a genome of weights into a
+// network and computes it's mean squared error on the given data.
+func Fitness(top Topology, X, Y [][]float64) gago.Float64Function {
+	return gago.Float64Function{
+		Image: func(weights []float64) float64 {
+			return MeanSquaredError(top.Decode(weights), X, Y)
+		},
+	}
+}
+
+// GA returns a GA configuration for training a network with the given topology
+// on the given data. The weights are directly encoded as floating point genes,
+// hence the usual floating point operators are used.
+func GA(top Topology, X, Y [][]float64) gago.GA {
+	return gago.GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 50,
+		NbrGenes:       top.NbWeights(),
+		Ff:             Fitness(top, X, Y),
+		Initializer: gago.InitGaussianF{
+			Mean: 0,
+			Std:  1,
+		},
+		Model: gago.ModGenerational{
+			Selector: gago.SelTournament{
+				NbParticipants: 3,
+			},
+			Crossover: gago.CrossUniformF{},
+			Mutator: gago.MutNormalF{
+				Rate: 0.1,
+				Std:  1,
+			},
+			MutRate: 0.5,
+		},
+		Migrator:     gago.MigShuffle{},
+		MigFrequency: 10,
+	}
+}
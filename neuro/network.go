@@ -0,0 +1,98 @@
+package neuro
+
+import (
+	"fmt"
+	"math"
+)
+
+// An Activation is applied to the weighted sum of the inputs of each neuron.
+type Activation func(float64) float64
+
+// Identity returns it's input as is.
+func Identity(x float64) float64 { return x }
+
+// Sigmoid squashes it's input into (0, 1).
+func Sigmoid(x float64) float64 { return 1 / (1 + math.Exp(-x)) }
+
+// Tanh squashes it's input into (-1, 1).
+func Tanh(x float64) float64 { return math.Tanh(x) }
+
+// ReLU returns it's input if it's positive and 0 otherwise.
+func ReLU(x float64) float64 { return math.Max(0, x) }
+
+// A Topology describes a fully connected feedforward network. Layers contains
+// the number of neurons in each layer, starting with the number of inputs and
+// ending with the number of outputs. Each neuron has a bias. Hidden is the
+// activation of the hidden layers and Output is the activation of the output
+// layer, both default to Tanh and Identity if they are nil.
+type Topology struct {
+	Layers []int
+	Hidden Activation
+	Output Activation
+}
+
+// NbWeights returns the number of weights (biases included) required by the
+// topology, which is the number of genes of the genome encoding a network.
+func (top Topology) NbWeights() int {
+	var n int
+	for l := 1; l < len(top.Layers); l++ {
+		n += (top.Layers[l-1] + 1) * top.Layers[l]
+	}
+	return n
+}
+
+// A Network is a feedforward network obtained by decoding a genome.
+type Network struct {
+	topology Topology
+	weights  []float64
+}
+
+// Decode builds a network from a slice of weights, which is usually the genome
+// of an individual. The weights are read layer by layer, for each neuron the
+// bias comes first and is followed by the weights of the incoming connections.
+// The weights are copied, hence the slice can be modified afterwards.
+func (top Topology) Decode(weights []float64) *Network {
+	if top.Hidden == nil {
+		top.Hidden = Tanh
+	}
+	if top.Output == nil {
+		top.Output = Identity
+	}
+	var copied = make([]float64, len(weights))
+	copy(copied, weights)
+	return &Network{topology: top, weights: copied}
+}
+
+// Predict feeds an input forward through the network and returns the outputs.
+// The input should have a value for each neuron of the input layer, Predict
+// panics otherwise.
+func (net *Network) Predict(x []float64) []float64 {
+	if len(x) != net.topology.Layers[0] {
+		panic(fmt.Errorf("the network expects %d inputs, got %d", net.topology.Layers[0], len(x)))
+	}
+	var (
+		layers     = net.topology.Layers
+		activation = x
+		w          = 0
+	)
+	for l := 1; l < len(layers); l++ {
+		var (
+			next = make([]float64, layers[l])
+			f    = net.topology.Hidden
+		)
+		if l == len(layers)-1 {
+			f = net.topology.Output
+		}
+		for j := range next {
+			var sum = net.weights[w]
+			w++
+			for _, a := range activation {
+				sum += net.weights[w] * a
+				w++
+			}
+			next[j] = f(sum)
+		}
+		activation = next
+	}
+	return activation
+}
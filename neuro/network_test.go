@@ -0,0 +1,52 @@
+package neuro
+
+import "testing"
+
+func TestNbWeights(t *testing.T) {
+	var top = Topology{Layers: []int{2, 3, 1}}
+	if top.NbWeights() != 3*3+4*1 {
+		t.Error("NbWeights didn't count the weights correctly")
+	}
+}
+
+func TestPredict(t *testing.T) {
+	var (
+		top = Topology{Layers: []int{2, 1, 1}, Hidden: Identity}
+		// Hidden neuron computes 1 + x0 + 2*x1, output neuron computes 3*h - 1
+		net = top.Decode([]float64{1, 1, 2, -1, 3})
+		y   = net.Predict([]float64{1, 1})
+	)
+	if len(y) != 1 || y[0] != 11 {
+		t.Error("Predict didn't feed the input forward correctly")
+	}
+	for _, x := range [][]float64{{1}, {1, 1, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Predict should panic with %d inputs", len(x))
+				}
+			}()
+			net.Predict(x)
+		}()
+	}
+}
+
+func TestGA(t *testing.T) {
+	var (
+		X   = [][]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+		Y   = [][]float64{{0}, {1}, {1}, {0}}
+		top = Topology{Layers: []int{2, 2, 1}}
+		ga  = GA(top, X, Y)
+	)
+	ga.Initialize()
+	var initial = ga.Best().Fitness
+	for i := 0; i < 10; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness > initial {
+		t.Error("The best network got worse")
+	}
+	if len(ga.Best().Genome) != top.NbWeights() {
+		t.Error("The genome doesn't encode the network's weights")
+	}
+}
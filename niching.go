@@ -0,0 +1,309 @@
+package gago
+
+import (
+	"errors"
+	"math"
+)
+
+// Niching methods maintain several subpopulations, called niches, around the
+// different optima of a multimodal function so that a single run can find
+// several of them.
+
+// ModClearing applies clearing before running another model. The population
+// is traversed from the best to the worst individual, each individual that
+// isn't cleared becomes the winner of a niche of radius Radius. The Capacity
+// best individuals of each niche keep their fitness whereas the other members
+// of the niche are cleared, meaning their fitness is set to +Inf so that they
+// are unlikely to be selected. Cleared individuals are marked as not evaluated
+// so that their real fitness is computed again if they survive.
+type ModClearing struct {
+	Model    Model
+	Metric   DistanceMetric
+	Radius   float64
+	Capacity int // Number of individuals allowed to keep their fitness in each niche
+}
+
+// Apply clearing and then the wrapped model to a population.
+func (mod ModClearing) Apply(pop *Population) {
+	pop.Individuals.SortWith(pop.cmp)
+	var (
+		indis   = pop.Individuals
+		cleared = make([]bool, len(indis))
+	)
+	for i := range indis {
+		if cleared[i] {
+			continue
+		}
+		// The i-th individual is the winner of a new niche
+		var members = 1
+		for j := i + 1; j < len(indis); j++ {
+			if cleared[j] || mod.Metric.Apply(indis[i], indis[j]) >= mod.Radius {
+				continue
+			}
+			if members < mod.Capacity {
+				members++
+			} else {
+				cleared[j] = true
+			}
+		}
+	}
+	for i := range indis {
+		if cleared[i] {
+			indis[i].Fitness = math.Inf(1)
+			indis[i].Evaluated = false
+		}
+	}
+	mod.Model.Apply(pop)
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModClearing) Validate() error {
+	// Check the wrapped model presence
+	if mod.Model == nil {
+		return errors.New("'Model' cannot be nil")
+	}
+	// Check the metric presence
+	if mod.Metric == nil {
+		return errors.New("'Metric' cannot be nil")
+	}
+	// Check the radius value
+	if mod.Radius <= 0 {
+		return errors.New("'Radius' should be higher than 0")
+	}
+	// Check the capacity value
+	if mod.Capacity < 1 {
+		return errors.New("'Capacity' should be higher or equal to 1")
+	}
+	return mod.Model.Validate()
+}
+
+// ModCrowding implements deterministic crowding. The population is randomly
+// paired, each pair of parents produces two offsprings through crossover and
+// mutation. Each offspring is then matched with the parent it is closest to
+// and replaces it if it is fitter. Because offsprings only compete with
+// similar parents, the niches of the population are preserved.
+type ModCrowding struct {
+	Crossover Crossover
+	Mutator   Mutator
+	MutRate   float64
+	Metric    DistanceMetric
+}
+
+// Apply deterministic crowding to a population.
+func (mod ModCrowding) Apply(pop *Population) {
+	var order = pop.rng.Perm(len(pop.Individuals))
+	for k := 0; k+1 < len(order); k += 2 {
+		var i, j = order[k], order[k+1]
+		pop.Individuals[i].Evaluate(pop.ff)
+		pop.Individuals[j].Evaluate(pop.ff)
+		var (
+			p1, p2 = pop.Individuals[i], pop.Individuals[j]
+			o1, o2 = mod.Crossover.Apply(p1, p2, pop.rng)
+		)
+		// Apply mutation to the offsprings
+		if mod.Mutator != nil {
+			mutateSiblings(&o1, &o2, mod.Mutator, mod.MutRate, pop.rng)
+		}
+		o1.Evaluate(pop.ff)
+		o2.Evaluate(pop.ff)
+		// Match each offspring with the closest parent
+		if mod.Metric.Apply(p1, o1)+mod.Metric.Apply(p2, o2) > mod.Metric.Apply(p1, o2)+mod.Metric.Apply(p2, o1) {
+			o1, o2 = o2, o1
+		}
+		if less(pop.cmp, o1, p1) {
+			pop.Individuals[i] = o1
+		}
+		if less(pop.cmp, o2, p2) {
+			pop.Individuals[j] = o2
+		}
+	}
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModCrowding) Validate() error {
+	// Check the crossover method presence
+	if mod.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the metric presence
+	if mod.Metric == nil {
+		return errors.New("'Metric' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if mod.Mutator != nil && (mod.MutRate < 0 || mod.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	return nil
+}
+
+// Optima returns the distinct solutions found by the GA, which is mostly useful
+// after a run that used niching. The individuals of every population are
+// considered from the best to the worst, an individual is kept if it is at a
+// distance of at least radius from every individual that was kept before it.
+// The returned individuals are sorted by increasing fitness and are copies of
+// the individuals in the populations.
+func (ga GA) Optima(radius float64, metric DistanceMetric) Individuals {
+	var candidates Individuals
+	if best := ga.Best(); best.Evaluated {
+		candidates = append(candidates, best)
+	}
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			if indi.Evaluated && !math.IsInf(indi.Fitness, 1) {
+				candidates = append(candidates, indi)
+			}
+		}
+	}
+	candidates.SortWith(ga.Comparator)
+	var optima Individuals
+	for _, candidate := range candidates {
+		var distinct = true
+		for _, optimum := range optima {
+			if metric.Apply(candidate, optimum) < radius {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			var genome = make(Genome, len(candidate.Genome))
+			copy(genome, candidate.Genome)
+			candidate.Genome = genome
+			optima = append(optima, candidate)
+		}
+	}
+	return optima
+}
+
+// Speciate splits individuals into species. The individuals are traversed from
+// the best to the worst, each individual joins the first species whose
+// representative is at a distance lower than threshold or else becomes the
+// representative of a new species. The representative of a species is it's
+// first member, hence it's best member if the individuals are sorted.
+func (indis Individuals) Speciate(metric DistanceMetric, threshold float64) []Individuals {
+	var species []Individuals
+	for _, indi := range indis {
+		var found = false
+		for i := range species {
+			if metric.Apply(species[i][0], indi) < threshold {
+				species[i] = append(species[i], indi)
+				found = true
+				break
+			}
+		}
+		if !found {
+			species = append(species, Individuals{indi})
+		}
+	}
+	return species
+}
+
+// ModSpeciation implements speciation with explicit fitness sharing as done in
+// NEAT. The population is split into species with Speciate, each species then
+// produces a number of offsprings proportional to the mean score of it's
+// members, where the score of an individual is the difference between the
+// worst fitness of the population and it's fitness. Because the members of a
+// species share their score, a large species can't take over the population
+// and new structures are given time to be optimized. The best member of each
+// species that produces offsprings is copied as is, the other offsprings are
+// produced from parents uniformly chosen among the Survival proportion of the
+// best members of the species.
+type ModSpeciation struct {
+	Metric    DistanceMetric
+	Threshold float64
+	Survival  float64
+	Crossover Crossover
+	Mutator   Mutator
+	MutRate   float64
+}
+
+// Apply speciation to a population.
+func (mod ModSpeciation) Apply(pop *Population) {
+	pop.Individuals.Evaluate(pop.ff)
+	pop.Individuals.SortWith(pop.cmp)
+	var (
+		species = pop.Individuals.Speciate(mod.Metric, mod.Threshold)
+		n       = len(pop.Individuals)
+		worst   = math.Inf(-1)
+	)
+	for _, indi := range pop.Individuals {
+		if !math.IsInf(indi.Fitness, 0) && indi.Fitness > worst {
+			worst = indi.Fitness
+		}
+	}
+	// Compute the shared score of each species
+	var (
+		scores = make([]float64, len(species))
+		total  float64
+	)
+	for i, members := range species {
+		for _, member := range members {
+			if !math.IsInf(member.Fitness, 0) {
+				scores[i] += worst - member.Fitness
+			}
+		}
+		scores[i] /= float64(len(members))
+		total += scores[i]
+	}
+	// Allocate the offsprings to each species, the leftovers go to the species
+	// containing the best individual
+	var (
+		counts    = make([]int, len(species))
+		allocated int
+	)
+	for i := range species {
+		if total > 0 {
+			counts[i] = int(float64(n) * scores[i] / total)
+		} else {
+			counts[i] = n / len(species)
+		}
+		allocated += counts[i]
+	}
+	counts[0] += n - allocated
+	// Produce the offsprings of each species
+	var offsprings = make(Individuals, 0, n)
+	for i, members := range species {
+		if counts[i] == 0 {
+			continue
+		}
+		offsprings = append(offsprings, members[0].clone(pop.rng))
+		var parents = members[:int(math.Max(1, math.Ceil(mod.Survival*float64(len(members)))))]
+		for len(offsprings) < cap(offsprings) && counts[i] > 1 {
+			var (
+				p1    = parents[pop.rng.Intn(len(parents))]
+				p2    = parents[pop.rng.Intn(len(parents))]
+				o1, _ = mod.Crossover.Apply(p1, p2, pop.rng)
+			)
+			if mod.Mutator != nil && pop.rng.Float64() < mod.MutRate {
+				o1.Mutate(mod.Mutator, pop.rng)
+			}
+			offsprings = append(offsprings, o1)
+			counts[i]--
+		}
+	}
+	pop.Individuals = offsprings
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModSpeciation) Validate() error {
+	// Check the metric presence
+	if mod.Metric == nil {
+		return errors.New("'Metric' cannot be nil")
+	}
+	// Check the threshold value
+	if mod.Threshold <= 0 {
+		return errors.New("'Threshold' should be higher than 0")
+	}
+	// Check the survival proportion
+	if mod.Survival <= 0 || mod.Survival > 1 {
+		return errors.New("'Survival' should belong to the (0, 1] interval")
+	}
+	// Check the crossover method presence
+	if mod.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if mod.Mutator != nil && (mod.MutRate < 0 || mod.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestClearing(t *testing.T) {
+	var (
+		src = rand.NewSource(time.Now().UnixNano())
+		rng = rand.New(src)
+		pop = Population{Individuals: makeIndividuals(4, 1, rng), rng: rng, ff: ff}
+		mod = ModClearing{
+			Model:    ModMutationOnly{NbrParents: 4, Selector: SelElitism{}, NbrOffsprings: 1, Mutator: MutProb{MutNormalF{1, 1}, 0}},
+			Metric:   DistEuclidean{},
+			Radius:   1,
+			Capacity: 1,
+		}
+	)
+	// Two niches of two individuals
+	for i, x := range []float64{0, 0.5, 10, 10.5} {
+		pop.Individuals[i].Genome[0] = x
+		pop.Individuals[i].Fitness = x
+		pop.Individuals[i].Evaluated = true
+	}
+	if err := mod.Validate(); err != nil {
+		t.Error(err)
+	}
+	mod.Apply(&pop)
+	var cleared int
+	for _, indi := range pop.Individuals {
+		if math.IsInf(indi.Fitness, 1) {
+			cleared++
+		}
+	}
+	if cleared != 2 {
+		t.Error("Clearing didn't clear one individual per niche")
+	}
+}
+
+func TestCrowdingKeepsNiches(t *testing.T) {
+	var (
+		src = rand.NewSource(time.Now().UnixNano())
+		rng = rand.New(src)
+		pop = Population{Individuals: makeIndividuals(10, 1, rng), rng: rng, ff: ff}
+		mod = ModCrowding{
+			Crossover: CrossUniformF{},
+			Metric:    DistEuclidean{},
+		}
+	)
+	for i := range pop.Individuals {
+		pop.Individuals[i].Genome[0] = float64(i)
+	}
+	var before = make(Individuals, len(pop.Individuals))
+	copy(before, pop.Individuals)
+	mod.Apply(&pop)
+	// Check no individual got worse, since offsprings only replace fitter parents
+	for i, indi := range pop.Individuals {
+		before[i].Evaluate(ff)
+		if indi.Fitness > before[i].Fitness {
+			t.Error("Deterministic crowding replaced a parent with a worse offspring")
+		}
+	}
+}
+
+func TestOptima(t *testing.T) {
+	var (
+		src = rand.NewSource(time.Now().UnixNano())
+		rng = rand.New(src)
+		ga  = GA{Populations: Populations{
+			Population{Individuals: makeIndividuals(3, 1, rng)},
+			Population{Individuals: makeIndividuals(3, 1, rng)},
+		}}
+		genes = []float64{0, 0.2, 5, 5.1, 9, 20}
+	)
+	// Three peaks at 0, 5 and 9, the individual at 20 isn't evaluated
+	for i, x := range genes {
+		var indi = &ga.Populations[i/3].Individuals[i%3]
+		indi.Genome[0] = x
+		indi.Fitness = x
+		indi.Evaluated = x != 20
+	}
+	var optima = ga.Optima(1, DistEuclidean{})
+	if len(optima) != 3 {
+		t.Fatal("Optima didn't find the distinct optima")
+	}
+	for i, x := range []float64{0, 5, 9} {
+		if optima[i].Genome[0] != x {
+			t.Error("Optima didn't keep the best individual of each peak")
+		}
+	}
+	// Check the optima don't share genomes with the populations
+	optima[0].Genome[0] = 42.0
+	if ga.Populations[0].Individuals[0].Genome[0] == 42.0 {
+		t.Error("Optima shares a genome with an individual")
+	}
+}
+
+func TestSpeciate(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = makeIndividuals(5, 1, rng)
+	)
+	for i, x := range []float64{0, 0.5, 10, 0.8, 10.2} {
+		indis[i].Genome[0] = x
+	}
+	var species = indis.Speciate(DistEuclidean{}, 1)
+	if len(species) != 2 {
+		t.Fatal("Speciate didn't find two species")
+	}
+	if len(species[0]) != 3 || len(species[1]) != 2 {
+		t.Error("Speciate didn't assign the individuals to the right species")
+	}
+	if species[1][0].Genome[0] != 10.0 {
+		t.Error("The representative of a species should be it's first member")
+	}
+}
+
+func TestSpeciationSharesOffsprings(t *testing.T) {
+	var (
+		src = rand.NewSource(time.Now().UnixNano())
+		rng = rand.New(src)
+		pop = Population{Individuals: makeIndividuals(10, 1, rng), rng: rng, ff: ff}
+		mod = ModSpeciation{
+			Metric:    DistEuclidean{},
+			Threshold: 1,
+			Survival:  1,
+			Crossover: CrossUniformF{},
+		}
+	)
+	// A large species around 1 and two single individuals at 5 and 20, the
+	// individual at 5 has a worse fitness than the large species but isn't driven
+	// out of the population
+	for i := range pop.Individuals {
+		pop.Individuals[i].Genome[0] = 1 + float64(i)/100
+	}
+	pop.Individuals[8].Genome[0] = 5.0
+	pop.Individuals[9].Genome[0] = 20.0
+	if err := mod.Validate(); err != nil {
+		t.Error(err)
+	}
+	mod.Apply(&pop)
+	if len(pop.Individuals) != 10 {
+		t.Error("Speciation changed the size of the population")
+	}
+	var found = false
+	for _, indi := range pop.Individuals {
+		if indi.Genome[0] == 5.0 {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Speciation didn't keep the representative of the small species")
+	}
+}
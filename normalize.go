@@ -0,0 +1,105 @@
+package gago
+
+import (
+	"errors"
+	"math"
+)
+
+// A Normalizer maps genomes whose genes belong to [0, 1] to values in user
+// units, so that the genetic operators work on dimensions of comparable
+// scales whatever the units of the problem. The i-th gene u becomes
+// Transforms[i](u) if there is such a transform, otherwise it's mapped
+// linearly to [Lower[i], Upper[i]]. A transform can for example map u to
+// 10^(-5+4u) for a learning rate that spans several orders of magnitude. The
+// genes are clipped to [0, 1] before being mapped.
+type Normalizer struct {
+	Lower, Upper []float64
+	Transforms   []func(u float64) float64
+}
+
+// Return the number of genes the normalizer handles.
+func (n Normalizer) dim() int {
+	return max(len(n.Lower), len(n.Transforms))
+}
+
+// Denormalize returns the values in user units of a normalized genome.
+func (n Normalizer) Denormalize(genome Genome) []float64 {
+	var values = make([]float64, len(genome))
+	for i, gene := range genome {
+		var u = math.Min(math.Max(gene.(float64), 0), 1)
+		if i < len(n.Transforms) && n.Transforms[i] != nil {
+			values[i] = n.Transforms[i](u)
+		} else {
+			values[i] = n.Lower[i] + u*(n.Upper[i]-n.Lower[i])
+		}
+	}
+	return values
+}
+
+// Normalize returns the normalized genome of values in user units, which is
+// useful to seed a GA with known solutions. An error is returned if a value
+// is handled by a transform, because transforms can't be inverted.
+func (n Normalizer) Normalize(values []float64) (Genome, error) {
+	var genome = make(Genome, len(values))
+	for i, x := range values {
+		if i < len(n.Transforms) && n.Transforms[i] != nil {
+			return nil, errors.New("values handled by a transform can't be normalized")
+		}
+		if n.Upper[i] == n.Lower[i] {
+			genome[i] = 0.0
+			continue
+		}
+		genome[i] = math.Min(math.Max((x-n.Lower[i])/(n.Upper[i]-n.Lower[i]), 0), 1)
+	}
+	return genome, nil
+}
+
+// Validate the normalizer to verify the parameters are coherent.
+func (n Normalizer) Validate() error {
+	// Check the bounds
+	if len(n.Lower) != len(n.Upper) {
+		return errors.New("'Lower' and 'Upper' should have the same length")
+	}
+	for i := range n.Lower {
+		if n.Lower[i] > n.Upper[i] {
+			return errors.New("'Lower' should be lower or equal to 'Upper'")
+		}
+	}
+	// Check each gene is handled
+	for i := len(n.Lower); i < len(n.Transforms); i++ {
+		if n.Transforms[i] == nil {
+			return errors.New("each gene should have bounds or a transform")
+		}
+	}
+	if n.dim() == 0 {
+		return errors.New("the normalizer should handle at least 1 gene")
+	}
+	return nil
+}
+
+// NormalizedFunction is for functions with floating point slices in user units
+// as input that are optimized with a normalized genome, the genome is mapped
+// to user units by Normalizer before calling Image. The genes of the best
+// individual can be mapped with Normalizer.Denormalize.
+type NormalizedFunction struct {
+	Normalizer Normalizer
+	Image      func([]float64) float64
+}
+
+// Apply the fitness function wrapped in NormalizedFunction.
+func (ff NormalizedFunction) apply(genome Genome) float64 {
+	return ff.Image(ff.Normalizer.Denormalize(genome))
+}
+
+// NewNormalizedGA returns a GA for minimizing a function of real variables in
+// user units whose domains are described by a Normalizer, the number of genes
+// being the number of variables the normalizer handles. The GA evolves
+// normalized genomes with the same operators as NewFloatGA with a [0, 1]
+// domain, hence the steps of the mutation are proportional to the width of
+// each domain. Every field of the returned GA can be changed before calling
+// Initialize.
+func NewNormalizedGA(normalizer Normalizer, fitness func([]float64) float64) GA {
+	var ga = NewFloatGA(normalizer.dim(), 0, 1, nil)
+	ga.Ff = NormalizedFunction{Normalizer: normalizer, Image: fitness}
+	return ga
+}
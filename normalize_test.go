@@ -0,0 +1,73 @@
+package gago
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizer(t *testing.T) {
+	var (
+		n = Normalizer{
+			Lower:      []float64{-10, 0},
+			Upper:      []float64{10, 1000},
+			Transforms: []func(float64) float64{nil, nil, func(u float64) float64 { return math.Pow(10, -5+4*u) }},
+		}
+		values = n.Denormalize(Genome{0.5, 0.25, 1.0})
+	)
+	if n.Validate() != nil {
+		t.Error("The normalizer should be valid")
+	}
+	if values[0] != 0 || values[1] != 250 || math.Abs(values[2]-0.1) > 1e-12 {
+		t.Errorf("Unexpected values %v", values)
+	}
+	// The genes are clipped
+	if values = n.Denormalize(Genome{-1.0, 2.0}); values[0] != -10 || values[1] != 1000 {
+		t.Errorf("Unexpected values %v", values)
+	}
+	var genome, err = n.Normalize([]float64{5, 100})
+	if err != nil || genome[0] != 0.75 || genome[1] != 0.1 {
+		t.Errorf("Unexpected genome %v, %v", genome, err)
+	}
+	if _, err = n.Normalize([]float64{5, 100, 1e-3}); err == nil {
+		t.Error("A value handled by a transform shouldn't be normalized")
+	}
+}
+
+func TestNormalizerValidate(t *testing.T) {
+	var testCases = []Normalizer{
+		{},
+		{Lower: []float64{0}, Upper: []float64{1, 2}},
+		{Lower: []float64{1}, Upper: []float64{0}},
+		{Lower: []float64{0}, Upper: []float64{1}, Transforms: []func(float64) float64{nil, nil}},
+	}
+	for _, n := range testCases {
+		if n.Validate() == nil {
+			t.Errorf("%+v shouldn't be valid", n)
+		}
+	}
+}
+
+func TestNewNormalizedGA(t *testing.T) {
+	// The dimensions have very different scales, the optimum is (500, -0.002)
+	var (
+		n = Normalizer{
+			Lower: []float64{0, -0.01},
+			Upper: []float64{1000, 0.01},
+		}
+		ga = NewNormalizedGA(n, func(x []float64) float64 {
+			return math.Pow((x[0]-500)/1000, 2) + math.Pow((x[1]+0.002)/0.01, 2)
+		})
+	)
+	ga.Seed = 42
+	if ga.NbrGenes != 2 {
+		t.Errorf("Expected 2 genes, got %d", ga.NbrGenes)
+	}
+	ga.Initialize()
+	for i := 0; i < 50; i++ {
+		ga.Enhance()
+	}
+	var x = n.Denormalize(ga.Best().Genome)
+	if math.Abs(x[0]-500) > 10 || math.Abs(x[1]+0.002) > 1e-4 {
+		t.Errorf("Expected about (500, -0.002), got %v", x)
+	}
+}
@@ -0,0 +1,557 @@
+package gago
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// NonDominatedSort sorts vectors of objectives into successive non-dominated
+// fronts with the fast non-dominated sorting procedure of Deb et al. Each
+// front contains the indexes of the vectors that are only dominated by vectors
+// of the previous fronts, hence the first front is the Pareto front of the
+// vectors. The dominance relation is provided so that it can account for
+// preferences, for example Preferences.Dominates, Pareto dominance is used if
+// it's nil.
+func NonDominatedSort(objs [][]float64, dominates func(a, b []float64) bool) [][]int {
+	if dominates == nil {
+		dominates = Dominates
+	}
+	var (
+		dominated = make([][]int, len(objs)) // Indexes dominated by each vector
+		counts    = make([]int, len(objs))   // Number of vectors dominating each vector
+		front     []int
+		fronts    [][]int
+	)
+	for i := range objs {
+		for j := i + 1; j < len(objs); j++ {
+			if dominates(objs[i], objs[j]) {
+				dominated[i] = append(dominated[i], j)
+				counts[j]++
+			} else if dominates(objs[j], objs[i]) {
+				dominated[j] = append(dominated[j], i)
+				counts[i]++
+			}
+		}
+	}
+	for i, count := range counts {
+		if count == 0 {
+			front = append(front, i)
+		}
+	}
+	for len(front) > 0 {
+		fronts = append(fronts, front)
+		var next []int
+		for _, i := range front {
+			for _, j := range dominated[i] {
+				counts[j]--
+				if counts[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		front = next
+	}
+	return fronts
+}
+
+// Sort individuals into successive non-dominated fronts with the constrained
+// dominance of Deb et al., where a feasible individual dominates an infeasible
+// one, an infeasible individual dominates the individuals that violate the
+// constraints more and feasible individuals are compared with dominates. The
+// feasible individuals thus come first, followed by the infeasible individuals
+// grouped by increasing violation. objs contains the objectives of each
+// individual.
+func constrainedSort(indis Individuals, objs [][]float64, dominates func(a, b []float64) bool) [][]int {
+	var feasible, infeasible []int
+	for i, indi := range indis {
+		if indi.Feasible() {
+			feasible = append(feasible, i)
+		} else {
+			infeasible = append(infeasible, i)
+		}
+	}
+	if len(infeasible) == 0 {
+		return NonDominatedSort(objs, dominates)
+	}
+	var sub = make([][]float64, len(feasible))
+	for k, i := range feasible {
+		sub[k] = objs[i]
+	}
+	var fronts = NonDominatedSort(sub, dominates)
+	for _, front := range fronts {
+		for k := range front {
+			front[k] = feasible[front[k]]
+		}
+	}
+	sort.SliceStable(infeasible, func(a, b int) bool {
+		return indis[infeasible[a]].Violation < indis[infeasible[b]].Violation
+	})
+	for k, i := range infeasible {
+		if k > 0 && indis[i].Violation == indis[infeasible[k-1]].Violation {
+			fronts[len(fronts)-1] = append(fronts[len(fronts)-1], i)
+		} else {
+			fronts = append(fronts, []int{i})
+		}
+	}
+	return fronts
+}
+
+// ConstrainedSort sorts individuals into successive non-dominated fronts of
+// indexes, like NonDominatedSort does with their objectives, with the
+// constrained dominance of Deb et al.: feasible individuals come first and
+// infeasible individuals are grouped by increasing violation. The objectives
+// of an individual that wasn't evaluated by an ObjectivesFunction are it's
+// fitness. Pareto dominance is used if dominates is nil.
+func ConstrainedSort(indis Individuals, dominates func(a, b []float64) bool) [][]int {
+	var objs = make([][]float64, len(indis))
+	for i, indi := range indis {
+		objs[i] = objectives(indi)
+	}
+	return constrainedSort(indis, objs, dominates)
+}
+
+// CrowdingDistance computes the crowding distance of each member of a front,
+// given by it's indexes in objs, which is the sum over each objective of the
+// normalized distance between the two neighbours of the member. The members at
+// the boundaries of the front have an infinite crowding distance so that they
+// are always preferred. The i-th distance is the one of the i-th member.
+func CrowdingDistance(objs [][]float64, front []int) []float64 {
+	var (
+		distances = make([]float64, len(front))
+		order     = make([]int, len(front))
+	)
+	if len(front) == 0 {
+		return distances
+	}
+	for m := range objs[front[0]] {
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool {
+			return objs[front[order[i]]][m] < objs[front[order[j]]][m]
+		})
+		var (
+			min = objs[front[order[0]]][m]
+			max = objs[front[order[len(order)-1]]][m]
+		)
+		distances[order[0]] = math.Inf(1)
+		distances[order[len(order)-1]] = math.Inf(1)
+		if max == min {
+			continue
+		}
+		for i := 1; i < len(order)-1; i++ {
+			distances[order[i]] += (objs[front[order[i+1]]][m] - objs[front[order[i-1]]][m]) / (max - min)
+		}
+	}
+	return distances
+}
+
+// Generate n offsprings from the parents, the parents are chosen with the
+// provided function.
+func breed(n int, choose func() Individual, cross Crossover, mutator Mutator, mutRate float64, rng *rand.Rand) Individuals {
+	var offsprings = make(Individuals, 0, n+1)
+	for len(offsprings) < n {
+		var o1, o2 = cross.Apply(choose(), choose(), rng)
+		offsprings = append(offsprings, o1, o2)
+	}
+	offsprings = offsprings[:n]
+	if mutator != nil {
+		offsprings.Mutate(mutator, mutRate, rng)
+	}
+	return offsprings
+}
+
+// Both NSGA models need a multi-objective fitness function, ObjectivesFunction
+// for example. An individual evaluated by a single-objective fitness function
+// is treated as having a single objective, in which case the models boil down
+// to elitist models. If the fitness function is a ConstrainedFunction the
+// fronts are built with the constrained dominance of Deb et al., hence the
+// feasible individuals are preferred to the infeasible ones.
+
+// ModNSGA2 implements the NSGA-II algorithm of Deb et al. At each generation
+// as many offsprings as there are individuals are generated, the parents being
+// chosen with binary tournaments based on the rank of their front and their
+// crowding distance. The parents and the offsprings are then sorted into
+// non-dominated fronts which are used to fill the next population, the last
+// front that fits partially is truncated by keeping it's least crowded
+// individuals. If Preferences is not nil then the preferred individuals are
+// ranked first instead of the non-dominated ones.
+type ModNSGA2 struct {
+	Crossover   Crossover
+	Mutator     Mutator
+	MutRate     float64
+	Preferences *Preferences
+}
+
+// Apply NSGA-II to a population.
+func (mod ModNSGA2) Apply(pop *Population) {
+	var (
+		n         = len(pop.Individuals)
+		objs      = pop.Individuals.Objectives()
+		ranks     = make([]int, n)
+		distances = make([]float64, n)
+	)
+	for rank, front := range constrainedSort(pop.Individuals, objs, mod.Preferences.Dominates) {
+		for i, d := range CrowdingDistance(objs, front) {
+			ranks[front[i]] = rank
+			distances[front[i]] = d
+		}
+	}
+	// Crowded binary tournament
+	var choose = func() Individual {
+		var i, j = pop.rng.Intn(n), pop.rng.Intn(n)
+		if ranks[j] < ranks[i] || (ranks[j] == ranks[i] && distances[j] > distances[i]) {
+			i = j
+		}
+		return pop.Individuals[i]
+	}
+	var offsprings = breed(n, choose, mod.Crossover, mod.Mutator, mod.MutRate, pop.rng)
+	offsprings.Evaluate(pop.ff)
+	// Fill the next population front by front
+	var (
+		indis = append(pop.Individuals[:n:n], offsprings...)
+		next  = make(Individuals, 0, n)
+	)
+	objs = indis.Objectives()
+	for _, front := range constrainedSort(indis, objs, mod.Preferences.Dominates) {
+		if len(next)+len(front) > n {
+			var d = CrowdingDistance(objs, front)
+			sort.Sort(byDistance{front, d})
+			front = front[:n-len(next)]
+		}
+		for _, i := range front {
+			next = append(next, indis[i])
+		}
+		if len(next) == n {
+			break
+		}
+	}
+	pop.Individuals = next
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModNSGA2) Validate() error {
+	// Check the crossover method presence
+	if mod.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if mod.Mutator != nil && (mod.MutRate < 0 || mod.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	return nil
+}
+
+// Sort the members of a front by decreasing crowding distance.
+type byDistance struct {
+	front     []int
+	distances []float64
+}
+
+func (b byDistance) Len() int           { return len(b.front) }
+func (b byDistance) Less(i, j int) bool { return b.distances[i] > b.distances[j] }
+func (b byDistance) Swap(i, j int) {
+	b.front[i], b.front[j] = b.front[j], b.front[i]
+	b.distances[i], b.distances[j] = b.distances[j], b.distances[i]
+}
+
+// WeightVectors generates evenly spread vectors on the unit simplex with the
+// method of Das and Dennis. Each coordinate is a multiple of 1/divisions and
+// the coordinates of each vector sum up to 1, which produces C(nbObjectives +
+// divisions - 1, divisions) vectors. They are used as reference points by
+// NSGA-III and as weights by decomposition based algorithms.
+func WeightVectors(nbObjectives, divisions int) [][]float64 {
+	var (
+		vectors [][]float64
+		vector  = make([]float64, nbObjectives)
+		fill    func(m, left int)
+	)
+	fill = func(m, left int) {
+		if m == nbObjectives-1 {
+			vector[m] = float64(left) / float64(divisions)
+			var v = make([]float64, nbObjectives)
+			copy(v, vector)
+			vectors = append(vectors, v)
+			return
+		}
+		for k := 0; k <= left; k++ {
+			vector[m] = float64(k) / float64(divisions)
+			fill(m+1, left-k)
+		}
+	}
+	if nbObjectives > 0 && divisions > 0 {
+		fill(0, divisions)
+	}
+	return vectors
+}
+
+// ModNSGA3 implements the NSGA-III algorithm of Deb and Jain which is better
+// suited than NSGA-II to problems with four objectives or more, in which case
+// most individuals are non-dominated and the crowding distance doesn't
+// preserve diversity anymore. The population is replaced the same way as in
+// NSGA-II except that the last front is truncated by associating each
+// individual with the closest of a set of reference points and by picking
+// individuals from the least represented reference points. The reference
+// points are generated with WeightVectors and Divisions, the number of
+// individuals should be close to the number of reference points. The parents
+// are chosen at random. As with ModNSGA2 the fronts can account for
+// Preferences.
+type ModNSGA3 struct {
+	Divisions   int
+	Crossover   Crossover
+	Mutator     Mutator
+	MutRate     float64
+	Preferences *Preferences
+}
+
+// Apply NSGA-III to a population.
+func (mod ModNSGA3) Apply(pop *Population) {
+	var (
+		n      = len(pop.Individuals)
+		choose = func() Individual { return pop.Individuals[pop.rng.Intn(n)] }
+	)
+	var offsprings = breed(n, choose, mod.Crossover, mod.Mutator, mod.MutRate, pop.rng)
+	offsprings.Evaluate(pop.ff)
+	var (
+		indis = append(pop.Individuals[:n:n], offsprings...)
+		objs  = indis.Objectives()
+		next  []int
+		last  []int
+	)
+	for _, front := range constrainedSort(indis, objs, mod.Preferences.Dominates) {
+		if len(next)+len(front) > n {
+			last = front
+			break
+		}
+		next = append(next, front...)
+		if len(next) == n {
+			break
+		}
+	}
+	if last != nil {
+		next = append(next, mod.niche(objs, next, last, n-len(next), pop.rng)...)
+	}
+	pop.Individuals = make(Individuals, len(next))
+	for i, j := range next {
+		pop.Individuals[i] = indis[j]
+	}
+}
+
+// Choose k members of the last front based on the reference points the
+// already chosen individuals are associated with.
+func (mod ModNSGA3) niche(objs [][]float64, chosen, last []int, k int, rng *rand.Rand) []int {
+	var (
+		candidates   = append(append([]int{}, chosen...), last...)
+		normalized   = normalize(objs, candidates)
+		refs         = WeightVectors(len(objs[0]), mod.Divisions)
+		assoc, dists = associate(normalized, refs)
+		counts       = make([]int, len(refs))
+		members      = make([][]int, len(refs)) // Members of the last front associated with each reference point
+		excluded     = make([]bool, len(refs))
+		picked       []int
+	)
+	for i := range chosen {
+		counts[assoc[i]]++
+	}
+	for i := len(chosen); i < len(candidates); i++ {
+		members[assoc[i]] = append(members[assoc[i]], i)
+	}
+	for len(picked) < k {
+		// Find the least represented reference points
+		var (
+			min    = math.MaxInt64
+			minima []int
+		)
+		for j := range refs {
+			if excluded[j] {
+				continue
+			}
+			if counts[j] < min {
+				min = counts[j]
+				minima = minima[:0]
+			}
+			if counts[j] == min {
+				minima = append(minima, j)
+			}
+		}
+		var j = minima[rng.Intn(len(minima))]
+		if len(members[j]) == 0 {
+			excluded[j] = true
+			continue
+		}
+		// Pick the closest member if the reference point isn't represented,
+		// else pick a random member
+		var m = rng.Intn(len(members[j]))
+		if counts[j] == 0 {
+			for i := range members[j] {
+				if dists[members[j][i]] < dists[members[j][m]] {
+					m = i
+				}
+			}
+		}
+		picked = append(picked, candidates[members[j][m]])
+		members[j] = append(members[j][:m], members[j][m+1:]...)
+		counts[j]++
+	}
+	return picked
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModNSGA3) Validate() error {
+	// Check the number of divisions
+	if mod.Divisions < 1 {
+		return errors.New("'Divisions' should be higher or equal to 1")
+	}
+	// Check the crossover method presence
+	if mod.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if mod.Mutator != nil && (mod.MutRate < 0 || mod.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	return nil
+}
+
+// Normalize the objectives of the candidates so that the ideal point is the
+// origin and the intercepts of the hyperplane going through the extreme points
+// are equal to 1. If the hyperplane is degenerate the worst value of each
+// objective is used as intercept instead.
+func normalize(objs [][]float64, candidates []int) [][]float64 {
+	var (
+		nbObjs     = len(objs[0])
+		ideal      = make([]float64, nbObjs)
+		nadir      = make([]float64, nbObjs)
+		translated = make([][]float64, len(candidates))
+	)
+	for m := range ideal {
+		ideal[m] = math.Inf(1)
+		nadir[m] = math.Inf(-1)
+	}
+	for _, i := range candidates {
+		for m, obj := range objs[i] {
+			ideal[m] = math.Min(ideal[m], obj)
+		}
+	}
+	for c, i := range candidates {
+		translated[c] = make([]float64, nbObjs)
+		for m, obj := range objs[i] {
+			translated[c][m] = obj - ideal[m]
+			nadir[m] = math.Max(nadir[m], translated[c][m])
+		}
+	}
+	// Find the extreme point of each objective with the achievement
+	// scalarizing function
+	var extremes = make([][]float64, nbObjs)
+	for m := range extremes {
+		var best = math.Inf(1)
+		for _, t := range translated {
+			var asf float64
+			for i, obj := range t {
+				var w = 1e-6
+				if i == m {
+					w = 1
+				}
+				asf = math.Max(asf, obj/w)
+			}
+			if asf < best {
+				best = asf
+				extremes[m] = t
+			}
+		}
+	}
+	var axes = intercepts(extremes)
+	for m := range axes {
+		if axes[m] < 1e-10 {
+			axes = nil
+			break
+		}
+	}
+	if axes == nil {
+		axes = nadir
+	}
+	for _, t := range translated {
+		for m := range t {
+			if axes[m] > 0 {
+				t[m] /= axes[m]
+			}
+		}
+	}
+	return translated
+}
+
+// Compute the intercepts of the hyperplane going through the given points by
+// solving the linear system points * x = 1 with Gaussian elimination, the
+// intercepts are the inverses of the solution. Returns nil if the system is
+// singular.
+func intercepts(points [][]float64) []float64 {
+	var (
+		n = len(points)
+		a = make([][]float64, n)
+	)
+	for i, p := range points {
+		a[i] = append(append([]float64{}, p...), 1)
+	}
+	for col := 0; col < n; col++ {
+		var pivot = col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := 0; row < n; row++ {
+			if row == col {
+				continue
+			}
+			var f = a[row][col] / a[col][col]
+			for k := col; k <= n; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+		}
+	}
+	var x = make([]float64, n)
+	for i := range x {
+		x[i] = a[i][n] / a[i][i]
+		if x[i] == 0 {
+			return nil
+		}
+		x[i] = 1 / x[i]
+	}
+	return x
+}
+
+// Associate each normalized vector of objectives with the reference point
+// whose line is the closest, also returns the perpendicular distance to that
+// line.
+func associate(normalized, refs [][]float64) ([]int, []float64) {
+	var (
+		assoc = make([]int, len(normalized))
+		dists = make([]float64, len(normalized))
+	)
+	for i, obj := range normalized {
+		dists[i] = math.Inf(1)
+		for j, ref := range refs {
+			var dot, norm float64
+			for m := range ref {
+				dot += obj[m] * ref[m]
+				norm += ref[m] * ref[m]
+			}
+			var d float64
+			for m := range ref {
+				d += math.Pow(obj[m]-dot/norm*ref[m], 2)
+			}
+			if d < dists[i] {
+				assoc[i] = j
+				dists[i] = d
+			}
+		}
+		dists[i] = math.Sqrt(dists[i])
+	}
+	return assoc, dists
+}
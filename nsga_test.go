@@ -0,0 +1,172 @@
+package gago
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestConstrainedSort(t *testing.T) {
+	var (
+		indis = Individuals{
+			{Objectives: []float64{0, 0}, Violation: 2},
+			{Objectives: []float64{1, 1}},
+			{Objectives: []float64{2, 2}},
+			{Objectives: []float64{0, 1}, Violation: 1},
+			{Objectives: []float64{1, 0}, Violation: 1},
+		}
+		fronts   = constrainedSort(indis, indis.Objectives(), Dominates)
+		expected = [][]int{{1}, {2}, {3, 4}, {0}}
+	)
+	if !reflect.DeepEqual(fronts, expected) {
+		t.Errorf("Expected %v, got %v", expected, fronts)
+	}
+	if fronts = ConstrainedSort(indis, nil); !reflect.DeepEqual(fronts, expected) {
+		t.Errorf("Expected %v, got %v", expected, fronts)
+	}
+}
+
+func TestNonDominatedSort(t *testing.T) {
+	var (
+		objs = [][]float64{
+			{1, 1},
+			{0, 2},
+			{2, 2},
+			{2, 0},
+			{3, 3},
+		}
+		fronts = NonDominatedSort(objs, Dominates)
+		ranks  = []int{0, 0, 1, 0, 2}
+	)
+	if len(fronts) != 3 {
+		t.Errorf("Expected 3 fronts, got %d", len(fronts))
+	}
+	if !reflect.DeepEqual(NonDominatedSort(objs, nil), fronts) {
+		t.Error("Pareto dominance should be used if no dominance relation is given")
+	}
+	for rank, front := range fronts {
+		for _, i := range front {
+			if ranks[i] != rank {
+				t.Errorf("Vector %d should belong to front %d, not %d", i, ranks[i], rank)
+			}
+		}
+	}
+}
+
+func TestCrowdingDistance(t *testing.T) {
+	var (
+		objs      = [][]float64{{0, 4}, {1, 3}, {3, 1}, {4, 0}}
+		distances = CrowdingDistance(objs, []int{0, 1, 2, 3})
+	)
+	if !math.IsInf(distances[0], 1) || !math.IsInf(distances[3], 1) {
+		t.Error("The boundaries of the front should have an infinite crowding distance")
+	}
+	if distances[1] != 1.5 || distances[2] != 1.5 {
+		t.Errorf("Expected crowding distances of 1.5, got %v", distances[1:3])
+	}
+}
+
+func TestWeightVectors(t *testing.T) {
+	var testCases = []struct {
+		nbObjectives, divisions, n int
+	}{
+		{2, 4, 5},
+		{3, 4, 15},
+		{5, 3, 35},
+	}
+	for _, test := range testCases {
+		var vectors = WeightVectors(test.nbObjectives, test.divisions)
+		if len(vectors) != test.n {
+			t.Errorf("Expected %d vectors, got %d", test.n, len(vectors))
+		}
+		for _, v := range vectors {
+			if math.Abs(sum(v)-1) > 1e-10 {
+				t.Error("The coordinates of a weight vector should sum up to 1")
+			}
+		}
+	}
+}
+
+func TestIntercepts(t *testing.T) {
+	var axes = intercepts([][]float64{{2, 0, 0}, {0, 3, 0}, {0, 0, 4}})
+	for i, a := range []float64{2, 3, 4} {
+		if math.Abs(axes[i]-a) > 1e-10 {
+			t.Errorf("Expected intercepts %v, got %v", []float64{2, 3, 4}, axes)
+		}
+	}
+	if intercepts([][]float64{{1, 1}, {2, 2}}) != nil {
+		t.Error("Degenerate points shouldn't have intercepts")
+	}
+}
+
+// Run a multi-objective model on the DTLZ1-like linear front x1 + ... + xM = 1
+// and check the population ends up spread on the first front.
+func testNSGA(t *testing.T, model Model, nbObjectives int) {
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 40,
+		NbrGenes:       nbObjectives,
+		Ff: ObjectivesFunction{
+			Image: func(genome Genome) []float64 {
+				var (
+					objs  = make([]float64, nbObjectives)
+					total float64
+				)
+				for m, x := range genome {
+					objs[m] = math.Abs(x.(float64))
+					total += objs[m]
+				}
+				// Penalize the distance to the front
+				for m := range objs {
+					objs[m] += math.Abs(total - 1)
+				}
+				return objs
+			},
+		},
+		Initializer: InitUniformF{Lower: 0, Upper: 1},
+		Model:       model,
+	}
+	if err := ga.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ga.Initialize()
+	for i := 0; i < 30; i++ {
+		ga.Enhance()
+	}
+	var (
+		indis = ga.Populations[0].Individuals
+		front = NonDominatedSort(indis.Objectives(), Dominates)[0]
+	)
+	if len(indis) != 40 {
+		t.Errorf("Expected 40 individuals, got %d", len(indis))
+	}
+	if len(front) < 20 {
+		t.Errorf("Expected most individuals to be non-dominated, got %d", len(front))
+	}
+}
+
+func TestNSGA2(t *testing.T) {
+	testNSGA(t, ModNSGA2{
+		Crossover: CrossUniformF{},
+		Mutator:   MutNormalF{Rate: 0.5, Std: 0.1},
+		MutRate:   0.5,
+	}, 2)
+}
+
+func TestNSGA3(t *testing.T) {
+	testNSGA(t, ModNSGA3{
+		Divisions: 3,
+		Crossover: CrossUniformF{},
+		Mutator:   MutNormalF{Rate: 0.5, Std: 0.1},
+		MutRate:   0.5,
+	}, 4)
+}
+
+func TestNSGAValidate(t *testing.T) {
+	if (ModNSGA2{}).Validate() == nil {
+		t.Error("NSGA-II should require a crossover")
+	}
+	if (ModNSGA3{Crossover: CrossUniformF{}}).Validate() == nil {
+		t.Error("NSGA-III should require a number of divisions")
+	}
+}
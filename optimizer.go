@@ -0,0 +1,170 @@
+package gago
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// An Optimizer minimizes a fitness function until one of the termination
+// criteria of opts is met, it returns the final statistics and the reason why
+// it stopped in the same way as GA.Run. Best returns the best individual found
+// so far. The GA implements Optimizer, and so do the RandomSearch and
+// LHSSearch baselines, which allows checking that a GA configuration beats
+// trivial baselines under the same evaluation budget and with the same
+// statistics.
+type Optimizer interface {
+	Optimize(ctx context.Context, opts RunOptions) (Stats, TerminationReason, error)
+	Best() Individual
+}
+
+// Optimize initializes the GA and then runs it, see Run.
+func (ga *GA) Optimize(ctx context.Context, opts RunOptions) (Stats, TerminationReason, error) {
+	if err := ga.Validate(); err != nil {
+		return Stats{}, Failed, err
+	}
+	ga.Initialize()
+	return ga.Run(ctx, opts)
+}
+
+// A model that replaces the individuals of a population with new individuals
+// generated by an initializer, which turns a GA into a sampler.
+type modSample struct {
+	initializer Initializer
+	nbGenes     int
+}
+
+// Apply the sampling model to a population.
+func (mod modSample) Apply(pop *Population) {
+	for i := range pop.Individuals {
+		var indi = makeIndividual(mod.nbGenes, pop.rng)
+		mod.initializer.Apply(&indi, pop.rng)
+		pop.Individuals[i] = indi
+	}
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod modSample) Validate() error {
+	return nil
+}
+
+// RandomSearch is the simplest baseline: it evaluates batches of BatchSize
+// genomes, 100 if BatchSize is 0, generated independently by Initializer and
+// keeps the best one. Each batch counts as a generation, hence the termination
+// criteria and the statistics mean the same as for a GA with a single
+// population of BatchSize individuals; the evaluation budget is checked
+// between the batches as Run checks it between the generations. The batches
+// are drawn from Seed if it isn't 0. RandomSearch keeps the state of the last
+// search, hence it has to be used through a pointer.
+type RandomSearch struct {
+	NbrGenes    int
+	Initializer Initializer
+	Ff          FitnessFunction
+	Comparator  Comparator
+	BatchSize   int
+	Seed        int64
+	ga          *GA
+}
+
+// Return the GA that samples the batches.
+func (rs *RandomSearch) newGA() *GA {
+	var batchSize = rs.BatchSize
+	if batchSize == 0 {
+		batchSize = 100
+	}
+	return &GA{
+		NbrPopulations: 1,
+		NbrIndividuals: batchSize,
+		NbrGenes:       rs.NbrGenes,
+		Initializer:    rs.Initializer,
+		Ff:             rs.Ff,
+		Comparator:     rs.Comparator,
+		Model:          modSample{rs.Initializer, rs.NbrGenes},
+		Seed:           rs.Seed,
+	}
+}
+
+// Validate the parameters of the search.
+func (rs *RandomSearch) Validate() error {
+	// Check the batch size
+	if rs.BatchSize < 0 {
+		return errors.New("'BatchSize' should be higher or equal to 0")
+	}
+	return rs.newGA().Validate()
+}
+
+// Optimize samples batches of genomes until one of the termination criteria is
+// met.
+func (rs *RandomSearch) Optimize(ctx context.Context, opts RunOptions) (Stats, TerminationReason, error) {
+	if err := rs.Validate(); err != nil {
+		return Stats{}, Failed, err
+	}
+	rs.ga = rs.newGA()
+	return rs.ga.Optimize(ctx, opts)
+}
+
+// Best returns the best individual found by the last search, an individual
+// with an infinite fitness is returned if there was no search.
+func (rs *RandomSearch) Best() Individual {
+	if rs.ga == nil {
+		return Individual{Fitness: math.Inf(1)}
+	}
+	return rs.ga.Best()
+}
+
+// LHSSearch is a baseline that works like RandomSearch except that each batch
+// of genomes is a Latin hypercube sample of [Lower, Upper) for each gene, see
+// InitLatinHypercubeF. The batches cover the search space more evenly than
+// uniform samples. LHSSearch keeps the state of the last search, hence it has
+// to be used through a pointer.
+type LHSSearch struct {
+	NbrGenes     int
+	Lower, Upper float64
+	Ff           FitnessFunction
+	Comparator   Comparator
+	BatchSize    int
+	Seed         int64
+	search       RandomSearch
+}
+
+// Return the random search that samples the Latin hypercubes.
+func (ls *LHSSearch) randomSearch() RandomSearch {
+	var batchSize = ls.BatchSize
+	if batchSize == 0 {
+		batchSize = 100
+	}
+	return RandomSearch{
+		NbrGenes:    ls.NbrGenes,
+		Initializer: &InitLatinHypercubeF{Lower: ls.Lower, Upper: ls.Upper, Size: batchSize},
+		Ff:          ls.Ff,
+		Comparator:  ls.Comparator,
+		BatchSize:   ls.BatchSize,
+		Seed:        ls.Seed,
+	}
+}
+
+// Validate the parameters of the search.
+func (ls *LHSSearch) Validate() error {
+	// Check the bounds
+	if ls.Lower >= ls.Upper {
+		return errors.New("'Lower' should be lower than 'Upper'")
+	}
+	var rs = ls.randomSearch()
+	return rs.Validate()
+}
+
+// Optimize samples Latin hypercubes until one of the termination criteria is
+// met.
+func (ls *LHSSearch) Optimize(ctx context.Context, opts RunOptions) (Stats, TerminationReason, error) {
+	if err := ls.Validate(); err != nil {
+		return Stats{}, Failed, err
+	}
+	ls.search = ls.randomSearch()
+	return ls.search.Optimize(ctx, opts)
+}
+
+// Best returns the best individual found by the last search, an individual
+// with an infinite fitness is returned if there was no search.
+func (ls *LHSSearch) Best() Individual {
+	return ls.search.Best()
+}
@@ -0,0 +1,98 @@
+package gago
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBaselines(t *testing.T) {
+	var (
+		opts      = RunOptions{MaxEvaluations: 1000}
+		baselines = []func() Optimizer{
+			func() Optimizer {
+				return &RandomSearch{NbrGenes: 3, Initializer: InitUniformF{Lower: -5, Upper: 5}, Ff: sphere, BatchSize: 50, Seed: 42}
+			},
+			func() Optimizer {
+				return &LHSSearch{NbrGenes: 3, Lower: -5, Upper: 5, Ff: sphere, BatchSize: 50, Seed: 42}
+			},
+		}
+	)
+	for _, newOptimizer := range baselines {
+		var opt = newOptimizer()
+		if best := opt.Best(); best.Fitness < 1e300 {
+			t.Errorf("%T: there should be no best individual before the search", opt)
+		}
+		var stats, reason, err = opt.Optimize(context.Background(), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reason != EvaluationsReached || stats.Evaluations != 1000 || stats.Generations != 19 {
+			t.Errorf("%T: expected 1000 evaluations in 20 batches, got %d evaluations in %d generations (%s)", opt, stats.Evaluations, stats.Generations, reason)
+		}
+		var best = opt.Best()
+		if best.Fitness != stats.Best || best.Fitness != sphere.Image([]float64{best.Genome[0].(float64), best.Genome[1].(float64), best.Genome[2].(float64)}) {
+			t.Errorf("%T: the best individual doesn't match the statistics", opt)
+		}
+		// The search is reproducible
+		var other = newOptimizer()
+		other.Optimize(context.Background(), opts)
+		if other.Best().Fitness != best.Fitness {
+			t.Errorf("%T: searches with the same seed should find the same best individual", opt)
+		}
+	}
+}
+
+func TestBaselinesValidate(t *testing.T) {
+	var (
+		optimizers = []Optimizer{
+			&RandomSearch{NbrGenes: 3, Initializer: InitUniformF{Lower: -5, Upper: 5}, Ff: sphere, BatchSize: -1},
+			&RandomSearch{NbrGenes: 3, Ff: sphere},
+			&LHSSearch{NbrGenes: 3, Lower: 5, Upper: -5, Ff: sphere},
+			&LHSSearch{NbrGenes: 3, Lower: -5, Upper: 5},
+		}
+	)
+	for _, opt := range optimizers {
+		if _, reason, err := opt.Optimize(context.Background(), RunOptions{MaxEvaluations: 100}); err == nil || reason != Failed {
+			t.Errorf("%T: expected an error", opt)
+		}
+	}
+}
+
+func TestGABeatsBaselines(t *testing.T) {
+	var (
+		opts = RunOptions{MaxEvaluations: 5000}
+		ga   = &GA{
+			NbrPopulations: 1,
+			NbrIndividuals: 50,
+			NbrGenes:       10,
+			Ff:             sphere,
+			Initializer:    InitUniformF{Lower: -5, Upper: 5},
+			Model: ModGenerational{
+				Selector:  SelTournament{NbParticipants: 3},
+				Crossover: CrossUniformF{},
+				Mutator:   MutGaussianF{Rate: 0.5, Std: 0.1},
+				MutRate:   0.5,
+			},
+			Seed: 42,
+		}
+		optimizers = []Optimizer{
+			ga,
+			&RandomSearch{NbrGenes: 10, Initializer: InitUniformF{Lower: -5, Upper: 5}, Ff: sphere, BatchSize: 50, Seed: 42},
+			&LHSSearch{NbrGenes: 10, Lower: -5, Upper: 5, Ff: sphere, BatchSize: 50, Seed: 42},
+		}
+		bests = make([]float64, len(optimizers))
+	)
+	for i, opt := range optimizers {
+		var stats, _, err = opt.Optimize(context.Background(), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Evaluations != opts.MaxEvaluations {
+			t.Errorf("%T: expected %d evaluations, got %d", opt, opts.MaxEvaluations, stats.Evaluations)
+		}
+		bests[i] = stats.Best
+	}
+	if bests[0] >= bests[1] || bests[0] >= bests[2] {
+		t.Errorf("The GA should beat the baselines, got %v", bests)
+	}
+}
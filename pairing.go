@@ -0,0 +1,148 @@
+package gago
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// A Pairer decides which of the selected parents mate with each other, which
+// decouples the pairing of the parents from their selection. Apply returns
+// pairs of indexes of parents, each parent belongs to a single pair and there
+// are len(parents)/2 pairs. The models that accept a Pairer select the parents
+// of a generation at once and then pair them, otherwise the parents are
+// selected and paired two at a time.
+type Pairer interface {
+	Apply(parents Individuals, rng *rand.Rand) [][2]int
+}
+
+// PairAdjacent pairs each parent with the next one, in the order they were
+// selected in.
+type PairAdjacent struct{}
+
+// Apply adjacent pairing.
+func (pairer PairAdjacent) Apply(parents Individuals, rng *rand.Rand) [][2]int {
+	var pairs = make([][2]int, len(parents)/2)
+	for i := range pairs {
+		pairs[i] = [2]int{2 * i, 2*i + 1}
+	}
+	return pairs
+}
+
+// PairRandom pairs the parents at random.
+type PairRandom struct{}
+
+// Apply random pairing.
+func (pairer PairRandom) Apply(parents Individuals, rng *rand.Rand) [][2]int {
+	var (
+		perm  = rng.Perm(len(parents))
+		pairs = make([][2]int, len(parents)/2)
+	)
+	for i := range pairs {
+		pairs[i] = [2]int{perm[2*i], perm[2*i+1]}
+	}
+	return pairs
+}
+
+// PairBestWorst sorts the parents by fitness and pairs the best parent with
+// the worst one, the second best with the second worst, and so on. Mixing good
+// and bad parents slows down the convergence of the population.
+type PairBestWorst struct{}
+
+// Apply best-with-worst pairing.
+func (pairer PairBestWorst) Apply(parents Individuals, rng *rand.Rand) [][2]int {
+	var order = make([]int, len(parents))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return lessFitness(parents[order[i]].Fitness, parents[order[j]].Fitness)
+	})
+	var pairs = make([][2]int, len(parents)/2)
+	for i := range pairs {
+		pairs[i] = [2]int{order[i], order[len(order)-1-i]}
+	}
+	return pairs
+}
+
+// PairDistance pairs the parents according to the distance between them. The
+// parents are visited in a random order and each parent that isn't paired yet
+// is paired with the closest parent that isn't paired yet according to Metric.
+// If Dissimilar is true the farthest parent is chosen instead. Unlike
+// SelAssortative the parents are chosen by the selector regardless of their
+// similarity, only the pairs change.
+type PairDistance struct {
+	Metric     DistanceMetric
+	Dissimilar bool
+}
+
+// Apply distance based pairing.
+func (pairer PairDistance) Apply(parents Individuals, rng *rand.Rand) [][2]int {
+	var (
+		order  = rng.Perm(len(parents))
+		paired = make([]bool, len(parents))
+		pairs  = make([][2]int, 0, len(parents)/2)
+	)
+	for k, i := range order {
+		if paired[i] || len(pairs) == cap(pairs) {
+			continue
+		}
+		var (
+			mate     = -1
+			mateDist float64
+		)
+		for _, j := range order[k+1:] {
+			if paired[j] {
+				continue
+			}
+			var dist = pairer.Metric.Apply(parents[i], parents[j])
+			if mate == -1 || (!pairer.Dissimilar && dist < mateDist) || (pairer.Dissimilar && dist > mateDist) {
+				mate, mateDist = j, dist
+			}
+		}
+		paired[i], paired[mate] = true, true
+		pairs = append(pairs, [2]int{i, mate})
+	}
+	return pairs
+}
+
+// Select the parents of n offsprings at once and pair them with a Pairer.
+func pairParents(n int, indis Individuals, sel Selector, pairer Pairer, rng *rand.Rand) [][2]Individual {
+	var (
+		parents, _ = sel.Apply(n+n%2, indis, rng)
+		pairs      = pairer.Apply(parents, rng)
+		couples    = make([][2]Individual, len(pairs))
+	)
+	for i, pair := range pairs {
+		couples[i] = [2]Individual{parents[pair[0]], parents[pair[1]]}
+	}
+	return couples
+}
+
+// generatePairedOffsprings is the same as generateOffsprings except that the
+// parents are paired by a Pairer.
+func generatePairedOffsprings(n int, indis Individuals, sel Selector, pairer Pairer, cross Crossover, rng *rand.Rand) Individuals {
+	var (
+		offsprings = make(Individuals, 0, n+n%2)
+		couples    = pairParents(n, indis, sel, pairer, rng)
+	)
+	for i := 0; len(offsprings) < n; i++ {
+		var o1, o2 = cross.Apply(couples[i][0], couples[i][1], rng)
+		offsprings = append(offsprings, o1, o2)
+	}
+	return offsprings[:n]
+}
+
+// generatePairedOffspringsInto is the same as generateOffspringsInto except
+// that the parents are paired by a Pairer.
+func generatePairedOffspringsInto(offsprings, indis Individuals, sel Selector, pairer Pairer, cross CrossoverInto, rng *rand.Rand) {
+	var couples = pairParents(len(offsprings), indis, sel, pairer, rng)
+	for i := 0; i < len(offsprings); i += 2 {
+		var p1, p2 = couples[i/2][0], couples[i/2][1]
+		if i+1 < len(offsprings) {
+			cross.ApplyInto(p1, p2, &offsprings[i], &offsprings[i+1], rng)
+		} else {
+			var extra Individual
+			cross.ApplyInto(p1, p2, &offsprings[i], &extra, rng)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// Check that the pairs cover each parent at most once.
+func checkPairs(t *testing.T, pairs [][2]int, n int) {
+	if len(pairs) != n/2 {
+		t.Errorf("Expected %d pairs, got %d", n/2, len(pairs))
+	}
+	var seen = make(map[int]bool)
+	for _, pair := range pairs {
+		for _, i := range pair {
+			if i < 0 || i >= n || seen[i] {
+				t.Errorf("Parent %d is paired more than once or doesn't exist", i)
+			}
+			seen[i] = true
+		}
+	}
+}
+
+func TestPairers(t *testing.T) {
+	var (
+		rng     = rand.New(rand.NewSource(time.Now().UnixNano()))
+		pairers = []Pairer{
+			PairAdjacent{},
+			PairRandom{},
+			PairBestWorst{},
+			PairDistance{Metric: DistEuclidean{}},
+			PairDistance{Metric: DistEuclidean{}, Dissimilar: true},
+		}
+	)
+	for _, pairer := range pairers {
+		for _, n := range []int{0, 1, 2, 7, 10} {
+			var parents = makeIndividuals(n, 2, rng)
+			for i := range parents {
+				InitUniformF{Lower: -1, Upper: 1}.Apply(&parents[i], rng)
+				parents[i].Fitness = rng.Float64()
+			}
+			checkPairs(t, pairer.Apply(parents, rng), n)
+		}
+	}
+}
+
+func TestPairBestWorst(t *testing.T) {
+	var parents = Individuals{
+		Individual{Fitness: 3},
+		Individual{Fitness: 0},
+		Individual{Fitness: 2},
+		Individual{Fitness: 1},
+	}
+	var pairs = PairBestWorst{}.Apply(parents, nil)
+	if pairs[0] != [2]int{1, 0} || pairs[1] != [2]int{3, 2} {
+		t.Errorf("Wrong pairs: %v", pairs)
+	}
+}
+
+func TestPairDistance(t *testing.T) {
+	var (
+		rng     = rand.New(rand.NewSource(42))
+		parents = Individuals{
+			Individual{Genome: Genome{0.0}},
+			Individual{Genome: Genome{10.0}},
+			Individual{Genome: Genome{0.1}},
+			Individual{Genome: Genome{10.1}},
+		}
+		mates = func(pairs [][2]int) map[int]int {
+			var m = make(map[int]int)
+			for _, pair := range pairs {
+				m[pair[0]], m[pair[1]] = pair[1], pair[0]
+			}
+			return m
+		}
+	)
+	var similar = mates(PairDistance{Metric: DistEuclidean{}}.Apply(parents, rng))
+	if similar[0] != 2 || similar[1] != 3 {
+		t.Errorf("The closest parents should be paired, got %v", similar)
+	}
+	var dissimilar = mates(PairDistance{Metric: DistEuclidean{}, Dissimilar: true}.Apply(parents, rng))
+	if dissimilar[0] == 2 || dissimilar[1] == 3 {
+		t.Errorf("The farthest parents should be paired, got %v", dissimilar)
+	}
+}
+
+func TestGeneratePairedOffsprings(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indis = makeIndividuals(10, 2, rng)
+		sel   = SelTournament{NbParticipants: 3}
+		cross = CrossUniformF{}
+	)
+	for i := range indis {
+		InitUniformF{Lower: -1, Upper: 1}.Apply(&indis[i], rng)
+	}
+	for _, n := range []int{0, 1, 3, 10} {
+		if offsprings := generatePairedOffsprings(n, indis, sel, PairRandom{}, cross, rng); len(offsprings) != n {
+			t.Error("generatePairedOffsprings didn't produce the expected number of offsprings")
+		}
+		var offsprings = makeIndividuals(n, 2, rng)
+		generatePairedOffspringsInto(offsprings, indis, sel, PairBestWorst{}, cross, rng)
+		for _, offspring := range offsprings {
+			if _, ok := offspring.Genome[0].(float64); !ok {
+				t.Error("generatePairedOffspringsInto didn't fill every offspring")
+			}
+		}
+	}
+}
+
+func TestModGenerationalPairer(t *testing.T) {
+	for _, reuse := range []bool{false, true} {
+		var (
+			pop = makePopulation(11, 2, ff, initializer)
+			mod = ModGenerational{
+				Selector:  SelTournament{NbParticipants: 3},
+				Pairer:    PairDistance{Metric: DistEuclidean{}},
+				Crossover: CrossUniformF{},
+				Mutator:   MutNormalF{Rate: 0.5, Std: 1},
+				MutRate:   0.5,
+				Reuse:     reuse,
+			}
+		)
+		mod.Apply(&pop)
+		if len(pop.Individuals) != 11 {
+			t.Errorf("Expected 11 individuals, got %d", len(pop.Individuals))
+		}
+	}
+}
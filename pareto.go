@@ -0,0 +1,381 @@
+package gago
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Return the objectives of an individual, an individual that wasn't evaluated
+// by an ObjectivesFunction has a single objective which is it's fitness.
+func objectives(indi Individual) []float64 {
+	if indi.Objectives == nil {
+		return []float64{indi.Fitness}
+	}
+	return indi.Objectives
+}
+
+// Dominates checks if a vector of objectives a Pareto dominates a vector of
+// objectives b, meaning a is lower than or equal to b on every objective and
+// strictly lower on at least one objective.
+func Dominates(a, b []float64) bool {
+	var strict = false
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			strict = true
+		}
+	}
+	return strict
+}
+
+// CompareDominance returns -1 if a Pareto dominates b, 1 if b Pareto dominates
+// a and 0 if neither dominates the other.
+func CompareDominance(a, b []float64) int {
+	switch {
+	case Dominates(a, b):
+		return -1
+	case Dominates(b, a):
+		return 1
+	}
+	return 0
+}
+
+// ConstrainedDominates checks if an individual a dominates an individual b
+// according to the constrained dominance of Deb et al.: a feasible individual
+// dominates an infeasible one, an infeasible individual dominates the
+// individuals that violate the constraints more and feasible individuals are
+// compared on their objectives with dominates, Pareto dominance being used if
+// it's nil.
+func ConstrainedDominates(a, b Individual, dominates func(a, b []float64) bool) bool {
+	if dominates == nil {
+		dominates = Dominates
+	}
+	switch {
+	case a.Feasible() && b.Feasible():
+		return dominates(objectives(a), objectives(b))
+	case a.Feasible():
+		return true
+	case b.Feasible():
+		return false
+	}
+	return a.Violation < b.Violation
+}
+
+// Compute the box of a vector of objectives on a grid of size epsilon.
+func box(objs []float64, epsilon float64) []float64 {
+	var b = make([]float64, len(objs))
+	for i, obj := range objs {
+		b[i] = math.Floor(obj / epsilon)
+	}
+	return b
+}
+
+// Check if two boxes are the same.
+func sameBox(a, b []float64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Compute the distance between a vector of objectives and the lower corner of
+// it's box.
+func cornerDistance(objs, b []float64, epsilon float64) float64 {
+	var d float64
+	for i := range objs {
+		d += math.Pow(objs[i]-b[i]*epsilon, 2)
+	}
+	return d
+}
+
+// A ParetoArchive keeps track of the non-dominated individuals found during a
+// multi-objective run. It uses epsilon-dominance: the objective space is split
+// into boxes of size Epsilon, each box holds at most one individual and an
+// individual is only accepted if no archived individual lies in a box that
+// dominates it's box. Hence the archive is bounded in size and the front it
+// reports is evenly spread, the larger Epsilon is the smaller the archive is.
+// An Epsilon of 0 keeps every non-dominated individual. A ParetoArchive is safe
+// for concurrent use and has to be used through a pointer.
+//
+// ReferencePoint and ReferenceFront are optional, they are used to compute the
+// hypervolume and the IGD of the archived front that are reported in the
+// statistics of the GA.
+//
+// Store is optional, if it's set the genomes and the cases of the archived
+// individuals are kept in it instead of in memory, see ArchiveStore.
+// Individuals whose genes can't be encoded and individuals whose genome is
+// already archived are then ignored. Restore reads the archived individuals
+// back when a run is resumed.
+type ParetoArchive struct {
+	Epsilon        float64
+	ReferencePoint []float64
+	ReferenceFront [][]float64
+	Store          ArchiveStore
+	mu             sync.Mutex
+	members        Individuals
+	boxes          [][]float64
+	keys           []string // Hash of the genome of each member with a Store
+	err            error    // First error returned by Store
+}
+
+// Add an individual to the archive if it isn't epsilon-dominated, the archived
+// individuals it epsilon-dominates are removed. Returns true if the individual
+// was added. Individuals that haven't been evaluated are ignored.
+func (archive *ParetoArchive) Add(indi Individual) bool {
+	if !indi.Evaluated {
+		return false
+	}
+	archive.mu.Lock()
+	defer archive.mu.Unlock()
+	var (
+		objs   = objectives(indi)
+		b      = objs
+		key    string
+		member = copyIndividual(indi)
+	)
+	if archive.Epsilon > 0 {
+		b = box(objs, archive.Epsilon)
+	}
+	if archive.Store != nil {
+		if key = storeKey(indi.Genome); key == "" {
+			return false
+		}
+		for _, k := range archive.keys {
+			if k == key {
+				return false
+			}
+		}
+		member = stubIndividual(indi)
+	}
+	for i, m := range archive.members {
+		var mObjs = objectives(m)
+		if sameBox(archive.boxes[i], b) {
+			// Only one individual per box, the one that dominates or the one
+			// closest to the corner of the box is kept
+			if Dominates(objs, mObjs) || (!Dominates(mObjs, objs) && archive.Epsilon > 0 &&
+				cornerDistance(objs, b, archive.Epsilon) < cornerDistance(mObjs, b, archive.Epsilon)) {
+				if !archive.put(key, indi) {
+					return false
+				}
+				archive.delete(archive.keys[i])
+				archive.members[i], archive.keys[i] = member, key
+				return true
+			}
+			return false
+		}
+		if Dominates(archive.boxes[i], b) {
+			return false
+		}
+	}
+	if !archive.put(key, indi) {
+		return false
+	}
+	// Remove the individuals whose box is dominated by the new individual's box
+	var (
+		members = archive.members[:0]
+		boxes   = archive.boxes[:0]
+		keys    = archive.keys[:0]
+	)
+	for i, m := range archive.members {
+		if !Dominates(b, archive.boxes[i]) {
+			members = append(members, m)
+			boxes = append(boxes, archive.boxes[i])
+			keys = append(keys, archive.keys[i])
+		} else {
+			archive.delete(archive.keys[i])
+		}
+	}
+	archive.members = append(members, member)
+	archive.boxes = append(boxes, b)
+	archive.keys = append(keys, key)
+	return true
+}
+
+// Put an individual in the store if there is one, returns false if the store
+// failed.
+func (archive *ParetoArchive) put(key string, indi Individual) bool {
+	if archive.Store == nil {
+		return true
+	}
+	if err := archive.Store.Put(key, indi); err != nil {
+		archive.fail(err)
+		return false
+	}
+	return true
+}
+
+// Delete an individual from the store if there is one.
+func (archive *ParetoArchive) delete(key string) {
+	if archive.Store == nil {
+		return
+	}
+	if err := archive.Store.Delete(key); err != nil {
+		archive.fail(err)
+	}
+}
+
+// Record the first error returned by the store.
+func (archive *ParetoArchive) fail(err error) {
+	if archive.err == nil {
+		archive.err = err
+	}
+}
+
+// Update adds each individual in a slice of individuals to the archive.
+func (archive *ParetoArchive) Update(indis Individuals) {
+	for _, indi := range indis {
+		archive.Add(indi)
+	}
+}
+
+// Front returns a copy of the archived individuals sorted by their first
+// objective. With a Store the individuals are read from it, the individuals
+// that can't be read are left out, see Err.
+func (archive *ParetoArchive) Front() Individuals {
+	archive.mu.Lock()
+	defer archive.mu.Unlock()
+	var front = archive.copyMembers()
+	sort.SliceStable(front, func(i, j int) bool {
+		return objectives(front[i])[0] < objectives(front[j])[0]
+	})
+	return front
+}
+
+// Return a copy of the members, the lock has to be held.
+func (archive *ParetoArchive) copyMembers() Individuals {
+	var members = make(Individuals, 0, len(archive.members))
+	for i, member := range archive.members {
+		if archive.Store == nil {
+			members = append(members, copyIndividual(member))
+			continue
+		}
+		var indi, err = loadMember(archive.Store, archive.keys[i], member)
+		if err != nil {
+			archive.fail(err)
+			continue
+		}
+		members = append(members, indi)
+	}
+	return members
+}
+
+// Len returns the number of archived individuals.
+func (archive *ParetoArchive) Len() int {
+	archive.mu.Lock()
+	defer archive.mu.Unlock()
+	return len(archive.members)
+}
+
+// Err returns the first error returned by Store, nil is returned if there is
+// no Store or if it never failed. An individual that couldn't be put in Store
+// isn't added to the archive.
+func (archive *ParetoArchive) Err() error {
+	archive.mu.Lock()
+	defer archive.mu.Unlock()
+	return archive.err
+}
+
+// Restore replaces the archived individuals with the individuals held by
+// Store, which is useful to resume a run that was interrupted. The individuals
+// are added to the archive one after the other, hence the individuals that
+// are epsilon-dominated are deleted from Store. The IDs, birth generations and
+// origins of the archived individuals aren't restored.
+func (archive *ParetoArchive) Restore() error {
+	if archive.Store == nil {
+		return errors.New("'Store' should be set")
+	}
+	var keys, err = archive.Store.Keys()
+	if err != nil {
+		return err
+	}
+	var indis = make(Individuals, len(keys))
+	for i, key := range keys {
+		if indis[i], err = archive.Store.Get(key); err != nil {
+			return err
+		}
+	}
+	// Rebuild the archive in memory and then delete the individuals that
+	// weren't kept
+	var rebuilt = &ParetoArchive{Epsilon: archive.Epsilon}
+	rebuilt.Update(indis)
+	var kept = make(map[string]bool)
+	archive.mu.Lock()
+	defer archive.mu.Unlock()
+	archive.members, archive.boxes, archive.keys = nil, nil, nil
+	for i, member := range rebuilt.members {
+		var key = storeKey(member.Genome)
+		kept[key] = true
+		archive.members = append(archive.members, stubIndividual(member))
+		archive.boxes = append(archive.boxes, rebuilt.boxes[i])
+		archive.keys = append(archive.keys, key)
+	}
+	for _, key := range keys {
+		if !kept[key] {
+			if err = archive.Store.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Return a copy of the archive that doesn't share it's members. The copy of an
+// archive backed by a store keeps it's members in memory.
+func (archive *ParetoArchive) clone() *ParetoArchive {
+	archive.mu.Lock()
+	defer archive.mu.Unlock()
+	var clone = &ParetoArchive{
+		Epsilon:        archive.Epsilon,
+		ReferencePoint: archive.ReferencePoint,
+		ReferenceFront: archive.ReferenceFront,
+	}
+	for i, member := range archive.members {
+		if archive.Store != nil {
+			var err error
+			if member, err = loadMember(archive.Store, archive.keys[i], member); err != nil {
+				archive.fail(err)
+				continue
+			}
+		}
+		clone.members = append(clone.members, copyIndividual(member))
+		clone.boxes = append(clone.boxes, append([]float64(nil), archive.boxes[i]...))
+		clone.keys = append(clone.keys, "")
+	}
+	return clone
+}
+
+// Copy an individual so that it doesn't share it's genome with the original.
+func copyIndividual(indi Individual) Individual {
+	var genome = make(Genome, len(indi.Genome))
+	copy(genome, indi.Genome)
+	indi.Genome = genome
+	return indi
+}
+
+// Hypervolume returns the hypervolume of the archived front with regard to the
+// reference point of the archive, it returns 0 if there is no reference point.
+func (archive *ParetoArchive) Hypervolume() float64 {
+	if archive.ReferencePoint == nil {
+		return 0
+	}
+	archive.mu.Lock()
+	defer archive.mu.Unlock()
+	return Hypervolume(archive.members.Objectives(), archive.ReferencePoint)
+}
+
+// IGD returns the IGD of the archived front with regard to the reference front
+// of the archive, it returns 0 if there is no reference front.
+func (archive *ParetoArchive) IGD() float64 {
+	if archive.ReferenceFront == nil {
+		return 0
+	}
+	archive.mu.Lock()
+	defer archive.mu.Unlock()
+	return IGD(archive.members.Objectives(), archive.ReferenceFront)
+}
@@ -0,0 +1,145 @@
+package gago
+
+import (
+	"testing"
+)
+
+func TestDominates(t *testing.T) {
+	var testCases = []struct {
+		a, b      []float64
+		dominates bool
+	}{
+		{[]float64{0, 0}, []float64{1, 1}, true},
+		{[]float64{0, 1}, []float64{1, 1}, true},
+		{[]float64{1, 1}, []float64{1, 1}, false},
+		{[]float64{0, 2}, []float64{1, 1}, false},
+		{[]float64{1, 1}, []float64{0, 0}, false},
+	}
+	for _, test := range testCases {
+		if Dominates(test.a, test.b) != test.dominates {
+			t.Errorf("Dominates(%v, %v) should be %t", test.a, test.b, test.dominates)
+		}
+	}
+}
+
+func makeObjectives(objs ...float64) Individual {
+	return Individual{Genome: Genome{objs[0]}, Objectives: objs, Evaluated: true}
+}
+
+func TestCompareDominance(t *testing.T) {
+	var testCases = []struct {
+		a, b []float64
+		cmp  int
+	}{
+		{[]float64{0, 0}, []float64{1, 1}, -1},
+		{[]float64{1, 1}, []float64{0, 1}, 1},
+		{[]float64{0, 1}, []float64{1, 0}, 0},
+		{[]float64{1, 1}, []float64{1, 1}, 0},
+	}
+	for i, tc := range testCases {
+		if cmp := CompareDominance(tc.a, tc.b); cmp != tc.cmp {
+			t.Errorf("Test %d: expected %d, got %d", i, tc.cmp, cmp)
+		}
+	}
+}
+
+func TestConstrainedDominates(t *testing.T) {
+	var (
+		feasible   = Individual{Objectives: []float64{2, 2}}
+		better     = Individual{Objectives: []float64{1, 1}}
+		infeasible = Individual{Objectives: []float64{0, 0}, Violation: 1}
+		worse      = Individual{Objectives: []float64{0, 0}, Violation: 2}
+	)
+	if !ConstrainedDominates(better, feasible, nil) || ConstrainedDominates(feasible, better, nil) {
+		t.Error("Feasible individuals should be compared on their objectives")
+	}
+	if !ConstrainedDominates(feasible, infeasible, nil) || ConstrainedDominates(infeasible, feasible, nil) {
+		t.Error("A feasible individual should dominate an infeasible one")
+	}
+	if !ConstrainedDominates(infeasible, worse, nil) || ConstrainedDominates(worse, infeasible, nil) {
+		t.Error("Infeasible individuals should be compared on their violation")
+	}
+}
+
+func TestParetoArchiveNoEpsilon(t *testing.T) {
+	var archive = &ParetoArchive{}
+	if !archive.Add(makeObjectives(1, 3)) || !archive.Add(makeObjectives(3, 1)) {
+		t.Error("Non-dominated individuals should be added")
+	}
+	if archive.Add(makeObjectives(4, 4)) {
+		t.Error("A dominated individual shouldn't be added")
+	}
+	if archive.Add(Individual{Genome: Genome{0.0}, Objectives: []float64{0, 0}}) {
+		t.Error("An unevaluated individual shouldn't be added")
+	}
+	if !archive.Add(makeObjectives(2, 0.5)) {
+		t.Error("A dominating individual should be added")
+	}
+	var front = archive.Front()
+	if len(front) != 2 {
+		t.Errorf("Expected 2 archived individuals, got %d", len(front))
+	}
+	if front[0].Objectives[0] != 1 || front[1].Objectives[0] != 2 {
+		t.Error("The front should be sorted by the first objective")
+	}
+	// The front is a copy
+	front[0].Genome[0] = 42.0
+	if archive.Front()[0].Genome[0] == 42.0 {
+		t.Error("Modifying the front shouldn't modify the archive")
+	}
+}
+
+func TestParetoArchiveEpsilon(t *testing.T) {
+	var archive = &ParetoArchive{Epsilon: 1}
+	archive.Add(makeObjectives(0.5, 0.5))
+	// Same box but closer to the corner
+	if !archive.Add(makeObjectives(0.2, 0.6)) {
+		t.Error("An individual closer to the corner of the box should replace the archived one")
+	}
+	// Same box and further from the corner
+	if archive.Add(makeObjectives(0.9, 0.1)) {
+		t.Error("An individual further from the corner of the box shouldn't be added")
+	}
+	// Different box but epsilon-dominated
+	if archive.Add(makeObjectives(1.5, 0)) {
+		t.Error("An epsilon-dominated individual shouldn't be added")
+	}
+	if archive.Len() != 1 {
+		t.Errorf("Expected 1 archived individual, got %d", archive.Len())
+	}
+}
+
+func TestGAArchive(t *testing.T) {
+	var ga = GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 20,
+		NbrGenes:       1,
+		Ff: ObjectivesFunction{
+			Image: func(genome Genome) []float64 {
+				var x = genome[0].(float64)
+				return []float64{x * x, (x - 2) * (x - 2)}
+			},
+		},
+		Initializer: InitUniformF{Lower: -5, Upper: 5},
+		Model:       model,
+		Archive:     &ParetoArchive{Epsilon: 0.1},
+	}
+	ga.Initialize()
+	for i := 0; i < 10; i++ {
+		ga.Enhance()
+	}
+	var front = ga.Archive.Front()
+	if len(front) == 0 {
+		t.Error("The archive shouldn't be empty")
+	}
+	for _, a := range front {
+		if len(a.Objectives) != 2 {
+			t.Error("Archived individuals should have two objectives")
+		}
+		for _, b := range front {
+			if Dominates(a.Objectives, b.Objectives) {
+				t.Error("Archived individuals shouldn't dominate each other")
+			}
+		}
+	}
+}
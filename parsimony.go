@@ -0,0 +1,126 @@
+package gago
+
+import "math/rand"
+
+// Bloat is the growth of variable-length genomes, for example the trees of
+// genetic programming, without a matching improvement of the fitness. The
+// following tools control it in different ways: ParsimonyFunction penalizes
+// the fitness of large genomes, SelDoubleTournament favors small individuals
+// during selection and CrossLimit and MutLimit reject the offsprings that
+// exceed a maximum size or depth. CompParsimony can also be used as the
+// Comparator of a GA to break fitness ties with the size of the genomes.
+
+// Return the size of an individual with a size function, the length of the
+// genome is used if the function is nil.
+func sizeOf(size func(indi Individual) float64, indi Individual) float64 {
+	if size == nil {
+		return float64(len(indi.Genome))
+	}
+	return size(indi)
+}
+
+// ParsimonyFunction adds Coefficient times the size of a genome to the fitness
+// computed by Function, which is called parametric parsimony pressure. Size
+// returns the size of a genome, the length of the genome is used if Size is
+// nil. The penalty is part of the fitness, hence the errors on each case or
+// the objectives computed by Function are not available to the GA.
+type ParsimonyFunction struct {
+	Function    FitnessFunction
+	Coefficient float64
+	Size        func(genome Genome) float64
+}
+
+// Apply the fitness function wrapped in ParsimonyFunction and add the penalty.
+func (ff ParsimonyFunction) apply(genome Genome) float64 {
+	var size = float64(len(genome))
+	if ff.Size != nil {
+		size = ff.Size(genome)
+	}
+	return ff.Function.apply(genome) + ff.Coefficient*size
+}
+
+// SelDoubleTournament selection applies a size tournament to the winners of
+// fitness tournaments, as described by Luke and Panait. Each individual is
+// chosen between the winners of two tournaments of NbParticipants individuals
+// compared by fitness: the smallest one is chosen with probability
+// Pressure / 2, where Pressure belongs to [1, 2]. A Pressure of 1 doesn't take
+// the size into account whereas a Pressure of 2 always chooses the smallest
+// winner; a Pressure of 0 is treated as 1.4, which controls bloat without
+// hurting the fitness much. Size returns the size of an individual, the length
+// of the genome is used if Size is nil.
+type SelDoubleTournament struct {
+	NbParticipants int
+	Pressure       float64
+	Size           func(indi Individual) float64
+}
+
+// Apply double tournament selection.
+func (sel SelDoubleTournament) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	var (
+		pressure   = sel.Pressure
+		tournament = SelTournament{NbParticipants: sel.NbParticipants}
+		indexes    = make([]int, n)
+		winners    = make(Individuals, n)
+	)
+	if pressure == 0 {
+		pressure = 1.4
+	}
+	for i := range winners {
+		var finalists, finalistIndexes = tournament.Apply(2, indis, rng)
+		// Put the smallest finalist first
+		if sizeOf(sel.Size, finalists[1]) < sizeOf(sel.Size, finalists[0]) {
+			finalists[0], finalists[1] = finalists[1], finalists[0]
+			finalistIndexes[0], finalistIndexes[1] = finalistIndexes[1], finalistIndexes[0]
+		}
+		var w = 0
+		if rng.Float64() >= pressure/2 {
+			w = 1
+		}
+		indexes[i] = finalistIndexes[w]
+		winners[i] = finalists[w]
+	}
+	return winners, indexes
+}
+
+// CrossLimit applies a crossover operator and replaces each offspring whose
+// size exceeds MaxSize with a clone of the parent it originates from, which
+// prevents the genomes from growing indefinitely. Size returns the size of an
+// individual, the length of the genome is used if Size is nil; for trees it
+// can return their depth to enforce a depth limit.
+type CrossLimit struct {
+	Crossover Crossover
+	Size      func(indi Individual) float64
+	MaxSize   float64
+}
+
+// Apply the crossover operator and reject the offsprings that are too large.
+func (cross CrossLimit) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = cross.Crossover.Apply(p1, p2, rng)
+	if sizeOf(cross.Size, o1) > cross.MaxSize {
+		o1 = p1.clone(rng)
+	}
+	if sizeOf(cross.Size, o2) > cross.MaxSize {
+		o2 = p2.clone(rng)
+	}
+	return o1, o2
+}
+
+// MutLimit applies a mutator and restores the individual as it was before the
+// mutation if it's size exceeds MaxSize. Size returns the size of an
+// individual, the length of the genome is used if Size is nil. The mutator
+// should not modify the values that the genes point to, otherwise they can't
+// be restored.
+type MutLimit struct {
+	Mutator Mutator
+	Size    func(indi Individual) float64
+	MaxSize float64
+}
+
+// Apply the mutator and undo the mutation if the individual is too large.
+func (mut MutLimit) Apply(indi *Individual, rng *rand.Rand) {
+	var original = copyIndividual(*indi)
+	mut.Mutator.Apply(indi, rng)
+	if sizeOf(mut.Size, *indi) > mut.MaxSize {
+		*indi = original
+	}
+}
@@ -0,0 +1,120 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// crossConcat produces offsprings whose genomes are the concatenation of the
+// genomes of the parents.
+type crossConcat struct{}
+
+func (cross crossConcat) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var genome = append(append(Genome{}, p1.Genome...), p2.Genome...)
+	return Individual{Genome: genome}, Individual{Genome: append(Genome{}, genome...)}
+}
+
+// mutGrow appends a gene to the genome of an individual.
+type mutGrow struct{}
+
+func (mut mutGrow) Apply(indi *Individual, rng *rand.Rand) {
+	indi.Genome = append(indi.Genome, 0.0)
+}
+
+func TestParsimonyFunction(t *testing.T) {
+	var (
+		genome    = Genome{1.0, 2.0, 3.0, 4.0}
+		testCases = []struct {
+			ff      ParsimonyFunction
+			fitness float64
+		}{
+			{ParsimonyFunction{Function: ff, Coefficient: 0.5}, 12},
+			{ParsimonyFunction{Function: ff}, 10},
+			{ParsimonyFunction{Function: ff, Coefficient: 2, Size: func(genome Genome) float64 { return 1 }}, 12},
+		}
+	)
+	for _, test := range testCases {
+		if fitness := test.ff.apply(genome); fitness != test.fitness {
+			t.Errorf("Expected %f, got %f", test.fitness, fitness)
+		}
+	}
+}
+
+func TestSelDoubleTournament(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indis = make(Individuals, 10)
+		total float64
+	)
+	// The larger individuals are the fitter ones
+	for i := range indis {
+		indis[i] = Individual{Genome: make(Genome, i+1), Fitness: float64(-i)}
+		total += float64(i + 1)
+	}
+	var testCases = []struct {
+		sel     SelDoubleTournament
+		smaller bool
+	}{
+		{SelDoubleTournament{NbParticipants: 1, Pressure: 2}, true},
+		{SelDoubleTournament{NbParticipants: 1}, true},
+		{SelDoubleTournament{NbParticipants: 10, Pressure: 1}, false},
+	}
+	for _, test := range testCases {
+		var (
+			selected, indexes = test.sel.Apply(1000, indis, rng)
+			size              float64
+		)
+		for i, indi := range selected {
+			if len(indi.Genome) != len(indis[indexes[i]].Genome) {
+				t.Error("The indexes don't match the selected individuals")
+				break
+			}
+			size += float64(len(indi.Genome))
+		}
+		size /= float64(len(selected))
+		if test.smaller && size >= total/float64(len(indis)) {
+			t.Errorf("Expected the selected individuals to be smaller than average, got a mean size of %f", size)
+		}
+		if !test.smaller && size != 10 {
+			t.Errorf("Expected the fittest individual to be selected, got a mean size of %f", size)
+		}
+	}
+}
+
+func TestCrossLimit(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(time.Now().UnixNano()))
+		p1     = Individual{Genome: Genome{1.0, 2.0}}
+		p2     = Individual{Genome: Genome{3.0}}
+		o1, o2 = CrossLimit{Crossover: crossConcat{}, MaxSize: 3}.Apply(p1, p2, rng)
+	)
+	if len(o1.Genome) != 3 || len(o2.Genome) != 3 {
+		t.Error("CrossLimit rejected offsprings that weren't too large")
+	}
+	o1, o2 = CrossLimit{Crossover: crossConcat{}, MaxSize: 2}.Apply(p1, p2, rng)
+	if len(o1.Genome) != 2 || o1.Genome[0] != 1.0 || len(o2.Genome) != 1 || o2.Genome[0] != 3.0 {
+		t.Error("CrossLimit didn't replace the offsprings with clones of the parents")
+	}
+	// Measure the size with a custom function
+	var size = func(indi Individual) float64 { return indi.Genome[0].(float64) }
+	o1, o2 = CrossLimit{Crossover: crossConcat{}, Size: size, MaxSize: 2}.Apply(p2, p1, rng)
+	if len(o1.Genome) != 1 || len(o2.Genome) != 2 {
+		t.Error("CrossLimit didn't use the Size function")
+	}
+}
+
+func TestMutLimit(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indi = Individual{Genome: Genome{1.0, 2.0}, Fitness: 3, Evaluated: true}
+	)
+	MutLimit{Mutator: mutGrow{}, MaxSize: 3}.Apply(&indi, rng)
+	if len(indi.Genome) != 3 {
+		t.Error("MutLimit undid a mutation that respected the limit")
+	}
+	MutLimit{Mutator: mutGrow{}, MaxSize: 3}.Apply(&indi, rng)
+	if len(indi.Genome) != 3 || indi.Fitness != 3 || !indi.Evaluated {
+		t.Error("MutLimit didn't restore the individual")
+	}
+}
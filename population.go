@@ -10,18 +10,28 @@ import (
 type Population struct {
 	Individuals Individuals
 	Duration    time.Duration
+	Stagnation  int             // Number of generations since the best individual of the population last improved
 	rng         *rand.Rand      // Each population has a random number generator to bypass the global rand mutex
+	src         rand.Source     // Source of rng, kept so that the state of rng can be copied by Clone
 	ff          FitnessFunction // The fitness function is also added to each population for access practicality
+	cmp         Comparator      // Order of the individuals, they are ordered by fitness if nil
+	spare       Individuals     // Individuals of the previous generation whose memory can be reused
 }
 
 // Generate a new population.
 func makePopulation(nbIndis, nbGenes int, ff FitnessFunction, init Initializer) Population {
+	return makeSourcedPopulation(nbIndis, nbGenes, ff, init, rand.NewSource(time.Now().UnixNano()))
+}
+
+// Generate a new population whose random number generator draws it's values
+// from src.
+func makeSourcedPopulation(nbIndis, nbGenes int, ff FitnessFunction, init Initializer, src rand.Source) Population {
 	var (
-		src = rand.NewSource(time.Now().UnixNano())
 		rng = rand.New(src)
 		pop = Population{
 			Individuals: makeIndividuals(nbIndis, nbGenes, rng),
 			rng:         rng,
+			src:         src,
 			ff:          ff,
 		}
 	)
@@ -32,5 +42,43 @@ func makePopulation(nbIndis, nbGenes int, ff FitnessFunction, init Initializer)
 	return pop
 }
 
+// Rand returns the random number generator of the population. Migrators
+// should draw their random numbers from it rather than from the global
+// generator so that the runs can be seeded and replayed.
+func (pop Population) Rand() *rand.Rand {
+	return pop.rng
+}
+
+// Sort the individuals of the population from the best to the worst in the
+// same order as the GA. Migrators that insert individuals into a population
+// should sort it afterwards so that it's best individual comes first.
+func (pop *Population) Sort() {
+	pop.Individuals.SortWith(pop.cmp)
+}
+
+// Evaluate the individuals of the population that haven't been evaluated yet
+// with ff, or with the fitness function of the GA the population belongs to if
+// ff is nil, and return the fitness of each individual along with the error
+// that made it's evaluation fail, both in the order of the individuals. An
+// evaluation can only fail if the fitness function is an ErrFunction, the
+// errors of the other individuals are nil. The individuals are evaluated in
+// the same way as during a generation, for example in a single batch for a
+// BatchFunction, but they aren't sorted.
+func (pop *Population) Evaluate(ff FitnessFunction) ([]float64, []error) {
+	if ff == nil {
+		ff = pop.ff
+	}
+	pop.Individuals.Evaluate(ff)
+	var (
+		fitnesses = make([]float64, len(pop.Individuals))
+		errs      = make([]error, len(pop.Individuals))
+	)
+	for i, indi := range pop.Individuals {
+		fitnesses[i] = indi.Fitness
+		errs[i] = indi.err
+	}
+	return fitnesses, errs
+}
+
 // Populations type is necessary for migration and clusterting purposes.
 type Populations []Population
@@ -0,0 +1,104 @@
+package gago
+
+import (
+	"math"
+	"sort"
+)
+
+// Preferences articulate which region of the Pareto front is of interest with
+// the goal attainment and priority scheme of Fonseca and Fleming. Each
+// objective can be given a goal which is the value it should reach, once an
+// objective attains it's goal improving it further doesn't matter as much as
+// improving the objectives that don't. Each objective can also be given a
+// priority, the objectives with the highest priority are compared first and
+// the objectives with a lower priority only break ties.
+//
+// Goals and Priorities are optional. Without goals no objective is considered
+// to be attained, without priorities every objective has the same priority. A
+// nil *Preferences amounts to Pareto dominance.
+type Preferences struct {
+	Goals      []float64
+	Priorities []int
+}
+
+// Dominates checks if a vector of objectives a is preferable to a vector of
+// objectives b.
+func (prefs *Preferences) Dominates(a, b []float64) bool {
+	if prefs == nil {
+		return Dominates(a, b)
+	}
+	// Group the objectives by increasing priority
+	var levels = make(map[int][]int)
+	for i := range a {
+		var priority int
+		if prefs.Priorities != nil {
+			priority = prefs.Priorities[i]
+		}
+		levels[priority] = append(levels[priority], i)
+	}
+	var priorities = make([]int, 0, len(levels))
+	for priority := range levels {
+		priorities = append(priorities, priority)
+	}
+	sort.Ints(priorities)
+	var groups = make([][]int, len(priorities))
+	for k, priority := range priorities {
+		groups[k] = levels[priority]
+	}
+	return prefs.prefers(a, b, groups, len(groups)-1)
+}
+
+// Return the goal of an objective.
+func (prefs *Preferences) goal(i int) float64 {
+	if prefs.Goals == nil {
+		return math.Inf(-1)
+	}
+	return prefs.Goals[i]
+}
+
+// Check if a is preferable to b by comparing the objectives of priority level
+// k and resorting to the lower levels in case of a tie.
+func (prefs *Preferences) prefers(a, b []float64, groups [][]int, k int) bool {
+	// Split the objectives of the level depending on a attaining their goal
+	var unattained, attained []int
+	for _, i := range groups[k] {
+		if a[i] > prefs.goal(i) {
+			unattained = append(unattained, i)
+		} else {
+			attained = append(attained, i)
+		}
+	}
+	// The objectives a doesn't attain are compared first
+	if dominatesOn(a, b, unattained) {
+		return true
+	}
+	for _, i := range unattained {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	// a is preferable if b doesn't attain a goal a attains
+	for _, i := range attained {
+		if b[i] > prefs.goal(i) {
+			return true
+		}
+	}
+	if k == 0 {
+		return dominatesOn(a, b, attained)
+	}
+	return prefs.prefers(a, b, groups, k-1)
+}
+
+// Check if a dominates b on a subset of objectives.
+func dominatesOn(a, b []float64, indexes []int) bool {
+	var strict = false
+	for _, i := range indexes {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			strict = true
+		}
+	}
+	return strict
+}
@@ -0,0 +1,72 @@
+package gago
+
+import "testing"
+
+func TestPreferencesDominates(t *testing.T) {
+	var testCases = []struct {
+		prefs     *Preferences
+		a, b      []float64
+		dominates bool
+	}{
+		// Pareto dominance
+		{nil, []float64{0, 0}, []float64{1, 1}, true},
+		{&Preferences{}, []float64{0, 0}, []float64{1, 1}, true},
+		{&Preferences{}, []float64{0, 2}, []float64{1, 1}, false},
+		// a attains every goal whereas b doesn't
+		{&Preferences{Goals: []float64{1, 1}}, []float64{0.5, 0.5}, []float64{0.2, 2}, true},
+		{&Preferences{Goals: []float64{1, 1}}, []float64{0.2, 2}, []float64{0.5, 0.5}, false},
+		// Both attain every goal
+		{&Preferences{Goals: []float64{1, 1}}, []float64{0.5, 0.5}, []float64{0.2, 0.6}, false},
+		{&Preferences{Goals: []float64{1, 1}}, []float64{0.2, 0.5}, []float64{0.5, 0.5}, true},
+		// Neither attains the second goal
+		{&Preferences{Goals: []float64{1, 1}}, []float64{0.5, 2}, []float64{0.2, 3}, true},
+		{&Preferences{Goals: []float64{1, 1}}, []float64{0.5, 3}, []float64{0.2, 2}, false},
+		// The first objective has priority
+		{&Preferences{Priorities: []int{1, 0}}, []float64{0, 5}, []float64{1, 0}, true},
+		{&Preferences{Priorities: []int{1, 0}}, []float64{1, 0}, []float64{0, 5}, false},
+		{&Preferences{Priorities: []int{1, 0}}, []float64{1, 0}, []float64{1, 5}, true},
+		// The first objective has priority and a goal
+		{&Preferences{Goals: []float64{2, 0}, Priorities: []int{1, 0}}, []float64{1, 0}, []float64{0, 5}, true},
+		{&Preferences{Goals: []float64{2, 0}, Priorities: []int{1, 0}}, []float64{0, 5}, []float64{1, 0}, false},
+	}
+	for _, test := range testCases {
+		if test.prefs.Dominates(test.a, test.b) != test.dominates {
+			t.Errorf("%v preferable to %v with %+v should be %t", test.a, test.b, test.prefs, test.dominates)
+		}
+	}
+}
+
+func TestNSGA2Preferences(t *testing.T) {
+	// Both goals are attained for x in [0.775, 1]
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 40,
+		NbrGenes:       1,
+		Ff: ObjectivesFunction{
+			Image: func(genome Genome) []float64 {
+				var x = genome[0].(float64)
+				return []float64{x * x, (x - 2) * (x - 2)}
+			},
+		},
+		Initializer: InitUniformF{Lower: 0, Upper: 2},
+		Model: ModNSGA2{
+			Crossover:   CrossUniformF{},
+			Mutator:     MutNormalF{Rate: 0.5, Std: 0.1},
+			MutRate:     0.5,
+			Preferences: &Preferences{Goals: []float64{1, 1.5}},
+		},
+	}
+	ga.Initialize()
+	for i := 0; i < 20; i++ {
+		ga.Enhance()
+	}
+	var attained int
+	for _, indi := range ga.Populations[0].Individuals {
+		if x := indi.Genome[0].(float64); x >= 0.77 && x <= 1 {
+			attained++
+		}
+	}
+	if attained < 30 {
+		t.Errorf("Expected the population to gather where the goals are attained, got %d individuals", attained)
+	}
+}
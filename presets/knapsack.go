@@ -0,0 +1,55 @@
+package presets
+
+import "github.com/MaxHalford/gago"
+
+// Knapsack returns a configuration for solving 0-1 knapsack problems, which
+// consist in choosing items of given weights and values so that their total
+// value is maximal and their total weight doesn't exceed a capacity. Each gene
+// indicates if an item is selected, the genomes are repaired after each
+// crossover and mutation so that every individual is feasible. The fitness is
+// the opposite of the total value.
+func Knapsack(weights, values []float64, capacity float64) gago.GA {
+	var repairer = gago.RepKnapsackB{
+		Weights:  weights,
+		Values:   values,
+		Capacity: capacity,
+	}
+	return gago.GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 50,
+		NbrGenes:       len(weights),
+		Ff: gago.BoolFunction{
+			Image: func(selected []bool) float64 {
+				var value float64
+				for i, s := range selected {
+					if s {
+						value += values[i]
+					}
+				}
+				return -value
+			},
+		},
+		Initializer: gago.InitKnapsackB{
+			Weights:  weights,
+			Values:   values,
+			Capacity: capacity,
+			Prob:     0.8,
+		},
+		Model: gago.ModGenerational{
+			Selector: gago.SelTournament{
+				NbParticipants: 3,
+			},
+			Crossover: gago.CrossRepair{
+				Crossover: gago.CrossUniform{},
+				Repairer:  repairer,
+			},
+			Mutator: gago.MutRepair{
+				Mutator:  gago.MutFlipB{Rate: 0.05},
+				Repairer: repairer,
+			},
+			MutRate: 0.5,
+		},
+		Migrator:     gago.MigShuffle{},
+		MigFrequency: 10,
+	}
+}
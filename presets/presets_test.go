@@ -16,7 +16,7 @@ func TestGAFloat(t *testing.T) {
 			}
 			return sum
 		}
-		ga = Float(nbVariables, ff)
+		ga = Float64(nbVariables, ff)
 	)
 	ga.Initialize()
 	var err = ga.Validate()
@@ -46,6 +46,27 @@ func TestGATSP(t *testing.T) {
 	}
 }
 
+func TestGATSPACO(t *testing.T) {
+	var (
+		alphabet = []string{"A", "B", "C", "D"}
+		ff       = func(S []string) float64 {
+			var sum float64
+			for i := range S {
+				if alphabet[i] != S[i] {
+					sum++
+				}
+			}
+			return sum
+		}
+		between = func(a, b string) float64 { return 1 }
+		ga      = TSPACO(alphabet, ff, between)
+	)
+	var err = ga.Validate()
+	if err != nil {
+		t.Error("'TSPACO' preset parameters are invalid")
+	}
+}
+
 func TestGAAlignment(t *testing.T) {
 	var (
 		alphabet = strings.Split("garry the goat", "")
@@ -66,3 +87,22 @@ func TestGAAlignment(t *testing.T) {
 		t.Error("'Alignement' preset parameters are invalid")
 	}
 }
+
+func TestGAKnapsack(t *testing.T) {
+	var (
+		weights = []float64{10, 20, 30, 15, 25}
+		values  = []float64{60, 100, 120, 30, 50}
+		ga      = Knapsack(weights, values, 50)
+	)
+	if err := ga.Validate(); err != nil {
+		t.Error("'Knapsack' preset parameters are invalid")
+	}
+	ga.Initialize()
+	for i := 0; i < 10; i++ {
+		ga.Enhance()
+	}
+	// The optimal selection is the second and the third items
+	if ga.Best().Fitness != -220 {
+		t.Errorf("Expected the optimal value of 220, got %f", -ga.Best().Fitness)
+	}
+}
@@ -48,3 +48,35 @@ func TSP(places []string, distance func([]string) float64) gago.GA {
 		MigFrequency: 10,
 	}
 }
+
+// TSPACO returns a configuration for solving Travelling Salesman Problems with
+// ant colony optimization instead of genetic operators. between returns the
+// distance between two places, the ants favour the closest places; the tours
+// built by the ants are improved with 2-opt.
+func TSPACO(places []string, distance func([]string) float64, between func(a, b string) float64) gago.GA {
+	return gago.GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 30,
+		NbrGenes:       len(places),
+		Ff: gago.StringFunction{
+			Image: distance,
+		},
+		Initializer: gago.InitUniqueS{
+			Corpus: places,
+		},
+		Model: gago.ModMemetic{
+			Model: &gago.ModACO{
+				NbrBest:      3,
+				Elitism:      1,
+				MinPheromone: 0.01,
+				Heuristic: func(a, b interface{}) float64 {
+					return 1 / (between(a.(string), b.(string)) + 1e-9)
+				},
+				Cycle:     true,
+				Symmetric: true,
+			},
+			LocalSearcher: gago.LocalTwoOpt{MaxEvaluations: 10 * len(places)},
+			Rate:          0.2,
+		},
+	}
+}
@@ -0,0 +1,169 @@
+package gago
+
+import (
+	"log"
+	"math"
+	"sync"
+)
+
+// A PressureMonitor estimates the selection pressure of each population by
+// following the takeover of it's best individual. The copies of the best
+// individual, which are the individuals that have the same fitness, are
+// counted at each generation; their growth rate is the geometric mean of the
+// ratio between the number of copies of two successive generations since the
+// best individual appeared. From the growth rate follows the takeover time,
+// which is the number of generations the best individual would need to fill
+// the population if only selection was applied.
+//
+// A growth rate close to 1 means the best individual isn't reproduced more
+// than the others and that the search is close to a random walk, whereas a
+// high growth rate means the population loses it's diversity within a few
+// generations and might converge prematurely. For example binary tournaments
+// roughly double the copies of the best individual at each generation. If Low
+// or High is not 0 a warning is written to Logger, or to the standard logger
+// if Logger is nil, the first time the growth rate of a best individual goes
+// below Low or above High. The estimates are also reported by the Populations
+// field of the statistics returned by the Stats method of the GA. A
+// PressureMonitor has to be used through a pointer.
+type PressureMonitor struct {
+	Low    float64
+	High   float64
+	Logger *log.Logger
+	mu     sync.Mutex
+	states []pressureState
+}
+
+// The takeover of the best individual of a population.
+type pressureState struct {
+	best    float64 // Fitness of the best individual
+	first   int     // Number of copies when the best individual appeared
+	copies  int
+	age     int // Number of generations since the best individual appeared
+	size    int // Number of individuals in the population
+	growth  float64
+	full    bool // The best individual has taken over the population
+	warned  bool
+	started bool
+}
+
+// Count the individuals that have the same fitness as the best individual of
+// a population.
+func countCopies(pop Population) int {
+	var n = 0
+	for _, indi := range pop.Individuals {
+		if indi.Fitness == pop.Individuals[0].Fitness {
+			n++
+		}
+	}
+	return n
+}
+
+// Forget the previous run.
+func (pm *PressureMonitor) reset() {
+	pm.mu.Lock()
+	pm.states = nil
+	pm.mu.Unlock()
+}
+
+// Return a copy of the monitor with the same estimates.
+func (pm *PressureMonitor) clone() *PressureMonitor {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	return &PressureMonitor{
+		Low:    pm.Low,
+		High:   pm.High,
+		Logger: pm.Logger,
+		states: append([]pressureState(nil), pm.states...),
+	}
+}
+
+// Update the estimates with the current generation of each population and log
+// a warning for each population whose growth rate is out of bounds.
+func (pm *PressureMonitor) update(pops Populations) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	for len(pm.states) < len(pops) {
+		pm.states = append(pm.states, pressureState{})
+	}
+	for p, pop := range pops {
+		var (
+			state  = &pm.states[p]
+			best   = pop.Individuals[0].Fitness
+			copies = countCopies(pop)
+		)
+		// Start following a new best individual
+		if !state.started || best != state.best {
+			*state = pressureState{
+				best:    best,
+				first:   copies,
+				copies:  copies,
+				size:    len(pop.Individuals),
+				full:    copies >= len(pop.Individuals),
+				started: true,
+			}
+			continue
+		}
+		state.copies = copies
+		state.size = len(pop.Individuals)
+		// The growth rate isn't meaningful anymore once the population has
+		// been taken over
+		if state.full {
+			continue
+		}
+		state.age++
+		state.growth = math.Pow(float64(copies)/float64(state.first), 1/float64(state.age))
+		state.full = copies >= state.size
+		if state.warned {
+			continue
+		}
+		if pm.High != 0 && state.growth > pm.High {
+			pm.warn("the selection pressure of population %d is too high, the copies of it's best individual grow by %.2f per generation", p, state.growth)
+			state.warned = true
+		} else if pm.Low != 0 && state.growth < pm.Low {
+			pm.warn("the selection pressure of population %d is too low, the copies of it's best individual grow by %.2f per generation", p, state.growth)
+			state.warned = true
+		}
+	}
+}
+
+// Write a warning to the logger.
+func (pm *PressureMonitor) warn(format string, v ...interface{}) {
+	if pm.Logger != nil {
+		pm.Logger.Printf(format, v...)
+		return
+	}
+	log.Printf(format, v...)
+}
+
+// Growth returns the growth rate of the copies of the best individual of the
+// p-th population, 0 is returned if the best individual appeared in the last
+// generation.
+func (pm *PressureMonitor) Growth(p int) float64 {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if p >= len(pm.states) {
+		return 0
+	}
+	return pm.states[p].growth
+}
+
+// TakeoverTime returns the estimated number of generations for the best
+// individual of the p-th population to fill the population. If the best
+// individual has already taken over the population the number of generations
+// it took is returned. +Inf is returned if the copies of the best individual
+// don't grow.
+func (pm *PressureMonitor) TakeoverTime(p int) float64 {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if p >= len(pm.states) {
+		return math.Inf(1)
+	}
+	var state = pm.states[p]
+	if state.full {
+		return float64(state.age)
+	}
+	if state.growth <= 1 {
+		return math.Inf(1)
+	}
+	return math.Log(float64(state.size)/float64(state.first)) / math.Log(state.growth)
+}
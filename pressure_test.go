@@ -0,0 +1,91 @@
+package gago
+
+import (
+	"bytes"
+	"log"
+	"math"
+	"strings"
+	"testing"
+)
+
+// Make a population of n individuals, the first copies of which have a
+// fitness of 0.
+func makeCopies(copies, n int) Population {
+	var pop = Population{Individuals: make(Individuals, n)}
+	for i := range pop.Individuals {
+		if i >= copies {
+			pop.Individuals[i].Fitness = float64(i)
+		}
+	}
+	return pop
+}
+
+func TestPressureMonitor(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		pm  = &PressureMonitor{High: 1.5, Logger: log.New(&buf, "", 0)}
+	)
+	for _, copies := range []int{1, 2, 4} {
+		pm.update(Populations{makeCopies(copies, 8)})
+	}
+	if growth := pm.Growth(0); math.Abs(growth-2) > 1e-10 {
+		t.Errorf("Expected a growth rate of 2, got %f", growth)
+	}
+	if takeover := pm.TakeoverTime(0); math.Abs(takeover-3) > 1e-10 {
+		t.Errorf("Expected a takeover time of 3, got %f", takeover)
+	}
+	// The warning is only written once per best individual
+	if strings.Count(buf.String(), "too high") != 1 {
+		t.Errorf("Expected one warning, got %q", buf.String())
+	}
+	// Once the population is taken over the takeover time is the number of
+	// generations it took
+	pm.update(Populations{makeCopies(8, 8)})
+	pm.update(Populations{makeCopies(8, 8)})
+	if takeover := pm.TakeoverTime(0); takeover != 3 {
+		t.Errorf("Expected a takeover time of 3, got %f", takeover)
+	}
+	// A new best individual is followed from scratch
+	var pop = makeCopies(1, 8)
+	pop.Individuals[0].Fitness = -1
+	pm.update(Populations{pop})
+	if pm.Growth(0) != 0 || !math.IsInf(pm.TakeoverTime(0), 1) {
+		t.Error("The new best individual wasn't followed from scratch")
+	}
+	// The copies of the best individual don't grow
+	buf.Reset()
+	pm.Low = 1.1
+	pm.update(Populations{pop})
+	if pm.Growth(0) != 1 || !strings.Contains(buf.String(), "too low") {
+		t.Error("Expected a warning about a low selection pressure")
+	}
+}
+
+func TestPressureStats(t *testing.T) {
+	var g = GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+		Pressure:       &PressureMonitor{},
+	}
+	g.Initialize()
+	for i := 0; i < 5; i++ {
+		g.Enhance()
+	}
+	for i, stats := range g.Stats().Populations {
+		if stats.Copies < 1 || stats.Growth < 0 || stats.Takeover < 0 {
+			t.Errorf("Population %d has incoherent takeover statistics %v", i, stats)
+		}
+		if stats.Growth != g.Pressure.Growth(i) {
+			t.Error("Stats didn't report the growth rate of the monitor")
+		}
+	}
+	// Initialize forgets the previous run
+	g.Initialize()
+	if g.Pressure.Growth(0) != 0 {
+		t.Error("The monitor wasn't reset")
+	}
+}
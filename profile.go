@@ -0,0 +1,393 @@
+package gago
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Timings break down the time spent in each phase of the generation loop when
+// the GA is profiled. The populations are evolved in parallel, hence the
+// timings are summed over the populations and can exceed the duration of the
+// GA. Selection and crossover also cover the operators they wrap, for example
+// a repairer applied by CrossRepair counts as crossover.
+type Timings struct {
+	Selection  time.Duration
+	Crossover  time.Duration
+	Mutation   time.Duration
+	Evaluation time.Duration
+}
+
+// Total returns the time spent in all the phases.
+func (t Timings) Total() time.Duration {
+	return t.Selection + t.Crossover + t.Mutation + t.Evaluation
+}
+
+// Return the name and the duration of each phase.
+func (t Timings) phases() ([]string, []time.Duration) {
+	return []string{"selection", "crossover", "mutation", "evaluation"},
+		[]time.Duration{t.Selection, t.Crossover, t.Mutation, t.Evaluation}
+}
+
+// Bottleneck returns the name of the phase the most time was spent in, which
+// is "selection", "crossover", "mutation" or "evaluation". An empty string is
+// returned if no time was measured.
+func (t Timings) Bottleneck() string {
+	var (
+		names, durations = t.phases()
+		bottleneck       string
+		longest          time.Duration
+	)
+	for i, d := range durations {
+		if d > longest {
+			bottleneck, longest = names[i], d
+		}
+	}
+	return bottleneck
+}
+
+// Subtract the durations of other timings from the timings.
+func (t Timings) sub(other Timings) Timings {
+	return Timings{
+		Selection:  t.Selection - other.Selection,
+		Crossover:  t.Crossover - other.Crossover,
+		Mutation:   t.Mutation - other.Mutation,
+		Evaluation: t.Evaluation - other.Evaluation,
+	}
+}
+
+// String returns the duration and the share of the total time of each phase,
+// followed by the bottleneck.
+func (t Timings) String() string {
+	var (
+		b                = strings.Builder{}
+		names, durations = t.phases()
+	)
+	for i, d := range durations {
+		var share float64
+		if t.Total() > 0 {
+			share = 100 * float64(d) / float64(t.Total())
+		}
+		fmt.Fprintf(&b, "%-10s %12v %6.2f%%\n", names[i], d, share)
+	}
+	fmt.Fprintf(&b, "bottleneck: %s\n", t.Bottleneck())
+	return b.String()
+}
+
+// An OperatorTiming is the time spent in an operator during a phase, the
+// operator being identified by it's type, for example "gago.SelTournament".
+// The time spent evaluating is attributed to the fitness function.
+type OperatorTiming struct {
+	Phase    string
+	Operator string
+	Duration time.Duration
+}
+
+// OperatorTimings are sorted from the operator the most time was spent in to
+// the one the least time was spent in.
+type OperatorTimings []OperatorTiming
+
+// WriteFolded writes the timings in the folded format used by flame graph
+// tools such as flamegraph.pl and speedscope, each line holds a phase and an
+// operator separated by a semicolon followed by a number of microseconds.
+func (timings OperatorTimings) WriteFolded(w io.Writer) error {
+	for _, t := range timings {
+		var _, err = fmt.Fprintf(w, "%s;%s %d\n", t.Phase, t.Operator, t.Duration.Microseconds())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// An operator is identified by the phase it's used in and by it's type.
+type operatorKey struct {
+	phase    string
+	operator string
+}
+
+// A profiler accumulates the time spent in each phase and in each operator,
+// the durations are stored in nanoseconds so that they can be incremented
+// atomically.
+type profiler struct {
+	selection  int64
+	crossover  int64
+	mutation   int64
+	evaluation int64
+	function   string // Type of the fitness function, to which the evaluations are attributed
+	mu         sync.Mutex
+	operators  map[operatorKey]*int64
+	generation Timings // Timings of the last generation
+}
+
+// Return a profiler that attributes the evaluations to a fitness function.
+func newProfiler(ff FitnessFunction) *profiler {
+	return &profiler{function: fmt.Sprintf("%T", ff)}
+}
+
+// Return the current time if there is a profiler. The profiler can be nil so
+// that a phase can be timed without checking if the GA is profiled.
+func (p *profiler) now() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// Add the time elapsed since start to a phase and to the counters of the
+// operators that were applied.
+func (p *profiler) add(phase *int64, start time.Time, operators ...*int64) {
+	if p != nil {
+		var elapsed = int64(time.Since(start))
+		atomic.AddInt64(phase, elapsed)
+		for _, op := range operators {
+			atomic.AddInt64(op, elapsed)
+		}
+	}
+}
+
+// Return the counter of an operator used in a phase.
+func (p *profiler) operator(phase string, op interface{}) *int64 {
+	var key = operatorKey{phase, operatorName(op)}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.operators == nil {
+		p.operators = make(map[operatorKey]*int64)
+	}
+	if p.operators[key] == nil {
+		p.operators[key] = new(int64)
+	}
+	return p.operators[key]
+}
+
+// Return the accumulated timings.
+func (p *profiler) timings() Timings {
+	return Timings{
+		Selection:  time.Duration(atomic.LoadInt64(&p.selection)),
+		Crossover:  time.Duration(atomic.LoadInt64(&p.crossover)),
+		Mutation:   time.Duration(atomic.LoadInt64(&p.mutation)),
+		Evaluation: time.Duration(atomic.LoadInt64(&p.evaluation)),
+	}
+}
+
+// Return the accumulated timings of each operator, the time spent evaluating
+// is attributed to the fitness function.
+func (p *profiler) operatorTimings() OperatorTimings {
+	var timings = OperatorTimings{{
+		Phase:    "evaluation",
+		Operator: p.function,
+		Duration: time.Duration(atomic.LoadInt64(&p.evaluation)),
+	}}
+	p.mu.Lock()
+	for key, d := range p.operators {
+		timings = append(timings, OperatorTiming{
+			Phase:    key.phase,
+			Operator: key.operator,
+			Duration: time.Duration(atomic.LoadInt64(d)),
+		})
+	}
+	p.mu.Unlock()
+	sort.SliceStable(timings, func(i, j int) bool {
+		if timings[i].Duration != timings[j].Duration {
+			return timings[i].Duration > timings[j].Duration
+		}
+		if timings[i].Phase != timings[j].Phase {
+			return timings[i].Phase < timings[j].Phase
+		}
+		return timings[i].Operator < timings[j].Operator
+	})
+	return timings
+}
+
+// Return a copy of the profiler with the same timings.
+func (p *profiler) clone() *profiler {
+	var timings = p.timings()
+	var clone = &profiler{
+		selection:  int64(timings.Selection),
+		crossover:  int64(timings.Crossover),
+		mutation:   int64(timings.Mutation),
+		evaluation: int64(timings.Evaluation),
+		function:   p.function,
+		operators:  make(map[operatorKey]*int64),
+		generation: p.generation,
+	}
+	p.mu.Lock()
+	for key, d := range p.operators {
+		var c = atomic.LoadInt64(d)
+		clone.operators[key] = &c
+	}
+	p.mu.Unlock()
+	return clone
+}
+
+// An operator wrapped by wrapModel, for example to time it, which can return
+// the operator it wraps.
+type wrappedOperator interface {
+	unwrap() interface{}
+}
+
+// Return the type of an operator, for example "gago.CrossPoint". The
+// operators wrapped by wrapModel are unwrapped first, hence an operator has
+// the same name whether the GA is profiled, traced or debugged or not.
+func operatorName(op interface{}) string {
+	for {
+		var wrapped, ok = op.(wrappedOperator)
+		if !ok {
+			return fmt.Sprintf("%T", op)
+		}
+		op = wrapped.unwrap()
+	}
+}
+
+// The operators of a profiled model are wrapped so that the time spent in each
+// of them is accumulated. Each wrapper has it's own Apply method, hence the
+// phases can also be told apart in a CPU profile obtained with pprof.
+
+type profiledSelector struct {
+	Selector
+	p  *profiler
+	op *int64
+}
+
+func (sel profiledSelector) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	var start = time.Now()
+	defer sel.p.add(&sel.p.selection, start, sel.op)
+	return sel.Selector.Apply(n, indis, rng)
+}
+
+func (sel profiledSelector) unwrap() interface{} {
+	return sel.Selector
+}
+
+type profiledCrossover struct {
+	Crossover
+	p  *profiler
+	op *int64
+}
+
+func (cross profiledCrossover) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var start = time.Now()
+	defer cross.p.add(&cross.p.crossover, start, cross.op)
+	return cross.Crossover.Apply(p1, p2, rng)
+}
+
+func (cross profiledCrossover) unwrap() interface{} {
+	return cross.Crossover
+}
+
+// A profiled crossover that can write into existing individuals, which keeps
+// the memory reuse of ModGenerational working when the GA is profiled.
+type profiledCrossoverInto struct {
+	profiledCrossover
+	into CrossoverInto
+}
+
+func (cross profiledCrossoverInto) ApplyInto(p1 Individual, p2 Individual, o1 *Individual, o2 *Individual, rng *rand.Rand) {
+	var start = time.Now()
+	defer cross.p.add(&cross.p.crossover, start, cross.op)
+	cross.into.ApplyInto(p1, p2, o1, o2, rng)
+}
+
+type profiledMutator struct {
+	Mutator
+	p  *profiler
+	op *int64
+}
+
+func (mut profiledMutator) Apply(indi *Individual, rng *rand.Rand) {
+	var start = time.Now()
+	defer mut.p.add(&mut.p.mutation, start, mut.op)
+	mut.Mutator.Apply(indi, rng)
+}
+
+func (mut profiledMutator) unwrap() interface{} {
+	return mut.Mutator
+}
+
+// A profiled mutator that mutates sibling offsprings at once, which keeps the
+// mirrored sampling of MutMirroredF working when the GA is profiled.
+type profiledPairMutator struct {
+	profiledMutator
+	pair PairMutator
+}
+
+func (mut profiledPairMutator) ApplyPair(indi1 *Individual, indi2 *Individual, rng *rand.Rand) {
+	var start = time.Now()
+	defer mut.p.add(&mut.p.mutation, start, mut.op)
+	mut.pair.ApplyPair(indi1, indi2, rng)
+}
+
+var (
+	modelType     = reflect.TypeOf((*Model)(nil)).Elem()
+	selectorType  = reflect.TypeOf((*Selector)(nil)).Elem()
+	crossoverType = reflect.TypeOf((*Crossover)(nil)).Elem()
+	mutatorType   = reflect.TypeOf((*Mutator)(nil)).Elem()
+)
+
+// Operator wrappers are applied to the operators of a model by wrapModel, a
+// nil wrapper leaves the operators of it's kind untouched.
+type wrappers struct {
+	selector  func(Selector) Selector
+	crossover func(Crossover) Crossover
+	mutator   func(Mutator) Mutator
+}
+
+// Return a copy of a model where each operator is wrapped. The fields of the
+// model are inspected with reflection so that any model, including a custom
+// one, can be wrapped; the models wrapped by the model are wrapped too. Models
+// that aren't structs are returned as is.
+func wrapModel(model Model, w wrappers) Model {
+	var v = reflect.ValueOf(model)
+	if v.Kind() != reflect.Struct {
+		return model
+	}
+	var c = reflect.New(v.Type()).Elem()
+	c.Set(v)
+	for i := 0; i < c.NumField(); i++ {
+		var f = c.Field(i)
+		if !f.CanSet() || f.Kind() != reflect.Interface || f.IsNil() {
+			continue
+		}
+		switch {
+		case f.Type() == modelType:
+			f.Set(reflect.ValueOf(wrapModel(f.Interface().(Model), w)))
+		case f.Type() == selectorType && w.selector != nil:
+			f.Set(reflect.ValueOf(w.selector(f.Interface().(Selector))))
+		case f.Type() == crossoverType && w.crossover != nil:
+			f.Set(reflect.ValueOf(w.crossover(f.Interface().(Crossover))))
+		case f.Type() == mutatorType && w.mutator != nil:
+			f.Set(reflect.ValueOf(w.mutator(f.Interface().(Mutator))))
+		}
+	}
+	return c.Interface().(Model)
+}
+
+// Return a copy of a model where each operator is wrapped by a profiled
+// operator.
+func profileModel(model Model, p *profiler) Model {
+	return wrapModel(model, wrappers{
+		selector: func(sel Selector) Selector {
+			return profiledSelector{sel, p, p.operator("selection", sel)}
+		},
+		crossover: func(cross Crossover) Crossover {
+			var profiled = profiledCrossover{cross, p, p.operator("crossover", cross)}
+			if into, ok := cross.(CrossoverInto); ok {
+				return profiledCrossoverInto{profiled, into}
+			}
+			return profiled
+		},
+		mutator: func(mut Mutator) Mutator {
+			var profiled = profiledMutator{mut, p, p.operator("mutation", mut)}
+			if pair, ok := mut.(PairMutator); ok {
+				return profiledPairMutator{profiled, pair}
+			}
+			return profiled
+		},
+	})
+}
@@ -0,0 +1,114 @@
+package gago
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfileModel(t *testing.T) {
+	var (
+		p       = &profiler{}
+		wrapped = ModClearing{
+			Model:  model,
+			Metric: DistEuclidean{},
+		}
+		profiled = profileModel(wrapped, p).(ModClearing)
+		inner    = profiled.Model.(ModGenerational)
+	)
+	if _, ok := inner.Selector.(profiledSelector); !ok {
+		t.Error("The selector of the wrapped model wasn't profiled")
+	}
+	if _, ok := inner.Crossover.(CrossoverInto); !ok {
+		t.Error("The profiled crossover should still implement CrossoverInto")
+	}
+	if _, ok := inner.Mutator.(profiledMutator); !ok {
+		t.Error("The mutator of the wrapped model wasn't profiled")
+	}
+	var mirrored = profileModel(ModGenerational{Mutator: MutMirroredF{Mutator: MutGaussianF{}}}, p).(ModGenerational)
+	if _, ok := mirrored.Mutator.(PairMutator); !ok {
+		t.Error("The profiled mutator should still implement PairMutator")
+	}
+	// Check the original model wasn't modified
+	if _, ok := wrapped.Model.(ModGenerational).Selector.(profiledSelector); ok {
+		t.Error("Profiling modified the original model")
+	}
+}
+
+func TestProfile(t *testing.T) {
+	var g = GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+		Profile:        true,
+	}
+	g.Initialize()
+	for i := 0; i < 5; i++ {
+		g.Enhance()
+	}
+	var timings = g.Stats().Timings
+	if timings.Selection <= 0 || timings.Crossover <= 0 || timings.Mutation <= 0 || timings.Evaluation <= 0 {
+		t.Error("Profiling didn't measure every phase")
+	}
+	var last = g.Stats().GenerationTimings
+	if last.Total() <= 0 || last.Total() >= timings.Total() {
+		t.Error("The timings of the last generation should be a part of the timings")
+	}
+	// Check the time spent in each operator was measured
+	var operators = make(map[string]time.Duration)
+	for _, op := range g.Stats().Operators {
+		operators[op.Phase+";"+op.Operator] = op.Duration
+	}
+	for _, key := range []string{
+		"selection;gago.SelTournament",
+		"crossover;gago.CrossUniformF",
+		"mutation;gago.MutNormalF",
+		"evaluation;gago.Float64Function",
+	} {
+		if operators[key] <= 0 {
+			t.Errorf("The time spent in %s wasn't measured", key)
+		}
+	}
+	if operators["selection;gago.SelTournament"] > timings.Selection {
+		t.Error("An operator can't take more time than it's phase")
+	}
+	var buf bytes.Buffer
+	if err := g.Stats().Operators.WriteFolded(&buf); err != nil {
+		t.Error(err)
+	}
+	if !strings.Contains(buf.String(), "evaluation;gago.Float64Function ") {
+		t.Error("The folded timings should have a line per operator")
+	}
+	if g.Evaluations != nbPopulations*nbIndividuals*6 {
+		t.Error("Profiling changed the number of evaluations")
+	}
+	// Check the GA isn't profiled by default
+	if ga.Stats().Timings != (Timings{}) {
+		t.Error("The GA was profiled without being asked to")
+	}
+}
+
+func TestTimingsBottleneck(t *testing.T) {
+	var timings = Timings{
+		Selection:  time.Millisecond,
+		Crossover:  2 * time.Millisecond,
+		Mutation:   time.Millisecond,
+		Evaluation: 6 * time.Millisecond,
+	}
+	if timings.Total() != 10*time.Millisecond {
+		t.Error("Wrong total")
+	}
+	if timings.Bottleneck() != "evaluation" {
+		t.Error("Wrong bottleneck")
+	}
+	if !strings.Contains(timings.String(), "60.00%") {
+		t.Error("The share of each phase should be reported")
+	}
+	if (Timings{}).Bottleneck() != "" {
+		t.Error("Timings without any time shouldn't have a bottleneck")
+	}
+}
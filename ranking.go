@@ -0,0 +1,85 @@
+package gago
+
+import "math/rand"
+
+// A Ranker orders the individuals of a population from the best to the worst,
+// the order can be stochastic. It's applied by the GA after the individuals
+// have been sorted with the Comparator, see the Ranker field of the GA.
+type Ranker interface {
+	Rank(indis Individuals, rng *rand.Rand)
+}
+
+// StochasticRanking orders individuals with the stochastic ranking of
+// Runarsson and Yao, which balances the fitness and the violation of the
+// constraints without having to choose a penalty coefficient. The individuals
+// are ordered with a bubble sort where two adjacent individuals are compared
+// by fitness if both are feasible or with probability Pf, otherwise they are
+// compared by violation. A Pf under 0.5 favors the feasible individuals while
+// still letting good infeasible individuals rank high, which helps crossing
+// infeasible regions; a Pf of 0 is treated as 0.45. The sort stops after
+// Sweeps sweeps, or as many sweeps as there are individuals if Sweeps is 0, or
+// when a sweep doesn't swap any individuals. The violations are set by a
+// ConstrainedFunction.
+//
+// StochasticRanking can be used as the Ranker of a GA, in which case the
+// populations are ranked at the end of each generation, or as a Selector that
+// chooses the n first individuals of the ranking, for example to select the
+// survivors of a ModDownToSize.
+type StochasticRanking struct {
+	Pf     float64
+	Sweeps int
+}
+
+// Rank the individuals with stochastic ranking.
+func (sr StochasticRanking) Rank(indis Individuals, rng *rand.Rand) {
+	sr.rank(indis, nil, rng)
+}
+
+// Rank the individuals along with their indexes, indexes can be nil.
+func (sr StochasticRanking) rank(indis Individuals, indexes []int, rng *rand.Rand) {
+	var (
+		pf     = sr.Pf
+		sweeps = sr.Sweeps
+	)
+	if pf == 0 {
+		pf = 0.45
+	}
+	if sweeps < 1 {
+		sweeps = len(indis)
+	}
+	for s := 0; s < sweeps; s++ {
+		var swapped = false
+		for i := 0; i < len(indis)-1; i++ {
+			var (
+				a, b    = indis[i], indis[i+1]
+				byValue = (a.Feasible() && b.Feasible()) || rng.Float64() < pf
+			)
+			if (byValue && b.Fitness < a.Fitness) || (!byValue && b.Violation < a.Violation) {
+				indis[i], indis[i+1] = b, a
+				if indexes != nil {
+					indexes[i], indexes[i+1] = indexes[i+1], indexes[i]
+				}
+				swapped = true
+			}
+		}
+		if !swapped {
+			break
+		}
+	}
+}
+
+// Apply stochastic ranking selection, the n first individuals of the ranking
+// are chosen. The individuals are ranked in a copy, hence their order isn't
+// modified.
+func (sr StochasticRanking) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	var (
+		ranked  = make(Individuals, len(indis))
+		indexes = make([]int, len(indis))
+	)
+	copy(ranked, indis)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sr.rank(ranked, indexes, rng)
+	return ranked[:n], indexes[:n]
+}
@@ -0,0 +1,79 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestStochasticRanking(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(42))
+		indis = Individuals{
+			{Fitness: 3},
+			{Fitness: 0, Violation: 2},
+			{Fitness: 2},
+			{Fitness: 1, Violation: 1},
+			{Fitness: 1},
+		}
+	)
+	// Infeasible individuals are almost never compared by fitness
+	var ranked = make(Individuals, len(indis))
+	copy(ranked, indis)
+	StochasticRanking{Pf: 1e-12}.Rank(ranked, rng)
+	for i, fitness := range []float64{1, 2, 3, 1, 0} {
+		if ranked[i].Fitness != fitness {
+			t.Fatalf("Expected the fitnesses [1 2 3 1 0], got %v", ranked.getFitnesses())
+		}
+	}
+	// Every individual is compared by fitness
+	copy(ranked, indis)
+	StochasticRanking{Pf: 1}.Rank(ranked, rng)
+	for i := 1; i < len(ranked); i++ {
+		if ranked[i].Fitness < ranked[i-1].Fitness {
+			t.Fatalf("Expected the individuals to be sorted by fitness, got %v", ranked.getFitnesses())
+		}
+	}
+	// The selector doesn't modify the individuals
+	var selected, indexes = StochasticRanking{}.Apply(2, indis, rng)
+	if len(selected) != 2 || indis[0].Fitness != 3 {
+		t.Error("StochasticRanking modified the individuals")
+	}
+	for i, index := range indexes {
+		if selected[i].Fitness != indis[index].Fitness || selected[i].Violation != indis[index].Violation {
+			t.Error("StochasticRanking returned the wrong indexes")
+		}
+	}
+}
+
+func TestRankerGA(t *testing.T) {
+	var ga = GA{
+		NbrPopulations: 2,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Ff: ConstrainedFunction{
+			Function: ff,
+			Violation: func(genome Genome) float64 {
+				// The first gene should be positive
+				return max(-genome[0].(float64), 0)
+			},
+		},
+		Initializer: initializer,
+		Model: ModDownToSize{
+			NbrOffsprings: 20,
+			SelectorA:     SelTournament{NbParticipants: 3},
+			Crossover:     CrossUniformF{},
+			SelectorB:     StochasticRanking{},
+			Mutator:       MutNormalF{Rate: 0.5, Std: 1},
+			MutRate:       0.5,
+		},
+		Comparator: CompConstrained{},
+		Ranker:     StochasticRanking{},
+	}
+	ga.Initialize()
+	for i := 0; i < 20; i++ {
+		ga.Enhance()
+	}
+	if !ga.Best().Feasible() {
+		t.Error("The best individual should be feasible")
+	}
+}
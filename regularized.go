@@ -0,0 +1,76 @@
+package gago
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ModRegularized implements regularized evolution, also called aging
+// evolution, which is used in neural architecture search. The population is a
+// queue ordered by age: at each step SampleSize individuals are sampled
+// uniformly, the best of them is copied and mutated, and the offspring is
+// evaluated and pushed at the end of the queue while the oldest individual is
+// removed, whatever it's fitness. NbrOffsprings steps are done per generation.
+// Because even the best individuals die of old age the model keeps exploring
+// and is robust to noisy fitness functions.
+//
+// The GA sorts the individuals of a population after each generation, hence
+// the queue is rebuilt before each generation from the IDs of the individuals,
+// which the GA gives in the order in which they join the populations; the
+// individuals without an ID are the youngest.
+type ModRegularized struct {
+	SampleSize    int
+	NbrOffsprings int
+	Mutator       Mutator
+}
+
+// Return the age rank of an individual, lower is older.
+func ageRank(indi Individual) int {
+	if indi.ID == 0 {
+		return math.MaxInt64
+	}
+	return indi.ID
+}
+
+// Apply regularized evolution to a population.
+func (mod ModRegularized) Apply(pop *Population) {
+	// Order the individuals from the oldest to the youngest
+	var queue = make(Individuals, len(pop.Individuals))
+	copy(queue, pop.Individuals)
+	sort.SliceStable(queue, func(i, j int) bool {
+		return ageRank(queue[i]) < ageRank(queue[j])
+	})
+	var sel = SelTournament{
+		NbParticipants: min(mod.SampleSize, len(queue)),
+		Comparator:     pop.cmp,
+	}
+	for i := 0; i < mod.NbrOffsprings; i++ {
+		var (
+			parents, _ = sel.Apply(1, queue, pop.rng)
+			offspring  = parents[0].clone(pop.rng)
+		)
+		offspring.Mutate(mod.Mutator, pop.rng)
+		offspring.Evaluate(pop.ff)
+		// Remove the oldest individual and add the offspring
+		queue = append(queue[1:], offspring)
+	}
+	pop.Individuals = queue
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModRegularized) Validate() error {
+	// Check the sample size
+	if mod.SampleSize < 1 {
+		return errors.New("'SampleSize' should be higher or equal to 1")
+	}
+	// Check the number of offsprings
+	if mod.NbrOffsprings < 1 {
+		return errors.New("'NbrOffsprings' should be higher or equal to 1")
+	}
+	// Check the mutator presence
+	if mod.Mutator == nil {
+		return errors.New("'Mutator' cannot be nil")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package gago
+
+import "testing"
+
+func TestModRegularized(t *testing.T) {
+	var (
+		pop = makePopulation(6, 2, ff, initializer)
+		mod = ModRegularized{SampleSize: 3, NbrOffsprings: 2, Mutator: MutNormalF{0.1, 1}}
+	)
+	// The individuals are ordered from the youngest to the oldest and the
+	// oldest individual is the best one, it should still be removed
+	for i := range pop.Individuals {
+		pop.Individuals[i].ID = 6 - i
+		pop.Individuals[i].Fitness = float64(5 - i)
+	}
+	mod.Apply(&pop)
+	if len(pop.Individuals) != 6 {
+		t.Error("The size of the population was modified")
+	}
+	for i, indi := range pop.Individuals[:4] {
+		if indi.ID != i+3 {
+			t.Errorf("Expected the individual with ID %d at position %d, got %d", i+3, i, indi.ID)
+		}
+	}
+	for _, indi := range pop.Individuals[4:] {
+		if indi.ID != 0 || !indi.Evaluated {
+			t.Error("The offsprings weren't added at the end of the queue")
+		}
+	}
+}
+
+func TestModRegularizedValidate(t *testing.T) {
+	var mod = ModRegularized{SampleSize: 3, NbrOffsprings: 1, Mutator: MutNormalF{0.1, 1}}
+	if mod.Validate() != nil {
+		t.Error("A valid ModRegularized returned an error")
+	}
+	for _, invalid := range []ModRegularized{
+		{SampleSize: 0, NbrOffsprings: 1, Mutator: MutNormalF{0.1, 1}},
+		{SampleSize: 3, NbrOffsprings: 0, Mutator: MutNormalF{0.1, 1}},
+		{SampleSize: 3, NbrOffsprings: 1},
+	} {
+		if invalid.Validate() == nil {
+			t.Errorf("Expected an error for %+v", invalid)
+		}
+	}
+}
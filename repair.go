@@ -0,0 +1,125 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// A Repairer modifies an individual so that it's genome satisfies the
+// constraints of a problem, for example after a crossover or a mutation
+// produced a genome that is out of the problem's domain.
+type Repairer interface {
+	Apply(indi *Individual, rng *rand.Rand)
+}
+
+// RepSumI repairs integer genomes so that the sum of their genes is equal to
+// Sum, while keeping each gene in the [Lower, Upper] range. Random genes are
+// shifted towards the target sum until it is reached. If the target sum can't
+// be reached because of the bounds then the genome is brought as close as
+// possible to it.
+type RepSumI struct {
+	Sum, Lower, Upper int
+}
+
+// Apply sum repair.
+func (rep RepSumI) Apply(indi *Individual, rng *rand.Rand) {
+	var diff = rep.Sum
+	for _, gene := range indi.Genome {
+		diff -= gene.(int)
+	}
+	// Go through the genes in a random order and shift each one as much as
+	// possible
+	for _, i := range rng.Perm(len(indi.Genome)) {
+		if diff == 0 {
+			break
+		}
+		var (
+			gene    = indi.Genome[i].(int)
+			shifted = clipInt(gene+diff, rep.Lower, rep.Upper)
+		)
+		indi.Genome[i] = shifted
+		diff -= shifted - gene
+	}
+}
+
+// RepClipF repairs floating point genomes by clipping each gene to the
+// [Lower, Upper] range.
+type RepClipF struct {
+	Lower, Upper float64
+}
+
+// Apply clip repair.
+func (rep RepClipF) Apply(indi *Individual, rng *rand.Rand) {
+	for i, gene := range indi.Genome {
+		indi.Genome[i] = math.Max(rep.Lower, math.Min(rep.Upper, gene.(float64)))
+	}
+}
+
+// Order the items of a knapsack by decreasing value to weight ratio.
+func ratioOrder(weights, values []float64) []int {
+	var order = make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]]*weights[order[j]] > values[order[j]]*weights[order[i]]
+	})
+	return order
+}
+
+// RepKnapsackB repairs boolean genomes that select items to put in a knapsack,
+// gene i indicates if the item of weight Weights[i] and of value Values[i] is
+// selected. While the selected items weigh more than Capacity, the selected
+// item with the lowest value to weight ratio is dropped.
+type RepKnapsackB struct {
+	Weights, Values []float64
+	Capacity        float64
+}
+
+// Apply knapsack repair.
+func (rep RepKnapsackB) Apply(indi *Individual, rng *rand.Rand) {
+	var (
+		order  = ratioOrder(rep.Weights, rep.Values)
+		weight float64
+	)
+	for i, gene := range indi.Genome {
+		if gene.(bool) {
+			weight += rep.Weights[i]
+		}
+	}
+	for k := len(order) - 1; k >= 0 && weight > rep.Capacity; k-- {
+		if i := order[k]; indi.Genome[i].(bool) {
+			indi.Genome[i] = false
+			weight -= rep.Weights[i]
+		}
+	}
+}
+
+// CrossRepair applies a crossover operator and then repairs both offsprings so
+// that they satisfy the constraints of the problem.
+type CrossRepair struct {
+	Crossover Crossover
+	Repairer  Repairer
+}
+
+// Apply the crossover operator and repair the offsprings.
+func (cross CrossRepair) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = cross.Crossover.Apply(p1, p2, rng)
+	cross.Repairer.Apply(&o1, rng)
+	cross.Repairer.Apply(&o2, rng)
+	return o1, o2
+}
+
+// MutRepair applies a mutator and then repairs the individual so that it
+// satisfies the constraints of the problem.
+type MutRepair struct {
+	Mutator  Mutator
+	Repairer Repairer
+}
+
+// Apply the mutator and repair the individual.
+func (mut MutRepair) Apply(indi *Individual, rng *rand.Rand) {
+	mut.Mutator.Apply(indi, rng)
+	mut.Repairer.Apply(indi, rng)
+}
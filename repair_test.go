@@ -0,0 +1,106 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRepSumI(t *testing.T) {
+	var (
+		src       = rand.NewSource(time.Now().UnixNano())
+		rng       = rand.New(src)
+		testCases = []struct {
+			sum, lower, upper int
+			feasible          bool
+		}{
+			{10, 0, 5, true},
+			{0, -3, 3, true},
+			{100, 0, 5, false},
+		}
+	)
+	for _, testCase := range testCases {
+		var (
+			indi = makeIndividual(5, rng)
+			init = InitUniformI{testCase.lower, testCase.upper}
+			rep  = RepSumI{testCase.sum, testCase.lower, testCase.upper}
+		)
+		init.Apply(&indi, rng)
+		rep.Apply(&indi, rng)
+		var sum int
+		for _, gene := range indi.Genome {
+			// Check the bounds are respected
+			if gene.(int) < testCase.lower || gene.(int) > testCase.upper {
+				t.Error("RepSumI produced a gene out of bounds")
+			}
+			sum += gene.(int)
+		}
+		// Check the sum has been reached if it was feasible
+		if testCase.feasible && sum != testCase.sum {
+			t.Error("RepSumI didn't reach the target sum")
+		}
+	}
+}
+
+func TestRepKnapsackB(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		rep  = RepKnapsackB{Weights: []float64{1, 2, 3, 4}, Values: []float64{4, 4, 3, 2}, Capacity: 5}
+		indi = Individual{Genome: Genome{true, true, true, true}}
+	)
+	rep.Apply(&indi, rng)
+	// The items with the lowest ratios are dropped first
+	for i, gene := range []bool{true, true, false, false} {
+		if indi.Genome[i] != gene {
+			t.Errorf("Expected %v, got %v", []bool{true, true, false, false}, indi.Genome)
+			break
+		}
+	}
+	// A feasible genome isn't modified
+	indi = Individual{Genome: Genome{false, false, false, true}}
+	rep.Apply(&indi, rng)
+	if indi.Genome[3] != true {
+		t.Error("RepKnapsackB modified a feasible genome")
+	}
+}
+
+func TestRepClipF(t *testing.T) {
+	var indi = Individual{Genome: Genome{-2.0, 0.5, 3.0}}
+	RepClipF{Lower: -1, Upper: 1}.Apply(&indi, nil)
+	for i, gene := range []float64{-1, 0.5, 1} {
+		if indi.Genome[i] != gene {
+			t.Errorf("Expected %v, got %v", gene, indi.Genome[i])
+		}
+	}
+}
+
+func TestRepairWrappers(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		rep   = RepKnapsackB{Weights: []float64{1, 1, 1, 1}, Values: []float64{1, 1, 1, 1}, Capacity: 2}
+		cross = CrossRepair{Crossover: CrossUniform{}, Repairer: rep}
+		mut   = MutRepair{Mutator: MutFlipB{Rate: 1}, Repairer: rep}
+		count = func(indi Individual) int {
+			var n int
+			for _, gene := range indi.Genome {
+				if gene.(bool) {
+					n++
+				}
+			}
+			return n
+		}
+	)
+	var o1, o2 = cross.Apply(
+		Individual{Genome: Genome{true, true, true, true}},
+		Individual{Genome: Genome{true, true, true, true}},
+		rng,
+	)
+	if count(o1) != 2 || count(o2) != 2 {
+		t.Error("CrossRepair didn't repair the offsprings")
+	}
+	var indi = Individual{Genome: Genome{false, false, false, false}}
+	mut.Apply(&indi, rng)
+	if count(indi) != 2 {
+		t.Error("MutRepair didn't repair the individual")
+	}
+}
@@ -0,0 +1,239 @@
+package gago
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// An EventLog records the stochastic decisions of a run so that the run can be
+// replayed exactly, for example to debug a run that behaved unexpectedly.
+// Every random decision of a GA, whether it's made by the initializer, a
+// selector, a crossover, a mutator or the migrator, comes from the random
+// number generator of a population, hence the EventLog records the values
+// produced by the source of each generator. It also records the order in which
+// AsyncSteadyState integrates the offsprings, which depends on the duration of
+// the evaluations.
+//
+// A run is recorded by setting the EventLog of a GA, the recording starts
+// anew each time Initialize is called. If Replay is true the next run draws
+// the recorded values instead of new ones; a replay requires the same
+// parameters, the same sequence of calls to Initialize and Enhance and a
+// deterministic fitness function. If the replayed run draws more values than
+// were recorded, for example because the parameters are different, the replay
+// diverges and the missing values are drawn from the usual sources, see
+// Diverged. A log is saved with Save and loaded with LoadEventLog, the format
+// is versioned so that logs stay readable by later versions of the library.
+// The log grows with every random number, hence it should only be recorded for
+// runs of a reasonable size.
+type EventLog struct {
+	Replay   bool
+	mu       sync.Mutex
+	streams  []*eventStream
+	order    []int // Dispatch number of each offspring integrated by AsyncSteadyState
+	next     int   // Position in order during a replay
+	diverged bool
+}
+
+// An eventStream is a rand.Source that records the values produced by another
+// source, or replays recorded values. A stream is only used by the goroutine
+// of it's population.
+type eventStream struct {
+	log    *EventLog
+	src    rand.Source
+	values []int64
+	pos    int // Position in values during a replay
+}
+
+// Int63 returns the next value of the stream.
+func (s *eventStream) Int63() int64 {
+	if !s.log.Replay {
+		var v = s.src.Int63()
+		s.values = append(s.values, v)
+		return v
+	}
+	if s.pos < len(s.values) {
+		s.pos++
+		return s.values[s.pos-1]
+	}
+	s.log.diverge()
+	return s.src.Int63()
+}
+
+// Seed seeds the underlying source.
+func (s *eventStream) Seed(seed int64) {
+	s.src.Seed(seed)
+}
+
+// Start recording or replaying a run, each source is wrapped in a stream that
+// records it's values or replays the recorded values of the corresponding
+// population.
+func (log *EventLog) start(sources []rand.Source) []rand.Source {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	var wrapped = make([]rand.Source, len(sources))
+	if !log.Replay {
+		log.streams = make([]*eventStream, len(sources))
+		log.order = nil
+		for i, src := range sources {
+			log.streams[i] = &eventStream{log: log, src: src}
+			wrapped[i] = log.streams[i]
+		}
+		return wrapped
+	}
+	// Replay the recorded streams from the start
+	log.next = 0
+	log.diverged = len(sources) != len(log.streams)
+	for i, src := range sources {
+		var stream = &eventStream{log: log, src: src}
+		if i < len(log.streams) {
+			stream.values = log.streams[i].values
+		}
+		wrapped[i] = stream
+	}
+	return wrapped
+}
+
+// Mark the replay as diverged.
+func (log *EventLog) diverge() {
+	log.mu.Lock()
+	log.diverged = true
+	log.mu.Unlock()
+}
+
+// Diverged returns true if the replayed run didn't follow the recorded run,
+// which is the case if it drew more values than were recorded, if it had a
+// different number of populations or if AsyncSteadyState integrated
+// offsprings that weren't recorded.
+func (log *EventLog) Diverged() bool {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	return log.diverged
+}
+
+// Len returns the number of recorded events, which is the number of random
+// values drawn plus the number of offsprings integrated by AsyncSteadyState.
+func (log *EventLog) Len() int {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	var n = len(log.order)
+	for _, stream := range log.streams {
+		n += len(stream.values)
+	}
+	return n
+}
+
+// Receive the next offspring evaluated by the workers of AsyncSteadyState.
+// The offsprings are recorded in the order they arrive, during a replay they
+// are returned in the recorded order and those that arrive early wait in
+// pending. dispatched is the number of offsprings that were dispatched to the
+// workers, integrated indicates which of them were already received.
+func (log *EventLog) receive(results <-chan asyncJob, pending map[int]asyncJob, dispatched int, integrated map[int]bool) asyncJob {
+	if !log.Replay {
+		var job = <-results
+		log.mu.Lock()
+		log.order = append(log.order, job.seq)
+		log.mu.Unlock()
+		return job
+	}
+	log.mu.Lock()
+	var seq = -1
+	if log.next < len(log.order) {
+		seq = log.order[log.next]
+		log.next++
+	}
+	// The recorded offspring has to be one that is being evaluated
+	if seq < 0 || seq >= dispatched || integrated[seq] {
+		log.diverged = true
+		seq = -1
+	}
+	log.mu.Unlock()
+	if seq < 0 {
+		// Take the pending offsprings in the order they were dispatched
+		if len(pending) > 0 {
+			var seqs = make([]int, 0, len(pending))
+			for s := range pending {
+				seqs = append(seqs, s)
+			}
+			sort.Ints(seqs)
+			var job = pending[seqs[0]]
+			delete(pending, seqs[0])
+			return job
+		}
+		return <-results
+	}
+	for {
+		if job, ok := pending[seq]; ok {
+			delete(pending, seq)
+			return job
+		}
+		var job = <-results
+		pending[job.seq] = job
+	}
+}
+
+// The header of the binary format of an event log, the version is increased
+// if the format changes so that old logs can still be read.
+const (
+	eventLogMagic   = "gagolog"
+	eventLogVersion = 1
+)
+
+// Save writes the event log in a binary format, each value is written as a
+// varint. The log can be read back with LoadEventLog.
+func (log *EventLog) Save(w io.Writer) error {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	var (
+		buf = bufio.NewWriter(w)
+		bw  = &binaryWriter{w: buf}
+	)
+	bw.string(eventLogMagic)
+	bw.uvarint(eventLogVersion)
+	bw.uvarint(uint64(len(log.streams)))
+	for _, stream := range log.streams {
+		bw.uvarint(uint64(len(stream.values)))
+		for _, v := range stream.values {
+			bw.uvarint(uint64(v))
+		}
+	}
+	bw.uvarint(uint64(len(log.order)))
+	for _, seq := range log.order {
+		bw.uvarint(uint64(seq))
+	}
+	if bw.err != nil {
+		return bw.err
+	}
+	return buf.Flush()
+}
+
+// LoadEventLog reads an event log written by Save, the returned log is ready
+// to be replayed.
+func LoadEventLog(r io.Reader) (*EventLog, error) {
+	var br = &binaryReader{r: bufio.NewReader(r)}
+	if magic := br.string(); br.err == nil && magic != eventLogMagic {
+		return nil, errors.New("not an event log")
+	}
+	if version := br.uvarint(); br.err == nil && version != eventLogVersion {
+		return nil, fmt.Errorf("unsupported event log version %d", version)
+	}
+	var log = &EventLog{Replay: true, streams: make([]*eventStream, br.length())}
+	for i := range log.streams {
+		log.streams[i] = &eventStream{log: log, values: make([]int64, br.length())}
+		for j := range log.streams[i].values {
+			log.streams[i].values[j] = int64(br.uvarint())
+		}
+	}
+	log.order = make([]int, br.length())
+	for i := range log.order {
+		log.order[i] = int(br.uvarint())
+	}
+	if br.err != nil {
+		return nil, br.err
+	}
+	return log, nil
+}
@@ -0,0 +1,152 @@
+package gago
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Check two GAs have the same individuals in the same order.
+func samePopulations(a, b Populations) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i].Individuals) != len(b[i].Individuals) {
+			return false
+		}
+		for j, indi := range a[i].Individuals {
+			var other = b[i].Individuals[j]
+			if indi.Fitness != other.Fitness || indi.Name != other.Name || !reflect.DeepEqual(indi.Genome, other.Genome) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestEventLogReplay(t *testing.T) {
+	var newGA = func(log *EventLog) GA {
+		return GA{
+			NbrPopulations: 2,
+			NbrIndividuals: 10,
+			NbrGenes:       nbGenes,
+			Ff:             ff,
+			Initializer:    initializer,
+			Model:          model,
+			Migrator:       MigShuffle{},
+			MigFrequency:   2,
+			EventLog:       log,
+		}
+	}
+	// Record a run
+	var (
+		log    = &EventLog{}
+		record = newGA(log)
+	)
+	record.Initialize()
+	var initial = make(Populations, len(record.Populations))
+	for i, pop := range record.Populations {
+		for _, indi := range pop.Individuals {
+			initial[i].Individuals = append(initial[i].Individuals, copyIndividual(indi))
+		}
+	}
+	for i := 0; i < 5; i++ {
+		record.Enhance()
+	}
+	if log.Len() == 0 {
+		t.Fatal("No events were recorded")
+	}
+	// Save and load the log
+	var buf bytes.Buffer
+	if err := log.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var loaded, err = LoadEventLog(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Replay || loaded.Len() != log.Len() {
+		t.Error("The loaded log is different from the saved one")
+	}
+	// Replay the run with a different seed
+	var replay = newGA(loaded)
+	replay.Seed = 42
+	replay.Initialize()
+	for i := 0; i < 5; i++ {
+		replay.Enhance()
+	}
+	if !samePopulations(record.Populations, replay.Populations) {
+		t.Error("The replayed run is different from the recorded run")
+	}
+	if loaded.Diverged() {
+		t.Error("The replay shouldn't have diverged")
+	}
+	// Going further than the recorded run diverges
+	replay.Enhance()
+	if !loaded.Diverged() {
+		t.Error("The replay should have diverged")
+	}
+	// Replaying again starts from the beginning
+	replay.Initialize()
+	if loaded.Diverged() || !samePopulations(initial, replay.Populations) {
+		t.Error("The replay didn't start from the beginning")
+	}
+}
+
+func TestEventLogAsync(t *testing.T) {
+	var newGA = func(log *EventLog) GA {
+		return GA{
+			NbrPopulations: 2,
+			NbrIndividuals: 10,
+			NbrGenes:       2,
+			Initializer:    initializer,
+			// The order in which the offsprings are evaluated varies
+			Ff: Float64Function{func(X []float64) float64 {
+				time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+				return X[0]*X[0] + X[1]*X[1]
+			}},
+			Model:    model,
+			EventLog: log,
+		}
+	}
+	var ass = AsyncSteadyState{
+		Selector:   SelTournament{NbParticipants: 3},
+		Crossover:  CrossUniformF{},
+		Mutator:    MutNormalF{0.5, 1},
+		MutRate:    0.5,
+		NbrWorkers: 4,
+	}
+	var (
+		log    = &EventLog{}
+		record = newGA(log)
+	)
+	record.Initialize()
+	ass.Apply(&record, 50)
+	log.Replay = true
+	var replay = newGA(log)
+	replay.Initialize()
+	ass.Apply(&replay, 50)
+	if !samePopulations(record.Populations, replay.Populations) {
+		t.Error("The replayed run is different from the recorded run")
+	}
+	if log.Diverged() {
+		t.Error("The replay shouldn't have diverged")
+	}
+}
+
+func TestLoadEventLogErrors(t *testing.T) {
+	var testCases = [][]byte{
+		{},
+		{3, 'f', 'o', 'o', 1, 0, 0},
+		{7, 'g', 'a', 'g', 'o', 'l', 'o', 'g', 2, 0, 0},
+		{7, 'g', 'a', 'g', 'o', 'l', 'o', 'g', 1, 1, 3, 1},
+	}
+	for _, data := range testCases {
+		if _, err := LoadEventLog(bytes.NewReader(data)); err == nil {
+			t.Errorf("Expected an error for %v", data)
+		}
+	}
+}
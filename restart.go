@@ -0,0 +1,79 @@
+package gago
+
+import "math"
+
+// A Restarter regenerates the populations of a GA once the GA has stagnated,
+// in other words once the best individual hasn't improved during a given
+// number of generations. Restarting helps escaping from local optima.
+type Restarter interface {
+	Apply(ga *GA)
+}
+
+// Replace the individuals of a population that come after the first keep
+// individuals with new randomly initialized individuals, in such a way that
+// the population ends up containing n individuals.
+func (pop *Population) reinitialize(keep, n, nbGenes int, init Initializer) {
+	pop.Individuals = pop.Individuals[:min(keep, len(pop.Individuals))]
+	pop.resize(n, nbGenes, init)
+}
+
+// RestartFull reinitializes each population entirely except for it's KeepBest
+// best individuals.
+type RestartFull struct {
+	KeepBest int
+}
+
+// Apply full restart.
+func (rs RestartFull) Apply(ga *GA) {
+	for i := range ga.Populations {
+		var pop = &ga.Populations[i]
+		pop.reinitialize(rs.KeepBest, len(pop.Individuals), ga.NbrGenes, ga.Initializer)
+	}
+}
+
+// RestartPartial reinitializes the worst individuals of each population, the
+// proportion of reinitialized individuals is given by Proportion which should
+// belong to the [0, 1] interval.
+type RestartPartial struct {
+	Proportion float64
+}
+
+// Apply partial restart.
+func (rs RestartPartial) Apply(ga *GA) {
+	for i := range ga.Populations {
+		var (
+			pop  = &ga.Populations[i]
+			n    = len(pop.Individuals)
+			keep = n - int(math.Floor(rs.Proportion*float64(n)+0.5))
+		)
+		pop.reinitialize(keep, n, ga.NbrGenes, ga.Initializer)
+	}
+}
+
+// RestartIPOP reinitializes each population entirely except for it's KeepBest
+// best individuals and multiplies the number of individuals in each population
+// by Factor, as done by the IPOP-CMA-ES algorithm. A larger population explores
+// the search space more globally. The NbrIndividuals parameter of the GA isn't
+// modified, the populations start again from it when the GA is initialized.
+// The populations stop growing once their individuals would exceed the
+// MemoryLimit of the GA. Note that a PopulationSizer, if provided, takes
+// precedence over the new size at the next generation.
+type RestartIPOP struct {
+	Factor   float64
+	KeepBest int
+}
+
+// Apply IPOP restart.
+func (rs RestartIPOP) Apply(ga *GA) {
+	if ga.ipopSize == 0 {
+		ga.ipopSize = ga.NbrIndividuals
+	}
+	var size = int(math.Ceil(float64(ga.ipopSize) * rs.Factor))
+	if ga.MemoryLimit <= 0 || len(ga.Populations) == 0 || len(ga.Populations[0].Individuals) == 0 ||
+		EstimateMemory(len(ga.Populations), size, ga.Populations[0].Individuals[0]) <= ga.MemoryLimit {
+		ga.ipopSize = size
+	}
+	for i := range ga.Populations {
+		ga.Populations[i].reinitialize(rs.KeepBest, ga.ipopSize, ga.NbrGenes, ga.Initializer)
+	}
+}
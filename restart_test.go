@@ -0,0 +1,96 @@
+package gago
+
+import "testing"
+
+func TestRestarters(t *testing.T) {
+	var restarters = []struct {
+		restarter Restarter
+		size      int // Expected population size after restarting
+	}{
+		{RestartFull{KeepBest: 2}, 10},
+		{RestartPartial{Proportion: 0.5}, 10},
+		{RestartIPOP{Factor: 2, KeepBest: 1}, 20},
+	}
+	for _, r := range restarters {
+		var ga = GA{
+			NbrPopulations: 2,
+			NbrIndividuals: 10,
+			NbrGenes:       2,
+			Ff:             ff,
+			Initializer:    initializer,
+			Model:          model,
+		}
+		ga.Initialize()
+		var best = ga.Populations[0].Individuals[0]
+		r.restarter.Apply(&ga)
+		for _, pop := range ga.Populations {
+			// Check the size of the populations
+			if len(pop.Individuals) != r.size {
+				t.Error("Restarter produced a population of the wrong size")
+			}
+			// Check the populations are sorted
+			for k := 1; k < len(pop.Individuals); k++ {
+				if pop.Individuals[k-1].Fitness > pop.Individuals[k].Fitness {
+					t.Error("Restarter didn't sort the population")
+				}
+			}
+		}
+		// Check the best individual was kept
+		if ga.Populations[0].Individuals[0].Fitness > best.Fitness {
+			t.Error("Restarter didn't keep the best individual")
+		}
+	}
+}
+
+func TestStagnationRestart(t *testing.T) {
+	var ga = GA{
+		NbrPopulations:  1,
+		NbrIndividuals:  10,
+		NbrGenes:        2,
+		Ff:              ff,
+		Initializer:     initializer,
+		Model:           model,
+		Restarter:       RestartFull{KeepBest: 1},
+		StagnationLimit: 2,
+	}
+	ga.Initialize()
+	// Make the best individual impossible to improve upon
+	ga.setBest(Individual{Fitness: -1e100})
+	for i := 0; i < 4; i++ {
+		ga.Enhance()
+	}
+	if ga.Restarts != 2 {
+		t.Error("The GA wasn't restarted after stagnating")
+	}
+}
+
+func TestRestartIPOP(t *testing.T) {
+	var (
+		ga = GA{
+			NbrPopulations: 2,
+			NbrIndividuals: 10,
+			NbrGenes:       2,
+			Ff:             ff,
+			Initializer:    initializer,
+			Model:          model,
+		}
+		rs = RestartIPOP{Factor: 2}
+	)
+	ga.Initialize()
+	rs.Apply(&ga)
+	rs.Apply(&ga)
+	if len(ga.Populations[0].Individuals) != 40 || ga.NbrIndividuals != 10 {
+		t.Error("RestartIPOP should grow the populations without modifying NbrIndividuals")
+	}
+	ga.Initialize()
+	if len(ga.Populations[0].Individuals) != 10 {
+		t.Error("The populations should have their initial size after initializing the GA")
+	}
+	// The populations can't grow beyond the memory limit
+	ga.MemoryLimit = EstimateMemory(2, 20, ga.Populations[0].Individuals[0])
+	rs.Apply(&ga)
+	rs.Apply(&ga)
+	if len(ga.Populations[0].Individuals) != 20 {
+		t.Errorf("Expected the populations to stop at 20 individuals, got %d", len(ga.Populations[0].Individuals))
+	}
+}
@@ -0,0 +1,105 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RobustFunction evaluates each genome under perturbations in order to find
+// solutions that are insensitive to manufacturing tolerances or to noise on
+// their inputs. Function is applied to Samples perturbed copies of the genome,
+// 10 if Samples is 0, and the fitness is the worst of the perturbed fitnesses if
+// Worst is true, else their mean. By default each float64 gene is perturbed by
+// a value drawn uniformly in [-Tolerance, Tolerance] and the other genes are
+// left unchanged, Perturb can be provided to perturb the genomes differently,
+// in which case Tolerance is ignored. Perturb shouldn't modify the genome it
+// receives.
+//
+// The mean and the worst perturbed fitnesses are stored in the Objectives
+// field of each individual, hence a multi-objective model can trade the
+// performance off against the robustness, and the Sensitivity field of the
+// GA's statistics is the gap between them for the best individual. The
+// perturbations are drawn from a random number generator seeded with Seed, or
+// with the current time if Seed is 0. The generator is shared by the
+// populations, hence RobustFunction has to be used through a pointer.
+type RobustFunction struct {
+	Function  FitnessFunction
+	Samples   int
+	Tolerance float64
+	Perturb   func(genome Genome, rng *rand.Rand) Genome
+	Worst     bool
+	Seed      int64
+	mu        sync.Mutex
+	rng       *rand.Rand
+}
+
+// Return a perturbed copy of a genome.
+func (ff *RobustFunction) perturb(genome Genome, rng *rand.Rand) Genome {
+	if ff.Perturb != nil {
+		return ff.Perturb(genome, rng)
+	}
+	var perturbed = make(Genome, len(genome))
+	copy(perturbed, genome)
+	for i, gene := range perturbed {
+		if x, ok := gene.(float64); ok {
+			perturbed[i] = x + ff.Tolerance*(2*rng.Float64()-1)
+		}
+	}
+	return perturbed
+}
+
+// Apply the fitness function wrapped in RobustFunction.
+func (ff *RobustFunction) apply(genome Genome) float64 {
+	return ff.aggregate(ff.applyObjectives(genome))
+}
+
+// Compute the mean and the worst fitness of the perturbed genomes.
+func (ff *RobustFunction) applyObjectives(genome Genome) []float64 {
+	var samples = ff.Samples
+	if samples == 0 {
+		samples = 10
+	}
+	// Draw the perturbations with the shared generator, the evaluations are
+	// done outside of the lock
+	var perturbed = make([]Genome, samples)
+	ff.mu.Lock()
+	if ff.rng == nil {
+		var seed = ff.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		ff.rng = rand.New(rand.NewSource(seed))
+	}
+	for i := range perturbed {
+		perturbed[i] = ff.perturb(genome, ff.rng)
+	}
+	ff.mu.Unlock()
+	var (
+		fitnesses = make([]float64, samples)
+		worst     = math.Inf(-1)
+	)
+	for i, p := range perturbed {
+		fitnesses[i] = ff.Function.apply(p)
+		worst = math.Max(worst, fitnesses[i])
+	}
+	return []float64{mean(fitnesses), worst}
+}
+
+// Aggregate the mean and the worst fitness depending on Worst.
+func (ff *RobustFunction) aggregate(objectives []float64) float64 {
+	if ff.Worst {
+		return objectives[1]
+	}
+	return objectives[0]
+}
+
+// Check if a fitness function is a RobustFunction, possibly constrained.
+func isRobust(ff FitnessFunction) bool {
+	if cf, ok := ff.(ConstrainedFunction); ok {
+		ff = cf.Function
+	}
+	var _, ok = ff.(*RobustFunction)
+	return ok
+}
@@ -0,0 +1,86 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRobustFunction(t *testing.T) {
+	var (
+		ff         = &RobustFunction{Function: sphere, Samples: 200, Tolerance: 1, Seed: 42}
+		objectives = ff.applyObjectives(Genome{0.0})
+	)
+	// The mean of x² for x uniform in [-1, 1] is 1/3
+	if math.Abs(objectives[0]-1.0/3) > 0.1 {
+		t.Errorf("Expected a mean fitness close to 1/3, got %f", objectives[0])
+	}
+	if objectives[1] < objectives[0] || objectives[1] > 1 {
+		t.Errorf("Unexpected worst fitness %f", objectives[1])
+	}
+	if ff.apply(Genome{0.0}) > 1 {
+		t.Error("The mean fitness is higher than the worst possible fitness")
+	}
+	ff.Worst = true
+	if ff.aggregate(objectives) != objectives[1] {
+		t.Error("The worst fitness wasn't used")
+	}
+	// Without tolerance every sample is the nominal genome
+	ff.Tolerance = 0
+	if objectives = ff.applyObjectives(Genome{2.0}); objectives[0] != 4 || objectives[1] != 4 {
+		t.Error("The genome was perturbed without tolerance")
+	}
+}
+
+func TestRobustFunctionPerturb(t *testing.T) {
+	var (
+		calls int
+		ff    = &RobustFunction{
+			Function: sphere,
+			Samples:  5,
+			Perturb: func(genome Genome, rng *rand.Rand) Genome {
+				calls++
+				return Genome{genome[0].(float64) + 1}
+			},
+			Seed: 42,
+		}
+		original = Genome{1.0}
+	)
+	if ff.apply(original) != 4 || calls != 5 {
+		t.Error("The genomes weren't perturbed by Perturb")
+	}
+	if original[0] != 1.0 {
+		t.Error("The original genome was modified")
+	}
+	// The genes that aren't float64 are left unchanged by default
+	ff = &RobustFunction{
+		Function: GenomeFunction{func(genome Genome) float64 {
+			if genome[1] != "label" {
+				return 1
+			}
+			return 0
+		}},
+		Tolerance: 1,
+		Seed:      42,
+	}
+	if ff.apply(Genome{0.0, "label"}) != 0 {
+		t.Error("A gene that isn't a float64 was perturbed")
+	}
+}
+
+func TestRobustStats(t *testing.T) {
+	var ga = NewFloatGA(2, -5, 5, func(x []float64) float64 { return 0 })
+	ga.Ff = &RobustFunction{Function: sphere, Samples: 5, Tolerance: 0.5, Seed: 42}
+	ga.Initialize()
+	ga.Enhance()
+	var (
+		best  = ga.Best()
+		stats = ga.Stats()
+	)
+	if len(best.Objectives) != 2 || best.Fitness != best.Objectives[0] {
+		t.Error("The mean and the worst fitness weren't stored")
+	}
+	if stats.Sensitivity <= 0 || stats.Sensitivity != best.Objectives[1]-best.Objectives[0] {
+		t.Errorf("Unexpected sensitivity %f", stats.Sensitivity)
+	}
+}
@@ -0,0 +1,168 @@
+package gago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// TerminationReason tells why a run stopped.
+type TerminationReason int
+
+// The reasons a run can stop for.
+const (
+	GenerationsReached TerminationReason = iota // The GA ran MaxGenerations generations
+	EvaluationsReached                          // The GA spent MaxEvaluations evaluations
+	DurationReached                             // The run lasted MaxDuration
+	TargetReached                               // Success returned true
+	Stagnated                                   // The best individual didn't improve for MaxStagnation generations
+	Cancelled                                   // The context of the run was cancelled
+	Interrupted                                 // The process received an interrupt signal
+	Failed                                      // The run couldn't be started, went wrong or it's results couldn't be written
+)
+
+// String returns the name of the reason.
+func (reason TerminationReason) String() string {
+	switch reason {
+	case GenerationsReached:
+		return "max generations"
+	case EvaluationsReached:
+		return "max evaluations"
+	case DurationReached:
+		return "max duration"
+	case TargetReached:
+		return "target reached"
+	case Stagnated:
+		return "stagnation"
+	case Cancelled:
+		return "cancelled"
+	case Interrupted:
+		return "interrupted"
+	case Failed:
+		return "failed"
+	}
+	return fmt.Sprintf("TerminationReason(%d)", int(reason))
+}
+
+// RunOptions tell Run when and how to stop a run. The run stops once the GA
+// has run MaxGenerations generations or spent MaxEvaluations evaluations since
+// it was initialized, once the run has lasted MaxDuration, once Success
+// returns true for the statistics of the GA, for example if the best fitness
+// is below a target, or once the best individual hasn't improved for
+// MaxStagnation generations. A criterion is ignored if it's 0 or nil, the run
+// goes on until it's cancelled or interrupted if they all are.
+//
+// If TrapInterrupts is true an interrupt signal, for instance sent by
+// pressing Ctrl+C, stops the run instead of killing the process. Once the run
+// has stopped the state of the GA is written to Checkpoint with
+// SaveCheckpoint, so that the run can be resumed with LoadCheckpoint, the
+// final statistics are written to Report in a human readable format and, if
+// the GA has a BestHistory, the genomes of the best individual are written to
+// History as a CSV table. The writers are optional.
+type RunOptions struct {
+	MaxGenerations int
+	MaxEvaluations int
+	MaxDuration    time.Duration
+	MaxStagnation  int
+	Success        func(stats Stats) bool
+	TrapInterrupts bool
+	Checkpoint     io.Writer
+	Report         io.Writer
+	History        io.Writer
+}
+
+// Validate the options to verify the parameters are coherent.
+func (opts RunOptions) Validate() error {
+	if opts.MaxGenerations < 0 || opts.MaxEvaluations < 0 || opts.MaxDuration < 0 || opts.MaxStagnation < 0 {
+		return errors.New("'MaxGenerations', 'MaxEvaluations', 'MaxDuration' and 'MaxStagnation' should be positive")
+	}
+	return nil
+}
+
+// Return the reason why a run should stop before running the next
+// generation, ok is false if the run should go on.
+func (opts RunOptions) check(ga *GA, start time.Time) (reason TerminationReason, ok bool) {
+	switch {
+	case opts.Success != nil && opts.Success(ga.Stats()):
+		return TargetReached, true
+	case opts.MaxGenerations > 0 && ga.Generations >= opts.MaxGenerations:
+		return GenerationsReached, true
+	case opts.MaxEvaluations > 0 && ga.Evaluations >= opts.MaxEvaluations:
+		return EvaluationsReached, true
+	case opts.MaxDuration > 0 && time.Since(start) >= opts.MaxDuration:
+		return DurationReached, true
+	case opts.MaxStagnation > 0 && ga.Stagnation >= opts.MaxStagnation:
+		return Stagnated, true
+	}
+	return 0, false
+}
+
+// Run runs generations of an initialized GA until one of the criteria of opts
+// is met, ctx is cancelled or, if opts.TrapInterrupts is true, the process
+// receives an interrupt signal. The criteria are checked between generations,
+// hence the generation that is being run is always completed and the GA is
+// left in a coherent state. Run returns the final statistics and the reason
+// why the run stopped. If the GA isn't initialized, if opts is invalid, if the
+// Guard of the GA records an error or if the checkpoint, the report or the
+// history can't be written the reason is Failed and the error is returned.
+func (ga *GA) Run(ctx context.Context, opts RunOptions) (Stats, TerminationReason, error) {
+	if len(ga.Populations) == 0 {
+		return Stats{}, Failed, errors.New("the GA should be initialized before being run")
+	}
+	if err := opts.Validate(); err != nil {
+		return ga.Stats(), Failed, err
+	}
+	var interrupts chan os.Signal
+	if opts.TrapInterrupts {
+		interrupts = make(chan os.Signal, 1)
+		signal.Notify(interrupts, os.Interrupt)
+		defer signal.Stop(interrupts)
+	}
+	var (
+		start  = time.Now()
+		reason TerminationReason
+	)
+loop:
+	for {
+		// Check for a cancellation or an interruption between generations,
+		// the interrupts channel is nil and never ready if they aren't trapped
+		select {
+		case <-ctx.Done():
+			reason = Cancelled
+			break loop
+		case <-interrupts:
+			reason = Interrupted
+			break loop
+		default:
+		}
+		if ga.Guard != nil && ga.Guard.Err() != nil {
+			return ga.Stats(), Failed, ga.Guard.Err()
+		}
+		var ok bool
+		if reason, ok = opts.check(ga, start); ok {
+			break
+		}
+		ga.Enhance()
+	}
+	var stats = ga.Stats()
+	if opts.Checkpoint != nil {
+		if err := ga.SaveCheckpoint(opts.Checkpoint); err != nil {
+			return stats, Failed, err
+		}
+	}
+	if opts.Report != nil {
+		if _, err := fmt.Fprintf(opts.Report, "Termination: %s\n%s", reason, stats); err != nil {
+			return stats, Failed, err
+		}
+	}
+	if opts.History != nil && ga.History != nil {
+		if err := ga.History.WriteCSV(opts.History); err != nil {
+			return stats, Failed, err
+		}
+	}
+	return stats, reason, nil
+}
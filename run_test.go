@@ -0,0 +1,140 @@
+package gago
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRunGA() GA {
+	return GA{
+		NbrPopulations: 2,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+	}
+}
+
+func TestRunGenerations(t *testing.T) {
+	var (
+		g      = newRunGA()
+		report bytes.Buffer
+	)
+	g.Initialize()
+	var stats, reason, err = g.Run(context.Background(), RunOptions{MaxGenerations: 3, Report: &report})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason != GenerationsReached || stats.Generations != 3 {
+		t.Error("The run should have completed it's generations")
+	}
+	if !strings.Contains(report.String(), "Termination: max generations") {
+		t.Error("The report should contain the termination reason")
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	var (
+		g           = newRunGA()
+		checkpoint  bytes.Buffer
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	g.Initialize()
+	g.Enhance()
+	cancel()
+	var stats, reason, err = g.Run(ctx, RunOptions{Checkpoint: &checkpoint})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason != Cancelled || stats.Generations != 1 {
+		t.Error("A cancelled run shouldn't run any generation")
+	}
+	// The checkpoint should resume the run
+	var resumed = newRunGA()
+	if err := resumed.LoadCheckpoint(&checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	if resumed.Generations != 1 || resumed.Best().Fitness != g.Best().Fitness {
+		t.Error("The checkpoint doesn't hold the state of the interrupted run")
+	}
+}
+
+func TestRunInterrupted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupt signals can't be sent on Windows")
+	}
+	var (
+		armed int32
+		g     = newRunGA()
+	)
+	// Send an interrupt signal during the first generation
+	g.Ff = Float64Function{
+		Image: func(X []float64) float64 {
+			if atomic.CompareAndSwapInt32(&armed, 1, 0) {
+				var p, _ = os.FindProcess(os.Getpid())
+				p.Signal(os.Interrupt)
+			}
+			return ff.Image(X)
+		},
+	}
+	g.Initialize()
+	atomic.StoreInt32(&armed, 1)
+	var stats, reason, err = g.Run(context.Background(), RunOptions{TrapInterrupts: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason != Interrupted || stats.Generations < 1 {
+		t.Error("The run should have been interrupted after completing a generation")
+	}
+}
+
+func TestRunCriteria(t *testing.T) {
+	var cases = []struct {
+		opts   RunOptions
+		reason TerminationReason
+	}{
+		{RunOptions{MaxEvaluations: 200}, EvaluationsReached},
+		{RunOptions{MaxDuration: time.Millisecond}, DurationReached},
+		{RunOptions{MaxStagnation: 1, MaxGenerations: 1000}, Stagnated},
+		{RunOptions{Success: func(stats Stats) bool { return stats.Generations >= 2 }}, TargetReached},
+	}
+	for i, c := range cases {
+		var g = newRunGA()
+		g.Ff = Float64Function{Image: func(X []float64) float64 { return 1 }}
+		g.Initialize()
+		var stats, reason, err = g.Run(context.Background(), c.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reason != c.reason {
+			t.Errorf("Test %d: expected %s, got %s", i, c.reason, reason)
+		}
+		if reason == EvaluationsReached && stats.Evaluations < 200 {
+			t.Error("The run stopped before spending it's evaluations")
+		}
+	}
+}
+
+func TestRunFailed(t *testing.T) {
+	var g = newRunGA()
+	if _, reason, err := g.Run(context.Background(), RunOptions{MaxGenerations: 1}); reason != Failed || err == nil {
+		t.Error("Running a GA that isn't initialized should fail")
+	}
+	g.Initialize()
+	if _, reason, err := g.Run(context.Background(), RunOptions{MaxGenerations: -1}); reason != Failed || err == nil {
+		t.Error("Running a GA with invalid options should fail")
+	}
+}
+
+func TestTerminationReasonString(t *testing.T) {
+	if Interrupted.String() != "interrupted" || TerminationReason(42).String() != "TerminationReason(42)" {
+		t.Error("Wrong termination reason names")
+	}
+}
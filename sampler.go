@@ -0,0 +1,96 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+)
+
+// A GeneSampler draws a random value for the i-th gene of a genome. It encodes
+// the prior distribution of each dimension of a problem once, InitSampler then
+// uses it to create the initial genomes and MutReset to reset genes during
+// mutation.
+type GeneSampler interface {
+	Sample(i int, rng *rand.Rand) interface{}
+}
+
+// SampleUniformF samples floating points x such that Lower <= x < Upper.
+type SampleUniformF struct {
+	Lower, Upper float64
+}
+
+// Sample a gene.
+func (s SampleUniformF) Sample(i int, rng *rand.Rand) interface{} {
+	return s.Lower + rng.Float64()*(s.Upper-s.Lower)
+}
+
+// SampleLogUniformF samples floating points x such that Lower <= x < Upper
+// whose logarithm is uniformly distributed, which suits scale parameters such
+// as learning rates. Lower and Upper should be strictly positive.
+type SampleLogUniformF struct {
+	Lower, Upper float64
+}
+
+// Sample a gene.
+func (s SampleLogUniformF) Sample(i int, rng *rand.Rand) interface{} {
+	var lower, upper = math.Log(s.Lower), math.Log(s.Upper)
+	return math.Exp(lower + rng.Float64()*(upper-lower))
+}
+
+// SampleUniformI samples integers x such that Lower <= x <= Upper.
+type SampleUniformI struct {
+	Lower, Upper int
+}
+
+// Sample a gene.
+func (s SampleUniformI) Sample(i int, rng *rand.Rand) interface{} {
+	return s.Lower + rng.Intn(s.Upper-s.Lower+1)
+}
+
+// SampleGrid samples one of the values of a grid uniformly, for example a set
+// of batch sizes or of activation functions.
+type SampleGrid struct {
+	Values []interface{}
+}
+
+// Sample a gene.
+func (s SampleGrid) Sample(i int, rng *rand.Rand) interface{} {
+	return s.Values[rng.Intn(len(s.Values))]
+}
+
+// SamplePerGene samples the i-th gene with the i-th sampler, the last sampler
+// is used for the genes beyond the number of samplers.
+type SamplePerGene []GeneSampler
+
+// Sample a gene.
+func (s SamplePerGene) Sample(i int, rng *rand.Rand) interface{} {
+	return s[min(i, len(s)-1)].Sample(i, rng)
+}
+
+// InitSampler generates genomes whose genes are drawn from a GeneSampler.
+type InitSampler struct {
+	Sampler GeneSampler
+}
+
+// Apply the InitSampler initializer.
+func (init InitSampler) Apply(indi *Individual, rng *rand.Rand) {
+	for i := range indi.Genome {
+		indi.Genome[i] = init.Sampler.Sample(i, rng)
+	}
+}
+
+// MutReset replaces each gene with probability Rate with a new value drawn
+// from a GeneSampler, which keeps the genes within the prior of their
+// dimension.
+type MutReset struct {
+	Sampler GeneSampler
+	Rate    float64
+}
+
+// Apply reset mutation.
+func (mut MutReset) Apply(indi *Individual, rng *rand.Rand) {
+	for i := range indi.Genome {
+		if rng.Float64() < mut.Rate {
+			indi.Genome[i] = mut.Sampler.Sample(i, rng)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestGeneSamplers(t *testing.T) {
+	var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 100; i++ {
+		if x := (SampleUniformF{-1, 1}).Sample(i, rng).(float64); x < -1 || x >= 1 {
+			t.Errorf("%f is out of [-1, 1)", x)
+		}
+		if x := (SampleLogUniformF{1e-4, 1e-1}).Sample(i, rng).(float64); x < 1e-4 || x >= 1e-1 {
+			t.Errorf("%f is out of [1e-4, 1e-1)", x)
+		}
+		if x := (SampleUniformI{2, 4}).Sample(i, rng).(int); x < 2 || x > 4 {
+			t.Errorf("%d is out of [2, 4]", x)
+		}
+		if x := (SampleGrid{[]interface{}{"a", "b"}}).Sample(i, rng); x != "a" && x != "b" {
+			t.Errorf("%v is not in the grid", x)
+		}
+	}
+}
+
+func TestSampleLogUniformF(t *testing.T) {
+	// Half of the values should be below the geometric mean of the bounds
+	var (
+		rng     = rand.New(rand.NewSource(42))
+		sampler = SampleLogUniformF{1e-4, 1}
+		below   int
+	)
+	for i := 0; i < 1000; i++ {
+		if sampler.Sample(0, rng).(float64) < 1e-2 {
+			below++
+		}
+	}
+	if below < 400 || below > 600 {
+		t.Errorf("Expected about 500 values below 1e-2, got %d", below)
+	}
+}
+
+func TestInitSamplerAndMutReset(t *testing.T) {
+	var (
+		rng     = rand.New(rand.NewSource(time.Now().UnixNano()))
+		sampler = SamplePerGene{
+			SampleLogUniformF{1e-3, 1e-1},
+			SampleGrid{[]interface{}{16, 32, 64}},
+		}
+		check = func(genome Genome) {
+			if x := genome[0].(float64); x < 1e-3 || x >= 1e-1 {
+				t.Errorf("%f is out of the prior of the first gene", x)
+			}
+			for _, gene := range genome[1:] {
+				if x := gene.(int); x != 16 && x != 32 && x != 64 {
+					t.Errorf("%d is out of the grid", x)
+				}
+			}
+		}
+		indi = makeIndividual(3, rng)
+	)
+	InitSampler{sampler}.Apply(&indi, rng)
+	check(indi.Genome)
+	var mut = MutReset{Sampler: sampler, Rate: 1}
+	for i := 0; i < 10; i++ {
+		mut.Apply(&indi, rng)
+		check(indi.Genome)
+	}
+	// A rate of 0 leaves the genome untouched
+	var genome = append(Genome{}, indi.Genome...)
+	MutReset{Sampler: sampler}.Apply(&indi, rng)
+	for i := range genome {
+		if genome[i] != indi.Genome[i] {
+			t.Error("The genome was modified")
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package gago
+
+import "math"
+
+// A Scalarizer reduces the objectives of a multi-objective problem to a single
+// value given a weight for each objective. Each weight vector leads to a
+// different point of the Pareto front, hence a front can be approximated with
+// single-objective runs when a full multi-objective algorithm is overkill.
+type Scalarizer interface {
+	Apply(objectives, weights []float64) float64
+}
+
+// ScalWeightedSum is the weighted sum of the objectives. It is the simplest
+// scalarizing function but it can only reach the convex parts of a front.
+type ScalWeightedSum struct{}
+
+// Apply the weighted sum.
+func (scal ScalWeightedSum) Apply(objectives, weights []float64) float64 {
+	var total float64
+	for i, obj := range objectives {
+		total += weights[i] * obj
+	}
+	return total
+}
+
+// ScalTchebycheff is the largest weighted distance between an objective and
+// the matching coordinate of the Ideal point, which is the origin if Ideal is
+// nil. Contrary to the weighted sum every point of a front can be reached.
+type ScalTchebycheff struct {
+	Ideal []float64
+}
+
+// Apply the Tchebycheff function.
+func (scal ScalTchebycheff) Apply(objectives, weights []float64) float64 {
+	var max = math.Inf(-1)
+	for i, obj := range objectives {
+		if scal.Ideal != nil {
+			obj -= scal.Ideal[i]
+		}
+		max = math.Max(max, weights[i]*math.Abs(obj))
+	}
+	return max
+}
+
+// ScalASF is the augmented achievement scalarizing function of Wierzbicki. It
+// measures how far the objectives are from the Reference point, which is the
+// origin if Reference is nil, and unlike the Tchebycheff function it keeps
+// improving once the reference point is attained. The augmentation term, which
+// is weighted by Rho, prevents weakly Pareto optimal solutions from being
+// preferred, a small value such as 1e-6 is usually enough.
+type ScalASF struct {
+	Reference []float64
+	Rho       float64
+}
+
+// Apply the achievement scalarizing function.
+func (scal ScalASF) Apply(objectives, weights []float64) float64 {
+	var (
+		max   = math.Inf(-1)
+		total float64
+	)
+	for i, obj := range objectives {
+		if scal.Reference != nil {
+			obj -= scal.Reference[i]
+		}
+		max = math.Max(max, weights[i]*obj)
+		total += weights[i] * obj
+	}
+	return max + scal.Rho*total
+}
+
+// ScalarizedFunction is for multi-objective functions whose objectives are
+// reduced to a single fitness with a Scalarizer and a vector of Weights. The
+// objectives are still stored in the Objectives field of each individual.
+type ScalarizedFunction struct {
+	Image      func(Genome) []float64
+	Scalarizer Scalarizer
+	Weights    []float64
+}
+
+// Apply the fitness function wrapped in ScalarizedFunction.
+func (ff ScalarizedFunction) apply(genome Genome) float64 {
+	return ff.aggregate(ff.Image(genome))
+}
+
+// Compute the objectives with the function wrapped in ScalarizedFunction.
+func (ff ScalarizedFunction) applyObjectives(genome Genome) []float64 {
+	return ff.Image(genome)
+}
+
+// Aggregate the objectives with the scalarizing function.
+func (ff ScalarizedFunction) aggregate(objectives []float64) float64 {
+	return ff.Scalarizer.Apply(objectives, ff.Weights)
+}
+
+// Sweep runs a GA for each vector of weights and returns the non-dominated
+// individuals out of the best individual of each run, sorted by their first
+// objective. Each GA is obtained by calling newGA, it's fitness function is
+// replaced with a ScalarizedFunction and it is enhanced nbGenerations times.
+// The weight vectors can be generated with WeightVectors.
+func Sweep(newGA func() GA, image func(Genome) []float64, scal Scalarizer, weights [][]float64, nbGenerations int) Individuals {
+	var archive = &ParetoArchive{}
+	for _, w := range weights {
+		var ga = newGA()
+		ga.Ff = ScalarizedFunction{
+			Image:      image,
+			Scalarizer: scal,
+			Weights:    w,
+		}
+		ga.Initialize()
+		for i := 0; i < nbGenerations; i++ {
+			ga.Enhance()
+		}
+		archive.Add(ga.Best())
+	}
+	return archive.Front()
+}
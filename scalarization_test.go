@@ -0,0 +1,74 @@
+package gago
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScalarizers(t *testing.T) {
+	var (
+		objs      = []float64{1, 3}
+		weights   = []float64{0.5, 0.25}
+		testCases = []struct {
+			scal   Scalarizer
+			output float64
+		}{
+			{ScalWeightedSum{}, 1.25},
+			{ScalTchebycheff{}, 0.75},
+			{ScalTchebycheff{Ideal: []float64{2, 0}}, 0.75},
+			{ScalTchebycheff{Ideal: []float64{-1, 3}}, 1},
+			{ScalASF{}, 0.75},
+			{ScalASF{Reference: []float64{2, 4}, Rho: 0.1}, -0.25 - 0.075},
+		}
+	)
+	for _, test := range testCases {
+		if out := test.scal.Apply(objs, weights); math.Abs(out-test.output) > 1e-10 {
+			t.Errorf("%T: expected %f, got %f", test.scal, test.output, out)
+		}
+	}
+}
+
+func TestScalarizedFunction(t *testing.T) {
+	var (
+		ff = ScalarizedFunction{
+			Image:      func(genome Genome) []float64 { return []float64{genome[0].(float64), 2} },
+			Scalarizer: ScalWeightedSum{},
+			Weights:    []float64{1, 3},
+		}
+		indi = Individual{Genome: Genome{1.0}}
+	)
+	indi.Evaluate(ff)
+	if indi.Fitness != 7 {
+		t.Errorf("Expected a fitness of 7, got %f", indi.Fitness)
+	}
+	if len(indi.Objectives) != 2 || indi.Objectives[1] != 2 {
+		t.Error("The objectives weren't stored")
+	}
+}
+
+func TestSweep(t *testing.T) {
+	var (
+		newGA = func() GA {
+			return GA{
+				NbrPopulations: 1,
+				NbrIndividuals: 20,
+				NbrGenes:       1,
+				Initializer:    InitUniformF{Lower: 0, Upper: 2},
+				Model:          model,
+			}
+		}
+		image = func(genome Genome) []float64 {
+			var x = genome[0].(float64)
+			return []float64{x * x, (x - 2) * (x - 2)}
+		}
+		front = Sweep(newGA, image, ScalTchebycheff{}, WeightVectors(2, 4), 10)
+	)
+	if len(front) < 2 {
+		t.Errorf("Expected several non-dominated individuals, got %d", len(front))
+	}
+	for i := 1; i < len(front); i++ {
+		if front[i].Objectives[0] < front[i-1].Objectives[0] {
+			t.Error("The front should be sorted by the first objective")
+		}
+	}
+}
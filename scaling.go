@@ -0,0 +1,76 @@
+package gago
+
+import "math"
+
+// A Scale gathers the parameters of a GA that depend on the size of the
+// problem.
+type Scale struct {
+	NbrIndividuals int     // Number of individuals in each population
+	NbParticipants int     // Number of participants in each tournament
+	MutRate        float64 // Probability of mutating an offspring
+	GeneRate       float64 // Probability of mutating each gene of a mutated offspring
+}
+
+// A Scaler picks the Scale of a GA from the number of genes of the problem,
+// which spares beginners from guessing sensible parameters.
+type Scaler interface {
+	Apply(nbGenes int) Scale
+}
+
+// ScaleLog makes the population size grow with the logarithm of the number of
+// genes n, as CMA-ES does: each population contains 4 + 3 ln(n) individuals,
+// the logarithm being rounded down, multiplied by Factor, which is 3 if Factor
+// is 0. The size of the tournaments grows with the logarithm of the population
+// size, starting from 2. Every offspring is mutated and each of it's genes is
+// mutated with probability 1/n, hence one gene is mutated on average.
+type ScaleLog struct {
+	Factor float64
+}
+
+// Apply the logarithmic scale.
+func (scale ScaleLog) Apply(nbGenes int) Scale {
+	var factor = scale.Factor
+	if factor == 0 {
+		factor = 3
+	}
+	var n = math.Max(float64(nbGenes), 1)
+	var nbIndis = max(int(math.Round(factor*(4+math.Floor(3*math.Log(n))))), 2)
+	return Scale{
+		NbrIndividuals: nbIndis,
+		NbParticipants: max(int(math.Round(math.Log(float64(nbIndis)))), 2),
+		MutRate:        1,
+		GeneRate:       1 / n,
+	}
+}
+
+// NewScaledGA returns a GA whose population size, tournament size and mutation
+// rates are picked by a Scaler from the number of genes, ScaleLog is used if
+// scaler is nil. The mutator is built by mutator from the probability of
+// mutating each gene, for example:
+//
+//	func(rate float64) Mutator { return MutNormalF{Rate: rate, Std: 1} }
+//
+// The GA runs two populations with a generational model and tournament
+// selection, they exchange individuals every 10 generations. Every field of the
+// returned GA can be changed before calling Initialize.
+func NewScaledGA(nbGenes int, ff FitnessFunction, init Initializer, cross Crossover, mutator func(rate float64) Mutator, scaler Scaler) GA {
+	if scaler == nil {
+		scaler = ScaleLog{}
+	}
+	var scale = scaler.Apply(nbGenes)
+	return GA{
+		NbrPopulations: 2,
+		NbrIndividuals: scale.NbrIndividuals,
+		NbrGenes:       nbGenes,
+		Ff:             ff,
+		Initializer:    init,
+		Model: ModGenerational{
+			Selector:  SelTournament{NbParticipants: scale.NbParticipants},
+			Crossover: cross,
+			Mutator:   mutator(scale.GeneRate),
+			MutRate:   scale.MutRate,
+		},
+		Migrator:     MigShuffle{},
+		MigFrequency: 10,
+	}
+}
@@ -0,0 +1,54 @@
+package gago
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScaleLog(t *testing.T) {
+	var testCases = []struct {
+		scale    ScaleLog
+		nbGenes  int
+		expected Scale
+	}{
+		{ScaleLog{}, 1, Scale{12, 2, 1, 1}},
+		{ScaleLog{}, 10, Scale{30, 3, 1, 0.1}},
+		{ScaleLog{}, 100, Scale{51, 4, 1, 0.01}},
+		{ScaleLog{Factor: 1}, 10, Scale{10, 2, 1, 0.1}},
+		{ScaleLog{Factor: 0.01}, 0, Scale{2, 2, 1, 1}},
+	}
+	for i, test := range testCases {
+		var scale = test.scale.Apply(test.nbGenes)
+		if scale.NbrIndividuals != test.expected.NbrIndividuals ||
+			scale.NbParticipants != test.expected.NbParticipants ||
+			scale.MutRate != test.expected.MutRate ||
+			math.Abs(scale.GeneRate-test.expected.GeneRate) > 1e-10 {
+			t.Errorf("Test case %d: expected %v, got %v", i, test.expected, scale)
+		}
+	}
+}
+
+func TestNewScaledGA(t *testing.T) {
+	var ga = NewScaledGA(
+		nbGenes,
+		ff,
+		initializer,
+		CrossUniformF{},
+		func(rate float64) Mutator { return MutNormalF{Rate: rate, Std: 1} },
+		nil,
+	)
+	if err := ga.Validate(); err != nil {
+		t.Error(err)
+	}
+	var scale = ScaleLog{}.Apply(nbGenes)
+	if ga.NbrIndividuals != scale.NbrIndividuals {
+		t.Errorf("Expected %d individuals, got %d", scale.NbrIndividuals, ga.NbrIndividuals)
+	}
+	var mod = ga.Model.(ModGenerational)
+	if mod.Selector.(SelTournament).NbParticipants != scale.NbParticipants ||
+		mod.Mutator.(MutNormalF).Rate != scale.GeneRate {
+		t.Error("The model doesn't use the scaled parameters")
+	}
+	ga.Initialize()
+	ga.Enhance()
+}
@@ -1,6 +1,11 @@
 package gago
 
-import "math/rand"
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
 
 // Selector chooses a subset of size n from a group of individuals.
 type Selector interface {
@@ -9,9 +14,16 @@ type Selector interface {
 
 // SelTournament selection chooses an individual through tournament selection.
 // The tournament is composed of randomly chosen individuals. The winner of the
-// tournament is the individual with the lowest fitness.
+// tournament is the individual with the lowest fitness with probability Prob,
+// otherwise the winner is chosen uniformly among the other participants. A
+// Prob of 0 is treated as 1, in which case the tournament is deterministic.
+// Lowering Prob lowers the selection pressure, which helps on deceptive
+// problems. The participants are compared with Comparator, or by fitness if
+// Comparator is nil.
 type SelTournament struct {
 	NbParticipants int
+	Prob           float64
+	Comparator     Comparator
 }
 
 // Apply tournament selection.
@@ -23,15 +35,32 @@ func (sel SelTournament) Apply(n int, indis Individuals, rng *rand.Rand) (Indivi
 	for i := range winners {
 		// Sample the GA
 		var roundIndexes, sample = indis.sample(sel.NbParticipants, rng)
+		// Sort the participants while keeping track of their indexes
+		sort.Sort(byComparator{sample, roundIndexes, sel.Comparator})
 		// The winner is the best individual participating in the tournament
-		sample.Sort()
-		indexes[i] = roundIndexes[0]
-		winners[i] = sample[0]
+		// unless the tournament is probabilistic
+		var w = 0
+		if sel.Prob > 0 && len(sample) > 1 && rng.Float64() >= sel.Prob {
+			w = 1 + rng.Intn(len(sample)-1)
+		}
+		indexes[i] = roundIndexes[w]
+		winners[i] = sample[w]
 	}
 	return winners, indexes
 }
 
-// SelElitism selection returns the best individuals in the GA.
+// Validate the parameters of a SelTournament.
+func (sel SelTournament) Validate() error {
+	// Check the probability value
+	if !(sel.Prob >= 0 && sel.Prob <= 1) {
+		return errors.New("'Prob' should belong to [0, 1]")
+	}
+	return nil
+}
+
+// SelElitism selection returns the best individuals in the GA. The
+// individuals are expected to be sorted from the best to the worst, which is
+// the case of the populations that the GA sorts with it's Comparator.
 type SelElitism struct{}
 
 // Apply elitism selection.
@@ -42,3 +71,273 @@ func (sel SelElitism) Apply(n int, indis Individuals, rng *rand.Rand) (Individua
 	}
 	return indis[:n], indexes
 }
+
+// SelRandom selection chooses n distinct individuals uniformly at random, it
+// doesn't apply any selection pressure.
+type SelRandom struct{}
+
+// Apply random selection.
+func (sel SelRandom) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	var indexes, sample = indis.sample(n, rng)
+	return sample, indexes
+}
+
+// SelDiverse selection chooses n distinct individuals that are as far as
+// possible from each other according to Metric. The first individual is the
+// best one, each following individual is the one whose distance to the
+// closest of the chosen individuals is the largest. The individuals are
+// expected to be sorted from the best to the worst, ties are broken in favour
+// of the best individuals.
+type SelDiverse struct {
+	Metric DistanceMetric
+}
+
+// Apply diverse selection.
+func (sel SelDiverse) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	var (
+		indexes  = make([]int, 0, n)
+		selected = make(Individuals, 0, n)
+		closest  = make([]float64, len(indis)) // Distance to the closest chosen individual
+		chosen   = make([]bool, len(indis))
+	)
+	for i := range closest {
+		closest[i] = math.Inf(1)
+	}
+	for len(indexes) < n {
+		// Choose the individual that is the farthest from the chosen ones
+		var next = -1
+		for i := range indis {
+			if !chosen[i] && (next == -1 || closest[i] > closest[next]) {
+				next = i
+			}
+		}
+		chosen[next] = true
+		indexes = append(indexes, next)
+		selected = append(selected, indis[next])
+		for i := range indis {
+			if !chosen[i] {
+				closest[i] = math.Min(closest[i], sel.Metric.Apply(indis[i], indis[next]))
+			}
+		}
+	}
+	return selected, indexes
+}
+
+// SelLexicase selection filters the individuals through the test cases of a
+// CasesFunction taken in a random order. For each case only the individuals
+// with the lowest error on the case are kept, the process stops when a single
+// individual remains or when every case has been used, in which case one of the
+// remaining individuals is chosen at random. Lexicase selection favors
+// specialists that solve hard cases which would be drowned in the sum of the
+// errors otherwise. Missing and NaN errors are considered infinite.
+type SelLexicase struct{}
+
+// Apply lexicase selection.
+func (sel SelLexicase) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	var (
+		indexes = make([]int, n)
+		winners = make(Individuals, n)
+	)
+	for i := range winners {
+		indexes[i] = lexicase(indis, rng, func(c int, errors []float64) float64 {
+			return min64(errors)
+		})
+		winners[i] = indis[indexes[i]]
+	}
+	return winners, indexes
+}
+
+// Return the highest number of cases of a slice of individuals. The
+// individuals may have been evaluated on different numbers of cases, their
+// error on a case they don't have is considered infinite.
+func nbCases(indis Individuals) int {
+	var n int
+	for _, indi := range indis {
+		if len(indi.Cases) > n {
+			n = len(indi.Cases)
+		}
+	}
+	return n
+}
+
+// Return the error of an individual on a case. A missing error or a NaN error
+// is considered infinite so that it can be compared to the other errors.
+func caseError(indi Individual, c int) float64 {
+	if c >= len(indi.Cases) || math.IsNaN(indi.Cases[c]) {
+		return math.Inf(1)
+	}
+	return indi.Cases[c]
+}
+
+// Filter a slice of individuals through randomly ordered cases and return the
+// index of the winner. For each case c, the individuals whose error is higher
+// than the threshold computed from the errors of the remaining individuals are
+// discarded.
+func lexicase(indis Individuals, rng *rand.Rand, threshold func(c int, errors []float64) float64) int {
+	var candidates = make([]int, len(indis))
+	for i := range candidates {
+		candidates[i] = i
+	}
+	for _, c := range rng.Perm(nbCases(indis)) {
+		if len(candidates) == 1 {
+			break
+		}
+		var errors = make([]float64, len(candidates))
+		for i, candidate := range candidates {
+			errors[i] = caseError(indis[candidate], c)
+		}
+		var (
+			limit     = threshold(c, errors)
+			survivors = make([]int, 0, len(candidates))
+		)
+		for i, candidate := range candidates {
+			if errors[i] <= limit {
+				survivors = append(survivors, candidate)
+			}
+		}
+		// Keep the candidates if none of them survives the case, which happens
+		// if the threshold isn't a number
+		if len(survivors) > 0 {
+			candidates = survivors
+		}
+	}
+	return candidates[rng.Intn(len(candidates))]
+}
+
+// SelEpsilonLexicase selection works like SelLexicase except that for each
+// case the individuals whose error is within epsilon of the lowest error are
+// kept. This makes lexicase selection usable with continuous errors, where
+// exact ties are rare. If Epsilon is 0 then a different epsilon is computed
+// for each case as the median absolute deviation of the errors of the
+// individuals on the case.
+type SelEpsilonLexicase struct {
+	Epsilon float64
+}
+
+// Apply epsilon lexicase selection.
+func (sel SelEpsilonLexicase) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	var (
+		indexes  = make([]int, n)
+		winners  = make(Individuals, n)
+		epsilons = make([]float64, nbCases(indis))
+	)
+	for c := range epsilons {
+		if sel.Epsilon > 0 {
+			epsilons[c] = sel.Epsilon
+			continue
+		}
+		// The deviation is computed on the individuals that have the case
+		var errors []float64
+		for _, indi := range indis {
+			if c < len(indi.Cases) {
+				errors = append(errors, caseError(indi, c))
+			}
+		}
+		epsilons[c] = medianAbsoluteDeviation(errors)
+		// The deviation isn't defined if the errors are infinite
+		if math.IsNaN(epsilons[c]) {
+			epsilons[c] = 0
+		}
+	}
+	for i := range winners {
+		indexes[i] = lexicase(indis, rng, func(c int, errors []float64) float64 {
+			return min64(errors) + epsilons[c]
+		})
+		winners[i] = indis[indexes[i]]
+	}
+	return winners, indexes
+}
+
+// Select n individuals with replacement where the probability of choosing an
+// individual only depends on it's rank according to cmp. weight returns the
+// weight of the individual of rank r, the best individual having rank 0.
+func rankSelect(n int, indis Individuals, cmp Comparator, rng *rand.Rand, weight func(r int) float64) (Individuals, []int) {
+	var (
+		ranked     = make([]int, len(indis))
+		cumulative = make([]float64, len(indis))
+		total      float64
+	)
+	for i := range ranked {
+		ranked[i] = i
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return less(cmp, indis[ranked[i]], indis[ranked[j]])
+	})
+	for r := range ranked {
+		total += weight(r)
+		cumulative[r] = total
+	}
+	var (
+		indexes = make([]int, n)
+		winners = make(Individuals, n)
+	)
+	for i := range winners {
+		var r = sort.SearchFloat64s(cumulative, rng.Float64()*total)
+		if r == len(cumulative) {
+			r--
+		}
+		indexes[i] = ranked[r]
+		winners[i] = indis[ranked[r]]
+	}
+	return winners, indexes
+}
+
+// SelLinearRanking selection chooses individuals with a probability that
+// decreases linearly with their rank. Only the order of the fitnesses matters,
+// not their magnitude. Pressure should belong to [1, 2] and is the expected
+// number of times the best individual is chosen out of n draws of n
+// individuals, the worst individual is chosen 2 - Pressure times. A pressure
+// of 1 amounts to uniform selection. Like every selector it can be used for
+// survivor selection, for example as the SelectorB of ModDownToSize. The
+// individuals are ranked with Comparator, or by fitness if Comparator is nil.
+type SelLinearRanking struct {
+	Pressure   float64
+	Comparator Comparator
+}
+
+// Apply linear ranking selection.
+func (sel SelLinearRanking) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	var size = float64(len(indis))
+	return rankSelect(n, indis, sel.Comparator, rng, func(r int) float64 {
+		if size == 1 {
+			return 1
+		}
+		return 2 - sel.Pressure + 2*(sel.Pressure-1)*(size-1-float64(r))/(size-1)
+	})
+}
+
+// Validate the parameters of a SelLinearRanking.
+func (sel SelLinearRanking) Validate() error {
+	// Check the pressure value, the weights of the worst individuals would
+	// be negative above 2
+	if !(sel.Pressure >= 1 && sel.Pressure <= 2) {
+		return errors.New("'Pressure' should belong to [1, 2]")
+	}
+	return nil
+}
+
+// SelExponentialRanking selection chooses the individual of rank r (the best
+// individual having rank 0) with a probability proportional to Base^r, where
+// Base should belong to (0, 1). The lower Base is, the higher the selection
+// pressure. The individuals are ranked with Comparator, or by fitness if
+// Comparator is nil.
+type SelExponentialRanking struct {
+	Base       float64
+	Comparator Comparator
+}
+
+// Apply exponential ranking selection.
+func (sel SelExponentialRanking) Apply(n int, indis Individuals, rng *rand.Rand) (Individuals, []int) {
+	return rankSelect(n, indis, sel.Comparator, rng, func(r int) float64 {
+		return math.Pow(sel.Base, float64(r))
+	})
+}
+
+// Validate the parameters of a SelExponentialRanking.
+func (sel SelExponentialRanking) Validate() error {
+	// Check the base value
+	if !(sel.Base > 0 && sel.Base < 1) {
+		return errors.New("'Base' should belong to (0, 1)")
+	}
+	return nil
+}
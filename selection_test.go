@@ -1,7 +1,9 @@
 package gago
 
 import (
+	"math"
 	"math/rand"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -22,7 +24,7 @@ func TestTournament(t *testing.T) {
 	copy(original, indis)
 	// All the individuals participate in the tournament
 	var (
-		selector  = SelTournament{size}
+		selector  = SelTournament{NbParticipants: size}
 		sample, _ = selector.Apply(size, indis, rng)
 	)
 	// Check the size of the sample
@@ -92,7 +94,7 @@ func TestTournamentAndElitism(t *testing.T) {
 	// All the individuals participate in the tournament
 	var (
 		elitism    = SelElitism{}
-		tournament = SelTournament{size}
+		tournament = SelTournament{NbParticipants: size}
 		elite, _   = elitism.Apply(size, indis, rng)
 		tourney, _ = tournament.Apply(size, indis, rng)
 	)
@@ -103,3 +105,260 @@ func TestTournamentAndElitism(t *testing.T) {
 		t.Error("Elitism and full tournament selection differed")
 	}
 }
+
+func TestProbabilisticTournament(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = Individuals{
+			Individual{Name: "a", Fitness: 2},
+			Individual{Name: "b", Fitness: 0},
+			Individual{Name: "c", Fitness: 1},
+		}
+		selector      = SelTournament{NbParticipants: 3, Prob: 0.5}
+		sample, index = selector.Apply(1000, indis, rng)
+		counts        = make(map[string]int)
+	)
+	for i, indi := range sample {
+		if indis[index[i]].Name != indi.Name {
+			t.Error("Tournament selection returned incoherent indexes")
+		}
+		counts[indi.Name]++
+	}
+	// The best individual wins half of the tournaments
+	if counts["b"] < 400 || counts["b"] > 600 {
+		t.Error("The best individual didn't win with the given probability")
+	}
+	if counts["a"] == 0 || counts["c"] == 0 {
+		t.Error("The other participants never won")
+	}
+}
+
+func TestTournamentValidate(t *testing.T) {
+	for _, prob := range []float64{0, 0.5, 1} {
+		if err := (SelTournament{NbParticipants: 3, Prob: prob}).Validate(); err != nil {
+			t.Errorf("A probability of %v should be valid, got %v", prob, err)
+		}
+	}
+	for _, prob := range []float64{-0.1, 1.5, math.NaN()} {
+		if (SelTournament{NbParticipants: 3, Prob: prob}).Validate() == nil {
+			t.Errorf("A probability of %v should be rejected", prob)
+		}
+	}
+}
+
+func TestLexicase(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = Individuals{
+			Individual{Name: "a", Cases: []float64{0, 5}, Fitness: 5},
+			Individual{Name: "b", Cases: []float64{5, 0}, Fitness: 5},
+			Individual{Name: "c", Cases: []float64{1, 1}, Fitness: 2},
+		}
+		selector      = SelLexicase{}
+		sample, index = selector.Apply(100, indis, rng)
+		counts        = make(map[string]int)
+	)
+	for i, indi := range sample {
+		if indis[index[i]].Name != indi.Name {
+			t.Error("Lexicase selection returned incoherent indexes")
+		}
+		counts[indi.Name]++
+	}
+	// The generalist is never the best on any case
+	if counts["c"] != 0 {
+		t.Error("Lexicase selection chose an individual that isn't the best on any case")
+	}
+	if counts["a"] == 0 || counts["b"] == 0 {
+		t.Error("Lexicase selection didn't choose the specialists")
+	}
+}
+
+func TestEpsilonLexicase(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = Individuals{
+			Individual{Name: "a", Cases: []float64{0.0, 5.0}},
+			Individual{Name: "b", Cases: []float64{0.01, 0.0}},
+			Individual{Name: "c", Cases: []float64{9.0, 9.0}},
+		}
+		selector  = SelEpsilonLexicase{Epsilon: 0.1}
+		sample, _ = selector.Apply(100, indis, rng)
+		counts    = make(map[string]int)
+	)
+	for _, indi := range sample {
+		counts[indi.Name]++
+	}
+	// b is within epsilon of a on the first case and the best on the second
+	// case, hence it always wins
+	if counts["b"] != 100 {
+		t.Error("Epsilon lexicase selection didn't account for epsilon")
+	}
+	// Check the automatic epsilon doesn't break selection
+	var automatic = SelEpsilonLexicase{}
+	if sample, _ = automatic.Apply(10, indis, rng); len(sample) != 10 {
+		t.Error("Wrong sample size")
+	}
+}
+
+func TestEpsilonLexicaseUnevenCases(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = Individuals{
+			Individual{Name: "a"},
+			Individual{Name: "b", Cases: []float64{0.0}},
+			Individual{Name: "c", Cases: []float64{1.0, 0.0, math.Inf(1)}},
+		}
+	)
+	// The missing cases are infinite errors, hence a never wins
+	for _, selector := range []Selector{SelLexicase{}, SelEpsilonLexicase{}, SelEpsilonLexicase{Epsilon: 0.1}} {
+		var sample, _ = selector.Apply(50, indis, rng)
+		for _, indi := range sample {
+			if indi.Name == "a" {
+				t.Errorf("%T chose an individual without cases", selector)
+			}
+		}
+	}
+}
+
+func TestLexicaseNaN(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = Individuals{
+			Individual{Name: "a", Cases: []float64{math.NaN(), 1}},
+			Individual{Name: "b", Cases: []float64{math.NaN(), 0}},
+		}
+	)
+	// NaN errors are infinite, hence b is the best on every case
+	for _, selector := range []Selector{SelLexicase{}, SelEpsilonLexicase{}, SelEpsilonLexicase{Epsilon: 0.1}} {
+		var sample, _ = selector.Apply(20, indis, rng)
+		for _, indi := range sample {
+			if indi.Name != "b" {
+				t.Errorf("%T chose an individual that isn't the best on any case", selector)
+			}
+		}
+	}
+}
+
+func TestRankingSelection(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = Individuals{
+			Individual{Name: "a", Fitness: 1e9},
+			Individual{Name: "b", Fitness: -1e9},
+			Individual{Name: "c", Fitness: 0},
+		}
+		selectors = []Selector{
+			SelLinearRanking{Pressure: 2},
+			SelExponentialRanking{Base: 0.5},
+		}
+	)
+	for _, selector := range selectors {
+		var (
+			sample, index = selector.Apply(3000, indis, rng)
+			counts        = make(map[string]int)
+		)
+		for i, indi := range sample {
+			if indis[index[i]].Name != indi.Name {
+				t.Error("Ranking selection returned incoherent indexes")
+			}
+			counts[indi.Name]++
+		}
+		if counts["b"] <= counts["c"] || counts["c"] <= counts["a"] {
+			t.Error("Ranking selection didn't favor the best ranked individuals")
+		}
+	}
+	// A pressure of 2 never chooses the worst individual
+	var sample, _ = SelLinearRanking{Pressure: 2}.Apply(100, indis, rng)
+	for _, indi := range sample {
+		if indi.Name == "a" {
+			t.Error("Linear ranking with a pressure of 2 chose the worst individual")
+		}
+	}
+}
+
+func BenchmarkSelectors(b *testing.B) {
+	var (
+		rng       = rand.New(rand.NewSource(42))
+		indis     = makeIndividuals(1000, 1, rng)
+		selectors = []Selector{
+			SelTournament{NbParticipants: 3},
+			SelElitism{},
+			SelLinearRanking{Pressure: 2},
+			SelExponentialRanking{Base: 0.5},
+		}
+	)
+	for i := range indis {
+		indis[i].Fitness = rng.Float64()
+	}
+	indis.Sort()
+	for _, sel := range selectors {
+		b.Run(reflect.TypeOf(sel).Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sel.Apply(100, indis, rng)
+			}
+		})
+	}
+}
+
+func TestSelRandom(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(42))
+		indis = makeIndividuals(10, 2, rng)
+	)
+	var selected, indexes = SelRandom{}.Apply(5, indis, rng)
+	var seen = make(map[int]bool)
+	for i, index := range indexes {
+		if seen[index] || selected[i].Name != indis[index].Name {
+			t.Error("SelRandom didn't choose distinct individuals")
+		}
+		seen[index] = true
+	}
+}
+
+func TestSelDiverse(t *testing.T) {
+	var indis = Individuals{
+		{Genome: Genome{0.0}, Fitness: 0},
+		{Genome: Genome{0.1}, Fitness: 1},
+		{Genome: Genome{5.0}, Fitness: 2},
+		{Genome: Genome{2.0}, Fitness: 3},
+		{Genome: Genome{5.1}, Fitness: 4},
+	}
+	var _, indexes = SelDiverse{DistEuclidean{}}.Apply(3, indis, nil)
+	if !reflect.DeepEqual(indexes, []int{0, 4, 3}) {
+		t.Errorf("Expected [0 4 3], got %v", indexes)
+	}
+}
+
+func TestRankingValidate(t *testing.T) {
+	var (
+		valid = []Selector{
+			SelLinearRanking{Pressure: 1},
+			SelLinearRanking{Pressure: 2},
+			SelExponentialRanking{Base: 0.5},
+		}
+		wrong = []Selector{
+			SelLinearRanking{},
+			SelLinearRanking{Pressure: 2.5},
+			SelLinearRanking{Pressure: math.NaN()},
+			SelExponentialRanking{},
+			SelExponentialRanking{Base: 1},
+			SelExponentialRanking{Base: -0.5},
+		}
+	)
+	for _, sel := range valid {
+		if err := sel.(interface{ Validate() error }).Validate(); err != nil {
+			t.Errorf("%v should be valid, got %v", sel, err)
+		}
+	}
+	for _, sel := range wrong {
+		if sel.(interface{ Validate() error }).Validate() == nil {
+			t.Errorf("%v should be rejected", sel)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package gago
+
+import "fmt"
+
+// Low-discrepancy sequences cover the unit hypercube more evenly than
+// pseudo-random numbers. The i-th point of a sequence is computed directly
+// from i, which means a point can be generated without storing the previous
+// ones.
+
+// Generate the first n prime numbers.
+func primes(n int) []int {
+	var ps = make([]int, 0, n)
+	for candidate := 2; len(ps) < n; candidate++ {
+		var isPrime = true
+		for _, p := range ps {
+			if p*p > candidate {
+				break
+			}
+			if candidate%p == 0 {
+				isPrime = false
+				break
+			}
+		}
+		if isPrime {
+			ps = append(ps, candidate)
+		}
+	}
+	return ps
+}
+
+// Compute the radical inverse of an index in a given base, in other words
+// mirror the digits of the index around the decimal point.
+func radicalInverse(index uint64, base int) float64 {
+	var (
+		b        = uint64(base)
+		inverse  float64
+		fraction = 1 / float64(base)
+	)
+	for index > 0 {
+		inverse += float64(index%b) * fraction
+		index /= b
+		fraction /= float64(base)
+	}
+	return inverse
+}
+
+// Compute the point of a Halton sequence in dim dimensions at a given index.
+// The j-th coordinate is the radical inverse of the index in the j-th prime
+// base.
+func haltonPoint(index uint64, dim int) []float64 {
+	var (
+		bases = primes(dim)
+		point = make([]float64, dim)
+	)
+	for j, base := range bases {
+		point[j] = radicalInverse(index, base)
+	}
+	return point
+}
+
+// Number of bits used to represent each Sobol coordinate.
+const sobolBits = 32
+
+// Primitive polynomials and initial direction numbers for the Sobol sequence,
+// taken from the table of Joe and Kuo (new-joe-kuo-6.21201). The first
+// dimension is the van der Corput sequence in base 2 and isn't listed.
+var sobolTable = []struct {
+	s int      // Degree of the primitive polynomial
+	a uint32   // Coefficients of the primitive polynomial
+	m []uint32 // Initial direction numbers
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+	{5, 11, []uint32{1, 1, 5, 1, 1}},
+	{5, 13, []uint32{1, 1, 1, 3, 11}},
+	{5, 14, []uint32{1, 3, 5, 5, 31}},
+	{6, 1, []uint32{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint32{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint32{1, 3, 1, 13, 27, 49}},
+	{6, 19, []uint32{1, 1, 1, 15, 7, 5}},
+	{6, 22, []uint32{1, 3, 1, 15, 13, 25}},
+	{6, 25, []uint32{1, 1, 5, 5, 19, 61}},
+	{7, 1, []uint32{1, 3, 7, 11, 23, 15, 103}},
+	{7, 4, []uint32{1, 3, 7, 13, 13, 15, 69}},
+}
+
+// SobolMaxDim is the highest number of dimensions the Sobol sequence supports,
+// it is equal to the number of rows in sobolTable plus one.
+const SobolMaxDim = 21
+
+// Compute the direction numbers of each dimension of the Sobol sequence.
+func sobolDirections(dim int) [][sobolBits]uint32 {
+	var directions = make([][sobolBits]uint32, dim)
+	// The first dimension uses the identity matrix
+	for k := 0; k < sobolBits; k++ {
+		directions[0][k] = 1 << uint(sobolBits-1-k)
+	}
+	for j := 1; j < dim; j++ {
+		var (
+			row = sobolTable[j-1]
+			v   = &directions[j]
+			s   = row.s
+		)
+		for k := 0; k < s && k < sobolBits; k++ {
+			v[k] = row.m[k] << uint(sobolBits-1-k)
+		}
+		// Apply the recurrence relation defined by the primitive polynomial
+		for k := s; k < sobolBits; k++ {
+			v[k] = v[k-s] ^ (v[k-s] >> uint(s))
+			for i := 1; i < s; i++ {
+				if (row.a>>uint(s-1-i))&1 == 1 {
+					v[k] ^= v[k-i]
+				}
+			}
+		}
+	}
+	return directions
+}
+
+// Compute the point of a Sobol sequence in dim dimensions at a given index.
+// The points are generated in Gray code order, which doesn't change the set
+// of points obtained after 2^k indexes.
+func sobolPoint(index uint64, directions [][sobolBits]uint32) []float64 {
+	var (
+		gray  = index ^ (index >> 1)
+		point = make([]float64, len(directions))
+	)
+	for j, v := range directions {
+		var x uint32
+		for k := 0; k < sobolBits && gray>>uint(k) > 0; k++ {
+			if (gray>>uint(k))&1 == 1 {
+				x ^= v[k]
+			}
+		}
+		point[j] = float64(x) / (1 << sobolBits)
+	}
+	return point
+}
+
+// Check a number of dimensions can be handled by the Sobol sequence.
+func checkSobolDim(dim int) error {
+	if dim > SobolMaxDim {
+		return fmt.Errorf("the Sobol sequence supports at most %d dimensions, got %d", SobolMaxDim, dim)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package gago
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrimes(t *testing.T) {
+	var target = []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+	for i, p := range primes(len(target)) {
+		if p != target[i] {
+			t.Error("primes didn't generate the right prime numbers")
+		}
+	}
+}
+
+func TestRadicalInverse(t *testing.T) {
+	var testCases = []struct {
+		index   uint64
+		base    int
+		inverse float64
+	}{
+		{0, 2, 0},
+		{1, 2, 0.5},
+		{2, 2, 0.25},
+		{3, 2, 0.75},
+		{1, 3, 1.0 / 3},
+		{5, 3, 2.0/3 + 1.0/9},
+	}
+	for _, testCase := range testCases {
+		if math.Abs(radicalInverse(testCase.index, testCase.base)-testCase.inverse) > 1e-12 {
+			t.Error("radicalInverse didn't work as expected")
+		}
+	}
+}
+
+func TestSobolMaxDim(t *testing.T) {
+	if len(sobolTable)+1 != SobolMaxDim {
+		t.Error("SobolMaxDim doesn't match the size of the direction numbers table")
+	}
+}
+
+func TestSobolStratification(t *testing.T) {
+	// The first 2^k points of each dimension should fall into distinct
+	// intervals of size 1/2^k
+	var (
+		k          = uint(8)
+		n          = 1 << k
+		directions = sobolDirections(SobolMaxDim)
+		seen       = make([][]bool, SobolMaxDim)
+	)
+	for j := range seen {
+		seen[j] = make([]bool, n)
+	}
+	for i := 0; i < n; i++ {
+		for j, x := range sobolPoint(uint64(i), directions) {
+			if x < 0 || x >= 1 {
+				t.Error("Sobol point is not in the unit hypercube")
+			}
+			var cell = int(x * float64(n))
+			if seen[j][cell] {
+				t.Errorf("Sobol sequence isn't stratified in dimension %d", j)
+			}
+			seen[j][cell] = true
+		}
+	}
+}
+
+func TestHaltonPoint(t *testing.T) {
+	var point = haltonPoint(1, 3)
+	if point[0] != 0.5 || point[1] != 1.0/3 || point[2] != 0.2 {
+		t.Error("haltonPoint didn't use the right bases")
+	}
+}
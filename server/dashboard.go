@@ -0,0 +1,277 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A Point records the progress of a GA at a generation.
+type Point struct {
+	Generation  int   `json:"generation"`
+	Evaluations int   `json:"evaluations"`
+	Best        Float `json:"best"`
+	Mean        Float `json:"mean"`
+	Diversity   Float `json:"diversity"`
+}
+
+// An Island describes the state of a population of a GA.
+type Island struct {
+	Best       Float `json:"best"`
+	Mean       Float `json:"mean"`
+	Size       int   `json:"size"`
+	Stagnation int   `json:"stagnation"`
+}
+
+// An Update is sent to the dashboard each time a generation is recorded.
+type Update struct {
+	Point   Point    `json:"point"`
+	Islands []Island `json:"islands"`
+	Best    Best     `json:"best"`
+}
+
+// A Dashboard is an http.Handler that displays the progress of a GA in a web
+// page: the convergence of the best and mean fitnesses, the diversity, the
+// status of each island and the current best genome. The GA is recorded with
+// Record, typically after each call to Enhance, and every recorded generation
+// is pushed to the open pages through a stream of server-sent events, hence
+// the page is live. The dashboard is embeddable in any HTTP server, for
+// example with http.Handle("/dashboard/", http.StripPrefix("/dashboard",
+// dashboard)), and the Server provides one for each run. The routes are the
+// following:
+//
+//	GET /        the web page
+//	GET /data    the recorded history, the islands and the best individual as JSON
+//	GET /events  the stream of Updates as server-sent events
+//
+// The diversity is the mean distance between the individuals according to
+// Metric, or the standard deviation of the fitnesses if Metric is nil. At most
+// MaxPoints points are kept, the oldest points being dropped, a MaxPoints of 0
+// is treated as 1000. A Dashboard has to be used through a pointer.
+type Dashboard struct {
+	Metric      gago.DistanceMetric
+	MaxPoints   int
+	mu          sync.Mutex
+	history     []Point
+	last        Update
+	subscribers map[chan []byte]struct{}
+}
+
+// Record the current generation of a GA and push it to the open pages. Record
+// shouldn't be called while the GA is being enhanced. An error is returned if
+// the update can't be encoded as JSON, for example because the genome of the
+// best individual contains genes that can't be, in which case the generation
+// is recorded but isn't pushed.
+func (d *Dashboard) Record(ga *gago.GA) error {
+	var (
+		stats  = ga.Stats()
+		best   = ga.Best()
+		update = Update{
+			Point: Point{
+				Generation:  stats.Generations,
+				Evaluations: stats.Evaluations,
+				Best:        Float(stats.Best),
+				Mean:        Float(stats.Mean),
+			},
+			Islands: make([]Island, len(ga.Populations)),
+			Best:    Best{Genome: best.Genome, Fitness: Float(best.Fitness)},
+		}
+	)
+	if d.Metric != nil {
+		var indis gago.Individuals
+		for _, pop := range ga.Populations {
+			indis = append(indis, pop.Individuals...)
+		}
+		update.Point.Diversity = Float(indis.Diversity(d.Metric))
+	} else {
+		update.Point.Diversity = Float(math.Sqrt(stats.Variance))
+	}
+	for i, pop := range ga.Populations {
+		update.Islands[i] = Island{
+			Best:       Float(stats.Populations[i].Best),
+			Mean:       Float(stats.Populations[i].Mean),
+			Size:       len(pop.Individuals),
+			Stagnation: pop.Stagnation,
+		}
+	}
+	var message, err = json.Marshal(update)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var maxPoints = d.MaxPoints
+	if maxPoints == 0 {
+		maxPoints = 1000
+	}
+	d.history = append(d.history, update.Point)
+	if len(d.history) > maxPoints {
+		d.history = d.history[len(d.history)-maxPoints:]
+	}
+	d.last = update
+	if err != nil {
+		return err
+	}
+	// Slow pages miss updates rather than blocking the GA
+	for subscriber := range d.subscribers {
+		select {
+		case subscriber <- message:
+		default:
+		}
+	}
+	return nil
+}
+
+// Register a page that listens to the updates.
+func (d *Dashboard) subscribe() chan []byte {
+	var subscriber = make(chan []byte, 16)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.subscribers == nil {
+		d.subscribers = make(map[chan []byte]struct{})
+	}
+	d.subscribers[subscriber] = struct{}{}
+	return subscriber
+}
+
+// Unregister a page.
+func (d *Dashboard) unsubscribe(subscriber chan []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.subscribers, subscriber)
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	switch strings.Trim(req.URL.Path, "/") {
+	case "":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, dashboardPage)
+	case "data":
+		d.mu.Lock()
+		var data = struct {
+			History []Point  `json:"history"`
+			Islands []Island `json:"islands"`
+			Best    Best     `json:"best"`
+		}{append([]Point{}, d.history...), d.last.Islands, d.last.Best}
+		d.mu.Unlock()
+		writeJSON(w, http.StatusOK, data)
+	case "events":
+		var flusher, ok = w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		var subscriber = d.subscribe()
+		defer d.unsubscribe(subscriber)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+		for {
+			select {
+			case message := <-subscriber:
+				fmt.Fprintf(w, "data: %s\n\n", message)
+				flusher.Flush()
+			case <-req.Context().Done():
+				return
+			}
+		}
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// The page of the dashboard, it draws the plots on canvases and has no
+// dependencies. The Floats are converted with Number, the infinite values
+// aren't plotted.
+const dashboardPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>gago dashboard</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+canvas { border: 1px solid #ccc; margin-right: 1em; }
+table { border-collapse: collapse; margin-top: 1em; }
+td, th { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: right; }
+pre { background: #f4f4f4; padding: 1em; white-space: pre-wrap; word-break: break-all; }
+</style>
+</head>
+<body>
+<h1>gago dashboard</h1>
+<p id="summary">Waiting for the first generation...</p>
+<canvas id="fitness" width="600" height="300"></canvas>
+<canvas id="diversity" width="600" height="300"></canvas>
+<h2>Islands</h2>
+<table id="islands"></table>
+<h2>Best genome</h2>
+<pre id="best"></pre>
+<script>
+var points = [], islands = [], best = null;
+
+function plot(id, series, colors) {
+	var canvas = document.getElementById(id), ctx = canvas.getContext("2d");
+	ctx.clearRect(0, 0, canvas.width, canvas.height);
+	var values = [].concat.apply([], series).filter(isFinite);
+	if (points.length < 2 || values.length == 0) return;
+	var min = Math.min.apply(null, values), max = Math.max.apply(null, values);
+	if (max == min) { max += 1; min -= 1; }
+	var x0 = points[0].generation, x1 = Math.max(points[points.length - 1].generation, x0 + 1);
+	series.forEach(function (ys, s) {
+		ctx.strokeStyle = colors[s];
+		ctx.beginPath();
+		ys.forEach(function (y, i) {
+			var px = 40 + (points[i].generation - x0) / (x1 - x0) * (canvas.width - 50);
+			var py = canvas.height - 20 - (y - min) / (max - min) * (canvas.height - 30);
+			if (i == 0) ctx.moveTo(px, py); else ctx.lineTo(px, py);
+		});
+		ctx.stroke();
+	});
+	ctx.fillStyle = "#222";
+	ctx.fillText(max.toPrecision(4), 2, 12);
+	ctx.fillText(min.toPrecision(4), 2, canvas.height - 20);
+	ctx.fillText(id, canvas.width / 2, canvas.height - 5);
+}
+
+function render() {
+	plot("fitness", [points.map(function (p) { return Number(p.best); }), points.map(function (p) { return Number(p.mean); })], ["#c0392b", "#2980b9"]);
+	plot("diversity", [points.map(function (p) { return Number(p.diversity); })], ["#27ae60"]);
+	var rows = "<tr><th>Island</th><th>Best</th><th>Mean</th><th>Size</th><th>Stagnation</th></tr>";
+	(islands || []).forEach(function (island, i) {
+		rows += "<tr><td>" + i + "</td><td>" + Number(island.best).toPrecision(6) + "</td><td>" + Number(island.mean).toPrecision(6) +
+			"</td><td>" + island.size + "</td><td>" + island.stagnation + "</td></tr>";
+	});
+	document.getElementById("islands").innerHTML = rows;
+	if (points.length > 0) {
+		var last = points[points.length - 1];
+		document.getElementById("summary").textContent = "Generation " + last.generation + ", " +
+			last.evaluations + " evaluations, best fitness " + last.best;
+	}
+	if (best) document.getElementById("best").textContent = JSON.stringify(best.genome);
+}
+
+fetch("data").then(function (r) { return r.json(); }).then(function (data) {
+	points = data.history || [];
+	islands = data.islands;
+	best = data.best;
+	render();
+	new EventSource("events").onmessage = function (e) {
+		var update = JSON.parse(e.data);
+		points.push(update.point);
+		if (points.length > 1000) points.shift();
+		islands = update.islands;
+		best = update.best;
+		render();
+	};
+});
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MaxHalford/gago"
+	"github.com/MaxHalford/gago/presets"
+)
+
+func TestDashboard(t *testing.T) {
+	var (
+		ga = presets.Float64(2, func(X []float64) float64 {
+			return X[0]*X[0] + X[1]*X[1]
+		})
+		dashboard = &Dashboard{Metric: gago.DistEuclidean{}, MaxPoints: 3}
+		ts        = httptest.NewServer(dashboard)
+	)
+	defer ts.Close()
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		dashboard.Record(&ga)
+		ga.Enhance()
+	}
+	// The page is served
+	var resp, err = http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var page, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(page), "<canvas") {
+		t.Error("The page wasn't served")
+	}
+	// The history is capped
+	var data struct {
+		History []Point  `json:"history"`
+		Islands []Island `json:"islands"`
+		Best    Best     `json:"best"`
+	}
+	get(t, ts.URL+"/data", &data)
+	if len(data.History) != 3 || data.History[2].Generation != 4 {
+		t.Errorf("Expected the last 3 generations, got %v", data.History)
+	}
+	if len(data.Islands) != ga.NbrPopulations || len(data.Best.Genome) != 2 {
+		t.Error("The islands or the best individual are missing")
+	}
+	if data.History[0].Diversity <= 0 {
+		t.Error("The diversity should be positive")
+	}
+	// The recorded generations are streamed
+	resp, err = http.Get(ts.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatal("The events aren't streamed")
+	}
+	var done = make(chan struct{})
+	defer close(done)
+	go func() {
+		// Record until the stream is read, the subscription might not be
+		// registered yet
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+				dashboard.Record(&ga)
+			}
+		}
+	}()
+	var line, _ = bufio.NewReader(resp.Body).ReadString('\n')
+	var update Update
+	if err = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &update); err != nil {
+		t.Fatal(err)
+	}
+	if update.Point.Generation != ga.Generations {
+		t.Errorf("Expected generation %d, got %d", ga.Generations, update.Point.Generation)
+	}
+}
+
+func TestRunDashboard(t *testing.T) {
+	var ts = newServer()
+	defer ts.Close()
+	var _, status = post(t, ts.URL, Config{Problem: "sphere", NbrIndividuals: 20, MaxGenerations: 3})
+	for status.Running {
+		time.Sleep(time.Millisecond)
+		get(t, ts.URL+"/runs/"+status.ID, &status)
+	}
+	var data struct {
+		History []Point `json:"history"`
+	}
+	if get(t, ts.URL+"/runs/"+status.ID+"/dashboard/data", &data) != http.StatusOK {
+		t.Fatal("The dashboard of the run wasn't served")
+	}
+	if len(data.History) != 4 {
+		t.Errorf("Expected 4 recorded generations, got %d", len(data.History))
+	}
+}
+
+func TestDashboardNonFinite(t *testing.T) {
+	var (
+		ga = presets.Float64(2, func(X []float64) float64 {
+			if X[0] < 0 {
+				return math.Inf(1)
+			}
+			return X[0]
+		})
+		dashboard = &Dashboard{}
+		ts        = httptest.NewServer(dashboard)
+	)
+	defer ts.Close()
+	ga.Initialize()
+	if err := dashboard.Record(&ga); err != nil {
+		t.Fatal(err)
+	}
+	var data struct {
+		History []Point `json:"history"`
+		Best    Best    `json:"best"`
+	}
+	if code := get(t, ts.URL+"/data", &data); code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if len(data.History) != 1 || !math.IsInf(float64(data.History[0].Mean), 1) {
+		t.Errorf("Expected an infinite mean, got %v", data.History)
+	}
+	// The infinite genes are encoded as well
+	var body, err = json.Marshal(Best{Genome: gago.Genome{math.Inf(-1), 1.5}, Fitness: Float(math.NaN())})
+	if err != nil || string(body) != `{"genome":["-Infinity",1.5],"fitness":"NaN"}` {
+		t.Errorf("Unexpected encoding %s: %v", body, err)
+	}
+	// Genes that can't be encoded are reported
+	ga = presets.Float64(2, func(X []float64) float64 { return 0 })
+	ga.Ff = gago.GenomeFunction{Image: func(genome gago.Genome) float64 { return 0 }}
+	ga.Initializer = chanInitializer{}
+	ga.Initialize()
+	if err := dashboard.Record(&ga); err == nil {
+		t.Error("Record should fail if the update can't be encoded")
+	}
+	if code := get(t, ts.URL+"/data", &data); code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", code)
+	}
+}
+
+// An initializer whose genes can't be encoded as JSON.
+type chanInitializer struct{}
+
+func (init chanInitializer) Apply(indi *gago.Individual, rng *rand.Rand) {
+	for i := range indi.Genome {
+		indi.Genome[i] = make(chan int)
+	}
+}
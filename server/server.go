@@ -0,0 +1,350 @@
+// Package server exposes GAs through an HTTP API. Because a GA contains
+// operators that can't be described with JSON, the problems are registered
+// with the server beforehand and runs are started by referring to a problem by
+// it's name. The API is the following:
+//
+//	GET    /problems             list the names of the registered problems
+//	POST   /runs                 start a run described by a Config, returns it's Status
+//	GET    /runs                 list the Status of every run
+//	GET    /runs/{id}            get the Status of a run
+//	GET    /runs/{id}/best       get the best individual of a run
+//	DELETE /runs/{id}            stop a run, returns it's Status
+//	GET    /runs/{id}/dashboard/ the live Dashboard of a run, see Dashboard
+//
+// The fitnesses and the statistics are Floats, the infinite and NaN values are
+// encoded as strings because JSON numbers can't represent them.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A Config describes a run. The sizes of the GA override the ones of the
+// problem if they are provided. The run stops once MaxGenerations generations
+// have been run or once MaxDuration (parsed with time.ParseDuration) has
+// elapsed, whichever comes first. A run without limits goes on until it is
+// stopped.
+type Config struct {
+	Problem        string `json:"problem"`
+	NbrPopulations int    `json:"nbrPopulations,omitempty"`
+	NbrIndividuals int    `json:"nbrIndividuals,omitempty"`
+	MaxGenerations int    `json:"maxGenerations,omitempty"`
+	MaxDuration    string `json:"maxDuration,omitempty"`
+}
+
+// A Float is a float64 whose infinite and NaN values are encoded in JSON as the
+// strings "Infinity", "-Infinity" and "NaN", which JavaScript's Number
+// converts back, whereas encoding/json fails on them. The fitness of an
+// individual that hasn't been evaluated is infinite, hence so are the
+// statistics of a GA that is being initialized.
+type Float float64
+
+// MarshalJSON implements the json.Marshaler interface.
+func (x Float) MarshalJSON() ([]byte, error) {
+	switch f := float64(x); {
+	case math.IsInf(f, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(f, -1):
+		return []byte(`"-Infinity"`), nil
+	case math.IsNaN(f):
+		return []byte(`"NaN"`), nil
+	}
+	return json.Marshal(float64(x))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (x *Float) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"Infinity"`:
+		*x = Float(math.Inf(1))
+	case `"-Infinity"`:
+		*x = Float(math.Inf(-1))
+	case `"NaN"`:
+		*x = Float(math.NaN())
+	default:
+		var f float64
+		if err := json.Unmarshal(data, &f); err != nil {
+			return err
+		}
+		*x = Float(f)
+	}
+	return nil
+}
+
+// A Status describes the progress of a run.
+type Status struct {
+	ID          string `json:"id"`
+	Problem     string `json:"problem"`
+	Running     bool   `json:"running"`
+	Generations int    `json:"generations"`
+	Evaluations int    `json:"evaluations"`
+	Duration    string `json:"duration"`
+	Best        Float  `json:"best"`
+	Mean        Float  `json:"mean"`
+	Variance    Float  `json:"variance"`
+	FrontSize   int    `json:"frontSize,omitempty"`
+	Hypervolume Float  `json:"hypervolume,omitempty"`
+	IGD         Float  `json:"igd,omitempty"`
+	Error       string `json:"error,omitempty"` // Why the run stopped, if it failed
+}
+
+// Update a status with the statistics of a GA.
+func (status *Status) update(stats gago.Stats) {
+	status.Generations = stats.Generations
+	status.Evaluations = stats.Evaluations
+	status.Duration = stats.Duration.String()
+	status.Best = Float(stats.Best)
+	status.Mean = Float(stats.Mean)
+	status.Variance = Float(stats.Variance)
+	status.FrontSize = stats.FrontSize
+	status.Hypervolume = Float(stats.Hypervolume)
+	status.IGD = Float(stats.IGD)
+}
+
+// A Best is the JSON representation of the best individual of a run.
+type Best struct {
+	Genome  gago.Genome `json:"genome"`
+	Fitness Float       `json:"fitness"`
+}
+
+// MarshalJSON implements the json.Marshaler interface, the floating point
+// genes are encoded as Floats.
+func (best Best) MarshalJSON() ([]byte, error) {
+	var genome = make([]interface{}, len(best.Genome))
+	for i, gene := range best.Genome {
+		switch g := gene.(type) {
+		case float64:
+			genome[i] = Float(g)
+		case gago.Vector:
+			var v = make([]Float, len(g))
+			for j, x := range g {
+				v[j] = Float(x)
+			}
+			genome[i] = v
+		default:
+			genome[i] = gene
+		}
+	}
+	return json.Marshal(struct {
+		Genome  []interface{} `json:"genome"`
+		Fitness Float         `json:"fitness"`
+	}{genome, best.Fitness})
+}
+
+// A run is a GA running in it's own goroutine.
+type run struct {
+	mu        sync.Mutex
+	ga        *gago.GA
+	status    Status
+	stop      chan struct{}
+	dashboard *Dashboard
+}
+
+// Get a copy of the status of a run.
+func (r *run) getStatus() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// Run generations until a limit is reached or until the run is stopped.
+func (r *run) loop(maxGenerations int, maxDuration time.Duration) {
+	var start = time.Now()
+	for {
+		select {
+		case <-r.stop:
+			r.mu.Lock()
+			r.status.Running = false
+			r.mu.Unlock()
+			return
+		default:
+		}
+		r.ga.Enhance()
+		var (
+			err   = r.dashboard.Record(r.ga)
+			stats = r.ga.Stats()
+		)
+		r.mu.Lock()
+		r.status.update(stats)
+		if err != nil {
+			r.status.Error = err.Error()
+			r.status.Running = false
+		}
+		if (maxGenerations > 0 && stats.Generations >= maxGenerations) ||
+			(maxDuration > 0 && time.Since(start) >= maxDuration) {
+			r.status.Running = false
+		}
+		var running = r.status.Running
+		r.mu.Unlock()
+		if !running {
+			return
+		}
+	}
+}
+
+// A Server runs GAs and reports their progress over HTTP. Problems maps the
+// name of each problem to a function returning a fresh GA configuration for
+// the problem, such as a preset. A Server has to be used through a pointer.
+type Server struct {
+	Problems map[string]func() gago.GA
+	mu       sync.Mutex
+	runs     map[string]*run
+	next     int
+}
+
+// Start a run and return it's status.
+func (s *Server) start(config Config) (Status, error) {
+	var newGA, ok = s.Problems[config.Problem]
+	if !ok {
+		return Status{}, errors.New("unknown problem '" + config.Problem + "'")
+	}
+	var maxDuration time.Duration
+	if config.MaxDuration != "" {
+		var err error
+		if maxDuration, err = time.ParseDuration(config.MaxDuration); err != nil {
+			return Status{}, err
+		}
+	}
+	var ga = newGA()
+	if config.NbrPopulations > 0 {
+		ga.NbrPopulations = config.NbrPopulations
+	}
+	if config.NbrIndividuals > 0 {
+		ga.NbrIndividuals = config.NbrIndividuals
+	}
+	if err := ga.Validate(); err != nil {
+		return Status{}, err
+	}
+	ga.Initialize()
+	s.mu.Lock()
+	if s.runs == nil {
+		s.runs = make(map[string]*run)
+	}
+	s.next++
+	var r = &run{
+		ga: &ga,
+		status: Status{
+			ID:      strconv.Itoa(s.next),
+			Problem: config.Problem,
+			Running: true,
+		},
+		stop:      make(chan struct{}),
+		dashboard: &Dashboard{},
+	}
+	r.status.update(ga.Stats())
+	if err := r.dashboard.Record(&ga); err != nil {
+		s.mu.Unlock()
+		return Status{}, err
+	}
+	s.runs[r.status.ID] = r
+	s.mu.Unlock()
+	go r.loop(config.MaxGenerations, maxDuration)
+	return r.getStatus(), nil
+}
+
+// Find a run by it's ID.
+func (s *Server) find(id string) (*run, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var r, ok = s.runs[id]
+	return r, ok
+}
+
+// Write a value as JSON with a status code, an internal server error is
+// written instead if the value can't be encoded.
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	var body, err = json.Marshal(v)
+	if err != nil {
+		code = http.StatusInternalServerError
+		body, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(append(body, '\n'))
+}
+
+// Write an error as JSON with a status code.
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var parts = strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "problems" && req.Method == http.MethodGet:
+		var names = make([]string, 0, len(s.Problems))
+		for name := range s.Problems {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		writeJSON(w, http.StatusOK, names)
+	case len(parts) == 1 && parts[0] == "runs" && req.Method == http.MethodPost:
+		var config Config
+		if err := json.NewDecoder(req.Body).Decode(&config); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		var status, err = s.start(config)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, status)
+	case len(parts) == 1 && parts[0] == "runs" && req.Method == http.MethodGet:
+		s.mu.Lock()
+		var statuses = make([]Status, 0, len(s.runs))
+		for _, r := range s.runs {
+			statuses = append(statuses, r.getStatus())
+		}
+		s.mu.Unlock()
+		sort.Slice(statuses, func(i, j int) bool {
+			var a, _ = strconv.Atoi(statuses[i].ID)
+			var b, _ = strconv.Atoi(statuses[j].ID)
+			return a < b
+		})
+		writeJSON(w, http.StatusOK, statuses)
+	case len(parts) >= 3 && parts[0] == "runs" && parts[2] == "dashboard":
+		var r, ok = s.find(parts[1])
+		if !ok {
+			writeError(w, http.StatusNotFound, errors.New("unknown run '"+parts[1]+"'"))
+			return
+		}
+		http.StripPrefix("/runs/"+parts[1]+"/dashboard", r.dashboard).ServeHTTP(w, req)
+	case len(parts) >= 2 && len(parts) <= 3 && parts[0] == "runs":
+		var r, ok = s.find(parts[1])
+		if !ok {
+			writeError(w, http.StatusNotFound, errors.New("unknown run '"+parts[1]+"'"))
+			return
+		}
+		switch {
+		case len(parts) == 2 && req.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, r.getStatus())
+		case len(parts) == 2 && req.Method == http.MethodDelete:
+			r.mu.Lock()
+			if r.status.Running {
+				close(r.stop)
+				r.status.Running = false
+			}
+			r.mu.Unlock()
+			writeJSON(w, http.StatusOK, r.getStatus())
+		case len(parts) == 3 && parts[2] == "best" && req.Method == http.MethodGet:
+			var best = r.ga.Best()
+			writeJSON(w, http.StatusOK, Best{Genome: best.Genome, Fitness: Float(best.Fitness)})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		}
+	default:
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MaxHalford/gago"
+	"github.com/MaxHalford/gago/presets"
+)
+
+func newServer() *httptest.Server {
+	return httptest.NewServer(&Server{
+		Problems: map[string]func() gago.GA{
+			"sphere": func() gago.GA {
+				return presets.Float64(2, func(X []float64) float64 {
+					return X[0]*X[0] + X[1]*X[1]
+				})
+			},
+		},
+	})
+}
+
+func post(t *testing.T, url string, config Config) (*http.Response, Status) {
+	var body, _ = json.Marshal(config)
+	var resp, err = http.Post(url+"/runs", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var status Status
+	json.NewDecoder(resp.Body).Decode(&status)
+	return resp, status
+}
+
+func get(t *testing.T, url string, v interface{}) int {
+	var resp, err = http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	json.NewDecoder(resp.Body).Decode(v)
+	return resp.StatusCode
+}
+
+func TestProblems(t *testing.T) {
+	var (
+		ts    = newServer()
+		names []string
+	)
+	defer ts.Close()
+	get(t, ts.URL+"/problems", &names)
+	if len(names) != 1 || names[0] != "sphere" {
+		t.Error("The registered problems weren't listed")
+	}
+}
+
+func TestRunToCompletion(t *testing.T) {
+	var ts = newServer()
+	defer ts.Close()
+	var resp, status = post(t, ts.URL, Config{Problem: "sphere", NbrIndividuals: 20, MaxGenerations: 5})
+	if resp.StatusCode != http.StatusCreated || status.ID == "" {
+		t.Fatal("The run wasn't started")
+	}
+	for status.Running {
+		time.Sleep(time.Millisecond)
+		get(t, ts.URL+"/runs/"+status.ID, &status)
+	}
+	if status.Generations != 5 {
+		t.Error("The run didn't stop after the maximum number of generations")
+	}
+	var best Best
+	if get(t, ts.URL+"/runs/"+status.ID+"/best", &best) != http.StatusOK {
+		t.Fatal("The best individual couldn't be fetched")
+	}
+	if len(best.Genome) != 2 || best.Fitness != status.Best {
+		t.Error("The best individual doesn't match the status of the run")
+	}
+	var statuses []Status
+	get(t, ts.URL+"/runs", &statuses)
+	if len(statuses) != 1 {
+		t.Error("The runs weren't listed")
+	}
+}
+
+func TestStopRun(t *testing.T) {
+	var ts = newServer()
+	defer ts.Close()
+	var _, status = post(t, ts.URL, Config{Problem: "sphere"})
+	var req, _ = http.NewRequest(http.MethodDelete, ts.URL+"/runs/"+status.ID, nil)
+	var resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	json.NewDecoder(resp.Body).Decode(&status)
+	resp.Body.Close()
+	if status.Running {
+		t.Error("The run wasn't stopped")
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	var ts = newServer()
+	defer ts.Close()
+	if resp, _ := post(t, ts.URL, Config{Problem: "unknown"}); resp.StatusCode != http.StatusBadRequest {
+		t.Error("An unknown problem should be rejected")
+	}
+	if resp, _ := post(t, ts.URL, Config{Problem: "sphere", MaxDuration: "soon"}); resp.StatusCode != http.StatusBadRequest {
+		t.Error("An invalid duration should be rejected")
+	}
+	if resp, _ := post(t, ts.URL, Config{Problem: "sphere", NbrIndividuals: 1}); resp.StatusCode != http.StatusBadRequest {
+		t.Error("An invalid GA should be rejected")
+	}
+	var status Status
+	if get(t, ts.URL+"/runs/42", &status) != http.StatusNotFound {
+		t.Error("An unknown run should return a 404")
+	}
+}
@@ -0,0 +1,46 @@
+package gago
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Apply f to the index of each population in parallel. By default each
+// population is handled by it's own goroutine. If Shards is set the
+// populations are split into Shards blocks of consecutive populations and
+// each block is handled by a single goroutine locked to an OS thread, hence at
+// most Shards threads are busy evolving the populations, which leaves the
+// other CPUs to the rest of the program. Locking a goroutine to a thread
+// doesn't pin the thread to a CPU, the OS is still free to move it; pinning
+// threads to CPUs or NUMA nodes and batching the evaluations across
+// populations are out of scope.
+func (ga *GA) parallel(f func(i int)) {
+	var (
+		n  = len(ga.Populations)
+		wg sync.WaitGroup
+	)
+	if ga.Shards == 0 {
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				f(j)
+			}(i)
+		}
+		wg.Wait()
+		return
+	}
+	var shards = min(ga.Shards, n)
+	for s := 0; s < shards; s++ {
+		wg.Add(1)
+		go func(s int) {
+			defer wg.Done()
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+			for i := s * n / shards; i < (s+1)*n/shards; i++ {
+				f(i)
+			}
+		}(s)
+	}
+	wg.Wait()
+}
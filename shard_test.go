@@ -0,0 +1,74 @@
+package gago
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestShards(t *testing.T) {
+	var newGA = func(shards int) GA {
+		return GA{
+			Ff:             ff,
+			Initializer:    initializer,
+			Model:          model,
+			NbrGenes:       nbGenes,
+			NbrIndividuals: nbIndividuals,
+			NbrPopulations: 5,
+			Seed:           42,
+			Shards:         shards,
+		}
+	}
+	var reference = newGA(0)
+	reference.Initialize()
+	for i := 0; i < 3; i++ {
+		reference.Enhance()
+	}
+	for _, shards := range []int{1, 2, 5, 8} {
+		var g = newGA(shards)
+		g.Initialize()
+		for i := 0; i < 3; i++ {
+			g.Enhance()
+		}
+		for i := range g.Populations {
+			if !reflect.DeepEqual(g.Populations[i].Individuals, reference.Populations[i].Individuals) {
+				t.Fatalf("Evolving the populations on %d shards shouldn't change the individuals", shards)
+			}
+		}
+	}
+}
+
+func TestValidationShards(t *testing.T) {
+	ga.Shards = -1
+	if ga.Validate() == nil {
+		t.Error("Invalid number of shards didn't return an error")
+	}
+	ga.Shards = 0
+}
+
+// Evolve large populations with and without shards.
+func BenchmarkEnhanceShards(b *testing.B) {
+	var counts = []int{0, 1}
+	if runtime.NumCPU() > 1 {
+		counts = append(counts, runtime.NumCPU())
+	}
+	for _, shards := range counts {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			var g = GA{
+				Ff:             ff,
+				Initializer:    InitUniformF{Lower: -1, Upper: 1},
+				Model:          model,
+				NbrGenes:       50,
+				NbrIndividuals: 1000,
+				NbrPopulations: 2 * runtime.NumCPU(),
+				Shards:         shards,
+			}
+			g.Initialize()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				g.Enhance()
+			}
+		})
+	}
+}
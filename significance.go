@@ -0,0 +1,279 @@
+package gago
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Rank a float64 slice, the lowest value has rank 1 and tied values are given
+// the mean of their ranks. The second value is the sum of t^3 - t over each
+// group of t tied values, which is used to correct the statistics for ties.
+func rank(values []float64) ([]float64, float64) {
+	var (
+		order = make([]int, len(values))
+		ranks = make([]float64, len(values))
+		ties  float64
+	)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+	for i := 0; i < len(order); {
+		var j = i + 1
+		for j < len(order) && values[order[j]] == values[order[i]] {
+			j++
+		}
+		// The values from i to j-1 are tied, their ranks go from i+1 to j
+		for k := i; k < j; k++ {
+			ranks[order[k]] = float64(i+1+j) / 2
+		}
+		var t = float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	return ranks, ties
+}
+
+// RankSumTest applies the Wilcoxon rank-sum test, also called the
+// Mann-Whitney U test, to two independent samples such as the best fitnesses
+// of the runs of two experiments. The null hypothesis is that both samples
+// come from the same distribution. It returns the z statistic, which is
+// negative if the values of a tend to be lower than those of b, and the
+// two-sided p-value. The normal approximation of the distribution of the
+// statistic is used, with a correction for continuity and for ties, hence each
+// sample should contain at least 8 values or so.
+func RankSumTest(a, b []float64) (z, p float64) {
+	var (
+		n1, n2      = float64(len(a)), float64(len(b))
+		n           = n1 + n2
+		ranks, ties = rank(append(append([]float64{}, a...), b...))
+		u           = sum(ranks[:len(a)]) - n1*(n1+1)/2
+		mu          = n1 * n2 / 2
+		sigma       = math.Sqrt(n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1))))
+	)
+	if sigma == 0 || math.IsNaN(sigma) {
+		return 0, 1
+	}
+	z = math.Max(math.Abs(u-mu)-0.5, 0) / sigma
+	if u < mu {
+		z = -z
+	}
+	return z, math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// FriedmanTest applies the Friedman test to k related samples, for example
+// the best fitnesses of k experiments over the same runs or the same problems.
+// samples[j][i] is the value of the j-th configuration in the i-th block; each
+// block is ranked separately. The null hypothesis is that the configurations
+// are equivalent. It returns the chi-squared statistic, corrected for ties,
+// and it's p-value according to the chi-squared distribution with k-1 degrees
+// of freedom, which is accurate once there are about 10 blocks.
+func FriedmanTest(samples [][]float64) (chi2, p float64, err error) {
+	// Check the samples
+	if len(samples) < 2 {
+		return 0, 0, errors.New("at least 2 samples are required")
+	}
+	var n = len(samples[0])
+	if n == 0 {
+		return 0, 0, errors.New("the samples should not be empty")
+	}
+	for _, sample := range samples {
+		if len(sample) != n {
+			return 0, 0, errors.New("the samples should have the same length")
+		}
+	}
+	var (
+		k        = len(samples)
+		rankSums = make([]float64, k)
+		ties     float64
+		block    = make([]float64, k)
+	)
+	for i := 0; i < n; i++ {
+		for j := range samples {
+			block[j] = samples[j][i]
+		}
+		var ranks, t = rank(block)
+		for j, r := range ranks {
+			rankSums[j] += r
+		}
+		ties += t
+	}
+	var fn, fk = float64(n), float64(k)
+	for _, r := range rankSums {
+		chi2 += r * r
+	}
+	chi2 = 12/(fn*fk*(fk+1))*chi2 - 3*fn*(fk+1)
+	var correction = 1 - ties/(fn*(fk*fk*fk-fk))
+	if correction == 0 {
+		// Every block is entirely tied
+		return 0, 1, nil
+	}
+	chi2 /= correction
+	return chi2, chiSquaredSF(chi2, fk-1), nil
+}
+
+// Compute the probability that a chi-squared variable with dof degrees of
+// freedom is higher than x.
+func chiSquaredSF(x, dof float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return upperGamma(dof/2, x/2)
+}
+
+// Compute the regularized upper incomplete gamma function Q(a, x) with a
+// series when x < a+1 and with a continued fraction otherwise.
+func upperGamma(a, x float64) float64 {
+	const (
+		eps     = 1e-15
+		maxIter = 1000
+		tiny    = 1e-300
+	)
+	var lgamma, _ = math.Lgamma(a)
+	var prefactor = math.Exp(-x + a*math.Log(x) - lgamma)
+	if x < a+1 {
+		var (
+			term  = 1 / a
+			total = term
+		)
+		for i := 1; i < maxIter; i++ {
+			term *= x / (a + float64(i))
+			total += term
+			if math.Abs(term) < math.Abs(total)*eps {
+				break
+			}
+		}
+		return 1 - total*prefactor
+	}
+	// Modified Lentz's method
+	var (
+		b = x + 1 - a
+		c = 1 / tiny
+		d = 1 / b
+		h = d
+	)
+	for i := 1; i < maxIter; i++ {
+		var an = -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		var delta = d * c
+		h *= delta
+		if math.Abs(delta-1) < eps {
+			break
+		}
+	}
+	return h * prefactor
+}
+
+// A Comparison summarizes the differences between the results of several
+// experiments. The rank-sum test is applied to each pair of experiments and
+// the Friedman test to all of them, the i-th run of each experiment forming a
+// block. Experiments with the same Seed use the same seeds for their runs,
+// which makes the blocks meaningful.
+type Comparison struct {
+	Names     []string
+	Medians   []float64   // Median of the best fitnesses of each experiment
+	MeanRanks []float64   // Mean rank of each experiment over the runs, lower is better
+	RankSumP  [][]float64 // p-value of the rank-sum test between each pair of experiments
+	Friedman  float64     // Statistic of the Friedman test
+	FriedmanP float64     // p-value of the Friedman test, NaN if the numbers of runs differ
+}
+
+// Compare the results of several experiments, each one identified by a name.
+func Compare(names []string, results []ExperimentResult) (Comparison, error) {
+	if len(names) != len(results) {
+		return Comparison{}, errors.New("there should be a name per result")
+	}
+	if len(results) < 2 {
+		return Comparison{}, errors.New("at least 2 results are required")
+	}
+	var comp = Comparison{
+		Names:     names,
+		Medians:   make([]float64, len(results)),
+		MeanRanks: make([]float64, len(results)),
+		RankSumP:  make([][]float64, len(results)),
+		FriedmanP: math.NaN(),
+		Friedman:  math.NaN(),
+	}
+	var samples = make([][]float64, len(results))
+	for i, result := range results {
+		if len(result.Bests) == 0 {
+			return Comparison{}, fmt.Errorf("the result of '%s' is empty", names[i])
+		}
+		samples[i] = result.Bests
+		comp.Medians[i] = median(result.Bests)
+		comp.RankSumP[i] = make([]float64, len(results))
+		for j := range results {
+			if i == j {
+				comp.RankSumP[i][j] = 1
+			} else {
+				_, comp.RankSumP[i][j] = RankSumTest(result.Bests, results[j].Bests)
+			}
+		}
+	}
+	if chi2, p, err := FriedmanTest(samples); err == nil {
+		comp.Friedman, comp.FriedmanP = chi2, p
+		var block = make([]float64, len(samples))
+		for i := range samples[0] {
+			for j := range samples {
+				block[j] = samples[j][i]
+			}
+			var ranks, _ = rank(block)
+			for j, r := range ranks {
+				comp.MeanRanks[j] += r / float64(len(samples[0]))
+			}
+		}
+	} else {
+		// Rank the medians instead
+		comp.MeanRanks, _ = rank(comp.Medians)
+	}
+	return comp, nil
+}
+
+// String returns a table with the median and the mean rank of each
+// experiment, followed by the result of the Friedman test and by the matrix of
+// the p-values of the rank-sum tests.
+func (comp Comparison) String() string {
+	var (
+		b strings.Builder
+		w = tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	)
+	fmt.Fprintln(w, "experiment\tmedian\tmean rank\t")
+	for i, name := range comp.Names {
+		fmt.Fprintf(w, "%s\t%g\t%.2f\t\n", name, comp.Medians[i], comp.MeanRanks[i])
+	}
+	w.Flush()
+	if math.IsNaN(comp.FriedmanP) {
+		fmt.Fprintln(&b, "\nFriedman test: not applicable, the numbers of runs differ")
+	} else {
+		fmt.Fprintf(&b, "\nFriedman test: chi2 = %.4f, p = %.4g\n", comp.Friedman, comp.FriedmanP)
+	}
+	fmt.Fprintln(&b, "\nRank-sum test p-values:")
+	w = tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "\t%s\t\n", strings.Join(comp.Names, "\t"))
+	for i, name := range comp.Names {
+		fmt.Fprint(w, name)
+		for j := range comp.Names {
+			if i == j {
+				fmt.Fprint(w, "\t-")
+			} else {
+				fmt.Fprintf(w, "\t%.4g", comp.RankSumP[i][j])
+			}
+		}
+		fmt.Fprintln(w, "\t")
+	}
+	w.Flush()
+	return b.String()
+}
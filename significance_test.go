@@ -0,0 +1,136 @@
+package gago
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	var ranks, ties = rank([]float64{3, 1, 3, 2, 3})
+	var expected = []float64{4, 1, 4, 2, 4}
+	for i := range ranks {
+		if ranks[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, ranks)
+			break
+		}
+	}
+	if ties != 24 {
+		t.Errorf("Expected a tie correction of 24, got %f", ties)
+	}
+}
+
+func TestRankSumTest(t *testing.T) {
+	var testCases = []struct {
+		a, b []float64
+		z, p float64
+	}{
+		{[]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, -2.5067, 0.01219},
+		{[]float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 2.5067, 0.01219},
+		{[]float64{1, 2, 3}, []float64{1, 2, 3}, 0, 1},
+		{[]float64{1, 1}, []float64{1, 1}, 0, 1},
+	}
+	for _, test := range testCases {
+		var z, p = RankSumTest(test.a, test.b)
+		if math.Abs(z-test.z) > 1e-4 || math.Abs(p-test.p) > 1e-5 {
+			t.Errorf("Expected z = %f and p = %f, got z = %f and p = %f", test.z, test.p, z, p)
+		}
+	}
+}
+
+func TestFriedmanTest(t *testing.T) {
+	var testCases = []struct {
+		samples [][]float64
+		chi2, p float64
+	}{
+		{[][]float64{{1, 2, 3, 4}, {2, 3, 4, 5}, {3, 4, 5, 6}}, 8, math.Exp(-4)},
+		{[][]float64{{1, 1}, {1, 2}, {2, 3}}, 3.7142857, 0.156118},
+		{[][]float64{{1, 1}, {1, 1}}, 0, 1},
+	}
+	for _, test := range testCases {
+		var chi2, p, err = FriedmanTest(test.samples)
+		if err != nil {
+			t.Error(err)
+		}
+		if math.Abs(chi2-test.chi2) > 1e-6 || math.Abs(p-test.p) > 1e-6 {
+			t.Errorf("Expected chi2 = %f and p = %f, got chi2 = %f and p = %f", test.chi2, test.p, chi2, p)
+		}
+	}
+	// Invalid samples
+	for _, samples := range [][][]float64{
+		{{1, 2}},
+		{{}, {}},
+		{{1, 2}, {1}},
+	} {
+		if _, _, err := FriedmanTest(samples); err == nil {
+			t.Errorf("Expected an error for %v", samples)
+		}
+	}
+}
+
+func TestChiSquaredSF(t *testing.T) {
+	var testCases = []struct {
+		x, dof, p float64
+	}{
+		{3.841459, 1, 0.05},
+		{7.814728, 3, 0.05},
+		{18.307038, 10, 0.05},
+		{0.5, 4, 0.973501},
+		{0, 2, 1},
+	}
+	for _, test := range testCases {
+		if p := chiSquaredSF(test.x, test.dof); math.Abs(p-test.p) > 1e-6 {
+			t.Errorf("Expected %f, got %f", test.p, p)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var results = []ExperimentResult{
+		{Bests: []float64{1, 2, 3, 4, 5, 6, 7, 8}},
+		{Bests: []float64{11, 12, 13, 14, 15, 16, 17, 18}},
+		{Bests: []float64{1.5, 2.5, 3.5, 4.5, 5.5, 6.5, 7.5, 8.5}},
+	}
+	var comp, err = Compare([]string{"a", "b", "c"}, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range results {
+		for j := range results {
+			if comp.RankSumP[i][j] != comp.RankSumP[j][i] {
+				t.Error("The rank-sum p-values should be symmetric")
+			}
+		}
+	}
+	if comp.RankSumP[0][1] > 0.01 || comp.RankSumP[0][2] < 0.05 {
+		t.Errorf("Wrong rank-sum p-values: %v", comp.RankSumP)
+	}
+	if comp.MeanRanks[0] != 1 || comp.MeanRanks[1] != 3 || comp.MeanRanks[2] != 2 {
+		t.Errorf("Wrong mean ranks: %v", comp.MeanRanks)
+	}
+	if comp.FriedmanP > 0.01 {
+		t.Errorf("Expected a significant Friedman test, got p = %f", comp.FriedmanP)
+	}
+	var output = comp.String()
+	for _, s := range []string{"a", "b", "c", "Friedman test: chi2 = 16.0000", "Rank-sum"} {
+		if !strings.Contains(output, s) {
+			t.Errorf("Expected the output to contain %q, got\n%s", s, output)
+		}
+	}
+	// The Friedman test requires the same number of runs
+	results[2].Bests = results[2].Bests[:4]
+	comp, _ = Compare([]string{"a", "b", "c"}, results)
+	if !math.IsNaN(comp.FriedmanP) || !strings.Contains(comp.String(), "not applicable") {
+		t.Error("The Friedman test should not be applied to a different number of runs")
+	}
+	// Invalid comparisons
+	if _, err := Compare([]string{"a"}, results); err == nil {
+		t.Error("Expected an error for a name per result")
+	}
+	if _, err := Compare([]string{"a"}, results[:1]); err == nil {
+		t.Error("Expected an error for a single result")
+	}
+	if _, err := Compare([]string{"a", "b"}, []ExperimentResult{{}, {}}); err == nil {
+		t.Error("Expected an error for empty results")
+	}
+}
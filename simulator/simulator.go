@@ -0,0 +1,283 @@
+// Package simulator evaluates individuals by driving an external simulator,
+// for example a reinforcement learning environment written in Python, which
+// is common in neuroevolution and control problems. The simulator is a
+// program that reads requests from it's standard input and writes responses
+// to it's standard output, one JSON object per line:
+//
+//	{"id": 1, "genomes": [[0.5, -1.2], [0.1, 0.3]], "episodes": 3}
+//	{"id": 1, "returns": [[10, 12, 9], [3, 4, 4]]}
+//
+// Each request contains a batch of genomes and the number of episodes each
+// genome should be run for, the response contains the return of each episode
+// of each genome, in the same order, along with the ID of the request. A
+// simulator that can't evaluate a batch responds with an "error" field
+// instead. The genomes are encoded with encoding/json, hence their genes
+// should be JSON values such as numbers, booleans or strings.
+package simulator
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A Request is sent to the simulator to evaluate a batch of genomes.
+type Request struct {
+	ID       int           `json:"id"`
+	Genomes  []gago.Genome `json:"genomes"`
+	Episodes int           `json:"episodes"`
+}
+
+// A Response is sent back by the simulator, Returns contains the return of
+// each episode of each genome.
+type Response struct {
+	ID      int         `json:"id"`
+	Returns [][]float64 `json:"returns"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// A Simulator evaluates genomes by sending them to Processes instances of the
+// program given by Command, the program and it's arguments, one instance if
+// Processes is 0. The genomes are sent in batches of BatchSize genomes, or in
+// a single batch if BatchSize is 0, and the batches are spread over the
+// instances. Each genome is run for Episodes episodes, one if Episodes is 0,
+// and it's fitness is the mean of it's returns, or the opposite of the mean
+// if Maximize is true because the returns are rewards to maximize.
+//
+// An instance that takes longer than Timeout to respond, that crashes or that
+// responds with an error is killed and restarted for the next batch, the
+// genomes of the failed batch are assigned the worst possible fitness, +Inf,
+// and OnError is called with the error if it's provided. A Timeout of 0
+// disables the timeout.
+//
+// The instances are started when the first batch is evaluated, Close stops
+// them. A Simulator is used by the GA through Function and has to be used
+// through a pointer.
+type Simulator struct {
+	Command   []string
+	Processes int
+	BatchSize int
+	Episodes  int
+	Timeout   time.Duration
+	Maximize  bool
+	OnError   func(err error)
+	once      sync.Once
+	pool      chan *process // Idle instances, nil for an instance that isn't started
+	mu        sync.Mutex
+	lastID    int
+}
+
+// Function returns the Simulator as a fitness function that evaluates the
+// individuals of a population in batches.
+func (sim *Simulator) Function() gago.FitnessFunction {
+	return gago.BatchFunction{Image: sim.Evaluate}
+}
+
+// Evaluate returns the fitness of each genome.
+func (sim *Simulator) Evaluate(genomes []gago.Genome) []float64 {
+	sim.once.Do(func() {
+		var n = max(sim.Processes, 1)
+		sim.pool = make(chan *process, n)
+		for i := 0; i < n; i++ {
+			sim.pool <- nil
+		}
+	})
+	var (
+		fitnesses = make([]float64, len(genomes))
+		size      = sim.BatchSize
+		wg        sync.WaitGroup
+	)
+	if size < 1 {
+		size = len(genomes)
+	}
+	for i := 0; i < len(genomes); i += size {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var j = min(i+size, len(genomes))
+			sim.evaluateBatch(genomes[i:j], fitnesses[i:j])
+		}(i)
+	}
+	wg.Wait()
+	return fitnesses
+}
+
+// Evaluate a batch of genomes on an idle instance.
+func (sim *Simulator) evaluateBatch(genomes []gago.Genome, fitnesses []float64) {
+	var p = <-sim.pool
+	var returns, err = sim.call(&p, genomes)
+	if err != nil {
+		if p != nil {
+			p.kill()
+			p = nil
+		}
+		if sim.OnError != nil {
+			sim.OnError(err)
+		}
+	}
+	sim.pool <- p
+	for i := range fitnesses {
+		if err != nil {
+			fitnesses[i] = math.Inf(1)
+			continue
+		}
+		var mean float64
+		for _, r := range returns[i] {
+			mean += r
+		}
+		mean /= float64(len(returns[i]))
+		if sim.Maximize {
+			mean = -mean
+		}
+		fitnesses[i] = mean
+	}
+}
+
+// Send a batch of genomes to an instance, which is started if needed.
+func (sim *Simulator) call(p **process, genomes []gago.Genome) ([][]float64, error) {
+	if *p == nil {
+		var started, err = start(sim.Command)
+		if err != nil {
+			return nil, err
+		}
+		*p = started
+	}
+	sim.mu.Lock()
+	sim.lastID++
+	var req = Request{ID: sim.lastID, Genomes: genomes, Episodes: max(sim.Episodes, 1)}
+	sim.mu.Unlock()
+	var resp, err = (*p).call(req, sim.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	// Check the response
+	if resp.Error != "" {
+		return nil, errors.New("simulator: " + resp.Error)
+	}
+	if resp.ID != req.ID {
+		return nil, fmt.Errorf("simulator: expected the response to request %d, got %d", req.ID, resp.ID)
+	}
+	if len(resp.Returns) != len(genomes) {
+		return nil, fmt.Errorf("simulator: expected the returns of %d genomes, got %d", len(genomes), len(resp.Returns))
+	}
+	for _, returns := range resp.Returns {
+		if len(returns) != req.Episodes {
+			return nil, fmt.Errorf("simulator: expected %d returns per genome, got %d", req.Episodes, len(returns))
+		}
+	}
+	return resp.Returns, nil
+}
+
+// Close stops the instances of the simulator, it waits for the batches being
+// evaluated.
+func (sim *Simulator) Close() error {
+	if sim.pool == nil {
+		return nil
+	}
+	var err error
+	for i := 0; i < cap(sim.pool); i++ {
+		if p := <-sim.pool; p != nil {
+			if e := p.close(); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+	close(sim.pool)
+	return err
+}
+
+// A process is a running instance of the simulator.
+type process struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	encoder *json.Encoder
+	lines   chan []byte // Lines written by the simulator, closed when it exits
+}
+
+// Start an instance of the simulator.
+func start(command []string) (*process, error) {
+	if len(command) == 0 {
+		return nil, errors.New("simulator: the command is empty")
+	}
+	var cmd = exec.Command(command[0], command[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+	var p = &process{
+		cmd:     cmd,
+		stdin:   stdin,
+		encoder: json.NewEncoder(stdin),
+		lines:   make(chan []byte),
+	}
+	// Read the responses in the background so that they can be waited for
+	// with a timeout
+	go func() {
+		defer close(p.lines)
+		var scanner = bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			p.lines <- append([]byte{}, scanner.Bytes()...)
+		}
+	}()
+	return p, nil
+}
+
+// Send a request and wait for the response.
+func (p *process) call(req Request, timeout time.Duration) (Response, error) {
+	if err := p.encoder.Encode(req); err != nil {
+		return Response{}, err
+	}
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		var timer = time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	select {
+	case line, ok := <-p.lines:
+		if !ok {
+			return Response{}, errors.New("simulator: the process exited")
+		}
+		var resp Response
+		if err := json.Unmarshal(line, &resp); err != nil {
+			return Response{}, fmt.Errorf("simulator: invalid response: %v", err)
+		}
+		return resp, nil
+	case <-deadline:
+		return Response{}, fmt.Errorf("simulator: no response after %s", timeout)
+	}
+}
+
+// Kill the process, the lines it might still write are discarded.
+func (p *process) kill() {
+	p.cmd.Process.Kill()
+	go func() {
+		for range p.lines {
+		}
+		p.cmd.Wait()
+	}()
+}
+
+// Stop the process by closing it's input.
+func (p *process) close() error {
+	p.stdin.Close()
+	for range p.lines {
+	}
+	return p.cmd.Wait()
+}
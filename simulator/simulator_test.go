@@ -0,0 +1,131 @@
+package simulator
+
+import (
+	"bufio"
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MaxHalford/gago"
+)
+
+// When the variable is set the test binary behaves as a simulator whose
+// returns are the sum of the genes, with a different noise for each episode.
+// A genome whose first gene is 666 hangs the simulator and a genome whose
+// first gene is 42 makes it respond with an error.
+const helperVariable = "GAGO_SIMULATOR_HELPER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(helperVariable) == "1" {
+		simulate()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func simulate() {
+	var (
+		scanner = bufio.NewScanner(os.Stdin)
+		encoder = json.NewEncoder(os.Stdout)
+	)
+	for scanner.Scan() {
+		var req struct {
+			ID       int         `json:"id"`
+			Genomes  [][]float64 `json:"genomes"`
+			Episodes int         `json:"episodes"`
+		}
+		json.Unmarshal(scanner.Bytes(), &req)
+		var resp = Response{ID: req.ID, Returns: make([][]float64, len(req.Genomes))}
+		for i, genome := range req.Genomes {
+			if genome[0] == 666 {
+				time.Sleep(time.Hour)
+			}
+			if genome[0] == 42 {
+				resp.Error = "the answer"
+			}
+			var sum float64
+			for _, gene := range genome {
+				sum += gene
+			}
+			for e := 0; e < req.Episodes; e++ {
+				resp.Returns[i] = append(resp.Returns[i], sum+float64(e))
+			}
+		}
+		encoder.Encode(resp)
+	}
+}
+
+func newSimulator() *Simulator {
+	os.Setenv(helperVariable, "1")
+	return &Simulator{Command: []string{os.Args[0]}}
+}
+
+func TestEvaluate(t *testing.T) {
+	var sim = newSimulator()
+	defer sim.Close()
+	sim.Processes = 2
+	sim.BatchSize = 2
+	sim.Episodes = 3
+	var fitnesses = sim.Evaluate([]gago.Genome{{1.0, 2.0}, {0.5}, {-1.0, 1.0}})
+	// The mean of the returns is the sum of the genes plus 1
+	for i, expected := range []float64{4, 1.5, 1} {
+		if fitnesses[i] != expected {
+			t.Errorf("Expected a fitness of %f, got %f", expected, fitnesses[i])
+		}
+	}
+	sim.Maximize = true
+	if fitnesses = sim.Evaluate([]gago.Genome{{1.0, 2.0}}); fitnesses[0] != -4 {
+		t.Error("The returns weren't negated")
+	}
+}
+
+func TestEvaluateFailures(t *testing.T) {
+	var (
+		sim    = newSimulator()
+		errors int
+	)
+	defer sim.Close()
+	sim.BatchSize = 1
+	sim.Timeout = 200 * time.Millisecond
+	sim.OnError = func(err error) { errors++ }
+	for _, genome := range []gago.Genome{{666.0}, {42.0}} {
+		if fitness := sim.Evaluate([]gago.Genome{genome}); !math.IsInf(fitness[0], 1) {
+			t.Errorf("Expected the failed genome %v to be assigned +Inf", genome)
+		}
+	}
+	if errors != 2 {
+		t.Errorf("Expected 2 errors, got %d", errors)
+	}
+	// The simulator is restarted after a failure
+	if fitness := sim.Evaluate([]gago.Genome{{1.0}}); fitness[0] != 1 {
+		t.Error("The simulator wasn't restarted")
+	}
+}
+
+func TestEmptyCommand(t *testing.T) {
+	var (
+		sim    = &Simulator{}
+		failed bool
+	)
+	sim.OnError = func(err error) { failed = true }
+	if fitness := sim.Evaluate([]gago.Genome{{1.0}}); !math.IsInf(fitness[0], 1) || !failed {
+		t.Error("A simulator without a command should fail")
+	}
+}
+
+func TestFunction(t *testing.T) {
+	var sim = newSimulator()
+	defer sim.Close()
+	sim.BatchSize = 10
+	var ga = gago.NewFloatGA(2, -5, 5, func(x []float64) float64 { return 0 })
+	ga.Ff = sim.Function()
+	ga.Initialize()
+	for i := 0; i < 3; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness >= 10 || ga.Best().Fitness < -10 {
+		t.Errorf("Unexpected best fitness %f", ga.Best().Fitness)
+	}
+}
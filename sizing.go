@@ -0,0 +1,77 @@
+package gago
+
+// A PopulationSizer decides how many individuals each population should contain
+// at a given generation. It is consulted by the GA at the end of each
+// generation, shrinking a population removes it's worst individuals whereas
+// growing a population adds new randomly initialized individuals.
+type PopulationSizer interface {
+	Apply(generation int) int
+}
+
+// SizeSawTooth makes the population size follow a saw-tooth pattern. The size
+// decreases linearly from Mean + Amplitude to Mean - Amplitude during Period
+// generations, the population is then replenished with new individuals and the
+// cycle starts again. The periodic reinitialization helps maintaining diversity.
+type SizeSawTooth struct {
+	Mean, Amplitude, Period int
+}
+
+// Apply the saw-tooth schedule.
+func (size SizeSawTooth) Apply(generation int) int {
+	if size.Period < 2 {
+		return size.Mean
+	}
+	var step = (generation - 1) % size.Period
+	if step < 0 {
+		step += size.Period
+	}
+	return size.Mean + size.Amplitude - 2*size.Amplitude*step/(size.Period-1)
+}
+
+// SizeLinear makes the population size go linearly from Start to End during
+// the given number of Generations, it then stays equal to End.
+type SizeLinear struct {
+	Start, End, Generations int
+}
+
+// Apply the linear schedule.
+func (size SizeLinear) Apply(generation int) int {
+	if generation >= size.Generations {
+		return size.End
+	}
+	return size.Start + (size.End-size.Start)*generation/size.Generations
+}
+
+// Resize a population so that it contains n individuals, n being at least 2.
+// The population is assumed to be sorted, hence shrinking it removes the worst
+// individuals. The individuals added when growing it are evaluated and sorted
+// in with the rest of the population.
+func (pop *Population) resize(n, nbGenes int, init Initializer) {
+	if n < 2 {
+		n = 2
+	}
+	if n <= len(pop.Individuals) {
+		pop.Individuals = pop.Individuals[:n]
+		return
+	}
+	for len(pop.Individuals) < n {
+		var indi = makeIndividual(nbGenes, pop.rng)
+		init.Apply(&indi, pop.rng)
+		indi.Evaluate(pop.ff)
+		pop.Individuals = append(pop.Individuals, indi)
+	}
+	pop.Individuals.SortWith(pop.cmp)
+}
+
+// Replace the individuals whose evaluation failed with new random individuals,
+// see ErrFunction.
+func (pop *Population) regenerate(nbGenes int, init Initializer) {
+	for i := range pop.Individuals {
+		if pop.Individuals[i].failed {
+			var indi = makeIndividual(nbGenes, pop.rng)
+			init.Apply(&indi, pop.rng)
+			indi.Evaluate(pop.ff)
+			pop.Individuals[i] = indi
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package gago
+
+import "testing"
+
+func TestSizeSawTooth(t *testing.T) {
+	var (
+		sizer  = SizeSawTooth{Mean: 20, Amplitude: 10, Period: 5}
+		target = []int{30, 25, 20, 15, 10, 30, 25}
+	)
+	for i, size := range target {
+		if sizer.Apply(i+1) != size {
+			t.Error("SizeSawTooth didn't follow the expected schedule")
+		}
+	}
+}
+
+func TestSizeLinear(t *testing.T) {
+	var (
+		sizer  = SizeLinear{Start: 10, End: 20, Generations: 5}
+		target = []int{10, 12, 14, 16, 18, 20, 20}
+	)
+	for i, size := range target {
+		if sizer.Apply(i) != size {
+			t.Error("SizeLinear didn't follow the expected schedule")
+		}
+	}
+}
+
+func TestResizeGA(t *testing.T) {
+	var ga = GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 20,
+		NbrGenes:       2,
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          model,
+		Sizer:          SizeSawTooth{Mean: 20, Amplitude: 10, Period: 5},
+	}
+	ga.Initialize()
+	for i := 1; i <= 7; i++ {
+		ga.Enhance()
+		for _, pop := range ga.Populations {
+			// Check the size of the population follows the schedule
+			if len(pop.Individuals) != ga.Sizer.Apply(i) {
+				t.Error("The population wasn't resized")
+			}
+			// Check the population is still sorted
+			for k := 1; k < len(pop.Individuals); k++ {
+				if pop.Individuals[k-1].Fitness > pop.Individuals[k].Fitness {
+					t.Error("The resized population isn't sorted")
+				}
+			}
+		}
+	}
+}
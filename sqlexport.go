@@ -0,0 +1,212 @@
+package gago
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// The tables written by an SQLExporter. The genomes and the objectives are
+// stored as space separated values, see CSVExporter for how the genes are
+// formatted. The floating point values that are NaN or infinite are stored as
+// NULL because most databases can't store them. The rank of an individual in
+// it's population is stored in the ranking column, RANK being a reserved word
+// in MySQL and in the SQL standard.
+var sqlTables = []string{
+	`CREATE TABLE IF NOT EXISTS runs (
+		run TEXT PRIMARY KEY,
+		started TEXT
+	)`,
+	`CREATE TABLE IF NOT EXISTS generations (
+		run TEXT,
+		generation INTEGER,
+		evaluations INTEGER,
+		duration REAL,
+		best REAL,
+		mean REAL,
+		variance REAL,
+		PRIMARY KEY (run, generation)
+	)`,
+	`CREATE TABLE IF NOT EXISTS individuals (
+		run TEXT,
+		generation INTEGER,
+		population INTEGER,
+		ranking INTEGER,
+		id INTEGER,
+		birth INTEGER,
+		origin INTEGER,
+		fitness REAL,
+		violation REAL,
+		objectives TEXT,
+		genome TEXT
+	)`,
+}
+
+// An SQLExporter writes snapshots of a GA to an SQL database, which allows
+// analyzing large campaigns of experiments with SQL queries instead of parsing
+// logs. The database is opened by the caller with the driver of it's choice,
+// for example an SQLite driver, the statements only use standard SQL with ?
+// placeholders. The runs, generations and individuals tables are created by
+// the first call to Export if they don't exist, each table has a run column
+// that holds Run, hence several runs can share a database. Export is meant to
+// be called after each generation, or at any interval, it inserts a row per
+// individual and a row with the statistics of the GA in a single transaction.
+// A run that is resumed, for example from a checkpoint, can keep being exported
+// by a new SQLExporter with the same Run; the generations that were already
+// exported are replaced. Runs and Bests query the database. The example in
+// examples/sqlite uses an SQLite driver.
+type SQLExporter struct {
+	DB      *sql.DB
+	Run     string // Name of the run, it should be unique within the database
+	created bool
+}
+
+// Create the tables if they don't exist and insert the run if it isn't in the
+// database yet, which is the case when a run is resumed from a checkpoint.
+func (exp *SQLExporter) create() error {
+	for _, table := range sqlTables {
+		if _, err := exp.DB.Exec(table); err != nil {
+			return err
+		}
+	}
+	var _, err = exp.DB.Exec(
+		"INSERT INTO runs (run, started) SELECT ?, ? WHERE NOT EXISTS (SELECT run FROM runs WHERE run = ?)",
+		exp.Run,
+		time.Now().UTC().Format(time.RFC3339),
+		exp.Run,
+	)
+	return err
+}
+
+// Export writes a snapshot of a GA.
+func (exp *SQLExporter) Export(ga *GA) error {
+	// Check the name of the run
+	if exp.Run == "" {
+		return errors.New("'Run' should be set")
+	}
+	if !exp.created {
+		if err := exp.create(); err != nil {
+			return err
+		}
+		exp.created = true
+	}
+	var tx, err = exp.DB.Begin()
+	if err != nil {
+		return err
+	}
+	if err = exp.export(tx, ga); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Return the value stored for a float, NULL if it's NaN or infinite.
+func sqlFloat(x float64) interface{} {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return nil
+	}
+	return x
+}
+
+// Insert the statistics and the individuals of the GA within a transaction.
+// The rows of the generation are deleted first in case it was already
+// exported, which happens when a run is resumed from an earlier checkpoint.
+func (exp *SQLExporter) export(tx *sql.Tx, ga *GA) error {
+	for _, table := range []string{"generations", "individuals"} {
+		var _, err = tx.Exec("DELETE FROM "+table+" WHERE run = ? AND generation = ?", exp.Run, ga.Generations)
+		if err != nil {
+			return err
+		}
+	}
+	var stats = ga.Stats()
+	var _, err = tx.Exec(
+		"INSERT INTO generations (run, generation, evaluations, duration, best, mean, variance) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		exp.Run,
+		stats.Generations,
+		stats.Evaluations,
+		stats.Duration.Seconds(),
+		sqlFloat(stats.Best),
+		sqlFloat(stats.Mean),
+		sqlFloat(stats.Variance),
+	)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(
+		"INSERT INTO individuals (run, generation, population, ranking, id, birth, origin, fitness, violation, objectives, genome) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+	)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for p, pop := range ga.Populations {
+		for r, indi := range pop.Individuals {
+			var objectives = make([]string, len(indi.Objectives))
+			for i, objective := range indi.Objectives {
+				objectives[i] = formatFloat(objective)
+			}
+			_, err = stmt.Exec(
+				exp.Run,
+				ga.Generations,
+				p,
+				r,
+				indi.ID,
+				indi.Birth,
+				indi.Origin,
+				sqlFloat(indi.Fitness),
+				sqlFloat(indi.Violation),
+				strings.Join(objectives, " "),
+				strings.Join(geneStrings(indi.Genome), " "),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Runs returns the names of the runs stored in the database, ordered by
+// starting time.
+func (exp *SQLExporter) Runs() ([]string, error) {
+	var rows, err = exp.DB.Query("SELECT run FROM runs ORDER BY started, run")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var runs []string
+	for rows.Next() {
+		var run string
+		if err = rows.Scan(&run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// Bests returns the fitness of the best individual of a run at each exported
+// generation, ordered by generation. A best fitness that was stored as NULL
+// because it wasn't finite is returned as NaN.
+func (exp *SQLExporter) Bests(run string) ([]float64, error) {
+	var rows, err = exp.DB.Query("SELECT best FROM generations WHERE run = ? ORDER BY generation", run)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var bests []float64
+	for rows.Next() {
+		var best sql.NullFloat64
+		if err = rows.Scan(&best); err != nil {
+			return nil, err
+		}
+		if !best.Valid {
+			best.Float64 = math.NaN()
+		}
+		bests = append(bests, best.Float64)
+	}
+	return bests, rows.Err()
+}
@@ -0,0 +1,214 @@
+package gago
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// A minimal SQL driver that stores the inserted rows in memory, skips the rows
+// of "INSERT ... WHERE NOT EXISTS" whose first column already holds the last
+// argument, deletes the rows with "DELETE FROM table WHERE a = ? [AND b = ?]"
+// and answers the queries of the form "SELECT column FROM table [WHERE a = ?]"
+// in the insertion order, which is enough to test the SQLExporter and the
+// SQLArchiveStore without depending on an actual database.
+type memDriver struct {
+	mu     sync.Mutex
+	tables map[string][]map[string]driver.Value
+}
+
+type memConn struct{ d *memDriver }
+
+type memStmt struct {
+	d     *memDriver
+	query string
+}
+
+type memRows struct {
+	column string
+	values []driver.Value
+}
+
+func (d *memDriver) Open(name string) (driver.Conn, error) { return memConn{d}, nil }
+
+func (c memConn) Prepare(query string) (driver.Stmt, error) { return memStmt{c.d, query}, nil }
+func (c memConn) Close() error                              { return nil }
+func (c memConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c memConn) Commit() error                             { return nil }
+func (c memConn) Rollback() error                           { return nil }
+
+func (s memStmt) Close() error  { return nil }
+func (s memStmt) NumInput() int { return -1 }
+
+// Return the columns of the conditions of a query.
+func whereColumns(fields []string) []string {
+	var columns []string
+	for i, field := range fields {
+		if field == "WHERE" || field == "AND" {
+			columns = append(columns, fields[i+1])
+		}
+	}
+	return columns
+}
+
+// Check if a row matches the conditions of a query.
+func matches(row map[string]driver.Value, columns []string, args []driver.Value) bool {
+	for i, column := range columns {
+		if row[column] != args[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if strings.HasPrefix(s.query, "DELETE") {
+		var (
+			fields  = strings.Fields(s.query)
+			columns = whereColumns(fields)
+			kept    []map[string]driver.Value
+		)
+		s.d.mu.Lock()
+		defer s.d.mu.Unlock()
+		for _, row := range s.d.tables[fields[2]] {
+			if !matches(row, columns, args) {
+				kept = append(kept, row)
+			}
+		}
+		s.d.tables[fields[2]] = kept
+		return driver.RowsAffected(0), nil
+	}
+	if !strings.HasPrefix(s.query, "INSERT") {
+		return driver.RowsAffected(0), nil
+	}
+	var (
+		fields  = strings.Fields(s.query)
+		columns = strings.Split(s.query[strings.Index(s.query, "(")+1:strings.Index(s.query, ")")], ", ")
+		row     = make(map[string]driver.Value)
+	)
+	for i, column := range columns {
+		row[column] = args[i]
+	}
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if strings.Contains(s.query, "WHERE NOT EXISTS") {
+		for _, existing := range s.d.tables[fields[2]] {
+			if existing[columns[0]] == args[len(args)-1] {
+				return driver.RowsAffected(0), nil
+			}
+		}
+	}
+	s.d.tables[fields[2]] = append(s.d.tables[fields[2]], row)
+	return driver.RowsAffected(1), nil
+}
+
+func (s memStmt) Query(args []driver.Value) (driver.Rows, error) {
+	var (
+		fields  = strings.Fields(s.query)
+		rows    = &memRows{column: fields[1]}
+		columns = whereColumns(fields)
+	)
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	for _, row := range s.d.tables[fields[3]] {
+		if matches(row, columns, args) {
+			rows.values = append(rows.values, row[rows.column])
+		}
+	}
+	return rows, nil
+}
+
+func (r *memRows) Columns() []string { return []string{r.column} }
+func (r *memRows) Close() error      { return nil }
+
+func (r *memRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+func TestSQLExporter(t *testing.T) {
+	var d = &memDriver{tables: make(map[string][]map[string]driver.Value)}
+	sql.Register("gago_mem", d)
+	var db, err = sql.Open("gago_mem", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var ga = GA{
+		NbrPopulations: 2,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Ff:             ff,
+		Initializer:    initializer,
+		Model:          model,
+	}
+	ga.Initialize()
+	// The name of the run is required
+	if err = (&SQLExporter{DB: db}).Export(&ga); err == nil {
+		t.Error("Export should fail without a run name")
+	}
+	var exp = &SQLExporter{DB: db, Run: "a"}
+	for i := 0; i < 3; i++ {
+		if err = exp.Export(&ga); err != nil {
+			t.Fatal(err)
+		}
+		ga.Enhance()
+	}
+	if n := len(d.tables["individuals"]); n != 3*2*nbIndividuals {
+		t.Errorf("Expected %d individuals, got %d", 3*2*nbIndividuals, n)
+	}
+	var genome = d.tables["individuals"][0]["genome"].(string)
+	if len(strings.Fields(genome)) != nbGenes {
+		t.Errorf("Expected %d genes, got %s", nbGenes, genome)
+	}
+	// Query the database
+	(&SQLExporter{DB: db, Run: "b"}).Export(&ga)
+	if runs, err := exp.Runs(); err != nil || len(runs) != 2 || runs[0] != "a" {
+		t.Errorf("Expected the runs [a b], got %v", runs)
+	}
+	var bests []float64
+	if bests, err = exp.Bests("a"); err != nil || len(bests) != 3 {
+		t.Fatalf("Expected 3 generations, got %v", bests)
+	}
+	if bests[2] > bests[0] {
+		t.Error("The best fitness shouldn't increase")
+	}
+	// A resumed run keeps being exported under the same name
+	var resumed = &SQLExporter{DB: db, Run: "a"}
+	if err = resumed.Export(&ga); err != nil {
+		t.Fatal(err)
+	}
+	if runs, _ := resumed.Runs(); len(runs) != 2 {
+		t.Errorf("The resumed run shouldn't be inserted twice, got %v", runs)
+	}
+	if bests, _ = resumed.Bests("a"); len(bests) != 4 {
+		t.Errorf("Expected 4 generations, got %v", bests)
+	}
+	// Exporting a generation again replaces it
+	var n = len(d.tables["individuals"])
+	if err = resumed.Export(&ga); err != nil {
+		t.Fatal(err)
+	}
+	if bests, _ = resumed.Bests("a"); len(bests) != 4 || len(d.tables["individuals"]) != n {
+		t.Errorf("The generation should have been replaced, got %v", bests)
+	}
+	if _, ok := d.tables["individuals"][0]["ranking"]; !ok {
+		t.Error("The rank of the individuals should be stored in the ranking column")
+	}
+	// Values that aren't finite are stored as NULL and read back as NaN
+	ga.setBest(Individual{Fitness: math.Inf(1)})
+	ga.Generations++
+	if err = resumed.Export(&ga); err != nil {
+		t.Fatal(err)
+	}
+	if bests, err = resumed.Bests("a"); err != nil || len(bests) != 5 || !math.IsNaN(bests[4]) {
+		t.Errorf("Expected the last best fitness to be NaN, got %v (%v)", bests, err)
+	}
+}
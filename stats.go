@@ -0,0 +1,122 @@
+package gago
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Stats summarizes the state of a GA at a given point of a run.
+type Stats struct {
+	Generations int
+	Evaluations int
+	Duration    time.Duration
+	Best        float64 // Fitness of the overall best individual
+	Mean        float64 // Mean fitness of the individuals of every population
+	Variance    float64 // Variance of the fitness of the individuals of every population
+	// Quality of the archived front, only set if the GA has an archive
+	FrontSize   int
+	Hypervolume float64
+	IGD         float64
+	// Constraint tolerance, only set if the Comparator is an EpsilonConstraint
+	Epsilon float64
+	// Gap between the worst and the mean perturbed fitness of the best
+	// individual, only set if the fitness function is a RobustFunction
+	Sensitivity float64
+	// Time spent in each phase since the GA was initialized and during the
+	// last generation, as well as in each operator, only set if the GA is
+	// profiled
+	Timings           Timings
+	GenerationTimings Timings
+	Operators         OperatorTimings
+	// Genome of the best individual every few generations, only set if the
+	// GA has a BestHistory
+	History []BestSnapshot
+	// Summary of each population, which allows comparing the models of a GA
+	// whose populations have different models
+	Populations []PopulationStats
+}
+
+// PopulationStats summarizes the state of a population.
+type PopulationStats struct {
+	Model      Model   // Model used by the population
+	Best       float64 // Fitness of the best individual of the population
+	Mean       float64
+	Variance   float64
+	Duration   time.Duration
+	Copies     int // Number of individuals that have the fitness of the best individual
+	Stagnation int // Number of generations since the best individual last improved
+	// Takeover of the best individual, only set if the GA has a PressureMonitor
+	Growth   float64
+	Takeover float64
+}
+
+// Stats returns the current statistics of the GA.
+func (ga GA) Stats() Stats {
+	var indis = ga.Populations.merge()
+	var stats = Stats{
+		Generations: ga.Generations,
+		Evaluations: ga.Evaluations,
+		Duration:    ga.Duration,
+		Best:        ga.Best().Fitness,
+		Mean:        indis.FitnessMean(),
+		Variance:    indis.FitnessVar(),
+	}
+	if ga.Archive != nil {
+		stats.FrontSize = ga.Archive.Len()
+		stats.Hypervolume = ga.Archive.Hypervolume()
+		stats.IGD = ga.Archive.IGD()
+	}
+	if ec, ok := ga.Comparator.(*EpsilonConstraint); ok {
+		stats.Epsilon = ec.Level()
+	}
+	if f, _ := uncount(ga.Ff); isRobust(f) {
+		if best := ga.Best(); len(best.Objectives) == 2 {
+			stats.Sensitivity = best.Objectives[1] - best.Objectives[0]
+		}
+	}
+	if ga.History != nil {
+		stats.History = ga.History.Snapshots()
+	}
+	if ga.profiler != nil {
+		stats.Timings = ga.profiler.timings()
+		stats.GenerationTimings = ga.profiler.generation
+		stats.Operators = ga.profiler.operatorTimings()
+	}
+	stats.Populations = make([]PopulationStats, len(ga.Populations))
+	for i, pop := range ga.Populations {
+		stats.Populations[i] = PopulationStats{
+			Model:      ga.populationModel(i),
+			Best:       pop.Individuals[0].Fitness,
+			Mean:       pop.Individuals.FitnessMean(),
+			Variance:   pop.Individuals.FitnessVar(),
+			Duration:   pop.Duration,
+			Copies:     countCopies(pop),
+			Stagnation: pop.Stagnation,
+		}
+		if ga.Pressure != nil {
+			stats.Populations[i].Growth = ga.Pressure.Growth(i)
+			stats.Populations[i].Takeover = ga.Pressure.TakeoverTime(i)
+		}
+	}
+	return stats
+}
+
+// String returns a human readable summary of the statistics.
+func (stats Stats) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Generations: %d\n", stats.Generations)
+	fmt.Fprintf(&b, "Evaluations: %d\n", stats.Evaluations)
+	fmt.Fprintf(&b, "Duration: %v\n", stats.Duration)
+	fmt.Fprintf(&b, "Best: %g\n", stats.Best)
+	fmt.Fprintf(&b, "Mean: %g\n", stats.Mean)
+	fmt.Fprintf(&b, "Variance: %g\n", stats.Variance)
+	if stats.FrontSize > 0 {
+		fmt.Fprintf(&b, "Front size: %d\n", stats.FrontSize)
+		fmt.Fprintf(&b, "Hypervolume: %g\n", stats.Hypervolume)
+	}
+	if stats.Timings.Total() > 0 {
+		fmt.Fprint(&b, stats.Timings)
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package gago
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	var g = GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+	}
+	g.Initialize()
+	g.Enhance()
+	var stats = g.Stats()
+	if stats.Generations != g.Generations || stats.Evaluations != g.Evaluations {
+		t.Error("Stats didn't copy the counters of the GA")
+	}
+	if stats.Best != g.Best().Fitness || stats.Mean < stats.Best {
+		t.Error("Stats didn't summarize the fitnesses correctly")
+	}
+	if stats.Variance < 0 {
+		t.Error("The fitness variance should be positive")
+	}
+}
+
+func TestPopulationStats(t *testing.T) {
+	var (
+		mutOnly = ModMutationOnly{
+			NbrParents:    3,
+			Selector:      SelTournament{NbParticipants: 2},
+			KeepParents:   true,
+			NbrOffsprings: 2,
+			Mutator:       MutNormalF{Rate: 0.5, Std: 1},
+		}
+		g = GA{
+			NbrPopulations: 3,
+			NbrIndividuals: nbIndividuals,
+			NbrGenes:       nbGenes,
+			Initializer:    initializer,
+			Ff:             ff,
+			Models:         []Model{model, mutOnly},
+		}
+	)
+	g.Initialize()
+	g.Enhance()
+	var stats = g.Stats()
+	if len(stats.Populations) != 3 {
+		t.Fatalf("Expected statistics for 3 populations, got %d", len(stats.Populations))
+	}
+	// The models are assigned to the populations in turn
+	for i, model := range []Model{model, mutOnly, model} {
+		if !reflect.DeepEqual(stats.Populations[i].Model, model) {
+			t.Errorf("Population %d didn't use the expected model", i)
+		}
+	}
+	for i, pop := range stats.Populations {
+		if pop.Best != g.Populations[i].Individuals[0].Fitness || pop.Best < stats.Best {
+			t.Errorf("The best fitness of population %d is incorrect", i)
+		}
+	}
+}
+
+func TestEnhanceFor(t *testing.T) {
+	var g = GA{
+		NbrPopulations: nbPopulations,
+		NbrIndividuals: nbIndividuals,
+		NbrGenes:       nbGenes,
+		Initializer:    initializer,
+		Ff:             ff,
+		Model:          model,
+	}
+	g.Initialize()
+	var (
+		start = time.Now()
+		stats = g.EnhanceFor(20 * time.Millisecond)
+	)
+	if time.Since(start) < 20*time.Millisecond {
+		t.Error("EnhanceFor stopped before the deadline")
+	}
+	if stats.Generations == 0 || stats.Generations != g.Generations {
+		t.Error("EnhanceFor didn't run any generation")
+	}
+}
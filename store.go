@@ -0,0 +1,178 @@
+package gago
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// HashGenome returns a stable hash of a genome, which is the hexadecimal
+// SHA-256 digest of it's binary encoding. Identical genomes have the same hash
+// in every run and on every machine, hence the hash can identify a genome
+// across separate runs of a problem. An error is returned if the genome
+// contains genes that can't be encoded, see EncodeIndividuals.
+func HashGenome(genome Genome) (string, error) {
+	var key = genomeKey(genome)
+	if key == "" && len(genome) > 0 {
+		return "", fmt.Errorf("the genome %v can't be encoded", genome)
+	}
+	var sum = sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// An EvaluationStore records the fitness of genomes identified by their hash,
+// see HashGenome. A store that persists the fitnesses allows sharing the
+// evaluations between separate runs of the same problem, for example when a
+// run is resumed from a checkpoint or when a configuration is tweaked between
+// runs. MemoryStore and FileStore are provided, other backends such as a
+// database only have to implement the interface. A store is shared by the
+// populations, hence it should be safe for concurrent use.
+type EvaluationStore interface {
+	Get(hash string) (float64, bool)
+	Put(hash string, fitness float64) error
+}
+
+// MemoryStore is an EvaluationStore that keeps the fitnesses in memory, it's
+// only shared by the runs of a process. It has to be used through a pointer.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	fitnesses map[string]float64
+}
+
+// Get returns the fitness of a genome if it was stored.
+func (ms *MemoryStore) Get(hash string) (float64, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	var fitness, ok = ms.fitnesses[hash]
+	return fitness, ok
+}
+
+// Put stores the fitness of a genome.
+func (ms *MemoryStore) Put(hash string, fitness float64) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.fitnesses == nil {
+		ms.fitnesses = make(map[string]float64)
+	}
+	ms.fitnesses[hash] = fitness
+	return nil
+}
+
+// Len returns the number of stored fitnesses.
+func (ms *MemoryStore) Len() int {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return len(ms.fitnesses)
+}
+
+// FileStore is an EvaluationStore that persists the fitnesses in a text file,
+// each line of the file contains the hash of a genome followed by it's fitness.
+// The file is read when the store is opened and each new fitness is appended
+// to it, hence the store survives the end of a run. A FileStore is created
+// with OpenFileStore and should be closed once the runs are over.
+type FileStore struct {
+	MemoryStore
+	fileMu sync.Mutex // Serializes the writes to the file
+	file   *os.File
+}
+
+// OpenFileStore opens the FileStore persisted in the file at path, the file is
+// created if it doesn't exist.
+func OpenFileStore(path string) (*FileStore, error) {
+	var file, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		fs      = &FileStore{file: file}
+		scanner = bufio.NewScanner(file)
+		line    int
+	)
+	for scanner.Scan() {
+		line++
+		var fields = strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			file.Close()
+			return nil, fmt.Errorf("line %d of %s should contain a hash and a fitness", line, path)
+		}
+		var fitness, err = strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("line %d of %s: %v", line, path, err)
+		}
+		fs.MemoryStore.Put(fields[0], fitness)
+	}
+	if err = scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return fs, nil
+}
+
+// Put stores the fitness of a genome and appends it to the file.
+func (fs *FileStore) Put(hash string, fitness float64) error {
+	fs.fileMu.Lock()
+	defer fs.fileMu.Unlock()
+	if _, err := fmt.Fprintf(fs.file, "%s %s\n", hash, strconv.FormatFloat(fitness, 'g', -1, 64)); err != nil {
+		return err
+	}
+	return fs.MemoryStore.Put(hash, fitness)
+}
+
+// Close closes the file of the store.
+func (fs *FileStore) Close() error {
+	return fs.file.Close()
+}
+
+// CachedFunction looks up the fitness of each genome in Store before applying
+// Function, the fitnesses computed by Function are then added to Store. The
+// genomes that can't be hashed are always evaluated and the errors returned
+// by Store are ignored, a failing store only costs evaluations. Store hits are
+// still counted as evaluations by the GA, Hits and Misses tell how many
+// evaluations were served by the store and how many were computed. Like the
+// decorators, CachedFunction only caches the fitness, hence the errors on each
+// case, the objectives or the failures of Function are not available to the
+// GA. CachedFunction has to be used through a pointer.
+type CachedFunction struct {
+	Function FitnessFunction
+	Store    EvaluationStore
+	hits     int64
+	misses   int64
+}
+
+// Apply the fitness function wrapped in CachedFunction if the genome's fitness
+// isn't stored.
+func (ff *CachedFunction) apply(genome Genome) float64 {
+	var hash, err = HashGenome(genome)
+	if err == nil {
+		if fitness, ok := ff.Store.Get(hash); ok {
+			atomic.AddInt64(&ff.hits, 1)
+			return fitness
+		}
+	}
+	atomic.AddInt64(&ff.misses, 1)
+	var fitness = ff.Function.apply(genome)
+	if err == nil {
+		ff.Store.Put(hash, fitness)
+	}
+	return fitness
+}
+
+// Hits returns the number of evaluations that were served by the store.
+func (ff *CachedFunction) Hits() int {
+	return int(atomic.LoadInt64(&ff.hits))
+}
+
+// Misses returns the number of evaluations that were computed by Function.
+func (ff *CachedFunction) Misses() int {
+	return int(atomic.LoadInt64(&ff.misses))
+}
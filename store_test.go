@@ -0,0 +1,87 @@
+package gago
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashGenome(t *testing.T) {
+	var (
+		a, errA = HashGenome(Genome{1.5, 2, "a"})
+		b, _    = HashGenome(Genome{1.5, 2, "a"})
+		c, _    = HashGenome(Genome{1.5, 2.0, "a"})
+	)
+	if errA != nil {
+		t.Fatal(errA)
+	}
+	if a != b {
+		t.Error("Identical genomes should have the same hash")
+	}
+	if a == c {
+		t.Error("Genes of different types should have different hashes")
+	}
+	if _, err := HashGenome(Genome{struct{}{}}); err == nil {
+		t.Error("HashGenome should fail on genes that can't be encoded")
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "evaluations")
+	var fs, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.Put("a", 1.5)
+	fs.Put("b", -0.1)
+	fs.Close()
+	// The fitnesses are read back by another store
+	if fs, err = OpenFileStore(path); err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+	if fitness, ok := fs.Get("b"); !ok || fitness != -0.1 || fs.Len() != 2 {
+		t.Errorf("Expected the fitness -0.1 to be persisted, got %f", fitness)
+	}
+	if _, ok := fs.Get("c"); ok {
+		t.Error("The store shouldn't contain an unknown hash")
+	}
+	// A malformed file is rejected
+	os.WriteFile(path, []byte("a 1 2\n"), 0644)
+	if _, err = OpenFileStore(path); err == nil {
+		t.Error("OpenFileStore should fail on a malformed file")
+	}
+}
+
+func TestCachedFunction(t *testing.T) {
+	var (
+		store = &MemoryStore{}
+		ga    = GA{
+			NbrPopulations: 2,
+			NbrIndividuals: nbIndividuals,
+			NbrGenes:       nbGenes,
+			Ff:             &CachedFunction{Function: ff, Store: store},
+			Initializer:    initializer,
+			Model:          model,
+			Seed:           42,
+		}
+	)
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	var first = ga.Ff.(*CachedFunction)
+	if first.Misses() == 0 || first.Misses() < store.Len() {
+		t.Errorf("Expected at least %d misses, got %d", store.Len(), first.Misses())
+	}
+	// A second run with the same seed is served by the store
+	ga.Ff = &CachedFunction{Function: ff, Store: store}
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		ga.Enhance()
+	}
+	var second = ga.Ff.(*CachedFunction)
+	if second.Misses() != 0 || second.Hits() == 0 {
+		t.Errorf("Expected only hits, got %d hits and %d misses", second.Hits(), second.Misses())
+	}
+}
@@ -0,0 +1,172 @@
+package gago
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// A Surrogate is a cheap approximation of the fitness function. Fit trains the
+// surrogate on evaluated individuals and returns a function that estimates the
+// fitness of an individual that hasn't been evaluated.
+type Surrogate interface {
+	Fit(indis Individuals) func(indi Individual) float64
+}
+
+// SurKNN estimates the fitness of an individual with the inverse distance
+// weighted mean of the fitnesses of it's K nearest neighbours. The neighbours
+// are found with Metric, hence SurKNN works for any type of gene for which a
+// distance is defined. K should be higher or equal to 1, all the individuals
+// are used if there are less than K of them.
+type SurKNN struct {
+	K      int
+	Metric DistanceMetric
+}
+
+// Fit the k-NN surrogate, which amounts to storing copies of the evaluated
+// individuals so that they aren't modified along with the population.
+func (sur SurKNN) Fit(indis Individuals) func(indi Individual) float64 {
+	var train Individuals
+	for _, indi := range indis {
+		if indi.Evaluated && !math.IsInf(indi.Fitness, 0) {
+			var genome = newGenome(len(indi.Genome))
+			copy(genome, indi.Genome)
+			indi.Genome = genome
+			train = append(train, indi)
+		}
+	}
+	return func(indi Individual) float64 {
+		if len(train) == 0 {
+			return 0
+		}
+		var distances = make([]float64, len(train))
+		var order = make([]int, len(train))
+		for i, neighbour := range train {
+			distances[i] = sur.Metric.Apply(indi, neighbour)
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool { return distances[order[i]] < distances[order[j]] })
+		var weighted, total float64
+		for _, i := range order[:min(sur.K, len(order))] {
+			// An individual that was already evaluated gets it's known fitness
+			if distances[i] == 0 {
+				return train[i].Fitness
+			}
+			weighted += train[i].Fitness / distances[i]
+			total += 1 / distances[i]
+		}
+		return weighted / total
+	}
+}
+
+// Validate the parameters of a SurKNN.
+func (sur SurKNN) Validate() error {
+	// Check the number of neighbours
+	if sur.K < 1 {
+		return errors.New("'K' should be higher or equal to 1")
+	}
+	// Check the metric presence
+	if sur.Metric == nil {
+		return errors.New("'Metric' cannot be nil")
+	}
+	return nil
+}
+
+// ModSurrogate is a surrogate-assisted model for expensive fitness functions.
+// At each generation the Surrogate is fitted on the population and
+// NbrOffsprings candidate offsprings are generated through selection,
+// crossover and mutation. The candidates are pre-screened with the surrogate
+// and only the NbrEvaluations most promising candidates are evaluated with the
+// real fitness function, which bounds the number of real evaluations per
+// generation. The evaluated candidates are then merged with the population and
+// the best individuals survive.
+type ModSurrogate struct {
+	Surrogate      Surrogate
+	NbrOffsprings  int
+	NbrEvaluations int
+	Selector       Selector
+	Crossover      Crossover
+	Mutator        Mutator
+	MutRate        float64
+}
+
+// Apply the surrogate-assisted model to a population.
+func (mod ModSurrogate) Apply(pop *Population) {
+	pop.Individuals.Evaluate(pop.ff)
+	var (
+		predict    = mod.Surrogate.Fit(pop.Individuals)
+		candidates = generateOffsprings(
+			mod.NbrOffsprings,
+			pop.Individuals,
+			mod.Selector,
+			mod.Crossover,
+			pop.rng,
+		)
+	)
+	// Apply mutation to the candidates
+	if mod.Mutator != nil {
+		candidates.Mutate(mod.Mutator, mod.MutRate, pop.rng)
+	}
+	// Pre-screen the candidates with the surrogate
+	var predictions = make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		predictions[i] = predict(candidate)
+	}
+	sort.Sort(byPrediction{candidates, predictions})
+	// Only evaluate the most promising candidates with the fitness function
+	var selected = candidates[:min(mod.NbrEvaluations, len(candidates))]
+	selected.Evaluate(pop.ff)
+	// Keep the best individuals out of the population and the candidates
+	var indis = make(Individuals, 0, len(selected)+len(pop.Individuals))
+	indis = append(append(indis, selected...), pop.Individuals...)
+	indis.SortWith(pop.cmp)
+	copy(pop.Individuals, indis)
+}
+
+// Sort individuals by the fitness predicted by a surrogate.
+type byPrediction struct {
+	indis       Individuals
+	predictions []float64
+}
+
+func (b byPrediction) Len() int           { return len(b.indis) }
+func (b byPrediction) Less(i, j int) bool { return b.predictions[i] < b.predictions[j] }
+func (b byPrediction) Swap(i, j int) {
+	b.indis[i], b.indis[j] = b.indis[j], b.indis[i]
+	b.predictions[i], b.predictions[j] = b.predictions[j], b.predictions[i]
+}
+
+// Validate the model to verify the parameters are coherent.
+func (mod ModSurrogate) Validate() error {
+	// Check the surrogate presence
+	if mod.Surrogate == nil {
+		return errors.New("'Surrogate' cannot be nil")
+	}
+	// Check the parameters of the surrogate
+	if v, ok := mod.Surrogate.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	// Check the number of offsprings value
+	if mod.NbrOffsprings < 1 {
+		return errors.New("'NbrOffsprings' should be higher or equal to 1")
+	}
+	// Check the number of evaluations value
+	if mod.NbrEvaluations < 1 || mod.NbrEvaluations > mod.NbrOffsprings {
+		return errors.New("'NbrEvaluations' should belong to the [1, NbrOffsprings] interval")
+	}
+	// Check the selection method presence
+	if mod.Selector == nil {
+		return errors.New("'Selector' cannot be nil")
+	}
+	// Check the crossover method presence
+	if mod.Crossover == nil {
+		return errors.New("'Crossover' cannot be nil")
+	}
+	// Check the mutation rate in the presence of a mutator
+	if mod.Mutator != nil && (mod.MutRate < 0 || mod.MutRate > 1) {
+		return errors.New("'MutRate' should belong to the [0, 1] interval")
+	}
+	return nil
+}
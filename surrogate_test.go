@@ -0,0 +1,97 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSurKNN(t *testing.T) {
+	var (
+		src   = rand.NewSource(time.Now().UnixNano())
+		rng   = rand.New(src)
+		indis = makeIndividuals(3, 1, rng)
+		sur   = SurKNN{K: 2, Metric: DistEuclidean{}}
+	)
+	for i, x := range []float64{0, 1, 10} {
+		indis[i].Genome[0] = x
+		indis[i].Fitness = x
+		indis[i].Evaluated = true
+	}
+	var (
+		predict = sur.Fit(indis)
+		query   = makeIndividual(1, rng)
+	)
+	query.Genome[0] = 1.0
+	if predict(query) != 1 {
+		t.Error("SurKNN should return the fitness of a known individual")
+	}
+	query.Genome[0] = 0.5
+	if predict(query) != 0.5 {
+		t.Error("SurKNN didn't weight the neighbours by their distance")
+	}
+}
+
+func TestSurKNNCopies(t *testing.T) {
+	var (
+		indis = Individuals{{Genome: Genome{0.0}, Fitness: 0, Evaluated: true}}
+		sur   = SurKNN{K: 5, Metric: DistEuclidean{}}
+		query = Individual{Genome: Genome{1.0}}
+	)
+	if err := sur.Validate(); err != nil {
+		t.Error(err)
+	}
+	var predict = sur.Fit(indis)
+	// Modifying the population shouldn't modify the training individuals
+	indis[0].Genome[0] = 1.0
+	if predict(query) != 0 {
+		t.Error("SurKNN should use a copy of the individuals it was fitted on")
+	}
+	for _, sur := range []SurKNN{{K: 0, Metric: DistEuclidean{}}, {K: 1}} {
+		if sur.Validate() == nil {
+			t.Errorf("%+v shouldn't be valid", sur)
+		}
+	}
+	var mod = ModSurrogate{
+		Surrogate:      SurKNN{Metric: DistEuclidean{}},
+		NbrOffsprings:  2,
+		NbrEvaluations: 1,
+		Selector:       SelTournament{NbParticipants: 3},
+		Crossover:      CrossUniformF{},
+	}
+	if mod.Validate() == nil {
+		t.Error("ModSurrogate should validate it's surrogate")
+	}
+}
+
+func TestSurrogateBudget(t *testing.T) {
+	var (
+		src = rand.NewSource(time.Now().UnixNano())
+		rng = rand.New(src)
+		pop = Population{Individuals: makeIndividuals(10, 2, rng), rng: rng, ff: ff}
+		mod = ModSurrogate{
+			Surrogate:      SurKNN{K: 3, Metric: DistEuclidean{}},
+			NbrOffsprings:  20,
+			NbrEvaluations: 4,
+			Selector:       SelTournament{NbParticipants: 3},
+			Crossover:      CrossUniformF{},
+			Mutator:        MutNormalF{0.5, 1},
+			MutRate:        0.5,
+		}
+	)
+	for i := range pop.Individuals {
+		InitUniformF{Lower: -1, Upper: 1}.Apply(&pop.Individuals[i], rng)
+	}
+	pop.Individuals.Evaluate(ff)
+	if err := mod.Validate(); err != nil {
+		t.Error(err)
+	}
+	var before = EVALUATIONS
+	mod.Apply(&pop)
+	if EVALUATIONS-before != mod.NbrEvaluations {
+		t.Error("ModSurrogate didn't respect the evaluation budget")
+	}
+	if len(pop.Individuals) != 10 {
+		t.Error("ModSurrogate changed the size of the population")
+	}
+}
@@ -0,0 +1,58 @@
+package symreg
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// A Dataset contains inputs X and the associated outputs Y a tree has to
+// predict.
+type Dataset struct {
+	X [][]float64
+	Y []float64
+}
+
+// NbVariables returns the number of input variables of the dataset.
+func (ds Dataset) NbVariables() int {
+	if len(ds.X) == 0 {
+		return 0
+	}
+	return len(ds.X[0])
+}
+
+// LoadCSV reads a dataset from CSV data where each row contains the input
+// variables followed by the output. If header is true the first row is
+// skipped.
+func LoadCSV(r io.Reader, header bool) (Dataset, error) {
+	var (
+		reader = csv.NewReader(r)
+		ds     Dataset
+	)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return ds, err
+	}
+	if header && len(records) > 0 {
+		records = records[1:]
+	}
+	if len(records) == 0 {
+		return ds, errors.New("the dataset doesn't contain any rows")
+	}
+	for i, record := range records {
+		if len(record) < 2 {
+			return ds, fmt.Errorf("row %d should contain at least one input and one output", i+1)
+		}
+		var values = make([]float64, len(record))
+		for j, field := range record {
+			if values[j], err = strconv.ParseFloat(field, 64); err != nil {
+				return ds, fmt.Errorf("row %d, column %d: %s", i+1, j+1, err)
+			}
+		}
+		ds.X = append(ds.X, values[:len(values)-1])
+		ds.Y = append(ds.Y, values[len(values)-1])
+	}
+	return ds, nil
+}
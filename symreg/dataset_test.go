@@ -0,0 +1,20 @@
+package symreg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadCSV(t *testing.T) {
+	var ds, err = LoadCSV(strings.NewReader("a,b,y\n1,2,3\n4,5,9\n"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ds.NbVariables() != 2 || len(ds.Y) != 2 || ds.X[1][0] != 4 || ds.Y[1] != 9 {
+		t.Error("LoadCSV didn't parse the dataset correctly")
+	}
+	// Check invalid values are detected
+	if _, err = LoadCSV(strings.NewReader("1,a\n"), false); err == nil {
+		t.Error("LoadCSV didn't detect an invalid value")
+	}
+}
@@ -0,0 +1,65 @@
+package symreg
+
+import "math"
+
+// Protected operators return a default value instead of an infinite value or
+// NaN when they are evaluated outside of their domain, hence any randomly
+// generated tree can be evaluated safely.
+
+// Values under this threshold are considered to be 0 by protected operators.
+const protection = 1e-9
+
+// Add returns the sum of two values.
+var Add = &Function{"add", 2, func(args []float64) float64 {
+	return args[0] + args[1]
+}}
+
+// Sub returns the difference of two values.
+var Sub = &Function{"sub", 2, func(args []float64) float64 {
+	return args[0] - args[1]
+}}
+
+// Mul returns the product of two values.
+var Mul = &Function{"mul", 2, func(args []float64) float64 {
+	return args[0] * args[1]
+}}
+
+// Div returns the quotient of two values, it is protected and returns 1 if the
+// divisor is close to 0.
+var Div = &Function{"div", 2, func(args []float64) float64 {
+	if math.Abs(args[1]) < protection {
+		return 1
+	}
+	return args[0] / args[1]
+}}
+
+// Sin returns the sine of a value.
+var Sin = &Function{"sin", 1, func(args []float64) float64 {
+	return math.Sin(args[0])
+}}
+
+// Cos returns the cosine of a value.
+var Cos = &Function{"cos", 1, func(args []float64) float64 {
+	return math.Cos(args[0])
+}}
+
+// Exp returns the exponential of a value, it is protected and caps it's input
+// to 100 to avoid overflowing.
+var Exp = &Function{"exp", 1, func(args []float64) float64 {
+	return math.Exp(math.Min(args[0], 100))
+}}
+
+// Log returns the natural logarithm of the absolute value of a value, it is
+// protected and returns 0 if the value is close to 0.
+var Log = &Function{"log", 1, func(args []float64) float64 {
+	if math.Abs(args[0]) < protection {
+		return 0
+	}
+	return math.Log(math.Abs(args[0]))
+}}
+
+// StandardFunctions is the usual function set for symbolic regression.
+var StandardFunctions = []*Function{Add, Sub, Mul, Div, Sin, Cos, Exp, Log}
+
+// ArithmeticFunctions only contains the four basic arithmetic operators.
+var ArithmeticFunctions = []*Function{Add, Sub, Mul, Div}
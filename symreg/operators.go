@@ -0,0 +1,149 @@
+package symreg
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/MaxHalford/gago"
+)
+
+// Each individual's genome contains a single gene which is the root of a tree.
+
+// Tree returns the tree contained in an individual's genome.
+func Tree(indi gago.Individual) *Node {
+	return indi.Genome[0].(*Node)
+}
+
+// TreeSize returns the number of nodes of the tree contained in an
+// individual's genome, it can be used as the Size of gago's bloat control
+// operators such as SelDoubleTournament.
+func TreeSize(indi gago.Individual) float64 {
+	return float64(Tree(indi).Size())
+}
+
+// TreeDepth returns the depth of the tree contained in an individual's genome,
+// it can be used as the Size of gago.CrossLimit and gago.MutLimit to enforce a
+// depth limit.
+func TreeDepth(indi gago.Individual) float64 {
+	return float64(Tree(indi).Depth())
+}
+
+// Create an unevaluated individual containing a tree.
+func makeIndividual(tree *Node) gago.Individual {
+	return gago.Individual{
+		Genome:  gago.Genome{tree},
+		Fitness: math.Inf(1),
+	}
+}
+
+// Generate a random leaf, which is a variable with probability 0.5 if there
+// are variables and a constant in [-1, 1] otherwise.
+func randomLeaf(nbVariables int, rng *rand.Rand) *Node {
+	if nbVariables > 0 && rng.Float64() < 0.5 {
+		return &Node{Variable: rng.Intn(nbVariables)}
+	}
+	return &Node{Variable: -1, Value: 2*rng.Float64() - 1}
+}
+
+// Generate a random tree of maximum depth depth. With the full method every
+// leaf is located at the maximum depth, with the grow method a leaf can be
+// chosen at any depth.
+func randomTree(functions []*Function, nbVariables, depth int, full bool, rng *rand.Rand) *Node {
+	if depth == 0 || (!full && rng.Float64() < 0.3) {
+		return randomLeaf(nbVariables, rng)
+	}
+	var (
+		function = functions[rng.Intn(len(functions))]
+		node     = &Node{Function: function, Children: make([]*Node, function.Arity)}
+	)
+	for i := range node.Children {
+		node.Children[i] = randomTree(functions, nbVariables, depth-1, full, rng)
+	}
+	return node
+}
+
+// InitTree generates random trees with the ramped half-and-half method. The
+// depth of each tree is chosen in [MinDepth, MaxDepth] and half of the trees
+// are generated with the full method while the other half are generated with
+// the grow method.
+type InitTree struct {
+	Functions          []*Function
+	NbVariables        int
+	MinDepth, MaxDepth int
+}
+
+// Apply the InitTree initializer.
+func (init InitTree) Apply(indi *gago.Individual, rng *rand.Rand) {
+	var depth = init.MinDepth + rng.Intn(init.MaxDepth-init.MinDepth+1)
+	indi.Genome[0] = randomTree(init.Functions, init.NbVariables, depth, rng.Float64() < 0.5, rng)
+}
+
+// CrossSubtree exchanges a randomly chosen subtree of each parent. If an
+// offspring is deeper than MaxDepth it is replaced with a copy of the parent
+// it originates from, which prevents the trees from growing indefinitely.
+type CrossSubtree struct {
+	MaxDepth int
+}
+
+// Apply subtree crossover.
+func (cross CrossSubtree) Apply(p1 gago.Individual, p2 gago.Individual, rng *rand.Rand) (gago.Individual, gago.Individual) {
+	var (
+		t1     = Tree(p1).Copy()
+		t2     = Tree(p2).Copy()
+		nodes1 = t1.nodes()
+		nodes2 = t2.nodes()
+		a      = nodes1[rng.Intn(len(nodes1))]
+		b      = nodes2[rng.Intn(len(nodes2))]
+	)
+	*a, *b = *b, *a
+	if t1.Depth() > cross.MaxDepth {
+		t1 = Tree(p1).Copy()
+	}
+	if t2.Depth() > cross.MaxDepth {
+		t2 = Tree(p2).Copy()
+	}
+	return makeIndividual(t1), makeIndividual(t2)
+}
+
+// MutSubtree replaces a randomly chosen subtree with a new random subtree
+// generated with the grow method, the new subtree is generated so that the tree
+// doesn't exceed MaxDepth.
+type MutSubtree struct {
+	Functions   []*Function
+	NbVariables int
+	MaxDepth    int
+}
+
+// Apply subtree mutation.
+func (mut MutSubtree) Apply(indi *gago.Individual, rng *rand.Rand) {
+	// Copy the tree in case it is shared with another individual
+	var (
+		tree   = Tree(*indi).Copy()
+		nodes  = tree.nodes()
+		levels = tree.levels(0)
+		i      = rng.Intn(len(nodes))
+		depth  = mut.MaxDepth - levels[i]
+	)
+	if depth < 0 {
+		depth = 0
+	}
+	*nodes[i] = *randomTree(mut.Functions, mut.NbVariables, depth, false, rng)
+	indi.Genome[0] = tree
+}
+
+// MutConstant perturbs the constants of a tree with Gaussian noise of standard
+// deviation Std, each constant is perturbed with probability Rate.
+type MutConstant struct {
+	Rate, Std float64
+}
+
+// Apply constant mutation.
+func (mut MutConstant) Apply(indi *gago.Individual, rng *rand.Rand) {
+	var tree = Tree(*indi).Copy()
+	for _, node := range tree.nodes() {
+		if node.Function == nil && node.Variable < 0 && rng.Float64() < mut.Rate {
+			node.Value += rng.NormFloat64() * mut.Std
+		}
+	}
+	indi.Genome[0] = tree
+}
@@ -0,0 +1,85 @@
+package symreg
+
+import (
+	"math"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A Regressor contains what is needed to evolve trees that fit a dataset.
+// Parsimony is a penalty added to the error for each node of a tree, it
+// favors simpler trees and limits bloat.
+type Regressor struct {
+	Dataset   Dataset
+	Functions []*Function
+	Parsimony float64
+	MaxDepth  int
+}
+
+// MeanSquaredError computes the mean squared error of a tree on a dataset. If
+// a prediction isn't a finite number then the error is +Inf.
+func MeanSquaredError(tree *Node, ds Dataset) float64 {
+	var total float64
+	for i, x := range ds.X {
+		var prediction = tree.Predict(x)
+		if math.IsNaN(prediction) || math.IsInf(prediction, 0) {
+			return math.Inf(1)
+		}
+		total += math.Pow(prediction-ds.Y[i], 2)
+	}
+	return total / float64(len(ds.X))
+}
+
+// Fitness returns the fitness function to minimize, which is the mean squared
+// error of a tree plus the complexity penalty.
+func (reg Regressor) Fitness() gago.GenomeFunction {
+	return gago.GenomeFunction{
+		Image: func(genome gago.Genome) float64 {
+			var tree = genome[0].(*Node)
+			return MeanSquaredError(tree, reg.Dataset) + reg.Parsimony*float64(tree.Size())
+		},
+	}
+}
+
+// GA returns a GA configuration for evolving trees that fit the dataset. As
+// with the presets, the configuration is a starting point that can be tuned.
+func (reg Regressor) GA() gago.GA {
+	var (
+		functions = reg.Functions
+		maxDepth  = reg.MaxDepth
+	)
+	if functions == nil {
+		functions = StandardFunctions
+	}
+	if maxDepth == 0 {
+		maxDepth = 8
+	}
+	return gago.GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 100,
+		NbrGenes:       1,
+		Ff:             reg.Fitness(),
+		Initializer: InitTree{
+			Functions:   functions,
+			NbVariables: reg.Dataset.NbVariables(),
+			MinDepth:    2,
+			MaxDepth:    4,
+		},
+		Model: gago.ModGenerational{
+			Selector: gago.SelTournament{
+				NbParticipants: 3,
+			},
+			Crossover: gago.CrossProb{
+				Crossover: CrossSubtree{MaxDepth: maxDepth},
+				Prob:      0.9,
+			},
+			Mutator: gago.MutPipeline{
+				{Mutator: MutSubtree{Functions: functions, NbVariables: reg.Dataset.NbVariables(), MaxDepth: maxDepth}, Prob: 0.5},
+				{Mutator: MutConstant{Rate: 0.5, Std: 0.1}, Prob: 0.5},
+			},
+			MutRate: 0.3,
+		},
+		Migrator:     gago.MigShuffle{},
+		MigFrequency: 10,
+	}
+}
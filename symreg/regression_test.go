@@ -0,0 +1,69 @@
+package symreg
+
+import (
+	"testing"
+
+	"github.com/MaxHalford/gago"
+)
+
+func TestRegressor(t *testing.T) {
+	var ds Dataset
+	for x := -2.0; x <= 2; x += 0.25 {
+		ds.X = append(ds.X, []float64{x})
+		ds.Y = append(ds.Y, x*x+x)
+	}
+	var (
+		reg = Regressor{Dataset: ds, Functions: ArithmeticFunctions, Parsimony: 0.001}
+		ga  = reg.GA()
+	)
+	ga.Initialize()
+	var initial = ga.Best().Fitness
+	for i := 0; i < 20; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness > initial {
+		t.Error("The best tree got worse")
+	}
+	// Check the depth limit is respected by the operators
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			if Tree(indi).Depth() > 8 {
+				t.Error("A tree exceeded the maximum depth")
+			}
+		}
+	}
+}
+
+func TestBloatControl(t *testing.T) {
+	var ds Dataset
+	for x := -2.0; x <= 2; x += 0.25 {
+		ds.X = append(ds.X, []float64{x})
+		ds.Y = append(ds.Y, x*x*x)
+	}
+	var ga = Regressor{Dataset: ds, Functions: ArithmeticFunctions}.GA()
+	ga.Model = gago.ModGenerational{
+		Selector: gago.SelDoubleTournament{NbParticipants: 3, Pressure: 2, Size: TreeSize},
+		Crossover: gago.CrossLimit{
+			Crossover: CrossSubtree{MaxDepth: 20},
+			Size:      TreeDepth,
+			MaxSize:   5,
+		},
+		Mutator: gago.MutLimit{
+			Mutator: MutSubtree{Functions: ArithmeticFunctions, NbVariables: 1, MaxDepth: 20},
+			Size:    TreeDepth,
+			MaxSize: 5,
+		},
+		MutRate: 0.5,
+	}
+	ga.Initialize()
+	for i := 0; i < 10; i++ {
+		ga.Enhance()
+	}
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			if TreeDepth(indi) > 5 {
+				t.Error("A tree exceeded the depth limit")
+			}
+		}
+	}
+}
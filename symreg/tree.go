@@ -0,0 +1,112 @@
+package symreg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Function is an operator that can be placed in the inner nodes of a tree.
+type Function struct {
+	Name  string
+	Arity int
+	Eval  func(args []float64) float64
+}
+
+// A Node is an element of an expression tree. An inner node applies a Function
+// to the values of it's children. A leaf node is either a variable, in which
+// case Variable is the index of the variable in the input, or a constant, in
+// which case Variable is equal to -1.
+type Node struct {
+	Function *Function
+	Children []*Node
+	Variable int
+	Value    float64
+}
+
+// Predict evaluates the tree on a single input.
+func (node *Node) Predict(x []float64) float64 {
+	if node.Function == nil {
+		if node.Variable >= 0 {
+			return x[node.Variable]
+		}
+		return node.Value
+	}
+	var args = make([]float64, len(node.Children))
+	for i, child := range node.Children {
+		args[i] = child.Predict(x)
+	}
+	return node.Function.Eval(args)
+}
+
+// PredictAll evaluates the tree on each row of a matrix of inputs.
+func (node *Node) PredictAll(X [][]float64) []float64 {
+	var predictions = make([]float64, len(X))
+	for i, x := range X {
+		predictions[i] = node.Predict(x)
+	}
+	return predictions
+}
+
+// Size returns the number of nodes in the tree.
+func (node *Node) Size() int {
+	var size = 1
+	for _, child := range node.Children {
+		size += child.Size()
+	}
+	return size
+}
+
+// Depth returns the length of the longest path from the node to a leaf, a
+// single leaf has a depth of 0.
+func (node *Node) Depth() int {
+	var depth = 0
+	for _, child := range node.Children {
+		if d := child.Depth() + 1; d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
+
+// Copy returns a deep copy of the tree.
+func (node *Node) Copy() *Node {
+	var clone = *node
+	clone.Children = make([]*Node, len(node.Children))
+	for i, child := range node.Children {
+		clone.Children[i] = child.Copy()
+	}
+	return &clone
+}
+
+// Collect the nodes of the tree in prefix order.
+func (node *Node) nodes() []*Node {
+	var nodes = []*Node{node}
+	for _, child := range node.Children {
+		nodes = append(nodes, child.nodes()...)
+	}
+	return nodes
+}
+
+// Compute the depth at which each node of the tree is located, in prefix order.
+func (node *Node) levels(level int) []int {
+	var levels = []int{level}
+	for _, child := range node.Children {
+		levels = append(levels, child.levels(level+1)...)
+	}
+	return levels
+}
+
+// String returns the expression represented by the tree in prefix notation.
+func (node *Node) String() string {
+	if node.Function == nil {
+		if node.Variable >= 0 {
+			return fmt.Sprintf("x%d", node.Variable)
+		}
+		return fmt.Sprintf("%g", node.Value)
+	}
+	var args = make([]string, len(node.Children))
+	for i, child := range node.Children {
+		args[i] = child.String()
+	}
+	return fmt.Sprintf("%s(%s)", node.Function.Name, strings.Join(args, ", "))
+}
@@ -0,0 +1,71 @@
+package symreg
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// x0 * (x1 + 2)
+var testTree = &Node{Function: Mul, Children: []*Node{
+	{Variable: 0},
+	{Function: Add, Children: []*Node{
+		{Variable: 1},
+		{Variable: -1, Value: 2},
+	}},
+}}
+
+func TestPredict(t *testing.T) {
+	if testTree.Predict([]float64{3, 1}) != 9 {
+		t.Error("Predict didn't evaluate the tree correctly")
+	}
+	var predictions = testTree.PredictAll([][]float64{{1, 0}, {2, 2}})
+	if predictions[0] != 2 || predictions[1] != 8 {
+		t.Error("PredictAll didn't evaluate the tree correctly")
+	}
+}
+
+func TestSizeAndDepth(t *testing.T) {
+	if testTree.Size() != 5 {
+		t.Error("Size didn't count the nodes correctly")
+	}
+	if testTree.Depth() != 2 {
+		t.Error("Depth didn't measure the tree correctly")
+	}
+	var indi = makeIndividual(testTree)
+	if TreeSize(indi) != 5 || TreeDepth(indi) != 2 {
+		t.Error("TreeSize and TreeDepth didn't measure the individual's tree correctly")
+	}
+	if testTree.String() != "mul(x0, add(x1, 2))" {
+		t.Error("String didn't represent the tree correctly")
+	}
+}
+
+func TestCopy(t *testing.T) {
+	var clone = testTree.Copy()
+	clone.Children[1].Children[1].Value = 42
+	if testTree.Children[1].Children[1].Value != 2 {
+		t.Error("Copy didn't produce a deep copy")
+	}
+}
+
+func TestProtectedFunctions(t *testing.T) {
+	if Div.Eval([]float64{1, 0}) != 1 {
+		t.Error("Div isn't protected")
+	}
+	if Log.Eval([]float64{0}) != 0 {
+		t.Error("Log isn't protected")
+	}
+}
+
+func TestRandomTreeDepth(t *testing.T) {
+	var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	for depth := 0; depth < 5; depth++ {
+		if randomTree(StandardFunctions, 2, depth, true, rng).Depth() != depth {
+			t.Error("The full method didn't generate a tree of the right depth")
+		}
+		if randomTree(StandardFunctions, 2, depth, false, rng).Depth() > depth {
+			t.Error("The grow method generated a tree that is too deep")
+		}
+	}
+}
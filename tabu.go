@@ -0,0 +1,148 @@
+package gago
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// A TabuList remembers the genomes of the individuals of the last Generations
+// generations, one generation if Generations is 0, and rejects the offsprings
+// whose genome is among them, which forces the GA to explore instead of
+// evaluating the same genomes over and over. The offsprings are the
+// individuals that joined a population during the current generation, they are
+// checked after the model has been applied and before they are evaluated. A
+// rejected offspring is mutated with Mutator until it isn't tabu anymore, at
+// most Attempts times or 10 times if Attempts is 0, after which it's replaced
+// by a new random individual produced by the GA's Initializer; it's replaced
+// straight away if Mutator is nil. Genomes are identified by their binary
+// encoding, the genomes whose genes can't be encoded are never tabu.
+//
+// The list is a ring buffer with a slot per generation, it's reset when the
+// GA is initialized. A TabuList is safe for concurrent use and has to be used
+// through a pointer.
+type TabuList struct {
+	Generations int
+	Mutator     Mutator
+	Attempts    int
+	mu          sync.RWMutex
+	slots       [][]string     // Distinct genomes of each of the last generations
+	next        int            // Slot of the next generation
+	counts      map[string]int // Number of slots each genome appears in
+	rejected    int64
+}
+
+// Tabu returns true if a genome belongs to one of the last generations.
+func (tl *TabuList) Tabu(genome Genome) bool {
+	var key = genomeKey(genome)
+	if key == "" {
+		return false
+	}
+	tl.mu.RLock()
+	defer tl.mu.RUnlock()
+	return tl.counts[key] > 0
+}
+
+// Rejected returns the number of offsprings that were rejected since the GA
+// was initialized.
+func (tl *TabuList) Rejected() int {
+	return int(atomic.LoadInt64(&tl.rejected))
+}
+
+// Forget every genome.
+func (tl *TabuList) reset() {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	tl.slots = make([][]string, max(tl.Generations, 1))
+	tl.next = 0
+	tl.counts = make(map[string]int)
+	atomic.StoreInt64(&tl.rejected, 0)
+}
+
+// Return a copy of the tabu list that remembers the same genomes.
+func (tl *TabuList) clone() *TabuList {
+	tl.mu.RLock()
+	defer tl.mu.RUnlock()
+	var clone = &TabuList{
+		Generations: tl.Generations,
+		Mutator:     tl.Mutator,
+		Attempts:    tl.Attempts,
+		slots:       make([][]string, len(tl.slots)),
+		next:        tl.next,
+		counts:      make(map[string]int, len(tl.counts)),
+		rejected:    atomic.LoadInt64(&tl.rejected),
+	}
+	for i, slot := range tl.slots {
+		clone.slots[i] = append([]string(nil), slot...)
+	}
+	for key, count := range tl.counts {
+		clone.counts[key] = count
+	}
+	return clone
+}
+
+// Record the genomes of the individuals of a generation, the genomes of the
+// oldest recorded generation are forgotten.
+func (tl *TabuList) record(pops Populations) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	if tl.counts == nil {
+		tl.slots = make([][]string, max(tl.Generations, 1))
+		tl.counts = make(map[string]int)
+	}
+	for _, key := range tl.slots[tl.next] {
+		if tl.counts[key]--; tl.counts[key] == 0 {
+			delete(tl.counts, key)
+		}
+	}
+	var (
+		seen = make(map[string]bool)
+		keys []string
+	)
+	for _, pop := range pops {
+		for _, indi := range pop.Individuals {
+			var key = genomeKey(indi.Genome)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+			tl.counts[key]++
+		}
+	}
+	tl.slots[tl.next] = keys
+	tl.next = (tl.next + 1) % len(tl.slots)
+}
+
+// Replace the offsprings of a population whose genome is tabu.
+func (tl *TabuList) filter(pop *Population, nbGenes int, init Initializer) {
+	var attempts = tl.Attempts
+	if attempts == 0 {
+		attempts = 10
+	}
+	for i := range pop.Individuals {
+		var indi = &pop.Individuals[i]
+		if indi.ID != 0 || !tl.Tabu(indi.Genome) {
+			continue
+		}
+		atomic.AddInt64(&tl.rejected, 1)
+		if tl.Mutator != nil {
+			for a := 0; a < attempts && tl.Tabu(indi.Genome); a++ {
+				indi.Mutate(tl.Mutator, pop.rng)
+			}
+			if !tl.Tabu(indi.Genome) {
+				continue
+			}
+		}
+		var replacement = makeIndividual(nbGenes, pop.rng)
+		init.Apply(&replacement, pop.rng)
+		*indi = replacement
+	}
+}
+
+// Record the genomes of the current generation in the tabu list, if there is
+// one.
+func (ga *GA) updateTabu() {
+	if ga.Tabu != nil {
+		ga.Tabu.record(ga.Populations)
+	}
+}
@@ -0,0 +1,101 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTabuListRing(t *testing.T) {
+	var (
+		tl   = &TabuList{Generations: 2}
+		pops = func(x float64) Populations {
+			return Populations{{Individuals: Individuals{{Genome: Genome{x}}, {Genome: Genome{x}}}}}
+		}
+	)
+	tl.reset()
+	tl.record(pops(1))
+	tl.record(pops(2))
+	if !tl.Tabu(Genome{1.0}) || !tl.Tabu(Genome{2.0}) || tl.Tabu(Genome{3.0}) {
+		t.Error("The genomes of the last 2 generations should be tabu")
+	}
+	// The first generation is forgotten
+	tl.record(pops(3))
+	if tl.Tabu(Genome{1.0}) || !tl.Tabu(Genome{2.0}) || !tl.Tabu(Genome{3.0}) {
+		t.Error("The oldest generation wasn't forgotten")
+	}
+	if len(tl.counts) != 2 {
+		t.Errorf("Expected 2 tabu genomes, got %d", len(tl.counts))
+	}
+	// Genomes that can't be encoded are never tabu
+	if tl.Tabu(Genome{[]float64{1}}) {
+		t.Error("A genome that can't be encoded is tabu")
+	}
+}
+
+func TestTabuListFilter(t *testing.T) {
+	var (
+		pop = Population{
+			Individuals: Individuals{{Genome: Genome{1.0}, ID: 1}, {Genome: Genome{1.0}}, {Genome: Genome{2.0}}},
+			rng:         rand.New(rand.NewSource(42)),
+		}
+		tl = &TabuList{}
+	)
+	tl.record(Populations{{Individuals: Individuals{{Genome: Genome{1.0}}}}})
+	tl.filter(&pop, 1, InitUniformF{Lower: 10, Upper: 10})
+	if pop.Individuals[0].Genome[0] != 1.0 || pop.Individuals[2].Genome[0] != 2.0 {
+		t.Error("An individual that isn't a tabu offspring was replaced")
+	}
+	if x := pop.Individuals[1].Genome[0].(float64); x == 1 || x < 0 || x >= 10 {
+		t.Error("The tabu offspring wasn't replaced by a random individual")
+	}
+	// With a mutator the offspring is mutated instead
+	pop.Individuals[1] = Individual{Genome: Genome{1.0}, Evaluated: true}
+	tl.Mutator = MutNormalF{Rate: 1, Std: 0.1}
+	tl.filter(&pop, 1, InitUniformF{Lower: 10, Upper: 10})
+	if x := pop.Individuals[1].Genome[0].(float64); x == 1 || math.Abs(x-1) > 1 || pop.Individuals[1].Evaluated {
+		t.Error("The tabu offspring wasn't mutated")
+	}
+	if tl.Rejected() != 2 {
+		t.Errorf("Expected 2 rejected offsprings, got %d", tl.Rejected())
+	}
+}
+
+func TestTabuListGA(t *testing.T) {
+	var (
+		ga = NewFloatGA(2, -5, 5, func(x []float64) float64 { return x[0]*x[0] + x[1]*x[1] })
+		tl = &TabuList{Generations: 3, Mutator: MutNormalF{Rate: 1, Std: 0.5}}
+	)
+	// The model only produces copies of the parents
+	ga.Model = ModMutationOnly{
+		NbrParents:    10,
+		Selector:      SelTournament{NbParticipants: 3},
+		NbrOffsprings: 3,
+		Mutator:       MutNormalF{Rate: 0, Std: 1},
+	}
+	ga.Tabu = tl
+	ga.Initialize()
+	for i := 0; i < 5; i++ {
+		var previous = make(map[string]bool)
+		for _, pop := range ga.Populations {
+			for _, indi := range pop.Individuals {
+				previous[genomeKey(indi.Genome)] = true
+			}
+		}
+		ga.Enhance()
+		for _, pop := range ga.Populations {
+			for _, indi := range pop.Individuals {
+				if previous[genomeKey(indi.Genome)] {
+					t.Fatal("An offspring has the genome of an individual of the previous generation")
+				}
+			}
+		}
+	}
+	if tl.Rejected() == 0 {
+		t.Error("No offspring was rejected")
+	}
+	ga.Initialize()
+	if tl.Rejected() != 0 {
+		t.Error("The tabu list wasn't reset")
+	}
+}
@@ -0,0 +1,368 @@
+package gago
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A Parameter is a dimension of the space searched by a Tuner. A categorical
+// parameter, for example the choice of an operator, lists it's Values. A
+// numerical parameter, for example a mutation rate, has no Values and takes
+// values in [Lower, Upper]; if Integer is true the values are the integers of
+// the interval.
+type Parameter struct {
+	Name    string
+	Values  []interface{}
+	Lower   float64
+	Upper   float64
+	Integer bool
+}
+
+// A Setting gives a value to each parameter of a Tuner, the values of
+// numerical parameters are float64s or ints if the parameter is Integer.
+type Setting map[string]interface{}
+
+// Float returns the value of a numerical parameter as a float64.
+func (s Setting) Float(name string) float64 {
+	return toFloat(s[name])
+}
+
+// Convert the value of a numerical parameter to a float64.
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	}
+	return math.NaN()
+}
+
+// Int returns the value of an Integer parameter.
+func (s Setting) Int(name string) int {
+	var v, _ = s[name].(int)
+	return v
+}
+
+// Draw a value of a parameter uniformly.
+func (param Parameter) sample(rng *rand.Rand) interface{} {
+	if param.Values != nil {
+		return param.Values[rng.Intn(len(param.Values))]
+	}
+	if param.Integer {
+		var lower, upper = int(math.Ceil(param.Lower)), int(math.Floor(param.Upper))
+		return lower + rng.Intn(upper-lower+1)
+	}
+	return param.Lower + rng.Float64()*(param.Upper-param.Lower)
+}
+
+// Draw a value of a parameter around the value of an elite setting. The
+// higher the iteration the closer to the elite the value is.
+func (param Parameter) sampleAround(value interface{}, iteration int, rng *rand.Rand) interface{} {
+	if param.Values != nil {
+		// The value of the elite is kept more and more often
+		if rng.Float64() < 1/float64(iteration+1) {
+			return param.sample(rng)
+		}
+		return value
+	}
+	var (
+		std = (param.Upper - param.Lower) / 2 * math.Pow(0.5, float64(iteration))
+		x   = math.Max(param.Lower, math.Min(param.Upper, toFloat(value)+rng.NormFloat64()*std))
+	)
+	if param.Integer {
+		return int(math.Max(math.Ceil(param.Lower), math.Min(math.Floor(param.Upper), math.Round(x))))
+	}
+	return x
+}
+
+// Validate the parameter to verify it describes a non-empty space.
+func (param Parameter) Validate() error {
+	if param.Values != nil {
+		// Check the values of a categorical parameter
+		if len(param.Values) == 0 {
+			return fmt.Errorf("'Values' of '%s' should not be empty", param.Name)
+		}
+		return nil
+	}
+	// Check the bounds of a numerical parameter
+	if param.Lower > param.Upper {
+		return fmt.Errorf("'Lower' of '%s' should be lower or equal to 'Upper'", param.Name)
+	}
+	if param.Integer && math.Ceil(param.Lower) > math.Floor(param.Upper) {
+		return fmt.Errorf("the interval of '%s' should contain an integer", param.Name)
+	}
+	return nil
+}
+
+// A Tuner searches for good settings of a GA for a problem with an iterated
+// racing procedure, as done by irace. At each iteration NbCandidates settings
+// are raced: each round runs the GA built by NewGA with every remaining
+// setting and the same seed, and once FirstTest rounds have been run the
+// settings that are significantly worse than the best one are eliminated. A
+// round is only run if the budget of the iteration allows running every
+// remaining setting, and the race ends once a single setting remains. The
+// first iteration samples the settings uniformly whereas the next ones sample
+// them around the NbElites best settings of the previous iteration, which are
+// raced again. Budget is the total number of GA runs, each of which stops
+// according to MaxGenerations, MaxEvaluations and MaxDuration.
+//
+// The Friedman test is applied to the remaining settings and, if it's p-value
+// is lower than Alpha, each setting whose results are worse than those of the
+// setting with the best mean rank according to the rank-sum test at level
+// Alpha is eliminated. The number of iterations is 2 + log2 of the number of
+// parameters. A NbCandidates of 0 lets the Tuner choose it according to the
+// budget, a NbElites of 0 is treated as 1, a FirstTest of 0 as 5 and an Alpha
+// of 0 as 0.05.
+type Tuner struct {
+	Parameters     []Parameter
+	NewGA          func(setting Setting) GA
+	Budget         int
+	NbCandidates   int
+	NbElites       int
+	FirstTest      int
+	Alpha          float64
+	MaxGenerations int
+	MaxEvaluations int
+	MaxDuration    time.Duration
+	Seed           int64
+}
+
+// TuningResult contains the best settings found by a Tuner, the best one
+// first, along with the best fitnesses obtained with them during the last
+// race.
+type TuningResult struct {
+	Best       Setting
+	Elites     []Setting
+	Results    [][]float64 // Best fitnesses of each elite, one per round of the last race
+	Runs       int         // Number of GA runs that were used
+	Iterations int
+}
+
+// Validate the tuner to verify the parameters are coherent.
+func (tuner Tuner) Validate() error {
+	// Check the parameters
+	if len(tuner.Parameters) == 0 {
+		return errors.New("'Parameters' should not be empty")
+	}
+	for _, param := range tuner.Parameters {
+		if err := param.Validate(); err != nil {
+			return err
+		}
+	}
+	// Check the GA generator presence
+	if tuner.NewGA == nil {
+		return errors.New("'NewGA' cannot be nil")
+	}
+	// Check the parameters of the race
+	if tuner.NbCandidates < 0 || tuner.NbElites < 0 || tuner.FirstTest < 0 {
+		return errors.New("'NbCandidates', 'NbElites' and 'FirstTest' should be positive")
+	}
+	// Check the budget allows at least one race
+	if tuner.Budget < 2*tuner.firstTest() {
+		return errors.New("'Budget' should allow racing two settings for 'FirstTest' rounds")
+	}
+	// Check the significance level
+	if tuner.Alpha < 0 || tuner.Alpha >= 1 {
+		return errors.New("'Alpha' should belong to the [0, 1) interval")
+	}
+	// Check the termination criteria
+	return tuner.experiment().checkTermination()
+}
+
+// Return an experiment whose termination criteria are those of the tuner.
+func (tuner Tuner) experiment() Experiment {
+	return Experiment{
+		MaxGenerations: tuner.MaxGenerations,
+		MaxEvaluations: tuner.MaxEvaluations,
+		MaxDuration:    tuner.MaxDuration,
+	}
+}
+
+// Return the number of rounds run before the first elimination test.
+func (tuner Tuner) firstTest() int {
+	if tuner.FirstTest == 0 {
+		return 5
+	}
+	return tuner.FirstTest
+}
+
+// Run a race between settings with a given budget and return the remaining
+// settings sorted by mean rank, along with their results and the number of
+// runs that were used.
+func (tuner Tuner) race(settings []Setting, budget int, rng *rand.Rand) ([]Setting, [][]float64, int) {
+	var (
+		alpha    = tuner.Alpha
+		exp      = tuner.experiment()
+		alive    = make([]int, len(settings))
+		results  = make([][]float64, len(settings))
+		runs     int
+		nbRounds int
+	)
+	if alpha == 0 {
+		alpha = 0.05
+	}
+	for i := range alive {
+		alive[i] = i
+	}
+	for len(alive) > 1 && runs+len(alive) <= budget {
+		// Run every remaining setting with the same seed
+		var (
+			seed = rng.Int63() + 1
+			wg   sync.WaitGroup
+		)
+		for _, c := range alive {
+			wg.Add(1)
+			results[c] = append(results[c], 0)
+			go func(c int) {
+				defer wg.Done()
+				var ga = tuner.NewGA(settings[c])
+				ga.Seed = seed
+				results[c][nbRounds] = exp.run(&ga).Best
+			}(c)
+		}
+		wg.Wait()
+		runs += len(alive)
+		nbRounds++
+		// Eliminate the settings that are significantly worse
+		if nbRounds < tuner.firstTest() {
+			continue
+		}
+		var samples = make([][]float64, len(alive))
+		for i, c := range alive {
+			samples[i] = results[c]
+		}
+		if _, p, _ := FriedmanTest(samples); p >= alpha {
+			continue
+		}
+		var (
+			ranks = meanRanks(samples)
+			best  = 0
+		)
+		for i := range ranks {
+			if ranks[i] < ranks[best] {
+				best = i
+			}
+		}
+		var survivors []int
+		for i, c := range alive {
+			if z, p := RankSumTest(samples[i], samples[best]); i == best || z <= 0 || p >= alpha {
+				survivors = append(survivors, c)
+			}
+		}
+		alive = survivors
+	}
+	// Sort the remaining settings by mean rank
+	var samples = make([][]float64, len(alive))
+	for i, c := range alive {
+		samples[i] = results[c]
+	}
+	var ranks = meanRanks(samples)
+	sort.Sort(byMeanRank{alive, ranks})
+	var (
+		sorted     = make([]Setting, len(alive))
+		sortedRuns = make([][]float64, len(alive))
+	)
+	for i, c := range alive {
+		sorted[i], sortedRuns[i] = settings[c], results[c]
+	}
+	return sorted, sortedRuns, runs
+}
+
+// Sort the indexes of settings according to their mean rank.
+type byMeanRank struct {
+	indexes []int
+	ranks   []float64
+}
+
+func (s byMeanRank) Len() int           { return len(s.indexes) }
+func (s byMeanRank) Less(i, j int) bool { return s.ranks[i] < s.ranks[j] }
+func (s byMeanRank) Swap(i, j int) {
+	s.indexes[i], s.indexes[j] = s.indexes[j], s.indexes[i]
+	s.ranks[i], s.ranks[j] = s.ranks[j], s.ranks[i]
+}
+
+// Compute the mean rank of each sample, the values of a round being ranked
+// together.
+func meanRanks(samples [][]float64) []float64 {
+	var means = make([]float64, len(samples))
+	if len(samples) == 0 || len(samples[0]) == 0 {
+		return means
+	}
+	var round = make([]float64, len(samples))
+	for i := range samples[0] {
+		for j := range samples {
+			round[j] = samples[j][i]
+		}
+		var ranks, _ = rank(round)
+		for j, r := range ranks {
+			means[j] += r / float64(len(samples[0]))
+		}
+	}
+	return means
+}
+
+// Run the tuner and return the best settings it found.
+func (tuner Tuner) Run() (TuningResult, error) {
+	if err := tuner.Validate(); err != nil {
+		return TuningResult{}, err
+	}
+	var seed = tuner.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	var (
+		rng        = rand.New(rand.NewSource(seed))
+		nbElites   = tuner.NbElites
+		iterations = int(2 + math.Log2(float64(len(tuner.Parameters))))
+		result     TuningResult
+	)
+	if nbElites == 0 {
+		nbElites = 1
+	}
+	for it := 0; it < iterations && tuner.Budget-result.Runs >= 2*tuner.firstTest(); it++ {
+		// Split the remaining budget between the remaining iterations
+		var (
+			budget       = (tuner.Budget - result.Runs) / (iterations - it)
+			nbCandidates = tuner.NbCandidates
+		)
+		if nbCandidates == 0 {
+			nbCandidates = budget / (tuner.firstTest() + it)
+		}
+		if nbCandidates < 2 {
+			nbCandidates = 2
+		}
+		// Keep the elites and sample new settings
+		var settings = append([]Setting{}, result.Elites...)
+		for len(settings) < nbCandidates {
+			var setting = make(Setting, len(tuner.Parameters))
+			if len(result.Elites) == 0 {
+				for _, param := range tuner.Parameters {
+					setting[param.Name] = param.sample(rng)
+				}
+			} else {
+				var elite = result.Elites[rng.Intn(len(result.Elites))]
+				for _, param := range tuner.Parameters {
+					setting[param.Name] = param.sampleAround(elite[param.Name], it, rng)
+				}
+			}
+			settings = append(settings, setting)
+		}
+		var survivors, results, runs = tuner.race(settings, budget, rng)
+		result.Runs += runs
+		result.Iterations++
+		if len(survivors) > nbElites {
+			survivors, results = survivors[:nbElites], results[:nbElites]
+		}
+		result.Elites, result.Results = survivors, results
+	}
+	if len(result.Elites) > 0 {
+		result.Best = result.Elites[0]
+	}
+	return result, nil
+}
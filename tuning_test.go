@@ -0,0 +1,122 @@
+package gago
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParameterSample(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(42))
+		params = []Parameter{
+			{Name: "rate", Lower: 0.2, Upper: 0.4},
+			{Name: "size", Lower: 1.5, Upper: 4.5, Integer: true},
+			{Name: "op", Values: []interface{}{"a", "b"}},
+		}
+	)
+	for i := 0; i < 100; i++ {
+		var setting = Setting{}
+		for _, param := range params {
+			setting[param.Name] = param.sample(rng)
+		}
+		for it := 0; it < 3; it++ {
+			for _, param := range params {
+				setting[param.Name] = param.sampleAround(setting[param.Name], it, rng)
+			}
+			if rate := setting.Float("rate"); rate < 0.2 || rate > 0.4 {
+				t.Errorf("Sampled rate %f out of bounds", rate)
+			}
+			if size := setting.Int("size"); size < 2 || size > 4 {
+				t.Errorf("Sampled size %d out of bounds", size)
+			}
+			if op := setting["op"]; op != "a" && op != "b" {
+				t.Errorf("Sampled unknown value %v", op)
+			}
+		}
+	}
+}
+
+func TestParameterValidate(t *testing.T) {
+	var testCases = []struct {
+		param Parameter
+		valid bool
+	}{
+		{Parameter{Lower: 0, Upper: 1}, true},
+		{Parameter{Lower: 1, Upper: 1}, true},
+		{Parameter{Lower: 1, Upper: 0}, false},
+		{Parameter{Lower: 0.2, Upper: 0.8, Integer: true}, false},
+		{Parameter{Values: []interface{}{1}}, true},
+		{Parameter{Values: []interface{}{}}, false},
+	}
+	for _, test := range testCases {
+		if err := test.param.Validate(); (err == nil) != test.valid {
+			t.Errorf("Wrong validation for %+v: %v", test.param, err)
+		}
+	}
+}
+
+func TestTuner(t *testing.T) {
+	var tuner = Tuner{
+		Parameters: []Parameter{
+			{Name: "shift", Lower: 0, Upper: 10},
+			{Name: "penalty", Values: []interface{}{0.0, 100.0}},
+		},
+		NewGA: func(setting Setting) GA {
+			var shift = setting.Float("shift") + setting.Float("penalty")
+			return GA{
+				Ff: Float64Function{func(X []float64) float64 {
+					return X[0]*X[0] + shift
+				}},
+				Initializer:    initializer,
+				Model:          model,
+				NbrGenes:       1,
+				NbrIndividuals: 10,
+				NbrPopulations: 1,
+			}
+		},
+		Budget:         200,
+		NbElites:       2,
+		MaxGenerations: 2,
+		Seed:           42,
+	}
+	var result, err = tuner.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Runs > tuner.Budget {
+		t.Errorf("Used %d runs out of a budget of %d", result.Runs, tuner.Budget)
+	}
+	if result.Iterations != 3 {
+		t.Errorf("Expected 3 iterations, got %d", result.Iterations)
+	}
+	if len(result.Elites) == 0 || len(result.Elites) > tuner.NbElites || len(result.Results) != len(result.Elites) {
+		t.Fatalf("Wrong number of elites: %d", len(result.Elites))
+	}
+	if result.Best.Float("penalty") != 0 || result.Best.Float("shift") > 3 {
+		t.Errorf("Expected a setting without penalty and with a small shift, got %v", result.Best)
+	}
+}
+
+func TestTunerValidate(t *testing.T) {
+	var (
+		params = []Parameter{{Name: "rate", Lower: 0, Upper: 1}}
+		newGA  = func(setting Setting) GA { return GA{} }
+	)
+	var testCases = []Tuner{
+		{NewGA: newGA, Budget: 100, MaxGenerations: 1},
+		{Parameters: []Parameter{{Lower: 1, Upper: 0}}, NewGA: newGA, Budget: 100, MaxGenerations: 1},
+		{Parameters: params, Budget: 100, MaxGenerations: 1},
+		{Parameters: params, NewGA: newGA, Budget: 9, MaxGenerations: 1},
+		{Parameters: params, NewGA: newGA, Budget: 100, NbElites: -1, MaxGenerations: 1},
+		{Parameters: params, NewGA: newGA, Budget: 100, Alpha: 1, MaxGenerations: 1},
+		{Parameters: params, NewGA: newGA, Budget: 100},
+	}
+	for _, tuner := range testCases {
+		if tuner.Validate() == nil {
+			t.Errorf("Expected an error for %+v", tuner)
+		}
+		if _, err := tuner.Run(); err == nil {
+			t.Error("Run should fail for an invalid tuner")
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package gago
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// IndividualOf is the generic counterpart of Individual, it's genome is a slice
+// of genes of a single type G, for example []float64 or []string. The generic
+// operators read and write the genes of an IndividualOf directly, which spares
+// them the type assertions and the boxing of each gene in an interface. The
+// operators suffixed with Of are the generic versions of the classic
+// operators.
+//
+// The GA and the rest of the API work with Individual, whose genes are
+// interfaces, which acts as a compatibility layer: Box and Unbox convert an
+// individual from one form to the other and the generic operators are wrapped
+// with InitTyped, CrossTyped and MutTyped to be used by a GA. The genes of the
+// boxed genomes are stored one by one, hence the genomes can be hashed,
+// deduplicated, checkpointed and exported like any other genome.
+type IndividualOf[G any] struct {
+	Genome    []G
+	Fitness   float64
+	Evaluated bool
+	Name      string
+}
+
+// Float is the set of floating point gene types handled by the generic
+// operators that only work with floating point values.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Integer is the set of integer gene types handled by the generic operators
+// that only work with integer values.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// MakeIndividualOf returns a new individual whose genome contains nbGenes zero
+// genes.
+func MakeIndividualOf[G any](nbGenes int, rng *rand.Rand) IndividualOf[G] {
+	return IndividualOf[G]{
+		Genome:    make([]G, nbGenes),
+		Fitness:   math.Inf(1),
+		Evaluated: false,
+		Name:      randomString(6, rng),
+	}
+}
+
+// Clone an individual by copying it's genome so that the clone can be modified
+// without altering the original individual. The clone is given a new name.
+func (indi IndividualOf[G]) Clone(rng *rand.Rand) IndividualOf[G] {
+	var clone = indi
+	clone.Genome = append([]G(nil), indi.Genome...)
+	clone.Name = randomString(6, rng)
+	return clone
+}
+
+// Evaluate the fitness of an individual if it hasn't been evaluated yet.
+func (indi *IndividualOf[G]) Evaluate(ff TypedFunction[G]) {
+	if !indi.Evaluated {
+		indi.Fitness = ff.Image(indi.Genome)
+		countEvaluations(1)
+	}
+	indi.Evaluated = true
+}
+
+// Mutate applies a mutator to an individual and sets it's Evaluated property to
+// false.
+func (indi *IndividualOf[G]) Mutate(mutator MutatorOf[G], rng *rand.Rand) {
+	indi.Evaluated = false
+	mutator.Apply(indi, rng)
+}
+
+// Crossover an individual with a mate and return the offsprings.
+func (indi IndividualOf[G]) Crossover(mate IndividualOf[G], crossover CrossoverOf[G], rng *rand.Rand) (IndividualOf[G], IndividualOf[G]) {
+	return crossover.Apply(indi, mate, rng)
+}
+
+// Box returns the individual as an Individual whose genes are interfaces.
+func (indi IndividualOf[G]) Box() Individual {
+	var genome = newGenome(len(indi.Genome))
+	boxGenes(indi.Genome, genome)
+	return Individual{
+		Genome:    genome,
+		Fitness:   indi.Fitness,
+		Evaluated: indi.Evaluated,
+		Name:      indi.Name,
+	}
+}
+
+// Unbox returns an Individual as an IndividualOf, ok is false if one of the
+// genes isn't of type G.
+func Unbox[G any](indi Individual) (unboxed IndividualOf[G], ok bool) {
+	var genes []G
+	if genes, ok = unboxGenes[G](indi.Genome); !ok {
+		return unboxed, false
+	}
+	return IndividualOf[G]{
+		Genome:    genes,
+		Fitness:   indi.Fitness,
+		Evaluated: indi.Evaluated,
+		Name:      indi.Name,
+	}, true
+}
+
+// Copy the genes of a generic genome into a genome of the same length.
+func boxGenes[G any](genes []G, genome Genome) {
+	for i, gene := range genes {
+		genome[i] = gene
+	}
+}
+
+// Return the genes of a genome as a slice of G, ok is false if one of the genes
+// isn't of type G.
+func unboxGenes[G any](genome Genome) ([]G, bool) {
+	var genes = make([]G, len(genome))
+	for i, gene := range genome {
+		var g, ok = gene.(G)
+		if !ok {
+			return nil, false
+		}
+		genes[i] = g
+	}
+	return genes, true
+}
+
+// Unbox an individual handled by a wrapped generic operator, the genes of the
+// individuals of a GA using a generic operator have to be of type G.
+func mustUnbox[G any](indi Individual) IndividualOf[G] {
+	var unboxed, ok = Unbox[G](indi)
+	if !ok {
+		panic(fmt.Errorf("the genome %v doesn't only contain genes of type %T", indi.Genome, *new(G)))
+	}
+	return unboxed
+}
+
+// An InitializerOf fills the genome of a generic individual.
+type InitializerOf[G any] interface {
+	Apply(indi *IndividualOf[G], rng *rand.Rand)
+}
+
+// A CrossoverOf produces two generic individuals from two parents, the parents
+// shouldn't be modified.
+type CrossoverOf[G any] interface {
+	Apply(p1, p2 IndividualOf[G], rng *rand.Rand) (IndividualOf[G], IndividualOf[G])
+}
+
+// A MutatorOf modifies the genome of a generic individual in place.
+type MutatorOf[G any] interface {
+	Apply(indi *IndividualOf[G], rng *rand.Rand)
+}
+
+// TypedFunction is for functions that take a generic genome as input, it
+// evaluates both IndividualOf and Individual whose genes are of type G.
+type TypedFunction[G any] struct {
+	Image func([]G) float64
+}
+
+// Apply the fitness function wrapped in TypedFunction.
+func (ff TypedFunction[G]) apply(genome Genome) float64 {
+	var genes, ok = unboxGenes[G](genome)
+	if !ok {
+		panic(fmt.Errorf("the genome %v doesn't only contain genes of type %T", genome, *new(G)))
+	}
+	return ff.Image(genes)
+}
+
+// InitTyped initializes the genomes of a GA with an InitializerOf.
+type InitTyped[G any] struct {
+	Initializer InitializerOf[G]
+}
+
+// Apply the InitTyped initializer.
+func (init InitTyped[G]) Apply(indi *Individual, rng *rand.Rand) {
+	var generic = IndividualOf[G]{Genome: make([]G, len(indi.Genome))}
+	init.Initializer.Apply(&generic, rng)
+	boxGenes(generic.Genome, indi.Genome)
+}
+
+// CrossTyped applies a CrossoverOf to the individuals of a GA.
+type CrossTyped[G any] struct {
+	Crossover CrossoverOf[G]
+}
+
+// Apply the wrapped crossover.
+func (cross CrossTyped[G]) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	return crossBoxed[G](cross.Crossover, p1, p2, rng)
+}
+
+// Apply a generic crossover to boxed parents.
+func crossBoxed[G any](cross CrossoverOf[G], p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var o1, o2 = cross.Apply(mustUnbox[G](p1), mustUnbox[G](p2), rng)
+	return o1.Box(), o2.Box()
+}
+
+// MutTyped applies a MutatorOf to the individuals of a GA. The genome of the
+// individual is modified in place, as with any other mutator.
+type MutTyped[G any] struct {
+	Mutator MutatorOf[G]
+}
+
+// Apply the wrapped mutator.
+func (mut MutTyped[G]) Apply(indi *Individual, rng *rand.Rand) {
+	var generic = mustUnbox[G](*indi)
+	mut.Mutator.Apply(&generic, rng)
+	boxGenes(generic.Genome, indi.Genome)
+}
+
+// InitUniformOf picks each gene uniformly from Corpus.
+type InitUniformOf[G any] struct {
+	Corpus []G
+}
+
+// Apply the InitUniformOf initializer.
+func (init InitUniformOf[G]) Apply(indi *IndividualOf[G], rng *rand.Rand) {
+	for i := range indi.Genome {
+		indi.Genome[i] = init.Corpus[rng.Intn(len(init.Corpus))]
+	}
+}
+
+// InitUniqueOf generates random permutations of Corpus, the genomes should
+// have as many genes as there are elements in Corpus.
+type InitUniqueOf[G any] struct {
+	Corpus []G
+}
+
+// Apply the InitUniqueOf initializer.
+func (init InitUniqueOf[G]) Apply(indi *IndividualOf[G], rng *rand.Rand) {
+	for i, j := range rng.Perm(len(init.Corpus)) {
+		indi.Genome[i] = init.Corpus[j]
+	}
+}
+
+// CrossPointOf is the generic version of CrossPoint.
+type CrossPointOf[G any] struct {
+	NbPoints int
+}
+
+// Apply n-point crossover to generic individuals.
+func (cross CrossPointOf[G]) Apply(p1, p2 IndividualOf[G], rng *rand.Rand) (IndividualOf[G], IndividualOf[G]) {
+	var (
+		nbGenes = len(p1.Genome)
+		o1      = MakeIndividualOf[G](nbGenes, rng)
+		o2      = MakeIndividualOf[G](nbGenes, rng)
+		points  = crossPoints(cross.NbPoints, blockBounds(nil, nbGenes), rng)
+	)
+	crossSegments(p1.Genome, p2.Genome, o1.Genome, o2.Genome, points)
+	return o1, o2
+}
+
+// CrossUniformOf is the generic version of CrossUniform.
+type CrossUniformOf[G any] struct{}
+
+// Apply uniform crossover to generic individuals.
+func (cross CrossUniformOf[G]) Apply(p1, p2 IndividualOf[G], rng *rand.Rand) (IndividualOf[G], IndividualOf[G]) {
+	var (
+		o1 = MakeIndividualOf[G](len(p1.Genome), rng)
+		o2 = MakeIndividualOf[G](len(p1.Genome), rng)
+	)
+	crossBlocks(p1.Genome, p2.Genome, o1.Genome, o2.Genome, nil, rng)
+	return o1, o2
+}
+
+// CrossPMXOf is the generic version of CrossPMX, the genes have to be
+// comparable.
+type CrossPMXOf[G comparable] struct{}
+
+// Apply partially mapped crossover to generic individuals.
+func (cross CrossPMXOf[G]) Apply(p1, p2 IndividualOf[G], rng *rand.Rand) (IndividualOf[G], IndividualOf[G]) {
+	var (
+		o1 = MakeIndividualOf[G](len(p1.Genome), rng)
+		o2 = MakeIndividualOf[G](len(p1.Genome), rng)
+	)
+	crossPMX(p1.Genome, p2.Genome, o1.Genome, o2.Genome, pmxPoint(len(p1.Genome), rng))
+	return o1, o2
+}
+
+// MutPermuteOf is the generic version of MutPermute.
+type MutPermuteOf[G any] struct {
+	Max int
+}
+
+// Apply permutation mutation to a generic individual.
+func (mut MutPermuteOf[G]) Apply(indi *IndividualOf[G], rng *rand.Rand) {
+	var genome = indi.Genome
+	if len(genome) < 2 {
+		return
+	}
+	for n := 0; n <= rng.Intn(max(mut.Max, 1)); n++ {
+		var points, _ = randomInts(2, 0, len(genome), rng)
+		genome[points[0]], genome[points[1]] = genome[points[1]], genome[points[0]]
+	}
+}
@@ -0,0 +1,192 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestTypedCrossovers(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(time.Now().UnixNano()))
+		corpus = []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+		p1     = MakeIndividualOf[string](len(corpus), rng)
+		p2     = MakeIndividualOf[string](len(corpus), rng)
+		init   = InitUniqueOf[string]{Corpus: corpus}
+	)
+	init.Apply(&p1, rng)
+	init.Apply(&p2, rng)
+	// Each gene of the offsprings comes from one parent and the other
+	// offspring gets the other parent's gene
+	for _, cross := range []CrossoverOf[string]{CrossPointOf[string]{NbPoints: 2}, CrossUniformOf[string]{}} {
+		var o1, o2 = p1.Crossover(p2, cross, rng)
+		for i := range p1.Genome {
+			if !(o1.Genome[i] == p1.Genome[i] && o2.Genome[i] == p2.Genome[i] || o1.Genome[i] == p2.Genome[i] && o2.Genome[i] == p1.Genome[i]) {
+				t.Errorf("%T produced an inconsistent gene at position %d", cross, i)
+				break
+			}
+		}
+	}
+	// PMX produces permutations
+	var o1, o2 = CrossPMXOf[string]{}.Apply(p1, p2, rng)
+	for _, o := range []IndividualOf[string]{o1, o2} {
+		var sorted = append([]string(nil), o.Genome...)
+		sort.Strings(sorted)
+		for i := range sorted {
+			if sorted[i] != corpus[i] {
+				t.Error("CrossPMXOf didn't produce a permutation")
+				break
+			}
+		}
+	}
+}
+
+func TestTypedFloatCrossovers(t *testing.T) {
+	var (
+		rng = rand.New(rand.NewSource(42))
+		p1  = IndividualOf[float32]{Genome: []float32{0, 1, 2}, Fitness: 1, Evaluated: true}
+		p2  = IndividualOf[float32]{Genome: []float32{2, 1, 0}, Fitness: 2, Evaluated: true}
+	)
+	var o1, o2 = CrossArithmeticOf[float32]{Alpha: 0.25}.Apply(p1, p2, rng)
+	if o1.Genome[0] != 1.5 || o2.Genome[0] != 0.5 || o1.Genome[1] != 1 {
+		t.Errorf("Wrong arithmetic offsprings %v and %v", o1.Genome, o2.Genome)
+	}
+	// The heuristic offsprings extrapolate beyond the fittest parent
+	o1, o2 = CrossHeuristicOf[float32]{}.Apply(p2, p1, rng)
+	if o1.Genome[0] > 0 || o2.Genome[2] < 2 {
+		t.Errorf("Wrong heuristic offsprings %v and %v", o1.Genome, o2.Genome)
+	}
+	var s1, s2 = CrossSegmentOf[uint8]{Lower: 0, Upper: 5}.Apply(
+		IndividualOf[uint8]{Genome: []uint8{0, 9, 3}},
+		IndividualOf[uint8]{Genome: []uint8{4, 9, 3}},
+		rng,
+	)
+	for _, o := range []IndividualOf[uint8]{s1, s2} {
+		if o.Genome[0] > 4 || o.Genome[1] != 5 && o.Genome[1] != 9 || o.Genome[2] != 3 {
+			t.Errorf("Wrong segment offspring %v", o.Genome)
+		}
+	}
+}
+
+// The boxed crossovers are the generic crossovers applied to boxed genomes,
+// hence they produce the same offsprings from the same random numbers.
+func TestBoxedCrossovers(t *testing.T) {
+	var (
+		p1     = IndividualOf[float64]{Genome: []float64{0, 1, 2}, Fitness: 2, Evaluated: true, Name: "a"}
+		p2     = IndividualOf[float64]{Genome: []float64{3, -1, 5}, Fitness: 1, Evaluated: true, Name: "b"}
+		boxed  = []Crossover{CrossArithmeticF{Alpha: 0.3}, CrossHeuristicF{}, CrossBLXF{Alpha: 0.5, Beta: 0.1}}
+		typed  = []CrossoverOf[float64]{CrossArithmeticOf[float64]{Alpha: 0.3}, CrossHeuristicOf[float64]{}, CrossBLXOf[float64]{Alpha: 0.5, Beta: 0.1}}
+		unbox1 IndividualOf[float64]
+		ok     bool
+	)
+	if unbox1, ok = Unbox[float64](p1.Box()); !ok || unbox1.Name != "a" || unbox1.Fitness != 2 || !unbox1.Evaluated {
+		t.Error("Unbox didn't return the boxed individual")
+	}
+	if _, ok = Unbox[int](p1.Box()); ok {
+		t.Error("Unbox should fail if the genes have another type")
+	}
+	for i, cross := range boxed {
+		var (
+			b1, b2 = cross.Apply(p1.Box(), p2.Box(), rand.New(rand.NewSource(42)))
+			t1, t2 = typed[i].Apply(p1, p2, rand.New(rand.NewSource(42)))
+		)
+		for j := range b1.Genome {
+			if b1.Genome[j] != t1.Genome[j] || b2.Genome[j] != t2.Genome[j] {
+				t.Errorf("%T and %T produced different offsprings", cross, typed[i])
+				break
+			}
+		}
+	}
+}
+
+func TestMutTyped(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indi = Individual{Genome: Genome{0, 1, 2, 3, 4}, Evaluated: true, ID: 7}
+	)
+	indi.Mutate(MutTyped[int]{MutPermuteOf[int]{Max: 3}}, rng)
+	var genes, ok = unboxGenes[int](indi.Genome)
+	if !ok || len(genes) != 5 {
+		t.Fatal("MutTyped didn't keep the genes boxed one by one")
+	}
+	sort.Ints(genes)
+	for i, x := range genes {
+		if x != i {
+			t.Error("MutTyped didn't permute the genes")
+		}
+	}
+	if indi.Evaluated || indi.ID != 7 {
+		t.Error("MutTyped altered the individual")
+	}
+}
+
+func TestIndividualOf(t *testing.T) {
+	var (
+		rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+		indi  = MakeIndividualOf[float64](3, rng)
+		calls int
+		ff    = TypedFunction[float64]{func(X []float64) float64 {
+			calls++
+			return math.Abs(X[0] + X[1] + X[2])
+		}}
+	)
+	indi.Genome[0] = 1
+	indi.Evaluate(ff)
+	indi.Evaluate(ff)
+	if indi.Fitness != 1 || calls != 1 {
+		t.Error("The individual wasn't evaluated once")
+	}
+	var clone = indi.Clone(rng)
+	clone.Mutate(MutPermuteOf[float64]{}, rng)
+	if clone.Evaluated || clone.Name == indi.Name || indi.Genome[0] != 1 {
+		t.Error("The clone should be mutated independently")
+	}
+	// The fitness function also evaluates boxed individuals
+	var boxed = indi.Box()
+	boxed.Evaluated = false
+	boxed.Evaluate(ff)
+	if boxed.Fitness != 1 {
+		t.Error("TypedFunction didn't evaluate the boxed individual")
+	}
+}
+
+func TestTypedGA(t *testing.T) {
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 30,
+		NbrGenes:       50,
+		Ff: TypedFunction[bool]{
+			Image: func(genome []bool) float64 {
+				var n float64
+				for _, b := range genome {
+					if !b {
+						n++
+					}
+				}
+				return n
+			},
+		},
+		Initializer: InitTyped[bool]{InitUniformOf[bool]{Corpus: []bool{false, true}}},
+		Model: ModGenerational{
+			Selector:  SelTournament{NbParticipants: 3},
+			Crossover: CrossTyped[bool]{CrossUniformOf[bool]{}},
+			Mutator:   MutTyped[bool]{MutPermuteOf[bool]{Max: 2}},
+			MutRate:   0.5,
+		},
+		Deduplicate: true,
+	}
+	ga.Initialize()
+	var initial = ga.Best().Fitness
+	for i := 0; i < 20; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness >= initial {
+		t.Error("OneMax didn't improve")
+	}
+	// The genes are boxed one by one, hence the genomes can be encoded
+	if _, err := HashGenome(ga.Best().Genome); err != nil {
+		t.Error(err)
+	}
+}
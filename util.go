@@ -2,12 +2,14 @@ package gago
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 )
 
 // Find where an element is in a slice.
-func getIndex(element interface{}, array []interface{}) int {
+func getIndex[G comparable](element G, array []G) int {
 	for i, v := range array {
 		if v == element {
 			return i
@@ -51,13 +53,63 @@ func min(a, b int) int {
 	return b
 }
 
+// Restrict an integer to the [lower, upper] range.
+func clipInt[G Integer](x, lower, upper G) G {
+	if x < lower {
+		return lower
+	}
+	if x > upper {
+		return upper
+	}
+	return x
+}
+
+// Find the minimum of a float64 slice.
+func min64(slice []float64) float64 {
+	var m = math.Inf(1)
+	for _, v := range slice {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+// Compute the sum of a float64 slice.
+func sum(slice []float64) float64 {
+	var total float64
+	for _, v := range slice {
+		total += v
+	}
+	return total
+}
+
 // Compute the mean of a slice of a float64 slice.
 func mean(slice []float64) float64 {
-	var sum float64
-	for _, v := range slice {
-		sum += v
+	return sum(slice) / float64(len(slice))
+}
+
+// Compute the median of a float64 slice without modifying it.
+func median(slice []float64) float64 {
+	var sorted = make([]float64, len(slice))
+	copy(sorted, slice)
+	sort.Float64s(sorted)
+	var n = len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// Compute the median absolute deviation of a float64 slice, which is a robust
+// measure of the spread of the values.
+func medianAbsoluteDeviation(slice []float64) float64 {
+	var (
+		m          = median(slice)
+		deviations = make([]float64, len(slice))
+	)
+	for i, v := range slice {
+		deviations[i] = math.Abs(v - m)
 	}
-	return sum / float64(len(slice))
+	return median(deviations)
 }
 
 // Compute the variance of a float64 slice.
@@ -70,6 +122,53 @@ func variance(slice []float64) float64 {
 	return mean(squares) - math.Pow(mean(slice), 2)
 }
 
+// Draw the number of trials before the next success of a geometric
+// distribution whose probability of failure is exp(logq). The number is
+// clamped to n before being converted to an int, which would overflow when
+// the probability of success is very small.
+func geometricSkip(logq float64, n int, rng *rand.Rand) int {
+	return int(math.Min(math.Log(1-rng.Float64())/logq, float64(n)))
+}
+
+// Check the weights of n operators that pickWeighted chooses from, name is the
+// name of the field that holds the operators.
+func checkWeights(name string, n int, weights []float64) error {
+	if n == 0 {
+		return fmt.Errorf("'%s' should contain at least one operator", name)
+	}
+	if weights == nil {
+		return nil
+	}
+	if len(weights) != n {
+		return fmt.Errorf("'Weights' should contain %d weights, one for each operator", n)
+	}
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return errors.New("'Weights' should only contain finite weights higher or equal to 0")
+		}
+	}
+	if sum(weights) <= 0 {
+		return errors.New("'Weights' should contain at least one weight higher than 0")
+	}
+	return nil
+}
+
+// Pick an index out of n with a probability proportional to it's weight, the
+// indexes are picked uniformly if weights is nil.
+func pickWeighted(n int, weights []float64, rng *rand.Rand) int {
+	if weights == nil {
+		return rng.Intn(n)
+	}
+	var r = rng.Float64() * sum(weights)
+	for i, w := range weights {
+		r -= w
+		if r < 0 {
+			return i
+		}
+	}
+	return n - 1
+}
+
 // Sample k unique integers in range [min, max) using reservoir sampling,
 // specifically Algorithm R. It can be proven by induction that each interger
 // has probability of 1/(max-min) to be selected.
@@ -79,10 +178,10 @@ func randomInts(k, min, max int, rng *rand.Rand) ([]int, error) {
 	}
 	var ints = make([]int, k)
 	for i := min; i < min+k; i++ {
-		ints[i] = i
+		ints[i-min] = i
 	}
 	for i := min + k; i < max; i++ {
-		var j = rng.Intn(i)
+		var j = rng.Intn(i - min + 1)
 		if j < k {
 			ints[j] = i
 		}
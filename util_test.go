@@ -144,6 +144,23 @@ func TestRandomInts(t *testing.T) {
 	}
 }
 
+func TestRandomIntsUniform(t *testing.T) {
+	var (
+		rng    = rand.New(rand.NewSource(time.Now().UnixNano()))
+		counts = make(map[int]int)
+	)
+	for i := 0; i < 4000; i++ {
+		var ints, _ = randomInts(1, 2, 6, rng)
+		counts[ints[0]]++
+	}
+	// Each integer should be drawn around 1000 times
+	for i := 2; i < 6; i++ {
+		if counts[i] < 800 || counts[i] > 1200 {
+			t.Errorf("randomInts drew %d %d times out of 4000", i, counts[i])
+		}
+	}
+}
+
 func TestRandomString(t *testing.T) {
 	var (
 		src = rand.NewSource(time.Now().UnixNano())
@@ -157,3 +174,23 @@ func TestRandomString(t *testing.T) {
 		}
 	}
 }
+
+func TestMedian(t *testing.T) {
+	var testCases = []struct {
+		values []float64
+		median float64
+		mad    float64
+	}{
+		{[]float64{1.0}, 1.0, 0.0},
+		{[]float64{3.0, 1.0, 2.0}, 2.0, 1.0},
+		{[]float64{1.0, 2.0, 3.0, 10.0}, 2.5, 1.0},
+	}
+	for _, testCase := range testCases {
+		if median(testCase.values) != testCase.median {
+			t.Error("median didn't work as expected")
+		}
+		if medianAbsoluteDeviation(testCase.values) != testCase.mad {
+			t.Error("medianAbsoluteDeviation didn't work as expected")
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package gago
+
+import (
+	"math"
+	"math/rand"
+)
+
+// A Vector stores a floating point genome in a contiguous slice, which avoids
+// boxing each float64 in an interface and allows the compiler to optimize the
+// loops that process the genes. A packed genome holds a single gene which is a
+// Vector, the operators that are suffixed with Vector work on such genomes.
+// Vectors are shared between individuals, hence the operators never modify an
+// existing Vector but create new ones.
+type Vector []float64
+
+// VectorOf returns the Vector contained in an individual's genome.
+func VectorOf(indi Individual) Vector {
+	return indi.Genome[0].(Vector)
+}
+
+// Copy returns a Vector that doesn't share it's values with the original.
+func (v Vector) Copy() Vector {
+	var c = make(Vector, len(v))
+	copy(c, v)
+	return c
+}
+
+// VectorFunction is for functions that take a packed floating point genome as
+// input, the same functions as the ones of Float64Function can be used.
+type VectorFunction struct {
+	Image func([]float64) float64
+}
+
+// Apply the fitness function wrapped in VectorFunction.
+func (ff VectorFunction) apply(genome Genome) float64 {
+	return ff.Image(genome[0].(Vector))
+}
+
+// DistVector computes the Euclidean distance between two packed floating point
+// genomes.
+type DistVector struct{}
+
+// Apply the Vector Euclidean distance.
+func (dist DistVector) Apply(a, b Individual) float64 {
+	var (
+		u   = VectorOf(a)
+		v   = VectorOf(b)
+		sum float64
+	)
+	for i := range u {
+		sum += (u[i] - v[i]) * (u[i] - v[i])
+	}
+	return math.Sqrt(sum)
+}
+
+// InitUniformVector generates packed floating point genomes of N values
+// sampled uniformly in [Lower, Upper), the genomes should contain a single
+// gene.
+type InitUniformVector struct {
+	N            int
+	Lower, Upper float64
+}
+
+// Apply the InitUniformVector initializer.
+func (init InitUniformVector) Apply(indi *Individual, rng *rand.Rand) {
+	var v = make(Vector, init.N)
+	for i := range v {
+		v[i] = init.Lower + rng.Float64()*(init.Upper-init.Lower)
+	}
+	indi.Genome[0] = v
+}
+
+// Make an offspring containing a Vector.
+func makeVectorIndividual(v Vector, rng *rand.Rand) Individual {
+	var indi = makeIndividual(1, rng)
+	indi.Genome[0] = v
+	return indi
+}
+
+// CrossUniformVector is the equivalent of CrossUniformF for packed floating
+// point genomes, each value of an offspring is a random convex combination of
+// the values of the parents. The weights are drawn first so that the
+// combination is a plain loop over the slices.
+type CrossUniformVector struct{}
+
+// Apply uniform crossover to packed floating point genomes.
+func (cross CrossUniformVector) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var (
+		a  = VectorOf(p1)
+		b  = VectorOf(p2)
+		o1 = make(Vector, len(a))
+		o2 = make(Vector, len(a))
+	)
+	for i := range o1 {
+		o1[i] = rng.Float64()
+	}
+	for i := range o1 {
+		var d = a[i] - b[i]
+		o2[i] = a[i] - o1[i]*d
+		o1[i] = b[i] + o1[i]*d
+	}
+	return makeVectorIndividual(o1, rng), makeVectorIndividual(o2, rng)
+}
+
+// CrossArithmeticVector is the equivalent of CrossArithmeticF for packed
+// floating point genomes, the offsprings are the convex combinations of the
+// parents with weights Alpha and 1-Alpha.
+type CrossArithmeticVector struct {
+	Alpha float64
+}
+
+// Apply arithmetic crossover to packed floating point genomes.
+func (cross CrossArithmeticVector) Apply(p1 Individual, p2 Individual, rng *rand.Rand) (Individual, Individual) {
+	var (
+		a  = VectorOf(p1)
+		b  = VectorOf(p2)
+		o1 = make(Vector, len(a))
+		o2 = make(Vector, len(a))
+	)
+	for i := range o1 {
+		o1[i] = cross.Alpha*a[i] + (1-cross.Alpha)*b[i]
+		o2[i] = (1-cross.Alpha)*a[i] + cross.Alpha*b[i]
+	}
+	return makeVectorIndividual(o1, rng), makeVectorIndividual(o2, rng)
+}
+
+// MutNormalVector adds Gaussian noise of standard deviation Std to each value
+// of a packed floating point genome with probability Rate. As with
+// MutFlipBitset the gaps between mutated values are drawn from a geometric
+// distribution instead of drawing a random number for each value.
+type MutNormalVector struct {
+	Rate float64 // Mutation rate for each value
+	Std  float64 // Standard deviation
+}
+
+// Apply normal mutation to a packed floating point genome.
+func (mut MutNormalVector) Apply(indi *Individual, rng *rand.Rand) {
+	if mut.Rate <= 0 {
+		return
+	}
+	var (
+		v    = VectorOf(*indi).Copy()
+		logq = math.Log1p(-mut.Rate)
+	)
+	for i := -1; ; {
+		// Number of values to skip before the next mutation
+		if mut.Rate < 1 {
+			i += geometricSkip(logq, len(v), rng) + 1
+		} else {
+			i++
+		}
+		if i >= len(v) {
+			break
+		}
+		v[i] += rng.NormFloat64() * mut.Std
+	}
+	indi.Genome[0] = v
+}
+
+// Return the Vector held by a genome, ok is false if the genome doesn't consist
+// of a single Vector, for example if it's empty.
+func genomeVector(genome Genome) (Vector, bool) {
+	if len(genome) != 1 {
+		return nil, false
+	}
+	var v, ok = genome[0].(Vector)
+	return v, ok
+}
+
+// FlattenF copies floating point genomes into a single contiguous slice, one
+// genome after the other, which is the layout expected by evaluators that
+// process a whole generation at once, for example through cgo, CUDA or ONNX.
+// It's typically used in the function of a BatchFunction. The genomes can
+// either be made of float64 genes or hold a Vector, they should all have the
+// same number of values. The values are appended to buf[:0], hence a buffer
+// can be reused from one call to the next; buf can be nil. The second value is
+// the number of values per genome.
+func FlattenF(genomes []Genome, buf []float64) ([]float64, int) {
+	var (
+		values = buf[:0]
+		n      int
+	)
+	for _, genome := range genomes {
+		if v, ok := genomeVector(genome); ok {
+			values = append(values, v...)
+			n = len(v)
+			continue
+		}
+		for _, gene := range genome {
+			values = append(values, gene.(float64))
+		}
+		n = len(genome)
+	}
+	return values, n
+}
+
+// UnflattenF is the inverse of FlattenF, each genome is replaced by a new
+// genome in the same format filled with the next chunk of values. The genomes
+// are replaced rather than modified because genomes can be shared between
+// individuals.
+func UnflattenF(values []float64, genomes []Genome) {
+	for i, genome := range genomes {
+		if v, ok := genomeVector(genome); ok {
+			genomes[i] = Genome{Vector(values[:len(v)]).Copy()}
+			values = values[len(v):]
+			continue
+		}
+		genomes[i] = make(Genome, len(genome))
+		for j := range genome {
+			genomes[i][j] = values[j]
+		}
+		values = values[len(genome):]
+	}
+}
+
+// FlattenF copies the floating point genomes of the individuals into a single
+// contiguous slice, see FlattenF.
+func (indis Individuals) FlattenF(buf []float64) ([]float64, int) {
+	return FlattenF(indis.genomes(), buf)
+}
+
+// UnflattenF replaces the genomes of the individuals with consecutive chunks
+// of values, see UnflattenF. The individuals are marked as not evaluated.
+func (indis Individuals) UnflattenF(values []float64) {
+	var genomes = indis.genomes()
+	UnflattenF(values, genomes)
+	for i := range indis {
+		indis[i].Genome = genomes[i]
+		indis[i].Evaluated = false
+	}
+}
@@ -0,0 +1,204 @@
+package gago
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVectorOperators(t *testing.T) {
+	var (
+		rng  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		p1   = makeIndividual(1, rng)
+		p2   = makeIndividual(1, rng)
+		init = InitUniformVector{N: 50, Lower: -2, Upper: 3}
+	)
+	init.Apply(&p1, rng)
+	init.Apply(&p2, rng)
+	for _, x := range VectorOf(p1) {
+		if x < -2 || x >= 3 {
+			t.Error("InitUniformVector generated a value outside of [Lower, Upper)")
+		}
+	}
+	var (
+		v1 = VectorOf(p1).Copy()
+		v2 = VectorOf(p2).Copy()
+	)
+	for _, cross := range []Crossover{CrossUniformVector{}, CrossArithmeticVector{Alpha: 0.3}} {
+		var o1, o2 = cross.Apply(p1, p2, rng)
+		// Each value of the offsprings lies between the parents' values and
+		// the offsprings keep the sum of the parents' values
+		for i := range v1 {
+			var x, y = VectorOf(o1)[i], VectorOf(o2)[i]
+			if x < math.Min(v1[i], v2[i])-1e-9 || x > math.Max(v1[i], v2[i])+1e-9 ||
+				math.Abs(x+y-v1[i]-v2[i]) > 1e-9 {
+				t.Errorf("%T produced an inconsistent value at position %d", cross, i)
+				break
+			}
+		}
+	}
+	// Mutate every value, the genome shares it's values with the first parent
+	var indi = Individual{Genome: Genome{VectorOf(p1)}}
+	MutNormalVector{Rate: 1, Std: 1}.Apply(&indi, rng)
+	if (DistVector{}).Apply(indi, p1) == 0 {
+		t.Error("MutNormalVector with a rate of 1 didn't change the genome")
+	}
+	if (DistVector{}).Apply(p1, Individual{Genome: Genome{v1}}) != 0 {
+		t.Error("MutNormalVector modified the values of the original genome")
+	}
+	// Mutate a fraction of the values
+	indi = Individual{Genome: Genome{make(Vector, 10000)}}
+	MutNormalVector{Rate: 0.1, Std: 1}.Apply(&indi, rng)
+	var n int
+	for _, x := range VectorOf(indi) {
+		if x != 0 {
+			n++
+		}
+	}
+	if n < 800 || n > 1200 {
+		t.Errorf("Expected around 1000 mutated values, got %d", n)
+	}
+	// The gaps between mutated values don't overflow with a tiny rate
+	for i := 0; i < 100; i++ {
+		MutNormalVector{Rate: 1e-300, Std: 1}.Apply(&indi, rng)
+	}
+}
+
+func TestVectorGA(t *testing.T) {
+	var ga = GA{
+		NbrPopulations: 1,
+		NbrIndividuals: 30,
+		NbrGenes:       1,
+		Ff: VectorFunction{
+			Image: func(X []float64) float64 {
+				var sum float64
+				for _, x := range X {
+					sum += x * x
+				}
+				return sum
+			},
+		},
+		Initializer: InitUniformVector{N: 10, Lower: -5, Upper: 5},
+		Model: ModGenerational{
+			Selector:  SelTournament{NbParticipants: 3},
+			Crossover: CrossUniformVector{},
+			Mutator:   MutNormalVector{Rate: 0.1, Std: 0.5},
+			MutRate:   0.5,
+		},
+	}
+	ga.Initialize()
+	var initial = ga.Best().Fitness
+	for i := 0; i < 20; i++ {
+		ga.Enhance()
+	}
+	if ga.Best().Fitness >= initial {
+		t.Error("The sphere function didn't improve")
+	}
+}
+
+func TestEncodeVector(t *testing.T) {
+	var (
+		v   = Vector{1.5, -2, 0}
+		buf bytes.Buffer
+	)
+	if err := EncodeIndividuals(&buf, Individuals{Individual{Genome: Genome{v}}}); err != nil {
+		t.Fatal(err)
+	}
+	var decoded, err = DecodeIndividuals(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (DistVector{}).Apply(decoded[0], Individual{Genome: Genome{v}}) != 0 {
+		t.Error("The Vector wasn't decoded correctly")
+	}
+}
+
+// Benchmark a generation's worth of crossovers and mutations on 1000 value
+// genomes, packed and unpacked.
+
+func BenchmarkOperatorsVector(b *testing.B) {
+	var (
+		rng = rand.New(rand.NewSource(42))
+		p1  = makeIndividual(1, rng)
+		p2  = makeIndividual(1, rng)
+		ff  = VectorFunction{Image: func(X []float64) float64 { return X[0] }}
+	)
+	InitUniformVector{N: 1000, Lower: -1, Upper: 1}.Apply(&p1, rng)
+	InitUniformVector{N: 1000, Lower: -1, Upper: 1}.Apply(&p2, rng)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var o1, _ = CrossUniformVector{}.Apply(p1, p2, rng)
+		MutNormalVector{Rate: 0.01, Std: 1}.Apply(&o1, rng)
+		o1.Evaluate(ff)
+	}
+}
+
+func BenchmarkOperatorsF(b *testing.B) {
+	var (
+		rng = rand.New(rand.NewSource(42))
+		p1  = makeIndividual(1000, rng)
+		p2  = makeIndividual(1000, rng)
+		ff  = Float64Function{Image: func(X []float64) float64 { return X[0] }}
+	)
+	InitUniformF{Lower: -1, Upper: 1}.Apply(&p1, rng)
+	InitUniformF{Lower: -1, Upper: 1}.Apply(&p2, rng)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var o1, _ = CrossUniformF{}.Apply(p1, p2, rng)
+		MutNormalF{Rate: 0.01, Std: 1}.Apply(&o1, rng)
+		o1.Evaluate(ff)
+	}
+}
+
+func TestFlattenF(t *testing.T) {
+	var (
+		indis = Individuals{
+			Individual{Genome: Genome{1.0, 2.0, 3.0}, Evaluated: true},
+			Individual{Genome: Genome{4.0, 5.0, 6.0}, Evaluated: true},
+		}
+		vectors = Individuals{
+			Individual{Genome: Genome{Vector{1, 2}}},
+			Individual{Genome: Genome{Vector{3, 4}}},
+		}
+		buf = make([]float64, 0, 6)
+	)
+	var values, n = indis.FlattenF(buf)
+	if n != 3 || !reflect.DeepEqual(values, []float64{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("FlattenF returned %v and %d", values, n)
+	}
+	if &values[0] != &buf[:1][0] {
+		t.Error("FlattenF didn't reuse the buffer")
+	}
+	if values, n = vectors.FlattenF(nil); n != 2 || !reflect.DeepEqual(values, []float64{1, 2, 3, 4}) {
+		t.Errorf("FlattenF returned %v and %d for Vector genomes", values, n)
+	}
+	// Unflatten doubled values
+	var original = vectors[0].Genome
+	values, _ = indis.FlattenF(nil)
+	for i := range values {
+		values[i] *= 2
+	}
+	indis.UnflattenF(values)
+	if !reflect.DeepEqual(indis[1].Genome, Genome{8.0, 10.0, 12.0}) || indis[1].Evaluated {
+		t.Error("UnflattenF didn't replace the genomes")
+	}
+	vectors.UnflattenF([]float64{0, 0, 5, 5})
+	if !reflect.DeepEqual(vectors[1].Genome, Genome{Vector{5, 5}}) {
+		t.Error("UnflattenF didn't replace the Vector genomes")
+	}
+	if !reflect.DeepEqual(original, Genome{Vector{1, 2}}) {
+		t.Error("UnflattenF modified the original Vector")
+	}
+	// Empty genomes don't have any value
+	var empty = []Genome{{}, {}}
+	if values, n = FlattenF(empty, nil); len(values) != 0 || n != 0 {
+		t.Errorf("FlattenF returned %v and %d for empty genomes", values, n)
+	}
+	UnflattenF(nil, empty)
+	if len(empty[0]) != 0 {
+		t.Error("UnflattenF should keep empty genomes empty")
+	}
+}
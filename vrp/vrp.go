@@ -0,0 +1,169 @@
+// Package vrp helps solving capacitated vehicle routing problems with gago.
+// Solutions are encoded as giant tours, which are permutations of the
+// customers that don't contain any trip back to the depot. Each giant tour is
+// split into routes that respect the capacity of the vehicles with the optimal
+// splitting procedure of Prins, hence the usual permutation operators can be
+// used and every genome is a feasible solution.
+package vrp
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/MaxHalford/gago"
+)
+
+// A Problem is a capacitated vehicle routing problem. The depot has index 0 in
+// the matrix of distances and the customers have indexes 1 to n. Demands[i] is
+// the quantity to deliver to customer i, Demands[0] is ignored. Each vehicle
+// starts and ends it's route at the depot and can't carry more than Capacity.
+type Problem struct {
+	Distances [][]float64
+	Demands   []float64
+	Capacity  float64
+}
+
+// Euclidean computes the matrix of Euclidean distances between points, the
+// first point being the depot.
+func Euclidean(points [][2]float64) [][]float64 {
+	var distances = make([][]float64, len(points))
+	for i, a := range points {
+		distances[i] = make([]float64, len(points))
+		for j, b := range points {
+			distances[i][j] = math.Hypot(a[0]-b[0], a[1]-b[1])
+		}
+	}
+	return distances
+}
+
+// NbCustomers returns the number of customers.
+func (p Problem) NbCustomers() int {
+	return len(p.Distances) - 1
+}
+
+// Cost returns the total distance travelled by the vehicles following the
+// given routes.
+func (p Problem) Cost(routes [][]int) float64 {
+	var cost float64
+	for _, route := range routes {
+		var last = 0
+		for _, c := range route {
+			cost += p.Distances[last][c]
+			last = c
+		}
+		cost += p.Distances[last][0]
+	}
+	return cost
+}
+
+// Split cuts a giant tour into routes so that the total distance is minimal
+// and the capacity of the vehicles is respected, without changing the order in
+// which the customers are visited. It returns the routes and their cost. If
+// the demand of a customer exceeds the capacity then there is no solution and
+// the cost is +Inf.
+func (p Problem) Split(tour []int) ([][]int, float64) {
+	var (
+		n     = len(tour)
+		costs = make([]float64, n+1) // Cost of serving the first i customers
+		preds = make([]int, n+1)     // Start of the last route serving the first i customers
+	)
+	for i := 1; i <= n; i++ {
+		costs[i] = math.Inf(1)
+	}
+	for i := 0; i < n; i++ {
+		var load, cost float64
+		// Consider the route serving the customers i to j
+		for j := i; j < n; j++ {
+			var c = tour[j]
+			load += p.Demands[c]
+			if load > p.Capacity {
+				break
+			}
+			if j == i {
+				cost = p.Distances[0][c] + p.Distances[c][0]
+			} else {
+				var prev = tour[j-1]
+				cost += p.Distances[prev][c] + p.Distances[c][0] - p.Distances[prev][0]
+			}
+			if costs[i]+cost < costs[j+1] {
+				costs[j+1] = costs[i] + cost
+				preds[j+1] = i
+			}
+		}
+	}
+	if math.IsInf(costs[n], 1) {
+		return nil, costs[n]
+	}
+	var routes [][]int
+	for j := n; j > 0; j = preds[j] {
+		var route = make([]int, j-preds[j])
+		copy(route, tour[preds[j]:j])
+		routes = append([][]int{route}, routes...)
+	}
+	return routes, costs[n]
+}
+
+// Tour returns the giant tour contained in an individual's genome.
+func Tour(indi gago.Individual) []int {
+	var tour = make([]int, len(indi.Genome))
+	for i, gene := range indi.Genome {
+		tour[i] = gene.(int)
+	}
+	return tour
+}
+
+// Routes returns the routes of the solution encoded by an individual.
+func (p Problem) Routes(indi gago.Individual) [][]int {
+	var routes, _ = p.Split(Tour(indi))
+	return routes
+}
+
+// InitTour generates random giant tours, each genome is a permutation of the
+// customers 1 to n where n is the number of genes.
+type InitTour struct{}
+
+// Apply the InitTour initializer.
+func (init InitTour) Apply(indi *gago.Individual, rng *rand.Rand) {
+	for i, j := range rng.Perm(len(indi.Genome)) {
+		indi.Genome[i] = j + 1
+	}
+}
+
+// Fitness returns the fitness function to minimize, which is the cost of the
+// routes obtained by splitting a giant tour.
+func (p Problem) Fitness() gago.IntFunction {
+	return gago.IntFunction{
+		Image: func(tour []int) float64 {
+			var _, cost = p.Split(tour)
+			return cost
+		},
+	}
+}
+
+// GA returns a GA configuration for solving the problem. The giant tours are
+// evolved with PMX crossover and permutation mutations and are improved with
+// Or-opt moves. As with the presets, the configuration is a starting point
+// that can be tuned.
+func (p Problem) GA() gago.GA {
+	return gago.GA{
+		NbrPopulations: 2,
+		NbrIndividuals: 50,
+		NbrGenes:       p.NbCustomers(),
+		Ff:             p.Fitness(),
+		Initializer:    InitTour{},
+		Model: gago.ModMemetic{
+			Model: gago.ModGenerational{
+				Selector: gago.SelTournament{
+					NbParticipants: 3,
+				},
+				Crossover: gago.CrossPMX{},
+				Mutator:   gago.MutPermute{Max: 3},
+				MutRate:   0.3,
+			},
+			LocalSearcher: gago.LocalOrOpt{MaxEvaluations: 100},
+			Rate:          0.1,
+		},
+		Migrator:     gago.MigShuffle{},
+		MigFrequency: 10,
+	}
+}
@@ -0,0 +1,77 @@
+package vrp
+
+import (
+	"math"
+	"testing"
+)
+
+// Four customers on the corners of a square centered on the depot, two
+// vehicles are needed to serve them.
+var problem = Problem{
+	Distances: Euclidean([][2]float64{{0, 0}, {1, 1}, {1, -1}, {-1, -1}, {-1, 1}}),
+	Demands:   []float64{0, 1, 1, 1, 1},
+	Capacity:  2,
+}
+
+func TestEuclidean(t *testing.T) {
+	var d = Euclidean([][2]float64{{0, 0}, {3, 4}})
+	if d[0][1] != 5 || d[1][0] != 5 || d[0][0] != 0 {
+		t.Errorf("Wrong distance matrix %v", d)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	var (
+		routes, cost = problem.Split([]int{1, 2, 3, 4})
+		expected     = 2 * (2*math.Sqrt2 + 2)
+	)
+	if len(routes) != 2 || len(routes[0]) != 2 || routes[0][0] != 1 || routes[1][0] != 3 {
+		t.Errorf("Expected the routes [[1 2] [3 4]], got %v", routes)
+	}
+	if math.Abs(cost-expected) > 1e-10 {
+		t.Errorf("Expected a cost of %f, got %f", expected, cost)
+	}
+	if math.Abs(problem.Cost(routes)-cost) > 1e-10 {
+		t.Error("The cost of the routes doesn't match the cost of the split")
+	}
+	// Every route respects the capacity
+	routes, _ = problem.Split([]int{1, 3, 2, 4})
+	for _, route := range routes {
+		var load float64
+		for _, c := range route {
+			load += problem.Demands[c]
+		}
+		if load > problem.Capacity {
+			t.Errorf("Route %v exceeds the capacity", route)
+		}
+	}
+}
+
+func TestSplitInfeasible(t *testing.T) {
+	var p = problem
+	p.Demands = []float64{0, 3, 1, 1, 1}
+	if routes, cost := p.Split([]int{1, 2, 3, 4}); routes != nil || !math.IsInf(cost, 1) {
+		t.Error("A customer whose demand exceeds the capacity can't be served")
+	}
+}
+
+func TestGA(t *testing.T) {
+	var ga = problem.GA()
+	if err := ga.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ga.Initialize()
+	for i := 0; i < 10; i++ {
+		ga.Enhance()
+	}
+	var (
+		best     = ga.Best()
+		expected = 2 * (2*math.Sqrt2 + 2)
+	)
+	if math.Abs(best.Fitness-expected) > 1e-10 {
+		t.Errorf("Expected an optimal cost of %f, got %f", expected, best.Fitness)
+	}
+	if len(problem.Routes(best)) != 2 {
+		t.Error("The best solution should use two vehicles")
+	}
+}